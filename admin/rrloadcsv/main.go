@@ -34,12 +34,14 @@ var App struct {
 	dbdir          *sql.DB                    // phonebook db
 	dbrr           *sql.DB                    //rentroll db
 	AcctDep        string                     // account depository
+	AcctMapFile    string                     // map GL accounts to the consolidated chart of accounts
 	ARFile         string                     // account rules
 	AsmtFile       string                     // Assessments
 	AssignFile     string                     // assign custom attributes
 	BizFile        string                     // name of csv file with new biz info
 	BldgFile       string                     // Buildings for this Business
 	BUD            string                     // business unit designator
+	BudgetFile     string                     // monthly budgets of GL accounts
 	CoaFile        string                     // chart of accounts
 	CustomFile     string                     // custom attributes
 	DBDir          string                     // phonebook database
@@ -78,9 +80,11 @@ var App struct {
 func readCommandLineArgs() {
 	asmtPtr := flag.String("A", "", "add Assessments via csv file")
 	arPtr := flag.String("ar", "", "add AccountRules via csv file")
+	acctMapPtr := flag.String("am", "", "map GL accounts to the consolidated chart of accounts via csv file")
 	rpptr := flag.String("a", "", "add RatePlans via csv file")
 	dbuPtr := flag.String("B", "ec2-user", "database user name")
 	bizPtr := flag.String("b", "", "add Business via csv file")
+	budgetPtr := flag.String("bg", "", "add GL account budgets via csv file")
 	raPtr := flag.String("C", "", "add rental agreements via csv file")
 	coaPtr := flag.String("c", "", "add chart of accounts via csv file")
 	bldgPtr := flag.String("D", "", "add Buildings to a Business via csv file")
@@ -128,11 +132,13 @@ func readCommandLineArgs() {
 	}
 
 	App.AcctDep = *pAD
+	App.AcctMapFile = *acctMapPtr
 	App.ARFile = *arPtr
 	App.AsmtFile = *asmtPtr
 	App.AssignFile = *asgnPtr
 	App.BizFile = *bizPtr
 	App.BldgFile = *bldgPtr
+	App.BudgetFile = *budgetPtr
 	App.CoaFile = *coaPtr
 	App.CustomFile = *custPtr
 	App.DBDir = *dbnmPtr
@@ -344,6 +350,8 @@ func main() {
 		{Fname: App.RaFile, Handler: rcsv.LoadRentalAgreementCSV},
 		{Fname: App.PetFile, Handler: rcsv.LoadPetsCSV},
 		{Fname: App.CoaFile, Handler: rcsv.LoadChartOfAccountsCSV},
+		{Fname: App.BudgetFile, Handler: rcsv.LoadBudgetCSV},
+		{Fname: App.AcctMapFile, Handler: rcsv.LoadAcctMapCSV},
 		{Fname: App.ARFile, Handler: rcsv.LoadARCSV},
 		{Fname: App.RPFile, Handler: rcsv.LoadRatePlansCSV},
 		{Fname: App.RPRefFile, Handler: rcsv.LoadRatePlanRefsCSV},
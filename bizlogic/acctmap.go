@@ -0,0 +1,65 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"strings"
+)
+
+// SaveAcctMap maps GL account glno of business bid to the consolidated
+// account cglno, named cname, of the portfolio reports.  An existing map of
+// the account is replaced.
+//
+// INPUTS
+//
+//	ctx   = db context
+//	bid   = business id
+//	glno  = GL number of the account of the business
+//	cglno = consolidated GL number
+//	cname = consolidated account name
+//
+// RETURNS
+//
+//	the AcctMap written
+//	a list of errors, nil if the map was saved
+//
+// -----------------------------------------------------------------------------
+func SaveAcctMap(ctx context.Context, bid int64, glno, cglno, cname string) (rlib.AcctMap, []BizError) {
+	var a rlib.AcctMap
+	cglno = strings.TrimSpace(cglno)
+	cname = strings.TrimSpace(cname)
+	if len(cglno) == 0 {
+		err := fmt.Errorf("a consolidated GL number is required to map GL account %s", glno)
+		return a, bizErrSys(&err)
+	}
+	gl, err := rlib.GetLedgerByGLNo(ctx, bid, strings.TrimSpace(glno))
+	if err != nil {
+		return a, bizErrSys(&err)
+	}
+	if gl.LID == 0 {
+		err = fmt.Errorf("GL account %s not found", glno)
+		return a, bizErrSys(&err)
+	}
+	if len(cname) == 0 {
+		cname = gl.Name
+	}
+
+	a, err = rlib.GetAcctMapByLID(ctx, bid, gl.LID)
+	if err != nil {
+		return a, bizErrSys(&err)
+	}
+	a.BID = bid
+	a.LID = gl.LID
+	a.GLNumber = cglno
+	a.Name = cname
+	if a.AMID > 0 {
+		err = rlib.UpdateAcctMap(ctx, &a)
+	} else {
+		_, err = rlib.InsertAcctMap(ctx, &a)
+	}
+	if err != nil {
+		return a, bizErrSys(&err)
+	}
+	return a, nil
+}
@@ -0,0 +1,584 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"rentroll/rlib"
+	"time"
+)
+
+// ValidateACHEnrollment checks the supplied ACHEnrollment before it is
+// saved.  The routing number must have a valid check digit and the account
+// number must fit in a NACHA entry.
+//-----------------------------------------------------------------------------
+func ValidateACHEnrollment(ctx context.Context, a *rlib.ACHEnrollment) []BizError {
+	var e []BizError
+	fields := []string{}
+	if a.TCID == 0 {
+		fields = append(fields, "Payor")
+	}
+	if !rlib.ValidRoutingNumber(a.RoutingNumber) {
+		fields = append(fields, "RoutingNumber")
+	}
+	if len(a.AccountNumber) == 0 || len(a.AccountNumber) > 17 {
+		fields = append(fields, "AccountNumber")
+	}
+	if len(a.AccountName) == 0 {
+		fields = append(fields, "AccountName")
+	}
+	if a.MaxAmount < 0 {
+		fields = append(fields, "MaxAmount")
+	}
+	if !a.DtStop.After(a.DtStart) {
+		fields = append(fields, "DtStop")
+	}
+	if len(fields) > 0 {
+		msg := BizErrors[InvalidField].Message
+		for i := 0; i < len(fields); i++ {
+			msg += fmt.Sprintf("\n%s", fields[i])
+		}
+		e = append(e, BizError{Errno: InvalidField, Message: msg})
+	}
+	if len(e) > 0 {
+		return e
+	}
+	return nil
+}
+
+// CreateACHBatch creates a batch of ACH debits for business bid with the
+// effective entry date dt.  Every payor with an active ACHEnrollment is
+// debited for the unpaid portion of the assessments that start on or
+// before dt, less any funds they already have on unallocated receipts.  The
+// debit is capped at the enrollment's MaxAmount.
+//
+// For each debit, a Receipt is created using the ACH PaymentType (which is
+// created if it does not exist) and the AR named in the business properties.
+// The receipt's DocNo is the NACHA trace number and it is flagged as
+// RCPTACHPENDING until the batch is settled.  The receipts are allocated
+// with AutoAllocatePayorReceipts so the assessments they cover are not
+// debited again by a later batch.  If the bank returns a debit, the
+// receipt is reversed with ReverseReceipt.
+//
+// INPUTS
+//    ctx = db context, should contain a transaction
+//    bid = business id
+//    dt  = effective entry date of the debits
+//
+// RETURNS
+//    the new batch, ABID is 0 if there was nothing to debit
+//    any error encountered
+//-----------------------------------------------------------------------------
+func CreateACHBatch(ctx context.Context, bid int64, dt *time.Time) (rlib.ACHBatch, error) {
+	var b rlib.ACHBatch
+
+	p, err := rlib.GetACHPolicy(ctx, bid, "general")
+	if err != nil {
+		return b, err
+	}
+	if !p.Enabled {
+		return b, fmt.Errorf("ACH autopay is not enabled for business %d", bid)
+	}
+	ar, err := rlib.GetARByName(ctx, bid, p.ARName)
+	if err != nil {
+		return b, err
+	}
+	if ar.ARID == 0 {
+		return b, fmt.Errorf("ACH receipt account rule %q not found in business %d", p.ARName, bid)
+	}
+	pmt, err := achPaymentType(ctx, bid, p.PMTName)
+	if err != nil {
+		return b, err
+	}
+
+	m, err := rlib.GetActiveACHEnrollments(ctx, bid, dt)
+	if err != nil {
+		return b, err
+	}
+
+	done := map[int64]bool{} // a payor is debited once, using their first enrollment
+	for i := 0; i < len(m); i++ {
+		if done[m[i].TCID] {
+			continue
+		}
+		done[m[i].TCID] = true
+
+		amt, err := achAmountDue(ctx, bid, m[i].TCID, dt)
+		if err != nil {
+			return b, err
+		}
+		if m[i].MaxAmount > 0 && amt > m[i].MaxAmount {
+			amt = m[i].MaxAmount
+		}
+		if amt < 0.01 {
+			continue
+		}
+
+		//------------------------------------------------------------
+		// Create the batch when we find the first debit
+		//------------------------------------------------------------
+		if b.ABID == 0 {
+			b.BID = bid
+			b.Dt = *dt
+			if _, err = rlib.InsertACHBatch(ctx, &b); err != nil {
+				return b, err
+			}
+		}
+
+		//------------------------------------------------------------
+		// The entry id provides the trace sequence number, so insert
+		// the entry before the receipt that references it.
+		//------------------------------------------------------------
+		e := rlib.ACHEntry{
+			ABID:   b.ABID,
+			BID:    bid,
+			AEID:   m[i].AEID,
+			TCID:   m[i].TCID,
+			Amount: amt,
+		}
+		if _, err = rlib.InsertACHEntry(ctx, &e); err != nil {
+			return b, err
+		}
+		e.TraceNumber = rlib.NACHATraceNumber(p.ODFI, e.AENID)
+
+		r := rlib.Receipt{
+			BID:     bid,
+			TCID:    m[i].TCID,
+			PMTID:   pmt.PMTID,
+			Dt:      *dt,
+			DocNo:   e.TraceNumber,
+			Amount:  amt,
+			ARID:    ar.ARID,
+			FLAGS:   rlib.RCPTACHPENDING,
+			Comment: fmt.Sprintf("ACH debit, batch %d", b.ABID),
+		}
+		if err = InsertReceipt(ctx, &r); err != nil {
+			return b, err
+		}
+		e.RCPTID = r.RCPTID
+		if err = rlib.UpdateACHEntry(ctx, &e); err != nil {
+			return b, err
+		}
+		if err = AutoAllocatePayorReceipts(ctx, m[i].TCID, dt); err != nil {
+			return b, err
+		}
+
+		b.EntryCount++
+		b.TotalAmount += amt
+	}
+
+	if b.ABID > 0 {
+		b.TotalAmount = rlib.RoundToCent(b.TotalAmount)
+		err = rlib.UpdateACHBatch(ctx, &b)
+	}
+	return b, err
+}
+
+// achAmountDue returns the amount payor tcid owes as of dt: the unpaid
+// portion of every assessment that starts on or before dt, less the funds
+// remaining on the payor's unallocated receipts.
+//
+// INPUTS
+//    ctx  = db context
+//    bid  = business id
+//    tcid = the payor
+//    dt   = the date
+//
+// RETURNS
+//    the amount due, never less than 0
+//    any error encountered
+//-----------------------------------------------------------------------------
+func achAmountDue(ctx context.Context, bid, tcid int64, dt *time.Time) (float64, error) {
+	m, err := GetAllUnpaidAssessmentsForPayor(ctx, bid, tcid, dt)
+	if err != nil {
+		return 0, err
+	}
+	due := float64(0)
+	for i := 0; i < len(m); i++ {
+		if m[i].FLAGS&3 == 3 || m[i].FLAGS&rlib.ASMREVERSED != 0 { // offsets and reversals are not paid
+			continue
+		}
+		if m[i].Start.After(*dt) {
+			continue
+		}
+		due += AssessmentUnpaidPortion(ctx, &m[i])
+	}
+
+	n, err := rlib.GetUnallocatedReceiptsByPayor(ctx, bid, tcid)
+	if err != nil {
+		return 0, err
+	}
+	for i := 0; i < len(n); i++ {
+		due -= RemainingReceiptFunds(ctx, &n[i])
+	}
+	due = rlib.RoundToCent(due)
+	if due < 0 {
+		due = 0
+	}
+	return due, nil
+}
+
+// achPaymentType returns the PaymentType used for ACH receipts in business
+// bid.  If it does not exist it is created.
+//-----------------------------------------------------------------------------
+func achPaymentType(ctx context.Context, bid int64, name string) (rlib.PaymentType, error) {
+	var pmt rlib.PaymentType
+	if err := rlib.GetPaymentTypeByName(ctx, bid, name, &pmt); err != nil {
+		return pmt, err
+	}
+	if pmt.PMTID > 0 {
+		return pmt, nil
+	}
+	pmt = rlib.PaymentType{
+		BID:         bid,
+		Name:        name,
+		Description: "ACH debit from the payor's bank account",
+	}
+	_, err := rlib.InsertPaymentType(ctx, &pmt)
+	return pmt, err
+}
+
+// WriteACHBatchFile writes the NACHA file for batch abid to w.  The bank
+// account numbers are not kept with the batch, they are decrypted from
+// each entry's ACHEnrollment as the file is written.
+//
+// INPUTS
+//    ctx  = db context
+//    abid = the batch
+//    w    = where to write the file
+//
+// RETURNS
+//    any error encountered
+//-----------------------------------------------------------------------------
+func WriteACHBatchFile(ctx context.Context, abid int64, w io.Writer) error {
+	b, err := rlib.GetACHBatch(ctx, abid)
+	if err != nil {
+		return err
+	}
+	if b.ABID == 0 {
+		return fmt.Errorf("ACH batch %d not found", abid)
+	}
+	p, err := rlib.GetACHPolicy(ctx, b.BID, "general")
+	if err != nil {
+		return err
+	}
+	b.E, err = rlib.GetACHEntries(ctx, abid)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	f := p.NACHAFile(&b, &now)
+	for i := 0; i < len(b.E); i++ {
+		a, err := rlib.GetACHEnrollment(ctx, b.E[i].AEID)
+		if err != nil {
+			return err
+		}
+		if a.AEID == 0 {
+			return fmt.Errorf("ACH enrollment %d for entry %d not found", b.E[i].AEID, b.E[i].AENID)
+		}
+		f.Entries = append(f.Entries, rlib.NACHAEntry{
+			RoutingNumber:  a.RoutingNumber,
+			AccountNumber:  a.AccountNumber,
+			Savings:        a.FLAGS&rlib.FlACHSavings != 0,
+			Amount:         b.E[i].Amount,
+			IndividualID:   rlib.IDtoShortString("TC", b.E[i].TCID),
+			IndividualName: a.AccountName,
+			TraceNumber:    b.E[i].TraceNumber,
+		})
+	}
+	return f.Write(w)
+}
+
+// SettleACHBatch marks batch abid as settled.  The receipts for the entries
+// that were not returned are no longer pending.
+//
+// INPUTS
+//    ctx  = db context, should contain a transaction
+//    abid = the batch
+//
+// RETURNS
+//    any error encountered
+//-----------------------------------------------------------------------------
+func SettleACHBatch(ctx context.Context, abid int64) error {
+	b, err := rlib.GetACHBatch(ctx, abid)
+	if err != nil {
+		return err
+	}
+	if b.ABID == 0 {
+		return fmt.Errorf("ACH batch %d not found", abid)
+	}
+	if b.FLAGS&rlib.FlACHSettled != 0 {
+		return nil
+	}
+	m, err := rlib.GetACHEntries(ctx, abid)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(m); i++ {
+		if m[i].FLAGS&rlib.FlACHEntryRetnd != 0 || m[i].RCPTID == 0 {
+			continue
+		}
+		r, err := rlib.GetReceipt(ctx, m[i].RCPTID)
+		if err != nil {
+			return err
+		}
+		if r.RCPTID == 0 || r.FLAGS&rlib.RCPTACHPENDING == 0 {
+			continue
+		}
+		r.FLAGS &^= rlib.RCPTACHPENDING
+		if err = rlib.UpdateReceipt(ctx, &r); err != nil {
+			return err
+		}
+	}
+	b.FLAGS |= rlib.FlACHSettled
+	return rlib.UpdateACHBatch(ctx, &b)
+}
+
+// ACHReturnResult summarizes the results of an ACH return file import
+type ACHReturnResult struct {
+	Reversed   int      // receipts reversed
+	Duplicates int      // returns that were processed by an earlier import
+	NSFFees    int      // NSF fee assessments created
+	NotFound   []string // trace numbers that do not match any ACH entry
+}
+
+// ImportACHReturns processes a NACHA return file for business bid.  Each
+// return is matched to the ACHEntry, and thus the Receipt, with the same
+// trace number and then handled by ReturnACHEntry. Returns that were
+// already processed are skipped, so a file can be imported more than once.
+//
+// INPUTS
+//    ctx = db context, should contain a transaction
+//    bid = business id
+//    r   = the return file
+//
+// RETURNS
+//    a summary of the import
+//    any error encountered
+//-----------------------------------------------------------------------------
+func ImportACHReturns(ctx context.Context, bid int64, r io.Reader) (ACHReturnResult, error) {
+	var res ACHReturnResult
+
+	m, err := rlib.ParseNACHAReturns(r)
+	if err != nil {
+		return res, err
+	}
+	p, err := rlib.GetACHPolicy(ctx, bid, "general")
+	if err != nil {
+		return res, err
+	}
+
+	for i := 0; i < len(m); i++ {
+		e, err := rlib.GetACHEntryByTraceNumber(ctx, bid, m[i].TraceNumber)
+		if err != nil {
+			return res, err
+		}
+		if e.AENID == 0 {
+			res.NotFound = append(res.NotFound, m[i].TraceNumber)
+			continue
+		}
+		if e.FLAGS&rlib.FlACHEntryRetnd != 0 {
+			res.Duplicates++
+			continue
+		}
+		dt := m[i].Dt
+		if dt.IsZero() {
+			dt = time.Now()
+		}
+		fee, err := ReturnACHEntry(ctx, &e, &p, m[i].ReturnCode, &dt)
+		if err != nil {
+			return res, err
+		}
+		res.Reversed++
+		if fee {
+			res.NSFFees++
+		}
+	}
+	return res, nil
+}
+
+// ReturnACHEntry handles an ACH debit that the bank returned. The entry's
+// Receipt is reversed as of dt with ReverseReceipt. The reason for the
+// return is added to the receipt's comment, which is what the payor
+// statement shows next to the reversal, and a note is added to the payor's
+// note list.  If the ACH settings call for it, an NSF fee is assessed
+// against the rental agreement that the receipt paid.
+//
+// INPUTS
+//    ctx  = db context, should contain a transaction
+//    e    = the returned entry
+//    p    = the business's ACH settings
+//    code = the return reason code (R-code)
+//    dt   = date of the return
+//
+// RETURNS
+//    true if an NSF fee was assessed
+//    any error encountered
+//-----------------------------------------------------------------------------
+func ReturnACHEntry(ctx context.Context, e *rlib.ACHEntry, p *rlib.BizPropsACH, code string, dt *time.Time) (bool, error) {
+	r, err := rlib.GetReceipt(ctx, e.RCPTID)
+	if err != nil {
+		return false, err
+	}
+	if r.RCPTID == 0 {
+		return false, fmt.Errorf("receipt %d for ACH trace number %s not found", e.RCPTID, e.TraceNumber)
+	}
+
+	//------------------------------------------------------------
+	// Find the rental agreement and rentable the receipt paid
+	// before the allocations are reversed
+	//------------------------------------------------------------
+	if err = rlib.GetReceiptAllocations(ctx, r.RCPTID, &r); err != nil {
+		return false, err
+	}
+	var raid, rid int64
+	for i := 0; i < len(r.RA); i++ {
+		if r.RA[i].ASMID == 0 {
+			continue
+		}
+		a, err := rlib.GetAssessment(ctx, r.RA[i].ASMID)
+		if err != nil {
+			return false, err
+		}
+		if a.RAID > 0 {
+			raid, rid = a.RAID, a.RID
+			break
+		}
+	}
+	if raid == 0 {
+		if raid, rid, err = achPayorRentalAgreement(ctx, r.BID, r.TCID, dt); err != nil {
+			return false, err
+		}
+	}
+
+	reason := fmt.Sprintf("ACH return %s: %s", code, rlib.NACHAReturnReason(code))
+	if len(r.Comment) > 0 {
+		r.Comment += ", "
+	}
+	r.Comment += reason
+	r.FLAGS &^= rlib.RCPTACHPENDING
+	if err = ReverseReceipt(ctx, &r, dt); err != nil {
+		return false, err
+	}
+
+	e.FLAGS |= rlib.FlACHEntryRetnd
+	e.ReturnCode = code
+	if err = rlib.UpdateACHEntry(ctx, e); err != nil {
+		return false, err
+	}
+
+	note := fmt.Sprintf("%s. ACH debit of %s on %s (receipt %s, trace %s) was reversed.",
+		reason, rlib.RRCommaf(e.Amount), r.Dt.Format(rlib.RRDATEFMT3), r.IDtoString(), e.TraceNumber)
+	if err = addPayorNote(ctx, r.BID, r.TCID, raid, rid, note); err != nil {
+		return false, err
+	}
+
+	//------------------------------------------------------------
+	// NSF fee
+	//------------------------------------------------------------
+	if !p.NSFFeeApplies(code) || raid == 0 {
+		return false, nil
+	}
+	ar, err := rlib.GetARByName(ctx, r.BID, p.NSFFeeARName)
+	if err != nil {
+		return false, err
+	}
+	if ar.ARID == 0 {
+		return false, fmt.Errorf("NSF fee account rule %q not found in business %d", p.NSFFeeARName, r.BID)
+	}
+	lc, err := openClosePeriod(ctx, r.BID)
+	if err != nil {
+		return false, err
+	}
+
+	a := rlib.Assessment{
+		BID:            r.BID,
+		RID:            rid,
+		RAID:           raid,
+		AssocElemType:  rlib.ELEMRECEIPT,
+		AssocElemID:    r.RCPTID,
+		Amount:         p.NSFFeeAmount,
+		Start:          *dt,
+		Stop:           *dt,
+		RentCycle:      rlib.RECURNONE,
+		ProrationCycle: rlib.RECURNONE,
+		ARID:           ar.ARID,
+		Comment:        fmt.Sprintf("NSF fee, %s, receipt %s", reason, r.IDtoString()),
+	}
+	if errlist := InsertAssessment(ctx, &a, 0, &lc); len(errlist) > 0 {
+		return false, BizErrorListToError(errlist)
+	}
+	return true, nil
+}
+
+// achPayorRentalAgreement returns a rental agreement for which payor tcid
+// is responsible on dt, and a rentable of that agreement. It is used when
+// a returned receipt had not been allocated to any assessment.
+//-----------------------------------------------------------------------------
+func achPayorRentalAgreement(ctx context.Context, bid, tcid int64, dt *time.Time) (int64, int64, error) {
+	d2 := dt.AddDate(0, 0, 1)
+	m, err := rlib.GetRentalAgreementsByPayorRange(ctx, bid, tcid, dt, &d2)
+	if err != nil || len(m) == 0 {
+		return 0, 0, err
+	}
+	n, err := rlib.GetRentalAgreementRentables(ctx, m[0].RAID, dt, &d2)
+	if err != nil || len(n) == 0 {
+		return m[0].RAID, 0, err
+	}
+	return m[0].RAID, n[0].RID, nil
+}
+
+// addPayorNote adds a note to the note list of payor tcid, creating the
+// note list if the payor does not have one yet.  The note is tagged with
+// the payor, rental agreement and rentable.
+//-----------------------------------------------------------------------------
+func addPayorNote(ctx context.Context, bid, tcid, raid, rid int64, comment string) error {
+	var t rlib.Transactant
+	if err := rlib.GetTransactant(ctx, tcid, &t); err != nil {
+		return err
+	}
+	if t.TCID == 0 {
+		return fmt.Errorf("payor %d not found", tcid)
+	}
+	n := rlib.Note{
+		BID:     bid,
+		NLID:    t.NLID,
+		TCID:    tcid,
+		RAID:    raid,
+		RID:     rid,
+		Comment: comment,
+	}
+	if err := addNote(ctx, &n); err != nil {
+		return err
+	}
+	if t.NLID == n.NLID {
+		return nil
+	}
+	t.NLID = n.NLID
+	return rlib.UpdateTransactant(ctx, &t)
+}
+
+// addNote inserts note n.  If n.NLID is 0 a new note list is created for
+// it, the caller is responsible for saving n.NLID with the note's owner.
+// The note gets the first note type of the business.
+//-----------------------------------------------------------------------------
+func addNote(ctx context.Context, n *rlib.Note) error {
+	if n.NLID == 0 {
+		nl := rlib.NoteList{BID: n.BID}
+		nlid, err := rlib.InsertNoteList(ctx, &nl)
+		if err != nil {
+			return err
+		}
+		n.NLID = nlid
+	}
+	nt, err := rlib.GetAllNoteTypes(ctx, n.BID)
+	if err != nil {
+		return err
+	}
+	if len(nt) > 0 {
+		n.NTID = nt[0].NTID
+	}
+	_, err = rlib.InsertNote(ctx, n)
+	return err
+}
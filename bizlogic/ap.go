@@ -0,0 +1,569 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A vendor bill moves through its statuses as follows:
+//
+//    Entered -> Approved -> Paid
+//
+// An Entered bill can be changed freely, nothing has been posted yet.
+// Approving it posts a journal entry dated on the bill date that debits the
+// GL account of each line and credits the accounts payable account named in
+// the business properties (BizProps.AP).  A payment run pays approved bills
+// from a depository: for each bill it debits accounts payable and credits
+// the depository's GL account.  Entered and Approved bills can be voided,
+// voiding an Approved bill reverses its journal entry.  Paid and Void bills
+// cannot be changed.
+
+// jnlLine is one debit / credit pair of a journal entry
+type jnlLine struct {
+	dlid, clid int64   // GL accounts debited and credited
+	rid        int64   // rentable, 0 if none
+	amt        float64 // amount
+}
+
+// getPayableAccount returns the accounts payable GL account of business bid
+func getPayableAccount(ctx context.Context, bid int64) (rlib.GLAccount, error) {
+	p, err := rlib.GetAPPolicy(ctx, bid, "general")
+	if err != nil {
+		return rlib.GLAccount{}, err
+	}
+	if len(p.PayableGLNumber) == 0 {
+		return rlib.GLAccount{}, fmt.Errorf("no accounts payable GL account is defined for business %d", bid)
+	}
+	gl, err := rlib.GetLedgerByGLNo(ctx, bid, p.PayableGLNumber)
+	if err != nil {
+		return gl, err
+	}
+	if gl.LID == 0 {
+		return gl, fmt.Errorf("accounts payable GL account %s not found", p.PayableGLNumber)
+	}
+	return gl, nil
+}
+
+// postJournal writes a journal entry of type jtype for the record with id
+// id, with one allocation for each entry of m, and the ledger entries for
+// them.
+//
+// INPUTS
+//    ctx     = db context
+//    bid     = business id
+//    jtype   = JNLTYPEVBIL, JNLTYPEVPMT or JNLTYPECLOS
+//    id      = the vendor bill or fiscal year close
+//    dt      = date of the journal entry
+//    comment = journal comment
+//    m       = the debits and credits
+//
+// RETURNS
+//    the JID of the journal entry
+//    any error encountered
+//-----------------------------------------------------------------------------
+func postJournal(ctx context.Context, bid int64, jtype int64, id int64, dt *time.Time, comment string, m []jnlLine) (int64, error) {
+	var xbiz rlib.XBusiness
+	if err := rlib.InitBizInternals(bid, &xbiz); err != nil {
+		return 0, err
+	}
+	j := rlib.Journal{
+		BID:     bid,
+		Dt:      *dt,
+		Type:    jtype,
+		ID:      id,
+		Comment: comment,
+	}
+	for i := 0; i < len(m); i++ {
+		j.Amount += m[i].amt
+	}
+	j.Amount = rlib.RoundToCent(j.Amount)
+	if _, err := rlib.InsertJournal(ctx, &j); err != nil {
+		return 0, err
+	}
+	for i := 0; i < len(m); i++ {
+		ja := rlib.JournalAllocation{
+			JID:    j.JID,
+			BID:    bid,
+			RID:    m[i].rid,
+			Amount: m[i].amt,
+			AcctRule: fmt.Sprintf("d %s %.2f, c %s %.2f",
+				rlib.RRdb.BizTypes[bid].GLAccounts[m[i].dlid].GLNumber, m[i].amt,
+				rlib.RRdb.BizTypes[bid].GLAccounts[m[i].clid].GLNumber, m[i].amt),
+		}
+		if _, err := rlib.InsertJournalAllocationEntry(ctx, &ja); err != nil {
+			return 0, err
+		}
+		j.JA = append(j.JA, ja)
+	}
+	d1 := time.Date(dt.Year(), dt.Month(), 1, 0, 0, 0, 0, time.UTC)
+	d2 := d1.AddDate(0, 1, 0)
+	rlib.InitLedgerCache()
+	if _, err := rlib.GenerateLedgerEntriesFromJournal(ctx, &xbiz, &j, &d1, &d2); err != nil {
+		return 0, err
+	}
+	return j.JID, nil
+}
+
+// SaveVendor inserts or updates the vendor v.  Vendor names are unique
+// within a business.
+//
+// INPUTS
+//    ctx = db context
+//    v   = the vendor, VID 0 to add a new one
+//
+// RETURNS
+//    a list of errors, nil if the vendor was saved
+//-----------------------------------------------------------------------------
+func SaveVendor(ctx context.Context, v *rlib.Vendor) []BizError {
+	var errlist []BizError
+	fields := []string{}
+
+	v.Name = strings.TrimSpace(v.Name)
+	v.TaxID = strings.TrimSpace(v.TaxID)
+	if len(v.Name) == 0 {
+		fields = append(fields, "Name")
+	}
+	if v.Terms < 0 {
+		fields = append(fields, "Terms")
+	}
+	if v.Is1099() && len(v.TaxID) == 0 {
+		fields = append(fields, "TaxID (required for a 1099 vendor)")
+	}
+	if len(fields) > 0 {
+		msg := BizErrors[InvalidField].Message
+		for i := 0; i < len(fields); i++ {
+			msg += fmt.Sprintf("\n%s", fields[i])
+		}
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: msg})
+		return errlist
+	}
+
+	m, err := rlib.GetVendorsByBusiness(ctx, v.BID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	for i := 0; i < len(m); i++ {
+		if m[i].VID != v.VID && strings.EqualFold(m[i].Name, v.Name) {
+			return AddBizErrToList(errlist, DuplicateName)
+		}
+	}
+
+	if v.VID == 0 {
+		_, err = rlib.InsertVendor(ctx, v)
+	} else {
+		var old rlib.Vendor
+		if old, err = rlib.GetVendor(ctx, v.VID); err != nil {
+			return bizErrSys(&err)
+		}
+		if old.VID == 0 || old.BID != v.BID {
+			err = fmt.Errorf("vendor %d not found", v.VID)
+			return bizErrSys(&err)
+		}
+		err = rlib.UpdateVendor(ctx, v)
+	}
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// getVendorBill reads vendor bill vbid and checks that it belongs to
+// business bid
+func getVendorBill(ctx context.Context, bid, vbid int64) (rlib.VendorBill, error) {
+	vb, err := rlib.GetVendorBill(ctx, vbid)
+	if err != nil {
+		return vb, err
+	}
+	if vb.VBID == 0 || vb.BID != bid {
+		return vb, fmt.Errorf("vendor bill %d not found", vbid)
+	}
+	return vb, nil
+}
+
+// SaveVendorBill inserts or updates the vendor bill vb and replaces its
+// lines with m.  Only Entered bills can be saved, use ApproveVendorBill,
+// VoidVendorBill and PayVendorBills to move them along.  The amount of the
+// bill is the total of its lines and the due date defaults to the bill date
+// plus the vendor's terms.
+//
+// INPUTS
+//    ctx = db context
+//    vb  = the bill, VBID 0 to add a new one
+//    m   = the lines of the bill
+//
+// RETURNS
+//    a list of errors, nil if the bill was saved
+//-----------------------------------------------------------------------------
+func SaveVendorBill(ctx context.Context, vb *rlib.VendorBill, m []rlib.VendorBillLine) []BizError {
+	var (
+		err     error
+		errlist []BizError
+	)
+	fields := []string{}
+
+	if vb.VBID > 0 {
+		old, err := getVendorBill(ctx, vb.BID, vb.VBID)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		if old.Status != rlib.VBStatusEntered {
+			err = fmt.Errorf("vendor bill %s is %s, it cannot be changed", old.IDtoShortString(), strings.ToLower(old.StatusName()))
+			return bizErrSys(&err)
+		}
+	}
+
+	v, err := rlib.GetVendor(ctx, vb.VID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if v.VID == 0 || v.BID != vb.BID {
+		fields = append(fields, "VID")
+	} else if !v.IsActive() && vb.VBID == 0 {
+		fields = append(fields, "VID (the vendor is inactive)")
+	}
+	vb.InvoiceNo = strings.TrimSpace(vb.InvoiceNo)
+	if vb.Dt.Year() < 1971 {
+		fields = append(fields, "Dt")
+	}
+	if vb.DueDt.Year() < 1971 {
+		vb.DueDt = vb.Dt.AddDate(0, 0, int(v.Terms))
+	} else if vb.DueDt.Before(vb.Dt) {
+		fields = append(fields, "DueDt (before the bill date)")
+	}
+	if len(m) == 0 {
+		fields = append(fields, "Lines (a bill needs at least one line)")
+	}
+	vb.Amount = 0
+	for i := 0; i < len(m); i++ {
+		if m[i].Amount <= 0 {
+			fields = append(fields, fmt.Sprintf("Line %d: Amount", i+1))
+		}
+		gl, err := rlib.GetLedger(ctx, m[i].LID)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		if gl.LID == 0 || gl.BID != vb.BID || !gl.AllowPost {
+			fields = append(fields, fmt.Sprintf("Line %d: LID", i+1))
+		}
+		if m[i].RID > 0 {
+			rnt, err := rlib.GetRentable(ctx, m[i].RID)
+			if err != nil {
+				return bizErrSys(&err)
+			}
+			if rnt.RID == 0 || rnt.BID != vb.BID {
+				fields = append(fields, fmt.Sprintf("Line %d: RID", i+1))
+			}
+		}
+		vb.Amount += m[i].Amount
+	}
+	vb.Amount = rlib.RoundToCent(vb.Amount)
+	if len(fields) > 0 {
+		msg := BizErrors[InvalidField].Message
+		for i := 0; i < len(fields); i++ {
+			msg += fmt.Sprintf("\n%s", fields[i])
+		}
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: msg})
+		return errlist
+	}
+
+	vb.Status = rlib.VBStatusEntered
+	if vb.VBID == 0 {
+		_, err = rlib.InsertVendorBill(ctx, vb)
+	} else {
+		err = rlib.UpdateVendorBill(ctx, vb)
+	}
+	if err != nil {
+		return bizErrSys(&err)
+	}
+
+	//-------------------------------------------
+	// replace the lines
+	//-------------------------------------------
+	old, err := rlib.GetVendorBillLines(ctx, vb.VBID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	for i := 0; i < len(old); i++ {
+		if err = rlib.DeleteVendorBillLine(ctx, old[i].VBLID); err != nil {
+			return bizErrSys(&err)
+		}
+	}
+	for i := 0; i < len(m); i++ {
+		m[i].VBLID = 0
+		m[i].VBID = vb.VBID
+		m[i].BID = vb.BID
+		m[i].Description = strings.TrimSpace(m[i].Description)
+		if _, err = rlib.InsertVendorBillLine(ctx, &m[i]); err != nil {
+			return bizErrSys(&err)
+		}
+	}
+	return nil
+}
+
+// DeleteVendorBill removes an Entered vendor bill and its lines
+//
+// INPUTS
+//    ctx  = db context
+//    bid  = business id
+//    vbid = the bill
+//
+// RETURNS
+//    a list of errors, nil if the bill was deleted
+//-----------------------------------------------------------------------------
+func DeleteVendorBill(ctx context.Context, bid, vbid int64) []BizError {
+	vb, err := getVendorBill(ctx, bid, vbid)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if vb.Status != rlib.VBStatusEntered {
+		err = fmt.Errorf("vendor bill %s is %s, void it instead", vb.IDtoShortString(), strings.ToLower(vb.StatusName()))
+		return bizErrSys(&err)
+	}
+	m, err := rlib.GetVendorBillLines(ctx, vb.VBID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	for i := 0; i < len(m); i++ {
+		if err = rlib.DeleteVendorBillLine(ctx, m[i].VBLID); err != nil {
+			return bizErrSys(&err)
+		}
+	}
+	if err = rlib.DeleteVendorBill(ctx, vb.VBID); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// ApproveVendorBill approves an Entered vendor bill and posts it to accounts
+// payable on the bill date, which must be in an open period.
+//
+// INPUTS
+//    ctx  = db context
+//    bid  = business id
+//    vbid = the bill
+//
+// RETURNS
+//    a list of errors, nil if the bill was approved
+//-----------------------------------------------------------------------------
+func ApproveVendorBill(ctx context.Context, bid, vbid int64) []BizError {
+	vb, err := getVendorBill(ctx, bid, vbid)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if vb.Status != rlib.VBStatusEntered {
+		err = fmt.Errorf("vendor bill %s is %s, only entered bills can be approved", vb.IDtoShortString(), strings.ToLower(vb.StatusName()))
+		return bizErrSys(&err)
+	}
+	lc, err := openClosePeriod(ctx, bid)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if !vb.Dt.After(lc.Dt) {
+		err = fmt.Errorf("vendor bill %s cannot be approved because its date (%s) is in a closed period", vb.IDtoShortString(), vb.Dt.Format(rlib.RRDATEFMT3))
+		return bizErrSys(&err)
+	}
+	ap, err := getPayableAccount(ctx, bid)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	lines, err := rlib.GetVendorBillLines(ctx, vb.VBID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	v, err := rlib.GetVendor(ctx, vb.VID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+
+	var m []jnlLine
+	for i := 0; i < len(lines); i++ {
+		m = append(m, jnlLine{dlid: lines[i].LID, clid: ap.LID, rid: lines[i].RID, amt: lines[i].Amount})
+	}
+	comment := fmt.Sprintf("%s %s", v.Name, vb.InvoiceNo)
+	if vb.JID, err = postJournal(ctx, bid, rlib.JNLTYPEVBIL, vb.VBID, &vb.Dt, strings.TrimSpace(comment), m); err != nil {
+		return bizErrSys(&err)
+	}
+	if sess, ok := rlib.SessionFromContext(ctx); ok {
+		vb.ApprovedBy = sess.UID
+	}
+	vb.DtApproved = time.Now()
+	vb.Status = rlib.VBStatusApproved
+	if err = rlib.UpdateVendorBill(ctx, &vb); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// VoidVendorBill voids an Entered or Approved vendor bill.  The accounts
+// payable entry of an Approved bill is reversed on dt.
+//
+// INPUTS
+//    ctx  = db context
+//    bid  = business id
+//    vbid = the bill
+//    dt   = date of the reversal
+//
+// RETURNS
+//    a list of errors, nil if the bill was voided
+//-----------------------------------------------------------------------------
+func VoidVendorBill(ctx context.Context, bid, vbid int64, dt *time.Time) []BizError {
+	vb, err := getVendorBill(ctx, bid, vbid)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if vb.Status != rlib.VBStatusEntered && vb.Status != rlib.VBStatusApproved {
+		err = fmt.Errorf("vendor bill %s is %s, it cannot be voided", vb.IDtoShortString(), strings.ToLower(vb.StatusName()))
+		return bizErrSys(&err)
+	}
+	if vb.Status == rlib.VBStatusApproved {
+		lc, err := openClosePeriod(ctx, bid)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		if !dt.After(lc.Dt) {
+			err = fmt.Errorf("vendor bill %s cannot be voided on %s, it is in a closed period", vb.IDtoShortString(), dt.Format(rlib.RRDATEFMT3))
+			return bizErrSys(&err)
+		}
+		ap, err := getPayableAccount(ctx, bid)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		lines, err := rlib.GetVendorBillLines(ctx, vb.VBID)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		var m []jnlLine
+		for i := 0; i < len(lines); i++ {
+			m = append(m, jnlLine{dlid: ap.LID, clid: lines[i].LID, rid: lines[i].RID, amt: lines[i].Amount})
+		}
+		comment := fmt.Sprintf("Void of %s", vb.IDtoShortString())
+		if _, err = postJournal(ctx, bid, rlib.JNLTYPEVBIL, vb.VBID, dt, comment, m); err != nil {
+			return bizErrSys(&err)
+		}
+	}
+	vb.Status = rlib.VBStatusVoid
+	if err = rlib.UpdateVendorBill(ctx, &vb); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// PayVendorBills pays the approved vendor bills vbids from the depository of
+// payment run pr.  Each bill gets its own journal entry on pr.Dt, debiting
+// accounts payable and crediting the depository's GL account.  If
+// pr.FirstCheckNo is set the bills are numbered with consecutive check
+// numbers in the order supplied.
+//
+// INPUTS
+//    ctx   = db context
+//    pr    = the payment run, it is inserted with the total paid
+//    vbids = the bills to pay
+//
+// RETURNS
+//    a list of errors, nil if the bills were paid
+//-----------------------------------------------------------------------------
+func PayVendorBills(ctx context.Context, pr *rlib.VendorPaymentRun, vbids []int64) []BizError {
+	var errlist []BizError
+	fields := []string{}
+
+	dep, err := rlib.GetDepository(ctx, pr.DEPID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if dep.DEPID == 0 || dep.BID != pr.BID || dep.LID == 0 {
+		fields = append(fields, "DEPID")
+	}
+	if pr.Dt.Year() < 1971 {
+		fields = append(fields, "Dt")
+	}
+	if pr.FirstCheckNo < 0 {
+		fields = append(fields, "FirstCheckNo")
+	}
+	if len(vbids) == 0 {
+		fields = append(fields, "Bills (no bills to pay)")
+	}
+	if len(fields) > 0 {
+		msg := BizErrors[InvalidField].Message
+		for i := 0; i < len(fields); i++ {
+			msg += fmt.Sprintf("\n%s", fields[i])
+		}
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: msg})
+		return errlist
+	}
+
+	lc, err := openClosePeriod(ctx, pr.BID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if !pr.Dt.After(lc.Dt) {
+		err = fmt.Errorf("the payment date (%s) is in a closed period", pr.Dt.Format(rlib.RRDATEFMT3))
+		return bizErrSys(&err)
+	}
+	ap, err := getPayableAccount(ctx, pr.BID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+
+	var bills []rlib.VendorBill
+	seen := map[int64]bool{}
+	for _, id := range vbids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		vb, err := getVendorBill(ctx, pr.BID, id)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		if vb.Status != rlib.VBStatusApproved {
+			err = fmt.Errorf("vendor bill %s is %s, only approved bills can be paid", vb.IDtoShortString(), strings.ToLower(vb.StatusName()))
+			errlist = AddErrToBizErrlist(err, errlist)
+			continue
+		}
+		if pr.Dt.Before(vb.Dt) {
+			err = fmt.Errorf("vendor bill %s cannot be paid before its date (%s)", vb.IDtoShortString(), vb.Dt.Format(rlib.RRDATEFMT3))
+			errlist = AddErrToBizErrlist(err, errlist)
+			continue
+		}
+		bills = append(bills, vb)
+	}
+	if len(errlist) > 0 {
+		return errlist
+	}
+
+	pr.Amount = 0
+	pr.BillCount = int64(len(bills))
+	for i := 0; i < len(bills); i++ {
+		pr.Amount += bills[i].Amount
+	}
+	pr.Amount = rlib.RoundToCent(pr.Amount)
+	if _, err = rlib.InsertVendorPaymentRun(ctx, pr); err != nil {
+		return bizErrSys(&err)
+	}
+
+	for i := 0; i < len(bills); i++ {
+		vb := &bills[i]
+		if pr.FirstCheckNo > 0 {
+			vb.DocNo = strconv.FormatInt(pr.FirstCheckNo+int64(i), 10)
+		}
+		comment := fmt.Sprintf("Payment of %s by %s", vb.IDtoShortString(), pr.IDtoShortString())
+		if len(vb.DocNo) > 0 {
+			comment += ", check " + vb.DocNo
+		}
+		m := []jnlLine{{dlid: ap.LID, clid: dep.LID, amt: vb.Amount}}
+		if vb.PmtJID, err = postJournal(ctx, pr.BID, rlib.JNLTYPEVPMT, vb.VBID, &pr.Dt, comment, m); err != nil {
+			return bizErrSys(&err)
+		}
+		vb.VPRID = pr.VPRID
+		vb.DtPaid = pr.Dt
+		vb.Status = rlib.VBStatusPaid
+		if err = rlib.UpdateVendorBill(ctx, vb); err != nil {
+			return bizErrSys(&err)
+		}
+	}
+	return nil
+}
@@ -129,3 +129,20 @@ func ValidateAcctRule(ctx context.Context, a *rlib.AR) []BizError {
 	rlib.Console("after debit acct check: len(e) = %d\n", len(e))
 	return e
 }
+
+// getARByNameAndType returns the account rule named name in business bid,
+// which must be of type artype.  It is an error if there is no such rule.
+//-----------------------------------------------------------------------------
+func getARByNameAndType(ctx context.Context, bid int64, name string, artype int64) (rlib.AR, error) {
+	ar, err := rlib.GetARByName(ctx, bid, name)
+	if err != nil {
+		return ar, err
+	}
+	if ar.ARID == 0 {
+		return ar, fmt.Errorf("account rule %q not found in business %d", name, bid)
+	}
+	if ar.ARType != artype {
+		return ar, fmt.Errorf("account rule %q has the wrong type", name)
+	}
+	return ar, nil
+}
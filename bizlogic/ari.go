@@ -0,0 +1,219 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"sort"
+	"time"
+)
+
+// ExportARI sends the rates and availability (ARI) of business bid to each
+// export destination configured in its business properties.  Each
+// destination gets an OTA_HotelRateAmountNotifRQ message with the rates of
+// the rate plans flagged for its channel, and an OTA_HotelAvailNotifRQ
+// message with the availability of the rentable types those rates are for.
+// The export covers the configured number of days starting on dt.
+//
+// INPUTS
+//    ctx = db context
+//    bid = business id
+//    dt  = first day exported
+//
+// RETURNS
+//    the number of messages sent
+//    any error encountered
+//-----------------------------------------------------------------------------
+func ExportARI(ctx context.Context, bid int64, dt *time.Time) (int, error) {
+	var xbiz rlib.XBusiness
+	count := 0
+
+	p, err := rlib.GetARIPolicy(ctx, bid, "general")
+	if err != nil {
+		return count, err
+	}
+	if len(p.Sinks) == 0 {
+		return count, nil
+	}
+	if err = rlib.InitBizInternals(bid, &xbiz); err != nil {
+		return count, err
+	}
+	d2 := dt.AddDate(0, 0, p.Days)
+	now := time.Now()
+
+	for i := 0; i < len(p.Sinks); i++ {
+		flag, ok := rlib.ARIExportFlags[p.Sinks[i].Channel]
+		if !ok {
+			return count, fmt.Errorf("unknown ARI export channel: %s", p.Sinks[i].Channel)
+		}
+		sink, err := rlib.NewARISink(&p.Sinks[i])
+		if err != nil {
+			return count, err
+		}
+		rates, rtids, err := ariRates(ctx, &xbiz, flag, dt, &d2)
+		if err != nil {
+			return count, err
+		}
+		avail, err := ariAvail(ctx, &xbiz, rtids, dt, &d2)
+		if err != nil {
+			return count, err
+		}
+
+		name := fmt.Sprintf("%s-%s-%s", xbiz.P.Designation, p.Sinks[i].Channel, dt.Format("20060102"))
+		b, err := rlib.MarshalOTA(rlib.NewOTAHotelRateAmountNotif(p.HotelCode, p.Currency, rates, &now))
+		if err != nil {
+			return count, err
+		}
+		if err = sink.Send(name+"-rates.xml", b); err != nil {
+			return count, err
+		}
+		count++
+		if b, err = rlib.MarshalOTA(rlib.NewOTAHotelAvailNotif(p.HotelCode, avail, &now)); err != nil {
+			return count, err
+		}
+		if err = sink.Send(name+"-avail.xml", b); err != nil {
+			return count, err
+		}
+		count++
+		rlib.Ulog("ExportARI: %s %s, %d rates, %d availabilities\n", xbiz.P.Designation, p.Sinks[i].Channel, len(rates), len(avail))
+	}
+	return count, nil
+}
+
+// ariRates returns the nightly rates for d1 - d2 of the rate plans of xbiz
+// whose FLAGS include flag.  On each day the RatePlanRef in effect is used.
+// RatePlanRefs with a promo code or hidden from users are not exported.
+// Consecutive days with the same rate are combined.
+//
+// INPUTS
+//    ctx  = db context
+//    xbiz = the business, its internals initialized
+//    flag = the export flag of the channel
+//    d1   = first day
+//    d2   = day after the last day
+//
+// RETURNS
+//    the rates
+//    the rentable types that have rates, in order
+//    any error encountered
+//-----------------------------------------------------------------------------
+func ariRates(ctx context.Context, xbiz *rlib.XBusiness, flag uint64, d1, d2 *time.Time) ([]rlib.ARIRate, []int64, error) {
+	var m []rlib.ARIRate
+	var rtids []int64
+	seen := map[int64]bool{}
+
+	rps, err := rlib.GetAllRatePlans(ctx, xbiz.P.BID)
+	if err != nil {
+		return m, rtids, err
+	}
+	cache := map[int64]*rlib.RatePlanRef{} // by RPRID
+	for i := 0; i < len(rps); i++ {
+		flags, err := rlib.GetRatePlanFlags(ctx, rps[i].RPID)
+		if err != nil {
+			return m, rtids, err
+		}
+		if flags&flag == 0 {
+			continue
+		}
+
+		//-------------------------------------------------
+		// the RatePlanRef in effect on each day
+		//-------------------------------------------------
+		var days []time.Time
+		var refs []*rlib.RatePlanRef
+		for dt := *d1; dt.Before(*d2); dt = dt.AddDate(0, 0, 1) {
+			n, err := rlib.GetRatePlanRefsInRange(ctx, rps[i].RPID, &dt, &dt)
+			if err != nil {
+				return m, rtids, err
+			}
+			var ref *rlib.RatePlanRef
+			for j := 0; j < len(n) && ref == nil; j++ {
+				if len(n[j].PromoCode) > 0 || n[j].FLAGS&rlib.FlRTRRefHide != 0 {
+					continue
+				}
+				if ref = cache[n[j].RPRID]; ref == nil {
+					ref = &n[j]
+					if err = rlib.GetRatePlanRefFull(ctx, ref.RPRID, ref); err != nil {
+						return m, rtids, err
+					}
+					cache[ref.RPRID] = ref
+				}
+			}
+			days = append(days, dt)
+			refs = append(refs, ref)
+		}
+
+		//-------------------------------------------------
+		// the rates of each rentable type
+		//-------------------------------------------------
+		var types []int64
+		for k := range xbiz.RT {
+			types = append(types, k)
+		}
+		sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+		for _, rtid := range types {
+			rt := xbiz.RT[rtid]
+			for j := 0; j < len(days); j++ {
+				if refs[j] == nil {
+					continue
+				}
+				for k := 0; k < len(refs[j].RT); k++ {
+					a := &refs[j].RT[k]
+					if a.RTID != rtid || a.FLAGS&rlib.FlRTRna != 0 {
+						continue
+					}
+					m = rlib.AppendARIRate(m, rlib.ARIRate{
+						RatePlanCode:       rps[i].Name,
+						InvTypeCode:        rt.Style,
+						DtStart:            days[j],
+						DtStop:             days[j].AddDate(0, 0, 1),
+						Amount:             rlib.RoundToCent(a.Amount(rt.MarketRateOn(&days[j]))),
+						Guests:             refs[j].MaxNoFeeUsers,
+						AdditionalGuestFee: refs[j].AdditionalUserFee,
+					})
+					if !seen[rtid] {
+						seen[rtid] = true
+						rtids = append(rtids, rtid)
+					}
+				}
+			}
+		}
+	}
+	sort.Slice(rtids, func(i, j int) bool { return rtids[i] < rtids[j] })
+	return m, rtids, nil
+}
+
+// ariAvail returns the number of rentables of each type of rtids available
+// on each day of d1 - d2.  Consecutive days with the same count are
+// combined.
+//
+// INPUTS
+//    ctx   = db context
+//    xbiz  = the business, its internals initialized
+//    rtids = the rentable types
+//    d1    = first day
+//    d2    = day after the last day
+//
+// RETURNS
+//    the availability
+//    any error encountered
+//-----------------------------------------------------------------------------
+func ariAvail(ctx context.Context, xbiz *rlib.XBusiness, rtids []int64, d1, d2 *time.Time) ([]rlib.ARIAvail, error) {
+	var m []rlib.ARIAvail
+	for _, rtid := range rtids {
+		for dt := *d1; dt.Before(*d2); dt = dt.AddDate(0, 0, 1) {
+			next := dt.AddDate(0, 0, 1)
+			n, err := rlib.CountAvailableRentables(ctx, xbiz.P.BID, rtid, &dt, &next)
+			if err != nil {
+				return m, err
+			}
+			m = rlib.AppendARIAvail(m, rlib.ARIAvail{
+				InvTypeCode: xbiz.RT[rtid].Style,
+				DtStart:     dt,
+				DtStop:      next,
+				Available:   n,
+			})
+		}
+	}
+	return m, nil
+}
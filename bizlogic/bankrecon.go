@@ -0,0 +1,267 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"rentroll/rlib"
+	"time"
+)
+
+// BankImportResult summarizes the results of a bank statement import
+type BankImportResult struct {
+	BSID       int64 // id of the new BankStatement
+	Imported   int   // number of statement lines saved
+	Duplicates int   // lines skipped because they were imported previously
+	Matched    int   // lines automatically matched to a Deposit
+}
+
+// ImportBankStatement parses a bank statement file (OFX, QFX, or CSV) and
+// saves it as a BankStatement for Depository depid. Lines that were already
+// imported, either from an earlier statement or an overlapping file, are
+// skipped based on the bank's transaction id (FITID).  After the lines are
+// saved, they are automatically matched against the Deposits made to the
+// depository.  The matches are proposals, nothing is marked as cleared
+// until a match is confirmed.
+//
+// INPUTS
+//    ctx   = db context, should contain a transaction
+//    bid   = business id
+//    depid = the depository
+//    r     = the file contents
+//    fname = the file name, its extension selects the parser
+//
+// RETURNS
+//    a summary of the import
+//    any error encountered
+//-----------------------------------------------------------------------------
+func ImportBankStatement(ctx context.Context, bid, depid int64, r io.Reader, fname string) (BankImportResult, error) {
+	var res BankImportResult
+
+	dep, err := rlib.GetDepository(ctx, depid)
+	if err != nil {
+		return res, err
+	}
+	if dep.DEPID == 0 || dep.BID != bid {
+		return res, fmt.Errorf("depository %d not found in business %d", depid, bid)
+	}
+
+	bs, err := rlib.ParseBankStatement(r, fname)
+	if err != nil {
+		return res, err
+	}
+	bs.BID = bid
+	bs.DEPID = depid
+
+	if _, err = rlib.InsertBankStatement(ctx, &bs); err != nil {
+		return res, err
+	}
+	res.BSID = bs.BSID
+
+	for i := 0; i < len(bs.L); i++ {
+		if len(bs.L[i].FITID) > 0 {
+			b, err := rlib.GetBankStatementLineByFITID(ctx, bid, depid, bs.L[i].FITID)
+			if err != nil {
+				return res, err
+			}
+			if b.BSLID > 0 {
+				res.Duplicates++
+				continue
+			}
+		}
+		bs.L[i].BSID = bs.BSID
+		bs.L[i].BID = bid
+		bs.L[i].DEPID = depid
+		if _, err = rlib.InsertBankStatementLine(ctx, &bs.L[i]); err != nil {
+			return res, err
+		}
+		res.Imported++
+	}
+
+	d1 := bs.DtStart
+	d2 := bs.DtStop.AddDate(0, 0, 1)
+	res.Matched, err = AutoMatchBankStatementLines(ctx, bid, depid, &d1, &d2)
+	return res, err
+}
+
+// AutoMatchBankStatementLines proposes a Deposit for every unmatched bank
+// statement line of Depository depid in the range d1 <= Dt < d2.  See
+// rlib.MatchBankStatementLines for the matching rules. Deposits that have
+// cleared or that are matched to some other statement line are not
+// considered.
+//
+// INPUTS
+//    ctx   = db context
+//    bid   = business id
+//    depid = the depository
+//    d1,d2 = the date range of the statement lines to match
+//
+// RETURNS
+//    the number of lines that were matched
+//    any error encountered
+//-----------------------------------------------------------------------------
+func AutoMatchBankStatementLines(ctx context.Context, bid, depid int64, d1, d2 *time.Time) (int, error) {
+	m, err := rlib.GetBankStatementLinesInRange(ctx, bid, depid, d1, d2)
+	if err != nil {
+		return 0, err
+	}
+
+	//------------------------------------------------------------
+	// Candidate deposits: anything in the date window that has
+	// not cleared and is not already spoken for.
+	//------------------------------------------------------------
+	inList := map[int64]bool{}
+	for i := 0; i < len(m); i++ {
+		if m[i].DID > 0 {
+			inList[m[i].DID] = true
+		}
+	}
+	dt1 := d1.AddDate(0, 0, -rlib.BankMatchDays)
+	dt2 := d2.AddDate(0, 0, rlib.BankMatchDays)
+	n, err := rlib.GetDepositsByDEPIDInRange(ctx, bid, depid, &dt1, &dt2)
+	if err != nil {
+		return 0, err
+	}
+	var d []rlib.Deposit
+	for i := 0; i < len(n); i++ {
+		if n[i].FLAGS&rlib.FlDepositCleared != 0 || inList[n[i].DID] {
+			continue
+		}
+		b, err := rlib.GetBankStatementLineByDID(ctx, n[i].DID)
+		if err != nil {
+			return 0, err
+		}
+		if b.BSLID > 0 {
+			continue
+		}
+		d = append(d, n[i])
+	}
+
+	var before = make([]int64, len(m))
+	for i := 0; i < len(m); i++ {
+		before[i] = m[i].DID
+	}
+	count := rlib.MatchBankStatementLines(m, d, rlib.BankMatchDays)
+	for i := 0; i < len(m); i++ {
+		if m[i].DID == before[i] {
+			continue
+		}
+		if err = rlib.UpdateBankStatementLine(ctx, &m[i]); err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
+
+// ConfirmBankStatementLine confirms that bank statement line bslid is the
+// bank's record of Deposit did. The Deposit is marked as cleared and its
+// ClearedAmount is set to the amount the bank reported. If did is 0, the
+// Deposit proposed by the automatic match is confirmed.
+//
+// The cleared amount is information from the bank, it does not change any
+// journal or ledger entries, so deposits in closed periods can be cleared.
+//
+// INPUTS
+//    ctx   = db context, should contain a transaction
+//    bslid = the bank statement line
+//    did   = the deposit, or 0 to accept the proposed match
+//
+// RETURNS
+//    any error encountered
+//-----------------------------------------------------------------------------
+func ConfirmBankStatementLine(ctx context.Context, bslid, did int64) error {
+	l, err := rlib.GetBankStatementLine(ctx, bslid)
+	if err != nil {
+		return err
+	}
+	if l.BSLID == 0 {
+		return fmt.Errorf("bank statement line %d not found", bslid)
+	}
+	if did == 0 {
+		did = l.DID
+	}
+	if did == 0 {
+		return fmt.Errorf("bank statement line %d has not been matched to a deposit", bslid)
+	}
+
+	dep, err := rlib.GetDeposit(ctx, did)
+	if err != nil {
+		return err
+	}
+	if dep.DID == 0 || dep.BID != l.BID {
+		return fmt.Errorf("deposit %d not found", did)
+	}
+	if dep.DEPID != l.DEPID {
+		return fmt.Errorf("deposit %d was made to a different depository than the bank statement line", did)
+	}
+	o, err := rlib.GetBankStatementLineByDID(ctx, did)
+	if err != nil {
+		return err
+	}
+	if o.BSLID > 0 && o.BSLID != l.BSLID {
+		return fmt.Errorf("deposit %d is already matched to bank statement line %d", did, o.BSLID)
+	}
+
+	//------------------------------------------------------------
+	// If the line was matched to a different deposit, release it
+	//------------------------------------------------------------
+	if l.DID > 0 && l.DID != did {
+		if err = UnmatchBankStatementLine(ctx, l.BSLID); err != nil {
+			return err
+		}
+		if l, err = rlib.GetBankStatementLine(ctx, bslid); err != nil {
+			return err
+		}
+	}
+
+	l.DID = did
+	l.FLAGS |= rlib.FlBankLineConfirmed
+	if err = rlib.UpdateBankStatementLine(ctx, &l); err != nil {
+		return err
+	}
+	dep.ClearedAmount = l.Amount
+	dep.FLAGS |= rlib.FlDepositCleared
+	return rlib.UpdateDeposit(ctx, &dep)
+}
+
+// UnmatchBankStatementLine removes the Deposit match from bank statement
+// line bslid. If the match had been confirmed, the Deposit is returned to
+// the uncleared state.
+//
+// INPUTS
+//    ctx   = db context, should contain a transaction
+//    bslid = the bank statement line
+//
+// RETURNS
+//    any error encountered
+//-----------------------------------------------------------------------------
+func UnmatchBankStatementLine(ctx context.Context, bslid int64) error {
+	l, err := rlib.GetBankStatementLine(ctx, bslid)
+	if err != nil {
+		return err
+	}
+	if l.BSLID == 0 {
+		return fmt.Errorf("bank statement line %d not found", bslid)
+	}
+	if l.DID == 0 {
+		return nil
+	}
+
+	if l.FLAGS&rlib.FlBankLineConfirmed != 0 {
+		dep, err := rlib.GetDeposit(ctx, l.DID)
+		if err != nil {
+			return err
+		}
+		if dep.DID > 0 {
+			dep.ClearedAmount = float64(0)
+			dep.FLAGS &^= rlib.FlDepositCleared
+			if err = rlib.UpdateDeposit(ctx, &dep); err != nil {
+				return err
+			}
+		}
+	}
+
+	l.DID = 0
+	l.FLAGS &^= rlib.FlBankLineConfirmed
+	return rlib.UpdateBankStatementLine(ctx, &l)
+}
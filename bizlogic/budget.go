@@ -0,0 +1,103 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"time"
+)
+
+// A budget is entered for a fiscal year as BudgetMonths monthly amounts per
+// income or expense GL account.  Each month is stored as a Budget record
+// dated on the first day of the month.  Only posting accounts are budgeted,
+// the budget of a parent account is the sum of its children.
+
+// getBudgetAccount returns the GL account of business bid with GL number
+// glno, which must be an income or expense account that allows posting
+func getBudgetAccount(ctx context.Context, bid int64, glno string) (rlib.GLAccount, error) {
+	gl, err := rlib.GetLedgerByGLNo(ctx, bid, glno)
+	if err != nil {
+		return gl, err
+	}
+	if gl.LID == 0 {
+		return gl, fmt.Errorf("GL account %s not found", glno)
+	}
+	if !gl.AllowPost {
+		return gl, fmt.Errorf("GL account %s is a summary account, budget its child accounts", gl.GLNumber)
+	}
+	if !rlib.IsIncomeStatementAcct(&gl) {
+		return gl, fmt.Errorf("GL account %s is an %s account, only income and expense accounts are budgeted", gl.GLNumber, gl.AcctType)
+	}
+	return gl, nil
+}
+
+// SaveBudgetYear sets the monthly budget of GL account glno of business bid
+// for the fiscal year holding dt.  The months with a zero amount are
+// removed.
+//
+// INPUTS
+//    ctx     = db context
+//    bid     = business id
+//    glno    = GL number of the account
+//    dt      = any date in the fiscal year
+//    amt     = the BudgetMonths amounts, amt[0] is the first month of the year
+//    comment = note saved with each month
+//
+// RETURNS
+//    a list of errors, nil if the budget was saved
+//-----------------------------------------------------------------------------
+func SaveBudgetYear(ctx context.Context, bid int64, glno string, dt *time.Time, amt []float64, comment string) []BizError {
+	if len(amt) != rlib.BudgetMonths {
+		err := fmt.Errorf("a budget has %d monthly amounts, found %d", rlib.BudgetMonths, len(amt))
+		return bizErrSys(&err)
+	}
+	gl, err := getBudgetAccount(ctx, bid, glno)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	p, err := rlib.GetFiscalYearPolicy(ctx, bid, "general")
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	d1, _ := p.YearContaining(dt)
+
+	for i := 0; i < rlib.BudgetMonths; i++ {
+		m := d1.AddDate(0, i, 0)
+		a, err := rlib.GetBudgetForAccount(ctx, bid, gl.LID, &m)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		v := rlib.RoundToCent(amt[i])
+		switch {
+		case a.BGTID > 0 && v == 0:
+			err = rlib.DeleteBudget(ctx, a.BGTID)
+		case a.BGTID > 0:
+			a.Amount = v
+			a.Comment = comment
+			err = rlib.UpdateBudget(ctx, &a)
+		case v != 0:
+			a = rlib.Budget{BID: bid, LID: gl.LID, Dt: m, Amount: v, Comment: comment}
+			_, err = rlib.InsertBudget(ctx, &a)
+		}
+		if err != nil {
+			return bizErrSys(&err)
+		}
+	}
+	return nil
+}
+
+// DeleteBudgetYear removes the budget of GL account glno of business bid for
+// the fiscal year holding dt
+//
+// INPUTS
+//    ctx  = db context
+//    bid  = business id
+//    glno = GL number of the account
+//    dt   = any date in the fiscal year
+//
+// RETURNS
+//    a list of errors, nil if the budget was removed
+//-----------------------------------------------------------------------------
+func DeleteBudgetYear(ctx context.Context, bid int64, glno string, dt *time.Time) []BizError {
+	return SaveBudgetYear(ctx, bid, glno, dt, make([]float64, rlib.BudgetMonths), "")
+}
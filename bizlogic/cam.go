@@ -0,0 +1,393 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"sort"
+	"time"
+)
+
+// camContext holds what the CAM reconciliations of a business for one
+// period have in common
+type camContext struct {
+	xbiz      rlib.XBusiness
+	p         rlib.BizPropsCAM
+	expARIDs  map[int64]bool                  // recoverable expense account rules
+	estARIDs  map[int64]bool                  // estimated charge account rules
+	totalSqFt int64                           // square feet of all rentables
+	totals    map[time.Time]map[int64]float64 // expenses by LID, indexed by start of period
+}
+
+// newCAMContext loads the pass-through settings of business bid and the
+// total square feet of its rentables on dt.
+//-----------------------------------------------------------------------------
+func newCAMContext(ctx context.Context, bid int64, dt *time.Time) (*camContext, error) {
+	var err error
+	cc := camContext{
+		expARIDs: map[int64]bool{},
+		estARIDs: map[int64]bool{},
+		totals:   map[time.Time]map[int64]float64{},
+	}
+	if err = rlib.InitBizInternals(bid, &cc.xbiz); err != nil {
+		return &cc, err
+	}
+	if cc.p, err = rlib.GetCAMPolicy(ctx, bid, "general"); err != nil {
+		return &cc, err
+	}
+	if len(cc.p.ExpenseARNames) == 0 {
+		return &cc, fmt.Errorf("no recoverable expense account rules are defined for business %d", bid)
+	}
+	for i := 0; i < len(cc.p.ExpenseARNames); i++ {
+		ar, err := getARByNameAndType(ctx, bid, cc.p.ExpenseARNames[i], rlib.AREXPENSE)
+		if err != nil {
+			return &cc, err
+		}
+		cc.expARIDs[ar.ARID] = true
+	}
+	for i := 0; i < len(cc.p.EstimateARNames); i++ {
+		ar, err := getARByNameAndType(ctx, bid, cc.p.EstimateARNames[i], rlib.ARASSESSMENT)
+		if err != nil {
+			return &cc, err
+		}
+		cc.estARIDs[ar.ARID] = true
+	}
+
+	m, err := rlib.GetRentablesByBusiness(ctx, bid)
+	if err != nil {
+		return &cc, err
+	}
+	for i := 0; i < len(m); i++ {
+		n, err := rlib.RentableSqFt(ctx, &cc.xbiz, m[i].RID, dt)
+		if err != nil {
+			return &cc, err
+		}
+		cc.totalSqFt += n
+	}
+	return &cc, nil
+}
+
+// expenses returns the recoverable expenses of the business in d1 - d2,
+// totaled by the GL account that each expense's account rule debits.
+//-----------------------------------------------------------------------------
+func (cc *camContext) expenses(ctx context.Context, d1, d2 *time.Time) (map[int64]float64, error) {
+	if m, ok := cc.totals[*d1]; ok {
+		return m, nil
+	}
+	m := map[int64]float64{}
+	x, err := rlib.GetExpensesByRange(ctx, cc.xbiz.P.BID, d1, d2)
+	if err != nil {
+		return m, err
+	}
+	for i := 0; i < len(x); i++ {
+		if !cc.expARIDs[x[i].ARID] {
+			continue
+		}
+		m[rlib.RRdb.BizTypes[x[i].BID].AR[x[i].ARID].DebitLID] += x[i].Amount
+	}
+	cc.totals[*d1] = m
+	return m, nil
+}
+
+// initCAMReconciliation fills in reconciliation c for rental agreement ra
+// and the period c.Dt1 - c.Dt2.
+//-----------------------------------------------------------------------------
+func (cc *camContext) initCAMReconciliation(ctx context.Context, c *rlib.CAMReconciliation, ra *rlib.RentalAgreement) error {
+	c.BID = ra.BID
+	c.RAID = ra.RAID
+	c.LeaseType = ra.LeaseType
+	c.ExpenseAdjustmentType = ra.ExpenseAdjustmentType
+	c.ExpensesStop = ra.ExpensesStop
+	c.TotalSqFt = cc.totalSqFt
+	c.Occupancy = rlib.Occupancy(&c.Dt1, &c.Dt2, &ra.AgreementStart, &ra.AgreementStop)
+	dt := c.Dt2.AddDate(0, 0, -1)
+
+	//------------------------------------------------------------
+	// the tenant's square feet and the payor
+	//------------------------------------------------------------
+	c.SqFt = 0
+	c.RID = 0
+	rar, err := rlib.GetRentalAgreementRentables(ctx, ra.RAID, &c.Dt1, &c.Dt2)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(rar); i++ {
+		if c.RID == 0 {
+			c.RID = rar[i].RID
+		}
+		n, err := rlib.RentableSqFt(ctx, &cc.xbiz, rar[i].RID, &dt)
+		if err != nil {
+			return err
+		}
+		c.SqFt += n
+	}
+	c.TCID = 0
+	pyr, err := rlib.GetRentalAgreementPayorsInRange(ctx, ra.RAID, &c.Dt1, &c.Dt2)
+	if err != nil {
+		return err
+	}
+	if len(pyr) > 0 {
+		c.TCID = pyr[0].TCID
+	}
+
+	//------------------------------------------------------------
+	// the expenses by GL account, for the period and base year
+	//------------------------------------------------------------
+	cur, err := cc.expenses(ctx, &c.Dt1, &c.Dt2)
+	if err != nil {
+		return err
+	}
+	base := map[int64]float64{}
+	if ra.ExpenseAdjustmentType == rlib.ExpAdjBaseYear {
+		if !ra.BaseYearEnd.After(rlib.TIME0) {
+			return fmt.Errorf("rental agreement %s has a base year expense adjustment but no base year", rlib.IDtoShortString("RA", ra.RAID))
+		}
+		b1, b2 := ra.BaseYear()
+		if base, err = cc.expenses(ctx, &b1, &b2); err != nil {
+			return err
+		}
+	}
+	var lids []int64
+	for lid := range cur {
+		lids = append(lids, lid)
+	}
+	for lid := range base {
+		if _, ok := cur[lid]; !ok {
+			lids = append(lids, lid)
+		}
+	}
+	sort.Slice(lids, func(i, j int) bool { return lids[i] < lids[j] })
+	c.L = nil
+	for i := 0; i < len(lids); i++ {
+		c.L = append(c.L, rlib.CAMReconLine{
+			BID:          c.BID,
+			LID:          lids[i],
+			Expenses:     rlib.RoundToCent(cur[lids[i]]),
+			BaseExpenses: rlib.RoundToCent(base[lids[i]]),
+		})
+	}
+
+	//------------------------------------------------------------
+	// the estimated charges already assessed
+	//------------------------------------------------------------
+	c.Estimated = 0
+	m, err := rlib.GetAssessmentInstancesByRAIDRange(ctx, ra.RAID, &c.Dt1, &c.Dt2)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(m); i++ {
+		if !cc.estARIDs[m[i].ARID] || m[i].FLAGS&rlib.ASMREVERSED != 0 || m[i].Start.Before(c.Dt1) || !m[i].Start.Before(c.Dt2) {
+			continue
+		}
+		c.Estimated += m[i].Amount
+	}
+	c.Estimated = rlib.RoundToCent(c.Estimated)
+
+	c.Compute()
+	return nil
+}
+
+// InitCAMReconciliation computes the reconciliation for c.RAID and the
+// period c.Dt1 - c.Dt2 without saving it.
+//
+// INPUTS
+//    ctx = db context
+//    c   = the reconciliation, RAID, Dt1 and Dt2 must be set
+//
+// RETURNS
+//    any error encountered
+//-----------------------------------------------------------------------------
+func InitCAMReconciliation(ctx context.Context, c *rlib.CAMReconciliation) error {
+	if !c.Dt1.Before(c.Dt2) {
+		return fmt.Errorf("the reconciliation period must end after it starts")
+	}
+	ra, err := rlib.GetRentalAgreement(ctx, c.RAID)
+	if err != nil {
+		return err
+	}
+	if ra.RAID == 0 {
+		return fmt.Errorf("rental agreement %d not found", c.RAID)
+	}
+	if !ra.PassesThroughExpenses() {
+		return fmt.Errorf("rental agreement %s does not pass through operating expenses", rlib.IDtoShortString("RA", ra.RAID))
+	}
+	dt := c.Dt2.AddDate(0, 0, -1)
+	cc, err := newCAMContext(ctx, ra.BID, &dt)
+	if err != nil {
+		return err
+	}
+	return cc.initCAMReconciliation(ctx, c, &ra)
+}
+
+// CreateCAMReconciliations creates the reconciliations of business bid for
+// the period d1 - d2. There is one for each rental agreement in the period
+// that passes through its operating expenses.  Agreements that already
+// have a reconciliation for the period are skipped, so the routine can be
+// run again after new agreements have been added.
+//
+// INPUTS
+//    ctx   = db context, should contain a transaction
+//    bid   = business id
+//    d1,d2 = the period, usually a calendar year
+//
+// RETURNS
+//    the reconciliations created
+//    any error encountered
+//-----------------------------------------------------------------------------
+func CreateCAMReconciliations(ctx context.Context, bid int64, d1, d2 *time.Time) ([]rlib.CAMReconciliation, error) {
+	var t []rlib.CAMReconciliation
+	if !d1.Before(*d2) {
+		return t, fmt.Errorf("the reconciliation period must end after it starts")
+	}
+	dt := d2.AddDate(0, 0, -1)
+	cc, err := newCAMContext(ctx, bid, &dt)
+	if err != nil {
+		return t, err
+	}
+	m, err := rlib.GetRentalAgreementsByRange(ctx, bid, d1, d2)
+	if err != nil {
+		return t, err
+	}
+
+	for i := 0; i < len(m); i++ {
+		if !m[i].PassesThroughExpenses() || m[i].FLAGS&(1<<6) != 0 { // skip voided agreements
+			continue
+		}
+		n, err := rlib.GetCAMReconciliationsByRAID(ctx, m[i].RAID)
+		if err != nil {
+			return t, err
+		}
+		found := false
+		for j := 0; j < len(n) && !found; j++ {
+			found = n[j].Dt1.Equal(*d1) && n[j].Dt2.Equal(*d2)
+		}
+		if found {
+			continue
+		}
+
+		c := rlib.CAMReconciliation{Dt1: *d1, Dt2: *d2}
+		if err = cc.initCAMReconciliation(ctx, &c, &m[i]); err != nil {
+			return t, err
+		}
+		if _, err = rlib.InsertCAMReconciliation(ctx, &c); err != nil {
+			return t, err
+		}
+		for j := 0; j < len(c.L); j++ {
+			c.L[j].CAMRID = c.CAMRID
+			if _, err = rlib.InsertCAMReconLine(ctx, &c.L[j]); err != nil {
+				return t, err
+			}
+		}
+		t = append(t, c)
+	}
+	return t, nil
+}
+
+// PostCAMReconciliation bills or credits the tenant for the true-up of
+// reconciliation camrid.  If the tenant's share is more than the estimated
+// charges the difference is assessed, if it is less the difference is
+// credited with a receipt that is applied to the tenant's future charges.
+// The next expense adjustment of the rental agreement is moved a year past
+// the reconciled period.
+//
+// INPUTS
+//    ctx    = db context, should contain a transaction
+//    camrid = the reconciliation
+//
+// RETURNS
+//    the posted reconciliation
+//    any error encountered
+//-----------------------------------------------------------------------------
+func PostCAMReconciliation(ctx context.Context, camrid int64) (rlib.CAMReconciliation, error) {
+	c, err := rlib.GetCAMReconciliation(ctx, camrid)
+	if err != nil {
+		return c, err
+	}
+	if c.CAMRID == 0 {
+		return c, fmt.Errorf("CAM reconciliation %d not found", camrid)
+	}
+	if c.FLAGS&rlib.FlCAMPosted != 0 {
+		return c, fmt.Errorf("CAM reconciliation %s has already been posted", c.IDtoString())
+	}
+	if c.TCID == 0 {
+		return c, fmt.Errorf("rental agreement %d has no payor", c.RAID)
+	}
+	p, err := rlib.GetCAMPolicy(ctx, c.BID, "general")
+	if err != nil {
+		return c, err
+	}
+	dt := rlib.DateAtTimeZero(rlib.Now())
+	comment := fmt.Sprintf("CAM reconciliation %s, %s - %s", c.IDtoString(), c.Dt1.Format(rlib.RRDATEFMT3), c.Dt2.AddDate(0, 0, -1).Format(rlib.RRDATEFMT3))
+
+	if c.TrueUp >= 0.01 {
+		ar, err := getARByNameAndType(ctx, c.BID, p.TrueUpARName, rlib.ARASSESSMENT)
+		if err != nil {
+			return c, err
+		}
+		lc, err := openClosePeriod(ctx, c.BID)
+		if err != nil {
+			return c, err
+		}
+
+		a := rlib.Assessment{
+			BID:            c.BID,
+			RID:            c.RID,
+			RAID:           c.RAID,
+			Amount:         c.TrueUp,
+			Start:          dt,
+			Stop:           dt,
+			RentCycle:      rlib.RECURNONE,
+			ProrationCycle: rlib.RECURNONE,
+			ARID:           ar.ARID,
+			Comment:        comment,
+		}
+		if errlist := InsertAssessment(ctx, &a, 0, &lc); len(errlist) > 0 {
+			return c, BizErrorListToError(errlist)
+		}
+		c.ASMID = a.ASMID
+	} else if c.TrueUp <= -0.01 {
+		ar, err := getARByNameAndType(ctx, c.BID, p.CreditARName, rlib.ARRECEIPT)
+		if err != nil {
+			return c, err
+		}
+		pmt, err := getPaymentTypeByName(ctx, c.BID, p.PMTName, "Credit for operating expenses paid in excess of the tenant's share")
+		if err != nil {
+			return c, err
+		}
+		r := rlib.Receipt{
+			BID:     c.BID,
+			TCID:    c.TCID,
+			PMTID:   pmt.PMTID,
+			Dt:      dt,
+			DocNo:   c.IDtoString(),
+			Amount:  -c.TrueUp,
+			ARID:    ar.ARID,
+			Comment: comment,
+		}
+		if err = InsertReceipt(ctx, &r); err != nil {
+			return c, err
+		}
+		c.RCPTID = r.RCPTID
+	}
+
+	c.FLAGS |= rlib.FlCAMPosted
+	if err = rlib.UpdateCAMReconciliation(ctx, &c); err != nil {
+		return c, err
+	}
+
+	ra, err := rlib.GetRentalAgreement(ctx, c.RAID)
+	if err != nil {
+		return c, err
+	}
+	if next := c.Dt2.AddDate(1, 0, 0); ra.ExpenseAdjustment.Before(next) {
+		ra.ExpenseAdjustment = next
+		if err = rlib.UpdateRentalAgreement(ctx, &ra); err != nil {
+			return c, err
+		}
+	}
+
+	note := fmt.Sprintf("%s posted. Share of expenses %s, estimated charges %s, true-up %s.",
+		comment, rlib.RRCommaf(c.Share), rlib.RRCommaf(c.Estimated), rlib.RRCommaf(c.TrueUp))
+	err = addPayorNote(ctx, c.BID, c.TCID, c.RAID, c.RID, note)
+	return c, err
+}
@@ -0,0 +1,37 @@
+package bizlogic
+
+import (
+	"context"
+	"rentroll/rlib"
+)
+
+// openClosePeriod returns the close period info of business bid.  lc.Dt is
+// the date of the last close, TIME0 if the business was never closed, and
+// nothing is posted on or before it:
+//
+//    * assessments inserted or reversed with lc are moved to the open
+//      period, lc.OpenPeriodDt, the day after the last close
+//    * the other postings (receipts, expenses, journals) are rejected when
+//      their date is not after lc.Dt
+//
+// INPUTS
+//    ctx = db context
+//    bid = business id
+//
+// RETURNS
+//    the close period info
+//    any error encountered
+//-----------------------------------------------------------------------------
+func openClosePeriod(ctx context.Context, bid int64) (rlib.ClosePeriod, error) {
+	lc, err := rlib.GetLastClosePeriod(ctx, bid)
+	if err != nil {
+		return lc, err
+	}
+	if lc.CPID == 0 {
+		lc.Dt = rlib.TIME0
+	}
+	lc.OpenPeriodDt = lc.Dt.AddDate(0, 0, 1)
+	lc.ExpandAsmDtStart = rlib.TIME0
+	lc.ExpandAsmDtStop = rlib.ENDOFTIME
+	return lc, nil
+}
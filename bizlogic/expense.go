@@ -41,7 +41,8 @@ func ReverseExpense(ctx context.Context, aold *rlib.Expense, dt *time.Time) []Bi
 }
 
 // UpdateExpense updates the supplied expense, reversing existing expenses
-// if necessary
+// if necessary.  When the expense is reversed, the new expense that replaces
+// it is journaled.
 //
 // INPUTS
 //    a = the expense to update
@@ -79,8 +80,9 @@ func UpdateExpense(ctx context.Context, anew *rlib.Expense, dt *time.Time) []Biz
 	//   ARID
 	//   Amount
 	//   Dt
+	// The new expense is journaled like any new expense.
 	//---------------------------------------------------------------------------------
-	if aold.ARID != anew.ARID || aold.Amount != anew.Amount || (!aold.Dt.Equal(anew.Dt)) {
+	if rlib.ExpenseEditReverses(&aold, anew) {
 		errlist = ReverseExpense(ctx, &aold, dt) // reverse the expense itself
 		if errlist != nil {
 			return errlist
@@ -90,6 +92,10 @@ func UpdateExpense(ctx context.Context, anew *rlib.Expense, dt *time.Time) []Biz
 		if err != nil {
 			return bizErrSys(&err)
 		}
+		var xbiz rlib.XBusiness
+		if err = rlib.ProcessNewExpense(ctx, anew, &xbiz); err != nil {
+			return bizErrSys(&err)
+		}
 	} else {
 		err = rlib.UpdateExpense(ctx, anew) // reversal not needed, just update the expense
 		if err != nil {
@@ -0,0 +1,297 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"strings"
+	"time"
+)
+
+// Closing a fiscal year moves the balance of every income and expense
+// account into the retained earnings account named in the business
+// properties (BizProps.FiscalYear).  The closing journal is dated on the last
+// day of the year, so the income statement accounts start the next year at
+// zero.  The monthly periods must be closed through the end of the year
+// first, the closing journal is the only thing posted into them, and the
+// ledger markers written when they were closed are adjusted to include it.
+// While the year is closed its last monthly period cannot be reopened.
+//
+// Reopening the year posts a journal that reverses the closing journal on
+// the same date.  The FiscalYearClose record is kept, marked reopened, with
+// who reopened it, when and why.
+
+// getRetainedEarningsAccount returns the retained earnings GL account of
+// business bid
+func getRetainedEarningsAccount(ctx context.Context, bid int64, p *rlib.BizPropsFiscalYear) (rlib.GLAccount, error) {
+	if len(p.RetainedEarningsGLNumber) == 0 {
+		return rlib.GLAccount{}, fmt.Errorf("no retained earnings GL account is defined for business %d", bid)
+	}
+	gl, err := rlib.GetLedgerByGLNo(ctx, bid, p.RetainedEarningsGLNumber)
+	if err != nil {
+		return gl, err
+	}
+	if gl.LID == 0 {
+		return gl, fmt.Errorf("retained earnings GL account %s not found", p.RetainedEarningsGLNumber)
+	}
+	if !gl.AllowPost {
+		return gl, fmt.Errorf("retained earnings GL account %s does not allow posting", gl.GLNumber)
+	}
+	if rlib.IsIncomeStatementAcct(&gl) {
+		return gl, fmt.Errorf("retained earnings GL account %s is an %s account, it must be an equity account", gl.GLNumber, gl.AcctType)
+	}
+	return gl, nil
+}
+
+// getClosingLines returns the journal lines that zero the income and
+// expense accounts of business bid into retained earnings account re as of
+// dt, and the net income they move.  The balance of a parent account
+// includes its children, only its own part is closed.
+func getClosingLines(ctx context.Context, bid int64, re *rlib.GLAccount, dt *time.Time) ([]jnlLine, float64, error) {
+	var m []jnlLine
+	net := float64(0)
+	t, err := rlib.GetLedgerList(ctx, bid)
+	if err != nil {
+		return m, net, err
+	}
+	for i := 0; i < len(t); i++ {
+		if !t[i].AllowPost || !rlib.IsIncomeStatementAcct(&t[i]) {
+			continue
+		}
+		bal, err := rlib.GetAccountBalance(ctx, bid, t[i].LID, dt)
+		if err != nil {
+			return m, net, err
+		}
+		c, err := rlib.GetGLAccountChildAccts(ctx, bid, t[i].LID)
+		if err != nil {
+			return m, net, err
+		}
+		for k := 0; k < len(c); k++ {
+			b, err := rlib.GetAccountBalance(ctx, bid, c[k], dt)
+			if err != nil {
+				return m, net, err
+			}
+			bal -= b
+		}
+		bal = rlib.RoundToCent(bal)
+		switch {
+		case bal > 0: // debit balance, an expense
+			m = append(m, jnlLine{dlid: re.LID, clid: t[i].LID, amt: bal})
+		case bal < 0: // credit balance, income
+			m = append(m, jnlLine{dlid: t[i].LID, clid: re.LID, amt: -bal})
+		}
+		net -= bal
+	}
+	return m, rlib.RoundToCent(net), nil
+}
+
+// adjustLedgerMarkers adds the entries of m to the general ledger markers
+// dated after dt.  These markers were written when the periods after dt
+// were closed, before the entries were posted.
+func adjustLedgerMarkers(ctx context.Context, bid int64, dt *time.Time, m []jnlLine) error {
+	delta := map[int64]float64{}
+	for i := 0; i < len(m); i++ {
+		delta[m[i].dlid] += m[i].amt
+		delta[m[i].clid] -= m[i].amt
+	}
+	for lid, amt := range delta {
+		if rlib.RoundToCent(amt) == 0 {
+			continue
+		}
+		lm, err := rlib.GetLedgerMarkersInRange(ctx, bid, lid, dt, &rlib.ENDOFTIME)
+		if err != nil {
+			return err
+		}
+		for k := 0; k < len(lm); k++ {
+			lm[k].Balance = rlib.RoundToCent(lm[k].Balance + amt)
+			if err = rlib.UpdateLedgerMarker(ctx, &lm[k]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// CloseFiscalYear closes the fiscal year of business bid that holds dt.
+// Fiscal years are closed in order: the close fails if the year before has
+// not been closed, or, when no year was ever closed, if the year before
+// has income or expenses left to close.  The monthly periods must be closed
+// through the last day of the year.
+//
+// INPUTS
+//    ctx = db context
+//    bid = business id
+//    dt  = any date in the fiscal year to close
+//
+// RETURNS
+//    the FiscalYearClose record written
+//    a list of errors, nil if the year was closed
+//-----------------------------------------------------------------------------
+func CloseFiscalYear(ctx context.Context, bid int64, dt *time.Time) (rlib.FiscalYearClose, []BizError) {
+	var fyc rlib.FiscalYearClose
+	p, err := rlib.GetFiscalYearPolicy(ctx, bid, "general")
+	if err != nil {
+		return fyc, bizErrSys(&err)
+	}
+	re, err := getRetainedEarningsAccount(ctx, bid, &p)
+	if err != nil {
+		return fyc, bizErrSys(&err)
+	}
+	d1, d2 := p.YearContaining(dt)
+	cdt := d2.AddDate(0, 0, -1) // the closing journal is on the last day of the year
+
+	last, err := rlib.GetLastFiscalYearClose(ctx, bid)
+	if err != nil {
+		return fyc, bizErrSys(&err)
+	}
+	if last.FYCID > 0 && !last.DtStop.Before(d2) {
+		err = fmt.Errorf("the fiscal year %s - %s is already closed by %s", last.DtStart.Format(rlib.RRDATEFMT3), last.Dt.Format(rlib.RRDATEFMT3), last.IDtoShortString())
+		return fyc, bizErrSys(&err)
+	}
+
+	//-------------------------------------------------------------
+	// The year before must be closed.  If no year was ever closed
+	// the year before must have nothing to close: its income and
+	// expense accounts are all zero.
+	//-------------------------------------------------------------
+	prev := d1.AddDate(-1, 0, 0)
+	prevErr := fmt.Errorf("the fiscal year %s - %s must be closed first", prev.Format(rlib.RRDATEFMT3), d1.AddDate(0, 0, -1).Format(rlib.RRDATEFMT3))
+	if last.FYCID > 0 && last.DtStop.Before(d1) {
+		return fyc, bizErrSys(&prevErr)
+	}
+	if last.FYCID == 0 {
+		pm, _, err := getClosingLines(ctx, bid, &re, &d1)
+		if err != nil {
+			return fyc, bizErrSys(&err)
+		}
+		if len(pm) > 0 {
+			return fyc, bizErrSys(&prevErr)
+		}
+	}
+
+	lc, err := openClosePeriod(ctx, bid)
+	if err != nil {
+		return fyc, bizErrSys(&err)
+	}
+	if lc.Dt.Before(cdt) {
+		err = fmt.Errorf("the periods must be closed through %s before the fiscal year can be closed", cdt.Format(rlib.RRDATEFMT3))
+		return fyc, bizErrSys(&err)
+	}
+
+	m, net, err := getClosingLines(ctx, bid, &re, &d2)
+	if err != nil {
+		return fyc, bizErrSys(&err)
+	}
+	fyc = rlib.FiscalYearClose{
+		BID:       bid,
+		DtStart:   d1,
+		DtStop:    d2,
+		Dt:        cdt,
+		LID:       re.LID,
+		NetIncome: net,
+	}
+	if _, err = rlib.InsertFiscalYearClose(ctx, &fyc); err != nil {
+		return fyc, bizErrSys(&err)
+	}
+	if len(m) > 0 {
+		comment := fmt.Sprintf("Close of fiscal year %s - %s", d1.Format(rlib.RRDATEFMT3), cdt.Format(rlib.RRDATEFMT3))
+		if fyc.JID, err = postJournal(ctx, bid, rlib.JNLTYPECLOS, fyc.FYCID, &cdt, comment, m); err != nil {
+			return fyc, bizErrSys(&err)
+		}
+		if err = adjustLedgerMarkers(ctx, bid, &cdt, m); err != nil {
+			return fyc, bizErrSys(&err)
+		}
+	}
+	if err = rlib.UpdateFiscalYearClose(ctx, &fyc); err != nil {
+		return fyc, bizErrSys(&err)
+	}
+	return fyc, nil
+}
+
+// ReopenFiscalYear reopens the fiscal year closed by fycid, which must be
+// the last fiscal year closed.  The closing journal is reversed on the same
+// date and the record is marked reopened.
+//
+// INPUTS
+//    ctx    = db context
+//    bid    = business id
+//    fycid  = the fiscal year close
+//    reason = why the year is reopened
+//
+// RETURNS
+//    a list of errors, nil if the year was reopened
+//-----------------------------------------------------------------------------
+func ReopenFiscalYear(ctx context.Context, bid, fycid int64, reason string) []BizError {
+	fyc, err := rlib.GetFiscalYearClose(ctx, fycid)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if fyc.FYCID == 0 || fyc.BID != bid {
+		err = fmt.Errorf("fiscal year close %d not found", fycid)
+		return bizErrSys(&err)
+	}
+	if fyc.IsReopened() {
+		err = fmt.Errorf("the fiscal year closed by %s was already reopened", fyc.IDtoShortString())
+		return bizErrSys(&err)
+	}
+	last, err := rlib.GetLastFiscalYearClose(ctx, bid)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if last.FYCID != fyc.FYCID {
+		err = fmt.Errorf("only the last fiscal year closed (%s) can be reopened", last.IDtoShortString())
+		return bizErrSys(&err)
+	}
+	reason = strings.TrimSpace(reason)
+	if len(reason) == 0 {
+		err = fmt.Errorf("a reason is required to reopen a fiscal year")
+		return bizErrSys(&err)
+	}
+
+	if fyc.JID > 0 {
+		j, err := rlib.GetJournal(ctx, fyc.JID)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		if err = rlib.GetJournalAllocations(ctx, &j); err != nil {
+			return bizErrSys(&err)
+		}
+		var m []jnlLine
+		for i := 0; i < len(j.JA); i++ {
+			le, err := rlib.GetLedgerEntriesByJAID(ctx, bid, j.JA[i].JAID)
+			if err != nil {
+				return bizErrSys(&err)
+			}
+			l := jnlLine{amt: j.JA[i].Amount}
+			for k := 0; k < len(le); k++ {
+				if le[k].Amount > 0 {
+					l.clid = le[k].LID // the debit is reversed by a credit
+				} else {
+					l.dlid = le[k].LID
+				}
+			}
+			if l.dlid == 0 || l.clid == 0 {
+				err = fmt.Errorf("the ledger entries of closing journal %s allocation %d are missing", rlib.IDtoShortString("J", j.JID), j.JA[i].JAID)
+				return bizErrSys(&err)
+			}
+			m = append(m, l)
+		}
+		comment := fmt.Sprintf("Reopen of %s: %s", fyc.IDtoShortString(), reason)
+		if fyc.RevJID, err = postJournal(ctx, bid, rlib.JNLTYPECLOS, fyc.FYCID, &fyc.Dt, comment, m); err != nil {
+			return bizErrSys(&err)
+		}
+		if err = adjustLedgerMarkers(ctx, bid, &fyc.Dt, m); err != nil {
+			return bizErrSys(&err)
+		}
+	}
+	if sess, ok := rlib.SessionFromContext(ctx); ok {
+		fyc.ReopenedBy = sess.UID
+	}
+	fyc.DtReopened = time.Now()
+	fyc.Reason = reason
+	fyc.FLAGS |= rlib.FlFiscalYearReopened
+	if err = rlib.UpdateFiscalYearClose(ctx, &fyc); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
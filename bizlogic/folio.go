@@ -0,0 +1,354 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"time"
+)
+
+// folioAdultAge is the age used to price the adults of a folio
+const folioAdultAge = 18
+
+// OpenFolio checks in the stay of folio f.  f.BID and f.RAID are required;
+// the other fields default from the rental agreement:
+//
+//    RID, RTID - the first rentable of the agreement on the arrival date,
+//                and its rentable type
+//    TCID      - the first payor of the agreement
+//    DtArrive  - the business date
+//    DtDepart  - the end of possession of the agreement
+//    Adults,
+//    Children  - the unspecified adults and children of the agreement
+//
+// The nights before the business date have been audited, so the stay cannot
+// start before it.  The room cannot be in another open folio for any night
+// of the stay.
+//
+// INPUTS
+//    ctx = db context
+//    f   = the folio, FOLID is set when it is saved
+//
+// RETURNS
+//    any error encountered
+//-----------------------------------------------------------------------------
+func OpenFolio(ctx context.Context, f *rlib.Folio) error {
+	ra, err := rlib.GetRentalAgreement(ctx, f.RAID)
+	if err != nil {
+		return err
+	}
+	if ra.RAID == 0 || ra.BID != f.BID {
+		return fmt.Errorf("rental agreement %d not found", f.RAID)
+	}
+	bd, err := rlib.GetBusinessDate(ctx, f.BID)
+	if err != nil {
+		return err
+	}
+
+	//----------------------------------------------------
+	// defaults from the rental agreement
+	//----------------------------------------------------
+	if f.DtArrive.IsZero() {
+		f.DtArrive = bd
+	}
+	if f.DtDepart.IsZero() {
+		f.DtDepart = ra.PossessionStop
+	}
+	f.DtArrive = rlib.DateAtTimeZero(f.DtArrive)
+	f.DtDepart = rlib.DateAtTimeZero(f.DtDepart)
+	if !f.DtArrive.Before(f.DtDepart) {
+		return fmt.Errorf("the stay must end after it starts")
+	}
+	if f.DtArrive.Before(bd) {
+		return fmt.Errorf("the stay cannot start before the business date, %s", bd.Format(rlib.RRDATEFMT4))
+	}
+	if f.RID == 0 {
+		m, err := rlib.GetRentalAgreementRentables(ctx, f.RAID, &f.DtArrive, &f.DtDepart)
+		if err != nil {
+			return err
+		}
+		if len(m) == 0 {
+			return fmt.Errorf("rental agreement %d has no rentable for the stay", f.RAID)
+		}
+		f.RID = m[0].RID
+	}
+	if f.RTID == 0 {
+		rtr, err := rlib.GetRentableTypeRefForDate(ctx, f.RID, &f.DtArrive)
+		if err != nil {
+			return err
+		}
+		f.RTID = rtr.RTID
+	}
+	if f.TCID == 0 {
+		m, err := rlib.GetRentalAgreementPayorsInRange(ctx, f.RAID, &f.DtArrive, &f.DtDepart)
+		if err != nil {
+			return err
+		}
+		if len(m) > 0 {
+			f.TCID = m[0].TCID
+		}
+	}
+	if f.Adults == 0 && f.Children == 0 {
+		f.Adults = ra.UnspecifiedAdults
+		f.Children = ra.UnspecifiedChildren
+	}
+
+	//----------------------------------------------------
+	// the room must be free
+	//----------------------------------------------------
+	m, err := rlib.GetFoliosInRange(ctx, f.BID, &f.DtArrive, &f.DtDepart)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(m); i++ {
+		if m[i].RID == f.RID && m[i].Status == rlib.FolioOpen && m[i].FOLID != f.FOLID {
+			return fmt.Errorf("the rentable is in folio %d from %s to %s", m[i].FOLID, m[i].DtArrive.Format(rlib.RRDATEFMT4), m[i].DtDepart.Format(rlib.RRDATEFMT4))
+		}
+	}
+
+	f.PostedThrough = f.DtArrive
+	f.Status = rlib.FolioOpen
+	_, err = rlib.InsertFolio(ctx, f)
+	return err
+}
+
+// PostFolioCharge posts a charge, an incidental such as a minibar or
+// telephone charge, to open folio folid.  The charge is an assessment of the
+// folio's rental agreement dated on the business date.
+//
+// INPUTS
+//    ctx     = db context
+//    folid   = the folio
+//    arid    = account rule of the charge
+//    amt     = the amount charged
+//    comment = what is charged
+//
+// RETURNS
+//    the assessment
+//    any error encountered
+//-----------------------------------------------------------------------------
+func PostFolioCharge(ctx context.Context, folid, arid int64, amt float64, comment string) (rlib.Assessment, error) {
+	f, err := rlib.GetFolio(ctx, folid)
+	if err != nil {
+		return rlib.Assessment{}, err
+	}
+	if f.FOLID == 0 {
+		return rlib.Assessment{}, fmt.Errorf("folio %d not found", folid)
+	}
+	if f.Status != rlib.FolioOpen {
+		return rlib.Assessment{}, fmt.Errorf("folio %d is closed", folid)
+	}
+	if amt <= 0 {
+		return rlib.Assessment{}, fmt.Errorf("the amount must be greater than 0")
+	}
+	bd, err := rlib.GetBusinessDate(ctx, f.BID)
+	if err != nil {
+		return rlib.Assessment{}, err
+	}
+	lc, err := openClosePeriod(ctx, f.BID)
+	if err != nil {
+		return rlib.Assessment{}, err
+	}
+	return postFolioAssessment(ctx, &f, arid, rlib.RoundToCent(amt), &bd, comment, &lc)
+}
+
+// CloseFolio checks out the stay of folio folid.  A guest who leaves before
+// the departure date departs on the business date; the nights not stayed
+// are not posted.
+//
+// INPUTS
+//    ctx   = db context
+//    folid = the folio
+//
+// RETURNS
+//    the closed folio
+//    any error encountered
+//-----------------------------------------------------------------------------
+func CloseFolio(ctx context.Context, folid int64) (rlib.Folio, error) {
+	f, err := rlib.GetFolio(ctx, folid)
+	if err != nil {
+		return f, err
+	}
+	if f.FOLID == 0 {
+		return f, fmt.Errorf("folio %d not found", folid)
+	}
+	if f.Status != rlib.FolioOpen {
+		return f, fmt.Errorf("folio %d is closed", folid)
+	}
+	bd, err := rlib.GetBusinessDate(ctx, f.BID)
+	if err != nil {
+		return f, err
+	}
+	if bd.Before(f.DtDepart) {
+		f.DtDepart = bd
+		if f.DtDepart.Before(f.PostedThrough) {
+			f.DtDepart = f.PostedThrough
+		}
+	}
+	f.Status = rlib.FolioClosed
+	return f, rlib.UpdateFolio(ctx, &f)
+}
+
+// RunNightAudit audits the business date of business bid.  The room charge
+// of the night, and the taxes on it (see TaxAssessment), are posted to each
+// open folio in house.
+// A folio's room charge is its Rate, or the rate plan quote for the night if
+// it has none.  The night's figures are saved in a NightAudit record, which
+// rolls the business date to the next day.
+//
+// A business date cannot be audited before it starts.
+//
+// INPUTS
+//    ctx = db context, the caller should roll back its transaction if an
+//          error is returned
+//    bid = business id
+//    now = the current time
+//
+// RETURNS
+//    the night audit
+//    any error encountered
+//-----------------------------------------------------------------------------
+func RunNightAudit(ctx context.Context, bid int64, now *time.Time) (rlib.NightAudit, error) {
+	var xbiz rlib.XBusiness
+	var na = rlib.NightAudit{BID: bid}
+
+	p, err := rlib.GetFolioPolicy(ctx, bid, "general")
+	if err != nil {
+		return na, err
+	}
+	if len(p.RoomAR) == 0 {
+		return na, fmt.Errorf("no room charge account rule is set in the folio settings")
+	}
+	rar, err := rlib.GetARByName(ctx, bid, p.RoomAR)
+	if err != nil {
+		return na, err
+	}
+	if rar.ARID == 0 {
+		return na, fmt.Errorf("account rule %q not found", p.RoomAR)
+	}
+
+	if na.Dt, err = rlib.GetBusinessDate(ctx, bid); err != nil {
+		return na, err
+	}
+	if na.Dt.After(*now) {
+		return na, fmt.Errorf("business date %s has not started", na.Dt.Format(rlib.RRDATEFMT4))
+	}
+	if err = rlib.InitBizInternals(bid, &xbiz); err != nil {
+		return na, err
+	}
+	lc, err := openClosePeriod(ctx, bid)
+	if err != nil {
+		return na, err
+	}
+	if na.Rooms, err = countDailyRentables(ctx, &xbiz, &na.Dt); err != nil {
+		return na, err
+	}
+
+	//----------------------------------------------------
+	// the folios arriving, in house or departing
+	//----------------------------------------------------
+	d0 := na.Dt.AddDate(0, 0, -1)
+	next := na.Dt.AddDate(0, 0, 1)
+	m, err := rlib.GetFoliosInRange(ctx, bid, &d0, &next)
+	if err != nil {
+		return na, err
+	}
+	for i := 0; i < len(m); i++ {
+		f := &m[i]
+		if f.DtDepart.Equal(na.Dt) {
+			na.Departures++
+		}
+		if !f.InHouse(&na.Dt) {
+			continue
+		}
+		if f.DtArrive.Equal(na.Dt) {
+			na.Arrivals++
+		}
+		na.Occupied++
+		if f.Status != rlib.FolioOpen || f.PostedThrough.After(na.Dt) {
+			continue
+		}
+
+		rate := f.Rate
+		if rate == 0 {
+			q := rlib.RateQuoteRequest{BID: bid, RTID: f.RTID, DtStart: na.Dt, DtStop: next}
+			for j := int64(0); j < f.Adults+f.Children; j++ {
+				age := int64(0)
+				if j < f.Adults {
+					age = folioAdultAge
+				}
+				q.Ages = append(q.Ages, age)
+			}
+			quote, err := rlib.GetRateQuote(ctx, &xbiz, &q)
+			if err != nil {
+				return na, err
+			}
+			rate = quote.Total
+		}
+		rate = rlib.RoundToCent(rate)
+		night := na.Dt.Format(rlib.RRDATEFMT4)
+		a, err := postFolioAssessment(ctx, f, rar.ARID, rate, &na.Dt, "Room charge, night of "+night, &lc)
+		if err != nil {
+			return na, err
+		}
+		na.RoomRevenue += rate
+		tax, err := TaxAssessment(ctx, &a, &lc)
+		if err != nil {
+			return na, err
+		}
+		na.TaxRevenue += tax
+		f.PostedThrough = next
+		if err = rlib.UpdateFolio(ctx, f); err != nil {
+			return na, err
+		}
+	}
+	na.RoomRevenue = rlib.RoundToCent(na.RoomRevenue)
+	na.TaxRevenue = rlib.RoundToCent(na.TaxRevenue)
+	_, err = rlib.InsertNightAudit(ctx, &na)
+	return na, err
+}
+
+// postFolioAssessment posts a charge of amt on dt to folio f
+//-----------------------------------------------------------------------------
+func postFolioAssessment(ctx context.Context, f *rlib.Folio, arid int64, amt float64, dt *time.Time, comment string, lc *rlib.ClosePeriod) (rlib.Assessment, error) {
+	a := rlib.Assessment{
+		BID:            f.BID,
+		RID:            f.RID,
+		RAID:           f.RAID,
+		AssocElemType:  rlib.ELEMFOLIO,
+		AssocElemID:    f.FOLID,
+		Amount:         amt,
+		Start:          *dt,
+		Stop:           *dt,
+		RentCycle:      rlib.RECURNONE,
+		ProrationCycle: rlib.RECURNONE,
+		ARID:           arid,
+		Comment:        comment,
+	}
+	errlist := InsertAssessment(ctx, &a, 0, lc)
+	if len(errlist) > 0 {
+		return a, BizErrorListToError(errlist)
+	}
+	return a, nil
+}
+
+// countDailyRentables returns the number of rentables of xbiz whose
+// rentable type on dt is rented daily: the rooms of a hotel
+//-----------------------------------------------------------------------------
+func countDailyRentables(ctx context.Context, xbiz *rlib.XBusiness, dt *time.Time) (int64, error) {
+	var n int64
+	m, err := rlib.GetRentablesByBusiness(ctx, xbiz.P.BID)
+	if err != nil {
+		return n, err
+	}
+	for i := 0; i < len(m); i++ {
+		rtr, err := rlib.GetRentableTypeRefForDate(ctx, m[i].RID, dt)
+		if err != nil {
+			return n, err
+		}
+		if rt, ok := xbiz.RT[rtr.RTID]; ok && rt.RentCycle == rlib.RECURDAILY {
+			n++
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,173 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"time"
+)
+
+// AssessLateFees applies the late fee policy of business bid as of date dt.
+// Every unpaid assessment covered by the policy whose grace period has
+// expired on or before dt receives one late fee assessment. The late fee is
+// bound to the late assessment through AssocElemType = ELEMASSESSMENT and
+// AssocElemID = ASMID of the late assessment. That binding is what makes the
+// process idempotent: an assessment that already has a late fee (even one
+// that was later reversed) is never charged again, so the routine can be
+// run as often as needed for any period.
+//
+// Only the assessments that became late within the policy's lookback (see
+// LateFeeLookback) are charged, so the first run does not charge a late fee
+// on every unpaid assessment in the history of the business.
+//
+// The late fee is dated on the first day after the grace period. If that
+// date falls in a closed period, InsertAssessment snaps it to the open period.
+//
+// INPUTS
+//    ctx = db context, the caller should roll back its transaction if an
+//          error is returned
+//    bid = business id
+//    dt  = the date on which to evaluate lateness
+//
+// RETURNS
+//    the number of late fee assessments created
+//    any error encountered
+//-----------------------------------------------------------------------------
+func AssessLateFees(ctx context.Context, bid int64, dt *time.Time) (int, error) {
+	var err error
+	count := 0
+
+	p, err := rlib.GetLateFeePolicy(ctx, bid, "general")
+	if err != nil {
+		return count, err
+	}
+	if !p.Enabled {
+		return count, nil
+	}
+
+	lfar, err := rlib.GetARByName(ctx, bid, p.ARName)
+	if err != nil {
+		return count, err
+	}
+	if lfar.ARID == 0 {
+		return count, fmt.Errorf("late fee account rule %q not found in business %d", p.ARName, bid)
+	}
+
+	arids, err := lateFeeARIDs(ctx, bid, &p)
+	if err != nil {
+		return count, err
+	}
+	arids[lfar.ARID] = false // never charge a late fee on a late fee
+
+	lc, err := openClosePeriod(ctx, bid)
+	if err != nil {
+		return count, err
+	}
+
+	start := p.LateFeeLookback(dt)
+	d1 := start.AddDate(0, 0, -int(p.GraceDays)-1) // earliest Start of an assessment late on start
+	d2 := dt.AddDate(0, 0, 1)
+	m, err := rlib.GetRentalAgreementsByRange(ctx, bid, &d1, &d2)
+	if err != nil {
+		return count, err
+	}
+
+	for i := 0; i < len(m); i++ {
+		n, err := rlib.GetUnpaidAssessmentsByRAID(ctx, m[i].RAID)
+		if err != nil {
+			return count, err
+		}
+		for j := 0; j < len(n); j++ {
+			if !arids[n[j].ARID] {
+				continue
+			}
+			if n[j].FLAGS&3 == 3 { // offsets are not paid, so they cannot be late
+				continue
+			}
+			lateDt := p.LateFeeDate(&n[j].Start)
+			if lateDt.After(*dt) {
+				continue // still in the grace period
+			}
+			if lateDt.Before(start) {
+				continue // late before the lookback
+			}
+
+			//----------------------------------------------------
+			// if a late fee already exists, we're done with it
+			//----------------------------------------------------
+			b, err := rlib.GetAssessmentByAssocElem(ctx, bid, rlib.ELEMASSESSMENT, n[j].ASMID)
+			if err != nil {
+				return count, err
+			}
+			if b.ASMID > 0 {
+				continue
+			}
+
+			unpaid := AssessmentUnpaidPortion(ctx, &n[j])
+			fee := p.LateFeeAmount(unpaid)
+			if fee <= 0 {
+				continue
+			}
+
+			a := rlib.Assessment{
+				BID:            bid,
+				RID:            n[j].RID,
+				RAID:           n[j].RAID,
+				AssocElemType:  rlib.ELEMASSESSMENT,
+				AssocElemID:    n[j].ASMID,
+				Amount:         fee,
+				Start:          lateDt,
+				Stop:           lateDt,
+				RentCycle:      rlib.RECURNONE,
+				ProrationCycle: rlib.RECURNONE,
+				ARID:           lfar.ARID,
+				Comment:        fmt.Sprintf("Late fee for ASM-%d, unpaid amount %s", n[j].ASMID, rlib.RRCommaf(unpaid)),
+			}
+			errlist := InsertAssessment(ctx, &a, 0, &lc)
+			if len(errlist) > 0 {
+				return count, BizErrorListToError(errlist)
+			}
+			count++
+		}
+	}
+	return count, nil
+}
+
+// lateFeeARIDs returns a map of the ARIDs to which the late fee policy
+// applies. If the policy does not list any Account Rules, all Account Rules
+// flagged as Rent assessments are used.
+//
+// INPUTS
+//    ctx = db context
+//    bid = business id
+//    p   = the late fee policy
+//
+// RETURNS
+//    map of ARID to true for each ARID covered by the policy
+//    any error encountered
+//-----------------------------------------------------------------------------
+func lateFeeARIDs(ctx context.Context, bid int64, p *rlib.BizPropsLateFee) (map[int64]bool, error) {
+	var m = map[int64]bool{}
+	if len(p.AppliesTo) == 0 {
+		n, err := rlib.GetARsByFLAGS(ctx, bid, 1<<rlib.ARIsRentASM)
+		if err != nil {
+			return m, err
+		}
+		for i := 0; i < len(n); i++ {
+			m[n[i].ARID] = true
+		}
+		return m, nil
+	}
+
+	for i := 0; i < len(p.AppliesTo); i++ {
+		ar, err := rlib.GetARByName(ctx, bid, p.AppliesTo[i])
+		if err != nil {
+			return m, err
+		}
+		if ar.ARID == 0 {
+			return m, fmt.Errorf("late fee policy references unknown account rule %q", p.AppliesTo[i])
+		}
+		m[ar.ARID] = true
+	}
+	return m, nil
+}
@@ -0,0 +1,209 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"time"
+)
+
+// ConvertMonthToMonth extends the rental agreements of business bid that
+// are set for month to month renewal and whose AgreementStop is on or
+// before dt.  Each one is extended by a month.  When an agreement first goes
+// month to month, its recurring rent assessments are replaced by new ones
+// that include the business's month to month premium, and its RAMonthToMonth
+// flag is set.  After that its recurring assessments are simply extended.
+// Each extension is recorded in the note list of the agreement.
+//
+// Moving AgreementStop past dt is what makes the process idempotent, so the
+// routine can be run as often as needed.  There is no limit on how long ago
+// an agreement stopped: if the routine has not run for a while, each agreement
+// is caught up one month at a time, and the recurring charges of the months
+// already past are created (see rlib.MonthToMonthTerms).
+//
+// INPUTS
+//    ctx = db context
+//    bid = business id
+//    dt  = agreements that stop on or before this date are extended
+//
+// RETURNS
+//    the number of month to month extensions made
+//    any error encountered
+//-----------------------------------------------------------------------------
+func ConvertMonthToMonth(ctx context.Context, bid int64, dt *time.Time) (int, error) {
+	count := 0
+
+	p, err := rlib.GetMonthToMonthPolicy(ctx, bid, "general")
+	if err != nil {
+		return count, err
+	}
+	if !p.Enabled {
+		return count, nil
+	}
+	arids, err := rentARIDs(ctx, bid)
+	if err != nil {
+		return count, err
+	}
+
+	lc, err := openClosePeriod(ctx, bid)
+	if err != nil {
+		return count, err
+	}
+	var xbiz rlib.XBusiness
+	if err = rlib.GetXBusiness(ctx, bid, &xbiz); err != nil {
+		return count, err
+	}
+
+	d2 := dt.AddDate(0, 0, 1)
+	m, err := rlib.GetMonthToMonthRentalAgreements(ctx, bid, &d2)
+	if err != nil {
+		return count, err
+	}
+	for i := 0; i < len(m); i++ {
+		terms := rlib.MonthToMonthTerms(&m[i].AgreementStop, dt)
+		for j := 0; j < len(terms); j++ {
+			if err = extendMonthToMonth(ctx, &m[i], &p, arids, &terms[j], &xbiz, &lc); err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+	return count, nil
+}
+
+// extendMonthToMonth extends ra by term, the month after its
+// AgreementStop.  Its rentables, payors, users, lease status and recurring
+// assessments that stop with the agreement are extended with it.  The
+// instances of the recurring assessments due in term.DtStart - term.ChargeStop
+// are created, the assessment bot only creates those of the current month.
+//
+// INPUTS
+//    ctx   = db context
+//    ra    = the rental agreement, it is updated
+//    p     = the month to month settings
+//    arids = ARIDs of the rent assessments
+//    term  = the month of the extension
+//    xbiz  = the business
+//    lc    = the last close period
+//
+// RETURNS
+//    any error encountered
+//-----------------------------------------------------------------------------
+func extendMonthToMonth(ctx context.Context, ra *rlib.RentalAgreement, p *rlib.BizPropsMonthToMonth, arids map[int64]bool, term *rlib.MonthToMonthTerm, xbiz *rlib.XBusiness, lc *rlib.ClosePeriod) error {
+	d1 := term.DtStart.AddDate(0, 0, -1) // the last day of the term
+	d2 := term.DtStart
+	d3 := term.DtStop
+	convert := ra.FLAGS&rlib.RAMonthToMonth == 0
+	var rents []string
+
+	//--------------------------------------------------------
+	// Recurring assessments that stop with the agreement
+	//--------------------------------------------------------
+	m, err := rlib.GetRecurringAssessmentDefsByRAID(ctx, ra.RAID, &d1, &d2)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(m); i++ {
+		if m[i].FLAGS&rlib.ASMREVERSED != 0 || m[i].Stop.After(d2) {
+			continue
+		}
+		if !convert || !arids[m[i].ARID] {
+			m[i].Stop = d3
+			if err = rlib.UpdateAssessment(ctx, &m[i]); err != nil {
+				return err
+			}
+			if err = rlib.ExpandAssessment(ctx, &m[i], xbiz, &term.DtStart, &term.ChargeStop, true, lc); err != nil {
+				return err
+			}
+			continue
+		}
+		a := m[i]
+		a.ASMID = 0
+		a.FLAGS &= ^uint64(7) // not paid, not reversed
+		a.Start = d2
+		a.Stop = d3
+		a.Amount = p.MonthToMonthRent(m[i].Amount)
+		a.Comment = fmt.Sprintf("Month to month rent from %s (ASM-%d)", rlib.RRCommaf(m[i].Amount), m[i].ASMID)
+		xlc := *lc // InsertAssessment changes the expansion dates
+		if errlist := InsertAssessment(ctx, &a, 1, &xlc); len(errlist) > 0 {
+			return BizErrorListToError(errlist)
+		}
+		rents = append(rents, fmt.Sprintf("rent %s is now %s", rlib.RRCommaf(m[i].Amount), rlib.RRCommaf(a.Amount)))
+	}
+
+	//--------------------------------------------------------
+	// Rentables, their users and lease status
+	//--------------------------------------------------------
+	n, err := rlib.GetRentalAgreementRentables(ctx, ra.RAID, &d1, &d2)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(n); i++ {
+		if n[i].RARDtStop.After(d2) {
+			continue
+		}
+		n[i].RARDtStop = d3
+		if err = rlib.UpdateRentalAgreementRentable(ctx, &n[i]); err != nil {
+			return err
+		}
+		if err = rlib.SetRentableLeaseStatusAbbr(ctx, ra.BID, n[i].RID, rlib.LEASESTATUSleased, &d2, &d3, false); err != nil {
+			return err
+		}
+		u, err := rlib.GetRentableUsersInRange(ctx, n[i].RID, &d1, &d2)
+		if err != nil {
+			return err
+		}
+		for j := 0; j < len(u); j++ {
+			if u[j].DtStop.After(d2) {
+				continue
+			}
+			u[j].DtStop = d3
+			if err = rlib.UpdateRentableUser(ctx, &u[j]); err != nil {
+				return err
+			}
+		}
+	}
+
+	//--------------------------------------------------------
+	// Payors
+	//--------------------------------------------------------
+	t, err := rlib.GetRentalAgreementPayorsInRange(ctx, ra.RAID, &d1, &d2)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(t); i++ {
+		if t[i].DtStop.After(d2) {
+			continue
+		}
+		t[i].DtStop = d3
+		if err = rlib.UpdateRentalAgreementPayor(ctx, &t[i]); err != nil {
+			return err
+		}
+	}
+
+	//--------------------------------------------------------
+	// The agreement itself
+	//--------------------------------------------------------
+	ra.AgreementStop = d3
+	if !ra.RentStop.After(d2) {
+		ra.RentStop = d3
+	}
+	if !ra.PossessionStop.After(d2) {
+		ra.PossessionStop = d3
+	}
+	s := fmt.Sprintf("Month to month: extended to %s", d3.Format(rlib.RRDATEFMT3))
+	if convert {
+		s = fmt.Sprintf("Month to month: the term ended on %s, extended to %s", d2.Format(rlib.RRDATEFMT3), d3.Format(rlib.RRDATEFMT3))
+		for i := 0; i < len(rents); i++ {
+			s += ", " + rents[i]
+		}
+	}
+	rlib.Ulog("MonthToMonthBot: RA-%d %s\n", ra.RAID, s)
+	note := rlib.Note{BID: ra.BID, NLID: ra.NLID, RAID: ra.RAID, Comment: s}
+	if err = addNote(ctx, &note); err != nil {
+		return err
+	}
+	ra.NLID = note.NLID
+	ra.FLAGS |= rlib.RAMonthToMonth
+	return rlib.UpdateRentalAgreement(ctx, ra)
+}
@@ -0,0 +1,310 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"strings"
+	"time"
+)
+
+// ProcessOTAHotelResNotif books, modifies or cancels the reservations of
+// OpenTravel message rq sent to business bid.  The sender must be one of
+// the requestors of the business's ARI settings.  Each reservation is
+// identified by the channel's confirmation number, its UniqueID; a
+// reservation already booked is not booked again and one already cancelled
+// is not cancelled again, so a channel can safely resend a message.
+//
+// The message is processed as a whole: if any reservation fails, the error
+// is returned and the caller should roll back the transaction in ctx.
+//
+// INPUTS
+//    ctx = db context with a transaction
+//    bid = business id
+//    rq  = the message
+//
+// RETURNS
+//    the reservations processed, with the ids to acknowledge
+//    any error encountered, an *rlib.OTAError if it should be reported
+//    as such to the sender
+//-----------------------------------------------------------------------------
+func ProcessOTAHotelResNotif(ctx context.Context, bid int64, rq *rlib.OTAHotelResNotifRQ) ([]rlib.OTAHotelReservationRS, error) {
+	var m []rlib.OTAHotelReservationRS
+	var xbiz rlib.XBusiness
+
+	p, err := rlib.GetARIPolicy(ctx, bid, "general")
+	if err != nil {
+		return m, err
+	}
+	if !p.IsRequestor(rq.RequestorID.ID, rq.RequestorID.MessagePassword) {
+		return m, rlib.NewOTAError(rlib.OTAErrTypeAuth, rlib.OTAErrAuthorization, "requestor %q is not authorized", rq.RequestorID.ID)
+	}
+	if len(rq.HotelReservations) == 0 {
+		return m, rlib.NewOTAError(rlib.OTAErrTypeRequired, rlib.OTAErrRequired, "no HotelReservation")
+	}
+	if err = rlib.InitBizInternals(bid, &xbiz); err != nil {
+		return m, err
+	}
+
+	for i := 0; i < len(rq.HotelReservations); i++ {
+		r := &rq.HotelReservations[i]
+		action, err := rq.Action(r)
+		if err != nil {
+			return m, err
+		}
+		if len(r.UniqueID.ID) == 0 {
+			return m, rlib.NewOTAError(rlib.OTAErrTypeRequired, rlib.OTAErrRequired, "the reservation has no UniqueID")
+		}
+		o, err := rlib.GetOTAReservationByResID(ctx, bid, rq.RequestorID.ID, r.UniqueID.ID)
+		if err != nil {
+			return m, err
+		}
+
+		switch action {
+		case "Book":
+			if o.ORID == 0 {
+				err = bookOTAReservation(ctx, &xbiz, &p, rq.RequestorID.ID, r, &o)
+			} else if o.Status == rlib.OTAReservationCancelled {
+				err = rlib.NewOTAError(rlib.OTAErrTypeBizRule, rlib.OTAErrCancelled, "reservation %s was cancelled", r.UniqueID.ID)
+			}
+		case "Modify":
+			switch {
+			case o.ORID == 0:
+				err = rlib.NewOTAError(rlib.OTAErrTypeBizRule, rlib.OTAErrResNotFound, "reservation %s not found", r.UniqueID.ID)
+			case o.Status == rlib.OTAReservationCancelled:
+				err = rlib.NewOTAError(rlib.OTAErrTypeBizRule, rlib.OTAErrCancelled, "reservation %s was cancelled", r.UniqueID.ID)
+			default:
+				err = modifyOTAReservation(ctx, &xbiz, &p, r, &o)
+			}
+		case "Cancel":
+			if o.ORID == 0 {
+				err = rlib.NewOTAError(rlib.OTAErrTypeBizRule, rlib.OTAErrResNotFound, "reservation %s not found", r.UniqueID.ID)
+			} else if o.Status != rlib.OTAReservationCancelled {
+				err = cancelOTAReservation(ctx, &o)
+			}
+		}
+		if err != nil {
+			return m, err
+		}
+		m = append(m, rlib.OTAHotelReservationRS{
+			ResStatus:           action,
+			UniqueID:            r.UniqueID,
+			HotelReservationIDs: []rlib.OTAHotelReservationID{{ResIDType: rlib.OTAUIDHotel, ResIDValue: o.ConfirmationCode}},
+		})
+	}
+	return m, nil
+}
+
+// otaStay is the rentable type, dates and guest of a reservation
+type otaStay struct {
+	RTID    int64
+	DtStart time.Time
+	DtStop  time.Time
+	Guest   rlib.OTACustomer
+}
+
+// getOTAStay validates the room stay of reservation r and returns it.
+// RoomTypeCode must be the InvTypeCode of a rentable type of xbiz, as
+// exported in the ARI feed, and HotelCode, if set, must be the HotelCode of
+// the business.
+//-----------------------------------------------------------------------------
+func getOTAStay(xbiz *rlib.XBusiness, p *rlib.BizPropsARI, r *rlib.OTAHotelReservation) (otaStay, error) {
+	var s otaStay
+	var err error
+	if len(r.RoomStays) != 1 {
+		return s, rlib.NewOTAError(rlib.OTAErrTypeBizRule, rlib.OTAErrUnableToProc, "reservation %s must have exactly one RoomStay", r.UniqueID.ID)
+	}
+	rs := &r.RoomStays[0]
+	if hc := rs.BasicPropertyInfo.HotelCode; len(hc) > 0 && len(p.HotelCode) > 0 && hc != p.HotelCode {
+		return s, rlib.NewOTAError(rlib.OTAErrTypeBizRule, rlib.OTAErrHotelCode, "unknown HotelCode: %s", hc)
+	}
+	if len(rs.RoomTypes) == 0 || len(rs.RoomTypes[0].RoomTypeCode) == 0 {
+		return s, rlib.NewOTAError(rlib.OTAErrTypeRequired, rlib.OTAErrRequired, "reservation %s has no RoomTypeCode", r.UniqueID.ID)
+	}
+	code := rs.RoomTypes[0].RoomTypeCode
+	for rtid, rt := range xbiz.RT {
+		if strings.EqualFold(rt.Style, code) {
+			s.RTID = rtid
+			break
+		}
+	}
+	if s.RTID == 0 {
+		return s, rlib.NewOTAError(rlib.OTAErrTypeBizRule, rlib.OTAErrRoomType, "unknown RoomTypeCode: %s", code)
+	}
+	if s.DtStart, err = rlib.ParseOTADate(rs.TimeSpan.Start); err != nil {
+		return s, err
+	}
+	if s.DtStop, err = rlib.ParseOTADate(rs.TimeSpan.End); err != nil {
+		return s, err
+	}
+	if !s.DtStart.Before(s.DtStop) {
+		return s, rlib.NewOTAError(rlib.OTAErrTypeBizRule, rlib.OTAErrInvalidDate, "departure %s is not after arrival %s", rs.TimeSpan.End, rs.TimeSpan.Start)
+	}
+	if len(r.ResGuests) > 0 {
+		s.Guest = r.ResGuests[0].Customer
+	}
+	return s, nil
+}
+
+// reserveOTAStay reserves a rentable for stay s under confirmation code
+// conf.  Rentable prefer is used if it is available, otherwise the first
+// rentable of the type that is.
+//
+// RETURNS
+//    the rentable reserved
+//    any error encountered
+//-----------------------------------------------------------------------------
+func reserveOTAStay(ctx context.Context, bid int64, s *otaStay, conf, comment string, prefer int64) (int64, error) {
+	m, err := rlib.GetAvailableRentables(ctx, bid, s.RTID, &s.DtStart, &s.DtStop)
+	if err != nil {
+		return 0, err
+	}
+	for i := 0; i < len(m); i++ {
+		if m[i] == prefer {
+			m[0], m[i] = m[i], m[0]
+			break
+		}
+	}
+	rid := int64(0)
+	for i := 0; i < len(m) && rid == 0; i++ {
+		ok, err := isRentableFree(ctx, m[i], &s.DtStart, &s.DtStop)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			rid = m[i]
+		}
+	}
+	if rid == 0 {
+		return 0, rlib.NewOTAError(rlib.OTAErrTypeBizRule, rlib.OTAErrNoAvail, "no availability from %s to %s", s.DtStart.Format(rlib.RRDATEFMTSQL), s.DtStop.Format(rlib.RRDATEFMTSQL))
+	}
+
+	var phone string
+	if len(s.Guest.Telephone) > 0 {
+		phone = s.Guest.Telephone[0].PhoneNumber
+	}
+	var rls = rlib.RentableLeaseStatus{
+		RID:              rid,
+		BID:              bid,
+		DtStart:          s.DtStart,
+		DtStop:           s.DtStop,
+		LeaseStatus:      rlib.LEASESTATUSreserved,
+		Comment:          comment,
+		FirstName:        s.Guest.GivenName,
+		LastName:         s.Guest.Surname,
+		Email:            s.Guest.Email,
+		Phone:            phone,
+		Address:          s.Guest.AddressLine,
+		City:             s.Guest.CityName,
+		State:            s.Guest.StateProv,
+		PostalCode:       s.Guest.PostalCode,
+		Country:          s.Guest.CountryName,
+		ConfirmationCode: conf,
+	}
+	return rid, rlib.SetRentableLeaseStatus(ctx, &rls, false)
+}
+
+// isRentableFree returns true if rentable rid is neither leased nor
+// reserved and is ready for use during d1 - d2
+//-----------------------------------------------------------------------------
+func isRentableFree(ctx context.Context, rid int64, d1, d2 *time.Time) (bool, error) {
+	ls, err := rlib.GetRentableLeaseStatusByRange(ctx, rid, d1, d2)
+	if err != nil {
+		return false, err
+	}
+	for i := 0; i < len(ls); i++ {
+		if ls[i].LeaseStatus != rlib.LEASESTATUSnotleased {
+			return false, nil
+		}
+	}
+	us, err := rlib.GetRentableUseStatusByRange(ctx, rid, d1, d2)
+	if err != nil {
+		return false, err
+	}
+	for i := 0; i < len(us); i++ {
+		if us[i].UseStatus != rlib.USESTATUSready && us[i].DtStop.After(*d1) {
+			return false, nil
+		}
+	}
+	return len(ls) > 0, nil
+}
+
+// releaseOTAReservation makes the rentable of reservation o not leased
+// again for the dates o reserved
+//-----------------------------------------------------------------------------
+func releaseOTAReservation(ctx context.Context, o *rlib.OTAReservation) error {
+	return rlib.SetRentableLeaseStatusAbbr(ctx, o.BID, o.RID, rlib.LEASESTATUSnotleased, &o.DtStart, &o.DtStop, false)
+}
+
+// bookOTAReservation books reservation r for channel requestor and saves
+// it in o
+//-----------------------------------------------------------------------------
+func bookOTAReservation(ctx context.Context, xbiz *rlib.XBusiness, p *rlib.BizPropsARI, requestor string, r *rlib.OTAHotelReservation, o *rlib.OTAReservation) error {
+	s, err := getOTAStay(xbiz, p, r)
+	if err != nil {
+		return err
+	}
+	*o = rlib.OTAReservation{
+		BID:              xbiz.P.BID,
+		RequestorID:      requestor,
+		ResID:            r.UniqueID.ID,
+		RTID:             s.RTID,
+		DtStart:          s.DtStart,
+		DtStop:           s.DtStop,
+		ConfirmationCode: rlib.GenerateUserRefNo(),
+		Status:           rlib.OTAReservationBooked,
+	}
+	if o.RID, err = reserveOTAStay(ctx, o.BID, &s, o.ConfirmationCode, otaComment(o), 0); err != nil {
+		return err
+	}
+	if _, err = rlib.InsertOTAReservation(ctx, o); err != nil {
+		return err
+	}
+	rlib.Ulog("ProcessOTAHotelResNotif: %s booked %s, RID %d, %s\n", o.RequestorID, o.ResID, o.RID, rlib.ConsoleDRange(&o.DtStart, &o.DtStop))
+	return nil
+}
+
+// modifyOTAReservation changes reservation o to the stay of r.  Its
+// rentable is kept if it is still available.
+//-----------------------------------------------------------------------------
+func modifyOTAReservation(ctx context.Context, xbiz *rlib.XBusiness, p *rlib.BizPropsARI, r *rlib.OTAHotelReservation, o *rlib.OTAReservation) error {
+	s, err := getOTAStay(xbiz, p, r)
+	if err != nil {
+		return err
+	}
+	if err = releaseOTAReservation(ctx, o); err != nil {
+		return err
+	}
+	prefer := int64(0)
+	if s.RTID == o.RTID {
+		prefer = o.RID
+	}
+	if o.RID, err = reserveOTAStay(ctx, o.BID, &s, o.ConfirmationCode, otaComment(o), prefer); err != nil {
+		return err
+	}
+	o.RTID, o.DtStart, o.DtStop = s.RTID, s.DtStart, s.DtStop
+	if err = rlib.UpdateOTAReservation(ctx, o); err != nil {
+		return err
+	}
+	rlib.Ulog("ProcessOTAHotelResNotif: %s modified %s, RID %d, %s\n", o.RequestorID, o.ResID, o.RID, rlib.ConsoleDRange(&o.DtStart, &o.DtStop))
+	return nil
+}
+
+// cancelOTAReservation cancels reservation o
+//-----------------------------------------------------------------------------
+func cancelOTAReservation(ctx context.Context, o *rlib.OTAReservation) error {
+	if err := releaseOTAReservation(ctx, o); err != nil {
+		return err
+	}
+	o.Status = rlib.OTAReservationCancelled
+	if err := rlib.UpdateOTAReservation(ctx, o); err != nil {
+		return err
+	}
+	rlib.Ulog("ProcessOTAHotelResNotif: %s cancelled %s\n", o.RequestorID, o.ResID)
+	return nil
+}
+
+// otaComment returns the comment of the lease status of reservation o
+func otaComment(o *rlib.OTAReservation) string {
+	return fmt.Sprintf("%s reservation %s", o.RequestorID, o.ResID)
+}
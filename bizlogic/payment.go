@@ -381,3 +381,23 @@ func AutoAllocatePayorReceipts(ctx context.Context, tcid int64, dt *time.Time) e
 
 	return nil
 }
+
+// getPaymentTypeByName returns the PaymentType called name in business bid.
+// If it does not exist it is created with the description descr.
+//-----------------------------------------------------------------------------
+func getPaymentTypeByName(ctx context.Context, bid int64, name, descr string) (rlib.PaymentType, error) {
+	var pmt rlib.PaymentType
+	if err := rlib.GetPaymentTypeByName(ctx, bid, name, &pmt); err != nil {
+		return pmt, err
+	}
+	if pmt.PMTID > 0 {
+		return pmt, nil
+	}
+	pmt = rlib.PaymentType{
+		BID:         bid,
+		Name:        name,
+		Description: descr,
+	}
+	_, err := rlib.InsertPaymentType(ctx, &pmt)
+	return pmt, err
+}
@@ -0,0 +1,329 @@
+package bizlogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"rentroll/rlib"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TenantMinPasswordLength is the minimum length of a tenant portal password
+const TenantMinPasswordLength = 8
+
+// ErrTenantLogin is returned for every failed tenant login so that the
+// response does not tell whether the username exists or the login is locked
+var ErrTenantLogin = errors.New("invalid username or password")
+
+// ValidateTenantLogin checks the portal login tl before it is saved. A new
+// login must have a password.
+//
+// INPUTS
+//    ctx      = db context
+//    tl       = the login
+//    password = the new password, "" to keep the current one
+//
+// RETURNS
+//    a list of errors, nil if the login is valid
+//-----------------------------------------------------------------------------
+func ValidateTenantLogin(ctx context.Context, tl *rlib.TenantLogin, password string) []BizError {
+	var errlist []BizError
+	fields := []string{}
+
+	tl.Username = strings.TrimSpace(tl.Username)
+	if len(tl.Username) == 0 {
+		fields = append(fields, "Username")
+	}
+	if (tl.TLID == 0 || len(password) > 0) && len(password) < TenantMinPasswordLength {
+		fields = append(fields, fmt.Sprintf("Password (at least %d characters)", TenantMinPasswordLength))
+	}
+	var t rlib.Transactant
+	if err := rlib.GetTransactant(ctx, tl.TCID, &t); err != nil {
+		return AddErrToBizErrlist(err, errlist)
+	}
+	if t.TCID == 0 || t.BID != tl.BID {
+		fields = append(fields, "TCID")
+	}
+	if len(fields) > 0 {
+		msg := BizErrors[InvalidField].Message
+		for i := 0; i < len(fields); i++ {
+			msg += fmt.Sprintf("\n%s", fields[i])
+		}
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: msg})
+		return errlist
+	}
+
+	dup, err := rlib.GetTenantLoginByUsername(ctx, tl.BID, tl.Username)
+	if err != nil {
+		return AddErrToBizErrlist(err, errlist)
+	}
+	if dup.TLID > 0 && dup.TLID != tl.TLID {
+		errlist = AddBizErrToList(errlist, DuplicateName)
+	}
+	dup, err = rlib.GetTenantLoginByTCID(ctx, tl.BID, tl.TCID)
+	if err != nil {
+		return AddErrToBizErrlist(err, errlist)
+	}
+	if dup.TLID > 0 && dup.TLID != tl.TLID {
+		err = fmt.Errorf("%s already has a portal login", t.GetFullTransactantName())
+		errlist = AddErrToBizErrlist(err, errlist)
+	}
+	return errlist
+}
+
+// SaveTenantLogin inserts or updates the portal login tl. If password is
+// supplied it replaces the current one and unlocks the login.
+//
+// INPUTS
+//    ctx      = db context
+//    tl       = the login, TLID 0 to add a new one
+//    password = the new password, "" to keep the current one
+//
+// RETURNS
+//    a list of errors, nil if the login was saved
+//-----------------------------------------------------------------------------
+func SaveTenantLogin(ctx context.Context, tl *rlib.TenantLogin, password string) []BizError {
+	var err error
+	if errlist := ValidateTenantLogin(ctx, tl, password); len(errlist) > 0 {
+		return errlist
+	}
+
+	if tl.TLID > 0 {
+		old, err := rlib.GetTenantLogin(ctx, tl.TLID)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		if old.TLID == 0 || old.BID != tl.BID {
+			err = fmt.Errorf("tenant login %d not found", tl.TLID)
+			return bizErrSys(&err)
+		}
+		tl.PasswordHash = old.PasswordHash
+		tl.FailedLogins = old.FailedLogins
+		tl.LastLogin = old.LastLogin
+	}
+	if len(password) > 0 {
+		if tl.PasswordHash, err = rlib.HashPassword(password); err != nil {
+			return bizErrSys(&err)
+		}
+		tl.FailedLogins = 0
+	}
+
+	if tl.TLID == 0 {
+		_, err = rlib.InsertTenantLogin(ctx, tl)
+	} else {
+		err = rlib.UpdateTenantLogin(ctx, tl)
+	}
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// AuthenticateTenant checks the username and password of a tenant portal
+// login of business bid.  Failed attempts are counted and the login is
+// locked after rlib.TenantMaxFailedLogins of them until the property
+// manager sets a new password.
+//
+// INPUTS
+//    ctx      = db context
+//    bid      = business id
+//    username = the login name
+//    password = the password
+//
+// RETURNS
+//    the login
+//    ErrTenantLogin if the login failed, or any other error encountered
+//-----------------------------------------------------------------------------
+func AuthenticateTenant(ctx context.Context, bid int64, username, password string) (rlib.TenantLogin, error) {
+	tl, err := rlib.GetTenantLoginByUsername(ctx, bid, strings.TrimSpace(username))
+	if err != nil {
+		return tl, err
+	}
+	if tl.TLID == 0 {
+		return tl, ErrTenantLogin
+	}
+	if tl.FLAGS&rlib.FlTenantLoginDisabled != 0 || tl.FailedLogins >= rlib.TenantMaxFailedLogins {
+		return tl, ErrTenantLogin
+	}
+	if !rlib.CheckPassword(tl.PasswordHash, password) {
+		tl.FailedLogins++
+		if err = rlib.UpdateTenantLogin(ctx, &tl); err != nil {
+			return tl, err
+		}
+		if tl.FailedLogins >= rlib.TenantMaxFailedLogins {
+			rlib.Ulog("tenant login %s (TLID %d) locked after %d failed logins\n", tl.Username, tl.TLID, tl.FailedLogins)
+		}
+		return tl, ErrTenantLogin
+	}
+	tl.FailedLogins = 0
+	tl.LastLogin = time.Now()
+	err = rlib.UpdateTenantLogin(ctx, &tl)
+	return tl, err
+}
+
+// TenantRentalAgreements returns the rental agreements of business bid for
+// which transactant tcid is, or has been, a payor.  These are the only
+// agreements a tenant can see in the portal.
+//
+// INPUTS
+//    ctx  = db context
+//    bid  = business id
+//    tcid = the tenant
+//
+// RETURNS
+//    the rental agreements, ordered by RAID
+//    any error encountered
+//-----------------------------------------------------------------------------
+func TenantRentalAgreements(ctx context.Context, bid, tcid int64) ([]rlib.RentalAgreement, error) {
+	var m []rlib.RentalAgreement
+	p, err := rlib.GetRentalAgreementsByPayor(ctx, bid, tcid)
+	if err != nil {
+		return m, err
+	}
+	seen := map[int64]bool{}
+	for i := 0; i < len(p); i++ {
+		if seen[p[i].RAID] {
+			continue
+		}
+		seen[p[i].RAID] = true
+		ra, err := rlib.GetRentalAgreement(ctx, p[i].RAID)
+		if err != nil {
+			return m, err
+		}
+		if ra.RAID == 0 || ra.BID != bid {
+			continue
+		}
+		m = append(m, ra)
+	}
+	sort.Slice(m, func(i, j int) bool { return m[i].RAID < m[j].RAID })
+	return m, nil
+}
+
+// TenantCanAccessRAID returns true if transactant tcid is, or has been, a
+// payor of rental agreement raid in business bid.
+//
+// INPUTS
+//    ctx  = db context
+//    bid  = business id
+//    tcid = the tenant
+//    raid = the rental agreement
+//
+// RETURNS
+//    true if the tenant can see the rental agreement
+//    any error encountered
+//-----------------------------------------------------------------------------
+func TenantCanAccessRAID(ctx context.Context, bid, tcid, raid int64) (bool, error) {
+	if raid <= 0 {
+		return false, nil
+	}
+	p, err := rlib.GetRentalAgreementsByPayor(ctx, bid, tcid)
+	if err != nil {
+		return false, err
+	}
+	for i := 0; i < len(p); i++ {
+		if p[i].RAID == raid {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// TenantCanAccessReceipt returns true if transactant tcid paid receipt r or
+// if r was received for, or allocated to, one of the tenant's rental
+// agreements.
+//
+// INPUTS
+//    ctx  = db context
+//    bid  = business id
+//    tcid = the tenant
+//    r    = the receipt, with its allocations
+//
+// RETURNS
+//    true if the tenant can see the receipt
+//    any error encountered
+//-----------------------------------------------------------------------------
+func TenantCanAccessReceipt(ctx context.Context, bid, tcid int64, r *rlib.Receipt) (bool, error) {
+	if r.RCPTID == 0 || r.BID != bid {
+		return false, nil
+	}
+	if r.TCID == tcid {
+		return true, nil
+	}
+	m, err := TenantRentalAgreements(ctx, bid, tcid)
+	if err != nil {
+		return false, err
+	}
+	for i := 0; i < len(m); i++ {
+		if r.RAID == m[i].RAID {
+			return true, nil
+		}
+		for j := 0; j < len(r.RA); j++ {
+			if r.RA[j].RAID == m[i].RAID {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// SubmitMaintenanceRequest saves a maintenance request submitted by a
+// tenant.  The rentable, if supplied, must be one of the rentables of the
+// rental agreement.  The request is dated now and is open.
+//
+// INPUTS
+//    ctx = db context
+//    mr  = the request, BID, RAID and TCID must be set
+//
+// RETURNS
+//    a list of errors, nil if the request was saved
+//-----------------------------------------------------------------------------
+func SubmitMaintenanceRequest(ctx context.Context, mr *rlib.MaintenanceRequest) []BizError {
+	var errlist []BizError
+	fields := []string{}
+
+	mr.Description = strings.TrimSpace(mr.Description)
+	if len(mr.Description) == 0 {
+		fields = append(fields, "Description")
+	}
+	ra, err := rlib.GetRentalAgreement(ctx, mr.RAID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if ra.RAID == 0 || ra.BID != mr.BID {
+		fields = append(fields, "RAID")
+	} else if mr.RID > 0 {
+		m, err := rlib.GetRentalAgreementRentables(ctx, mr.RAID, &ra.AgreementStart, &ra.AgreementStop)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		found := false
+		for i := 0; i < len(m); i++ {
+			if m[i].RID == mr.RID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fields = append(fields, "RID")
+		}
+	}
+	if len(fields) > 0 {
+		msg := BizErrors[InvalidField].Message
+		for i := 0; i < len(fields); i++ {
+			msg += fmt.Sprintf("\n%s", fields[i])
+		}
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: msg})
+		return errlist
+	}
+
+	mr.MRID = 0
+	mr.Dt = time.Now()
+	mr.Status = rlib.MRStatusOpen
+	mr.Response = ""
+	if _, err = rlib.InsertMaintenanceRequest(ctx, mr); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
@@ -0,0 +1,278 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"sort"
+	"time"
+)
+
+// GenerateRateRecommendations recommends a market rate for each active
+// rentable type of business bid, to start on the first day of the month
+// after dt.  The recommendation is based on the occupancy of the type on dt,
+// the notices to move that take effect within the look ahead period, the
+// length of the vacancies found by VacancyDetect over the history period
+// and the seasonal adjustment of the month.  Types without a market rate
+// and types that already have a recommendation for the same start date are
+// skipped, so the routine can be run as often as needed.
+//
+// INPUTS
+//    ctx = db context
+//    bid = business id
+//    dt  = date of the recommendations
+//
+// RETURNS
+//    the number of recommendations made
+//    any error encountered
+//-----------------------------------------------------------------------------
+func GenerateRateRecommendations(ctx context.Context, bid int64, dt *time.Time) (int, error) {
+	var xbiz rlib.XBusiness
+	count := 0
+
+	p, err := rlib.GetPricingPolicy(ctx, bid, "general")
+	if err != nil {
+		return count, err
+	}
+	if err = rlib.InitBizInternals(bid, &xbiz); err != nil {
+		return count, err
+	}
+	f, err := pricingFactors(ctx, &xbiz, &p, dt)
+	if err != nil {
+		return count, err
+	}
+
+	start := time.Date(dt.Year(), dt.Month()+1, 1, 0, 0, 0, 0, dt.Location())
+	d1 := start.AddDate(0, 0, 1)
+	var rtids []int64
+	for k := range xbiz.RT {
+		rtids = append(rtids, k)
+	}
+	sort.Slice(rtids, func(i, j int) bool { return rtids[i] < rtids[j] })
+	for _, rtid := range rtids {
+		if xbiz.RT[rtid].FLAGS&0x1 != 0 { // inactive
+			continue
+		}
+		m, err := rlib.GetRateRecommendationsByRTID(ctx, rtid)
+		if err != nil {
+			return count, err
+		}
+		done := false
+		for i := 0; i < len(m); i++ {
+			done = done || m[i].DtStart.Equal(start)
+		}
+		if done {
+			continue
+		}
+		mr, err := rlib.GetRentableMarketRateByRange(ctx, rtid, &start, &d1)
+		if err != nil {
+			return count, err
+		}
+		if len(mr) == 0 || mr[len(mr)-1].MarketRate <= 0 {
+			continue
+		}
+
+		x := f[rtid]
+		if x == nil {
+			x = &rlib.PricingFactors{}
+		}
+		x.SeasonalPct = p.SeasonalPct(&start)
+		a := rlib.RateRecommendation{
+			BID:             bid,
+			RTID:            rtid,
+			DtStart:         start,
+			DtStop:          rlib.ENDOFTIME,
+			CurrentRate:     mr[len(mr)-1].MarketRate,
+			RecommendedRate: p.RecommendedRate(mr[len(mr)-1].MarketRate, x),
+			Rentables:       x.Rentables,
+			Occupied:        x.Occupied,
+			MoveOuts:        x.MoveOuts,
+			AvgDaysVacant:   x.AvgDaysVacant,
+			SeasonalPct:     x.SeasonalPct,
+			Status:          rlib.RateRecommendationOpen,
+		}
+		if _, err = rlib.InsertRateRecommendation(ctx, &a); err != nil {
+			return count, err
+		}
+		rlib.Ulog("RateRecommendation: RT-%d rate %s recommended %s from %s\n", rtid,
+			rlib.RRCommaf(a.CurrentRate), rlib.RRCommaf(a.RecommendedRate), start.Format(rlib.RRDATEFMT3))
+		count++
+	}
+	return count, nil
+}
+
+// pricingFactors computes the pricing figures of each rentable type of
+// xbiz on dt.  A rentable is occupied when a rental agreement covers dt.  It
+// moves out when the agreement that covers it is in the notice to move state
+// and its NoticeToMoveDate falls within p.LookAheadDays of dt.  The vacancies
+// are the periods found by VacancyDetect over the p.HistoryMonths before
+// dt, consecutive periods counting as one.
+//
+// INPUTS
+//    ctx  = db context
+//    xbiz = the business, its internals initialized
+//    p    = the pricing settings
+//    dt   = the date
+//
+// RETURNS
+//    the figures, indexed by RTID
+//    any error encountered
+//-----------------------------------------------------------------------------
+func pricingFactors(ctx context.Context, xbiz *rlib.XBusiness, p *rlib.BizPropsPricing, dt *time.Time) (map[int64]*rlib.PricingFactors, error) {
+	f := map[int64]*rlib.PricingFactors{}
+	types := map[int64]int64{} // RTID of each rentable on dt
+	d1 := dt.AddDate(0, 0, 1)
+	h1 := dt.AddDate(0, -p.HistoryMonths, 0)
+	days := map[int64]float64{} // total days vacant, by RTID
+
+	r, err := rlib.GetRentablesByBusiness(ctx, xbiz.P.BID)
+	if err != nil {
+		return f, err
+	}
+	for i := 0; i < len(r); i++ {
+		rta, err := rlib.GetRentableTypeRefsByRange(ctx, r[i].RID, dt, &d1)
+		if err != nil {
+			return f, err
+		}
+		if len(rta) == 0 {
+			continue // the rentable has no type on dt
+		}
+		rtid := rta[0].RTID
+		types[r[i].RID] = rtid
+		if _, ok := f[rtid]; !ok {
+			f[rtid] = &rlib.PricingFactors{}
+		}
+		f[rtid].Rentables++
+
+		t, err := rlib.GetAgreementsForRentable(ctx, r[i].RID, dt, &d1)
+		if err != nil {
+			return f, err
+		}
+		if len(t) > 0 {
+			f[rtid].Occupied++
+		}
+
+		//-------------------------------------------------
+		// vacancies of the history period
+		//-------------------------------------------------
+		if rta, err = rlib.GetRentableTypeRefsByRange(ctx, r[i].RID, &h1, dt); err != nil {
+			return f, err
+		}
+		if len(rta) == 0 {
+			continue // VacancyDetect needs a type over the period
+		}
+		m, err := rlib.VacancyDetect(ctx, xbiz, &h1, dt, r[i].RID)
+		if err != nil {
+			return f, err
+		}
+		for j := 0; j < len(m); j++ {
+			if j == 0 || !m[j].DtStart.Equal(m[j-1].DtStop) {
+				f[rtid].Vacancies++
+			}
+			days[rtid] += m[j].DtStop.Sub(m[j].DtStart).Hours() / 24
+		}
+	}
+	for rtid, x := range f {
+		if x.Vacancies > 0 {
+			x.AvgDaysVacant = rlib.RoundToCent(days[rtid] / float64(x.Vacancies))
+		}
+	}
+
+	//-------------------------------------------------
+	// notices to move
+	//-------------------------------------------------
+	d2 := dt.AddDate(0, 0, p.LookAheadDays)
+	m, err := rlib.GetRentalAgreementsByRange(ctx, xbiz.P.BID, dt, &d1)
+	if err != nil {
+		return f, err
+	}
+	for i := 0; i < len(m); i++ {
+		if m[i].FLAGS&0xf != rlib.RASTATENoticeToMove || m[i].NoticeToMoveDate.Before(*dt) || !m[i].NoticeToMoveDate.Before(d2) {
+			continue
+		}
+		n, err := rlib.GetRentalAgreementRentables(ctx, m[i].RAID, dt, &d1)
+		if err != nil {
+			return f, err
+		}
+		for j := 0; j < len(n); j++ {
+			if rtid, ok := types[n[j].RID]; ok {
+				f[rtid].MoveOuts++
+			}
+		}
+	}
+	return f, nil
+}
+
+// ApproveRateRecommendation approves rate recommendation rcmid and writes
+// its rate as the market rate of the rentable type from its DtStart.  The
+// rate approved is rate, or the recommended rate if rate is 0.
+//
+// INPUTS
+//    ctx   = db context
+//    rcmid = the recommendation
+//    rate  = the rate to apply, 0 for the recommended rate
+//
+// RETURNS
+//    any error encountered
+//-----------------------------------------------------------------------------
+func ApproveRateRecommendation(ctx context.Context, rcmid int64, rate float64) error {
+	a, err := openRateRecommendation(ctx, rcmid)
+	if err != nil {
+		return err
+	}
+	if rate < 0 {
+		return fmt.Errorf("the market rate cannot be negative")
+	}
+	if rate == 0 {
+		rate = a.RecommendedRate
+	}
+	mr := rlib.RentableMarketRate{
+		RTID:       a.RTID,
+		BID:        a.BID,
+		MarketRate: rate,
+		DtStart:    a.DtStart,
+		DtStop:     a.DtStop,
+	}
+	if err = rlib.SetRentableMarketRate(ctx, &mr); err != nil {
+		return err
+	}
+	a.AppliedRate = rate
+	a.Status = rlib.RateRecommendationApproved
+	return rlib.UpdateRateRecommendation(ctx, &a)
+}
+
+// RejectRateRecommendation rejects rate recommendation rcmid.  The market
+// rate of the rentable type is left as is.
+//
+// INPUTS
+//    ctx   = db context
+//    rcmid = the recommendation
+//
+// RETURNS
+//    any error encountered
+//-----------------------------------------------------------------------------
+func RejectRateRecommendation(ctx context.Context, rcmid int64) error {
+	a, err := openRateRecommendation(ctx, rcmid)
+	if err != nil {
+		return err
+	}
+	a.Status = rlib.RateRecommendationRejected
+	return rlib.UpdateRateRecommendation(ctx, &a)
+}
+
+// openRateRecommendation returns rate recommendation rcmid.  It is an error
+// if it does not exist or has already been approved or rejected.
+//-----------------------------------------------------------------------------
+func openRateRecommendation(ctx context.Context, rcmid int64) (rlib.RateRecommendation, error) {
+	a, err := rlib.GetRateRecommendation(ctx, rcmid)
+	if err != nil {
+		return a, err
+	}
+	if a.RCMID == 0 {
+		return a, fmt.Errorf("rate recommendation %d not found", rcmid)
+	}
+	if a.Status != rlib.RateRecommendationOpen {
+		return a, fmt.Errorf("rate recommendation %d is %s", rcmid, rlib.RateRecommendationStatus[a.Status])
+	}
+	return a, nil
+}
@@ -0,0 +1,213 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"time"
+)
+
+// GenerateRenewalOffers makes renewal offers for the active rental
+// agreements of business bid that stop within the business's renewal
+// notice period after dt.  One offer is made for each recurring rent
+// assessment in force at the end of the agreement.  The new term starts on
+// the AgreementStop of the agreement, and the offered rent follows the
+// market rate of the rentable within the configured caps.  Agreements set
+// for month to month renewal, agreements with a notice to move and
+// agreements that already have offers for the same term are skipped, so
+// the routine can be run as often as needed.  Open offers whose ExpireDt
+// is on or before dt are marked expired.
+//
+// INPUTS
+//    ctx = db context
+//    bid = business id
+//    dt  = date of the offers
+//
+// RETURNS
+//    the number of offers made
+//    any error encountered
+//-----------------------------------------------------------------------------
+func GenerateRenewalOffers(ctx context.Context, bid int64, dt *time.Time) (int, error) {
+	var xbiz rlib.XBusiness
+	count := 0
+
+	if err := ExpireRenewalOffers(ctx, bid, dt); err != nil {
+		return count, err
+	}
+	p, err := rlib.GetRenewalPolicy(ctx, bid, "general")
+	if err != nil {
+		return count, err
+	}
+	if err = rlib.InitBizInternals(bid, &xbiz); err != nil {
+		return count, err
+	}
+	arids, err := rentARIDs(ctx, bid)
+	if err != nil {
+		return count, err
+	}
+
+	d2 := dt.AddDate(0, 0, p.NoticeDays+1)
+	m, err := rlib.GetRentalAgreementsByAgreementStop(ctx, bid, dt, &d2)
+	if err != nil {
+		return count, err
+	}
+	for i := 0; i < len(m); i++ {
+		if m[i].FLAGS&0xf != rlib.RASTATEActive || m[i].Renewal == 1 {
+			continue
+		}
+		n, err := renewalOffers(ctx, &xbiz, &m[i], &p, arids, dt)
+		if err != nil {
+			return count, err
+		}
+		count += n
+	}
+	return count, nil
+}
+
+// renewalOffers makes the renewal offers for ra unless it already has offers
+// for the term that follows it.
+//
+// INPUTS
+//    ctx   = db context
+//    xbiz  = the business
+//    ra    = the expiring rental agreement
+//    p     = the renewal settings
+//    arids = ARIDs of the rent assessments
+//    dt    = date of the offers
+//
+// RETURNS
+//    the number of offers made
+//    any error encountered
+//-----------------------------------------------------------------------------
+func renewalOffers(ctx context.Context, xbiz *rlib.XBusiness, ra *rlib.RentalAgreement, p *rlib.BizPropsRenewal, arids map[int64]bool, dt *time.Time) (int, error) {
+	count := 0
+	t, err := rlib.GetRenewalOffersByRAID(ctx, ra.RAID)
+	if err != nil {
+		return count, err
+	}
+	for i := 0; i < len(t); i++ {
+		if t[i].TermStart.Equal(ra.AgreementStop) {
+			return count, nil
+		}
+	}
+
+	start := ra.AgreementStop
+	stop := p.TermStop(&start)
+	expire := dt.AddDate(0, 0, p.OfferValidDays)
+	if expire.After(start) {
+		expire = start
+	}
+	last := start.AddDate(0, 0, -1)
+	m, err := rentDefsOnDate(ctx, ra, arids, &last)
+	if err != nil {
+		return count, err
+	}
+	d1 := start.AddDate(0, 0, 1)
+	for i := 0; i < len(m); i++ {
+		mr, err := rlib.GetRentableMarketRate(ctx, xbiz, m[i].RID, &start, &d1)
+		if err != nil {
+			return count, err
+		}
+		a := rlib.RenewalOffer{
+			BID:         ra.BID,
+			RAID:        ra.RAID,
+			RID:         m[i].RID,
+			ASMID:       m[i].ASMID,
+			ARID:        m[i].ARID,
+			CurrentRent: m[i].Amount,
+			MarketRent:  mr,
+			OfferRent:   p.ProposedRent(m[i].Amount, mr),
+			TermStart:   start,
+			TermStop:    stop,
+			OfferDt:     *dt,
+			ExpireDt:    expire,
+			Status:      rlib.RenewalOfferOpen,
+		}
+		if _, err = rlib.InsertRenewalOffer(ctx, &a); err != nil {
+			return count, err
+		}
+		rlib.Ulog("RenewalOffer: RA-%d R-%d rent %s offered %s for %s\n", ra.RAID, a.RID,
+			rlib.RRCommaf(a.CurrentRent), rlib.RRCommaf(a.OfferRent), rlib.ConsoleDRange(&start, &stop))
+		count++
+	}
+	return count, nil
+}
+
+// ExpireRenewalOffers marks expired the open renewal offers of business bid
+// whose ExpireDt is on or before dt.
+//
+// INPUTS
+//    ctx = db context
+//    bid = business id
+//    dt  = the current date
+//
+// RETURNS
+//    any error encountered
+//-----------------------------------------------------------------------------
+func ExpireRenewalOffers(ctx context.Context, bid int64, dt *time.Time) error {
+	m, err := rlib.GetRenewalOffersInRange(ctx, bid, &rlib.TIME0, &rlib.ENDOFTIME)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(m); i++ {
+		if m[i].Status != rlib.RenewalOfferOpen || m[i].ExpireDt.After(*dt) {
+			continue
+		}
+		m[i].Status = rlib.RenewalOfferExpired
+		if err = rlib.UpdateRenewalOffer(ctx, &m[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OpenRenewalOffers returns the open renewal offers of rental agreement
+// raid.  It is an error if there are none.
+//
+// INPUTS
+//    ctx  = db context
+//    raid = the expiring rental agreement
+//
+// RETURNS
+//    the open offers
+//    any error encountered
+//-----------------------------------------------------------------------------
+func OpenRenewalOffers(ctx context.Context, raid int64) ([]rlib.RenewalOffer, error) {
+	var t []rlib.RenewalOffer
+	m, err := rlib.GetRenewalOffersByRAID(ctx, raid)
+	if err != nil {
+		return t, err
+	}
+	for i := 0; i < len(m); i++ {
+		if m[i].Status == rlib.RenewalOfferOpen {
+			t = append(t, m[i])
+		}
+	}
+	if len(t) == 0 {
+		return t, fmt.Errorf("rental agreement %d has no open renewal offers", raid)
+	}
+	return t, nil
+}
+
+// SetRenewalOffersStatus sets the status of renewal offers m.  newRAID is the
+// amended rental agreement of accepted offers.
+//
+// INPUTS
+//    ctx     = db context
+//    m       = the offers
+//    status  = the new status
+//    newRAID = the amended rental agreement, 0 unless accepted
+//
+// RETURNS
+//    any error encountered
+//-----------------------------------------------------------------------------
+func SetRenewalOffersStatus(ctx context.Context, m []rlib.RenewalOffer, status, newRAID int64) error {
+	for i := 0; i < len(m); i++ {
+		m[i].Status = status
+		m[i].NewRAID = newRAID
+		if err := rlib.UpdateRenewalOffer(ctx, &m[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"time"
+)
+
+// RentEscalation is one scheduled escalation of a recurring rent assessment
+type RentEscalation struct {
+	RAID       int64     // rental agreement with the scheduled RateChange
+	ASMID      int64     // recurring rent assessment definition in force on Dt
+	RID        int64     // rentable being charged
+	ARID       int64     // account rule of the rent assessment
+	Dt         time.Time // date of the escalation
+	Amount     float64   // rent before the escalation
+	NewAmount  float64   // rent after the escalation
+	RateChange float64   // the rental agreement's RateChange
+	Fixed      bool      // true if RateChange is an amount, false if a percentage
+}
+
+// firstRateChangeDt is the earliest NextRateChange that is considered
+// scheduled.  NextRateChange defaults to TIME0 when nothing is scheduled.
+var firstRateChangeDt = rlib.TIME0.AddDate(0, 0, 1)
+
+// EscalateRents applies the scheduled rent escalations of business bid that
+// are due on or before dt.  For each rental agreement whose NextRateChange
+// has arrived, every recurring rent assessment in force on that date is
+// stopped on NextRateChange and replaced by a new recurring assessment at
+// the escalated amount starting on NextRateChange. Instances of the old
+// assessment dated on or after NextRateChange are reversed.  NextRateChange
+// is then advanced by the business's escalation interval.  If several
+// escalations were missed they are all applied, each on its own date.
+//
+// Advancing NextRateChange is what makes the process idempotent, so the
+// routine can be run as often as needed.
+//
+// INPUTS
+//    ctx = db context
+//    bid = business id
+//    dt  = escalations due on or before this date are applied
+//
+// RETURNS
+//    the number of rent assessments escalated
+//    any error encountered
+//-----------------------------------------------------------------------------
+func EscalateRents(ctx context.Context, bid int64, dt *time.Time) (int, error) {
+	count := 0
+
+	p, err := rlib.GetRentEscalationPolicy(ctx, bid, "general")
+	if err != nil {
+		return count, err
+	}
+	if !p.Enabled {
+		return count, nil
+	}
+
+	arids, err := rentARIDs(ctx, bid)
+	if err != nil {
+		return count, err
+	}
+
+	lc, err := openClosePeriod(ctx, bid)
+	if err != nil {
+		return count, err
+	}
+
+	d2 := dt.AddDate(0, 0, 1)
+	m, err := rlib.GetRentalAgreementsByNextRateChange(ctx, bid, &firstRateChangeDt, &d2)
+	if err != nil {
+		return count, err
+	}
+
+	for i := 0; i < len(m); i++ {
+		for !m[i].NextRateChange.After(*dt) && m[i].NextRateChange.Before(m[i].AgreementStop) {
+			n, err := escalateRent(ctx, &m[i], arids, dt, &lc)
+			if err != nil {
+				return count, err
+			}
+			count += n
+			m[i].NextRateChange = p.NextEscalationDate(&m[i].NextRateChange)
+		}
+		if err = rlib.UpdateRentalAgreement(ctx, &m[i]); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// escalateRent applies the escalation of ra scheduled on ra.NextRateChange
+// to each of its recurring rent assessments.
+//
+// INPUTS
+//    ctx   = db context
+//    ra    = the rental agreement
+//    arids = ARIDs of the rent assessments
+//    dt    = date of the modification
+//    lc    = the last close period
+//
+// RETURNS
+//    the number of rent assessments escalated
+//    any error encountered
+//-----------------------------------------------------------------------------
+func escalateRent(ctx context.Context, ra *rlib.RentalAgreement, arids map[int64]bool, dt *time.Time, lc *rlib.ClosePeriod) (int, error) {
+	count := 0
+	d := ra.NextRateChange
+	m, err := rentDefsOnDate(ctx, ra, arids, &d)
+	if err != nil {
+		return count, err
+	}
+
+	for i := 0; i < len(m); i++ {
+		old := m[i]
+		if errlist := ReverseAssessmentsAfterStop(ctx, &old, &d, dt, lc); len(errlist) > 0 {
+			return count, BizErrorListToError(errlist)
+		}
+		old.Stop = d
+		if err = rlib.UpdateAssessment(ctx, &old); err != nil {
+			return count, err
+		}
+
+		a := m[i]
+		a.ASMID = 0
+		a.FLAGS &= ^uint64(7) // not paid, not reversed
+		a.Start = d
+		a.Amount = ra.EscalatedRent(m[i].Amount)
+		a.Comment = fmt.Sprintf("Rent escalation from %s (ASM-%d)", rlib.RRCommaf(m[i].Amount), m[i].ASMID)
+		xlc := *lc // InsertAssessment changes the expansion dates
+		if errlist := InsertAssessment(ctx, &a, 1, &xlc); len(errlist) > 0 {
+			return count, BizErrorListToError(errlist)
+		}
+		rlib.Ulog("RentEscalationBot: RA-%d rent ASM-%d %s replaced by ASM-%d %s on %s\n",
+			ra.RAID, m[i].ASMID, rlib.RRCommaf(m[i].Amount), a.ASMID, rlib.RRCommaf(a.Amount), d.Format(rlib.RRDATEFMT3))
+		count++
+	}
+	return count, nil
+}
+
+// GetRentEscalations returns the scheduled rent escalations of business bid
+// due before dt.  Escalations whose date has passed but that have not been
+// applied yet are included.  If an agreement escalates more than once
+// before dt, each escalation is listed with the amounts compounded.
+//
+// INPUTS
+//    ctx = db context
+//    bid = business id
+//    dt  = escalations before this date are listed
+//
+// RETURNS
+//    the escalations ordered by date
+//    any error encountered
+//-----------------------------------------------------------------------------
+func GetRentEscalations(ctx context.Context, bid int64, dt *time.Time) ([]RentEscalation, error) {
+	var t []RentEscalation
+
+	p, err := rlib.GetRentEscalationPolicy(ctx, bid, "general")
+	if err != nil {
+		return t, err
+	}
+	arids, err := rentARIDs(ctx, bid)
+	if err != nil {
+		return t, err
+	}
+	m, err := rlib.GetRentalAgreementsByNextRateChange(ctx, bid, &firstRateChangeDt, dt)
+	if err != nil {
+		return t, err
+	}
+
+	for i := 0; i < len(m); i++ {
+		n, err := rentDefsOnDate(ctx, &m[i], arids, &m[i].NextRateChange)
+		if err != nil {
+			return t, err
+		}
+		for j := 0; j < len(n); j++ {
+			amt := n[j].Amount
+			for d := m[i].NextRateChange; d.Before(*dt) && d.Before(m[i].AgreementStop) && d.Before(n[j].Stop); d = p.NextEscalationDate(&d) {
+				x := m[i].EscalatedRent(amt)
+				t = append(t, RentEscalation{
+					RAID:       m[i].RAID,
+					ASMID:      n[j].ASMID,
+					RID:        n[j].RID,
+					ARID:       n[j].ARID,
+					Dt:         d,
+					Amount:     amt,
+					NewAmount:  x,
+					RateChange: m[i].RateChange,
+					Fixed:      m[i].FLAGS&rlib.RAFixedRateChange != 0,
+				})
+				amt = x
+			}
+		}
+	}
+	return t, nil
+}
+
+// rentDefsOnDate returns the recurring rent assessment definitions of ra
+// that are in force on d and that started before d.  A definition that
+// starts on d is the result of an escalation that has already been applied.
+//-----------------------------------------------------------------------------
+func rentDefsOnDate(ctx context.Context, ra *rlib.RentalAgreement, arids map[int64]bool, d *time.Time) ([]rlib.Assessment, error) {
+	var t []rlib.Assessment
+	d2 := d.AddDate(0, 0, 1)
+	m, err := rlib.GetRecurringAssessmentDefsByRAID(ctx, ra.RAID, d, &d2)
+	if err != nil {
+		return t, err
+	}
+	for i := 0; i < len(m); i++ {
+		if !arids[m[i].ARID] || m[i].FLAGS&rlib.ASMREVERSED != 0 || !m[i].Start.Before(*d) {
+			continue
+		}
+		t = append(t, m[i])
+	}
+	return t, nil
+}
+
+// rentARIDs returns a map of the ARIDs of the Account Rules flagged as Rent
+// assessments.
+//-----------------------------------------------------------------------------
+func rentARIDs(ctx context.Context, bid int64) (map[int64]bool, error) {
+	var m = map[int64]bool{}
+	n, err := rlib.GetARsByFLAGS(ctx, bid, 1<<rlib.ARIsRentASM)
+	if err != nil {
+		return m, err
+	}
+	for i := 0; i < len(n); i++ {
+		m[n[i].ARID] = true
+	}
+	return m, nil
+}
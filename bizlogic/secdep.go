@@ -0,0 +1,431 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+)
+
+// Security deposits are settled at move-out as follows:
+// 1. Determine the deposits held for the Rental Agreement. This is the
+//    amount assessed to the security deposit account rules (see
+//    rlib.GetSecDepBalance) less any part of those assessments that was
+//    never paid.
+// 2. List the assessments that are still unpaid on the move-out date and
+//    the itemized damages.
+// 3. Apply the deposits to the unpaid assessments with a Receipt that uses
+//    the ApplyARName account rule. The receipt is allocated to the
+//    assessments in the order they were listed.
+// 4. Apply what is left of the deposits to the damages with an Expense that
+//    uses the DamageARName account rule.
+// 5. Refund what is left after that with an Expense that uses the
+//    RefundARName account rule, or assess the damages the deposits did not
+//    cover with the BalanceDueARName account rule.
+//
+// The disposition letter (rrpt.SecDepLetterTable) itemizes all of this.
+
+// ValidateSecDepSettlement checks the supplied settlement before it is
+// saved.  Each damage item needs a description and a positive amount.
+//-----------------------------------------------------------------------------
+func ValidateSecDepSettlement(ctx context.Context, s *rlib.SecDepSettlement) []BizError {
+	var e []BizError
+	fields := []string{}
+	if s.RAID == 0 {
+		fields = append(fields, "RAID")
+	}
+	if s.Dt.IsZero() {
+		fields = append(fields, "Dt")
+	}
+	for i := 0; i < len(s.I); i++ {
+		if s.I[i].ItemType != rlib.SecDepItemDamage {
+			continue
+		}
+		if len(s.I[i].Description) == 0 {
+			fields = append(fields, fmt.Sprintf("Description (damage %d)", i+1))
+		}
+		if s.I[i].Amount <= 0 {
+			fields = append(fields, fmt.Sprintf("Amount (damage %d)", i+1))
+		}
+	}
+	if len(fields) > 0 {
+		msg := BizErrors[InvalidField].Message
+		for i := 0; i < len(fields); i++ {
+			msg += fmt.Sprintf("\n%s", fields[i])
+		}
+		e = append(e, BizError{Errno: InvalidField, Message: msg})
+	}
+	if len(e) > 0 {
+		return e
+	}
+	return nil
+}
+
+// InitSecDepSettlement fills in the parts of settlement s that come from the
+// database: the deposits held on s.Dt, the payor (if s.TCID is 0), the
+// forwarding address (if it is empty) and an item for each assessment that
+// is unpaid on s.Dt. The damage items in s.I are kept. The totals are then
+// computed with s.Disposition().
+//
+// INPUTS
+//    ctx = db context
+//    s   = the settlement, BID, RAID and Dt must be set
+//
+// RETURNS
+//    any error encountered
+//-----------------------------------------------------------------------------
+func InitSecDepSettlement(ctx context.Context, s *rlib.SecDepSettlement) error {
+	ra, err := rlib.GetRentalAgreement(ctx, s.RAID)
+	if err != nil {
+		return err
+	}
+	if ra.RAID == 0 {
+		return fmt.Errorf("rental agreement %d not found", s.RAID)
+	}
+	s.BID = ra.BID
+	var xbiz rlib.XBusiness
+	if err = rlib.InitBizInternals(s.BID, &xbiz); err != nil {
+		return err
+	}
+
+	d1 := ra.AgreementStart
+	d2 := s.Dt.AddDate(0, 0, 1)
+	if s.TCID == 0 {
+		m, err := rlib.GetRentalAgreementPayorsInRange(ctx, s.RAID, &d1, &d2)
+		if err != nil {
+			return err
+		}
+		if len(m) > 0 {
+			s.TCID = m[len(m)-1].TCID
+		}
+	}
+	if len(s.ForwardAddress) == 0 && s.TCID > 0 {
+		var t rlib.Transactant
+		if err = rlib.GetTransactant(ctx, s.TCID, &t); err != nil {
+			return err
+		}
+		s.ForwardAddress = t.SingleLineAddress()
+	}
+
+	sda, err := rlib.SecDepRules(s.BID)
+	if err != nil {
+		return err
+	}
+	secdep := map[int64]bool{}
+	for i := 0; i < len(sda); i++ {
+		secdep[sda[i]] = true
+	}
+
+	//------------------------------------------------------------
+	// Deposits assessed for each rentable of the agreement...
+	//------------------------------------------------------------
+	s.DepositHeld = 0
+	rr, err := rlib.GetRentalAgreementRentables(ctx, s.RAID, &d1, &d2)
+	if err != nil {
+		return err
+	}
+	t0 := rlib.TIME0
+	for i := 0; i < len(rr); i++ {
+		amt, err := rlib.GetSecDepBalance(ctx, s.BID, s.RAID, rr[i].RID, &t0, &d2)
+		if err != nil {
+			return err
+		}
+		s.DepositHeld += amt
+	}
+
+	//------------------------------------------------------------
+	// ... less what was never paid.  Everything else that is unpaid
+	// becomes a charge against the deposits.
+	//------------------------------------------------------------
+	items := []rlib.SecDepItem{}
+	m, err := rlib.GetUnpaidAssessmentsByRAID(ctx, s.RAID)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(m); i++ {
+		if m[i].Start.After(s.Dt) {
+			continue
+		}
+		unpaid := AssessmentUnpaidPortion(ctx, &m[i])
+		if secdep[m[i].ARID] {
+			s.DepositHeld -= unpaid
+			continue
+		}
+		if unpaid < 0.01 {
+			continue
+		}
+		items = append(items, rlib.SecDepItem{
+			SDSID:       s.SDSID,
+			BID:         s.BID,
+			ItemType:    rlib.SecDepItemCharge,
+			ASMID:       m[i].ASMID,
+			RID:         m[i].RID,
+			Description: fmt.Sprintf("%s, %s", rlib.RRdb.BizTypes[s.BID].AR[m[i].ARID].Name, m[i].Start.Format(rlib.RRDATEFMT3)),
+			Amount:      rlib.RoundToCent(unpaid),
+		})
+	}
+	s.DepositHeld = rlib.RoundToCent(s.DepositHeld)
+
+	for i := 0; i < len(s.I); i++ {
+		if s.I[i].ItemType == rlib.SecDepItemDamage {
+			s.I[i].SDSID = s.SDSID
+			s.I[i].BID = s.BID
+			items = append(items, s.I[i])
+		}
+	}
+	s.I = items
+	s.Disposition()
+	return nil
+}
+
+// SaveSecDepSettlement initializes settlement s with InitSecDepSettlement
+// and writes it and its items to the database.  Posted settlements cannot
+// be changed.
+//
+// INPUTS
+//    ctx = db context, should contain a transaction
+//    s   = the settlement, SDSID is 0 for a new one
+//
+// RETURNS
+//    any error encountered
+//-----------------------------------------------------------------------------
+func SaveSecDepSettlement(ctx context.Context, s *rlib.SecDepSettlement) error {
+	if errlist := ValidateSecDepSettlement(ctx, s); len(errlist) > 0 {
+		return BizErrorListToError(errlist)
+	}
+	if s.SDSID > 0 {
+		old, err := rlib.GetSecDepSettlement(ctx, s.SDSID)
+		if err != nil {
+			return err
+		}
+		if old.SDSID == 0 {
+			return fmt.Errorf("security deposit settlement %d not found", s.SDSID)
+		}
+		if old.FLAGS&rlib.FlSecDepPosted != 0 {
+			return fmt.Errorf("security deposit settlement %s has been posted, it cannot be changed", old.IDtoString())
+		}
+	}
+	if err := InitSecDepSettlement(ctx, s); err != nil {
+		return err
+	}
+
+	var err error
+	if s.SDSID == 0 {
+		_, err = rlib.InsertSecDepSettlement(ctx, s)
+	} else {
+		err = rlib.UpdateSecDepSettlement(ctx, s)
+	}
+	if err != nil {
+		return err
+	}
+	return saveSecDepItems(ctx, s)
+}
+
+// saveSecDepItems replaces the items of settlement s in the database with
+// the ones in s.I
+//-----------------------------------------------------------------------------
+func saveSecDepItems(ctx context.Context, s *rlib.SecDepSettlement) error {
+	m, err := rlib.GetSecDepItems(ctx, s.SDSID)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(m); i++ {
+		if err = rlib.DeleteSecDepItem(ctx, m[i].SDIID); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < len(s.I); i++ {
+		s.I[i].SDIID = 0
+		s.I[i].SDSID = s.SDSID
+		if _, err = rlib.InsertSecDepItem(ctx, &s.I[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PostSecDepSettlement makes the journal entries for settlement sdsid. The
+// settlement is initialized again first so that it reflects any payments
+// made since it was saved.  A settlement dated in a closed period cannot be
+// posted.
+//
+// INPUTS
+//    ctx   = db context, should contain a transaction
+//    sdsid = the settlement
+//
+// RETURNS
+//    the posted settlement
+//    any error encountered
+//-----------------------------------------------------------------------------
+func PostSecDepSettlement(ctx context.Context, sdsid int64) (rlib.SecDepSettlement, error) {
+	s, err := rlib.GetSecDepSettlement(ctx, sdsid)
+	if err != nil {
+		return s, err
+	}
+	if s.SDSID == 0 {
+		return s, fmt.Errorf("security deposit settlement %d not found", sdsid)
+	}
+	if s.FLAGS&rlib.FlSecDepPosted != 0 {
+		return s, fmt.Errorf("security deposit settlement %s has already been posted", s.IDtoString())
+	}
+	if s.I, err = rlib.GetSecDepItems(ctx, sdsid); err != nil {
+		return s, err
+	}
+	if err = InitSecDepSettlement(ctx, &s); err != nil {
+		return s, err
+	}
+	if s.TCID == 0 {
+		return s, fmt.Errorf("rental agreement %d has no payor", s.RAID)
+	}
+	lc, err := openClosePeriod(ctx, s.BID)
+	if err != nil {
+		return s, err
+	}
+	if !s.Dt.After(lc.Dt) {
+		return s, fmt.Errorf("security deposit settlement %s cannot be posted because its date (%s) is in a closed period", s.IDtoString(), s.Dt.Format(rlib.RRDATEFMT3))
+	}
+	d := s.Disposition()
+
+	p, err := rlib.GetSecDepPolicy(ctx, s.BID, "general")
+	if err != nil {
+		return s, err
+	}
+	rid := int64(0)
+	for i := 0; i < len(s.I); i++ {
+		if s.I[i].RID > 0 {
+			rid = s.I[i].RID
+			break
+		}
+	}
+	comment := fmt.Sprintf("Security deposit settlement %s", s.IDtoString())
+
+	//------------------------------------------------------------
+	// deposits applied to the unpaid assessments
+	//------------------------------------------------------------
+	if d.ToCharges > 0 {
+		ar, err := getARByNameAndType(ctx, s.BID, p.ApplyARName, rlib.ARRECEIPT)
+		if err != nil {
+			return s, err
+		}
+		pmt, err := getPaymentTypeByName(ctx, s.BID, p.PMTName, "Security deposit applied to amounts owed")
+		if err != nil {
+			return s, err
+		}
+		r := rlib.Receipt{
+			BID:     s.BID,
+			TCID:    s.TCID,
+			PMTID:   pmt.PMTID,
+			Dt:      s.Dt,
+			DocNo:   s.IDtoString(),
+			Amount:  d.ToCharges,
+			ARID:    ar.ARID,
+			Comment: comment,
+		}
+		if err = InsertReceipt(ctx, &r); err != nil {
+			return s, err
+		}
+		s.RCPTID = r.RCPTID
+
+		amt := d.ToCharges
+		for i := 0; i < len(s.I) && amt >= 0.01; i++ {
+			if s.I[i].ItemType != rlib.SecDepItemCharge {
+				continue
+			}
+			a, err := rlib.GetAssessment(ctx, s.I[i].ASMID)
+			if err != nil {
+				return s, err
+			}
+			needed := AssessmentUnpaidPortion(ctx, &a)
+			if err = PayAssessment(ctx, &a, &r, &needed, &amt, &s.Dt); err != nil {
+				return s, err
+			}
+		}
+	}
+
+	//------------------------------------------------------------
+	// deposits applied to the damages
+	//------------------------------------------------------------
+	if d.ToDamages > 0 {
+		x, err := secDepExpense(ctx, &s, p.DamageARName, rid, d.ToDamages, comment+", damages")
+		if err != nil {
+			return s, err
+		}
+		s.DMGEXPID = x.EXPID
+	}
+
+	//------------------------------------------------------------
+	// refund
+	//------------------------------------------------------------
+	if d.Refund > 0 {
+		x, err := secDepExpense(ctx, &s, p.RefundARName, rid, d.Refund, comment+", refund")
+		if err != nil {
+			return s, err
+		}
+		s.REFEXPID = x.EXPID
+	}
+
+	//------------------------------------------------------------
+	// damages the deposits did not cover
+	//------------------------------------------------------------
+	if d.UnpaidDamages > 0 {
+		ar, err := getARByNameAndType(ctx, s.BID, p.BalanceDueARName, rlib.ARASSESSMENT)
+		if err != nil {
+			return s, err
+		}
+
+		a := rlib.Assessment{
+			BID:            s.BID,
+			RID:            rid,
+			RAID:           s.RAID,
+			Amount:         d.UnpaidDamages,
+			Start:          s.Dt,
+			Stop:           s.Dt,
+			RentCycle:      rlib.RECURNONE,
+			ProrationCycle: rlib.RECURNONE,
+			ARID:           ar.ARID,
+			Comment:        comment + ", damages not covered by the deposit",
+		}
+		if errlist := InsertAssessment(ctx, &a, 0, &lc); len(errlist) > 0 {
+			return s, BizErrorListToError(errlist)
+		}
+		s.BDASMID = a.ASMID
+	}
+
+	s.FLAGS |= rlib.FlSecDepPosted
+	if err = rlib.UpdateSecDepSettlement(ctx, &s); err != nil {
+		return s, err
+	}
+	if err = saveSecDepItems(ctx, &s); err != nil {
+		return s, err
+	}
+
+	note := fmt.Sprintf("%s posted. Deposits held %s, applied %s, refund %s, balance due %s.",
+		comment, rlib.RRCommaf(s.DepositHeld), rlib.RRCommaf(s.DepositApplied), rlib.RRCommaf(s.Refund), rlib.RRCommaf(s.BalanceDue))
+	err = addPayorNote(ctx, s.BID, s.TCID, s.RAID, rid, note)
+	return s, err
+}
+
+// secDepExpense creates and journals an Expense for settlement s using the
+// account rule named arname.
+//-----------------------------------------------------------------------------
+func secDepExpense(ctx context.Context, s *rlib.SecDepSettlement, arname string, rid int64, amt float64, comment string) (rlib.Expense, error) {
+	var x rlib.Expense
+	ar, err := getARByNameAndType(ctx, s.BID, arname, rlib.AREXPENSE)
+	if err != nil {
+		return x, err
+	}
+	x = rlib.Expense{
+		BID:     s.BID,
+		RID:     rid,
+		RAID:    s.RAID,
+		Amount:  amt,
+		Dt:      s.Dt,
+		ARID:    ar.ARID,
+		Comment: comment,
+	}
+	if _, err = rlib.InsertExpense(ctx, &x); err != nil {
+		return x, err
+	}
+	var xbiz rlib.XBusiness
+	err = rlib.ProcessNewExpense(ctx, &x, &xbiz)
+	return x, err
+}
@@ -0,0 +1,258 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"time"
+)
+
+// TaxAssessment applies the taxes of the rentable type of assessment a to
+// a.  Each tax in effect on a.Start gets an AssessmentTax record saying what
+// was charged or why nothing was:
+//
+//    * a rental agreement that is not taxable on a.Start (RentalAgreementTax)
+//      is not charged, the record is flagged do not apply
+//    * a stay that has become exempt (longer than the tax's ExemptDays) is
+//      not charged, the record is flagged exempt, and the tax charged on the
+//      earlier days of the stay is refunded: the tax assessments are
+//      reversed and their records flagged refunded
+//    * otherwise the tax at the rate in effect on a.Start is posted as an
+//      assessment to the tax's account rule, dated with a.  The tax of a
+//      folio charge is posted to the folio.
+//
+// Taxes that a already has a record for are skipped, so a can be taxed
+// again safely.
+//
+// INPUTS
+//    ctx = db context, the caller should roll back its transaction if an
+//          error is returned
+//    a   = the assessment to tax
+//    lc  = close period info used to post and reverse the tax assessments
+//
+// RETURNS
+//    the net tax posted: the tax charged less the tax refunded
+//    any error encountered
+//-----------------------------------------------------------------------------
+func TaxAssessment(ctx context.Context, a *rlib.Assessment, lc *rlib.ClosePeriod) (float64, error) {
+	var net float64
+	if a.FLAGS&4 != 0 || a.RAID == 0 || a.RID == 0 {
+		return net, nil
+	}
+	m, err := rlib.GetAssessmentTaxesByASMID(ctx, a.ASMID)
+	if err != nil {
+		return net, err
+	}
+	done := map[int64]bool{}
+	for i := 0; i < len(m); i++ {
+		done[m[i].TAXID] = true
+	}
+
+	//----------------------------------------------------
+	// the rentable type and the start of the stay
+	//----------------------------------------------------
+	var rtid int64
+	var start time.Time
+	if a.AssocElemType == rlib.ELEMFOLIO {
+		f, err := rlib.GetFolio(ctx, a.AssocElemID)
+		if err != nil {
+			return net, err
+		}
+		rtid = f.RTID
+		start = f.DtArrive
+	}
+	if rtid == 0 {
+		rtr, err := rlib.GetRentableTypeRefForDate(ctx, a.RID, &a.Start)
+		if err != nil {
+			return net, err
+		}
+		rtid = rtr.RTID
+	}
+	if start.IsZero() {
+		ra, err := rlib.GetRentalAgreement(ctx, a.RAID)
+		if err != nil {
+			return net, err
+		}
+		start = ra.PossessionStart
+	}
+
+	next := a.Start.AddDate(0, 0, 1)
+	b, err := rlib.GetRentableTypeTaxesInRange(ctx, rtid, &a.Start, &next)
+	if err != nil {
+		return net, err
+	}
+	rat, err := rlib.GetRentalAgreementTaxesInRange(ctx, a.RAID, &a.Start, &next)
+	if err != nil {
+		return net, err
+	}
+	taxable := rlib.IsTaxable(rat, &a.Start)
+
+	for i := 0; i < len(b); i++ {
+		if done[b[i].TAXID] {
+			continue
+		}
+		done[b[i].TAXID] = true
+		tax, err := rlib.GetTax(ctx, b[i].TAXID)
+		if err != nil {
+			return net, err
+		}
+		at := rlib.AssessmentTax{
+			ASMID:    a.ASMID,
+			BID:      a.BID,
+			RAID:     a.RAID,
+			TAXID:    tax.TAXID,
+			Dt:       a.Start,
+			Base:     a.Amount,
+			DtRefund: rlib.TIME0,
+		}
+		switch {
+		case !taxable:
+			at.FLAGS |= rlib.ATaxDoNotApply
+		case tax.ExemptStay(&start, &a.Start):
+			at.FLAGS |= rlib.ATaxExempt
+			refund, err := refundStayTax(ctx, a.RAID, &tax, &start, &a.Start, lc)
+			if err != nil {
+				return net, err
+			}
+			net -= refund
+		default:
+			rates, err := rlib.GetTaxRatesInRange(ctx, tax.TAXID, &a.Start, &next)
+			if err != nil {
+				return net, err
+			}
+			r := rlib.TaxRateOn(rates, &a.Start)
+			if r == nil {
+				continue // no rate in effect, nothing to record
+			}
+			at.Amount = r.Tax(a.Amount)
+			if at.Amount == 0 {
+				break
+			}
+			if tax.ARID == 0 {
+				return net, fmt.Errorf("tax %s has no account rule", tax.Name)
+			}
+			t := rlib.Assessment{
+				BID:            a.BID,
+				RID:            a.RID,
+				RAID:           a.RAID,
+				Amount:         at.Amount,
+				Start:          a.Start,
+				Stop:           a.Start,
+				RentCycle:      rlib.RECURNONE,
+				ProrationCycle: rlib.RECURNONE,
+				ARID:           tax.ARID,
+				Comment:        fmt.Sprintf("%s on %s", tax.Name, a.IDtoShortString()),
+			}
+			if a.AssocElemType == rlib.ELEMFOLIO {
+				t.AssocElemType = a.AssocElemType
+				t.AssocElemID = a.AssocElemID
+			}
+			errlist := InsertAssessment(ctx, &t, 0, lc)
+			if len(errlist) > 0 {
+				return net, BizErrorListToError(errlist)
+			}
+			at.TaxASMID = t.ASMID
+			net += at.Amount
+		}
+		if _, err = rlib.InsertAssessmentTax(ctx, &at); err != nil {
+			return net, err
+		}
+	}
+	return rlib.RoundToCent(net), nil
+}
+
+// refundStayTax refunds the tax charged by tax on the stay of rental
+// agreement raid that started on start, now that it is exempt.  The tax
+// assessments are reversed on dt and their AssessmentTax records are
+// flagged refunded on dt.
+//
+// RETURNS
+//    the amount refunded
+//    any error encountered
+//-----------------------------------------------------------------------------
+func refundStayTax(ctx context.Context, raid int64, tax *rlib.Tax, start, dt *time.Time, lc *rlib.ClosePeriod) (float64, error) {
+	var refund float64
+	m, err := rlib.GetAssessmentTaxesByRAID(ctx, raid, tax.TAXID)
+	if err != nil {
+		return refund, err
+	}
+	for i := 0; i < len(m); i++ {
+		if m[i].TaxASMID == 0 || m[i].FLAGS&rlib.ATaxRefunded != 0 || m[i].Dt.Before(*start) {
+			continue
+		}
+		t, err := rlib.GetAssessment(ctx, m[i].TaxASMID)
+		if err != nil {
+			return refund, err
+		}
+		if t.ASMID > 0 && t.FLAGS&4 == 0 {
+			errlist := ReverseAssessment(ctx, &t, 0, dt, lc)
+			if len(errlist) > 0 {
+				return refund, BizErrorListToError(errlist)
+			}
+			refund += m[i].Amount
+		}
+		m[i].FLAGS |= rlib.ATaxRefunded
+		m[i].DtRefund = *dt
+		if err = rlib.UpdateAssessmentTax(ctx, &m[i]); err != nil {
+			return refund, err
+		}
+	}
+	return refund, nil
+}
+
+// AssessTaxes taxes the rent charges of business bid that start in d1 - d2:
+// the non-recurring assessments and the instances of the recurring ones
+// whose account rule is flagged as rent.  See TaxAssessment.
+//
+// INPUTS
+//    ctx   = db context, the caller should roll back its transaction if an
+//            error is returned
+//    bid   = business id
+//    d1,d2 = time range of the charges
+//
+// RETURNS
+//    the number of charges taxed
+//    any error encountered
+//-----------------------------------------------------------------------------
+func AssessTaxes(ctx context.Context, bid int64, d1, d2 *time.Time) (int, error) {
+	var n int
+	rent, err := rentARIDs(ctx, bid)
+	if err != nil {
+		return n, err
+	}
+	taxes, err := rlib.GetTaxesByBusiness(ctx, bid)
+	if err != nil {
+		return n, err
+	}
+	for i := 0; i < len(taxes); i++ {
+		delete(rent, taxes[i].ARID) // never tax a tax
+	}
+	if len(rent) == 0 || len(taxes) == 0 {
+		return n, nil
+	}
+	lc, err := openClosePeriod(ctx, bid)
+	if err != nil {
+		return n, err
+	}
+
+	ra, err := rlib.GetRentalAgreementsByRange(ctx, bid, d1, d2)
+	if err != nil {
+		return n, err
+	}
+	for i := 0; i < len(ra); i++ {
+		m, err := rlib.GetAssessmentChargesByRAID(ctx, ra[i].RAID, d1, d2)
+		if err != nil {
+			return n, err
+		}
+		for j := 0; j < len(m); j++ {
+			if !rent[m[j].ARID] {
+				continue
+			}
+			if _, err = TaxAssessment(ctx, &m[j], &lc); err != nil {
+				return n, err
+			}
+			n++
+		}
+	}
+	return n, nil
+}
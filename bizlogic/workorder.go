@@ -0,0 +1,414 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"strings"
+	"time"
+)
+
+// A work order moves through its statuses as follows:
+//
+//    Open -> Assigned -> Scheduled -> In Progress -> Completed -> Closed
+//
+// Steps can be skipped going forward, and until the work starts it can go
+// back to an earlier step or be Canceled.  When the work order is Completed
+// its labor and material costs are posted as Expenses dated DtCompleted and
+// the chargeback, if any, is assessed to the rental agreement.  The costs of
+// a Completed work order can still be corrected: the Expenses and the
+// chargeback are reversed and posted again.  Closed and Canceled work
+// orders cannot be changed.
+
+// woTransitions lists the statuses a work order can move to from each
+// status. Staying in the same status is always allowed.
+var woTransitions = map[int64][]int64{
+	rlib.WOStatusOpen:       {rlib.WOStatusAssigned, rlib.WOStatusScheduled, rlib.WOStatusInProgress, rlib.WOStatusCompleted, rlib.WOStatusCanceled},
+	rlib.WOStatusAssigned:   {rlib.WOStatusOpen, rlib.WOStatusScheduled, rlib.WOStatusInProgress, rlib.WOStatusCompleted, rlib.WOStatusCanceled},
+	rlib.WOStatusScheduled:  {rlib.WOStatusOpen, rlib.WOStatusAssigned, rlib.WOStatusInProgress, rlib.WOStatusCompleted, rlib.WOStatusCanceled},
+	rlib.WOStatusInProgress: {rlib.WOStatusScheduled, rlib.WOStatusCompleted},
+	rlib.WOStatusCompleted:  {rlib.WOStatusClosed},
+	rlib.WOStatusClosed:     {},
+	rlib.WOStatusCanceled:   {},
+}
+
+// woCanMove returns true if a work order can go from status s1 to s2
+func woCanMove(s1, s2 int64) bool {
+	if s1 == s2 {
+		return true
+	}
+	for _, s := range woTransitions[s1] {
+		if s == s2 {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateWorkOrder checks the work order wo before it is saved.
+//
+// INPUTS
+//    ctx = db context
+//    wo  = the work order
+//    old = the work order as it is in the database, nil for a new one
+//
+// RETURNS
+//    a list of errors, nil if the work order is valid
+//-----------------------------------------------------------------------------
+func ValidateWorkOrder(ctx context.Context, wo, old *rlib.WorkOrder) []BizError {
+	var errlist []BizError
+	fields := []string{}
+
+	wo.Description = strings.TrimSpace(wo.Description)
+	wo.Vendor = strings.TrimSpace(wo.Vendor)
+	if len(wo.Description) == 0 {
+		fields = append(fields, "Description")
+	}
+	if wo.Priority < rlib.WOPriorityLow || wo.Priority > rlib.WOPriorityLast {
+		fields = append(fields, "Priority")
+	}
+	if wo.Status < rlib.WOStatusOpen || wo.Status > rlib.WOStatusLast {
+		fields = append(fields, "Status")
+	}
+	if wo.Status == rlib.WOStatusAssigned && len(wo.Vendor) == 0 {
+		fields = append(fields, "Vendor (required to assign the work order)")
+	}
+	if wo.Status == rlib.WOStatusScheduled && wo.ScheduledStart.Year() < 1971 {
+		fields = append(fields, "ScheduledStart (required to schedule the work order)")
+	}
+	if wo.ScheduledStop.Year() > 1970 && wo.ScheduledStop.Before(wo.ScheduledStart) {
+		fields = append(fields, "ScheduledStop (before ScheduledStart)")
+	}
+	if wo.LaborCost < 0 {
+		fields = append(fields, "LaborCost")
+	}
+	if wo.MaterialCost < 0 {
+		fields = append(fields, "MaterialCost")
+	}
+	if wo.ChargebackAmount < 0 {
+		fields = append(fields, "ChargebackAmount")
+	}
+
+	rnt, err := rlib.GetRentable(ctx, wo.RID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if rnt.RID == 0 || rnt.BID != wo.BID {
+		fields = append(fields, "RID")
+	}
+	if wo.RAID > 0 {
+		ra, err := rlib.GetRentalAgreement(ctx, wo.RAID)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		if ra.RAID == 0 || ra.BID != wo.BID {
+			fields = append(fields, "RAID")
+		}
+	} else if wo.ChargebackAmount > 0 {
+		fields = append(fields, "RAID (required for a chargeback)")
+	}
+	if wo.MRID > 0 {
+		mr, err := rlib.GetMaintenanceRequest(ctx, wo.MRID)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		if mr.MRID == 0 || mr.BID != wo.BID {
+			fields = append(fields, "MRID")
+		}
+	}
+
+	arules := []struct {
+		name   string
+		arid   int64
+		amt    float64
+		artype int64
+	}{
+		{"LaborARID", wo.LaborARID, wo.LaborCost, rlib.AREXPENSE},
+		{"MaterialARID", wo.MaterialARID, wo.MaterialCost, rlib.AREXPENSE},
+		{"ChargebackARID", wo.ChargebackARID, wo.ChargebackAmount, rlib.ARASSESSMENT},
+	}
+	for _, a := range arules {
+		if a.amt == 0 && a.arid == 0 {
+			continue
+		}
+		ar, err := rlib.GetAR(ctx, a.arid)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		if ar.ARID == 0 || ar.BID != wo.BID || ar.ARType != a.artype {
+			fields = append(fields, a.name)
+		}
+	}
+
+	if len(fields) > 0 {
+		msg := BizErrors[InvalidField].Message
+		for i := 0; i < len(fields); i++ {
+			msg += fmt.Sprintf("\n%s", fields[i])
+		}
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: msg})
+		return errlist
+	}
+
+	if old != nil && !woCanMove(old.Status, wo.Status) {
+		err = fmt.Errorf("work order %s cannot go from %s to %s", wo.IDtoShortString(), old.StatusName(), wo.StatusName())
+		return AddErrToBizErrlist(err, errlist)
+	}
+	return nil
+}
+
+// SaveWorkOrder inserts or updates the work order wo.  The ids of the
+// Expenses and of the chargeback Assessment are maintained here, the
+// values supplied in wo are ignored.  When the work order is Completed its
+// costs and its chargeback are posted, and if it was opened for a tenant's
+// maintenance request the request is closed.
+//
+// INPUTS
+//    ctx = db context
+//    wo  = the work order, WOID 0 to add a new one
+//
+// RETURNS
+//    a list of errors, nil if the work order was saved
+//-----------------------------------------------------------------------------
+func SaveWorkOrder(ctx context.Context, wo *rlib.WorkOrder) []BizError {
+	var (
+		err error
+		old *rlib.WorkOrder
+	)
+
+	wo.LaborEXPID = 0
+	wo.MaterialEXPID = 0
+	wo.ChargebackASMID = 0
+	if wo.WOID > 0 {
+		o, err := rlib.GetWorkOrder(ctx, wo.WOID)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		if o.WOID == 0 || o.BID != wo.BID {
+			err = fmt.Errorf("work order %d not found", wo.WOID)
+			return bizErrSys(&err)
+		}
+		if o.Status == rlib.WOStatusClosed || o.Status == rlib.WOStatusCanceled {
+			err = fmt.Errorf("work order %s is %s, it cannot be changed", o.IDtoShortString(), strings.ToLower(o.StatusName()))
+			return bizErrSys(&err)
+		}
+		wo.LaborEXPID = o.LaborEXPID
+		wo.MaterialEXPID = o.MaterialEXPID
+		wo.ChargebackASMID = o.ChargebackASMID
+		old = &o
+	}
+	if errlist := ValidateWorkOrder(ctx, wo, old); len(errlist) > 0 {
+		return errlist
+	}
+
+	now := time.Now()
+	if wo.Dt.Year() < 1971 {
+		wo.Dt = now
+	}
+	if wo.Status >= rlib.WOStatusCompleted && wo.Status != rlib.WOStatusCanceled && wo.DtCompleted.Year() < 1971 {
+		wo.DtCompleted = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, rlib.RRdb.Zone)
+	}
+
+	if wo.WOID == 0 {
+		_, err = rlib.InsertWorkOrder(ctx, wo)
+	} else {
+		err = rlib.UpdateWorkOrder(ctx, wo)
+	}
+	if err != nil {
+		return bizErrSys(&err)
+	}
+
+	//-------------------------------------------------------------------
+	// Post the costs when the work is completed, and repost them if they
+	// are changed afterwards.  Closing the work order does not change
+	// anything that was posted.
+	//-------------------------------------------------------------------
+	if wo.Status == rlib.WOStatusCompleted {
+		if errlist := postWorkOrder(ctx, wo, &now); len(errlist) > 0 {
+			return errlist
+		}
+		if err = rlib.UpdateWorkOrder(ctx, wo); err != nil {
+			return bizErrSys(&err)
+		}
+	}
+	if err = updateWorkOrderRequest(ctx, wo, old); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// postWorkOrder posts the labor and material costs of work order wo as
+// Expenses and its chargeback as an Assessment.  Anything already posted
+// whose amount, account rule or date changed is reversed and posted again.
+//
+// INPUTS
+//    ctx = db context
+//    wo  = the work order, it is updated with the ids of what was posted
+//    dt  = date of the reversals, if any
+//
+// RETURNS
+//    a list of errors, nil if everything was posted
+//-----------------------------------------------------------------------------
+func postWorkOrder(ctx context.Context, wo *rlib.WorkOrder, dt *time.Time) []BizError {
+	lc, err := openClosePeriod(ctx, wo.BID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+
+	if errlist := postWorkOrderExpense(ctx, wo, &lc, wo.LaborCost, wo.LaborARID, &wo.LaborEXPID, "Labor", dt); len(errlist) > 0 {
+		return errlist
+	}
+	if errlist := postWorkOrderExpense(ctx, wo, &lc, wo.MaterialCost, wo.MaterialARID, &wo.MaterialEXPID, "Materials", dt); len(errlist) > 0 {
+		return errlist
+	}
+	return postWorkOrderChargeback(ctx, wo, &lc, dt)
+}
+
+// postWorkOrderExpense posts one of the costs of work order wo.  When the
+// cost changes, the expense posted before is reversed and the new cost is
+// posted as a new expense.
+//
+// INPUTS
+//    ctx   = db context
+//    wo    = the work order
+//    lc    = the last close period
+//    amt   = the cost
+//    arid  = the expense account rule for the cost
+//    expid = the id of the expense posted for the cost, it is updated
+//    label = what the cost is for, used in the comment of the expense
+//    dt    = date of the reversal, if any
+//
+// RETURNS
+//    a list of errors, nil if the cost was posted
+//-----------------------------------------------------------------------------
+func postWorkOrderExpense(ctx context.Context, wo *rlib.WorkOrder, lc *rlib.ClosePeriod, amt float64, arid int64, expid *int64, label string, dt *time.Time) []BizError {
+	if *expid == 0 {
+		if amt == 0 {
+			return nil
+		}
+		if !wo.DtCompleted.After(lc.Dt) {
+			err := fmt.Errorf("%s cannot be posted because its completion date (%s) is in a closed period", wo.IDtoShortString(), wo.DtCompleted.Format(rlib.RRDATEFMT3))
+			return bizErrSys(&err)
+		}
+		x := rlib.Expense{
+			BID:     wo.BID,
+			RID:     wo.RID,
+			RAID:    wo.RAID,
+			Amount:  amt,
+			Dt:      wo.DtCompleted,
+			ARID:    arid,
+			Comment: fmt.Sprintf("%s for work order %s", label, wo.IDtoShortString()),
+		}
+		if _, err := rlib.InsertExpense(ctx, &x); err != nil {
+			return bizErrSys(&err)
+		}
+		var xbiz rlib.XBusiness
+		if err := rlib.ProcessNewExpense(ctx, &x, &xbiz); err != nil {
+			return bizErrSys(&err)
+		}
+		*expid = x.EXPID
+		return nil
+	}
+
+	x, err := rlib.GetExpense(ctx, *expid)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if x.Amount == amt && x.ARID == arid && x.Dt.Equal(wo.DtCompleted) {
+		return nil // nothing changed
+	}
+	if !x.Dt.After(lc.Dt) || !wo.DtCompleted.After(lc.Dt) {
+		err = fmt.Errorf("the %s cost of %s cannot be changed because it is in a closed period", strings.ToLower(label), wo.IDtoShortString())
+		return bizErrSys(&err)
+	}
+	if errlist := ReverseExpense(ctx, &x, dt); len(errlist) > 0 {
+		return errlist
+	}
+	*expid = 0
+	return postWorkOrderExpense(ctx, wo, lc, amt, arid, expid, label, dt) // post the new cost
+}
+
+// postWorkOrderChargeback assesses the chargeback of work order wo to its
+// rental agreement.  The assessment is dated DtCompleted, InsertAssessment
+// moves it to the open period if needed.
+//
+// INPUTS
+//    ctx = db context
+//    wo  = the work order, ChargebackASMID is updated
+//    lc  = the last close period
+//    dt  = date of the reversal, if any
+//
+// RETURNS
+//    a list of errors, nil if the chargeback was posted
+//-----------------------------------------------------------------------------
+func postWorkOrderChargeback(ctx context.Context, wo *rlib.WorkOrder, lc *rlib.ClosePeriod, dt *time.Time) []BizError {
+	if wo.ChargebackASMID > 0 {
+		a, err := rlib.GetAssessment(ctx, wo.ChargebackASMID)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		if a.Amount == wo.ChargebackAmount && a.ARID == wo.ChargebackARID && a.RAID == wo.RAID {
+			return nil // nothing changed
+		}
+		if errlist := ReverseAssessment(ctx, &a, 0, dt, lc); len(errlist) > 0 {
+			return errlist
+		}
+		wo.ChargebackASMID = 0
+	}
+	if wo.ChargebackAmount == 0 {
+		return nil
+	}
+	a := rlib.Assessment{
+		BID:            wo.BID,
+		RID:            wo.RID,
+		RAID:           wo.RAID,
+		Amount:         wo.ChargebackAmount,
+		Start:          wo.DtCompleted,
+		Stop:           wo.DtCompleted,
+		RentCycle:      rlib.RECURNONE,
+		ProrationCycle: rlib.RECURNONE,
+		ARID:           wo.ChargebackARID,
+		Comment:        fmt.Sprintf("Repair charge, work order %s: %s", wo.IDtoShortString(), wo.Description),
+	}
+	if errlist := InsertAssessment(ctx, &a, 0, lc); len(errlist) > 0 {
+		return errlist
+	}
+	wo.ChargebackASMID = a.ASMID
+	return nil
+}
+
+// updateWorkOrderRequest keeps the tenant's maintenance request in step with
+// the work order opened for it: the request is accepted when the work order
+// is created and closed when the work is completed.
+//
+// INPUTS
+//    ctx = db context
+//    wo  = the work order that was saved
+//    old = the work order before it was saved, nil if it is new
+//
+// RETURNS
+//    any error encountered
+//-----------------------------------------------------------------------------
+func updateWorkOrderRequest(ctx context.Context, wo, old *rlib.WorkOrder) error {
+	if wo.MRID == 0 {
+		return nil
+	}
+	mr, err := rlib.GetMaintenanceRequest(ctx, wo.MRID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case wo.Status == rlib.WOStatusCompleted && (old == nil || old.Status != rlib.WOStatusCompleted):
+		if mr.Status == rlib.MRStatusClosed {
+			return nil
+		}
+		mr.Status = rlib.MRStatusClosed
+		if len(mr.Response) == 0 {
+			mr.Response = fmt.Sprintf("The work was completed on %s.", wo.DtCompleted.Format(rlib.RRDATEFMT3))
+		}
+	case mr.Status == rlib.MRStatusOpen && wo.Status != rlib.WOStatusCanceled:
+		mr.Status = rlib.MRStatusAccepted
+	default:
+		return nil
+	}
+	return rlib.UpdateMaintenanceRequest(ctx, &mr)
+}
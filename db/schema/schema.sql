@@ -36,12 +36,14 @@ CREATE TABLE TBind (
 CREATE TABLE Tax (
     TAXID BIGINT NOT NULL AUTO_INCREMENT,                   -- unique identifier for this tax
     BID BIGINT NOT NULL DEFAULT 0,                          -- what business is this tax associated with
-    Name VARCHAR(50),                                       -- a name for this tax
-    TaxingAuthority VARCHAR(100),                           -- name of the Taxing Authority
-    TaxingAuthorityAddress VARCHAR(256),                    -- where these taxes are sent
+    Name VARCHAR(50) NOT NULL DEFAULT '',                   -- a name for this tax
+    TaxingAuthority VARCHAR(100) NOT NULL DEFAULT '',       -- name of the Taxing Authority, the jurisdiction
+    TaxingAuthorityAddress VARCHAR(256) NOT NULL DEFAULT '',-- where these taxes are sent
     FilingDate DATE NOT NULL DEFAULT '1970-01-01',          -- date on which taxes need to be filed
     FilingCycle BIGINT NOT NULL DEFAULT 0,                  -- epoch date for recurrence calculation
     Instructions VARCHAR(1024) NOT NULL DEFAULT '',         -- filing instructions
+    ARID BIGINT NOT NULL DEFAULT 0,                         -- account rule of the tax assessments
+    ExemptDays BIGINT NOT NULL DEFAULT 0,                   -- stays longer than this many days are exempt, 0 = no exemption
     LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
     LastModBy BIGINT NOT NULL DEFAULT 0,                    -- employee UID (from phonebook) that modified it
     CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,  -- when was this record created
@@ -50,17 +52,19 @@ CREATE TABLE Tax (
 );
 
 CREATE TABLE TaxRate (
+    TRID BIGINT NOT NULL AUTO_INCREMENT,                    -- unique identifier for this rate
     TAXID BIGINT NOT NULL DEFAULT 0,                        -- reference to which tax this table represents
     BID BIGINT NOT NULL DEFAULT 0,                          -- what business is this tax associated with
     DtStart DATE NOT NULL DEFAULT '1970-01-01 00:00:00',    -- date when this tax rate goes into effect
     DtStop DATE NOT NULL DEFAULT '1970-01-01 00:00:00',     -- date when this tax rate is no longer applicable
-    Rate DECIMAL(19,4) NOT NULL DEFAULT 0,                  -- floating point number representing the rate. Set to 0 if not applicable.
-    Fee DECIMAL(19,4) NOT NULL DEFAULT 0,                   -- floating point number.  Set to 0 if not applicable.
-    Formula VARCHAR(256) NOT NULL DEFAULT '',               -- RPN calculator notation of formula, Set to '' if not needed
+    Rate DECIMAL(19,4) NOT NULL DEFAULT 0,                  -- percent of the charge (12.5 = 12.5%). Set to 0 if not applicable.
+    Fee DECIMAL(19,4) NOT NULL DEFAULT 0,                   -- amount added for each charge taxed.  Set to 0 if not applicable.
+    Formula VARCHAR(256) NOT NULL DEFAULT '',               -- RPN calculator notation of formula, Set to '' if not needed. Not used yet.
     LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
     LastModBy BIGINT NOT NULL DEFAULT 0,                    -- employee UID (from phonebook) that modified it
     CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,  -- when was this record created
-    CreateBy BIGINT NOT NULL DEFAULT 0                      -- employee UID (from phonebook) that created this record
+    CreateBy BIGINT NOT NULL DEFAULT 0,                     -- employee UID (from phonebook) that created this record
+    PRIMARY KEY(TRID)
 );
 
 CREATE TABLE StringList (
@@ -200,7 +204,7 @@ CREATE TABLE RentalAgreement (
     BaseYearEnd DATE NOT NULL DEFAULT '1970-01-01 00:00:00',            -- last day of the base year
     ExpenseAdjustment DATE NOT NULL DEFAULT '1970-01-01 00:00:00',      -- the next date on which an expense adjustment is due
     EstimatedCharges DECIMAL(19,4) NOT NULL DEFAULT 0,                  -- a periodic fee charged to the tenant to reimburse LL for anticipated expenses
-    RateChange DECIMAL(19,4) NOT NULL DEFAULT 0,                        -- predetermined amount of rent increase, expressed as a percentage
+    RateChange DECIMAL(19,4) NOT NULL DEFAULT 0,                        -- predetermined amount of rent increase, expressed as a percentage (or a fixed amount if FLAGS & (1<<7))
     CSAgent BIGINT NOT NULL DEFAULT 0,                                  -- Accord Directory UserID - for the CSAgent
     NextRateChange DATE NOT NULL DEFAULT '1970-01-01 00:00:00',         -- the next date on which a RateChange will occur
     PermittedUses VARCHAR(128) NOT NULL DEFAULT '',                     -- indicates primary use of the space, ex: doctor's office, or warehouse/distribution, etc.
@@ -216,6 +220,8 @@ CREATE TABLE RentalAgreement (
                                                                            1<<4 - Approver1 decision, only valid if Approver1 > 0, 0 = Declined, 1 = Approved
                                                                            1<<5 - Approver2 decision, only valid if Approver2 > 0, 0 = Declined, 1 = Approved
                                                                            1<<6 - VOID indicator: 0 = not voided, 1 = this RentalAgreement was voided - before its term arrived it was amended with a new Rental Agreement
+                                                                           1<<7 - RateChange type: 0 = percentage of the rent, 1 = fixed amount added to the rent
+                                                                           1<<8 - month to month: 1 = the term ended and the agreement was extended month to month (Renewal = 1)
            bits 0:3
         -------------  ---------------------------     --------------------------------------
         (FLAGS & 0xF)  State                           Meaning
@@ -266,6 +272,33 @@ CREATE TABLE RentalAgreement (
     PRIMARY KEY (RAID)
 );
 
+-- A renewal offer for one rentable of a rental agreement that is about to
+-- expire.  The offers of an agreement are accepted together, this creates
+-- the amended agreement NewRAID for the new term at the offered rents.
+CREATE TABLE RenewalOffer (
+    ROID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id for this offer
+    BID BIGINT NOT NULL DEFAULT 0,                              -- business id
+    RAID BIGINT NOT NULL DEFAULT 0,                             -- the expiring rental agreement
+    RID BIGINT NOT NULL DEFAULT 0,                              -- the rentable
+    ASMID BIGINT NOT NULL DEFAULT 0,                            -- the recurring rent assessment being renewed
+    ARID BIGINT NOT NULL DEFAULT 0,                             -- account rule of the rent assessment
+    CurrentRent DECIMAL(19,4) NOT NULL DEFAULT 0.0,             -- rent at the end of the current term
+    MarketRent DECIMAL(19,4) NOT NULL DEFAULT 0.0,              -- market rate of the rentable for the new term
+    OfferRent DECIMAL(19,4) NOT NULL DEFAULT 0.0,               -- rent offered for the new term
+    TermStart DATE NOT NULL DEFAULT '1970-01-01 00:00:00',      -- start of the new term, the AgreementStop of RAID
+    TermStop DATE NOT NULL DEFAULT '1970-01-01 00:00:00',       -- stop of the new term
+    OfferDt DATE NOT NULL DEFAULT '1970-01-01 00:00:00',        -- date the offer was made
+    ExpireDt DATE NOT NULL DEFAULT '1970-01-01 00:00:00',       -- the offer must be accepted before this date
+    Status SMALLINT NOT NULL DEFAULT 0,                         -- 0 = open, 1 = accepted, 2 = declined, 3 = expired
+    NewRAID BIGINT NOT NULL DEFAULT 0,                          -- the amended rental agreement, once accepted
+    Comment VARCHAR(256) NOT NULL DEFAULT '',                   -- note
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (ROID)
+);
+
 CREATE TABLE RentalAgreementRentables (
     RARID BIGINT NOT NULL AUTO_INCREMENT,                     -- internal unique id
     RAID BIGINT NOT NULL DEFAULT 0,                           -- Rental Agreement id
@@ -313,6 +346,7 @@ CREATE TABLE RentableUsers (
 );
 
 CREATE TABLE RentalAgreementTax (
+    RATAXID BIGINT NOT NULL AUTO_INCREMENT,                   -- unique id
     RAID BIGINT NOT NULL DEFAULT 0,                           -- Rental Agreement id
     BID BIGINT NOT NULL DEFAULT 0,                            -- Business (so that we can process by Business)
     DtStart DATE NOT NULL DEFAULT '1970-01-01 00:00:00',      -- date when this flag went into effect
@@ -321,7 +355,8 @@ CREATE TABLE RentalAgreementTax (
     LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
     LastModBy BIGINT NOT NULL DEFAULT 0,                      -- employee UID (from phonebook) that modified it
     CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,    -- when was this record created
-    CreateBy BIGINT NOT NULL DEFAULT 0                        -- employee UID (from phonebook) that created this record
+    CreateBy BIGINT NOT NULL DEFAULT 0,                       -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (RATAXID)
 );
 
 CREATE TABLE Pets (
@@ -648,9 +683,107 @@ CREATE TABLE RentableMarketRate (
     PRIMARY KEY (RMRID)
 );
 
+-- A market rate recommended for a RentableType by the pricing engine.  Once
+-- approved, AppliedRate is written as the market rate of the type starting
+-- on DtStart.
+CREATE TABLE RateRecommendation (
+    RCMID BIGINT NOT NULL AUTO_INCREMENT,                       -- unique id for this recommendation
+    BID BIGINT NOT NULL DEFAULT 0,                              -- business id
+    RTID BIGINT NOT NULL DEFAULT 0,                             -- the rentable type
+    DtStart DATE NOT NULL DEFAULT '1970-01-01 00:00:00',        -- the recommended rate starts on this date
+    DtStop DATE NOT NULL DEFAULT '9999-12-31 00:00:00',         -- and stops on this date
+    CurrentRate DECIMAL(19,4) NOT NULL DEFAULT 0.0,             -- market rate in force when the recommendation was made
+    RecommendedRate DECIMAL(19,4) NOT NULL DEFAULT 0.0,         -- the rate recommended
+    AppliedRate DECIMAL(19,4) NOT NULL DEFAULT 0.0,             -- the rate approved, 0 until approved
+    Rentables BIGINT NOT NULL DEFAULT 0,                        -- number of rentables of the type
+    Occupied BIGINT NOT NULL DEFAULT 0,                         -- how many of them were occupied
+    MoveOuts BIGINT NOT NULL DEFAULT 0,                         -- notices to move out within the look ahead period
+    AvgDaysVacant DECIMAL(19,4) NOT NULL DEFAULT 0.0,           -- average length of the vacancies of the history period
+    SeasonalPct DECIMAL(19,4) NOT NULL DEFAULT 0.0,             -- seasonal adjustment, percent
+    Status SMALLINT NOT NULL DEFAULT 0,                         -- 0 = open, 1 = approved, 2 = rejected
+    Comment VARCHAR(256) NOT NULL DEFAULT '',                   -- note
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (RCMID)
+);
+
+-- A reservation received from a channel in an OTA_HotelResNotifRQ message.
+-- ResID is the channel's confirmation number; a message for a ResID already
+-- received updates or cancels the reservation it created.  The reservation
+-- itself is the RentableLeaseStatus of RID with ConfirmationCode.
+CREATE TABLE OTAReservation (
+    ORID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id for this reservation
+    BID BIGINT NOT NULL DEFAULT 0,                              -- business id
+    RequestorID VARCHAR(100) NOT NULL DEFAULT '',               -- the channel that sent it
+    ResID VARCHAR(100) NOT NULL DEFAULT '',                     -- the channel's confirmation number
+    RID BIGINT NOT NULL DEFAULT 0,                              -- the rentable reserved
+    RTID BIGINT NOT NULL DEFAULT 0,                             -- its rentable type
+    DtStart DATE NOT NULL DEFAULT '1970-01-01 00:00:00',        -- arrival
+    DtStop DATE NOT NULL DEFAULT '1970-01-01 00:00:00',         -- departure
+    ConfirmationCode VARCHAR(100) NOT NULL DEFAULT '',          -- ConfirmationCode of the RentableLeaseStatus
+    Status SMALLINT NOT NULL DEFAULT 0,                         -- 0 = booked, 1 = cancelled
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (ORID),
+    UNIQUE KEY (BID, RequestorID, ResID)
+);
+
+-- A guest folio: the account of one stay.  Its room charges, taxes and
+-- incidentals are Assessments of the RentalAgreement with AssocElemType
+-- ELEMFOLIO and AssocElemID FOLID.  The night audit posts the room and tax
+-- charges of each night of the stay.
+CREATE TABLE Folio (
+    FOLID BIGINT NOT NULL AUTO_INCREMENT,                       -- unique id for this folio
+    BID BIGINT NOT NULL DEFAULT 0,                              -- business id
+    RAID BIGINT NOT NULL DEFAULT 0,                             -- the rental agreement of the stay
+    RID BIGINT NOT NULL DEFAULT 0,                              -- the rentable (room)
+    RTID BIGINT NOT NULL DEFAULT 0,                             -- its rentable type
+    TCID BIGINT NOT NULL DEFAULT 0,                             -- the guest
+    DtArrive DATE NOT NULL DEFAULT '1970-01-01 00:00:00',       -- arrival
+    DtDepart DATE NOT NULL DEFAULT '1970-01-01 00:00:00',       -- departure
+    Adults BIGINT NOT NULL DEFAULT 0,                           -- adults staying
+    Children BIGINT NOT NULL DEFAULT 0,                         -- children staying
+    Rate DECIMAL(19,4) NOT NULL DEFAULT 0.0,                    -- nightly room rate, 0 to use the rate plans
+    PostedThrough DATE NOT NULL DEFAULT '1970-01-01 00:00:00',  -- the nights before this date have been posted
+    Status SMALLINT NOT NULL DEFAULT 0,                         -- 0 = open, 1 = closed
+    Comment VARCHAR(256) NOT NULL DEFAULT '',                   -- note
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (FOLID)
+);
+
+-- A night audit: the room and tax charges of a business date were posted to
+-- the folios in house and the business date was rolled to the next day.
+-- The figures of the night are kept for the night audit report.
+CREATE TABLE NightAudit (
+    NAID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id for this night audit
+    BID BIGINT NOT NULL DEFAULT 0,                              -- business id
+    Dt DATE NOT NULL DEFAULT '1970-01-01 00:00:00',             -- the business date audited
+    Rooms BIGINT NOT NULL DEFAULT 0,                            -- rentables rented daily
+    Occupied BIGINT NOT NULL DEFAULT 0,                         -- how many of them were in house
+    Arrivals BIGINT NOT NULL DEFAULT 0,                         -- stays that arrived
+    Departures BIGINT NOT NULL DEFAULT 0,                       -- stays that departed
+    RoomRevenue DECIMAL(19,4) NOT NULL DEFAULT 0.0,             -- room charges posted
+    TaxRevenue DECIMAL(19,4) NOT NULL DEFAULT 0.0,              -- taxes posted on the room charges
+    Comment VARCHAR(256) NOT NULL DEFAULT '',                   -- note
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (NAID),
+    UNIQUE KEY (BID, Dt)
+);
+
 -- RentableType RTID needs to have tax TAXID applied to rental assessments.
 -- There can be as many of these records as needed per rentable type.
 CREATE TABLE RentableTypeTax (
+    RTTAXID BIGINT NOT NULL AUTO_INCREMENT,                     -- unique id
     RTID BIGINT NOT NULL DEFAULT 0,                             -- associated Rentable type
     BID BIGINT NOT NULL DEFAULT 0,                              -- associated Business id
     TAXID BIGINT NOT NULL DEFAULT 0,                            -- which tax
@@ -659,7 +792,8 @@ CREATE TABLE RentableTypeTax (
     LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
     LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
     CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
-    CreateBy BIGINT NOT NULL DEFAULT 0                          -- employee UID (from phonebook) that created this record
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (RTTAXID)
 );
 
 -- ===========================================
@@ -1121,16 +1255,26 @@ CREATE TABLE Assessments (
 
 -- the actual tax rate or fee will be read from the TaxRate table based on the instance date of the assessment
 CREATE TABLE AssessmentTax (
+    ATID BIGINT NOT NULL AUTO_INCREMENT,                    -- unique id
     ASMID BIGINT NOT NULL DEFAULT 0,                        -- the assessment to which this tax is bound
     BID BIGINT NOT NULL DEFAULT 0,                          -- Business id
+    RAID BIGINT NOT NULL DEFAULT 0,                         -- rental agreement of the assessment
     TAXID BIGINT NOT NULL DEFAULT 0,                        -- what type of tax.
+    TaxASMID BIGINT NOT NULL DEFAULT 0,                     -- the tax assessment, 0 if no tax was charged
+    Dt DATE NOT NULL DEFAULT '1970-01-01 00:00:00',         -- date of the assessment, the tax rate in effect on it applies
+    Base DECIMAL(19,4) NOT NULL DEFAULT 0,                  -- amount of the assessment taxed
+    Amount DECIMAL(19,4) NOT NULL DEFAULT 0,                -- tax charged
     FLAGS BIGINT NOT NULL DEFAULT 0,                        -- bit 0 = override this tax -- do not apply, bit 1 - override and use OverrideAmount
+                                                            -- bit 2 = exempt stay, bit 3 = refunded when the stay became exempt
+    DtRefund DATE NOT NULL DEFAULT '1970-01-01 00:00:00',   -- date the tax was refunded, the date of the reversal
     OverrideTaxApprover MEDIUMINT NOT NULL DEFAULT 0,       -- if tax is overridden, who approved it
     OverrideAmount DECIMAL(19,4) NOT NULL DEFAULT 0,        -- Don't calculate. Use this amount. OverrideApprover required.  0 if not applicable.
+    Comment VARCHAR(256) NOT NULL DEFAULT '',               -- note
     LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
     LastModBy BIGINT NOT NULL DEFAULT 0,                    -- employee UID (from phonebook) that modified it
     CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,  -- when was this record created
-    CreateBy BIGINT NOT NULL DEFAULT 0                      -- employee UID (from phonebook) that created this record
+    CreateBy BIGINT NOT NULL DEFAULT 0,                     -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (ATID)
 );
 
 -- **************************************
@@ -1233,6 +1377,7 @@ CREATE TABLE Receipt (
     AcctRuleApply VARCHAR(4096) NOT NULL DEFAULT '',            -- How the funds will be applied
     FLAGS BIGINT NOT NULL DEFAULT 0,                            /* bits 0-1 : 0 unallocated, 1 = partially allocated, 2 = fully allocated,
                                                                  *     1<<2 : This receipt is reversed
+                                                                 *     1<<3 : ACH debit sent to the bank, not yet settled
                                                                  */
     Comment VARCHAR(256) NOT NULL DEFAULT '',                   -- for comments like "Prior Period Adjustment"
     OtherPayorName VARCHAR(128) NOT NULL DEFAULT '',            -- If not '' then Payment was made by a payor who is not on the RA, and may not be in our system at all
@@ -1297,7 +1442,7 @@ CREATE TABLE Deposit (
     Dt DATE NOT NULL DEFAULT '1970-01-01 00:00:00',             -- Date of deposit
     Amount DECIMAL(19,4) NOT NULL DEFAULT 0.0,                  -- total amount of all Receipts in this deposit
     ClearedAmount DECIMAL(19,4) NOT NULL DEFAULT 0.0,           -- Amount cleared by the bank
-    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- bitflags
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 = the bank has cleared this deposit (ClearedAmount is valid)
     LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
     LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
     CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
@@ -1317,6 +1462,390 @@ CREATE TABLE DepositPart (
     PRIMARY KEY (DPID)
 );
 
+-- **************************************
+-- ****                              ****
+-- ****       BANK STATEMENTS        ****
+-- ****                              ****
+-- **************************************
+-- A bank statement file (OFX, QFX, or CSV) imported for a Depository
+CREATE TABLE BankStatement (
+    BSID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id for this bank statement
+    BID BIGINT NOT NULL DEFAULT 0,                              -- business id
+    DEPID BIGINT NOT NULL DEFAULT 0,                            -- the Depository this statement is for
+    FileName VARCHAR(256) NOT NULL DEFAULT '',                  -- name of the file that was imported
+    DtStart DATE NOT NULL DEFAULT '1970-01-01 00:00:00',        -- first day covered by the statement
+    DtStop DATE NOT NULL DEFAULT '1970-01-01 00:00:00',         -- last day covered by the statement
+    ClosingBalance DECIMAL(19,4) NOT NULL DEFAULT 0.0,          -- ledger balance reported by the bank at DtStop
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 = ClosingBalance was supplied by the bank
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (BSID)
+);
+
+-- One transaction from a bank statement.  When it is matched to a Deposit,
+-- DID is set.  When the match is confirmed, the Deposit is marked as cleared.
+CREATE TABLE BankStatementLine (
+    BSLID BIGINT NOT NULL AUTO_INCREMENT,                       -- unique id for this line
+    BSID BIGINT NOT NULL DEFAULT 0,                             -- the bank statement this line came from
+    BID BIGINT NOT NULL DEFAULT 0,                              -- business id
+    DEPID BIGINT NOT NULL DEFAULT 0,                            -- the Depository
+    Dt DATE NOT NULL DEFAULT '1970-01-01 00:00:00',             -- date the bank posted the transaction
+    Amount DECIMAL(19,4) NOT NULL DEFAULT 0.0,                  -- amount, credits are positive, debits are negative
+    FITID VARCHAR(256) NOT NULL DEFAULT '',                     -- the bank's transaction id, used to detect duplicate imports
+    Description VARCHAR(256) NOT NULL DEFAULT '',               -- payee / description / memo from the bank
+    DID BIGINT NOT NULL DEFAULT 0,                              -- Deposit matched to this line, 0 = unmatched
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 = match confirmed
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (BSLID)
+);
+
+-- **************************************
+-- ****                              ****
+-- ****          ACH AUTOPAY         ****
+-- ****                              ****
+-- **************************************
+-- A payor's authorization to debit their bank account for amounts due.
+-- The routing and account numbers are encrypted.
+CREATE TABLE ACHEnrollment (
+    AEID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id for this enrollment
+    BID BIGINT NOT NULL DEFAULT 0,                              -- business id
+    TCID BIGINT NOT NULL DEFAULT 0,                             -- the payor
+    AccountName VARCHAR(100) NOT NULL DEFAULT '',               -- name on the bank account
+    RoutingNumber CHAR(128) NOT NULL DEFAULT '',                -- encrypted 9 digit routing number
+    AccountNumber CHAR(128) NOT NULL DEFAULT '',                -- encrypted account number
+    MaxAmount DECIMAL(19,4) NOT NULL DEFAULT 0.0,               -- largest amount that can be debited at one time, 0 = no limit
+    DtStart DATE NOT NULL DEFAULT '1970-01-01 00:00:00',        -- first day the account may be debited
+    DtStop DATE NOT NULL DEFAULT '9999-12-31 00:00:00',         -- account may be debited up to but not including this date
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 = savings account (otherwise checking), 1<<1 = suspended
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (AEID)
+);
+
+-- A batch of ACH debits sent to the bank in one NACHA file
+CREATE TABLE ACHBatch (
+    ABID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id for this batch, also the NACHA batch number
+    BID BIGINT NOT NULL DEFAULT 0,                              -- business id
+    Dt DATE NOT NULL DEFAULT '1970-01-01 00:00:00',             -- effective entry date of the debits
+    EntryCount BIGINT NOT NULL DEFAULT 0,                       -- number of debits in the batch
+    TotalAmount DECIMAL(19,4) NOT NULL DEFAULT 0.0,             -- total of all debits
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 = settled
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (ABID)
+);
+
+-- One debit in an ACHBatch.  The Receipt for the debit is RCPTID.
+CREATE TABLE ACHEntry (
+    AENID BIGINT NOT NULL AUTO_INCREMENT,                       -- unique id for this entry
+    ABID BIGINT NOT NULL DEFAULT 0,                             -- the batch
+    BID BIGINT NOT NULL DEFAULT 0,                              -- business id
+    AEID BIGINT NOT NULL DEFAULT 0,                             -- the enrollment that authorized the debit
+    TCID BIGINT NOT NULL DEFAULT 0,                             -- the payor
+    RCPTID BIGINT NOT NULL DEFAULT 0,                           -- the Receipt created for this debit
+    Amount DECIMAL(19,4) NOT NULL DEFAULT 0.0,                  -- amount debited
+    TraceNumber VARCHAR(15) NOT NULL DEFAULT '',                -- 15 digit NACHA trace number
+    ReturnCode VARCHAR(3) NOT NULL DEFAULT '',                  -- R-code if the bank returned the debit
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 = returned
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (AENID)
+);
+
+-- **************************************
+-- ****                              ****
+-- ****  SECURITY DEPOSIT SETTLEMENT ****
+-- ****                              ****
+-- **************************************
+-- The move-out settlement of the security deposits held for a Rental
+-- Agreement. The deposits are applied against the unpaid assessments and
+-- itemized damages, the rest is refunded or the shortfall is assessed.
+CREATE TABLE SecDepSettlement (
+    SDSID BIGINT NOT NULL AUTO_INCREMENT,                       -- unique id for this settlement
+    BID BIGINT NOT NULL DEFAULT 0,                              -- business id
+    RAID BIGINT NOT NULL DEFAULT 0,                             -- the rental agreement
+    TCID BIGINT NOT NULL DEFAULT 0,                             -- payor who receives the refund or owes the balance
+    Dt DATE NOT NULL DEFAULT '1970-01-01 00:00:00',             -- move-out date
+    DepositHeld DECIMAL(19,4) NOT NULL DEFAULT 0.0,             -- security deposits held on Dt
+    Charges DECIMAL(19,4) NOT NULL DEFAULT 0.0,                 -- unpaid assessments on Dt
+    Damages DECIMAL(19,4) NOT NULL DEFAULT 0.0,                 -- itemized damage charges
+    DepositApplied DECIMAL(19,4) NOT NULL DEFAULT 0.0,          -- deposits applied to charges and damages
+    Refund DECIMAL(19,4) NOT NULL DEFAULT 0.0,                  -- deposits returned to the payor
+    BalanceDue DECIMAL(19,4) NOT NULL DEFAULT 0.0,              -- amount still owed after the deposits were applied
+    RCPTID BIGINT NOT NULL DEFAULT 0,                           -- receipt that applied the deposits to unpaid assessments
+    DMGEXPID BIGINT NOT NULL DEFAULT 0,                         -- expense that applied the deposits to damages
+    REFEXPID BIGINT NOT NULL DEFAULT 0,                         -- expense for the refund
+    BDASMID BIGINT NOT NULL DEFAULT 0,                          -- assessment for damages the deposits did not cover
+    ForwardAddress VARCHAR(256) NOT NULL DEFAULT '',            -- where the letter and refund are sent
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 = posted
+    Comment VARCHAR(256) NOT NULL DEFAULT '',                   -- notes
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (SDSID)
+);
+
+-- One line of a SecDepSettlement: an itemized damage or an unpaid assessment
+CREATE TABLE SecDepItem (
+    SDIID BIGINT NOT NULL AUTO_INCREMENT,                       -- unique id for this item
+    SDSID BIGINT NOT NULL DEFAULT 0,                            -- the settlement
+    BID BIGINT NOT NULL DEFAULT 0,                              -- business id
+    ItemType BIGINT NOT NULL DEFAULT 0,                         -- 0 = damage, 1 = unpaid assessment
+    ASMID BIGINT NOT NULL DEFAULT 0,                            -- the unpaid assessment if ItemType = 1
+    RID BIGINT NOT NULL DEFAULT 0,                              -- the rentable
+    Description VARCHAR(256) NOT NULL DEFAULT '',               -- what the charge is for
+    Amount DECIMAL(19,4) NOT NULL DEFAULT 0.0,                  -- amount charged
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (SDIID)
+);
+
+-- **************************************
+-- ****                              ****
+-- ****      CAM RECONCILIATION      ****
+-- ****                              ****
+-- **************************************
+-- The annual reconciliation of the operating expenses passed through to
+-- the tenant of a commercial Rental Agreement. The tenant's pro-rata share
+-- of the expenses is compared with the estimated charges already assessed
+-- and the difference is assessed or credited.
+CREATE TABLE CAMReconciliation (
+    CAMRID BIGINT NOT NULL AUTO_INCREMENT,                      -- unique id for this reconciliation
+    BID BIGINT NOT NULL DEFAULT 0,                              -- business id
+    RAID BIGINT NOT NULL DEFAULT 0,                             -- the rental agreement
+    RID BIGINT NOT NULL DEFAULT 0,                              -- the (first) rentable of the rental agreement
+    TCID BIGINT NOT NULL DEFAULT 0,                             -- payor who is billed or credited
+    Dt1 DATE NOT NULL DEFAULT '1970-01-01 00:00:00',            -- start of the period
+    Dt2 DATE NOT NULL DEFAULT '1970-01-01 00:00:00',            -- end of the period (not included)
+    LeaseType BIGINT NOT NULL DEFAULT 0,                        -- RentalAgreement.LeaseType: 2 = Modified Gross, 3 = Triple Net
+    ExpenseAdjustmentType BIGINT NOT NULL DEFAULT 0,            -- RentalAgreement.ExpenseAdjustmentType: 0 = Base Year, 1 = No Base Year, 2 = Pass Through
+    SqFt BIGINT NOT NULL DEFAULT 0,                             -- square feet of the tenant's rentables
+    TotalSqFt BIGINT NOT NULL DEFAULT 0,                        -- square feet of all rentables of the business
+    Occupancy DECIMAL(19,4) NOT NULL DEFAULT 0.0,               -- fraction of the period covered by the rental agreement
+    Expenses DECIMAL(19,4) NOT NULL DEFAULT 0.0,                -- recoverable operating expenses in the period
+    BaseExpenses DECIMAL(19,4) NOT NULL DEFAULT 0.0,            -- recoverable operating expenses in the base year
+    ExpensesStop DECIMAL(19,4) NOT NULL DEFAULT 0.0,            -- cap on the tenant's share for a full year, 0 = no cap
+    Share DECIMAL(19,4) NOT NULL DEFAULT 0.0,                   -- tenant's share of the expenses
+    Estimated DECIMAL(19,4) NOT NULL DEFAULT 0.0,               -- estimated charges assessed in the period
+    TrueUp DECIMAL(19,4) NOT NULL DEFAULT 0.0,                  -- Share - Estimated, > 0 is owed by the tenant, < 0 is credited
+    ASMID BIGINT NOT NULL DEFAULT 0,                            -- true-up assessment
+    RCPTID BIGINT NOT NULL DEFAULT 0,                           -- true-up credit
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 = posted
+    Comment VARCHAR(256) NOT NULL DEFAULT '',                   -- notes
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (CAMRID)
+);
+
+-- The recoverable operating expenses of one GL account in a CAMReconciliation
+CREATE TABLE CAMReconLine (
+    CAMRLID BIGINT NOT NULL AUTO_INCREMENT,                     -- unique id for this line
+    CAMRID BIGINT NOT NULL DEFAULT 0,                           -- the reconciliation
+    BID BIGINT NOT NULL DEFAULT 0,                              -- business id
+    LID BIGINT NOT NULL DEFAULT 0,                              -- GL account debited by the expenses
+    Expenses DECIMAL(19,4) NOT NULL DEFAULT 0.0,                -- expenses in the period
+    BaseExpenses DECIMAL(19,4) NOT NULL DEFAULT 0.0,            -- expenses in the base year
+    Share DECIMAL(19,4) NOT NULL DEFAULT 0.0,                   -- tenant's share of Expenses - BaseExpenses
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (CAMRLID)
+);
+
+-- **************************************
+-- ****                              ****
+-- ****        TENANT PORTAL         ****
+-- ****                              ****
+-- **************************************
+-- The portal login of a tenant. It ties a username and password to a
+-- Transactant.  The tenant can see the Rental Agreements for which it is
+-- a payor.
+CREATE TABLE TenantLogin (
+    TLID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id for this login
+    BID BIGINT NOT NULL DEFAULT 0,                              -- business id
+    TCID BIGINT NOT NULL DEFAULT 0,                             -- the tenant
+    Username VARCHAR(100) NOT NULL DEFAULT '',                  -- login name, unique within the business
+    PasswordHash VARCHAR(256) NOT NULL DEFAULT '',              -- salted hash of the password
+    FailedLogins BIGINT NOT NULL DEFAULT 0,                     -- consecutive failed logins
+    LastLogin DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',  -- when the tenant last logged in
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 = disabled
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (TLID),
+    UNIQUE KEY (BID, Username)
+);
+
+-- A repair request submitted by a tenant through the portal
+CREATE TABLE MaintenanceRequest (
+    MRID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id for this request
+    BID BIGINT NOT NULL DEFAULT 0,                              -- business id
+    RAID BIGINT NOT NULL DEFAULT 0,                             -- rental agreement of the tenant
+    RID BIGINT NOT NULL DEFAULT 0,                              -- rentable needing the repair, 0 if not known
+    TCID BIGINT NOT NULL DEFAULT 0,                             -- tenant who submitted the request
+    Dt DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',         -- when the request was submitted
+    Category VARCHAR(100) NOT NULL DEFAULT '',                  -- plumbing, electrical, appliance, ...
+    Description VARCHAR(2048) NOT NULL DEFAULT '',              -- what needs to be repaired
+    ContactPhone VARCHAR(100) NOT NULL DEFAULT '',              -- where the tenant can be reached
+    Status SMALLINT NOT NULL DEFAULT 0,                         -- 0 = open, 1 = accepted, 2 = closed
+    Response VARCHAR(2048) NOT NULL DEFAULT '',                 -- the property manager's answer to the tenant
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 = permission to enter
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (MRID)
+);
+
+-- **************************************
+-- ****                              ****
+-- ****         WORK ORDERS          ****
+-- ****                              ****
+-- **************************************
+-- A repair ticket for a Rentable.  The labor and material costs are posted
+-- as Expenses when the work is completed and the cost can be charged back
+-- to the tenant as an Assessment.
+CREATE TABLE WorkOrder (
+    WOID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id for this work order
+    BID BIGINT NOT NULL DEFAULT 0,                              -- business id
+    RID BIGINT NOT NULL DEFAULT 0,                              -- rentable needing the repair
+    RAID BIGINT NOT NULL DEFAULT 0,                             -- rental agreement, 0 if none
+    MRID BIGINT NOT NULL DEFAULT 0,                             -- tenant maintenance request that started it, 0 if none
+    Category VARCHAR(100) NOT NULL DEFAULT '',                  -- plumbing, electrical, appliance, ...
+    Priority SMALLINT NOT NULL DEFAULT 0,                       -- 0 = low, 1 = normal, 2 = high, 3 = emergency
+    Status SMALLINT NOT NULL DEFAULT 0,                         -- 0 = open, 1 = assigned, 2 = scheduled, 3 = in progress, 4 = completed, 5 = closed, 6 = canceled
+    Vendor VARCHAR(100) NOT NULL DEFAULT '',                    -- who is assigned to do the work
+    Description VARCHAR(2048) NOT NULL DEFAULT '',              -- what needs to be repaired
+    Dt DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',         -- when the problem was reported
+    ScheduledStart DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',  -- when the work is scheduled to start
+    ScheduledStop DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',   -- when the work is scheduled to be done
+    DtCompleted DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',     -- when the work was completed
+    LaborCost DECIMAL(19,4) NOT NULL DEFAULT 0.0,               -- cost of the labor
+    LaborARID BIGINT NOT NULL DEFAULT 0,                        -- expense account rule for the labor
+    LaborEXPID BIGINT NOT NULL DEFAULT 0,                       -- expense posted for the labor
+    MaterialCost DECIMAL(19,4) NOT NULL DEFAULT 0.0,            -- cost of the materials
+    MaterialARID BIGINT NOT NULL DEFAULT 0,                     -- expense account rule for the materials
+    MaterialEXPID BIGINT NOT NULL DEFAULT 0,                    -- expense posted for the materials
+    ChargebackAmount DECIMAL(19,4) NOT NULL DEFAULT 0.0,        -- amount charged to the tenant
+    ChargebackARID BIGINT NOT NULL DEFAULT 0,                   -- assessment account rule for the chargeback
+    ChargebackASMID BIGINT NOT NULL DEFAULT 0,                  -- assessment for the chargeback
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 = permission to enter
+    Comment VARCHAR(2048) NOT NULL DEFAULT '',                  -- notes on the work done
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (WOID)
+);
+
+-- **************************************
+-- ****                              ****
+-- ****      ACCOUNTS PAYABLE        ****
+-- ****                              ****
+-- **************************************
+-- A business that sells goods or services to the property.
+CREATE TABLE Vendor (
+    VID BIGINT NOT NULL AUTO_INCREMENT,                         -- unique id for this vendor
+    BID BIGINT NOT NULL DEFAULT 0,                              -- business id
+    Name VARCHAR(100) NOT NULL DEFAULT '',                      -- vendor name, unique within the business
+    ContactName VARCHAR(100) NOT NULL DEFAULT '',               -- who to talk to
+    Email VARCHAR(100) NOT NULL DEFAULT '',
+    Phone VARCHAR(100) NOT NULL DEFAULT '',
+    Address VARCHAR(100) NOT NULL DEFAULT '',                   -- remit-to address
+    Address2 VARCHAR(100) NOT NULL DEFAULT '',
+    City VARCHAR(100) NOT NULL DEFAULT '',
+    State CHAR(25) NOT NULL DEFAULT '',
+    PostalCode VARCHAR(100) NOT NULL DEFAULT '',
+    Country VARCHAR(100) NOT NULL DEFAULT '',
+    TaxID VARCHAR(25) NOT NULL DEFAULT '',                      -- EIN or SSN, reported on the 1099
+    Terms BIGINT NOT NULL DEFAULT 0,                            -- payment terms, days from the bill date to its due date
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 = 1099 eligible, 1<<1 = inactive
+    Comment VARCHAR(2048) NOT NULL DEFAULT '',
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (VID)
+);
+
+-- A bill received from a vendor.  It is posted to the accounts payable GL
+-- account when it is approved and it is paid by a VendorPaymentRun.
+CREATE TABLE VendorBill (
+    VBID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id for this bill
+    BID BIGINT NOT NULL DEFAULT 0,                              -- business id
+    VID BIGINT NOT NULL DEFAULT 0,                              -- the vendor who sent it
+    InvoiceNo VARCHAR(100) NOT NULL DEFAULT '',                 -- the vendor's invoice number
+    Dt DATE NOT NULL DEFAULT '1970-01-01 00:00:00',             -- bill date, the expenses are posted on this date
+    DueDt DATE NOT NULL DEFAULT '1970-01-01 00:00:00',          -- when it must be paid
+    Amount DECIMAL(19,4) NOT NULL DEFAULT 0.0,                  -- total of the lines
+    Status SMALLINT NOT NULL DEFAULT 0,                         -- 0 = entered, 1 = approved, 2 = paid, 3 = void
+    ApprovedBy BIGINT NOT NULL DEFAULT 0,                       -- UID of who approved it
+    DtApproved DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00', -- when it was approved
+    JID BIGINT NOT NULL DEFAULT 0,                              -- journal entry posted when it was approved
+    VPRID BIGINT NOT NULL DEFAULT 0,                            -- payment run that paid it
+    DtPaid DATE NOT NULL DEFAULT '1970-01-01 00:00:00',         -- when it was paid
+    DocNo VARCHAR(50) NOT NULL DEFAULT '',                      -- check number or other payment reference
+    PmtJID BIGINT NOT NULL DEFAULT 0,                           -- journal entry posted when it was paid
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- not used yet
+    Comment VARCHAR(2048) NOT NULL DEFAULT '',
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (VBID)
+);
+
+-- One line of a vendor bill, charged to a GL account.
+CREATE TABLE VendorBillLine (
+    VBLID BIGINT NOT NULL AUTO_INCREMENT,                       -- unique id for this line
+    VBID BIGINT NOT NULL DEFAULT 0,                             -- the bill
+    BID BIGINT NOT NULL DEFAULT 0,                              -- business id
+    LID BIGINT NOT NULL DEFAULT 0,                              -- GL account debited
+    RID BIGINT NOT NULL DEFAULT 0,                              -- rentable the cost is for, 0 if none
+    Amount DECIMAL(19,4) NOT NULL DEFAULT 0.0,
+    Description VARCHAR(256) NOT NULL DEFAULT '',
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (VBLID)
+);
+
+-- A batch of approved vendor bills paid from a Depository.
+CREATE TABLE VendorPaymentRun (
+    VPRID BIGINT NOT NULL AUTO_INCREMENT,                       -- unique id for this payment run
+    BID BIGINT NOT NULL DEFAULT 0,                              -- business id
+    DEPID BIGINT NOT NULL DEFAULT 0,                            -- depository the bills are paid from
+    Dt DATE NOT NULL DEFAULT '1970-01-01 00:00:00',             -- payment date
+    FirstCheckNo BIGINT NOT NULL DEFAULT 0,                     -- check number of the first bill paid, 0 if not paid by check
+    Amount DECIMAL(19,4) NOT NULL DEFAULT 0.0,                  -- total paid
+    BillCount BIGINT NOT NULL DEFAULT 0,                        -- number of bills paid
+    Comment VARCHAR(2048) NOT NULL DEFAULT '',
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (VPRID)
+);
+
 -- **************************************
 -- ****                              ****
 -- ****          INVOICE             ****
@@ -1503,6 +2032,23 @@ CREATE TABLE GLAccount (
     PRIMARY KEY (LID)
 );
 
+-- Maps a GL account of a business to an account of the consolidated chart of
+-- accounts used by the portfolio reports.  The accounts without a map are
+-- consolidated under their own GLNumber and Name.
+CREATE TABLE AcctMap (
+    AMID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id for this map
+    BID BIGINT NOT NULL DEFAULT 0,                              -- business id
+    LID BIGINT NOT NULL DEFAULT 0,                              -- GL account of the business
+    GLNumber VARCHAR(100) NOT NULL DEFAULT '',                  -- consolidated GL number
+    Name VARCHAR(100) NOT NULL DEFAULT '',                      -- consolidated account name
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (AMID),
+    UNIQUE KEY (BID, LID)
+);
+
 
 CREATE TABLE LedgerAudit (
     LEID BIGINT NOT NULL DEFAULT 0,                             -- what LEID was affected
@@ -1536,6 +2082,48 @@ CREATE TABLE ClosePeriod (
     PRIMARY KEY (CPID)
 );
 
+-- A fiscal year close.  The balances of the income and expense accounts are
+-- moved to the retained earnings account by the closing journal JID.  When
+-- the year is reopened the closing journal is reversed by RevJID and the
+-- record is kept, flagged as reopened, as the audit trail.
+CREATE TABLE FiscalYearClose (
+    FYCID BIGINT NOT NULL AUTO_INCREMENT,                       -- unique id for this close
+    BID BIGINT NOT NULL DEFAULT 0,                              -- business id
+    DtStart DATE NOT NULL DEFAULT '1970-01-01 00:00:00',        -- first day of the fiscal year
+    DtStop DATE NOT NULL DEFAULT '1970-01-01 00:00:00',         -- first day of the next fiscal year
+    Dt DATE NOT NULL DEFAULT '1970-01-01 00:00:00',             -- date of the closing journal, the last day of the year
+    LID BIGINT NOT NULL DEFAULT 0,                              -- retained earnings GL account
+    JID BIGINT NOT NULL DEFAULT 0,                              -- closing journal
+    NetIncome DECIMAL(19,4) NOT NULL DEFAULT 0.0,               -- amount credited to retained earnings, negative for a loss
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 reopened
+    RevJID BIGINT NOT NULL DEFAULT 0,                           -- journal reversing JID when the year was reopened
+    DtReopened DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00', -- when the year was reopened
+    ReopenedBy BIGINT NOT NULL DEFAULT 0,                       -- UID of the person who reopened the year
+    Reason VARCHAR(2048) NOT NULL DEFAULT '',                   -- why the year was reopened
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (FYCID)
+);
+
+-- The budgeted amount of a GL account for a month.  Amount has the natural
+-- sign of the account, income and expenses are both positive.
+CREATE TABLE Budget (
+    BGTID BIGINT NOT NULL AUTO_INCREMENT,                       -- unique id for this budget amount
+    BID BIGINT NOT NULL DEFAULT 0,                              -- business id
+    LID BIGINT NOT NULL DEFAULT 0,                              -- GL account
+    Dt DATE NOT NULL DEFAULT '1970-01-01 00:00:00',             -- first day of the month
+    Amount DECIMAL(19,4) NOT NULL DEFAULT 0.0,                  -- budgeted amount for the month
+    Comment VARCHAR(256) NOT NULL DEFAULT '',                   -- note
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (BGTID),
+    UNIQUE KEY (BID, LID, Dt)
+);
+
 
 -- **************************************
 -- ****                              ****
@@ -182,6 +182,55 @@ func RunCommandLine(ctx context.Context, dCtx *DispatchCtx) {
 		}
 		ri.ID = tlid
 		rrpt.TaskListTextReport(ctx, &ri)
+	case 26: // LEDGER AUDIT
+		// dCtx.Report format:  26  or  26,repair
+		// date range is from -j , -k.  The report lists the problems found, with
+		// repair the drifted ledger markers are then regenerated
+		fmt.Print(rrpt.LedgerAudit(ctx, &ri))
+		sa := strings.Split(dCtx.Args, ",")
+		if len(sa) < 2 || sa[1] != "repair" {
+			break
+		}
+		a, err := rlib.AuditLedgers(ctx, dCtx.xbiz.P.BID, &dCtx.DtStart, &dCtx.DtStop)
+		if err != nil {
+			rlib.LogAndPrintError("RunCommandLine", err)
+			os.Exit(1)
+		}
+		dts, err := rlib.RepairLedgerMarkers(ctx, &dCtx.xbiz, &a)
+		for i := 0; i < len(dts); i++ {
+			fmt.Printf("Regenerated the ledger markers of %s\n", dts[i].Format(rlib.RRDATEFMT4))
+		}
+		if err != nil {
+			rlib.LogAndPrintError("RunCommandLine", err)
+			os.Exit(1)
+		}
+		fmt.Printf("\n")
+		fmt.Print(rrpt.LedgerAudit(ctx, &ri))
+
+	case 27, 28, 29, 30: // PORTFOLIO REPORTS
+		// dCtx.Report format:  27,BUD,BUD,...   (BIDs may be used in place of BUDs)
+		// 27 = portfolio rentroll, 28 = consolidated trial balance,
+		// 29 = portfolio occupancy, 30 = portfolio delinquency.  The
+		// businesses default to the one set by -b
+		sa := strings.Split(dCtx.Args, ",")
+		if len(sa) > 1 {
+			bids, err := rlib.ParsePortfolio(ctx, strings.Join(sa[1:], ","))
+			if err != nil {
+				rlib.LogAndPrintError("RunCommandLine", err)
+				os.Exit(1)
+			}
+			ri.Portfolio = bids
+		}
+		switch dCtx.Report {
+		case 27:
+			fmt.Print(rrpt.PortfolioRentRoll(ctx, &ri))
+		case 28:
+			fmt.Print(rrpt.ConsolidatedTrialBalance(ctx, &ri))
+		case 29:
+			fmt.Print(rrpt.PortfolioOccupancy(ctx, &ri))
+		case 30:
+			fmt.Print(rrpt.PortfolioDelinquency(ctx, &ri))
+		}
 
 	default:
 		err := rlib.GenerateJournalRecords(ctx, &dCtx.xbiz, &dCtx.DtStart, &dCtx.DtStop, App.SkipVacCheck)
@@ -75,7 +75,7 @@ func readCommandLineArgs() {
 	pCert := flag.String("C", "localhost.crt", "Cert file")
 	pBud := flag.String("b", "", "Business Unit Identifier (BUD)")
 	verPtr := flag.Bool("v", false, "prints the version to stdout")
-	rptPtr := flag.String("r", "0", "report: 0 = generate Journal records, 1 = Journal, 2 = Rentable, 4=Rentroll, 5=AssessmentCheck, 6=LedgerBalance, 7=RentableCountByType, 8=Statement, 9=Invoice, 10=LedgerActivity, 11=RentableGSR, 12-RALedgerBalanceOnDate,LID,RAID,Date, 13-RAAcctActivity,LID,RAID, 14,Date=delinqRpt")
+	rptPtr := flag.String("r", "0", "report: 0 = generate Journal records, 1 = Journal, 2 = Rentable, 4=Rentroll, 5=AssessmentCheck, 6=LedgerBalance, 7=RentableCountByType, 8=Statement, 9=Invoice, 10=LedgerActivity, 11=RentableGSR, 12-RALedgerBalanceOnDate,LID,RAID,Date, 13-RAAcctActivity,LID,RAID, 14,Date=delinqRpt, 27-30,BUD,...=portfolio rentroll, consolidated trial balance, occupancy, delinquency")
 	pLoad := flag.String("L", "", "CSV Load index,filename")
 	pTN := flag.String("testDtNow", "", "Override time.Now with the supplied datetime string value")
 	portPtr := flag.Int("p", 8270, "port on which RentRoll server listens")
@@ -162,6 +162,8 @@ func initHTTP() {
 	http.HandleFunc("/home/", HomeUIHandler)
 	http.HandleFunc("/rhome/", RHomeUIHandler) // special purpose, receipt-only version of roller
 	http.HandleFunc("/v1/", ws.V1ServiceHandler)
+	http.HandleFunc("/portal/v1/", ws.PortalServiceHandler) // tenant portal, tenant sessions only
+	http.HandleFunc("/ota/v1/", ws.OTAServiceHandler)       // OpenTravel messages from channels
 	// http.HandleFunc("/wsvc/", ReportServiceHandler)
 }
 
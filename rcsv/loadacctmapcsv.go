@@ -0,0 +1,70 @@
+package rcsv
+
+import (
+	"context"
+	"fmt"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"strings"
+)
+
+// CSV record format:
+// 0    1         2                     3
+// BUD, GLNumber, ConsolidatedGLNumber, ConsolidatedName
+// REX, 41001,    4000,                 "Rent"
+//
+// Maps a GL account of the business to an account of the consolidated chart
+// of accounts used by the portfolio reports.
+
+// CreateAcctMapFromCSV reads a GL account map string array and maps the
+// GL account to its consolidated account
+func CreateAcctMapFromCSV(ctx context.Context, sa []string, lineno int) (int, error) {
+	const funcname = "CreateAcctMapFromCSV"
+	var (
+		err error
+	)
+
+	const (
+		BUD                  = 0
+		GLNumber             = iota
+		ConsolidatedGLNumber = iota
+		ConsolidatedName     = iota
+	)
+
+	// csvCols is an array that defines all the columns that should be in this csv file
+	var csvCols = []CSVColumn{
+		{"BUD", BUD},
+		{"GLNumber", GLNumber},
+		{"ConsolidatedGLNumber", ConsolidatedGLNumber},
+		{"ConsolidatedName", ConsolidatedName},
+	}
+
+	y, err := ValidateCSVColumnsErr(csvCols, sa, funcname, lineno)
+	if y {
+		return 1, err
+	}
+	if lineno == 1 {
+		return 0, nil // we've validated the col headings, all is good, send the next line
+	}
+
+	//-------------------------------------------------------------------
+	// Make sure the rlib.Business is in the database
+	//-------------------------------------------------------------------
+	bud := strings.ToLower(strings.TrimSpace(sa[BUD]))
+	b1, err := rlib.GetBusinessByDesignation(ctx, bud)
+	if err != nil || len(b1.Designation) == 0 {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - Business with designation %s does not exist", funcname, lineno, sa[BUD])
+	}
+
+	_, errlist := bizlogic.SaveAcctMap(ctx, b1.BID, sa[GLNumber], sa[ConsolidatedGLNumber], sa[ConsolidatedName])
+	if len(errlist) > 0 {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - error saving GL account map: %s", funcname, lineno, bizlogic.BizErrorListToError(errlist).Error())
+	}
+	return 0, nil
+}
+
+// LoadAcctMapCSV loads a csv file that maps GL accounts to the
+// consolidated chart of accounts
+func LoadAcctMapCSV(ctx context.Context, fname string) []error {
+	return LoadRentRollCSV(ctx, fname, CreateAcctMapFromCSV)
+}
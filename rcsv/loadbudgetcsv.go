@@ -0,0 +1,95 @@
+package rcsv
+
+import (
+	"context"
+	"fmt"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"strings"
+)
+
+// CSV record format:
+// 0    1           2         3       4       ... 14       15
+// BUD, FiscalYear, GLNumber, Month1, Month2, ... Month12, Comment
+// REX, 1/1/2018,   50001,    1200,   1200,   ... 1500,    "office supplies"
+//
+// FiscalYear is any date in the fiscal year.  Month1 is the first month of
+// the fiscal year as defined in the business properties.
+
+// CreateBudgetFromCSV reads a budget string array and sets the monthly budget
+// of a GL account for a fiscal year
+func CreateBudgetFromCSV(ctx context.Context, sa []string, lineno int) (int, error) {
+	const funcname = "CreateBudgetFromCSV"
+	var (
+		err     error
+		errmsg  string
+		bid     int64
+		amounts = make([]float64, rlib.BudgetMonths)
+	)
+
+	const (
+		BUD        = 0
+		FiscalYear = iota
+		GLNumber   = iota
+		Month1     = iota
+		Comment    = Month1 + rlib.BudgetMonths
+	)
+
+	// csvCols is an array that defines all the columns that should be in this csv file
+	var csvCols = []CSVColumn{
+		{"BUD", BUD},
+		{"FiscalYear", FiscalYear},
+		{"GLNumber", GLNumber},
+	}
+	for i := 0; i < rlib.BudgetMonths; i++ {
+		csvCols = append(csvCols, CSVColumn{fmt.Sprintf("Month%d", i+1), Month1 + i})
+	}
+	csvCols = append(csvCols, CSVColumn{"Comment", Comment})
+
+	y, err := ValidateCSVColumnsErr(csvCols, sa, funcname, lineno)
+	if y {
+		return 1, err
+	}
+	if lineno == 1 {
+		return 0, nil // we've validated the col headings, all is good, send the next line
+	}
+
+	//-------------------------------------------------------------------
+	// Make sure the rlib.Business is in the database
+	//-------------------------------------------------------------------
+	bud := strings.ToLower(strings.TrimSpace(sa[BUD]))
+	b1, err := rlib.GetBusinessByDesignation(ctx, bud)
+	if err != nil || len(b1.Designation) == 0 {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - Business with designation %s does not exist", funcname, lineno, sa[BUD])
+	}
+	bid = b1.BID
+
+	//-------------------------------------------------------------------
+	// Fiscal year
+	//-------------------------------------------------------------------
+	dt, err := rlib.StringToDate(sa[FiscalYear])
+	if err != nil {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - invalid fiscal year date:  %s", funcname, lineno, sa[FiscalYear])
+	}
+
+	//-------------------------------------------------------------------
+	// Monthly amounts
+	//-------------------------------------------------------------------
+	for i := 0; i < rlib.BudgetMonths; i++ {
+		amounts[i], errmsg = rlib.FloatFromString(sa[Month1+i], "Amount is invalid")
+		if len(errmsg) > 0 {
+			return CsvErrorSensitivity, fmt.Errorf("%s: line %d - Month%d amount is invalid: %s", funcname, lineno, i+1, sa[Month1+i])
+		}
+	}
+
+	errlist := bizlogic.SaveBudgetYear(ctx, bid, strings.TrimSpace(sa[GLNumber]), &dt, amounts, strings.TrimSpace(sa[Comment]))
+	if len(errlist) > 0 {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - error saving budget: %s", funcname, lineno, bizlogic.BizErrorListToError(errlist).Error())
+	}
+	return 0, nil
+}
+
+// LoadBudgetCSV loads a csv file with the monthly budgets of GL accounts
+func LoadBudgetCSV(ctx context.Context, fname string) []error {
+	return LoadRentRollCSV(ctx, fname, CreateBudgetFromCSV)
+}
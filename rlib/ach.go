@@ -0,0 +1,122 @@
+package rlib
+
+import (
+	"context"
+	"time"
+)
+
+// ACHPaymentTypeName is the name of the PaymentType used for ACH receipts
+// when the business properties do not name one.
+const ACHPaymentTypeName = "ACH"
+
+// BizPropsACH holds the ACH autopay settings for a business.  It is stored as
+// part of the business properties (BizProps.ACH).  Most of the values come
+// from the bank that originates the debits.
+//
+//    Enabled                  - ACH batches can only be created when this is true
+//    PMTName                  - name of the PaymentType for ACH receipts,
+//                               ACHPaymentTypeName if empty
+//    ARName                   - name of the Account Rule used for the receipts
+//    ImmediateDestination     - routing number of the bank that receives the file
+//    ImmediateDestinationName - name of the bank that receives the file
+//    ImmediateOrigin          - our id as assigned by the bank
+//    ImmediateOriginName      - our name
+//    CompanyName              - name that appears on the payor's statement
+//    CompanyID                - company identification assigned by the bank
+//    ODFI                     - routing number of the originating bank
+//    EntryDescription         - description on the payor's statement, RENT if empty
+//    NSFFeeARName             - name of the Account Rule for the fee assessed
+//                               when a debit is returned, no fee if empty
+//    NSFFeeAmount             - the fee, no fee if 0
+//    NSFFeeCodes              - return reason codes that incur the fee. If
+//                               empty, R01 and R09 (insufficient or
+//                               uncollected funds)
+//-----------------------------------------------------------------------------
+type BizPropsACH struct {
+	Enabled                  bool
+	PMTName                  string
+	ARName                   string
+	ImmediateDestination     string
+	ImmediateDestinationName string
+	ImmediateOrigin          string
+	ImmediateOriginName      string
+	CompanyName              string
+	CompanyID                string
+	ODFI                     string
+	EntryDescription         string
+	NSFFeeARName             string
+	NSFFeeAmount             float64
+	NSFFeeCodes              []string
+}
+
+// GetACHPolicy returns the ACH settings configured in the business
+// properties named bizPropName for business BID.
+//
+// INPUTS
+//     ctx         = context
+//     BID         = business id
+//     bizPropName = name of the business properties, usually "general"
+//
+// RETURNS
+//     the ACH settings
+//     any error encountered
+//-----------------------------------------------------------------------------
+func GetACHPolicy(ctx context.Context, BID int64, bizPropName string) (BizPropsACH, error) {
+	bizPropJSON, err := GetDataFromBusinessPropertyName(ctx, bizPropName, BID)
+	if err != nil {
+		return BizPropsACH{}, err
+	}
+	p := bizPropJSON.ACH
+	if len(p.PMTName) == 0 {
+		p.PMTName = ACHPaymentTypeName
+	}
+	if len(p.EntryDescription) == 0 {
+		p.EntryDescription = "RENT"
+	}
+	return p, nil
+}
+
+// NSFFeeApplies returns true if a debit returned with reason code code
+// incurs an NSF fee
+//-----------------------------------------------------------------------------
+func (p *BizPropsACH) NSFFeeApplies(code string) bool {
+	if len(p.NSFFeeARName) == 0 || p.NSFFeeAmount <= 0 {
+		return false
+	}
+	codes := p.NSFFeeCodes
+	if len(codes) == 0 {
+		codes = []string{"R01", "R09"}
+	}
+	for i := 0; i < len(codes); i++ {
+		if codes[i] == code {
+			return true
+		}
+	}
+	return false
+}
+
+// NACHAFile returns a NACHAFile for batch b with the header values filled in
+// from the ACH settings.  The caller adds the entries.
+//
+// INPUTS
+//     b   = the batch
+//     now = creation time for the file header
+//
+// RETURNS
+//     the NACHAFile
+//-----------------------------------------------------------------------------
+func (p *BizPropsACH) NACHAFile(b *ACHBatch, now *time.Time) NACHAFile {
+	return NACHAFile{
+		ImmediateDestination:     p.ImmediateDestination,
+		ImmediateDestinationName: p.ImmediateDestinationName,
+		ImmediateOrigin:          p.ImmediateOrigin,
+		ImmediateOriginName:      p.ImmediateOriginName,
+		CompanyName:              p.CompanyName,
+		CompanyID:                p.CompanyID,
+		ODFI:                     p.ODFI,
+		EntryDescription:         p.EntryDescription,
+		BatchNumber:              b.ABID,
+		CreateTime:               *now,
+		EffectiveDt:              b.Dt,
+	}
+}
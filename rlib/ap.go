@@ -0,0 +1,78 @@
+package rlib
+
+import (
+	"context"
+)
+
+// Vendor1099Threshold is the total paid to a 1099 eligible vendor in a
+// calendar year at or above which a 1099 must be filed
+const Vendor1099Threshold = float64(600)
+
+// BizPropsAP holds the accounts payable settings for a business.  It is
+// stored as part of the business properties (BizProps.AP).
+//
+//    PayableGLNumber - GL number of the accounts payable account.  It is
+//                      credited when a vendor bill is approved and debited
+//                      when the bill is paid.
+//-----------------------------------------------------------------------------
+type BizPropsAP struct {
+	PayableGLNumber string
+}
+
+// GetAPPolicy returns the accounts payable settings configured in the
+// business properties named bizPropName for business BID.
+//
+// INPUTS
+//     ctx         = context
+//     BID         = business id
+//     bizPropName = name of the business properties, usually "general"
+//
+// RETURNS
+//     the accounts payable settings
+//     any error encountered
+//-----------------------------------------------------------------------------
+func GetAPPolicy(ctx context.Context, BID int64, bizPropName string) (BizPropsAP, error) {
+	bizPropJSON, err := GetDataFromBusinessPropertyName(ctx, bizPropName, BID)
+	if err != nil {
+		return BizPropsAP{}, err
+	}
+	return bizPropJSON.AP, nil
+}
+
+// IDtoShortString is the short id string for a Vendor
+func (v *Vendor) IDtoShortString() string {
+	return IDtoShortString("V", v.VID)
+}
+
+// Is1099 returns true if the payments to the vendor are reported on a 1099
+func (v *Vendor) Is1099() bool {
+	return v.FLAGS&FlVendor1099 != 0
+}
+
+// IsActive returns true if new bills can be entered for the vendor
+func (v *Vendor) IsActive() bool {
+	return v.FLAGS&FlVendorInactive == 0
+}
+
+// IDtoString is the standard id string for a VendorBill
+func (vb *VendorBill) IDtoString() string {
+	return IDtoString("VB", vb.VBID)
+}
+
+// IDtoShortString is the short id string for a VendorBill
+func (vb *VendorBill) IDtoShortString() string {
+	return IDtoShortString("VB", vb.VBID)
+}
+
+// StatusName returns the display name of the bill's status
+func (vb *VendorBill) StatusName() string {
+	if vb.Status < 0 || vb.Status > VBStatusLast {
+		return "Unknown"
+	}
+	return VBStatusNames[vb.Status]
+}
+
+// IDtoShortString is the short id string for a VendorPaymentRun
+func (pr *VendorPaymentRun) IDtoShortString() string {
+	return IDtoShortString("VPR", pr.VPRID)
+}
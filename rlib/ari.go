@@ -0,0 +1,230 @@
+package rlib
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// ARIExportDays is the default number of days of rates and availability
+// exported
+const ARIExportDays = 30
+
+// ARIExportFlags maps the name of each channel rate plans can be exported to
+// onto its RatePlan FLAGS bit
+var ARIExportFlags = map[string]uint64{
+	"GDS":   FlRatePlanGDS,
+	"Sabre": FlRatePlanSabre,
+}
+
+// BizPropsARI holds the rate and availability (ARI) export settings for a
+// business, and the channels allowed to send it reservations.  It is stored
+// as part of the business properties (BizProps.ARI).
+//
+//    HotelCode  - the code of the business on the channels
+//    Currency   - currency code of the rates, left out if ""
+//    Days       - days exported, starting today, ARIExportDays if 0
+//    Sinks      - where the exports go, nothing is exported if there are none
+//    Requestors - the channels allowed to send reservations, none are
+//                 accepted if there are none
+//-----------------------------------------------------------------------------
+type BizPropsARI struct {
+	HotelCode  string
+	Currency   string
+	Days       int
+	Sinks      []BizPropsARISink
+	Requestors []BizPropsARIRequestor
+}
+
+// BizPropsARISink is a destination of the ARI export.  The rate plans
+// flagged for export to Channel are sent to URL, or written in directory
+// Dir if there is no URL.
+//
+//    Channel - GDS or Sabre, see ARIExportFlags
+//    URL     - the messages are POSTed to this URL
+//    Dir     - the messages are written in this directory
+//-----------------------------------------------------------------------------
+type BizPropsARISink struct {
+	Channel string
+	URL     string
+	Dir     string
+}
+
+// BizPropsARIRequestor is a channel allowed to send reservations.  It must
+// identify itself in the POS of its messages with RequestorID ID and
+// MessagePassword Password.  A requestor without a Password is not
+// accepted.
+//-----------------------------------------------------------------------------
+type BizPropsARIRequestor struct {
+	ID       string
+	Password string
+}
+
+// GetARIPolicy returns the ARI export settings configured in the business
+// properties named bizPropName for business BID.
+//
+// INPUTS
+//     ctx         = context
+//     BID         = business id
+//     bizPropName = name of the business properties, usually "general"
+//
+// RETURNS
+//     the export settings
+//     any error encountered
+//-----------------------------------------------------------------------------
+func GetARIPolicy(ctx context.Context, BID int64, bizPropName string) (BizPropsARI, error) {
+	bizPropJSON, err := GetDataFromBusinessPropertyName(ctx, bizPropName, BID)
+	if err != nil {
+		return BizPropsARI{}, err
+	}
+	p := bizPropJSON.ARI
+	if p.Days <= 0 {
+		p.Days = ARIExportDays
+	}
+	return p, nil
+}
+
+// IsRequestor returns true if id and password identify one of the channels
+// allowed to send reservations.  A requestor without an ID or a password is
+// never accepted.  The passwords are compared in constant time.
+func (p *BizPropsARI) IsRequestor(id, password string) bool {
+	if len(id) == 0 || len(password) == 0 {
+		return false
+	}
+	for i := 0; i < len(p.Requestors); i++ {
+		r := &p.Requestors[i]
+		if len(r.ID) == 0 || len(r.Password) == 0 || r.ID != id {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(r.Password), []byte(password)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// ARIRate is the rate of a rentable type under a rate plan for DtStart -
+// DtStop
+type ARIRate struct {
+	RatePlanCode       string    // the rate plan
+	InvTypeCode        string    // the rentable type
+	DtStart            time.Time // first day
+	DtStop             time.Time // day after the last day
+	Amount             float64   // nightly rate
+	Guests             int64     // guests included in Amount, 0 if not limited
+	AdditionalGuestFee float64   // charge for each additional guest
+}
+
+// ARIAvail is the number of rentables of a rentable type available for
+// DtStart - DtStop
+type ARIAvail struct {
+	InvTypeCode string    // the rentable type
+	DtStart     time.Time // first day
+	DtStop      time.Time // day after the last day
+	Available   int64     // rentables available
+}
+
+// AppendARIRate adds r to m.  If r continues the last rate of m with the
+// same values, that rate is extended instead.
+func AppendARIRate(m []ARIRate, r ARIRate) []ARIRate {
+	if n := len(m); n > 0 {
+		p := &m[n-1]
+		if p.DtStop.Equal(r.DtStart) && p.RatePlanCode == r.RatePlanCode && p.InvTypeCode == r.InvTypeCode &&
+			p.Amount == r.Amount && p.Guests == r.Guests && p.AdditionalGuestFee == r.AdditionalGuestFee {
+			p.DtStop = r.DtStop
+			return m
+		}
+	}
+	return append(m, r)
+}
+
+// AppendARIAvail adds a to m.  If a continues the last availability of m
+// with the same count, that availability is extended instead.
+func AppendARIAvail(m []ARIAvail, a ARIAvail) []ARIAvail {
+	if n := len(m); n > 0 {
+		p := &m[n-1]
+		if p.DtStop.Equal(a.DtStart) && p.InvTypeCode == a.InvTypeCode && p.Available == a.Available {
+			p.DtStop = a.DtStop
+			return m
+		}
+	}
+	return append(m, a)
+}
+
+// GetRatePlanFlags returns the FLAGS of rate plan rpid.  They are kept in the
+// rate plan's FLAGS custom attribute.
+//
+// INPUTS
+//     ctx  = db context
+//     rpid = the rate plan
+//
+// RETURNS
+//     the flags, 0 if the rate plan has none
+//     any error encountered
+//-----------------------------------------------------------------------------
+func GetRatePlanFlags(ctx context.Context, rpid int64) (uint64, error) {
+	m, err := GetAllCustomAttributes(ctx, ELEMRATEPLAN, rpid)
+	if err != nil {
+		return 0, err
+	}
+	c, ok := m["FLAGS"]
+	if !ok || len(c.Value) == 0 {
+		return 0, nil
+	}
+	return strconv.ParseUint(c.Value, 10, 64)
+}
+
+// ARISink is where the ARI messages are sent
+type ARISink interface {
+	Send(name string, data []byte) error
+}
+
+// ARIFileSink writes each ARI message in a file of directory Dir
+type ARIFileSink struct {
+	Dir string
+}
+
+// Send writes data in file name of the sink's directory
+func (s *ARIFileSink) Send(name string, data []byte) error {
+	return ioutil.WriteFile(filepath.Join(s.Dir, name), data, 0644)
+}
+
+// ARIHTTPSink POSTs each ARI message to URL
+type ARIHTTPSink struct {
+	URL    string
+	Client *http.Client // http.DefaultClient if nil
+}
+
+// Send POSTs data to the sink's URL.  Any status other than 2xx is an error.
+func (s *ARIHTTPSink) Send(name string, data []byte) error {
+	c := s.Client
+	if c == nil {
+		c = http.DefaultClient
+	}
+	resp, err := c.Post(s.URL, "application/xml", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s: %s returned %s", name, s.URL, resp.Status)
+	}
+	return nil
+}
+
+// NewARISink returns the sink of export destination p
+func NewARISink(p *BizPropsARISink) (ARISink, error) {
+	switch {
+	case len(p.URL) > 0:
+		return &ARIHTTPSink{URL: p.URL}, nil
+	case len(p.Dir) > 0:
+		return &ARIFileSink{Dir: p.Dir}, nil
+	}
+	return nil, fmt.Errorf("the %s export has neither a URL nor a directory", p.Channel)
+}
@@ -0,0 +1,125 @@
+package rlib
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// AvailableRentablesQuery is the query template for the rentables that are
+// available for a reservation.  The WhereClause should start with the
+// criteria returned by AvailableRentablesWhere.
+var AvailableRentablesQuery = `
+SELECT DISTINCT {{.SelectClause}}
+FROM RentableTypeRef
+LEFT JOIN RentableLeaseStatus on RentableLeaseStatus.RID = RentableTypeRef.RID
+LEFT JOIN RentableUseStatus on RentableUseStatus.RID = RentableTypeRef.RID
+LEFT JOIN Rentable on Rentable.RID = RentableTypeRef.RID
+WHERE {{.WhereClause}}
+ORDER BY {{.OrderClause}}
+` // don't add ';', later some parts will be added in query
+
+// AvailableRentablesWhere returns the criteria of AvailableRentablesQuery
+// for the rentables of type rtid of business bid that are available between
+// d1 and d2: they are not leased and are in service during the period.
+//
+// INPUTS
+//     bid  = business id
+//     rtid = rentable type
+//     d1   = start of the period
+//     d2   = stop of the period
+//
+// RETURNS
+//     the WHERE clause
+//-----------------------------------------------------------------------------
+func AvailableRentablesWhere(bid, rtid int64, d1, d2 *time.Time) string {
+	return fmt.Sprintf(`RentableTypeRef.BID=%d AND
+        RentableLeaseStatus.DtStart <= %q AND RentableLeaseStatus.DtStop >= %q AND RentableLeaseStatus.LeaseStatus = 0 AND
+		RentableTypeRef.DtStart <= %q AND RentableTypeRef.DtStop >= %q AND RentableTypeRef.RTID = %d AND
+		RentableUseStatus.DtStart <= %q AND RentableUseStatus.DtStop >= %q AND RentableUseStatus.UseStatus = 0`,
+		bid,
+		d2.Format(RRDATEFMTSQL),
+		d1.Format(RRDATEFMTSQL),
+		d2.Format(RRDATEFMTSQL),
+		d1.Format(RRDATEFMTSQL),
+		rtid,
+		d2.Format(RRDATEFMTSQL),
+		d1.Format(RRDATEFMTSQL),
+	)
+}
+
+// CountAvailableRentables returns the number of rentables of type rtid of
+// business bid that are available between d1 and d2.  It applies the same
+// criteria as the search for available rentables.
+//
+// INPUTS
+//     ctx  = db context
+//     bid  = business id
+//     rtid = rentable type
+//     d1   = start of the period
+//     d2   = stop of the period
+//
+// RETURNS
+//     the number of rentables available
+//     any error encountered
+//-----------------------------------------------------------------------------
+func CountAvailableRentables(ctx context.Context, bid, rtid int64, d1, d2 *time.Time) (int64, error) {
+	if getSessionCheck(ctx) {
+		return 0, ErrSessionRequired
+	}
+	qc := QueryClause{
+		"SelectClause": "RentableTypeRef.RID",
+		"WhereClause":  AvailableRentablesWhere(bid, rtid, d1, d2),
+		"OrderClause":  "RentableTypeRef.RID ASC",
+	}
+	return GetQueryCount(RenderSQLQuery(AvailableRentablesQuery, qc))
+}
+
+// GetAvailableRentables returns the RIDs of the rentables of type rtid of
+// business bid that are available between d1 and d2.  It applies the same
+// criteria as the search for available rentables.
+//
+// INPUTS
+//     ctx  = db context
+//     bid  = business id
+//     rtid = rentable type
+//     d1   = start of the period
+//     d2   = stop of the period
+//
+// RETURNS
+//     the RIDs of the rentables available, in order
+//     any error encountered
+//-----------------------------------------------------------------------------
+func GetAvailableRentables(ctx context.Context, bid, rtid int64, d1, d2 *time.Time) ([]int64, error) {
+	var m []int64
+	if getSessionCheck(ctx) {
+		return m, ErrSessionRequired
+	}
+	qc := QueryClause{
+		"SelectClause": "RentableTypeRef.RID",
+		"WhereClause":  AvailableRentablesWhere(bid, rtid, d1, d2),
+		"OrderClause":  "RentableTypeRef.RID ASC",
+	}
+	q := RenderSQLQuery(AvailableRentablesQuery, qc)
+
+	var rows *sql.Rows
+	var err error
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		rows, err = tx.Query(q)
+	} else {
+		rows, err = RRdb.Dbrr.Query(q)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var rid int64
+		if err = rows.Scan(&rid); err != nil {
+			return m, err
+		}
+		m = append(m, rid)
+	}
+	return m, rows.Err()
+}
@@ -0,0 +1,436 @@
+package rlib
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"html"
+	"io"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BankMatchDays is the default number of days that a bank statement line's
+// date may differ from the date of a Deposit and still be considered a match.
+const BankMatchDays = 5
+
+// ParseBankStatement reads a bank statement file and returns a BankStatement
+// with its lines in L.  OFX and QFX files (either the SGML 1.x or the XML 2.x
+// flavor) and CSV files are supported.  The file format is taken from the
+// extension of fname. If the extension is not recognized the content is
+// examined.
+//
+// The BID and DEPID of the returned values are not set, that is up to the
+// caller.
+//
+// INPUTS
+//    r     = the contents of the file
+//    fname = the name of the file
+//
+// RETURNS
+//    the bank statement
+//    any error encountered
+//-----------------------------------------------------------------------------
+func ParseBankStatement(r io.Reader, fname string) (BankStatement, error) {
+	var bs BankStatement
+	bs.FileName = filepath.Base(fname)
+
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return bs, err
+	}
+
+	switch strings.ToLower(filepath.Ext(fname)) {
+	case ".ofx", ".qfx":
+		err = parseOFX(b, &bs)
+	case ".csv":
+		err = parseBankCSV(b, &bs)
+	default:
+		if bytes.Contains(bytes.ToUpper(b), []byte("<OFX>")) {
+			err = parseOFX(b, &bs)
+		} else {
+			err = parseBankCSV(b, &bs)
+		}
+	}
+	if err != nil {
+		return bs, err
+	}
+	if len(bs.L) == 0 {
+		return bs, fmt.Errorf("no transactions found in %s", bs.FileName)
+	}
+	setBankStatementRange(&bs)
+	return bs, nil
+}
+
+// parseOFX parses the transactions and ledger balance out of an OFX / QFX
+// file. OFX 1.x is SGML and most elements have no end tag, OFX 2.x is XML.
+// Both are handled by treating the file as a stream of <TAG>value pairs and
+// only paying attention to the aggregates we care about.
+//-----------------------------------------------------------------------------
+func parseOFX(b []byte, bs *BankStatement) error {
+	var err error
+	var t *BankStatementLine
+	var inLedgerBal bool
+	var memo string
+
+	tokens := strings.Split(string(b), "<")
+	for i := 1; i < len(tokens); i++ {
+		k := strings.Index(tokens[i], ">")
+		if k < 0 {
+			continue
+		}
+		tag := strings.ToUpper(strings.TrimSpace(tokens[i][:k]))
+		val := html.UnescapeString(strings.TrimSpace(tokens[i][k+1:]))
+
+		switch tag {
+		case "STMTTRN":
+			t = &BankStatementLine{}
+			memo = ""
+		case "/STMTTRN":
+			if t == nil {
+				continue
+			}
+			if len(memo) > 0 && memo != t.Description {
+				if len(t.Description) > 0 {
+					t.Description += " - "
+				}
+				t.Description += memo
+			}
+			bs.L = append(bs.L, *t)
+			t = nil
+		case "LEDGERBAL":
+			inLedgerBal = true
+		case "/LEDGERBAL":
+			inLedgerBal = false
+		case "DTSTART":
+			if bs.DtStart, err = ofxDate(val); err != nil {
+				return err
+			}
+		case "DTEND":
+			if bs.DtStop, err = ofxDate(val); err != nil {
+				return err
+			}
+		case "BALAMT":
+			if !inLedgerBal {
+				continue
+			}
+			if bs.ClosingBalance, err = ofxAmount(val); err != nil {
+				return err
+			}
+			bs.FLAGS |= FlBankStmtBalance
+		case "DTPOSTED":
+			if t == nil {
+				continue
+			}
+			if t.Dt, err = ofxDate(val); err != nil {
+				return err
+			}
+		case "TRNAMT":
+			if t == nil {
+				continue
+			}
+			if t.Amount, err = ofxAmount(val); err != nil {
+				return err
+			}
+		case "FITID":
+			if t != nil {
+				t.FITID = val
+			}
+		case "NAME", "PAYEE":
+			if t != nil && len(t.Description) == 0 {
+				t.Description = val
+			}
+		case "MEMO":
+			if t != nil {
+				memo = val
+			}
+		}
+	}
+	return nil
+}
+
+// ofxDate converts an OFX date (YYYYMMDD[HHMMSS[.XXX][[gmt offset:tz]]]) to
+// a date. Only the date portion is used.
+//-----------------------------------------------------------------------------
+func ofxDate(s string) (time.Time, error) {
+	if len(s) < 8 {
+		return TIME0, fmt.Errorf("invalid OFX date: %q", s)
+	}
+	dt, err := time.Parse("20060102", s[:8])
+	if err != nil {
+		return TIME0, fmt.Errorf("invalid OFX date: %q", s)
+	}
+	return dt, nil
+}
+
+// ofxAmount converts an OFX amount to a float64. OFX allows either a period
+// or a comma as the decimal separator.
+//-----------------------------------------------------------------------------
+func ofxAmount(s string) (float64, error) {
+	x, err := strconv.ParseFloat(strings.Replace(s, ",", ".", -1), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid OFX amount: %q", s)
+	}
+	return RoundToCent(x), nil
+}
+
+// bankCSVCols maps the column names used by banks in their CSV exports to
+// the values we need.  Column names are compared in lower case.
+//-----------------------------------------------------------------------------
+var bankCSVCols = map[string][]string{
+	"Dt":          {"date", "posting date", "posted date", "post date", "transaction date"},
+	"Amount":      {"amount", "transaction amount"},
+	"Credit":      {"credit", "credits", "deposit", "deposits", "credit amount"},
+	"Debit":       {"debit", "debits", "withdrawal", "withdrawals", "debit amount"},
+	"Description": {"description", "payee", "name", "details"},
+	"Memo":        {"memo"},
+	"FITID":       {"fitid", "id", "transaction id", "reference", "reference number", "bank reference"},
+	"Balance":     {"balance", "running balance", "ledger balance"},
+}
+
+// parseBankCSV parses a bank CSV export.  The first non-empty row must be the
+// column headings. A Date column is required, as is either an Amount column
+// or Credit / Debit columns.  If the file has a Balance column, the balance
+// on the most recent line is used as the closing balance.
+//-----------------------------------------------------------------------------
+func parseBankCSV(b []byte, bs *BankStatement) error {
+	cr := csv.NewReader(bytes.NewReader(b))
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	recs, err := cr.ReadAll()
+	if err != nil {
+		return err
+	}
+
+	var col = map[string]int{}
+	var bals []float64
+	hdr := -1
+	for i := 0; i < len(recs); i++ {
+		if hdr < 0 {
+			if len(recs[i]) == 0 || (len(recs[i]) == 1 && len(strings.TrimSpace(recs[i][0])) == 0) {
+				continue
+			}
+			hdr = i
+			for j := 0; j < len(recs[i]); j++ {
+				h := strings.ToLower(strings.TrimSpace(recs[i][j]))
+				for k, v := range bankCSVCols {
+					if _, ok := col[k]; ok {
+						continue
+					}
+					for _, name := range v {
+						if h == name {
+							col[k] = j
+							break
+						}
+					}
+				}
+			}
+			if _, ok := col["Dt"]; !ok {
+				return fmt.Errorf("bank csv file has no Date column")
+			}
+			_, okamt := col["Amount"]
+			_, okcr := col["Credit"]
+			_, okdb := col["Debit"]
+			if !okamt && !okcr && !okdb {
+				return fmt.Errorf("bank csv file has no Amount, Credit, or Debit column")
+			}
+			continue
+		}
+
+		get := func(k string) string {
+			j, ok := col[k]
+			if !ok || j >= len(recs[i]) {
+				return ""
+			}
+			return strings.TrimSpace(recs[i][j])
+		}
+		ds := get("Dt")
+		if len(ds) == 0 {
+			continue // trailing blank or summary line
+		}
+		var t BankStatementLine
+		if t.Dt, err = StringToDate(ds); err != nil {
+			return fmt.Errorf("line %d: invalid date %q", i+1, ds)
+		}
+		if _, ok := col["Amount"]; ok {
+			if t.Amount, err = bankCSVMoney(get("Amount")); err != nil {
+				return fmt.Errorf("line %d: %s", i+1, err.Error())
+			}
+		} else {
+			c, err := bankCSVMoney(get("Credit"))
+			if err != nil {
+				return fmt.Errorf("line %d: %s", i+1, err.Error())
+			}
+			d, err := bankCSVMoney(get("Debit"))
+			if err != nil {
+				return fmt.Errorf("line %d: %s", i+1, err.Error())
+			}
+			t.Amount = RoundToCent(math.Abs(c) - math.Abs(d))
+		}
+		t.FITID = get("FITID")
+		t.Description = get("Description")
+		if m := get("Memo"); len(m) > 0 && m != t.Description {
+			if len(t.Description) > 0 {
+				t.Description += " - "
+			}
+			t.Description += m
+		}
+		if _, ok := col["Balance"]; ok {
+			x, err := bankCSVMoney(get("Balance"))
+			if err != nil {
+				return fmt.Errorf("line %d: %s", i+1, err.Error())
+			}
+			bals = append(bals, x)
+		}
+		bs.L = append(bs.L, t)
+	}
+
+	//--------------------------------------------------------------------
+	// Banks list transactions either oldest first or newest first. The
+	// closing balance is the balance on the newest line.
+	//--------------------------------------------------------------------
+	if n := len(bals); n > 0 && n == len(bs.L) {
+		if bs.L[0].Dt.After(bs.L[n-1].Dt) {
+			bs.ClosingBalance = bals[0]
+		} else {
+			bs.ClosingBalance = bals[n-1]
+		}
+		bs.FLAGS |= FlBankStmtBalance
+	}
+
+	//--------------------------------------------------------------------
+	// CSV exports rarely include a transaction id. Build one from the
+	// date, amount, and the position of the line among lines that share
+	// the same date and amount so that re-importing an overlapping file
+	// does not create duplicates.
+	//--------------------------------------------------------------------
+	seen := map[string]int{}
+	for i := 0; i < len(bs.L); i++ {
+		if len(bs.L[i].FITID) > 0 {
+			continue
+		}
+		key := fmt.Sprintf("%s:%.2f", bs.L[i].Dt.Format("20060102"), bs.L[i].Amount)
+		seen[key]++
+		bs.L[i].FITID = fmt.Sprintf("%s:%d", key, seen[key])
+	}
+	return nil
+}
+
+// bankCSVMoney converts a money value from a bank CSV file. Currency symbols
+// and thousands separators are removed. Values in parentheses are negative.
+//-----------------------------------------------------------------------------
+func bankCSVMoney(s string) (float64, error) {
+	s = strings.TrimSpace(Stripchars(s, "$, "))
+	if len(s) == 0 {
+		return 0, nil
+	}
+	neg := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		neg = true
+		s = s[1 : len(s)-1]
+	}
+	x, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount: %q", s)
+	}
+	if neg {
+		x = -x
+	}
+	return RoundToCent(x), nil
+}
+
+// setBankStatementRange fills in DtStart and DtStop from the statement lines
+// if the file did not supply them.
+//-----------------------------------------------------------------------------
+func setBankStatementRange(bs *BankStatement) {
+	if !bs.DtStart.IsZero() && !bs.DtStop.IsZero() {
+		return
+	}
+	d1 := bs.L[0].Dt
+	d2 := bs.L[0].Dt
+	for i := 1; i < len(bs.L); i++ {
+		if bs.L[i].Dt.Before(d1) {
+			d1 = bs.L[i].Dt
+		}
+		if bs.L[i].Dt.After(d2) {
+			d2 = bs.L[i].Dt
+		}
+	}
+	if bs.DtStart.IsZero() {
+		bs.DtStart = d1
+	}
+	if bs.DtStop.IsZero() {
+		bs.DtStop = d2
+	}
+}
+
+// MatchBankStatementLines proposes a Deposit for each unmatched bank
+// statement line. A line matches a Deposit when the amounts are equal and the
+// dates are no more than days apart.  Each Deposit is matched to at most one
+// line.  When there are several candidates, the pairs whose dates are
+// closest are matched first.
+//
+// Only lines with DID == 0 and a positive Amount are considered. Deposits
+// that are already cleared or already matched to one of the lines are
+// skipped. The DID of matched lines is updated in place.
+//
+// INPUTS
+//    m    = the bank statement lines
+//    d    = the candidate deposits
+//    days = the maximum number of days between the line and deposit dates
+//
+// RETURNS
+//    the number of lines that were matched
+//-----------------------------------------------------------------------------
+func MatchBankStatementLines(m []BankStatementLine, d []Deposit, days int) int {
+	type pair struct {
+		i, k int   // index into m, index into d
+		diff int64 // days between the dates
+	}
+	var p []pair
+
+	used := map[int64]bool{}
+	for i := 0; i < len(m); i++ {
+		if m[i].DID > 0 {
+			used[m[i].DID] = true
+		}
+	}
+
+	for i := 0; i < len(m); i++ {
+		if m[i].DID > 0 || m[i].Amount <= 0 {
+			continue
+		}
+		for k := 0; k < len(d); k++ {
+			if used[d[k].DID] || d[k].FLAGS&FlDepositCleared != 0 {
+				continue
+			}
+			if math.Abs(m[i].Amount-d[k].Amount) >= 0.005 {
+				continue
+			}
+			diff := int64(math.Abs(DateAtTimeZero(m[i].Dt).Sub(DateAtTimeZero(d[k].Dt)).Hours()/24) + 0.5)
+			if diff > int64(days) {
+				continue
+			}
+			p = append(p, pair{i, k, diff})
+		}
+	}
+
+	// stable, so ties keep statement line order, then deposit order
+	sort.SliceStable(p, func(a, b int) bool { return p[a].diff < p[b].diff })
+
+	count := 0
+	for _, x := range p {
+		if m[x.i].DID > 0 || used[d[x.k].DID] {
+			continue
+		}
+		m[x.i].DID = d[x.k].DID
+		used[d[x.k].DID] = true
+		count++
+	}
+	return count
+}
@@ -0,0 +1,161 @@
+package rlib
+
+import (
+	"strings"
+	"testing"
+)
+
+var ofxSGML = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>USD
+<BANKACCTFROM><BANKID>123456789<ACCTID>00012345<ACCTTYPE>CHECKING</BANKACCTFROM>
+<BANKTRANLIST>
+<DTSTART>20180301
+<DTEND>20180331120000.000[-8:PST]
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20180302
+<TRNAMT>1500.00
+<FITID>A1001
+<NAME>DEPOSIT
+<MEMO>BRANCH 12
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20180315
+<TRNAMT>-12.50
+<FITID>A1002
+<NAME>SERVICE CHARGE
+</STMTTRN>
+</BANKTRANLIST>
+<LEDGERBAL><BALAMT>10487.50<DTASOF>20180331</LEDGERBAL>
+<AVAILBAL><BALAMT>9000.00<DTASOF>20180331</AVAILBAL>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`
+
+var ofxXML = `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="211"?>
+<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<BANKTRANLIST>
+<STMTTRN><TRNTYPE>DEP</TRNTYPE><DTPOSTED>20180305</DTPOSTED><TRNAMT>250.75</TRNAMT><FITID>X9</FITID><NAME>SMITH &amp; SONS</NAME></STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>
+`
+
+var bankCSV = `Posting Date,Description,Debit,Credit,Balance
+03/31/2018,SERVICE CHARGE,12.50,,"10,487.50"
+03/02/2018,DEPOSIT,,"1,500.00","10,500.00"
+`
+
+// TestParseBankStatement parses an SGML OFX file, an XML OFX file and a
+// bank CSV file
+func TestParseBankStatement(t *testing.T) {
+	bs, err := ParseBankStatement(strings.NewReader(ofxSGML), "mar.qfx")
+	if err != nil {
+		t.Errorf("ParseBankStatement( sgml ): unexpected error: %s\n", err.Error())
+		return
+	}
+	if len(bs.L) != 2 {
+		t.Errorf("ParseBankStatement( sgml ): expected 2 lines, got %d\n", len(bs.L))
+		return
+	}
+	if bs.L[0].Amount != 1500 || bs.L[0].FITID != "A1001" || bs.L[0].Description != "DEPOSIT - BRANCH 12" {
+		t.Errorf("ParseBankStatement( sgml ): line 0 is wrong: %#v\n", bs.L[0])
+	}
+	if bs.L[1].Amount != -12.50 || bs.L[1].Dt.Format(RRDATEINPFMT) != "2018-03-15" {
+		t.Errorf("ParseBankStatement( sgml ): line 1 is wrong: %#v\n", bs.L[1])
+	}
+	if bs.ClosingBalance != 10487.50 || bs.FLAGS&FlBankStmtBalance == 0 {
+		t.Errorf("ParseBankStatement( sgml ): expected ledger balance 10487.50, got %.2f\n", bs.ClosingBalance)
+	}
+	if bs.DtStart.Format(RRDATEINPFMT) != "2018-03-01" || bs.DtStop.Format(RRDATEINPFMT) != "2018-03-31" {
+		t.Errorf("ParseBankStatement( sgml ): bad range %s - %s\n", bs.DtStart.Format(RRDATEINPFMT), bs.DtStop.Format(RRDATEINPFMT))
+	}
+
+	bs, err = ParseBankStatement(strings.NewReader(ofxXML), "mar.ofx")
+	if err != nil {
+		t.Errorf("ParseBankStatement( xml ): unexpected error: %s\n", err.Error())
+		return
+	}
+	if len(bs.L) != 1 || bs.L[0].Amount != 250.75 || bs.L[0].Description != "SMITH & SONS" {
+		t.Errorf("ParseBankStatement( xml ): wrong lines: %#v\n", bs.L)
+	}
+	if bs.FLAGS&FlBankStmtBalance != 0 {
+		t.Errorf("ParseBankStatement( xml ): no ledger balance was supplied, but the flag is set\n")
+	}
+
+	bs, err = ParseBankStatement(strings.NewReader(bankCSV), "mar.csv")
+	if err != nil {
+		t.Errorf("ParseBankStatement( csv ): unexpected error: %s\n", err.Error())
+		return
+	}
+	if len(bs.L) != 2 {
+		t.Errorf("ParseBankStatement( csv ): expected 2 lines, got %d\n", len(bs.L))
+		return
+	}
+	if bs.L[0].Amount != -12.50 || bs.L[1].Amount != 1500 {
+		t.Errorf("ParseBankStatement( csv ): wrong amounts %.2f, %.2f\n", bs.L[0].Amount, bs.L[1].Amount)
+	}
+	if bs.ClosingBalance != 10487.50 {
+		t.Errorf("ParseBankStatement( csv ): expected closing balance 10487.50, got %.2f\n", bs.ClosingBalance)
+	}
+	if len(bs.L[0].FITID) == 0 || bs.L[0].FITID == bs.L[1].FITID {
+		t.Errorf("ParseBankStatement( csv ): expected unique generated FITIDs, got %q and %q\n", bs.L[0].FITID, bs.L[1].FITID)
+	}
+}
+
+// bankMatchCase is a statement line and the DID it is expected to match
+type bankMatchCase struct {
+	dt     string
+	amount float64
+	expect int64
+}
+
+// TestMatchBankStatementLines verifies that lines are matched by amount,
+// within the date window, closest date first, and at most once per deposit
+func TestMatchBankStatementLines(t *testing.T) {
+	var d []Deposit
+	for _, x := range []struct {
+		did    int64
+		dt     string
+		amount float64
+		flags  uint64
+	}{
+		{1, "2018-03-01", 1500, 0},
+		{2, "2018-03-03", 1500, 0},
+		{3, "2018-03-10", 800, FlDepositCleared},
+		{4, "2018-03-10", 450, 0},
+	} {
+		dt, _ := StringToDate(x.dt)
+		d = append(d, Deposit{DID: x.did, Dt: dt, Amount: x.amount, FLAGS: x.flags})
+	}
+
+	var cases = []bankMatchCase{
+		{"2018-03-03", 1500, 2}, // closest date wins
+		{"2018-03-04", 1500, 1}, // DID 2 is taken, DID 1 is 3 days away
+		{"2018-03-11", 800, 0},  // DID 3 has already cleared
+		{"2018-03-25", 450, 0},  // outside the window
+		{"2018-03-10", -450, 0}, // debits are not matched
+	}
+	var m []BankStatementLine
+	for i := 0; i < len(cases); i++ {
+		dt, _ := StringToDate(cases[i].dt)
+		m = append(m, BankStatementLine{Dt: dt, Amount: cases[i].amount})
+	}
+
+	n := MatchBankStatementLines(m, d, BankMatchDays)
+	if n != 2 {
+		t.Errorf("MatchBankStatementLines: expected 2 matches, got %d\n", n)
+	}
+	for i := 0; i < len(cases); i++ {
+		t.Logf("info: case[%d] : %s %8.2f expect DID %d, got %d\n", i, cases[i].dt, cases[i].amount, cases[i].expect, m[i].DID)
+		if m[i].DID != cases[i].expect {
+			t.Errorf("case[%d] : %s %8.2f expect DID %d, got %d\n", i, cases[i].dt, cases[i].amount, cases[i].expect, m[i].DID)
+		}
+	}
+}
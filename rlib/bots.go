@@ -14,7 +14,14 @@ const (
 	TLReportBot       = int64(-7)
 	TLInstanceBot     = int64(-8)
 	CSVLoaderApp      = int64(-9)
-	LastBotUID        = int64(-9) // set this to the uid of the last bot
+	LateFeeBot        = int64(-10)
+	RentEscalationBot = int64(-11)
+	TenantPortalApp   = int64(-12)
+	MonthToMonthBot   = int64(-13)
+	ARIExportBot      = int64(-14)
+	OTAChannelApp     = int64(-15)
+	NightAuditBot     = int64(-16)
+	LastBotUID        = int64(-16) // set this to the uid of the last bot
 )
 
 // BotRegistryEntry is a struct to associate a bot's id with its name and
@@ -40,6 +47,13 @@ var BotReg = map[int64]BotRegistryEntry{
 	TLReportBot:       {TLReportBot, "TLReportBot", "TaskList Report Bot"},
 	TLInstanceBot:     {TLInstanceBot, "TLInstanceBot", "TaskList Instance Bot"},
 	CSVLoaderApp:      {CSVLoaderApp, "CSVLoaderApp", "CSV File Loader App"},
+	LateFeeBot:        {LateFeeBot, "LateFeeBot", "Late Fee Assessment Bot"},
+	RentEscalationBot: {RentEscalationBot, "RentEscalationBot", "Rent Escalation Bot"},
+	TenantPortalApp:   {TenantPortalApp, "TenantPortalApp", "Tenant Portal"},
+	MonthToMonthBot:   {MonthToMonthBot, "MonthToMonthBot", "Month To Month Renewal Bot"},
+	ARIExportBot:      {ARIExportBot, "ARIExportBot", "Rate And Availability Export Bot"},
+	OTAChannelApp:     {OTAChannelApp, "OTAChannelApp", "OTA Channel Reservations"},
+	NightAuditBot:     {NightAuditBot, "NightAuditBot", "Night Audit Bot"},
 }
 
 // BotName finds and returns the name associated with the bot uid.
@@ -0,0 +1,47 @@
+package rlib
+
+import (
+	"context"
+	"time"
+)
+
+// BudgetMonths is the number of monthly amounts in a budget year
+const BudgetMonths = 12
+
+// BudgetMonth returns the month of the budget year starting on d1 that
+// holds dt, 0 is the first month.  The result is outside 0 - 11 if dt is not
+// in the year.
+func BudgetMonth(d1, dt *time.Time) int {
+	return (dt.Year()-d1.Year())*12 + int(dt.Month()-d1.Month())
+}
+
+// GetBudgetYear returns the monthly budgets of business bid for the fiscal
+// year starting on d1.
+//
+// INPUTS
+//     ctx = context
+//     bid = business id
+//     d1  = first day of the fiscal year
+//
+// RETURNS
+//     the BudgetMonths amounts of each budgeted account, by LID
+//     any error encountered
+//-----------------------------------------------------------------------------
+func GetBudgetYear(ctx context.Context, bid int64, d1 *time.Time) (map[int64][]float64, error) {
+	var m = map[int64][]float64{}
+	d2 := d1.AddDate(0, BudgetMonths, 0)
+	t, err := GetBudgetsInRange(ctx, bid, d1, &d2)
+	if err != nil {
+		return m, err
+	}
+	for i := 0; i < len(t); i++ {
+		if _, ok := m[t[i].LID]; !ok {
+			m[t[i].LID] = make([]float64, BudgetMonths)
+		}
+		k := BudgetMonth(d1, &t[i].Dt)
+		if k >= 0 && k < BudgetMonths {
+			m[t[i].LID][k] += t[i].Amount
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,196 @@
+package rlib
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// CAMSqFtAttr is the name of the RentableType custom attribute that holds
+// the square feet of a rentable.  The rentroll report uses the same one.
+const CAMSqFtAttr = "Square Feet"
+
+// CAMCreditPaymentTypeName is the name of the PaymentType used for the
+// receipts that credit tenants who paid more than their share when the
+// business properties do not name one.
+const CAMCreditPaymentTypeName = "CAM Credit"
+
+// BizPropsCAM holds the operating expense pass-through settings for a
+// business.  It is stored as part of the business properties (BizProps.CAM).
+//
+//    ExpenseARNames  - Expense account rules of the recoverable operating
+//                      expenses.  The expenses are totaled by the GL
+//                      account each rule debits.
+//    EstimateARNames - Assessment account rules of the estimated charges
+//                      billed to tenants during the year
+//    TrueUpARName    - Assessment account rule for the amount a tenant owes
+//                      after the reconciliation
+//    CreditARName    - Receipt account rule for the amount credited to a
+//                      tenant who paid more than their share
+//    PMTName         - name of the PaymentType for the credits,
+//                      CAMCreditPaymentTypeName if empty
+//-----------------------------------------------------------------------------
+type BizPropsCAM struct {
+	ExpenseARNames  []string
+	EstimateARNames []string
+	TrueUpARName    string
+	CreditARName    string
+	PMTName         string
+}
+
+// GetCAMPolicy returns the operating expense pass-through settings
+// configured in the business properties named bizPropName for business BID.
+//
+// INPUTS
+//     ctx         = context
+//     BID         = business id
+//     bizPropName = name of the business properties, usually "general"
+//
+// RETURNS
+//     the pass-through settings
+//     any error encountered
+//-----------------------------------------------------------------------------
+func GetCAMPolicy(ctx context.Context, BID int64, bizPropName string) (BizPropsCAM, error) {
+	bizPropJSON, err := GetDataFromBusinessPropertyName(ctx, bizPropName, BID)
+	if err != nil {
+		return BizPropsCAM{}, err
+	}
+	p := bizPropJSON.CAM
+	if len(p.PMTName) == 0 {
+		p.PMTName = CAMCreditPaymentTypeName
+	}
+	return p, nil
+}
+
+// PassesThroughExpenses returns true if the tenant of the rental agreement
+// pays its share of the operating expenses
+func (ra *RentalAgreement) PassesThroughExpenses() bool {
+	return ra.LeaseType == LeaseTypeModifiedGross || ra.LeaseType == LeaseTypeTripleNet
+}
+
+// BaseYear returns the period of the rental agreement's base year, which is
+// the year ending on BaseYearEnd.
+//
+// RETURNS
+//     d1, d2 - start and end (not included) of the base year
+//-----------------------------------------------------------------------------
+func (ra *RentalAgreement) BaseYear() (time.Time, time.Time) {
+	d2 := ra.BaseYearEnd.AddDate(0, 0, 1)
+	return d2.AddDate(-1, 0, 0), d2
+}
+
+// IDtoString is the standard id string for a CAMReconciliation
+func (c *CAMReconciliation) IDtoString() string {
+	return IDtoString("CAMR", c.CAMRID)
+}
+
+// ProRata returns the tenant's share of the business's expenses: its
+// fraction of the square feet times the fraction of the period it occupied.
+func (c *CAMReconciliation) ProRata() float64 {
+	if c.TotalSqFt <= 0 {
+		return 0
+	}
+	return float64(c.SqFt) / float64(c.TotalSqFt) * c.Occupancy
+}
+
+// Compute totals the lines into Expenses and BaseExpenses and computes the
+// tenant's Share and the TrueUp.  The tenant pays its pro-rata share of the
+// expenses above the base year (BaseExpenses is 0 unless the agreement has
+// a base year), never less than 0.  Unless the expenses are passed through,
+// the share is capped at ExpensesStop, prorated for the occupancy.  The
+// line shares are prorated the same way, so they always add up to Share.
+//-----------------------------------------------------------------------------
+func (c *CAMReconciliation) Compute() {
+	c.Expenses = 0
+	c.BaseExpenses = 0
+	r := c.ProRata()
+	for i := 0; i < len(c.L); i++ {
+		c.Expenses += c.L[i].Expenses
+		c.BaseExpenses += c.L[i].BaseExpenses
+		c.L[i].Share = RoundToCent((c.L[i].Expenses - c.L[i].BaseExpenses) * r)
+	}
+	c.Expenses = RoundToCent(c.Expenses)
+	c.BaseExpenses = RoundToCent(c.BaseExpenses)
+
+	c.Share = RoundToCent(math.Max(c.Expenses-c.BaseExpenses, 0) * r)
+	if c.ExpenseAdjustmentType != ExpAdjPassThrough && c.ExpensesStop > 0 {
+		c.Share = RoundToCent(math.Min(c.Share, c.ExpensesStop*c.Occupancy))
+	}
+	c.prorateLines()
+	c.TrueUp = RoundToCent(c.Share - c.Estimated)
+}
+
+// prorateLines scales the line shares so that they add up to Share, which
+// may have been clamped at 0 or capped by the stop.  The cents lost to
+// rounding go to the last line.
+func (c *CAMReconciliation) prorateLines() {
+	var sum float64
+	for i := 0; i < len(c.L); i++ {
+		sum += c.L[i].Share
+	}
+	sum = RoundToCent(sum)
+	if sum == c.Share || len(c.L) == 0 {
+		return
+	}
+	k := float64(0)
+	if sum > 0 {
+		k = c.Share / sum
+	}
+	rest := c.Share
+	for i := 0; i < len(c.L)-1; i++ {
+		c.L[i].Share = RoundToCent(c.L[i].Share * k)
+		rest -= c.L[i].Share
+	}
+	c.L[len(c.L)-1].Share = RoundToCent(rest)
+}
+
+// Occupancy returns the fraction of the period d1 - d2 that falls within
+// a1 - a2
+//-----------------------------------------------------------------------------
+func Occupancy(d1, d2, a1, a2 *time.Time) float64 {
+	days := d2.Sub(*d1).Hours() / 24
+	if days <= 0 {
+		return 0
+	}
+	s := *d1
+	if a1.After(s) {
+		s = *a1
+	}
+	e := *d2
+	if a2.Before(e) {
+		e = *a2
+	}
+	if !e.After(s) {
+		return 0
+	}
+	return math.Round(e.Sub(s).Hours()/24) / math.Round(days)
+}
+
+// RentableSqFt returns the square feet of rentable rid on dt. It is the
+// CAMSqFtAttr custom attribute of the rentable's type on that date, or 0 if
+// the type does not have one.
+//
+// INPUTS
+//     ctx  = db context
+//     xbiz = the business, with its rentable types loaded
+//     rid  = rentable id
+//     dt   = date of interest
+//
+// RETURNS
+//     the square feet
+//     any error encountered
+//-----------------------------------------------------------------------------
+func RentableSqFt(ctx context.Context, xbiz *XBusiness, rid int64, dt *time.Time) (int64, error) {
+	rtr, err := GetRentableTypeRefForDate(ctx, rid, dt)
+	if err != nil {
+		return 0, err
+	}
+	if rtr.RTID == 0 {
+		return 0, nil
+	}
+	c, ok := xbiz.RT[rtr.RTID].CA[CAMSqFtAttr]
+	if !ok {
+		return 0, nil
+	}
+	return IntFromString(c.Value, "invalid "+CAMSqFtAttr+" attribute")
+}
@@ -0,0 +1,76 @@
+package rlib
+
+import (
+	"testing"
+	"time"
+)
+
+// TestCAMCompute checks the tenant's share and the true-up for the
+// different expense adjustment types
+func TestCAMCompute(t *testing.T) {
+	var cases = []struct {
+		adj                    int64
+		sqft, total            int64
+		occ                    float64
+		exp, base, stop, est   float64
+		share, trueUp, l0Share float64
+	}{
+		{ExpAdjNoBaseYear, 2500, 10000, 1, 40000, 0, 0, 9000, 10000, 1000, 7500},             // 25%, underpaid
+		{ExpAdjNoBaseYear, 2500, 10000, 1, 40000, 0, 0, 12000, 10000, -2000, 7500},           // overpaid
+		{ExpAdjBaseYear, 2500, 10000, 1, 40000, 32000, 0, 1500, 2000, 500, 1500},             // above base year
+		{ExpAdjBaseYear, 2500, 10000, 1, 30000, 32000, 0, 0, 0, 0, 0},                        // below base year, lines clamped too
+		{ExpAdjNoBaseYear, 2500, 10000, 1, 40000, 0, 8000, 9000, 8000, -1000, 6000},          // capped by the stop, lines prorated
+		{ExpAdjNoBaseYear, 2500, 10000, 0.5, 40000, 0, 8000, 3000, 4000, 1000, 3000},         // half year, stop prorated
+		{ExpAdjPassThrough, 2500, 10000, 1, 40000, 0, 8000, 9000, 10000, 1000, 7500},         // pass through ignores the stop
+		{ExpAdjNoBaseYear, 1000, 0, 1, 40000, 0, 0, 500, 0, -500, 0},                         // no square feet known
+		{ExpAdjNoBaseYear, 1234, 9876, 0.75, 51234.56, 0, 0, 4000, 4801.29, 801.29, 3600.97}, // rounding
+	}
+	for i := 0; i < len(cases); i++ {
+		c := cases[i]
+		r := CAMReconciliation{
+			ExpenseAdjustmentType: c.adj,
+			SqFt:                  c.sqft,
+			TotalSqFt:             c.total,
+			Occupancy:             c.occ,
+			ExpensesStop:          c.stop,
+			Estimated:             c.est,
+			L: []CAMReconLine{
+				{Expenses: RoundToCent(c.exp * 0.75), BaseExpenses: RoundToCent(c.base * 0.75)},
+				{Expenses: RoundToCent(c.exp * 0.25), BaseExpenses: RoundToCent(c.base * 0.25)},
+			},
+		}
+		r.Compute()
+		if r.Expenses != c.exp || r.BaseExpenses != c.base {
+			t.Errorf("case %d: expected expenses %.2f base %.2f, got %.2f %.2f\n", i, c.exp, c.base, r.Expenses, r.BaseExpenses)
+		}
+		if r.Share != c.share || r.TrueUp != c.trueUp {
+			t.Errorf("case %d: expected share %.2f true-up %.2f, got %.2f %.2f\n", i, c.share, c.trueUp, r.Share, r.TrueUp)
+		}
+		if r.L[0].Share != c.l0Share {
+			t.Errorf("case %d: expected line share %.2f, got %.2f\n", i, c.l0Share, r.L[0].Share)
+		}
+		if x := RoundToCent(r.L[0].Share + r.L[1].Share); x != r.Share {
+			t.Errorf("case %d: expected the line shares to add up to %.2f, got %.2f\n", i, r.Share, x)
+		}
+	}
+}
+
+// TestOccupancy checks the fraction of a period covered by an agreement
+func TestOccupancy(t *testing.T) {
+	d1 := time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)
+	d2 := time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC)
+	var cases = []struct {
+		a1, a2 time.Time
+		expect float64
+	}{
+		{time.Date(2016, time.March, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC), 1},
+		{time.Date(2017, time.July, 2, 0, 0, 0, 0, time.UTC), time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC), 183.0 / 365},
+		{time.Date(2016, time.July, 1, 0, 0, 0, 0, time.UTC), time.Date(2017, time.February, 1, 0, 0, 0, 0, time.UTC), 31.0 / 365},
+		{time.Date(2018, time.July, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, time.February, 1, 0, 0, 0, 0, time.UTC), 0},
+	}
+	for i := 0; i < len(cases); i++ {
+		if x := Occupancy(&d1, &d2, &cases[i].a1, &cases[i].a2); x != cases[i].expect {
+			t.Errorf("case %d: expected %f, got %f\n", i, cases[i].expect, x)
+		}
+	}
+}
@@ -7,10 +7,12 @@ import (
 
 // CloseInfo contains relevant close period information for a business
 type CloseInfo struct {
-	BID       int64     // Business ID
-	LastClose time.Time // last closed period
-	CPID      int64     // id of last close
-	BKDTRACP  bool      // backdate rental agreements in closed period allowed?
+	BID           int64     // Business ID
+	LastClose     time.Time // last closed period
+	CPID          int64     // id of last close
+	BKDTRACP      bool      // backdate rental agreements in closed period allowed?
+	LastYearClose time.Time // first day after the last closed fiscal year
+	FYCID         int64     // id of last fiscal year close, 0 if none
 }
 
 // GetCloseInfo returns a struct of information about closed periods and
@@ -37,6 +39,9 @@ func GetCloseInfo(ctx context.Context, bid int64) (CloseInfo, error) {
 	}
 	ci.LastClose = lc.Dt
 	ci.CPID = lc.CPID
+	if err = getYearCloseInfo(ctx, &ci); err != nil {
+		return ci, err
+	}
 	if err = getBiz(bid, &b); err != nil {
 		return ci, err
 	}
@@ -71,8 +76,22 @@ func GetAllBizCloseInfo(ctx context.Context) (map[string]CloseInfo, error) {
 		}
 		ci.LastClose = lc.Dt
 		ci.CPID = lc.CPID
+		if err = getYearCloseInfo(ctx, &ci); err != nil {
+			return m, err
+		}
 		ci.BKDTRACP = n[i].FLAGS&(1<<1) != 0
 		m[n[i].Designation] = ci
 	}
 	return m, nil
 }
+
+// getYearCloseInfo sets the last fiscal year close of ci.BID in ci
+func getYearCloseInfo(ctx context.Context, ci *CloseInfo) error {
+	fyc, err := GetLastFiscalYearClose(ctx, ci.BID)
+	if err != nil {
+		return err
+	}
+	ci.LastYearClose = fyc.DtStop
+	ci.FYCID = fyc.FYCID
+	return nil
+}
@@ -3,12 +3,26 @@ package rlib
 import (
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 )
 
+// PasswordHashIterations is the number of PBKDF2 iterations used by
+// HashPassword.  Hashes made with a different count can still be checked
+// because the count is stored in the hash.
+const PasswordHashIterations = 100000
+
+// passwordHashScheme identifies the format of the hashes made by
+// HashPassword
+const passwordHashScheme = "pbkdf2-sha256"
+
 // DecryptOrEmpty returns a decrypted string if there were no issues
 // otherwise it will return an empty string.
 //
@@ -123,3 +137,101 @@ func DecryptCore(ciphertext []byte, key []byte) ([]byte, error) {
 	nonce, ciphertext := ciphertext[:nonceSize], ciphertext[nonceSize:]
 	return gcm.Open(nil, nonce, ciphertext, nil)
 }
+
+// HashPassword returns a salted hash of password suitable for storing in
+// the database. The hash has the form:
+//
+//     pbkdf2-sha256$<iterations>$<salt>$<key>
+//
+// where salt and key are base64 encoded.
+//
+// INPUTS:
+//     password - the password to hash
+//
+// RETURNS:
+//     the hash
+//     any error encountered
+//-----------------------------------------------------------------------------
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return "", err
+	}
+	key := PBKDF2SHA256([]byte(password), salt, PasswordHashIterations, sha256.Size)
+	return fmt.Sprintf("%s$%d$%s$%s", passwordHashScheme, PasswordHashIterations,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// CheckPassword returns true if password matches hash, a value returned by
+// HashPassword.  A malformed hash never matches.
+//
+// INPUTS:
+//     hash     - the stored hash
+//     password - the password to check
+//
+// RETURNS:
+//     true if the password matches
+//-----------------------------------------------------------------------------
+func CheckPassword(hash, password string) bool {
+	sa := strings.Split(hash, "$")
+	if len(sa) != 4 || sa[0] != passwordHashScheme {
+		return false
+	}
+	iter, err := strconv.Atoi(sa[1])
+	if err != nil || iter < 1 {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(sa[2])
+	if err != nil {
+		return false
+	}
+	key, err := base64.RawStdEncoding.DecodeString(sa[3])
+	if err != nil || len(key) == 0 {
+		return false
+	}
+	k := PBKDF2SHA256([]byte(password), salt, iter, len(key))
+	return hmac.Equal(k, key)
+}
+
+// PBKDF2SHA256 derives a key of keyLen bytes from password and salt using
+// PBKDF2 (RFC 8018) with HMAC-SHA256 as the pseudorandom function.
+//
+// INPUTS:
+//     password - the password
+//     salt     - the salt
+//     iter     - number of iterations
+//     keyLen   - length of the derived key in bytes
+//
+// RETURNS:
+//     the derived key
+//-----------------------------------------------------------------------------
+func PBKDF2SHA256(password, salt []byte, iter, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	hlen := prf.Size()
+	nblocks := (keyLen + hlen - 1) / hlen
+	var buf [4]byte
+	dk := make([]byte, 0, nblocks*hlen)
+	u := make([]byte, hlen)
+	for block := 1; block <= nblocks; block++ {
+		// U1 = PRF(password, salt || INT(block))
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(buf[:], uint32(block))
+		prf.Write(buf[:])
+		dk = prf.Sum(dk)
+		t := dk[len(dk)-hlen:]
+		copy(u, t)
+
+		// T = U1 ^ U2 ^ ... ^ Uiter
+		for n := 2; n <= iter; n++ {
+			prf.Reset()
+			prf.Write(u)
+			u = u[:0]
+			u = prf.Sum(u)
+			for i := range u {
+				t[i] ^= u[i]
+			}
+		}
+	}
+	return dk[:keyLen]
+}
@@ -0,0 +1,62 @@
+package rlib
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// TestPBKDF2SHA256 checks the key derivation against the RFC 7914 test
+// vectors and a common 32 byte vector
+func TestPBKDF2SHA256(t *testing.T) {
+	var cases = []struct {
+		password, salt string
+		iter, keyLen   int
+		expect         string
+	}{
+		{"passwd", "salt", 1, 64, "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783"},
+		{"Password", "NaCl", 80000, 64, "4ddcd8f60b98be21830cee5ef22701f9641a4418d04c0414aeff08876b34ab56a1d425a1225833549adb841b51c9b3176a272bdebba1d078478f62b397f33c8d"},
+		{"password", "salt", 4096, 32, "c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a"},
+	}
+	for i := 0; i < len(cases); i++ {
+		c := cases[i]
+		k := PBKDF2SHA256([]byte(c.password), []byte(c.salt), c.iter, c.keyLen)
+		if x := hex.EncodeToString(k); x != c.expect {
+			t.Errorf("case %d: expected %s, got %s\n", i, c.expect, x)
+		}
+	}
+}
+
+// TestCheckPassword checks that a hashed password matches only itself and
+// that malformed hashes never match
+func TestCheckPassword(t *testing.T) {
+	h, err := HashPassword("correct horse")
+	if err != nil {
+		t.Fatalf("HashPassword: %s\n", err.Error())
+	}
+	if !strings.HasPrefix(h, passwordHashScheme+"$") {
+		t.Errorf("unexpected hash format: %s\n", h)
+	}
+	h2, _ := HashPassword("correct horse")
+	if h == h2 {
+		t.Errorf("expected different salts, got the same hash twice\n")
+	}
+	var cases = []struct {
+		hash, password string
+		expect         bool
+	}{
+		{h, "correct horse", true},
+		{h2, "correct horse", true},
+		{h, "correct horse ", false},
+		{h, "", false},
+		{"", "", false},
+		{"pbkdf2-sha256$0$c2FsdA$c2FsdA", "x", false},
+		{"md5$1$c2FsdA$c2FsdA", "x", false},
+		{strings.Replace(h, "$", "$$", 1), "correct horse", false},
+	}
+	for i := 0; i < len(cases); i++ {
+		if x := CheckPassword(cases[i].hash, cases[i].password); x != cases[i].expect {
+			t.Errorf("case %d: expected %t, got %t\n", i, cases[i].expect, x)
+		}
+	}
+}
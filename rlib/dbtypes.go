@@ -43,7 +43,8 @@ const (
 	ELEMVEHICLE         = 15
 	ELEMASSESSMENT      = 16
 	ELEMRECEIPT         = 17
-	ELEMLAST            = 17 // keep in sync with last one added
+	ELEMFOLIO           = 18
+	ELEMLAST            = 18 // keep in sync with last one added
 
 	RASTATEAppEdit          = 0
 	RASTATEPendingApproval1 = 1
@@ -74,6 +75,7 @@ const (
 	RCPTPARTIALALLOCATED = 1
 	RCPTFULLYALLOCATED   = 2
 	RCPTREVERSED         = 4
+	RCPTACHPENDING       = 8
 
 	// RTACTIVE et all are flags for rentableTypes
 	RTACTIVE   = 0
@@ -155,6 +157,9 @@ const (
 	JNLTYPERCPT = 2 // record is the result of a Receipt
 	JNLTYPEEXP  = 3 // record is the result of an Expense
 	JNLTYPEXFER = 4 // funds transfer between accounts
+	JNLTYPEVBIL = 5 // record is the result of approving or voiding a VendorBill
+	JNLTYPEVPMT = 6 // record is the result of paying a VendorBill
+	JNLTYPECLOS = 7 // record is the result of closing or reopening a fiscal year
 
 	JOURNALTYPEASMID  = 1
 	JOURNALTYPERCPTID = 2
@@ -233,6 +238,176 @@ type ClosePeriod struct {
 	ExpandAsmDtStop  time.Time // NOTE: for use in ExpandAssessment. If expansion date is > ExpandAsmDtStop then it gets snapped to ExpandAsmDtStop
 }
 
+// FiscalYearClose records the close of a fiscal year.  Reopening the year
+// reverses the closing journal, the record is kept as the audit trail.
+type FiscalYearClose struct {
+	FYCID       int64     // unique id for this close
+	BID         int64     // business id
+	DtStart     time.Time // first day of the fiscal year
+	DtStop      time.Time // first day of the next fiscal year
+	Dt          time.Time // date of the closing journal, the last day of the year
+	LID         int64     // retained earnings GL account
+	JID         int64     // closing journal
+	NetIncome   float64   // amount credited to retained earnings, negative for a loss
+	FLAGS       uint64    // 1<<0 reopened
+	RevJID      int64     // journal reversing JID when the year was reopened
+	DtReopened  time.Time // when the year was reopened
+	ReopenedBy  int64     // UID of the person who reopened the year
+	Reason      string    // why the year was reopened
+	LastModTime time.Time // when was this record last written
+	LastModBy   int64     // employee UID (from phonebook) that modified it
+	CreateTS    time.Time // when was this record created
+	CreateBy    int64     // employee UID (from phonebook) that created it
+}
+
+// Budget is the budgeted amount of a GL account for a month.  Amount has
+// the natural sign of the account, income and expenses are both positive.
+type Budget struct {
+	BGTID       int64     // unique id for this budget amount
+	BID         int64     // business id
+	LID         int64     // GL account
+	Dt          time.Time // first day of the month
+	Amount      float64   // budgeted amount for the month
+	Comment     string    // note
+	LastModTime time.Time // when was this record last written
+	LastModBy   int64     // employee UID (from phonebook) that modified it
+	CreateTS    time.Time // when was this record created
+	CreateBy    int64     // employee UID (from phonebook) that created it
+}
+
+// AcctMap maps a GL account of a business to an account of the
+// consolidated chart of accounts used by the portfolio reports.
+type AcctMap struct {
+	AMID        int64     // unique id for this map
+	BID         int64     // business id
+	LID         int64     // GL account of the business
+	GLNumber    string    // consolidated GL number
+	Name        string    // consolidated account name
+	LastModTime time.Time // when was this record last written
+	LastModBy   int64     // employee UID (from phonebook) that modified it
+	CreateTS    time.Time // when was this record created
+	CreateBy    int64     // employee UID (from phonebook) that created it
+}
+
+// RenewalOffer is a renewal offer for one rentable of a rental agreement
+// that is about to expire.  The offers of an agreement are accepted
+// together, this creates the amended agreement NewRAID for the new term.
+type RenewalOffer struct {
+	ROID        int64     // unique id for this offer
+	BID         int64     // business id
+	RAID        int64     // the expiring rental agreement
+	RID         int64     // the rentable
+	ASMID       int64     // the recurring rent assessment being renewed
+	ARID        int64     // account rule of the rent assessment
+	CurrentRent float64   // rent at the end of the current term
+	MarketRent  float64   // market rate of the rentable for the new term
+	OfferRent   float64   // rent offered for the new term
+	TermStart   time.Time // start of the new term, the AgreementStop of RAID
+	TermStop    time.Time // stop of the new term
+	OfferDt     time.Time // date the offer was made
+	ExpireDt    time.Time // the offer must be accepted before this date
+	Status      int64     // 0 = open, 1 = accepted, 2 = declined, 3 = expired
+	NewRAID     int64     // the amended rental agreement, once accepted
+	Comment     string    // note
+	LastModTime time.Time // when was this record last written
+	LastModBy   int64     // employee UID (from phonebook) that modified it
+	CreateTS    time.Time // when was this record created
+	CreateBy    int64     // employee UID (from phonebook) that created it
+}
+
+// Folio is a guest folio: the account of one stay.  Its room charges, taxes
+// and incidentals are Assessments of the RentalAgreement with AssocElemType
+// ELEMFOLIO and AssocElemID FOLID.  The night audit posts the room and tax
+// charges of each night of the stay.
+type Folio struct {
+	FOLID         int64     // unique id for this folio
+	BID           int64     // business id
+	RAID          int64     // the rental agreement of the stay
+	RID           int64     // the rentable (room)
+	RTID          int64     // its rentable type
+	TCID          int64     // the guest
+	DtArrive      time.Time // arrival
+	DtDepart      time.Time // departure
+	Adults        int64     // adults staying
+	Children      int64     // children staying
+	Rate          float64   // nightly room rate, 0 to use the rate plans
+	PostedThrough time.Time // the nights before this date have been posted
+	Status        int64     // 0 = open, 1 = closed
+	Comment       string    // note
+	LastModTime   time.Time // when was this record last written
+	LastModBy     int64     // employee UID (from phonebook) that modified it
+	CreateTS      time.Time // when was this record created
+	CreateBy      int64     // employee UID (from phonebook) that created it
+}
+
+// NightAudit is a night audit: the room and tax charges of business date Dt
+// were posted to the folios in house and the business date was rolled to
+// the next day.  The figures of the night are kept for the night audit
+// report.
+type NightAudit struct {
+	NAID        int64     // unique id for this night audit
+	BID         int64     // business id
+	Dt          time.Time // the business date audited
+	Rooms       int64     // rentables rented daily
+	Occupied    int64     // how many of them were in house
+	Arrivals    int64     // stays that arrived
+	Departures  int64     // stays that departed
+	RoomRevenue float64   // room charges posted
+	TaxRevenue  float64   // taxes posted on the room charges
+	Comment     string    // note
+	LastModTime time.Time // when was this record last written
+	LastModBy   int64     // employee UID (from phonebook) that modified it
+	CreateTS    time.Time // when was this record created
+	CreateBy    int64     // employee UID (from phonebook) that created it
+}
+
+// OTAReservation is a reservation received from a channel in an
+// OTA_HotelResNotifRQ message.  ResID is the channel's confirmation number;
+// a message for a ResID already received updates or cancels the reservation
+// it created.  The reservation itself is the RentableLeaseStatus of RID with
+// ConfirmationCode.
+type OTAReservation struct {
+	ORID             int64     // unique id for this reservation
+	BID              int64     // business id
+	RequestorID      string    // the channel that sent it
+	ResID            string    // the channel's confirmation number
+	RID              int64     // the rentable reserved
+	RTID             int64     // its rentable type
+	DtStart          time.Time // arrival
+	DtStop           time.Time // departure
+	ConfirmationCode string    // ConfirmationCode of the RentableLeaseStatus
+	Status           int64     // 0 = booked, 1 = cancelled
+	LastModTime      time.Time // when was this record last written
+	LastModBy        int64     // employee UID (from phonebook) that modified it
+	CreateTS         time.Time // when was this record created
+	CreateBy         int64     // employee UID (from phonebook) that created it
+}
+
+// RateRecommendation is a market rate recommended for a RentableType by the
+// pricing engine.  Once approved, AppliedRate is written as the market rate
+// of the type starting on DtStart.
+type RateRecommendation struct {
+	RCMID           int64     // unique id for this recommendation
+	BID             int64     // business id
+	RTID            int64     // the rentable type
+	DtStart         time.Time // the recommended rate starts on this date
+	DtStop          time.Time // and stops on this date
+	CurrentRate     float64   // market rate in force when the recommendation was made
+	RecommendedRate float64   // the rate recommended
+	AppliedRate     float64   // the rate approved, 0 until approved
+	Rentables       int64     // number of rentables of the type
+	Occupied        int64     // how many of them were occupied
+	MoveOuts        int64     // notices to move out within the look ahead period
+	AvgDaysVacant   float64   // average length of the vacancies of the history period
+	SeasonalPct     float64   // seasonal adjustment, percent
+	Status          int64     // 0 = open, 1 = approved, 2 = rejected
+	Comment         string    // note
+	LastModTime     time.Time // when was this record last written
+	LastModBy       int64     // employee UID (from phonebook) that modified it
+	CreateTS        time.Time // when was this record created
+	CreateBy        int64     // employee UID (from phonebook) that created it
+}
+
 // Task is an indivually tracked work item.
 // FLAGS are defined as follows:
 //    1<<0 pre-completion required (if 0 then there is no pre-completion required)
@@ -634,14 +809,14 @@ type RentalAgreement struct {
 	T                      []XPerson   // all the users
 	Renewal                int64       // 0 = not set, 1 = month to month automatic renewal, 2 = lease extension options
 	SpecialProvisions      string      // free-form text
-	LeaseType              int64       // Full Service Gross, Gross, ModifiedGross, Tripple Net
-	ExpenseAdjustmentType  int64       // Base Year, No Base Year, Pass Through
+	LeaseType              int64       // Full Service Gross, Gross, ModifiedGross, Tripple Net (LeaseTypeFullServiceGross...)
+	ExpenseAdjustmentType  int64       // Base Year, No Base Year, Pass Through (ExpAdjBaseYear...)
 	ExpensesStop           float64     // cap on the amount of oexpenses that can be passed through to the tenant
 	ExpenseStopCalculation string      // note on how to determine the expense stop
 	BaseYearEnd            time.Time   // last day of the base year
 	ExpenseAdjustment      time.Time   // the next date on which an expense adjustment is due
 	EstimatedCharges       float64     // a periodic fee charged to the tenant to reimburse LL for anticipated expenses
-	RateChange             float64     // predetermined amount of rent increase, expressed as a percentage
+	RateChange             float64     // predetermined amount of rent increase, expressed as a percentage (a fixed amount if FLAGS & RAFixedRateChange)
 	NextRateChange         time.Time   // he next date on which a RateChange will occur
 	PermittedUses          string      // indicates primary use of the space, ex: doctor's office, or warehouse/distribution, etc.
 	ExclusiveUses          string      // those uses to which the tenant has the exclusive rights within a complex, ex: Trader Joe's may have the exclusive right to sell groceries
@@ -687,8 +862,69 @@ type RentalAgreementPayor struct {
 	CreateBy    int64     // employee UID (from phonebook) that created it
 }
 
+// Tax is a tax levied by a jurisdiction, its TaxingAuthority.  Its rates
+// are in TaxRate, the rentable types it applies to in RentableTypeTax.  The
+// tax on each charge is an assessment of account rule ARID, recorded in
+// AssessmentTax.
+type Tax struct {
+	TAXID                  int64     // unique identifier for this tax
+	BID                    int64     // Business
+	Name                   string    // a name for this tax
+	TaxingAuthority        string    // name of the Taxing Authority, the jurisdiction
+	TaxingAuthorityAddress string    // where these taxes are sent
+	FilingDate             time.Time // date on which taxes need to be filed
+	FilingCycle            int64     // epoch date for recurrence calculation
+	Instructions           string    // filing instructions
+	ARID                   int64     // account rule of the tax assessments
+	ExemptDays             int64     // stays longer than this many days are exempt, 0 = no exemption
+	LastModTime            time.Time // when was this record last written
+	LastModBy              int64     // employee UID (from phonebook) that modified it
+	CreateTS               time.Time // when was this record created
+	CreateBy               int64     // employee UID (from phonebook) that created it
+}
+
+// TaxRate is the rate of a tax for DtStart - DtStop
+type TaxRate struct {
+	TRID        int64     // unique identifier for this rate
+	TAXID       int64     // the tax
+	BID         int64     // Business
+	DtStart     time.Time // date when this tax rate goes into effect
+	DtStop      time.Time // date when this tax rate is no longer applicable
+	Rate        float64   // percent of the charge (12.5 = 12.5%), 0 if not applicable
+	Fee         float64   // amount added for each charge taxed, 0 if not applicable
+	Formula     string    // RPN formula, not used yet
+	LastModTime time.Time // when was this record last written
+	LastModBy   int64     // employee UID (from phonebook) that modified it
+	CreateTS    time.Time // when was this record created
+	CreateBy    int64     // employee UID (from phonebook) that created it
+}
+
+// AssessmentTax is a tax on an assessment: the tax charged, or why none
+// was
+type AssessmentTax struct {
+	ATID                int64     // unique id
+	ASMID               int64     // the assessment taxed
+	BID                 int64     // Business
+	RAID                int64     // rental agreement of the assessment
+	TAXID               int64     // the tax
+	TaxASMID            int64     // the tax assessment, 0 if no tax was charged
+	Dt                  time.Time // date of the assessment
+	Base                float64   // amount of the assessment taxed
+	Amount              float64   // tax charged
+	FLAGS               uint64    // 1<<0 do not apply, 1<<1 use OverrideAmount, 1<<2 exempt stay, 1<<3 refunded
+	DtRefund            time.Time // date the tax was refunded, the date of the reversal
+	OverrideTaxApprover int64     // if tax is overridden, who approved it
+	OverrideAmount      float64   // the tax when it is overridden
+	Comment             string    // note
+	LastModTime         time.Time // when was this record last written
+	LastModBy           int64     // employee UID (from phonebook) that modified it
+	CreateTS            time.Time // when was this record created
+	CreateBy            int64     // employee UID (from phonebook) that created it
+}
+
 // RentalAgreementTax - the time based attribute for whether the rental agreement is taxable
 type RentalAgreementTax struct {
+	RATAXID     int64     // unique id
 	RAID        int64     //associated rental agreement
 	BID         int64     // Business
 	DtStart     time.Time // start date/time for this Payor
@@ -1056,9 +1292,21 @@ type BusinessProperties struct {
 // BizProps is the golang struct for a category of business properties.
 // This struct will be marshaled into JSON data and stored in BusinessProperties
 type BizProps struct {
-	Epochs      BizPropsEpochs // default epochs for recurring assessments
-	PetFees     []string       // AR names of all Pet Fees
-	VehicleFees []string       // AR names of all Vehicle Fees
+	Epochs         BizPropsEpochs         // default epochs for recurring assessments
+	PetFees        []string               // AR names of all Pet Fees
+	VehicleFees    []string               // AR names of all Vehicle Fees
+	LateFee        BizPropsLateFee        // late fee policy for unpaid assessments
+	ACH            BizPropsACH            // ACH autopay settings
+	SecDep         BizPropsSecDep         // security deposit disposition settings
+	RentEscalation BizPropsRentEscalation // scheduled rent escalation settings
+	Renewal        BizPropsRenewal        // lease renewal offer settings
+	MonthToMonth   BizPropsMonthToMonth   // month to month renewal settings
+	Pricing        BizPropsPricing        // market rate recommendation settings
+	ARI            BizPropsARI            // channel rate and availability export, and reservations
+	CAM            BizPropsCAM            // operating expense pass-through settings
+	AP             BizPropsAP             // accounts payable settings
+	FiscalYear     BizPropsFiscalYear     // fiscal year close settings
+	Folio          BizPropsFolio          // guest folio and night audit settings
 }
 
 // Building defines the location of a Building that is part of a Business
@@ -1110,7 +1358,7 @@ type Receipt struct {
 	AcctRuleReceive string    // Account rule to apply on the receipt of this payment -- essentially - bank account and unapplied funds
 	ARID            int64     // User selected rule
 	AcctRuleApply   string    // how the funds are applied to assessments
-	FLAGS           uint64    // bits 0-1 : 0 unallocated, 1 = partially allocated, 2 = fully allocated; bit 2: part of a voided receipt pair
+	FLAGS           uint64    // bits 0-1 : 0 unallocated, 1 = partially allocated, 2 = fully allocated; bit 2: part of a voided receipt pair; bit 3: ACH debit not yet settled
 	Comment         string    // any notes on this receipt
 	OtherPayorName  string    // if not '', the name of a payor who paid this receipt and who may not be in our system
 	LastModTime     time.Time
@@ -1180,6 +1428,11 @@ type Depository struct {
 	CreateBy    int64     // employee UID (from phonebook) that created it
 }
 
+// FlDepositCleared is the bit flag for Deposit FLAGS
+const (
+	FlDepositCleared = 1 << 0 // bit 0 = the bank has cleared this deposit, ClearedAmount is valid
+)
+
 // Deposit is simply a list of receipts that form a deposit to a Depository. This struct contains
 // the static attributes of the list
 type Deposit struct {
@@ -1190,7 +1443,7 @@ type Deposit struct {
 	Dt            time.Time     // Date of deposit
 	Amount        float64       // the total amount of the deposit
 	ClearedAmount float64       // the amount cleared by the depository
-	FLAGS         uint64        // bitflags
+	FLAGS         uint64        // 1<<0 = the bank has cleared this deposit
 	LastModTime   time.Time     // when was this record last written
 	LastModBy     int64         // employee UID (from phonebook) that modified it
 	CreateTS      time.Time     // when was this record created
@@ -1212,6 +1465,454 @@ type DepositPart struct {
 	CreateBy    int64     // employee UID (from phonebook) that created it
 }
 
+// FlBankStmtBalance and FlBankLineConfirmed are the bit flags for
+// BankStatement and BankStatementLine FLAGS respectively
+const (
+	FlBankStmtBalance   = 1 << 0 // BankStatement - bit 0 = ClosingBalance was supplied by the bank
+	FlBankLineConfirmed = 1 << 0 // BankStatementLine - bit 0 = match to the Deposit is confirmed
+)
+
+// BankStatement describes a bank statement file that was imported for a
+// Depository.  The individual transactions are in BankStatementLine.
+type BankStatement struct {
+	BSID           int64               // unique id for this bank statement
+	BID            int64               // business id
+	DEPID          int64               // the Depository this statement is for
+	FileName       string              // name of the file that was imported
+	DtStart        time.Time           // first day covered by the statement
+	DtStop         time.Time           // last day covered by the statement
+	ClosingBalance float64             // ledger balance reported by the bank at DtStop
+	FLAGS          uint64              // 1<<0 = ClosingBalance was supplied by the bank
+	LastModTime    time.Time           // when was this record last written
+	LastModBy      int64               // employee UID (from phonebook) that modified it
+	CreateTS       time.Time           // when was this record created
+	CreateBy       int64               // employee UID (from phonebook) that created it
+	L              []BankStatementLine // the transactions on this statement
+}
+
+// BankStatementLine is a single transaction from a bank statement. Credits
+// are positive amounts, debits are negative.  DID is the Deposit it has been
+// matched to.  When the match is confirmed the Deposit is marked as cleared.
+type BankStatementLine struct {
+	BSLID       int64     // unique id for this line
+	BSID        int64     // the bank statement this line came from
+	BID         int64     // business id
+	DEPID       int64     // the Depository
+	Dt          time.Time // date the bank posted the transaction
+	Amount      float64   // credits are positive, debits are negative
+	FITID       string    // the bank's transaction id
+	Description string    // payee / description / memo from the bank
+	DID         int64     // Deposit matched to this line, 0 = unmatched
+	FLAGS       uint64    // 1<<0 = match confirmed
+	LastModTime time.Time // when was this record last written
+	LastModBy   int64     // employee UID (from phonebook) that modified it
+	CreateTS    time.Time // when was this record created
+	CreateBy    int64     // employee UID (from phonebook) that created it
+}
+
+// FlACHSavings and the others are the bit flags for ACHEnrollment, ACHBatch
+// and ACHEntry FLAGS
+const (
+	FlACHSavings    = 1 << 0 // ACHEnrollment - bit 0 = savings account, otherwise checking
+	FlACHSuspended  = 1 << 1 // ACHEnrollment - bit 1 = do not debit this account
+	FlACHSettled    = 1 << 0 // ACHBatch - bit 0 = the bank has settled the batch
+	FlACHEntryRetnd = 1 << 0 // ACHEntry - bit 0 = the bank returned this debit
+)
+
+// ACHEnrollment is a payor's authorization to have amounts due debited from
+// their bank account.  RoutingNumber and AccountNumber are stored encrypted.
+type ACHEnrollment struct {
+	AEID          int64     // unique id for this enrollment
+	BID           int64     // business id
+	TCID          int64     // the payor
+	AccountName   string    // name on the bank account
+	RoutingNumber string    // 9 digit routing number (encrypted in the db)
+	AccountNumber string    // account number (encrypted in the db)
+	MaxAmount     float64   // largest amount that can be debited at one time, 0 = no limit
+	DtStart       time.Time // first day the account may be debited
+	DtStop        time.Time // account may be debited up to but not including this date
+	FLAGS         uint64    // 1<<0 = savings account, 1<<1 = suspended
+	LastModTime   time.Time // when was this record last written
+	LastModBy     int64     // employee UID (from phonebook) that modified it
+	CreateTS      time.Time // when was this record created
+	CreateBy      int64     // employee UID (from phonebook) that created it
+}
+
+// ACHBatch is a batch of ACH debits sent to the bank in one NACHA file. The
+// debits are in ACHEntry.
+type ACHBatch struct {
+	ABID        int64      // unique id for this batch, also the NACHA batch number
+	BID         int64      // business id
+	Dt          time.Time  // effective entry date of the debits
+	EntryCount  int64      // number of debits in the batch
+	TotalAmount float64    // total of all debits
+	FLAGS       uint64     // 1<<0 = settled
+	LastModTime time.Time  // when was this record last written
+	LastModBy   int64      // employee UID (from phonebook) that modified it
+	CreateTS    time.Time  // when was this record created
+	CreateBy    int64      // employee UID (from phonebook) that created it
+	E           []ACHEntry // the debits in this batch
+}
+
+// ACHEntry is one debit in an ACHBatch.  RCPTID is the Receipt that was
+// created for the debit.
+type ACHEntry struct {
+	AENID       int64     // unique id for this entry
+	ABID        int64     // the batch
+	BID         int64     // business id
+	AEID        int64     // the enrollment that authorized the debit
+	TCID        int64     // the payor
+	RCPTID      int64     // the Receipt created for this debit
+	Amount      float64   // amount debited
+	TraceNumber string    // 15 digit NACHA trace number
+	ReturnCode  string    // R-code if the bank returned the debit
+	FLAGS       uint64    // 1<<0 = returned
+	LastModTime time.Time // when was this record last written
+	LastModBy   int64     // employee UID (from phonebook) that modified it
+	CreateTS    time.Time // when was this record created
+	CreateBy    int64     // employee UID (from phonebook) that created it
+}
+
+// FlSecDepPosted and the others are the flags and item types for
+// SecDepSettlement and SecDepItem
+const (
+	FlSecDepPosted     = 1 << 0 // SecDepSettlement - bit 0 = the settlement has been posted
+	SecDepItemDamage   = 0      // SecDepItem - an itemized damage charge
+	SecDepItemCharge   = 1      // SecDepItem - an unpaid Assessment outstanding at move-out
+	SecDepItemTypeLast = 1      // keep in sync with the last item type
+)
+
+// SecDepSettlement is the move-out settlement of the security deposits held
+// for a Rental Agreement.  The deposits are applied against the charges
+// still owed and the itemized damages.  Whatever remains is refunded
+// (REFEXPID) or, if the deposits were not enough, the damages they did not
+// cover are assessed (BDASMID).
+type SecDepSettlement struct {
+	SDSID          int64        // unique id for this settlement
+	BID            int64        // business id
+	RAID           int64        // the Rental Agreement
+	TCID           int64        // the payor who receives the refund or the balance due
+	Dt             time.Time    // move-out date
+	DepositHeld    float64      // security deposits held on Dt
+	Charges        float64      // total of the unpaid Assessments on Dt
+	Damages        float64      // total of the itemized damage charges
+	DepositApplied float64      // amount of the deposits applied to charges and damages
+	Refund         float64      // amount of the deposits returned to the payor
+	BalanceDue     float64      // amount the payor still owes after the deposits are applied
+	RCPTID         int64        // Receipt that applied the deposits to the unpaid Assessments
+	DMGEXPID       int64        // Expense that applied the deposits to the damages
+	REFEXPID       int64        // Expense for the refund
+	BDASMID        int64        // Assessment for the damages the deposits did not cover
+	ForwardAddress string       // where the disposition letter and refund are sent
+	FLAGS          uint64       // 1<<0 = posted
+	Comment        string       // any notes about this settlement
+	LastModTime    time.Time    // when was this record last written
+	LastModBy      int64        // employee UID (from phonebook) that modified it
+	CreateTS       time.Time    // when was this record created
+	CreateBy       int64        // employee UID (from phonebook) that created it
+	I              []SecDepItem // the charges and damages of this settlement
+}
+
+// SecDepItem is one line of a SecDepSettlement, either an itemized damage
+// charge or an Assessment that was unpaid at move-out.
+type SecDepItem struct {
+	SDIID       int64     // unique id for this item
+	SDSID       int64     // the settlement
+	BID         int64     // business id
+	ItemType    int64     // SecDepItemDamage or SecDepItemCharge
+	ASMID       int64     // the unpaid Assessment if ItemType is SecDepItemCharge
+	RID         int64     // the rentable
+	Description string    // what the charge is for
+	Amount      float64   // amount charged
+	LastModTime time.Time // when was this record last written
+	LastModBy   int64     // employee UID (from phonebook) that modified it
+	CreateTS    time.Time // when was this record created
+	CreateBy    int64     // employee UID (from phonebook) that created it
+}
+
+// LeaseTypeFullServiceGross and the others are the values of
+// RentalAgreement.LeaseType and RentalAgreement.ExpenseAdjustmentType.
+// Operating expenses are only passed through to the tenant for Modified
+// Gross and Triple Net leases.
+const (
+	LeaseTypeFullServiceGross = 0 // landlord pays all operating expenses
+	LeaseTypeGross            = 1 // landlord pays all operating expenses
+	LeaseTypeModifiedGross    = 2 // tenant pays its share of operating expenses over the base year
+	LeaseTypeTripleNet        = 3 // tenant pays its share of all operating expenses
+	LeaseTypeLast             = 3 // keep in sync with the last lease type
+
+	ExpAdjBaseYear    = 0 // tenant pays its share of expenses above the base year
+	ExpAdjNoBaseYear  = 1 // tenant pays its share of all expenses
+	ExpAdjPassThrough = 2 // tenant pays its share of all expenses, ExpensesStop does not apply
+	ExpAdjLast        = 2 // keep in sync with the last expense adjustment type
+
+	FlCAMPosted = 1 << 0 // CAMReconciliation - bit 0 = the reconciliation has been posted
+)
+
+// CAMReconciliation is the annual reconciliation of the operating expenses
+// (common area maintenance, taxes, insurance, ...) passed through to the
+// tenant of a commercial Rental Agreement.  The tenant's share of the
+// expenses for the period Dt1 - Dt2 is compared with the estimated charges
+// already assessed.  The difference is assessed (ASMID) or credited to the
+// tenant (RCPTID).
+type CAMReconciliation struct {
+	CAMRID                int64          // unique id for this reconciliation
+	BID                   int64          // business id
+	RAID                  int64          // the rental agreement
+	RID                   int64          // the (first) rentable of the rental agreement
+	TCID                  int64          // payor who is billed or credited
+	Dt1                   time.Time      // start of the period
+	Dt2                   time.Time      // end of the period, not included
+	LeaseType             int64          // LeaseType of the rental agreement
+	ExpenseAdjustmentType int64          // ExpenseAdjustmentType of the rental agreement
+	SqFt                  int64          // square feet of the tenant's rentables
+	TotalSqFt             int64          // square feet of all the rentables of the business
+	Occupancy             float64        // fraction of the period covered by the rental agreement
+	Expenses              float64        // recoverable operating expenses in the period
+	BaseExpenses          float64        // recoverable operating expenses in the base year
+	ExpensesStop          float64        // cap on the tenant's share for a full year, 0 = no cap
+	Share                 float64        // tenant's share of the expenses
+	Estimated             float64        // estimated charges assessed in the period
+	TrueUp                float64        // Share - Estimated: > 0 is owed by the tenant, < 0 is credited
+	ASMID                 int64          // true-up assessment
+	RCPTID                int64          // true-up credit
+	FLAGS                 uint64         // 1<<0 = posted
+	Comment               string         // notes
+	LastModTime           time.Time      // when was this record last written
+	LastModBy             int64          // employee UID (from phonebook) that modified it
+	CreateTS              time.Time      // when was this record created
+	CreateBy              int64          // employee UID (from phonebook) that created it
+	L                     []CAMReconLine // the expenses by GL account, not stored in the db
+}
+
+// CAMReconLine is the recoverable operating expense for one GL account in a
+// CAMReconciliation
+type CAMReconLine struct {
+	CAMRLID      int64     // unique id for this line
+	CAMRID       int64     // the reconciliation
+	BID          int64     // business id
+	LID          int64     // GL account debited by the expenses
+	Expenses     float64   // expenses in the period
+	BaseExpenses float64   // expenses in the base year
+	Share        float64   // tenant's share of Expenses - BaseExpenses
+	LastModTime  time.Time // when was this record last written
+	LastModBy    int64     // employee UID (from phonebook) that modified it
+	CreateTS     time.Time // when was this record created
+	CreateBy     int64     // employee UID (from phonebook) that created it
+}
+
+// TenantLogin and MaintenanceRequest values
+const (
+	FlTenantLoginDisabled = 1 << 0 // TenantLogin - bit 0 = the tenant cannot log in
+	TenantMaxFailedLogins = 5      // the login is locked after this many consecutive failures
+
+	MRStatusOpen     = 0 // MaintenanceRequest - submitted by the tenant
+	MRStatusAccepted = 1 // MaintenanceRequest - the property manager is working on it
+	MRStatusClosed   = 2 // MaintenanceRequest - repaired or declined
+	MRStatusLast     = 2 // keep in sync with the last status
+
+	FlMREntryPermitted = 1 << 0 // MaintenanceRequest - bit 0 = staff may enter when the tenant is away
+)
+
+// TenantLogin is the portal login of a tenant. It ties a username and
+// password to a Transactant so that the tenant can see the rental
+// agreements for which it is a payor.
+type TenantLogin struct {
+	TLID         int64     // unique id for this login
+	BID          int64     // business id
+	TCID         int64     // the tenant
+	Username     string    // login name, unique within the business
+	PasswordHash string    // salted hash of the password, see HashPassword
+	FailedLogins int64     // consecutive failed logins, the login is locked at TenantMaxFailedLogins
+	LastLogin    time.Time // when the tenant last logged in
+	FLAGS        uint64    // 1<<0 = disabled
+	LastModTime  time.Time // when was this record last written
+	LastModBy    int64     // employee UID (from phonebook) that modified it
+	CreateTS     time.Time // when was this record created
+	CreateBy     int64     // employee UID (from phonebook) that created it
+}
+
+// MaintenanceRequest is a repair request submitted by a tenant through the
+// portal
+type MaintenanceRequest struct {
+	MRID         int64     // unique id for this request
+	BID          int64     // business id
+	RAID         int64     // rental agreement of the tenant
+	RID          int64     // rentable needing the repair, 0 if not known
+	TCID         int64     // tenant who submitted the request
+	Dt           time.Time // when the request was submitted
+	Category     string    // plumbing, electrical, appliance, ...
+	Description  string    // what needs to be repaired
+	ContactPhone string    // where the tenant can be reached
+	Status       int64     // 0 = open, 1 = accepted, 2 = closed
+	Response     string    // the property manager's answer to the tenant
+	FLAGS        uint64    // 1<<0 = permission to enter
+	LastModTime  time.Time // when was this record last written
+	LastModBy    int64     // employee UID (from phonebook) that modified it
+	CreateTS     time.Time // when was this record created
+	CreateBy     int64     // employee UID (from phonebook) that created it
+}
+
+// WorkOrder values
+const (
+	WOPriorityLow       = 0 // WorkOrder - when convenient
+	WOPriorityNormal    = 1 // WorkOrder - in the normal course of business
+	WOPriorityHigh      = 2 // WorkOrder - as soon as possible
+	WOPriorityEmergency = 3 // WorkOrder - immediately
+	WOPriorityLast      = 3 // keep in sync with the last priority
+
+	WOStatusOpen       = 0 // WorkOrder - reported, nobody assigned yet
+	WOStatusAssigned   = 1 // WorkOrder - a vendor has been assigned
+	WOStatusScheduled  = 2 // WorkOrder - the work has been scheduled
+	WOStatusInProgress = 3 // WorkOrder - the work has started
+	WOStatusCompleted  = 4 // WorkOrder - the work is done, costs are posted
+	WOStatusClosed     = 5 // WorkOrder - reviewed and closed
+	WOStatusCanceled   = 6 // WorkOrder - will not be done
+	WOStatusLast       = 6 // keep in sync with the last status
+
+	FlWOEntryPermitted = 1 << 0 // WorkOrder - bit 0 = staff may enter when the tenant is away
+)
+
+// WOPriorityNames and WOStatusNames are the display names of the WorkOrder
+// priorities and statuses, indexed by value
+var (
+	WOPriorityNames = []string{"Low", "Normal", "High", "Emergency"}
+	WOStatusNames   = []string{"Open", "Assigned", "Scheduled", "In Progress", "Completed", "Closed", "Canceled"}
+)
+
+// WorkOrder is a repair ticket for a Rentable. Its labor and material costs
+// are posted as Expenses when the work is completed, and the cost can be
+// charged back to the tenant of RAID as an Assessment.
+type WorkOrder struct {
+	WOID             int64     // unique id for this work order
+	BID              int64     // business id
+	RID              int64     // rentable needing the repair
+	RAID             int64     // rental agreement, 0 if none
+	MRID             int64     // tenant maintenance request that started it, 0 if none
+	Category         string    // plumbing, electrical, appliance, ...
+	Priority         int64     // WOPriorityLow ... WOPriorityEmergency
+	Status           int64     // WOStatusOpen ... WOStatusCanceled
+	Vendor           string    // who is assigned to do the work
+	Description      string    // what needs to be repaired
+	Dt               time.Time // when the problem was reported
+	ScheduledStart   time.Time // when the work is scheduled to start
+	ScheduledStop    time.Time // when the work is scheduled to be done
+	DtCompleted      time.Time // when the work was completed
+	LaborCost        float64   // cost of the labor
+	LaborARID        int64     // expense account rule for the labor
+	LaborEXPID       int64     // expense posted for the labor
+	MaterialCost     float64   // cost of the materials
+	MaterialARID     int64     // expense account rule for the materials
+	MaterialEXPID    int64     // expense posted for the materials
+	ChargebackAmount float64   // amount charged to the tenant
+	ChargebackARID   int64     // assessment account rule for the chargeback
+	ChargebackASMID  int64     // assessment for the chargeback
+	FLAGS            uint64    // 1<<0 = permission to enter
+	Comment          string    // notes on the work done
+	LastModTime      time.Time // when was this record last written
+	LastModBy        int64     // employee UID (from phonebook) that modified it
+	CreateTS         time.Time // when was this record created
+	CreateBy         int64     // employee UID (from phonebook) that created it
+}
+
+// Vendor and VendorBill values
+const (
+	FlVendor1099     = 1 << 0 // Vendor - bit 0 = payments are reported on a 1099
+	FlVendorInactive = 1 << 1 // Vendor - bit 1 = no new bills can be entered
+
+	VBStatusEntered  = 0 // VendorBill - entered, waiting for approval
+	VBStatusApproved = 1 // VendorBill - approved and posted to accounts payable
+	VBStatusPaid     = 2 // VendorBill - paid by a payment run
+	VBStatusVoid     = 3 // VendorBill - will not be paid
+	VBStatusLast     = 3 // keep in sync with the last status
+)
+
+// VBStatusNames are the display names of the VendorBill statuses, indexed
+// by value
+var VBStatusNames = []string{"Entered", "Approved", "Paid", "Void"}
+
+// Vendor is a business that sells goods or services to the property
+type Vendor struct {
+	VID         int64     // unique id for this vendor
+	BID         int64     // business id
+	Name        string    // vendor name, unique within the business
+	ContactName string    // who to talk to
+	Email       string    // contact email
+	Phone       string    // contact phone
+	Address     string    // remit-to address
+	Address2    string    // remit-to address, second line
+	City        string    // remit-to city
+	State       string    // remit-to state
+	PostalCode  string    // remit-to postal code
+	Country     string    // remit-to country
+	TaxID       string    // EIN or SSN, reported on the 1099
+	Terms       int64     // payment terms, days from the bill date to its due date
+	FLAGS       uint64    // 1<<0 = 1099 eligible, 1<<1 = inactive
+	Comment     string    // notes
+	LastModTime time.Time // when was this record last written
+	LastModBy   int64     // employee UID (from phonebook) that modified it
+	CreateTS    time.Time // when was this record created
+	CreateBy    int64     // employee UID (from phonebook) that created it
+}
+
+// VendorBill is a bill received from a Vendor.  It is posted to the
+// accounts payable GL account when it is approved and it is paid by a
+// VendorPaymentRun.
+type VendorBill struct {
+	VBID        int64     // unique id for this bill
+	BID         int64     // business id
+	VID         int64     // the vendor who sent it
+	InvoiceNo   string    // the vendor's invoice number
+	Dt          time.Time // bill date, the expenses are posted on this date
+	DueDt       time.Time // when it must be paid
+	Amount      float64   // total of the lines
+	Status      int64     // VBStatusEntered ... VBStatusVoid
+	ApprovedBy  int64     // UID of who approved it
+	DtApproved  time.Time // when it was approved
+	JID         int64     // journal entry posted when it was approved
+	VPRID       int64     // payment run that paid it
+	DtPaid      time.Time // when it was paid
+	DocNo       string    // check number or other payment reference
+	PmtJID      int64     // journal entry posted when it was paid
+	FLAGS       uint64    // not used yet
+	Comment     string    // notes
+	LastModTime time.Time // when was this record last written
+	LastModBy   int64     // employee UID (from phonebook) that modified it
+	CreateTS    time.Time // when was this record created
+	CreateBy    int64     // employee UID (from phonebook) that created it
+}
+
+// VendorBillLine is one line of a VendorBill, charged to a GL account
+type VendorBillLine struct {
+	VBLID       int64     // unique id for this line
+	VBID        int64     // the bill
+	BID         int64     // business id
+	LID         int64     // GL account debited
+	RID         int64     // rentable the cost is for, 0 if none
+	Amount      float64   // amount of the line
+	Description string    // what it is for
+	LastModTime time.Time // when was this record last written
+	LastModBy   int64     // employee UID (from phonebook) that modified it
+	CreateTS    time.Time // when was this record created
+	CreateBy    int64     // employee UID (from phonebook) that created it
+}
+
+// VendorPaymentRun is a batch of approved VendorBills paid from a Depository
+type VendorPaymentRun struct {
+	VPRID        int64     // unique id for this payment run
+	BID          int64     // business id
+	DEPID        int64     // depository the bills are paid from
+	Dt           time.Time // payment date
+	FirstCheckNo int64     // check number of the first bill paid, 0 if not paid by check
+	Amount       float64   // total paid
+	BillCount    int64     // number of bills paid
+	Comment      string    // notes
+	LastModTime  time.Time // when was this record last written
+	LastModBy    int64     // employee UID (from phonebook) that modified it
+	CreateTS     time.Time // when was this record created
+	CreateBy     int64     // employee UID (from phonebook) that created it
+}
+
 // DepositMethod is a list of methods used to make deposits to a depository
 type DepositMethod struct {
 	DPMID       int64     //the method id
@@ -1314,13 +2015,15 @@ type RentableMarketRate struct {
 	CreateBy    int64     // employee UID (from phonebook) that created it
 }
 
-// RentableTypeTax - the time based attribute for whether the rental agreement is taxable
+// RentableTypeTax - the time based attribute for which taxes apply to the
+// rental assessments of a rentable type
 type RentableTypeTax struct {
-	RAID        int64     //associated rental agreement
+	RTTAXID     int64     // unique id
+	RTID        int64     // associated rentable type
 	BID         int64     // Business
-	DtStart     time.Time // start date/time for this Payor
-	DtStop      time.Time // stop date/time
 	TAXID       int64     // which tax in the Tax Table
+	DtStart     time.Time // start date/time
+	DtStop      time.Time // stop date/time
 	LastModTime time.Time // when was this record last written
 	LastModBy   int64     // employee UID (from phonebook) that modified it
 	CreateTS    time.Time // when was this record created
@@ -2059,6 +2762,187 @@ type RRprepSQL struct {
 	DeleteRentableUseType                   *sql.Stmt
 	GetASMInstancesByRIDandDateRange        *sql.Stmt
 	DeleteRentable                          *sql.Stmt
+	GetAssessmentByAssocElem                *sql.Stmt
+	GetAssessmentsByAssocElem               *sql.Stmt
+	GetAssessmentChargesByRAID              *sql.Stmt
+	GetBankStatement                        *sql.Stmt
+	GetBankStatementsByDEPID                *sql.Stmt
+	InsertBankStatement                     *sql.Stmt
+	UpdateBankStatement                     *sql.Stmt
+	DeleteBankStatement                     *sql.Stmt
+	GetBankStatementLine                    *sql.Stmt
+	GetBankStatementLines                   *sql.Stmt
+	GetBankStatementLineByFITID             *sql.Stmt
+	GetBankStatementLinesInRange            *sql.Stmt
+	GetBankStatementLineByDID               *sql.Stmt
+	InsertBankStatementLine                 *sql.Stmt
+	UpdateBankStatementLine                 *sql.Stmt
+	DeleteBankStatementLine                 *sql.Stmt
+	GetDepositsByDEPIDInRange               *sql.Stmt
+	GetACHEnrollment                        *sql.Stmt
+	GetACHEnrollmentsByTCID                 *sql.Stmt
+	GetActiveACHEnrollments                 *sql.Stmt
+	InsertACHEnrollment                     *sql.Stmt
+	UpdateACHEnrollment                     *sql.Stmt
+	DeleteACHEnrollment                     *sql.Stmt
+	GetACHBatch                             *sql.Stmt
+	GetACHBatches                           *sql.Stmt
+	InsertACHBatch                          *sql.Stmt
+	UpdateACHBatch                          *sql.Stmt
+	DeleteACHBatch                          *sql.Stmt
+	GetACHEntry                             *sql.Stmt
+	GetACHEntries                           *sql.Stmt
+	GetACHEntryByTraceNumber                *sql.Stmt
+	InsertACHEntry                          *sql.Stmt
+	UpdateACHEntry                          *sql.Stmt
+	DeleteACHEntry                          *sql.Stmt
+	GetSecDepSettlement                     *sql.Stmt
+	GetSecDepSettlementsByRAID              *sql.Stmt
+	InsertSecDepSettlement                  *sql.Stmt
+	UpdateSecDepSettlement                  *sql.Stmt
+	DeleteSecDepSettlement                  *sql.Stmt
+	GetSecDepItem                           *sql.Stmt
+	GetSecDepItems                          *sql.Stmt
+	InsertSecDepItem                        *sql.Stmt
+	UpdateSecDepItem                        *sql.Stmt
+	DeleteSecDepItem                        *sql.Stmt
+	GetRentalAgreementsByNextRateChange     *sql.Stmt
+	GetRentalAgreementsByAgreementStop      *sql.Stmt
+	GetMonthToMonthRentalAgreements         *sql.Stmt
+	GetCAMReconciliation                    *sql.Stmt
+	GetCAMReconciliationsByRange            *sql.Stmt
+	GetCAMReconciliationsByRAID             *sql.Stmt
+	InsertCAMReconciliation                 *sql.Stmt
+	UpdateCAMReconciliation                 *sql.Stmt
+	DeleteCAMReconciliation                 *sql.Stmt
+	GetCAMReconLine                         *sql.Stmt
+	GetCAMReconLines                        *sql.Stmt
+	GetExpensesByRange                      *sql.Stmt
+	InsertCAMReconLine                      *sql.Stmt
+	UpdateCAMReconLine                      *sql.Stmt
+	DeleteCAMReconLine                      *sql.Stmt
+	GetTenantLogin                          *sql.Stmt
+	GetTenantLoginByUsername                *sql.Stmt
+	GetTenantLoginByTCID                    *sql.Stmt
+	GetTenantLogins                         *sql.Stmt
+	InsertTenantLogin                       *sql.Stmt
+	UpdateTenantLogin                       *sql.Stmt
+	DeleteTenantLogin                       *sql.Stmt
+	GetMaintenanceRequest                   *sql.Stmt
+	GetMaintenanceRequests                  *sql.Stmt
+	GetMaintenanceRequestsByRAID            *sql.Stmt
+	InsertMaintenanceRequest                *sql.Stmt
+	UpdateMaintenanceRequest                *sql.Stmt
+	DeleteMaintenanceRequest                *sql.Stmt
+	GetReceiptsByRAIDRange                  *sql.Stmt
+	GetWorkOrder                            *sql.Stmt
+	GetOpenWorkOrders                       *sql.Stmt
+	GetWorkOrdersByRID                      *sql.Stmt
+	GetWorkOrdersByMRID                     *sql.Stmt
+	InsertWorkOrder                         *sql.Stmt
+	UpdateWorkOrder                         *sql.Stmt
+	DeleteWorkOrder                         *sql.Stmt
+	GetVendor                               *sql.Stmt
+	GetVendorsByBusiness                    *sql.Stmt
+	InsertVendor                            *sql.Stmt
+	UpdateVendor                            *sql.Stmt
+	DeleteVendor                            *sql.Stmt
+	GetVendorBill                           *sql.Stmt
+	GetVendorBillsByStatus                  *sql.Stmt
+	GetVendorBillsByRange                   *sql.Stmt
+	GetUnpaidVendorBills                    *sql.Stmt
+	GetPaidVendorBills                      *sql.Stmt
+	GetVendorBillsByVID                     *sql.Stmt
+	GetVendorBillsByVPRID                   *sql.Stmt
+	InsertVendorBill                        *sql.Stmt
+	UpdateVendorBill                        *sql.Stmt
+	DeleteVendorBill                        *sql.Stmt
+	GetVendorBillLine                       *sql.Stmt
+	GetVendorBillLines                      *sql.Stmt
+	InsertVendorBillLine                    *sql.Stmt
+	UpdateVendorBillLine                    *sql.Stmt
+	DeleteVendorBillLine                    *sql.Stmt
+	GetVendorPaymentRun                     *sql.Stmt
+	GetVendorPaymentRuns                    *sql.Stmt
+	InsertVendorPaymentRun                  *sql.Stmt
+	UpdateVendorPaymentRun                  *sql.Stmt
+	DeleteVendorPaymentRun                  *sql.Stmt
+	GetLedgerMarkersInRange                 *sql.Stmt
+	DeleteLedgerMarkersOnDate               *sql.Stmt
+	GetClosePeriodBefore                    *sql.Stmt
+	GetFiscalYearClose                      *sql.Stmt
+	GetLastFiscalYearClose                  *sql.Stmt
+	GetFiscalYearCloses                     *sql.Stmt
+	InsertFiscalYearClose                   *sql.Stmt
+	UpdateFiscalYearClose                   *sql.Stmt
+	DeleteFiscalYearClose                   *sql.Stmt
+	GetBudget                               *sql.Stmt
+	GetBudgetForAccount                     *sql.Stmt
+	GetBudgetsInRange                       *sql.Stmt
+	InsertBudget                            *sql.Stmt
+	UpdateBudget                            *sql.Stmt
+	DeleteBudget                            *sql.Stmt
+	GetAcctMap                              *sql.Stmt
+	GetAcctMapByLID                         *sql.Stmt
+	GetAcctMaps                             *sql.Stmt
+	InsertAcctMap                           *sql.Stmt
+	UpdateAcctMap                           *sql.Stmt
+	DeleteAcctMap                           *sql.Stmt
+	GetRenewalOffer                         *sql.Stmt
+	GetRenewalOffersByRAID                  *sql.Stmt
+	GetRenewalOffersInRange                 *sql.Stmt
+	InsertRenewalOffer                      *sql.Stmt
+	UpdateRenewalOffer                      *sql.Stmt
+	DeleteRenewalOffer                      *sql.Stmt
+	GetRateRecommendation                   *sql.Stmt
+	GetRateRecommendationsByRTID            *sql.Stmt
+	GetRateRecommendationsInRange           *sql.Stmt
+	InsertRateRecommendation                *sql.Stmt
+	UpdateRateRecommendation                *sql.Stmt
+	DeleteRateRecommendation                *sql.Stmt
+	GetOTAReservation                       *sql.Stmt
+	GetOTAReservationByResID                *sql.Stmt
+	InsertOTAReservation                    *sql.Stmt
+	UpdateOTAReservation                    *sql.Stmt
+	DeleteOTAReservation                    *sql.Stmt
+	GetFolio                                *sql.Stmt
+	GetFoliosByRAID                         *sql.Stmt
+	GetFoliosInRange                        *sql.Stmt
+	InsertFolio                             *sql.Stmt
+	UpdateFolio                             *sql.Stmt
+	DeleteFolio                             *sql.Stmt
+	GetNightAudit                           *sql.Stmt
+	GetLastNightAudit                       *sql.Stmt
+	GetNightAuditsInRange                   *sql.Stmt
+	InsertNightAudit                        *sql.Stmt
+	UpdateNightAudit                        *sql.Stmt
+	DeleteNightAudit                        *sql.Stmt
+	GetTax                                  *sql.Stmt
+	GetTaxesByBusiness                      *sql.Stmt
+	InsertTax                               *sql.Stmt
+	UpdateTax                               *sql.Stmt
+	DeleteTax                               *sql.Stmt
+	GetTaxRate                              *sql.Stmt
+	GetTaxRates                             *sql.Stmt
+	GetTaxRatesInRange                      *sql.Stmt
+	InsertTaxRate                           *sql.Stmt
+	UpdateTaxRate                           *sql.Stmt
+	DeleteTaxRate                           *sql.Stmt
+	GetRentableTypeTax                      *sql.Stmt
+	GetRentableTypeTaxesByBusiness          *sql.Stmt
+	GetRentableTypeTaxesInRange             *sql.Stmt
+	InsertRentableTypeTax                   *sql.Stmt
+	UpdateRentableTypeTax                   *sql.Stmt
+	DeleteRentableTypeTax                   *sql.Stmt
+	GetRentalAgreementTaxesInRange          *sql.Stmt
+	GetAssessmentTax                        *sql.Stmt
+	GetAssessmentTaxesByASMID               *sql.Stmt
+	GetAssessmentTaxesByRAID                *sql.Stmt
+	GetAssessmentTaxesInRange               *sql.Stmt
+	GetAssessmentTaxesRefundedInRange       *sql.Stmt
+	InsertAssessmentTax                     *sql.Stmt
+	UpdateAssessmentTax                     *sql.Stmt
+	DeleteAssessmentTax                     *sql.Stmt
 }
 
 // DeleteBusinessFromDB deletes information from all tables if it is part of the supplied BID.
@@ -16,6 +16,28 @@ func deleteSessionCheck(ctx context.Context) error {
 	return nil
 }
 
+// DeleteAcctMap deletes the AcctMap associated with the supplied id
+func DeleteAcctMap(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteAcctMap)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteAcctMap.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting AcctMap for AMID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
 // DeleteAR deletes AR records with the supplied id
 func DeleteAR(ctx context.Context, id int64) error {
 	var err error
@@ -60,6 +82,105 @@ func DeleteAssessment(ctx context.Context, asmid int64) error {
 	return err
 }
 
+// DeleteAssessmentTax deletes the AssessmentTax associated with the supplied id
+func DeleteAssessmentTax(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteAssessmentTax)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteAssessmentTax.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting AssessmentTax for ATID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteBudget deletes the Budget associated with the supplied id
+func DeleteBudget(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteBudget)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteBudget.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting Budget for BGTID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteCAMReconLine deletes the CAMReconLine associated with the supplied id
+func DeleteCAMReconLine(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteCAMReconLine)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteCAMReconLine.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting CAMReconLine for CAMRLID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteCAMReconciliation deletes the CAMReconciliation associated with the
+// supplied id. The lines of the reconciliation are deleted as well.
+func DeleteCAMReconciliation(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	m, err := GetCAMReconLines(ctx, id)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(m); i++ {
+		if err = DeleteCAMReconLine(ctx, m[i].CAMRLID); err != nil {
+			return err
+		}
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteCAMReconciliation)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteCAMReconciliation.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting CAMReconciliation for CAMRID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
 // DeleteClosePeriod deletes ClosePeriod record with the supplied id
 func DeleteClosePeriod(ctx context.Context, id int64) error {
 	var err error
@@ -239,6 +360,138 @@ func DeleteDepositPart(ctx context.Context, id int64) error {
 	return err
 }
 
+// DeleteBankStatement deletes the BankStatement associated with the supplied id.
+// The lines of the statement are deleted as well.
+func DeleteBankStatement(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	m, err := GetBankStatementLines(ctx, id)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(m); i++ {
+		if err = DeleteBankStatementLine(ctx, m[i].BSLID); err != nil {
+			return err
+		}
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteBankStatement)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteBankStatement.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting BankStatement for BSID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteACHEnrollment deletes the ACHEnrollment associated with the supplied id
+func DeleteACHEnrollment(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteACHEnrollment)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteACHEnrollment.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting ACHEnrollment for AEID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteACHBatch deletes the ACHBatch associated with the supplied id.
+// The entries of the batch are deleted as well.
+func DeleteACHBatch(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	m, err := GetACHEntries(ctx, id)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(m); i++ {
+		if err = DeleteACHEntry(ctx, m[i].AENID); err != nil {
+			return err
+		}
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteACHBatch)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteACHBatch.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting ACHBatch for ABID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteACHEntry deletes the ACHEntry associated with the supplied id
+func DeleteACHEntry(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteACHEntry)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteACHEntry.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting ACHEntry for AENID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteBankStatementLine deletes the BankStatementLine associated with the supplied id
+func DeleteBankStatementLine(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteBankStatementLine)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteBankStatementLine.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting BankStatementLine for BSLID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
 // DeleteExpense deletes the Expense associated with the supplied id
 func DeleteExpense(ctx context.Context, id int64) error {
 	var err error
@@ -262,6 +515,28 @@ func DeleteExpense(ctx context.Context, id int64) error {
 	return nil
 }
 
+// DeleteFiscalYearClose deletes the FiscalYearClose associated with the supplied id
+func DeleteFiscalYearClose(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteFiscalYearClose)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteFiscalYearClose.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting FiscalYearClose for FYCID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
 // DeleteFlow deletes a flow with the given FlowID
 func DeleteFlow(ctx context.Context, FlowID int64) error {
 	var err error
@@ -306,6 +581,28 @@ func DeleteFlowByRefNo(ctx context.Context, BID int64, UserRefNo string) error {
 	return err
 }
 
+// DeleteFolio deletes the Folio associated with the supplied id
+func DeleteFolio(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteFolio)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteFolio.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting Folio for FOLID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
 // DeleteInvoice deletes the Invoice associated with the supplied id
 // For convenience, this routine calls DeleteInvoiceAssessments. The InvoiceAssessments are
 // tightly bound to the Invoice. If a Invoice is deleted, the parts should be deleted as well.
@@ -512,6 +809,74 @@ func DeleteLedgerMarker(ctx context.Context, lmid int64) error {
 	return err
 }
 
+// DeleteLedgerMarkersOnDate deletes the open and closed LedgerMarkers of the
+// GLAccounts and Rental Agreements of business bid dated dt.  These are the
+// markers created by GenerateLedgerMarkers.
+func DeleteLedgerMarkersOnDate(ctx context.Context, bid int64, dt *time.Time) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{bid, dt}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteLedgerMarkersOnDate)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteLedgerMarkersOnDate.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting LedgerMarkers for BID = %d, Dt = %s, error: %v\n", bid, dt.Format(RRDATEFMT4), err)
+	}
+	return err
+}
+
+// DeleteMaintenanceRequest deletes the MaintenanceRequest associated with the supplied id
+func DeleteMaintenanceRequest(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteMaintenanceRequest)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteMaintenanceRequest.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting MaintenanceRequest for MRID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteNightAudit deletes the NightAudit associated with the supplied id
+func DeleteNightAudit(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteNightAudit)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteNightAudit.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting NightAudit for NAID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
 // DeleteNote deletes the Note with the supplied id and all its children
 // PLEASE USE DeleteNoteAndChildNotes IF POSSIBLE
 func DeleteNote(ctx context.Context, nid int64) error {
@@ -589,32 +954,54 @@ func DeleteNoteList(ctx context.Context, nl *NoteList) error {
 		defer stmt.Close()
 		_, err = stmt.Exec(fields...)
 	} else {
-		_, err = RRdb.Prepstmt.DeleteNoteList.Exec(fields...)
+		_, err = RRdb.Prepstmt.DeleteNoteList.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting Note for NID = %d, error: %v\n", nl.NLID, err)
+	}
+	return err
+}
+
+// DeleteNoteType deletes the NoteType record with the supplied nid
+func DeleteNoteType(ctx context.Context, nid int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{nid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteNoteType)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteNoteType.Exec(fields...)
 	}
 	if err != nil {
-		Ulog("Error deleting Note for NID = %d, error: %v\n", nl.NLID, err)
+		Ulog("Error deleting NoteType for NID = %d, error: %v\n", nid, err)
 	}
 	return err
 }
 
-// DeleteNoteType deletes the NoteType record with the supplied nid
-func DeleteNoteType(ctx context.Context, nid int64) error {
+// DeleteOTAReservation deletes the OTAReservation associated with the supplied id
+func DeleteOTAReservation(ctx context.Context, id int64) error {
 	var err error
 
 	if err = deleteSessionCheck(ctx); err != nil {
 		return err
 	}
 
-	fields := []interface{}{nid}
+	fields := []interface{}{id}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.DeleteNoteType)
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteOTAReservation)
 		defer stmt.Close()
 		_, err = stmt.Exec(fields...)
 	} else {
-		_, err = RRdb.Prepstmt.DeleteNoteType.Exec(fields...)
+		_, err = RRdb.Prepstmt.DeleteOTAReservation.Exec(fields...)
 	}
 	if err != nil {
-		Ulog("Error deleting NoteType for NID = %d, error: %v\n", nid, err)
+		Ulog("Error deleting OTAReservation for ORID = %d, error: %v\n", id, err)
 	}
 	return err
 }
@@ -729,6 +1116,28 @@ func DeleteRatePlanRefSPRate(ctx context.Context, rtrid, rspid int64) error {
 	return err
 }
 
+// DeleteRateRecommendation deletes the RateRecommendation associated with the supplied id
+func DeleteRateRecommendation(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteRateRecommendation)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteRateRecommendation.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting RateRecommendation for RCMID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
 // DeleteReceipt deletes the Receipt record with the supplied rcptid
 func DeleteReceipt(ctx context.Context, rcptid int64) error {
 	var err error
@@ -795,6 +1204,28 @@ func DeleteReceiptAllocations(ctx context.Context, rcptid int64) error {
 	return err
 }
 
+// DeleteRenewalOffer deletes the RenewalOffer associated with the supplied id
+func DeleteRenewalOffer(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteRenewalOffer)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteRenewalOffer.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting RenewalOffer for ROID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
 // DeleteRentableTypeRefWithRTID deletes RentableTypeRef records with the supplied RTID
 func DeleteRentableTypeRefWithRTID(ctx context.Context, rtid int64) error {
 	var err error
@@ -927,6 +1358,28 @@ func DeleteRentableLeaseStatus(ctx context.Context, rlid int64) error {
 	return err
 }
 
+// DeleteRentableTypeTax deletes the RentableTypeTax associated with the supplied id
+func DeleteRentableTypeTax(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteRentableTypeTax)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteRentableTypeTax.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting RentableTypeTax for RTTAXID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
 // DeleteRentableUseStatus deletes RentableUseStatus records with the supplied rsid
 func DeleteRentableUseStatus(ctx context.Context, rsid int64) error {
 	var err error
@@ -1191,6 +1644,83 @@ func DeleteRentableUser(ctx context.Context, id int64) error {
 	return err
 }
 
+// DeleteRentalAgreementTax deletes the RentalAgreementTax associated with the supplied id
+func DeleteRentalAgreementTax(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteRentalAgreementTax)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteRentalAgreementTax.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting RentalAgreementTax for RATAXID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteSecDepSettlement deletes the SecDepSettlement associated with the
+// supplied id. The items of the settlement are deleted as well.
+func DeleteSecDepSettlement(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	m, err := GetSecDepItems(ctx, id)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(m); i++ {
+		if err = DeleteSecDepItem(ctx, m[i].SDIID); err != nil {
+			return err
+		}
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteSecDepSettlement)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteSecDepSettlement.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting SecDepSettlement for SDSID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteSecDepItem deletes the SecDepItem associated with the supplied id
+func DeleteSecDepItem(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteSecDepItem)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteSecDepItem.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting SecDepItem for SDIID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
 // DeleteStringList deletes the StringList with the specified id from the database
 func DeleteStringList(ctx context.Context, id int64) error {
 	var err error
@@ -1450,10 +1980,76 @@ func DeleteTBind(ctx context.Context, id int64) error {
 	return err
 }
 
+// DeleteTax deletes the Tax associated with the supplied id
+func DeleteTax(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteTax)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteTax.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting Tax for TAXID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteTaxRate deletes the TaxRate associated with the supplied id
+func DeleteTaxRate(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteTaxRate)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteTaxRate.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting TaxRate for TRID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
 //*****************************************************************************
 //  TRANSACTANT, PAYOR, USER, PROSPECT
 //*****************************************************************************
 
+// DeleteTenantLogin deletes the TenantLogin associated with the supplied id
+func DeleteTenantLogin(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteTenantLogin)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteTenantLogin.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting TenantLogin for TLID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
 // DeleteTransactant deletes the Transactant with the specified id from the database
 func DeleteTransactant(ctx context.Context, id int64) error {
 	var err error
@@ -1541,3 +2137,113 @@ func DeletePayor(ctx context.Context, id int64) error {
 	}
 	return err
 }
+
+// DeleteVendor deletes the Vendor associated with the supplied id
+func DeleteVendor(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteVendor)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteVendor.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting Vendor for VID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteVendorBill deletes the VendorBill associated with the supplied id
+func DeleteVendorBill(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteVendorBill)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteVendorBill.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting VendorBill for VBID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteVendorBillLine deletes the VendorBillLine associated with the supplied id
+func DeleteVendorBillLine(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteVendorBillLine)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteVendorBillLine.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting VendorBillLine for VBLID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteVendorPaymentRun deletes the VendorPaymentRun associated with the supplied id
+func DeleteVendorPaymentRun(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteVendorPaymentRun)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteVendorPaymentRun.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting VendorPaymentRun for VPRID = %d, error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteWorkOrder deletes the WorkOrder associated with the supplied id
+func DeleteWorkOrder(ctx context.Context, id int64) error {
+	var err error
+
+	if err = deleteSessionCheck(ctx); err != nil {
+		return err
+	}
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteWorkOrder)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteWorkOrder.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting WorkOrder for WOID = %d, error: %v\n", id, err)
+	}
+	return err
+}
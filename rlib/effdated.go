@@ -0,0 +1,175 @@
+package rlib
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Effective-dated records hold a value for a range of time, from DtStart up
+// to but not including DtStop: RentableUseStatus, RentableUseType,
+// RentableLeaseStatus, RentableTypeRef and RentableMarketRate are all
+// effective-dated.  Setting a new value for DtStart - DtStop changes the
+// records it overlaps:
+//
+//    existing:  @@@@@@@@@@@@@@@@@@@@@@@@######
+//    new:              ********
+//    result:    @@@@@@@********@@@@@@@@######     split
+//
+//    existing:  @@@@@@@@@@######@@@@@@@@@@@@
+//    new:              ********
+//    result:    @@@@@@@********@@@@@@@@@@@@@     overwrite
+//
+//    existing:  @@@@@@@@@@***********########
+//    new:              ********
+//    result:    @@@@@@@**************########     merge
+//
+// Records with the same value as the new one that it overlaps are merged
+// with it, records with a different value are trimmed, split or removed.
+// The records that do not overlap the new range are not changed.
+
+// EffectiveDatedSeries is implemented by the records of an effective-dated
+// series that overlap the range of a new value, and by the new value itself.
+// The existing records are numbered 0 ... Len()-1, the new value is -1.
+type EffectiveDatedSeries interface {
+	// Len returns the number of existing records
+	Len() int
+
+	// Span returns the range of record i
+	Span(i int) (time.Time, time.Time)
+
+	// SameValue returns true if record i holds the same value as the new
+	// one, it can then be merged with it
+	SameValue(i int) bool
+
+	// Update changes the range of existing record i to d1 - d2
+	Update(ctx context.Context, i int, d1, d2 *time.Time) error
+
+	// Insert adds a record with the value of record i, the new one if i is
+	// -1, over the range d1 - d2
+	Insert(ctx context.Context, i int, d1, d2 *time.Time) error
+
+	// Delete removes existing record i
+	Delete(ctx context.Context, i int) error
+}
+
+// Effective-dated record operations, see EDOp
+const (
+	EDDelete = iota // delete existing record Rec
+	EDUpdate        // set the range of existing record Rec to DtStart - DtStop
+	EDInsert        // insert a copy of record Rec (-1 = the new value) over DtStart - DtStop
+)
+
+// EDOp is one of the changes needed to set a new value in an
+// effective-dated series
+type EDOp struct {
+	Op      int       // EDDelete, EDUpdate or EDInsert
+	Rec     int       // the record it applies to, -1 is the new value
+	DtStart time.Time // range for EDUpdate and EDInsert
+	DtStop  time.Time
+}
+
+// PlanEffectiveDated computes the changes needed to set the new value of s
+// over d1 - d2.  The existing records of s must not overlap each other.
+// Records that do not overlap d1 - d2 are left alone, even the ones that
+// end where it starts or start where it ends with the same value.  The
+// deletes come first, then the updates, then the inserts.
+//
+// INPUTS
+//     s      - the series
+//     d1, d2 - range of the new value
+//
+// RETURNS
+//     the list of changes
+//-----------------------------------------------------------------------------
+func PlanEffectiveDated(s EffectiveDatedSeries, d1, d2 *time.Time) []EDOp {
+	var del, upd, ins []EDOp
+	start, stop := *d1, *d2
+	reuse := -1 // existing record with the same value that will hold the new range
+
+	for i := 0; i < s.Len(); i++ {
+		dtStart, dtStop := s.Span(i)
+		if !dtStop.After(*d1) || !dtStart.Before(*d2) {
+			continue // no overlap
+		}
+
+		//------------------------------------------------------------
+		// merge the records with the same value into one
+		//------------------------------------------------------------
+		if s.SameValue(i) {
+			if dtStart.Before(start) {
+				start = dtStart
+			}
+			if dtStop.After(stop) {
+				stop = dtStop
+			}
+			if reuse < 0 {
+				reuse = i
+			} else {
+				del = append(del, EDOp{Op: EDDelete, Rec: i})
+			}
+			continue
+		}
+
+		//------------------------------------------------------------
+		// trim, split or remove the records with a different value
+		//------------------------------------------------------------
+		before := dtStart.Before(*d1)
+		after := dtStop.After(*d2)
+		switch {
+		case before && after:
+			upd = append(upd, EDOp{Op: EDUpdate, Rec: i, DtStart: dtStart, DtStop: *d1})
+			ins = append(ins, EDOp{Op: EDInsert, Rec: i, DtStart: *d2, DtStop: dtStop})
+		case before:
+			upd = append(upd, EDOp{Op: EDUpdate, Rec: i, DtStart: dtStart, DtStop: *d1})
+		case after:
+			upd = append(upd, EDOp{Op: EDUpdate, Rec: i, DtStart: *d2, DtStop: dtStop})
+		default:
+			del = append(del, EDOp{Op: EDDelete, Rec: i})
+		}
+	}
+
+	if reuse >= 0 {
+		upd = append(upd, EDOp{Op: EDUpdate, Rec: reuse, DtStart: start, DtStop: stop})
+	} else {
+		ins = append(ins, EDOp{Op: EDInsert, Rec: -1, DtStart: start, DtStop: stop})
+	}
+	return append(append(del, upd...), ins...)
+}
+
+// SetEffectiveDated sets the new value of s over d1 - d2, changing the
+// existing records as computed by PlanEffectiveDated.  Nothing is done if
+// d1 equals d2.
+//
+// INPUTS
+//     ctx    - db context
+//     s      - the series
+//     d1, d2 - range of the new value
+//
+// RETURNS
+//     any error encountered
+//-----------------------------------------------------------------------------
+func SetEffectiveDated(ctx context.Context, s EffectiveDatedSeries, d1, d2 *time.Time) error {
+	if d2.Before(*d1) {
+		return fmt.Errorf("stop date %s is before start date %s", d2.Format(RRDATEFMT4), d1.Format(RRDATEFMT4))
+	}
+	if d1.Equal(*d2) {
+		return nil
+	}
+	var err error
+	m := PlanEffectiveDated(s, d1, d2)
+	for i := 0; i < len(m); i++ {
+		switch m[i].Op {
+		case EDDelete:
+			err = s.Delete(ctx, m[i].Rec)
+		case EDUpdate:
+			err = s.Update(ctx, m[i].Rec, &m[i].DtStart, &m[i].DtStop)
+		case EDInsert:
+			err = s.Insert(ctx, m[i].Rec, &m[i].DtStart, &m[i].DtStop)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
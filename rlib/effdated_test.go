@@ -0,0 +1,289 @@
+package rlib
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+)
+
+// memRec is an effective-dated record kept in memory, the range is in days
+type memRec struct {
+	start, stop int
+	val         int
+	deleted     bool
+}
+
+// memSeries is an in-memory EffectiveDatedSeries over the records recs[a[i]]
+type memSeries struct {
+	recs []memRec
+	a    []int
+	n    memRec
+}
+
+var edBase = time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func edDay(d int) time.Time { return edBase.AddDate(0, 0, d) }
+
+func edDays(t *time.Time) int { return int(t.Sub(edBase) / (24 * time.Hour)) }
+
+func (s *memSeries) Len() int { return len(s.a) }
+
+func (s *memSeries) Span(i int) (time.Time, time.Time) {
+	r := s.recs[s.a[i]]
+	return edDay(r.start), edDay(r.stop)
+}
+
+func (s *memSeries) SameValue(i int) bool { return s.recs[s.a[i]].val == s.n.val }
+
+func (s *memSeries) Update(ctx context.Context, i int, d1, d2 *time.Time) error {
+	s.recs[s.a[i]].start, s.recs[s.a[i]].stop = edDays(d1), edDays(d2)
+	return nil
+}
+
+func (s *memSeries) Insert(ctx context.Context, i int, d1, d2 *time.Time) error {
+	r := s.n
+	if i >= 0 {
+		r = s.recs[s.a[i]]
+	}
+	r.start, r.stop = edDays(d1), edDays(d2)
+	s.recs = append(s.recs, r)
+	return nil
+}
+
+func (s *memSeries) Delete(ctx context.Context, i int) error {
+	s.recs[s.a[i]].deleted = true
+	return nil
+}
+
+// newMemSeries returns the series for setting n over recs.  Like the
+// ByRange queries the setters use (DtStop>? AND DtStart<?), it selects the
+// records that overlap n; records that only touch n are not selected.
+func newMemSeries(recs []memRec, n memRec) *memSeries {
+	s := memSeries{recs: recs, n: n}
+	for i := 0; i < len(recs); i++ {
+		if recs[i].stop > n.start && recs[i].start < n.stop {
+			s.a = append(s.a, i)
+		}
+	}
+	return &s
+}
+
+// valueAt returns the value of the live record holding day d, -1 if none
+func valueAt(recs []memRec, d int) int {
+	for i := 0; i < len(recs); i++ {
+		if !recs[i].deleted && recs[i].start <= d && d < recs[i].stop {
+			return recs[i].val
+		}
+	}
+	return -1
+}
+
+// randomRecs returns sorted, non-overlapping records within 0 - 60 days.
+// Touching records have different values.
+func randomRecs(r *rand.Rand) []memRec {
+	var recs []memRec
+	d := r.Intn(5)
+	for n := r.Intn(6); n > 0 && d < 60; n-- {
+		x := memRec{start: d, stop: d + 1 + r.Intn(15), val: r.Intn(3)}
+		if k := len(recs) - 1; k >= 0 && recs[k].stop == x.start && recs[k].val == x.val {
+			x.val = (x.val + 1) % 3
+		}
+		recs = append(recs, x)
+		d = x.stop + r.Intn(3) // 0 means the next record touches this one
+	}
+	return recs
+}
+
+func TestSetEffectiveDatedProperties(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	ctx := context.Background()
+
+	for iter := 0; iter < 20000; iter++ {
+		orig := randomRecs(r)
+		d1 := r.Intn(65)
+		n := memRec{start: d1, stop: d1 + 1 + r.Intn(25), val: r.Intn(3)}
+		recs := make([]memRec, len(orig))
+		copy(recs, orig)
+		s := newMemSeries(recs, n)
+		dt1, dt2 := edDay(n.start), edDay(n.stop)
+		if err := SetEffectiveDated(ctx, s, &dt1, &dt2); err != nil {
+			t.Fatalf("iteration %d: unexpected error: %s", iter, err.Error())
+		}
+
+		//----------------------------------------
+		// no empty or overlapping records
+		//----------------------------------------
+		var live []memRec
+		for i := 0; i < len(s.recs); i++ {
+			if !s.recs[i].deleted {
+				live = append(live, s.recs[i])
+			}
+		}
+		sort.Slice(live, func(i, j int) bool { return live[i].start < live[j].start })
+		for i := 0; i < len(live); i++ {
+			if live[i].start >= live[i].stop {
+				t.Fatalf("iteration %d: empty record %+v", iter, live[i])
+			}
+			if i > 0 && live[i].start < live[i-1].stop {
+				t.Fatalf("iteration %d: records overlap: %+v, %+v", iter, live[i-1], live[i])
+			}
+		}
+
+		//----------------------------------------
+		// the new value inside, the old outside
+		//----------------------------------------
+		for d := -2; d < 100; d++ {
+			want := valueAt(orig, d)
+			if d >= n.start && d < n.stop {
+				want = n.val
+			}
+			if got := valueAt(s.recs, d); got != want {
+				t.Fatalf("iteration %d: day %d: got value %d, want %d\norig = %+v\nnew = %+v\nresult = %+v", iter, d, got, want, orig, n, live)
+			}
+		}
+
+		//----------------------------------------
+		// the new value is merged with the
+		// records of the same value it overlaps
+		//----------------------------------------
+		var m memRec
+		for i := 0; i < len(live); i++ {
+			if live[i].start <= n.start && n.start < live[i].stop {
+				m = live[i]
+			}
+		}
+		if m.stop < n.stop {
+			t.Fatalf("iteration %d: new range %+v is not held by one record, found %+v", iter, n, m)
+		}
+		for i := 0; i < len(orig); i++ {
+			o := orig[i]
+			if o.val == n.val && o.stop > n.start && o.start < n.stop && (o.start < m.start || o.stop > m.stop) {
+				t.Fatalf("iteration %d: record %+v not merged with %+v", iter, o, m)
+			}
+		}
+
+		//----------------------------------------
+		// records away from the range are left
+		// alone
+		//----------------------------------------
+		for i := 0; i < len(orig); i++ {
+			if orig[i].stop <= n.start || orig[i].start >= n.stop {
+				if s.recs[i] != orig[i] {
+					t.Fatalf("iteration %d: record %+v changed to %+v", iter, orig[i], s.recs[i])
+				}
+			}
+		}
+	}
+}
+
+func TestSetEffectiveDatedRange(t *testing.T) {
+	ctx := context.Background()
+	recs := []memRec{{start: 0, stop: 10, val: 1}}
+
+	s := newMemSeries(recs, memRec{start: 5, stop: 5, val: 2})
+	dt := edDay(5)
+	if err := SetEffectiveDated(ctx, s, &dt, &dt); err != nil {
+		t.Errorf("empty range: unexpected error: %s", err.Error())
+	}
+	if len(s.recs) != 1 || s.recs[0] != recs[0] {
+		t.Errorf("empty range: records changed to %+v", s.recs)
+	}
+
+	dt1, dt2 := edDay(8), edDay(3)
+	if err := SetEffectiveDated(ctx, s, &dt1, &dt2); err == nil {
+		t.Errorf("reversed range: expected an error")
+	}
+}
+
+func TestPlanEffectiveDated(t *testing.T) {
+	var m = []struct {
+		name string
+		recs []memRec
+		n    memRec
+		ops  []EDOp
+	}{
+		{"1a contained, same value",
+			[]memRec{{start: 0, stop: 30, val: 1}},
+			memRec{start: 10, stop: 20, val: 1},
+			[]EDOp{{EDUpdate, 0, edDay(0), edDay(30)}},
+		},
+		{"1b contained, split",
+			[]memRec{{start: 0, stop: 30, val: 1}},
+			memRec{start: 10, stop: 20, val: 2},
+			[]EDOp{
+				{EDUpdate, 0, edDay(0), edDay(10)},
+				{EDInsert, 0, edDay(20), edDay(30)},
+				{EDInsert, -1, edDay(10), edDay(20)},
+			},
+		},
+		{"1c new before",
+			[]memRec{{start: 10, stop: 30, val: 1}},
+			memRec{start: 0, stop: 20, val: 2},
+			[]EDOp{
+				{EDUpdate, 0, edDay(20), edDay(30)},
+				{EDInsert, -1, edDay(0), edDay(20)},
+			},
+		},
+		{"1d new after",
+			[]memRec{{start: 0, stop: 20, val: 1}},
+			memRec{start: 10, stop: 30, val: 2},
+			[]EDOp{
+				{EDUpdate, 0, edDay(0), edDay(10)},
+				{EDInsert, -1, edDay(10), edDay(30)},
+			},
+		},
+		{"2a overlaps two, same values",
+			[]memRec{{start: 0, stop: 10, val: 1}, {start: 20, stop: 30, val: 1}},
+			memRec{start: 5, stop: 25, val: 1},
+			[]EDOp{
+				{EDDelete, 1, time.Time{}, time.Time{}},
+				{EDUpdate, 0, edDay(0), edDay(30)},
+			},
+		},
+		{"2b overlaps two, different values",
+			[]memRec{{start: 0, stop: 10, val: 1}, {start: 10, stop: 30, val: 3}},
+			memRec{start: 5, stop: 25, val: 2},
+			[]EDOp{
+				{EDUpdate, 0, edDay(0), edDay(5)},
+				{EDUpdate, 1, edDay(25), edDay(30)},
+				{EDInsert, -1, edDay(5), edDay(25)},
+			},
+		},
+		{"2c overwrite the middle one",
+			[]memRec{{start: 0, stop: 10, val: 1}, {start: 10, stop: 15, val: 3}, {start: 15, stop: 30, val: 1}},
+			memRec{start: 8, stop: 20, val: 2},
+			[]EDOp{
+				{EDDelete, 1, time.Time{}, time.Time{}},
+				{EDUpdate, 0, edDay(0), edDay(8)},
+				{EDUpdate, 2, edDay(20), edDay(30)},
+				{EDInsert, -1, edDay(8), edDay(20)},
+			},
+		},
+		{"2d touches one with the same value, it is not selected",
+			[]memRec{{start: 0, stop: 10, val: 2}, {start: 10, stop: 30, val: 1}},
+			memRec{start: 10, stop: 20, val: 2},
+			[]EDOp{
+				{EDUpdate, 0, edDay(20), edDay(30)},
+				{EDInsert, -1, edDay(10), edDay(20)},
+			},
+		},
+	}
+
+	for i := 0; i < len(m); i++ {
+		s := newMemSeries(m[i].recs, m[i].n)
+		d1, d2 := edDay(m[i].n.start), edDay(m[i].n.stop)
+		ops := PlanEffectiveDated(s, &d1, &d2)
+		if len(ops) != len(m[i].ops) {
+			t.Errorf("%s: got %d operations, want %d: %+v", m[i].name, len(ops), len(m[i].ops), ops)
+			continue
+		}
+		for j := 0; j < len(ops); j++ {
+			if ops[j].Op != m[i].ops[j].Op || ops[j].Rec != m[i].ops[j].Rec ||
+				!ops[j].DtStart.Equal(m[i].ops[j].DtStart) || !ops[j].DtStop.Equal(m[i].ops[j].DtStop) {
+				t.Errorf("%s: operation %d: got %+v, want %+v", m[i].name, j, ops[j], m[i].ops[j])
+			}
+		}
+	}
+}
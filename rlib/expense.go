@@ -0,0 +1,10 @@
+package rlib
+
+// ExpenseEditReverses returns true if saving expense aold as anew must
+// reverse aold and post anew as a new expense, with its own journal entry:
+// its account rule, amount or date changed.  Other changes, like the
+// comment, are saved in place.
+//-----------------------------------------------------------------------------
+func ExpenseEditReverses(aold, anew *Expense) bool {
+	return aold.ARID != anew.ARID || aold.Amount != anew.Amount || !aold.Dt.Equal(anew.Dt)
+}
@@ -0,0 +1,30 @@
+package rlib
+
+import (
+	"testing"
+	"time"
+)
+
+// TestExpenseEditReverses checks which staff edits of an expense reverse it
+// and post a new expense
+func TestExpenseEditReverses(t *testing.T) {
+	d := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	aold := Expense{EXPID: 1, BID: 1, ARID: 4, Amount: 150, Dt: d, Comment: "test"}
+	var cases = []struct {
+		edit   func(*Expense)
+		expect bool
+	}{
+		{func(a *Expense) {}, false},
+		{func(a *Expense) { a.Comment = "changed" }, false},
+		{func(a *Expense) { a.Amount = 175 }, true},
+		{func(a *Expense) { a.ARID = 5 }, true},
+		{func(a *Expense) { a.Dt = d.AddDate(0, 0, 1) }, true},
+	}
+	for i := 0; i < len(cases); i++ {
+		anew := aold
+		cases[i].edit(&anew)
+		if x := ExpenseEditReverses(&aold, &anew); x != cases[i].expect {
+			t.Errorf("case %d: expected %t, got %t\n", i, cases[i].expect, x)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package rlib
+
+import (
+	"context"
+	"time"
+)
+
+// FiscalYearClose FLAGS
+const (
+	FlFiscalYearReopened = 1 << 0 // the year was reopened, the closing journal is reversed
+)
+
+// IncomeStatementAcctTypes are the account types whose balances are closed
+// into retained earnings at the end of a fiscal year
+var IncomeStatementAcctTypes = []string{
+	"Income",
+	"Income Offset",
+	"Other Income",
+	"Expense",
+	"Other Expense",
+}
+
+// BizPropsFiscalYear holds the fiscal year settings for a business.  It is
+// stored as part of the business properties (BizProps.FiscalYear).
+//
+//    FirstMonth               - first month of the fiscal year, 1 - 12.  0
+//                               means January, the calendar year.
+//    RetainedEarningsGLNumber - GL number of the equity account that the
+//                               income and expense accounts are closed into
+//                               at the end of the fiscal year.
+//-----------------------------------------------------------------------------
+type BizPropsFiscalYear struct {
+	FirstMonth               int64
+	RetainedEarningsGLNumber string
+}
+
+// GetFiscalYearPolicy returns the fiscal year settings configured in the
+// business properties named bizPropName for business BID.
+//
+// INPUTS
+//     ctx         = context
+//     BID         = business id
+//     bizPropName = name of the business properties, usually "general"
+//
+// RETURNS
+//     the fiscal year settings
+//     any error encountered
+//-----------------------------------------------------------------------------
+func GetFiscalYearPolicy(ctx context.Context, BID int64, bizPropName string) (BizPropsFiscalYear, error) {
+	bizPropJSON, err := GetDataFromBusinessPropertyName(ctx, bizPropName, BID)
+	if err != nil {
+		return BizPropsFiscalYear{}, err
+	}
+	return bizPropJSON.FiscalYear, nil
+}
+
+// YearContaining returns the fiscal year that holds dt.  d1 is the first
+// day of the year and d2 the first day of the next year.
+func (p *BizPropsFiscalYear) YearContaining(dt *time.Time) (time.Time, time.Time) {
+	m := time.Month(p.FirstMonth)
+	if m < time.January || m > time.December {
+		m = time.January
+	}
+	y := dt.Year()
+	if dt.Month() < m {
+		y--
+	}
+	d1 := time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+	return d1, d1.AddDate(1, 0, 0)
+}
+
+// IsIncomeStatementAcctType returns true if accounts of type s are closed
+// into retained earnings at the end of a fiscal year
+func IsIncomeStatementAcctType(s string) bool {
+	for i := 0; i < len(IncomeStatementAcctTypes); i++ {
+		if s == IncomeStatementAcctTypes[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// IsIncomeStatementAcct returns true if gl is an income or expense account
+func IsIncomeStatementAcct(gl *GLAccount) bool {
+	return IsIncomeStatementAcctType(gl.AcctType)
+}
+
+// IDtoShortString is the short id string for a FiscalYearClose
+func (a *FiscalYearClose) IDtoShortString() string {
+	return IDtoShortString("FYC", a.FYCID)
+}
+
+// IsReopened returns true if the closing journal of the year was reversed
+func (a *FiscalYearClose) IsReopened() bool {
+	return a.FLAGS&FlFiscalYearReopened != 0
+}
+
+// GetFiscalYearClosingAmounts returns what the closing journals of the
+// fiscal years of business bid closed in d1 - d2 added to each GL account.
+// The closes that were reopened are left out, their closing journal was
+// reversed.  Parent accounts do not include their children.
+//
+// INPUTS
+//     ctx    = context
+//     bid    = business id
+//     d1, d2 = the closing journals dated in this range are included
+//
+// RETURNS
+//     the amounts, by LID
+//     any error encountered
+//-----------------------------------------------------------------------------
+func GetFiscalYearClosingAmounts(ctx context.Context, bid int64, d1, d2 *time.Time) (map[int64]float64, error) {
+	var m = map[int64]float64{}
+	t, err := GetFiscalYearCloses(ctx, bid)
+	if err != nil {
+		return m, err
+	}
+	for i := 0; i < len(t); i++ {
+		if t[i].IsReopened() || t[i].JID == 0 || t[i].Dt.Before(*d1) || !t[i].Dt.Before(*d2) {
+			continue
+		}
+		j, err := GetJournal(ctx, t[i].JID)
+		if err != nil {
+			return m, err
+		}
+		if err = GetJournalAllocations(ctx, &j); err != nil {
+			return m, err
+		}
+		for k := 0; k < len(j.JA); k++ {
+			le, err := GetLedgerEntriesByJAID(ctx, bid, j.JA[k].JAID)
+			if err != nil {
+				return m, err
+			}
+			for n := 0; n < len(le); n++ {
+				m[le[n].LID] += le[n].Amount
+			}
+		}
+	}
+	return m, nil
+}
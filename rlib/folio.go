@@ -0,0 +1,105 @@
+package rlib
+
+import (
+	"context"
+	"time"
+)
+
+// Folio Status values
+const (
+	FolioOpen   = 0
+	FolioClosed = 1
+)
+
+// BizPropsFolio holds the guest folio and night audit settings for a
+// business.  It is stored as part of the business properties
+// (BizProps.Folio).
+//
+//    RoomAR    - name of the account rule of the nightly room charges, the
+//                night audit does not run without it.  The room charges
+//                are taxed by the taxes of the rentable type of the room.
+//    AutoAudit - the night audit bot audits the business every night
+//-----------------------------------------------------------------------------
+type BizPropsFolio struct {
+	RoomAR    string
+	AutoAudit bool
+}
+
+// GetFolioPolicy returns the folio and night audit settings configured in
+// the business properties named bizPropName for business BID.
+//
+// INPUTS
+//     ctx         = context
+//     BID         = business id
+//     bizPropName = name of the business properties, usually "general"
+//
+// RETURNS
+//     the folio settings
+//     any error encountered
+//-----------------------------------------------------------------------------
+func GetFolioPolicy(ctx context.Context, BID int64, bizPropName string) (BizPropsFolio, error) {
+	bizPropJSON, err := GetDataFromBusinessPropertyName(ctx, bizPropName, BID)
+	if err != nil {
+		return BizPropsFolio{}, err
+	}
+	return bizPropJSON.Folio, nil
+}
+
+// GetBusinessDate returns the business date of business bid: the day after
+// the last night audited.  A business that was never audited is on today's
+// date.
+//
+// INPUTS
+//     ctx = db context
+//     bid = business id
+//
+// RETURNS
+//     the business date
+//     any error encountered
+//-----------------------------------------------------------------------------
+func GetBusinessDate(ctx context.Context, bid int64) (time.Time, error) {
+	a, err := GetLastNightAudit(ctx, bid)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return a.BusinessDate(time.Now()), nil
+}
+
+// BusinessDate returns the business date after night audit a: the day
+// after a.Dt, or the date of now if a is not a night audit (NAID is 0).
+func (a *NightAudit) BusinessDate(now time.Time) time.Time {
+	if a.NAID == 0 {
+		return DateAtTimeZero(now)
+	}
+	return DateAtTimeZero(a.Dt).AddDate(0, 0, 1)
+}
+
+// InHouse returns true if the guest of f stays the night of dt
+func (f *Folio) InHouse(dt *time.Time) bool {
+	return !dt.Before(f.DtArrive) && dt.Before(f.DtDepart)
+}
+
+// Occupancy returns the percentage of the rooms occupied the night of a
+func (a *NightAudit) Occupancy() float64 {
+	if a.Rooms == 0 {
+		return 0
+	}
+	return float64(a.Occupied) * 100 / float64(a.Rooms)
+}
+
+// ADR returns the average daily rate the night of a: the room revenue per
+// occupied room
+func (a *NightAudit) ADR() float64 {
+	if a.Occupied == 0 {
+		return 0
+	}
+	return RoundToCent(a.RoomRevenue / float64(a.Occupied))
+}
+
+// RevPAR returns the revenue per available room the night of a
+func (a *NightAudit) RevPAR() float64 {
+	if a.Rooms == 0 {
+		return 0
+	}
+	return RoundToCent(a.RoomRevenue / float64(a.Rooms))
+}
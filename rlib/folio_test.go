@@ -0,0 +1,59 @@
+package rlib
+
+import (
+	"testing"
+	"time"
+)
+
+// TestNightAuditBusinessDate checks the business date rolls to the day after
+// the last audit
+func TestNightAuditBusinessDate(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 15, 4, 5, 0, time.UTC)
+	var a NightAudit
+	if d := a.BusinessDate(now); !d.Equal(time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("never audited: expected 2026-03-10, got %s\n", d.Format(RRDATEFMTSQL))
+	}
+	a = NightAudit{NAID: 1, Dt: time.Date(2026, time.February, 28, 0, 0, 0, 0, time.UTC)}
+	if d := a.BusinessDate(now); !d.Equal(time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("audited: expected 2026-03-01, got %s\n", d.Format(RRDATEFMTSQL))
+	}
+}
+
+// TestNightAuditStats checks occupancy, ADR and RevPAR
+func TestNightAuditStats(t *testing.T) {
+	var cases = []struct {
+		a                NightAudit
+		occ, adr, revpar float64
+	}{
+		{NightAudit{Rooms: 40, Occupied: 30, RoomRevenue: 3600}, 75, 120, 90},
+		{NightAudit{Rooms: 3, Occupied: 1, RoomRevenue: 100}, 100.0 / 3, 100, 33.33},
+		{NightAudit{Rooms: 10}, 0, 0, 0},
+		{NightAudit{}, 0, 0, 0},
+	}
+	for i := 0; i < len(cases); i++ {
+		a := &cases[i].a
+		if x := a.Occupancy(); x != cases[i].occ {
+			t.Errorf("case %d: expected occupancy %f, got %f\n", i, cases[i].occ, x)
+		}
+		if x := a.ADR(); x != cases[i].adr {
+			t.Errorf("case %d: expected ADR %.2f, got %.2f\n", i, cases[i].adr, x)
+		}
+		if x := a.RevPAR(); x != cases[i].revpar {
+			t.Errorf("case %d: expected RevPAR %.2f, got %.2f\n", i, cases[i].revpar, x)
+		}
+	}
+}
+
+// TestFolioInHouse checks the nights a guest stays
+func TestFolioInHouse(t *testing.T) {
+	f := Folio{
+		DtArrive: time.Date(2026, time.May, 1, 0, 0, 0, 0, time.UTC),
+		DtDepart: time.Date(2026, time.May, 3, 0, 0, 0, 0, time.UTC),
+	}
+	for i, x := range []bool{false, true, true, false} {
+		dt := time.Date(2026, time.April, 30+i, 0, 0, 0, 0, time.UTC)
+		if f.InHouse(&dt) != x {
+			t.Errorf("%s: expected in house %t\n", dt.Format(RRDATEFMTSQL), x)
+		}
+	}
+}
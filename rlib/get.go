@@ -38,6 +38,93 @@ func GetCountByTableName(ctx context.Context, t string, bid int64) (int, error)
 	return count, err
 }
 
+//=======================================================
+//  A C C O U N T   M A P
+//=======================================================
+
+// GetAcctMap reads an AcctMap structure based on the supplied AMID
+func GetAcctMap(ctx context.Context, id int64) (AcctMap, error) {
+	var a AcctMap
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAcctMap)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetAcctMap.QueryRow(fields...)
+	}
+	return a, ReadAcctMap(row, &a)
+}
+
+// GetAcctMapByLID returns the AcctMap of GL account lid of
+// business bid
+func GetAcctMapByLID(ctx context.Context, bid int64, lid int64) (AcctMap, error) {
+	var a AcctMap
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{bid, lid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAcctMapByLID)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetAcctMapByLID.QueryRow(fields...)
+	}
+	return a, ReadAcctMap(row, &a)
+}
+
+// GetAcctMaps returns all the AcctMaps of business bid, by
+// consolidated GL number
+func GetAcctMaps(ctx context.Context, bid int64) ([]AcctMap, error) {
+	var (
+		err error
+		t   []AcctMap
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{bid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAcctMaps)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetAcctMaps.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a AcctMap
+		err = ReadAcctMaps(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
 //=======================================================
 //  AR
 //=======================================================
@@ -895,6 +982,212 @@ func GetAssessmentDuplicate(ctx context.Context, start *time.Time, amt float64,
 	return a, ReadAssessment(row, &a)
 }
 
+// GetAssessmentByAssocElem returns the first Assessment in business bid that
+// was created on behalf of the supplied element. Reversed assessments are
+// included. If no such assessment exists the returned struct has ASMID == 0.
+//
+// INPUTS
+//    ctx      - context
+//    bid      - business id
+//    elemType - element type, ELEMASSESSMENT, ELEMPET, ...
+//    elemID   - id of the element
+//
+// RETURNS
+//    the assessment
+//    any error encountered
+//-----------------------------------------------------------------------------
+func GetAssessmentByAssocElem(ctx context.Context, bid, elemType, elemID int64) (Assessment, error) {
+	var a Assessment
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return a, ErrSessionRequired
+		}
+	}
+
+	var row *sql.Row
+	fields := []interface{}{bid, elemType, elemID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAssessmentByAssocElem)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetAssessmentByAssocElem.QueryRow(fields...)
+	}
+	return a, ReadAssessment(row, &a)
+}
+
+// GetAssessmentsByAssocElem returns all the Assessments of business bid
+// associated with the element of type elemType and id elemID, in order of
+// their start date.
+//
+// INPUTS
+//    ctx      - db context
+//    bid      - business id
+//    elemType - type of the associated element (ELEMFOLIO, ...)
+//    elemID   - id of the associated element
+//
+// RETURNS
+//    the assessments
+//    any error encountered
+//-----------------------------------------------------------------------------
+func GetAssessmentsByAssocElem(ctx context.Context, bid, elemType, elemID int64) ([]Assessment, error) {
+	var err error
+	var rows *sql.Rows
+	var t []Assessment
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return t, ErrSessionRequired
+		}
+	}
+
+	fields := []interface{}{bid, elemType, elemID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAssessmentsByAssocElem)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetAssessmentsByAssocElem.Query(fields...)
+	}
+	if err != nil {
+		return t, err
+	}
+	return getAssessmentsByRows(ctx, rows)
+}
+
+// GetAssessmentChargesByRAID returns the charges of rental agreement raid
+// dated in d1 - d2: its non-recurring assessments and the instances of its
+// recurring assessments.  Reversed assessments are left out.
+//
+// INPUTS
+//    ctx  - db context
+//    raid - rental agreement id
+//    d1   - start of the range
+//    d2   - stop of the range
+//
+// RETURNS
+//    the assessments, in order of their start date
+//    any error encountered
+//-----------------------------------------------------------------------------
+func GetAssessmentChargesByRAID(ctx context.Context, raid int64, d1, d2 *time.Time) ([]Assessment, error) {
+	var err error
+	var rows *sql.Rows
+	var t []Assessment
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return t, ErrSessionRequired
+		}
+	}
+
+	fields := []interface{}{raid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAssessmentChargesByRAID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetAssessmentChargesByRAID.Query(fields...)
+	}
+	if err != nil {
+		return t, err
+	}
+	return getAssessmentsByRows(ctx, rows)
+}
+
+//=======================================================
+//  B U D G E T
+//=======================================================
+
+// GetBudget reads a Budget structure based on the supplied BGTID
+func GetBudget(ctx context.Context, id int64) (Budget, error) {
+	var a Budget
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetBudget)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetBudget.QueryRow(fields...)
+	}
+	return a, ReadBudget(row, &a)
+}
+
+// GetBudgetForAccount returns the Budget of GL account lid for the month
+// starting on dt
+func GetBudgetForAccount(ctx context.Context, bid int64, lid int64, dt *time.Time) (Budget, error) {
+	var a Budget
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{bid, lid, dt}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetBudgetForAccount)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetBudgetForAccount.QueryRow(fields...)
+	}
+	return a, ReadBudget(row, &a)
+}
+
+// GetBudgetsInRange returns the Budgets of business bid for the months
+// starting in d1 - d2, by account then month
+func GetBudgetsInRange(ctx context.Context, bid int64, d1 *time.Time, d2 *time.Time) ([]Budget, error) {
+	var (
+		err error
+		t   []Budget
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{bid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetBudgetsInRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetBudgetsInRange.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a Budget
+		err = ReadBudgets(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
 //=======================================================
 //  B U I L D I N G
 //=======================================================
@@ -1179,61 +1472,334 @@ func GetBusinessPropertiesByName(ctx context.Context, Name string, BID int64) (b
 }
 
 //=======================================================
-//  CLOSE PERIOD
+//  CAM RECONCILIATION
+//  CAMReconciliation, CAMReconLine
 //=======================================================
 
-// GetClosePeriod reads specific ClosePeriod record
-//-----------------------------------------------------------------------------
-func GetClosePeriod(ctx context.Context, id int64) (ClosePeriod, error) {
-	var a ClosePeriod
-	var row *sql.Row
+// GetCAMReconciliation reads a CAMReconciliation structure based on the
+// supplied CAMRID. The lines are not loaded, use GetCAMReconLines for them.
+func GetCAMReconciliation(ctx context.Context, id int64) (CAMReconciliation, error) {
+	var a CAMReconciliation
 
-	fields := []interface{}{id}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetClosePeriod)
-		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
-	} else {
-		row = RRdb.Prepstmt.GetClosePeriod.QueryRow(fields...)
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
 	}
-	return a, ReadClosePeriod(row, &a)
-}
 
-// GetLastClosePeriod reads the last period closed
-//
-// INPUTS
-//  id  = BID
-//-----------------------------------------------------------------------------
-func GetLastClosePeriod(ctx context.Context, id int64) (ClosePeriod, error) {
-	var a ClosePeriod
 	var row *sql.Row
-
 	fields := []interface{}{id}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetLastClosePeriod)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetCAMReconciliation)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetLastClosePeriod.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetCAMReconciliation.QueryRow(fields...)
 	}
-	return a, ReadClosePeriod(row, &a)
+	return a, ReadCAMReconciliation(row, &a)
 }
 
-//=======================================================
-//  C U S T O M   A T T R I B U T E
-//  CustomAttribute, CustomAttributeRef
-//=======================================================
-
-// getCustomAttribute reads a CustomAttribute structure based on the supplied CustomAttribute id
-func getCustomAttribute(id int64) (CustomAttribute, error) {
-
+// GetCAMReconciliationsByRange returns the CAMReconciliations of business bid
+// whose period ends in d1 - d2, ordered by rental agreement
+func GetCAMReconciliationsByRange(ctx context.Context, bid int64, d1 *time.Time, d2 *time.Time) ([]CAMReconciliation, error) {
 	var (
-		a CustomAttribute
+		err error
+		t   []CAMReconciliation
 	)
 
-	var row *sql.Row
-	fields := []interface{}{id}
-	/*if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{bid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetCAMReconciliationsByRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetCAMReconciliationsByRange.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a CAMReconciliation
+		err = ReadCAMReconciliations(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+// GetCAMReconciliationsByRAID returns the CAMReconciliations for Rental
+// Agreement raid, most recent first
+func GetCAMReconciliationsByRAID(ctx context.Context, raid int64) ([]CAMReconciliation, error) {
+	var (
+		err error
+		t   []CAMReconciliation
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{raid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetCAMReconciliationsByRAID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetCAMReconciliationsByRAID.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a CAMReconciliation
+		err = ReadCAMReconciliations(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+// GetCAMReconLine reads a CAMReconLine structure based on the supplied CAMRLID
+func GetCAMReconLine(ctx context.Context, id int64) (CAMReconLine, error) {
+	var a CAMReconLine
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetCAMReconLine)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetCAMReconLine.QueryRow(fields...)
+	}
+	return a, ReadCAMReconLine(row, &a)
+}
+
+// GetCAMReconLines returns the lines of CAMReconciliation camrid, one for
+// each GL account
+func GetCAMReconLines(ctx context.Context, camrid int64) ([]CAMReconLine, error) {
+	var (
+		err error
+		t   []CAMReconLine
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{camrid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetCAMReconLines)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetCAMReconLines.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a CAMReconLine
+		err = ReadCAMReconLines(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+//=======================================================
+//  CLOSE PERIOD
+//=======================================================
+
+// GetClosePeriod reads specific ClosePeriod record
+//-----------------------------------------------------------------------------
+func GetClosePeriod(ctx context.Context, id int64) (ClosePeriod, error) {
+	var a ClosePeriod
+	var row *sql.Row
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetClosePeriod)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetClosePeriod.QueryRow(fields...)
+	}
+	return a, ReadClosePeriod(row, &a)
+}
+
+// GetLastClosePeriod reads the last period closed
+//
+// INPUTS
+//  id  = BID
+//-----------------------------------------------------------------------------
+func GetLastClosePeriod(ctx context.Context, id int64) (ClosePeriod, error) {
+	var a ClosePeriod
+	var row *sql.Row
+
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetLastClosePeriod)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetLastClosePeriod.QueryRow(fields...)
+	}
+	return a, ReadClosePeriod(row, &a)
+}
+
+// GetFiscalYearClose reads a FiscalYearClose structure based on the supplied FYCID
+func GetFiscalYearClose(ctx context.Context, id int64) (FiscalYearClose, error) {
+	var a FiscalYearClose
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetFiscalYearClose)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetFiscalYearClose.QueryRow(fields...)
+	}
+	return a, ReadFiscalYearClose(row, &a)
+}
+
+// GetLastFiscalYearClose returns the latest FiscalYearClose of business bid
+// that has not been reopened
+func GetLastFiscalYearClose(ctx context.Context, bid int64) (FiscalYearClose, error) {
+	var a FiscalYearClose
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{bid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetLastFiscalYearClose)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetLastFiscalYearClose.QueryRow(fields...)
+	}
+	return a, ReadFiscalYearClose(row, &a)
+}
+
+// GetFiscalYearCloses returns all the FiscalYearCloses of business bid,
+// reopened ones included, oldest first
+func GetFiscalYearCloses(ctx context.Context, bid int64) ([]FiscalYearClose, error) {
+	var (
+		err error
+		t   []FiscalYearClose
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{bid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetFiscalYearCloses)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetFiscalYearCloses.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a FiscalYearClose
+		err = ReadFiscalYearCloses(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+// GetClosePeriodBefore returns the latest ClosePeriod of business bid dated
+// before dt
+func GetClosePeriodBefore(ctx context.Context, bid int64, dt *time.Time) (ClosePeriod, error) {
+	var a ClosePeriod
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{bid, dt}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetClosePeriodBefore)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetClosePeriodBefore.QueryRow(fields...)
+	}
+	return a, ReadClosePeriod(row, &a)
+}
+
+//=======================================================
+//  C U S T O M   A T T R I B U T E
+//  CustomAttribute, CustomAttributeRef
+//=======================================================
+
+// getCustomAttribute reads a CustomAttribute structure based on the supplied CustomAttribute id
+func getCustomAttribute(id int64) (CustomAttribute, error) {
+
+	var (
+		a CustomAttribute
+	)
+
+	var row *sql.Row
+	fields := []interface{}{id}
+	/*if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
 		stmt := tx.Stmt(RRdb.Prepstmt.GetCustomAttribute)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
@@ -1892,84 +2458,95 @@ func GetAllDepositMethods(ctx context.Context, bid int64) ([]DepositMethod, erro
 	return t, rows.Err()
 }
 
-//=======================================================
-//  FLOW
-//=======================================================
+// GetDepositsByDEPIDInRange returns an array of the Deposits made to
+// Depository depid between the supplied dates. The DepositParts are not
+// loaded.
+func GetDepositsByDEPIDInRange(ctx context.Context, bid, depid int64, d1, d2 *time.Time) ([]Deposit, error) {
+	var (
+		err error
+		t   []Deposit
+	)
 
-// GetFlow reads a Flow structure based on the supplied flowId
-func GetFlow(ctx context.Context, flowID int64) (Flow, error) {
-	var a Flow
-	if _, ok := SessionCheck(ctx); !ok {
-		return a, ErrSessionRequired
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
 	}
 
-	var row *sql.Row
-	fields := []interface{}{flowID}
+	var rows *sql.Rows
+	fields := []interface{}{bid, depid, d1, d2}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetFlow)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetDepositsByDEPIDInRange)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetFlow.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetDepositsByDEPIDInRange.Query(fields...)
 	}
-	return a, ReadFlow(row, &a)
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a Deposit
+		err = ReadDeposits(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
 }
 
-// GetFlowForRAID reads a Flow structure based on the supplied
-// FlowType and ID
-//
-// INPUTS:
-//     flowtype = type of flow. "RA" or whatever
-//           ID - the id that refers to a permanent table association.
-//                for FlowType "RA", ID is the RAID
-//
-// RETURNS
-//     The Flow struct
-//     Any error encountered
-//-----------------------------------------------------------------------------
-func GetFlowForRAID(ctx context.Context, flowtype string, ID int64) (Flow, error) {
-	var a Flow
+//=======================================================
+//  BANK STATEMENT
+//  BankStatement, BankStatementLine
+//=======================================================
 
-	if _, ok := SessionCheck(ctx); !ok {
+// GetBankStatement reads a BankStatement structure based on the supplied
+// BSID.  The lines of the statement are not loaded, use GetBankStatementLines.
+func GetBankStatement(ctx context.Context, id int64) (BankStatement, error) {
+	var a BankStatement
+
+	// session... context
+	if getSessionCheck(ctx) {
 		return a, ErrSessionRequired
 	}
 
 	var row *sql.Row
-	fields := []interface{}{flowtype, ID}
+	fields := []interface{}{id}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetFlowForRAID)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetBankStatement)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetFlowForRAID.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetBankStatement.QueryRow(fields...)
 	}
-	return a, ReadFlow(row, &a)
+	return a, ReadBankStatement(row, &a)
 }
 
-// GetFlowsByFlowType reads all flowID for the current user
-func GetFlowsByFlowType(ctx context.Context, flowType string) ([]Flow, error) {
-
+// GetBankStatementsByDEPID returns all the BankStatements imported for
+// Depository depid, the most recent statement first.
+func GetBankStatementsByDEPID(ctx context.Context, bid, depid int64) ([]BankStatement, error) {
 	var (
 		err error
-		t   []Flow
+		t   []BankStatement
 	)
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return t, ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{flowType}
+	fields := []interface{}{bid, depid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetFlowsByFlowType)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetBankStatementsByDEPID)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetFlowsByFlowType.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetBankStatementsByDEPID.Query(fields...)
 	}
 
 	if err != nil {
@@ -1977,195 +2554,197 @@ func GetFlowsByFlowType(ctx context.Context, flowType string) ([]Flow, error) {
 	}
 	defer rows.Close()
 
-	for i := 0; rows.Next(); i++ {
-		var f Flow
-		err = ReadFlows(rows, &f)
+	for rows.Next() {
+		var a BankStatement
+		err = ReadBankStatements(rows, &a)
 		if err != nil {
 			return t, err
 		}
-		t = append(t, f)
+		t = append(t, a)
 	}
+
 	return t, rows.Err()
 }
 
-// GetFlowIDsByUser reads all flowID for the current user
-func GetFlowIDsByUser(ctx context.Context) ([]int64, error) {
-
-	var (
-		err error
-		t   []int64
-		UID = int64(0)
-	)
+// GetBankStatementLine reads a BankStatementLine structure based on the
+// supplied BSLID
+func GetBankStatementLine(ctx context.Context, id int64) (BankStatementLine, error) {
+	var a BankStatementLine
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		sess, ok := SessionFromContext(ctx)
-		if !ok {
-			return t, ErrSessionRequired
-		}
-		UID = sess.UID
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
 	}
 
-	var rows *sql.Rows
-	fields := []interface{}{UID}
+	var row *sql.Row
+	fields := []interface{}{id}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetFlowIDsByUser)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetBankStatementLine)
 		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
+		row = stmt.QueryRow(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetFlowIDsByUser.Query(fields...)
+		row = RRdb.Prepstmt.GetBankStatementLine.QueryRow(fields...)
 	}
+	return a, ReadBankStatementLine(row, &a)
+}
 
-	if err != nil {
-		return t, err
+// GetBankStatementLineByFITID reads the BankStatementLine with the supplied
+// bank transaction id in Depository depid.  It is used to detect statement
+// lines that have already been imported.
+func GetBankStatementLineByFITID(ctx context.Context, bid, depid int64, fitid string) (BankStatementLine, error) {
+	var a BankStatementLine
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
 	}
-	defer rows.Close()
 
-	for i := 0; rows.Next(); i++ {
-		var id int64
-		err = rows.Scan(&id)
-		if err != nil {
-			return t, err
-		}
-		t = append(t, id)
+	var row *sql.Row
+	fields := []interface{}{bid, depid, fitid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetBankStatementLineByFITID)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetBankStatementLineByFITID.QueryRow(fields...)
 	}
-	return t, rows.Err()
+	return a, ReadBankStatementLine(row, &a)
 }
 
-// GetFlowByUserRefNo reads all flows struct by supplied user ref no.
-//
-// INPUTS
-//     UserRefNo - string
-//
-// RETURNS
-//     a flow
-//     any error encountered
-//-----------------------------------------------------------------------------
-func GetFlowByUserRefNo(ctx context.Context, BID int64, UserRefNo string) (a Flow, err error) {
-	if _, ok := SessionCheck(ctx); !ok {
+// GetBankStatementLineByDID reads the BankStatementLine that has been
+// matched to Deposit did.  If there is no such line, the BSLID of the
+// returned struct is 0.
+func GetBankStatementLineByDID(ctx context.Context, did int64) (BankStatementLine, error) {
+	var a BankStatementLine
+
+	// session... context
+	if getSessionCheck(ctx) {
 		return a, ErrSessionRequired
 	}
 
-	var (
-		row      *sql.Row
-		prepStmt = RRdb.Prepstmt.GetFlowByUserRefNo
-		fields   = []interface{}{BID, UserRefNo}
-	)
-
+	var row *sql.Row
+	fields := []interface{}{did}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		tStmt := tx.Stmt(prepStmt)
-		defer tStmt.Close()
-		row = tStmt.QueryRow(fields...)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetBankStatementLineByDID)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
 	} else {
-		row = prepStmt.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetBankStatementLineByDID.QueryRow(fields...)
 	}
-
-	return a, ReadFlow(row, &a)
+	return a, ReadBankStatementLine(row, &a)
 }
 
-//=======================================================
-//  EXPENSE
-//=======================================================
+// GetBankStatementLines returns the lines of BankStatement bsid
+func GetBankStatementLines(ctx context.Context, bsid int64) ([]BankStatementLine, error) {
+	var err error
+	var rows *sql.Rows
 
-// GetExpense reads a Expense structure based on the supplied Expense id
-func GetExpense(ctx context.Context, id int64) (Expense, error) {
+	// session... context
+	if getSessionCheck(ctx) {
+		return nil, ErrSessionRequired
+	}
 
-	var (
-		// err error
-		a Expense
-	)
+	fields := []interface{}{bsid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetBankStatementLines)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetBankStatementLines.Query(fields...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return getBankStatementLineRows(rows)
+}
+
+// GetBankStatementLinesInRange returns the BankStatementLines for Depository
+// depid whose date is in the range d1 <= Dt < d2
+func GetBankStatementLinesInRange(ctx context.Context, bid, depid int64, d1, d2 *time.Time) ([]BankStatementLine, error) {
+	var err error
+	var rows *sql.Rows
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return a, ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return nil, ErrSessionRequired
 	}
 
-	var row *sql.Row
-	fields := []interface{}{id}
+	fields := []interface{}{bid, depid, d1, d2}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetExpense)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetBankStatementLinesInRange)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetExpense.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetBankStatementLinesInRange.Query(fields...)
 	}
-	return a, ReadExpense(row, &a)
+	if err != nil {
+		return nil, err
+	}
+	return getBankStatementLineRows(rows)
+}
+
+// getBankStatementLineRows reads all the BankStatementLines in rows and
+// closes rows when finished.
+func getBankStatementLineRows(rows *sql.Rows) ([]BankStatementLine, error) {
+	var t []BankStatementLine
+	defer rows.Close()
+	for rows.Next() {
+		var a BankStatementLine
+		if err := ReadBankStatementLines(rows, &a); err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+	return t, rows.Err()
 }
 
 //=======================================================
-//  I N V O I C E
+//  ACH
+//  ACHEnrollment, ACHBatch, ACHEntry
 //=======================================================
 
-// GetInvoice reads a Invoice structure based on the supplied Invoice id
-func GetInvoice(ctx context.Context, id int64) (Invoice, error) {
-
-	var (
-		err error
-		a   Invoice
-	)
+// GetACHEnrollment reads an ACHEnrollment structure based on the supplied
+// AEID. The routing and account numbers are decrypted.
+func GetACHEnrollment(ctx context.Context, id int64) (ACHEnrollment, error) {
+	var a ACHEnrollment
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return a, ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
 	}
 
 	var row *sql.Row
 	fields := []interface{}{id}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetInvoice)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetACHEnrollment)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetInvoice.QueryRow(fields...)
-	}
-	err = ReadInvoice(row, &a)
-	if err != nil {
-		return a, err
-	}
-
-	a.A, err = GetInvoiceAssessments(ctx, id)
-	if err != nil {
-		return a, err
-	}
-
-	a.P, err = GetInvoicePayors(ctx, id)
-	if err != nil {
-		return a, err
+		row = RRdb.Prepstmt.GetACHEnrollment.QueryRow(fields...)
 	}
-
-	return a, err
+	return a, ReadACHEnrollment(row, &a)
 }
 
-// GetAllInvoicesInRange returns an array of all Invoices for bid between the supplied dates
-func GetAllInvoicesInRange(ctx context.Context, bid int64, d1, d2 *time.Time) ([]Invoice, error) {
-
+// GetACHEnrollmentsByTCID returns all the ACHEnrollments for payor tcid
+func GetACHEnrollmentsByTCID(ctx context.Context, bid, tcid int64) ([]ACHEnrollment, error) {
 	var (
 		err error
-		t   []Invoice
+		t   []ACHEnrollment
 	)
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return t, ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{bid, d1, d2}
+	fields := []interface{}{bid, tcid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetAllInvoicesInRange)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetACHEnrollmentsByTCID)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetAllInvoicesInRange.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetACHEnrollmentsByTCID.Query(fields...)
 	}
 
 	if err != nil {
@@ -2174,260 +2753,262 @@ func GetAllInvoicesInRange(ctx context.Context, bid int64, d1, d2 *time.Time) ([
 	defer rows.Close()
 
 	for rows.Next() {
-		var a Invoice
-		err = ReadInvoices(rows, &a)
-		if err != nil {
-			return t, err
-		}
-
-		a.A, err = GetInvoiceAssessments(ctx, a.InvoiceNo)
+		var a ACHEnrollment
+		err = ReadACHEnrollments(rows, &a)
 		if err != nil {
 			return t, err
 		}
-
-		a.P, err = GetInvoicePayors(ctx, a.InvoiceNo)
 		t = append(t, a)
-		if err != nil {
-			return t, err
-		}
 	}
 
 	return t, rows.Err()
 }
 
-// GetInvoiceAssessments reads a InvoiceAssessment structure based on the supplied InvoiceAssessment DID
-func GetInvoiceAssessments(ctx context.Context, id int64) ([]InvoiceAssessment, error) {
-
+// GetActiveACHEnrollments returns the ACHEnrollments of business bid that
+// are in effect on dt and have not been suspended, ordered by payor.
+func GetActiveACHEnrollments(ctx context.Context, bid int64, dt *time.Time) ([]ACHEnrollment, error) {
 	var (
 		err error
-		m   []InvoiceAssessment
+		t   []ACHEnrollment
 	)
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return m, ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{id}
+	fields := []interface{}{bid, dt, dt}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetInvoiceAssessments)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetActiveACHEnrollments)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetInvoiceAssessments.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetActiveACHEnrollments.Query(fields...)
 	}
 
 	if err != nil {
-		return m, err
+		return t, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var a InvoiceAssessment
-		err = ReadInvoiceAssessments(rows, &a)
+		var a ACHEnrollment
+		err = ReadACHEnrollments(rows, &a)
 		if err != nil {
-			return m, err
+			return t, err
 		}
-		m = append(m, a)
+		t = append(t, a)
 	}
 
-	return m, rows.Err()
+	return t, rows.Err()
 }
 
-// GetInvoicePayors reads an InvoicePayor structure based on the supplied InvoiceNo (id)
-func GetInvoicePayors(ctx context.Context, id int64) ([]InvoicePayor, error) {
+// GetACHBatch reads an ACHBatch structure based on the supplied ABID.  The
+// entries of the batch are not loaded, use GetACHEntries.
+func GetACHBatch(ctx context.Context, id int64) (ACHBatch, error) {
+	var a ACHBatch
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetACHBatch)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetACHBatch.QueryRow(fields...)
+	}
+	return a, ReadACHBatch(row, &a)
+}
 
+// GetACHBatches returns the ACHBatches of business bid whose effective date
+// is in the range d1 <= Dt < d2, the most recent batch first.
+func GetACHBatches(ctx context.Context, bid int64, d1, d2 *time.Time) ([]ACHBatch, error) {
 	var (
 		err error
-		m   []InvoicePayor
+		t   []ACHBatch
 	)
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return m, ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{id}
+	fields := []interface{}{bid, d1, d2}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetInvoicePayors)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetACHBatches)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetInvoicePayors.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetACHBatches.Query(fields...)
 	}
 
 	if err != nil {
-		return m, err
+		return t, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var a InvoicePayor
-		err = ReadInvoicePayors(rows, &a)
+		var a ACHBatch
+		err = ReadACHBatches(rows, &a)
 		if err != nil {
-			return m, err
+			return t, err
 		}
-		m = append(m, a)
+		t = append(t, a)
 	}
 
-	return m, rows.Err()
+	return t, rows.Err()
 }
 
-//=======================================================
-//  JOURNAL
-//=======================================================
-
-// GetJournal returns the Journal struct for the journal entry with the supplied id
-func GetJournal(ctx context.Context, jid int64) (Journal, error) {
-
-	var (
-		// err error
-		r Journal
-	)
+// GetACHEntry reads an ACHEntry structure based on the supplied AENID
+func GetACHEntry(ctx context.Context, id int64) (ACHEntry, error) {
+	var a ACHEntry
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return r, ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
 	}
 
 	var row *sql.Row
-	fields := []interface{}{jid}
+	fields := []interface{}{id}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetJournal)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetACHEntry)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetJournal.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetACHEntry.QueryRow(fields...)
 	}
-	return r, ReadJournal(row, &r)
+	return a, ReadACHEntry(row, &a)
 }
 
-/*// GetJournalInstance returns the Journal struct for entries that were created with the assumption that
-// they are idempotent -- essentially: instances of recurring assessments and vacancy instances.  This call
-// is made prior to generating new ones to ensure that we don't have double entries for the same thing.
-func GetJournalInstance(ctx context.Context, id int64, dt1, dt2 *time.Time) (Journal, error) {
-	var (
-		// err error
-		r Journal
-	)
+// GetACHEntryByTraceNumber reads the ACHEntry of business bid with the
+// supplied NACHA trace number.  If there is no such entry, the AENID of the
+// returned struct is 0.
+func GetACHEntryByTraceNumber(ctx context.Context, bid int64, trace string) (ACHEntry, error) {
+	var a ACHEntry
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
 	var row *sql.Row
-	fields := []interface{}{id, dt1, dt2}
+	fields := []interface{}{bid, trace}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetJournalInstance)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetACHEntryByTraceNumber)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetJournalInstance.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetACHEntryByTraceNumber.QueryRow(fields...)
 	}
-	return r, ReadJournal(row, &r)
-}*/
-
-// GetJournalVacancy returns the Journal struct for entries that were created with the assumption that
-// they are idempotent -- essentially: instances of recurring assessments and vacancy instances.  This call
-// is made prior to generating new ones to ensure that we don't have double entries for the same thing.
-func GetJournalVacancy(ctx context.Context, id int64, dt1, dt2 *time.Time) (Journal, error) {
+	return a, ReadACHEntry(row, &a)
+}
 
+// GetACHEntries returns the entries of ACHBatch abid
+func GetACHEntries(ctx context.Context, abid int64) ([]ACHEntry, error) {
 	var (
-		// err error
-		r Journal
+		err error
+		t   []ACHEntry
 	)
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return r, ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
 	}
 
-	var row *sql.Row
-	fields := []interface{}{id, dt1, dt2}
+	var rows *sql.Rows
+	fields := []interface{}{abid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetJournalVacancy)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetACHEntries)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetJournalVacancy.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetACHEntries.Query(fields...)
 	}
-	return r, ReadJournal(row, &r)
-}
-
-// GetJournalByTypeAndID returns the Journal struct for entries match the supplied
-// Type and ID fields
-func GetJournalByTypeAndID(ctx context.Context, t, id int64) (Journal, error) {
 
-	var (
-		// err error
-		r Journal
-	)
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
 
-	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return r, ErrSessionRequired
+	for rows.Next() {
+		var a ACHEntry
+		err = ReadACHEntries(rows, &a)
+		if err != nil {
+			return t, err
 		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+//=======================================================
+//  FLOW
+//=======================================================
+
+// GetFlow reads a Flow structure based on the supplied flowId
+func GetFlow(ctx context.Context, flowID int64) (Flow, error) {
+	var a Flow
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
 	}
 
 	var row *sql.Row
-	fields := []interface{}{t, id}
+	fields := []interface{}{flowID}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetJournalByTypeAndID)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetFlow)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetJournalByTypeAndID.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetFlow.QueryRow(fields...)
 	}
-	return r, ReadJournal(row, &r)
+	return a, ReadFlow(row, &a)
 }
 
-// GetJournalByReceiptID returns the Journal struct for a Journal Entry that references the supplied
-// receiptID
-func GetJournalByReceiptID(ctx context.Context, id int64) (Journal, error) {
-
-	var (
-		// err error
-		r Journal
-	)
+// GetFlowForRAID reads a Flow structure based on the supplied
+// FlowType and ID
+//
+// INPUTS:
+//     flowtype = type of flow. "RA" or whatever
+//           ID - the id that refers to a permanent table association.
+//                for FlowType "RA", ID is the RAID
+//
+// RETURNS
+//     The Flow struct
+//     Any error encountered
+//-----------------------------------------------------------------------------
+func GetFlowForRAID(ctx context.Context, flowtype string, ID int64) (Flow, error) {
+	var a Flow
 
-	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return r, ErrSessionRequired
-		}
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
 	}
 
 	var row *sql.Row
-	fields := []interface{}{id}
+	fields := []interface{}{flowtype, ID}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetJournalByReceiptID)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetFlowForRAID)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetJournalByReceiptID.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetFlowForRAID.QueryRow(fields...)
 	}
-	return r, ReadJournal(row, &r)
+	return a, ReadFlow(row, &a)
 }
 
-// GetJournalsByReceiptID returns a slice of Journal structs where it references the supplied
-// receiptID
-func GetJournalsByReceiptID(ctx context.Context, id int64) ([]Journal, error) {
+// GetFlowsByFlowType reads all flowID for the current user
+func GetFlowsByFlowType(ctx context.Context, flowType string) ([]Flow, error) {
 
 	var (
 		err error
-		t   []Journal
+		t   []Flow
 	)
 
 	// session... context
@@ -2439,13 +3020,13 @@ func GetJournalsByReceiptID(ctx context.Context, id int64) ([]Journal, error) {
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{id}
+	fields := []interface{}{flowType}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetJournalByReceiptID)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetFlowsByFlowType)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetJournalByReceiptID.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetFlowsByFlowType.Query(fields...)
 	}
 
 	if err != nil {
@@ -2453,42 +3034,43 @@ func GetJournalsByReceiptID(ctx context.Context, id int64) ([]Journal, error) {
 	}
 	defer rows.Close()
 
-	for rows.Next() {
-		var r Journal
-		err = ReadJournals(rows, &r)
+	for i := 0; rows.Next(); i++ {
+		var f Flow
+		err = ReadFlows(rows, &f)
 		if err != nil {
 			return t, err
 		}
-		t = append(t, r)
+		t = append(t, f)
 	}
-
 	return t, rows.Err()
 }
 
-// GetJournalMarkers loads the last n Journal markers
-func GetJournalMarkers(ctx context.Context, n int64) ([]JournalMarker, error) {
+// GetFlowIDsByUser reads all flowID for the current user
+func GetFlowIDsByUser(ctx context.Context) ([]int64, error) {
 
 	var (
 		err error
-		t   []JournalMarker
+		t   []int64
+		UID = int64(0)
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
+		sess, ok := SessionFromContext(ctx)
 		if !ok {
 			return t, ErrSessionRequired
 		}
+		UID = sess.UID
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{n}
+	fields := []interface{}{UID}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetJournalMarkers)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetFlowIDsByUser)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetJournalMarkers.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetFlowIDsByUser.Query(fields...)
 	}
 
 	if err != nil {
@@ -2496,56 +3078,58 @@ func GetJournalMarkers(ctx context.Context, n int64) ([]JournalMarker, error) {
 	}
 	defer rows.Close()
 
-	for rows.Next() {
-		var r JournalMarker
-		err = ReadJournalMarkers(rows, &r)
+	for i := 0; rows.Next(); i++ {
+		var id int64
+		err = rows.Scan(&id)
 		if err != nil {
 			return t, err
 		}
-		t = append(t, r)
+		t = append(t, id)
 	}
-
 	return t, rows.Err()
 }
 
-// GetLastJournalMarker returns the last Journal marker or nil if no Journal markers exist
-func GetLastJournalMarker(ctx context.Context) (JournalMarker, error) {
-
-	var (
-		err error
-		j   JournalMarker
-	)
-
-	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return j, ErrSessionRequired
-		}
+// GetFlowByUserRefNo reads all flows struct by supplied user ref no.
+//
+// INPUTS
+//     UserRefNo - string
+//
+// RETURNS
+//     a flow
+//     any error encountered
+//-----------------------------------------------------------------------------
+func GetFlowByUserRefNo(ctx context.Context, BID int64, UserRefNo string) (a Flow, err error) {
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
 	}
 
-	t, err := GetJournalMarkers(ctx, 1)
-	if err != nil {
-		return j, err
-	}
+	var (
+		row      *sql.Row
+		prepStmt = RRdb.Prepstmt.GetFlowByUserRefNo
+		fields   = []interface{}{BID, UserRefNo}
+	)
 
-	if len(t) > 0 {
-		j = t[0]
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		tStmt := tx.Stmt(prepStmt)
+		defer tStmt.Close()
+		row = tStmt.QueryRow(fields...)
+	} else {
+		row = prepStmt.QueryRow(fields...)
 	}
 
-	return j, err
+	return a, ReadFlow(row, &a)
 }
 
 //=======================================================
-//  JOURNAL ALLOCATION
+//  EXPENSE
 //=======================================================
 
-// GetJournalAllocation returns the Journal allocation for the supplied JAID
-func GetJournalAllocation(ctx context.Context, jaid int64) (JournalAllocation, error) {
+// GetExpense reads a Expense structure based on the supplied Expense id
+func GetExpense(ctx context.Context, id int64) (Expense, error) {
 
 	var (
 		// err error
-		a JournalAllocation
+		a Expense
 	)
 
 	// session... context
@@ -2557,193 +3141,253 @@ func GetJournalAllocation(ctx context.Context, jaid int64) (JournalAllocation, e
 	}
 
 	var row *sql.Row
-	fields := []interface{}{jaid}
+	fields := []interface{}{id}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetJournalAllocation)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetExpense)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetJournalAllocation.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetExpense.QueryRow(fields...)
 	}
-	return a, ReadJournalAllocation(row, &a)
+	return a, ReadExpense(row, &a)
 }
 
-// GetJournalAllocations loads all Journal allocations associated with the supplied Journal id into
-// the RA array within a Journal structure
-func GetJournalAllocations(ctx context.Context, j *Journal) error {
-
+// GetExpensesByRange returns the Expenses of business bid dated in d1 - d2.
+// Reversed expenses and their reversals are not included.
+func GetExpensesByRange(ctx context.Context, bid int64, d1 *time.Time, d2 *time.Time) ([]Expense, error) {
 	var (
 		err error
+		t   []Expense
 	)
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{j.JID}
+	fields := []interface{}{bid, d1, d2}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetJournalAllocations)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetExpensesByRange)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetJournalAllocations.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetExpensesByRange.Query(fields...)
 	}
 
 	if err != nil {
-		return err
+		return t, err
 	}
+	defer rows.Close()
 
-	// get all journal allocation rows in j.JA field
-	jaRows, err := getJournalAllocationRows(ctx, rows)
-	if err != nil {
-		return err
+	for rows.Next() {
+		var a Expense
+		err = ReadExpenses(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
 	}
 
-	j.JA = jaRows
-	return err
+	return t, rows.Err()
 }
 
-// GetJournalAllocationByASMID returns an array of JournalAllocation records that reference
-// the supplied ASMID.
-func GetJournalAllocationByASMID(ctx context.Context, id int64) ([]JournalAllocation, error) {
+//=======================================================
+//  I N V O I C E
+//=======================================================
+
+// GetInvoice reads a Invoice structure based on the supplied Invoice id
+func GetInvoice(ctx context.Context, id int64) (Invoice, error) {
 
 	var (
-		err    error
-		jaRows []JournalAllocation
+		err error
+		a   Invoice
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return jaRows, ErrSessionRequired
+			return a, ErrSessionRequired
 		}
 	}
 
-	var rows *sql.Rows
+	var row *sql.Row
 	fields := []interface{}{id}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetJournalAllocationsByASMID)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetInvoice)
 		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
+		row = stmt.QueryRow(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetJournalAllocationsByASMID.Query(fields...)
+		row = RRdb.Prepstmt.GetInvoice.QueryRow(fields...)
+	}
+	err = ReadInvoice(row, &a)
+	if err != nil {
+		return a, err
 	}
 
+	a.A, err = GetInvoiceAssessments(ctx, id)
 	if err != nil {
-		return jaRows, err
+		return a, err
 	}
-	return getJournalAllocationRows(ctx, rows)
+
+	a.P, err = GetInvoicePayors(ctx, id)
+	if err != nil {
+		return a, err
+	}
+
+	return a, err
 }
 
-// GetJournalAllocationByASMandRCPTID returns an array of JournalAllocation
-// records that reference the supplied RCPTID and that have a non-zero ASMID.
-// These are the JournalAllocation entries created for Receipts that had
-// SubARs automatically generate an associated Assessment.
-//----------------------------------------------------------------------------
-func GetJournalAllocationByASMandRCPTID(ctx context.Context, id int64) ([]JournalAllocation, error) {
+// GetAllInvoicesInRange returns an array of all Invoices for bid between the supplied dates
+func GetAllInvoicesInRange(ctx context.Context, bid int64, d1, d2 *time.Time) ([]Invoice, error) {
 
 	var (
-		err    error
-		jaRows []JournalAllocation
+		err error
+		t   []Invoice
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return jaRows, ErrSessionRequired
+			return t, ErrSessionRequired
 		}
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{id}
+	fields := []interface{}{bid, d1, d2}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetJournalAllocationsByASMandRCPTID)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAllInvoicesInRange)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetJournalAllocationsByASMandRCPTID.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetAllInvoicesInRange.Query(fields...)
 	}
 
 	if err != nil {
-		return jaRows, err
+		return t, err
 	}
-	return getJournalAllocationRows(ctx, rows)
+	defer rows.Close()
+
+	for rows.Next() {
+		var a Invoice
+		err = ReadInvoices(rows, &a)
+		if err != nil {
+			return t, err
+		}
+
+		a.A, err = GetInvoiceAssessments(ctx, a.InvoiceNo)
+		if err != nil {
+			return t, err
+		}
+
+		a.P, err = GetInvoicePayors(ctx, a.InvoiceNo)
+		t = append(t, a)
+		if err != nil {
+			return t, err
+		}
+	}
+
+	return t, rows.Err()
 }
 
-func getJournalAllocationRows(ctx context.Context, rows *sql.Rows) ([]JournalAllocation, error) {
+// GetInvoiceAssessments reads a InvoiceAssessment structure based on the supplied InvoiceAssessment DID
+func GetInvoiceAssessments(ctx context.Context, id int64) ([]InvoiceAssessment, error) {
 
 	var (
 		err error
-		ja  []JournalAllocation
+		m   []InvoiceAssessment
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return ja, ErrSessionRequired
+			return m, ErrSessionRequired
 		}
 	}
 
+	var rows *sql.Rows
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetInvoiceAssessments)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetInvoiceAssessments.Query(fields...)
+	}
+
+	if err != nil {
+		return m, err
+	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var a JournalAllocation
-		err = ReadJournalAllocations(rows, &a)
+		var a InvoiceAssessment
+		err = ReadInvoiceAssessments(rows, &a)
 		if err != nil {
-			return ja, err
+			return m, err
 		}
-		ja = append(ja, a)
+		m = append(m, a)
 	}
 
-	return ja, rows.Err()
+	return m, rows.Err()
 }
 
-//=======================================================
-//  L E D G E R   M A R K E R
-//=======================================================
-
-// GetLatestLedgerMarkerByLID returns the latest LedgerMarker struct for the GLAccount with the supplied LID
-func GetLatestLedgerMarkerByLID(ctx context.Context, bid, lid int64) (LedgerMarker, error) {
+// GetInvoicePayors reads an InvoicePayor structure based on the supplied InvoiceNo (id)
+func GetInvoicePayors(ctx context.Context, id int64) ([]InvoicePayor, error) {
 
 	var (
-		// err error
-		r LedgerMarker
+		err error
+		m   []InvoicePayor
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return r, ErrSessionRequired
+			return m, ErrSessionRequired
 		}
 	}
 
-	var row *sql.Row
-	fields := []interface{}{bid, lid}
+	var rows *sql.Rows
+	fields := []interface{}{id}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetLatestLedgerMarkerByLID)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetInvoicePayors)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetLatestLedgerMarkerByLID.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetInvoicePayors.Query(fields...)
 	}
-	return r, ReadLedgerMarker(row, &r)
+
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a InvoicePayor
+		err = ReadInvoicePayors(rows, &a)
+		if err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+
+	return m, rows.Err()
 }
 
-// GetInitialLedgerMarkerByRAID returns the LedgerMarker struct for the GLAccount with the supplied LID
-func GetInitialLedgerMarkerByRAID(ctx context.Context, raid int64) (LedgerMarker, error) {
+//=======================================================
+//  JOURNAL
+//=======================================================
+
+// GetJournal returns the Journal struct for the journal entry with the supplied id
+func GetJournal(ctx context.Context, jid int64) (Journal, error) {
 
 	var (
 		// err error
-		r LedgerMarker
+		r Journal
 	)
 
 	// session... context
@@ -2755,51 +3399,84 @@ func GetInitialLedgerMarkerByRAID(ctx context.Context, raid int64) (LedgerMarker
 	}
 
 	var row *sql.Row
-	fields := []interface{}{raid}
+	fields := []interface{}{jid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetInitialLedgerMarkerByRAID)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetJournal)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetInitialLedgerMarkerByRAID.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetJournal.QueryRow(fields...)
 	}
-	return r, ReadLedgerMarker(row, &r)
+	return r, ReadJournal(row, &r)
 }
 
-// GetInitialLedgerMarkerByRID returns the LedgerMarker struct for the GLAccount with the supplied LID
-func GetInitialLedgerMarkerByRID(ctx context.Context, id int64) (LedgerMarker, error) {
-
+// GetAllJournalsInRange returns the Journals of business bid dated in d1 - d2
+func GetAllJournalsInRange(ctx context.Context, bid int64, d1 *time.Time, d2 *time.Time) ([]Journal, error) {
 	var (
-		// err error
-		a LedgerMarker
+		err error
+		t   []Journal
 	)
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return a, ErrSessionRequired
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{bid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAllJournalsInRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetAllJournalsInRange.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a Journal
+		err = ReadJournals(rows, &a)
+		if err != nil {
+			return t, err
 		}
+		t = append(t, a)
 	}
 
+	return t, rows.Err()
+}
+
+/*// GetJournalInstance returns the Journal struct for entries that were created with the assumption that
+// they are idempotent -- essentially: instances of recurring assessments and vacancy instances.  This call
+// is made prior to generating new ones to ensure that we don't have double entries for the same thing.
+func GetJournalInstance(ctx context.Context, id int64, dt1, dt2 *time.Time) (Journal, error) {
+	var (
+		// err error
+		r Journal
+	)
 	var row *sql.Row
-	fields := []interface{}{id}
+	fields := []interface{}{id, dt1, dt2}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetInitialLedgerMarkerByRID)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetJournalInstance)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetInitialLedgerMarkerByRID.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetJournalInstance.QueryRow(fields...)
 	}
-	return a, ReadLedgerMarker(row, &a)
-}
+	return r, ReadJournal(row, &r)
+}*/
 
-// GetLedgerMarkerOnOrBefore returns the LedgerMarker struct for the GLAccount with the supplied LID
-func GetLedgerMarkerOnOrBefore(ctx context.Context, bid, lid int64, dt *time.Time) (LedgerMarker, error) {
+// GetJournalVacancy returns the Journal struct for entries that were created with the assumption that
+// they are idempotent -- essentially: instances of recurring assessments and vacancy instances.  This call
+// is made prior to generating new ones to ensure that we don't have double entries for the same thing.
+func GetJournalVacancy(ctx context.Context, id int64, dt1, dt2 *time.Time) (Journal, error) {
 
 	var (
 		// err error
-		r LedgerMarker
+		r Journal
 	)
 
 	// session... context
@@ -2811,23 +3488,24 @@ func GetLedgerMarkerOnOrBefore(ctx context.Context, bid, lid int64, dt *time.Tim
 	}
 
 	var row *sql.Row
-	fields := []interface{}{bid, lid, dt}
+	fields := []interface{}{id, dt1, dt2}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetLedgerMarkerOnOrBefore)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetJournalVacancy)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetLedgerMarkerOnOrBefore.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetJournalVacancy.QueryRow(fields...)
 	}
-	return r, ReadLedgerMarker(row, &r)
+	return r, ReadJournal(row, &r)
 }
 
-/*// GetPayorLedgerMarkerOnOrBefore returns the LedgerMarker struct for the TCID
-func GetPayorLedgerMarkerOnOrBefore(ctx context.Context, bid, tcid int64, dt *time.Time) LedgerMarker {
+// GetJournalByTypeAndID returns the Journal struct for entries match the supplied
+// Type and ID fields
+func GetJournalByTypeAndID(ctx context.Context, t, id int64) (Journal, error) {
 
 	var (
 		// err error
-		r LedgerMarker
+		r Journal
 	)
 
 	// session... context
@@ -2839,25 +3517,24 @@ func GetPayorLedgerMarkerOnOrBefore(ctx context.Context, bid, tcid int64, dt *ti
 	}
 
 	var row *sql.Row
-	fields := []interface{}{bid, tcid, dt}
+	fields := []interface{}{t, id}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetPayorLedgerMarkerOnOrBefore)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetJournalByTypeAndID)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetPayorLedgerMarkerOnOrBefore.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetJournalByTypeAndID.QueryRow(fields...)
 	}
-	return r, ReadLedgerMarker(row, &r)
-}*/
+	return r, ReadJournal(row, &r)
+}
 
-// GetRALedgerMarkerOnOrBeforeDeprecated returns the LedgerMarker struct for the GLAccount with
-// the supplied LID filtered for the supplied RentalAgreement raid
-// THIS HAS BEEN DEPRECATED  7/27/2017
-func GetRALedgerMarkerOnOrBeforeDeprecated(ctx context.Context, bid, lid, raid int64, dt *time.Time) (LedgerMarker, error) {
+// GetJournalByReceiptID returns the Journal struct for a Journal Entry that references the supplied
+// receiptID
+func GetJournalByReceiptID(ctx context.Context, id int64) (Journal, error) {
 
 	var (
 		// err error
-		r LedgerMarker
+		r Journal
 	)
 
 	// session... context
@@ -2869,359 +3546,439 @@ func GetRALedgerMarkerOnOrBeforeDeprecated(ctx context.Context, bid, lid, raid i
 	}
 
 	var row *sql.Row
-	fields := []interface{}{bid, lid, raid, dt}
+	fields := []interface{}{id}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRALedgerMarkerOnOrBeforeDeprecated)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetJournalByReceiptID)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRALedgerMarkerOnOrBeforeDeprecated.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetJournalByReceiptID.QueryRow(fields...)
 	}
-	return r, ReadLedgerMarker(row, &r)
+	return r, ReadJournal(row, &r)
 }
 
-// GetRALedgerMarkerOnOrBefore returns the LedgerMarker struct for the RAID with
-// the supplied LID filtered for the supplied RentalAgreement raid
-//=============================================================================
-func GetRALedgerMarkerOnOrBefore(ctx context.Context, raid int64, dt *time.Time) (LedgerMarker, error) {
+// GetJournalsByReceiptID returns a slice of Journal structs where it references the supplied
+// receiptID
+func GetJournalsByReceiptID(ctx context.Context, id int64) ([]Journal, error) {
 
 	var (
-		// err error
-		r LedgerMarker
+		err error
+		t   []Journal
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return r, ErrSessionRequired
+			return t, ErrSessionRequired
 		}
 	}
 
-	var row *sql.Row
-	fields := []interface{}{raid, dt}
+	var rows *sql.Rows
+	fields := []interface{}{id}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRALedgerMarkerOnOrBefore)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetJournalByReceiptID)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRALedgerMarkerOnOrBefore.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetJournalByReceiptID.Query(fields...)
 	}
-	return r, ReadLedgerMarker(row, &r)
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r Journal
+		err = ReadJournals(rows, &r)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, r)
+	}
+
+	return t, rows.Err()
 }
 
-// GetRALedgerMarkerOnOrAfter returns the LedgerMarker struct for the RAID with
-// the supplied LID filtered for the supplied RentalAgreement raid
-//=============================================================================
-func GetRALedgerMarkerOnOrAfter(ctx context.Context, raid int64, dt *time.Time) (LedgerMarker, error) {
+// GetJournalMarkers loads the last n Journal markers
+func GetJournalMarkers(ctx context.Context, n int64) ([]JournalMarker, error) {
 
 	var (
-		// err error
-		r LedgerMarker
+		err error
+		t   []JournalMarker
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return r, ErrSessionRequired
+			return t, ErrSessionRequired
 		}
 	}
 
-	var row *sql.Row
-	fields := []interface{}{raid, dt}
+	var rows *sql.Rows
+	fields := []interface{}{n}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRALedgerMarkerOnOrAfter)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetJournalMarkers)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRALedgerMarkerOnOrAfter.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetJournalMarkers.Query(fields...)
 	}
-	return r, ReadLedgerMarker(row, &r)
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r JournalMarker
+		err = ReadJournalMarkers(rows, &r)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, r)
+	}
+
+	return t, rows.Err()
 }
 
-// GetTCLedgerMarkerOnOrBefore returns the LedgerMarker struct for the TCID
-// filtered for the supplied date
-//=============================================================================
-func GetTCLedgerMarkerOnOrBefore(ctx context.Context, tcid int64, dt *time.Time) (LedgerMarker, error) {
+// GetLastJournalMarker returns the last Journal marker or nil if no Journal markers exist
+func GetLastJournalMarker(ctx context.Context) (JournalMarker, error) {
 
 	var (
-		// err error
-		r LedgerMarker
+		err error
+		j   JournalMarker
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return r, ErrSessionRequired
+			return j, ErrSessionRequired
 		}
 	}
 
-	var row *sql.Row
-	fields := []interface{}{tcid, dt}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetTCLedgerMarkerOnOrBefore)
-		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
-	} else {
-		row = RRdb.Prepstmt.GetTCLedgerMarkerOnOrBefore.QueryRow(fields...)
+	t, err := GetJournalMarkers(ctx, 1)
+	if err != nil {
+		return j, err
 	}
-	return r, ReadLedgerMarker(row, &r)
+
+	if len(t) > 0 {
+		j = t[0]
+	}
+
+	return j, err
 }
 
-// GetTCLedgerMarkerOnOrAfter returns the LedgerMarker struct for the TCID
-// filtered for the supplied date
-//=============================================================================
-func GetTCLedgerMarkerOnOrAfter(ctx context.Context, tcid int64, dt *time.Time) (LedgerMarker, error) {
+//=======================================================
+//  JOURNAL ALLOCATION
+//=======================================================
+
+// GetJournalAllocation returns the Journal allocation for the supplied JAID
+func GetJournalAllocation(ctx context.Context, jaid int64) (JournalAllocation, error) {
 
 	var (
 		// err error
-		r LedgerMarker
+		a JournalAllocation
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return r, ErrSessionRequired
+			return a, ErrSessionRequired
 		}
 	}
 
 	var row *sql.Row
-	fields := []interface{}{tcid, dt}
+	fields := []interface{}{jaid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetTCLedgerMarkerOnOrAfter)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetJournalAllocation)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetTCLedgerMarkerOnOrAfter.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetJournalAllocation.QueryRow(fields...)
 	}
-	return r, ReadLedgerMarker(row, &r)
+	return a, ReadJournalAllocation(row, &a)
 }
 
-// GetRentableLedgerMarkerOnOrBefore returns the LedgerMarker struct for the GLAccount with
-// the supplied LID filtered for the supplied Rentable rid
-func GetRentableLedgerMarkerOnOrBefore(ctx context.Context, bid, lid, rid int64, dt *time.Time) (LedgerMarker, error) {
+// GetJournalAllocations loads all Journal allocations associated with the supplied Journal id into
+// the RA array within a Journal structure
+func GetJournalAllocations(ctx context.Context, j *Journal) error {
 
 	var (
-		// err error
-		r LedgerMarker
+		err error
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return r, ErrSessionRequired
+			return ErrSessionRequired
 		}
 	}
 
-	var row *sql.Row
-	fields := []interface{}{bid, lid, rid, dt}
+	var rows *sql.Rows
+	fields := []interface{}{j.JID}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableLedgerMarkerOnOrBefore)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetJournalAllocations)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRentableLedgerMarkerOnOrBefore.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetJournalAllocations.Query(fields...)
 	}
-	return r, ReadLedgerMarker(row, &r)
+
+	if err != nil {
+		return err
+	}
+
+	// get all journal allocation rows in j.JA field
+	jaRows, err := getJournalAllocationRows(ctx, rows)
+	if err != nil {
+		return err
+	}
+
+	j.JA = jaRows
+	return err
 }
 
-// GetRARentableLedgerMarkerOnOrBefore returns the LedgerMarker struct for the GLAccount with
-// the supplied LID filtered for the supplied Rentable rid
-func GetRARentableLedgerMarkerOnOrBefore(ctx context.Context, raid, rid int64, dt *time.Time) (LedgerMarker, error) {
+// GetJournalAllocationByASMID returns an array of JournalAllocation records that reference
+// the supplied ASMID.
+func GetJournalAllocationByASMID(ctx context.Context, id int64) ([]JournalAllocation, error) {
 
 	var (
-		// err error
-		r LedgerMarker
+		err    error
+		jaRows []JournalAllocation
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return r, ErrSessionRequired
+			return jaRows, ErrSessionRequired
 		}
 	}
 
-	var row *sql.Row
-	fields := []interface{}{raid, rid, dt}
+	var rows *sql.Rows
+	fields := []interface{}{id}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRARentableLedgerMarkerOnOrBefore)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetJournalAllocationsByASMID)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRARentableLedgerMarkerOnOrBefore.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetJournalAllocationsByASMID.Query(fields...)
 	}
-	return r, ReadLedgerMarker(row, &r)
+
+	if err != nil {
+		return jaRows, err
+	}
+	return getJournalAllocationRows(ctx, rows)
 }
 
-/*// LoadPayorLedgerMarker returns the LedgerMarker for the supplied bid,tcid
-// values. It loads the marker on-or-before dt.  If no such LedgerMarker exists,
-// then one will be created.
-//
-// TODO:  If a subsequent LedgerMarker exists and it is marked as the epoch (3) then
-// then it will be updated to normal status as the LedgerMarker just being will
-// created will be the new epoch.
-//
-// INPUTS
-//		bid  - business id
-//		tcid - which payor
-//		dt   - the ledger marker on this date, or the first prior LedgerMarker
-//			   will be loaded and returned.
-//-----------------------------------------------------------------------------
-func LoadPayorLedgerMarker(ctx context.Context, bid, tcid int64, dt *time.Time) (LedgerMarker, error) {
+// GetJournalAllocationByASMandRCPTID returns an array of JournalAllocation
+// records that reference the supplied RCPTID and that have a non-zero ASMID.
+// These are the JournalAllocation entries created for Receipts that had
+// SubARs automatically generate an associated Assessment.
+//----------------------------------------------------------------------------
+func GetJournalAllocationByASMandRCPTID(ctx context.Context, id int64) ([]JournalAllocation, error) {
 
 	var (
-		err error
-		lm LedgerMarker
+		err    error
+		jaRows []JournalAllocation
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return lm, ErrSessionRequired
+			return jaRows, ErrSessionRequired
 		}
 	}
 
-	lm, err = GetPayorLedgerMarkerOnOrBefore(ctx, bid, tcid, dt)
+	var rows *sql.Rows
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetJournalAllocationsByASMandRCPTID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetJournalAllocationsByASMandRCPTID.Query(fields...)
+	}
+
 	if err != nil {
-		lm.BID = bid
-		lm.TCID = tcid
-		lm.Dt = *dt
-		lm.State = LMINITIAL
-		err = InsertLedgerMarker(ctx, &lm)
-		if nil != err {
-			fmt.Printf("LoadRALedgerMarker: Error creating LedgerMarker: %s\n", err.Error())
-		}
+		return jaRows, err
 	}
-	return lm, err
-}*/
+	return getJournalAllocationRows(ctx, rows)
+}
 
-// LoadRALedgerMarker returns the LedgerMarker for the supplied bid,lid,raid
-// values. It loads the marker on-or-before dt.  If no such LedgerMarker exists,
-// then one will be created.
-//
-// TODO:  If a subsequent LedgerMarker exists and it is marked as the epoch (3) then
-// then it will be updated to normal status as the LedgerMarker just being will
-// created will be the new epoch.
-//
-// INPUTS
-//		bid  - business id
-//		lid  - parent ledger id
-//		raid - which RentalAgreement
-//		dt   - the ledger marker on this date, or the first prior LedgerMarker
-//			   will be loaded and returned.
-//-----------------------------------------------------------------------------
-func LoadRALedgerMarker(ctx context.Context, bid, lid, raid int64, dt *time.Time) (LedgerMarker, error) {
+func getJournalAllocationRows(ctx context.Context, rows *sql.Rows) ([]JournalAllocation, error) {
 
 	var (
 		err error
-		lm  LedgerMarker
+		ja  []JournalAllocation
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return lm, ErrSessionRequired
+			return ja, ErrSessionRequired
 		}
 	}
 
-	lm, err = GetRALedgerMarkerOnOrBeforeDeprecated(ctx, bid, lid, raid, dt)
-	if err != nil {
-		lm.LID = lid
-		lm.BID = bid
-		lm.RAID = raid
-		lm.Dt = time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)
-		lm.State = LMINITIAL
-		_, err = InsertLedgerMarker(ctx, &lm)
-		if nil != err {
-			fmt.Printf("LoadRALedgerMarker: Error creating LedgerMarker: %s\n", err.Error())
+	defer rows.Close()
+
+	for rows.Next() {
+		var a JournalAllocation
+		err = ReadJournalAllocations(rows, &a)
+		if err != nil {
+			return ja, err
 		}
+		ja = append(ja, a)
 	}
-	return lm, err
+
+	return ja, rows.Err()
 }
 
-// GetLatestLedgerMarkerByGLNo returns the LedgerMarker struct for the GLNo with the supplied name
-func GetLatestLedgerMarkerByGLNo(ctx context.Context, bid int64, s string) (LedgerMarker, error) {
+//=======================================================
+//  L E D G E R   M A R K E R
+//=======================================================
+
+// GetLatestLedgerMarkerByLID returns the latest LedgerMarker struct for the GLAccount with the supplied LID
+func GetLatestLedgerMarkerByLID(ctx context.Context, bid, lid int64) (LedgerMarker, error) {
 
 	var (
-		err error
-		lm  LedgerMarker
+		// err error
+		r LedgerMarker
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return lm, ErrSessionRequired
+			return r, ErrSessionRequired
 		}
 	}
 
-	l, err := GetLedgerByGLNo(ctx, bid, s)
-	if err != nil {
-		return lm, err
+	var row *sql.Row
+	fields := []interface{}{bid, lid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetLatestLedgerMarkerByLID)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetLatestLedgerMarkerByLID.QueryRow(fields...)
 	}
-	return GetLatestLedgerMarkerByLID(ctx, bid, l.LID)
+	return r, ReadLedgerMarker(row, &r)
 }
 
-/*// GetLatestLedgerMarkerByType returns the LedgerMarker struct for the supplied type
-func GetLatestLedgerMarkerByType(ctx context.Context, bid int64, t int64) (LedgerMarker, error) {
+// GetInitialLedgerMarkerByRAID returns the LedgerMarker struct for the GLAccount with the supplied LID
+func GetInitialLedgerMarkerByRAID(ctx context.Context, raid int64) (LedgerMarker, error) {
 
 	var (
-		err error
-		lm LedgerMarker
+		// err error
+		r LedgerMarker
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return lm, ErrSessionRequired
+			return r, ErrSessionRequired
 		}
 	}
 
-	l, err := GetLedgerByType(ctx, bid, t)
-	if err != nil {
-		return lm, err
+	var row *sql.Row
+	fields := []interface{}{raid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetInitialLedgerMarkerByRAID)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetInitialLedgerMarkerByRAID.QueryRow(fields...)
 	}
-	if 0 == l.LID {
-		return lm, err
+	return r, ReadLedgerMarker(row, &r)
+}
+
+// GetInitialLedgerMarkerByRID returns the LedgerMarker struct for the GLAccount with the supplied LID
+func GetInitialLedgerMarkerByRID(ctx context.Context, id int64) (LedgerMarker, error) {
+
+	var (
+		// err error
+		a LedgerMarker
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return a, ErrSessionRequired
+		}
 	}
-	return GetLatestLedgerMarkerByLID(ctx, bid, l.LID)
-}*/
 
-/*// GetAllLedgerMarkersOnOrBefore returns a map of all ledgermarkers for the supplied business and dat
-func GetAllLedgerMarkersOnOrBefore(ctx context.Context, bid int64, dt *time.Time) (map[int64]LedgerMarker, error) {
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetInitialLedgerMarkerByRID)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetInitialLedgerMarkerByRID.QueryRow(fields...)
+	}
+	return a, ReadLedgerMarker(row, &a)
+}
+
+// GetLedgerMarkerOnOrBefore returns the LedgerMarker struct for the GLAccount with the supplied LID
+func GetLedgerMarkerOnOrBefore(ctx context.Context, bid, lid int64, dt *time.Time) (LedgerMarker, error) {
 
 	var (
-		err error
-		t   = make(map[int64]LedgerMarker)
+		// err error
+		r LedgerMarker
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return t, ErrSessionRequired
+			return r, ErrSessionRequired
 		}
+	}
 
+	var row *sql.Row
+	fields := []interface{}{bid, lid, dt}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetLedgerMarkerOnOrBefore)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetLedgerMarkerOnOrBefore.QueryRow(fields...)
 	}
+	return r, ReadLedgerMarker(row, &r)
+}
 
+// GetLedgerMarkersInRange returns the LedgerMarkers of GLAccount lid dated
+// after d1 and up to and including d2, oldest first
+func GetLedgerMarkersInRange(ctx context.Context, bid int64, lid int64, d1 *time.Time, d2 *time.Time) ([]LedgerMarker, error) {
+	var (
+		err error
+		t   []LedgerMarker
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
 
 	var rows *sql.Rows
-	(bid, dt)
-	fields := []interface{}{}
+	fields := []interface{}{bid, lid, d1, d2}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(Prepstmt.GetAllLedgerMarkersOnOrBefore.Query)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetLedgerMarkersInRange)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = Prepstmt.GetAllLedgerMarkersOnOrBefore.Query.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetLedgerMarkersInRange.Query(fields...)
 	}
 
 	if err != nil {
@@ -3229,603 +3986,600 @@ func GetAllLedgerMarkersOnOrBefore(ctx context.Context, bid int64, dt *time.Time
 	}
 	defer rows.Close()
 
-	// fmt.Printf("%4s  %4s  %4s  %5s  %10s  %8s\n", "LMID", "LID", "BID", "State", "Dt", "Balance")
 	for rows.Next() {
-		var r LedgerMarker
-		err = ReadLedgerMarkers(rows, &r)
+		var a LedgerMarker
+		err = ReadLedgerMarkers(rows, &a)
 		if err != nil {
 			return t, err
 		}
-		t[r.LID] = r
-		// fmt.Printf("%4d  %4d  %4d  %5d  %10s  %8.2f\n", r.LMID, r.LID, r.BID, r.State, r.Dt, r.Balance)
+		t = append(t, a)
 	}
 
 	return t, rows.Err()
-}*/
-
-//=======================================================
-//  L E D G E R
-//=======================================================
+}
 
-// GetLedgerList returns an array of all GLAccount
-// this is essentially a way to get the exhaustive list of GLAccount numbers for a Business
-func GetLedgerList(ctx context.Context, bid int64) ([]GLAccount, error) {
+/*// GetPayorLedgerMarkerOnOrBefore returns the LedgerMarker struct for the TCID
+func GetPayorLedgerMarkerOnOrBefore(ctx context.Context, bid, tcid int64, dt *time.Time) LedgerMarker {
 
 	var (
-		err error
-		t   []GLAccount
+		// err error
+		r LedgerMarker
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return t, ErrSessionRequired
+			return r, ErrSessionRequired
 		}
 	}
 
-	var rows *sql.Rows
-	fields := []interface{}{bid}
+	var row *sql.Row
+	fields := []interface{}{bid, tcid, dt}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetLedgerList)
-		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
-	} else {
-		rows, err = RRdb.Prepstmt.GetLedgerList.Query(fields...)
-	}
-
-	if err != nil {
-		return t, err
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var r GLAccount
-		err = ReadGLAccounts(rows, &r)
-		if err != nil {
-			return t, err
-		}
-		t = append(t, r)
-	}
-
-	return t, rows.Err()
-}
-
-// getLedgerMap returns a map of all GLAccounts for the supplied business
-func getLedgerMap(bid int64) (map[int64]GLAccount, error) {
-
-	var (
-		err error
-		t   = make(map[int64]GLAccount)
-	)
-
-	var rows *sql.Rows
-	fields := []interface{}{bid}
-	/*if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetLedgerList)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetPayorLedgerMarkerOnOrBefore)
 		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
+		row = stmt.QueryRow(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetLedgerList.Query(fields...)
-	}*/
-	rows, err = RRdb.Prepstmt.GetLedgerList.Query(fields...)
-
-	if err != nil {
-		return t, err
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var r GLAccount
-		err = ReadGLAccounts(rows, &r)
-		if err != nil {
-			return t, err
-		}
-		t[r.LID] = r
+		row = RRdb.Prepstmt.GetPayorLedgerMarkerOnOrBefore.QueryRow(fields...)
 	}
+	return r, ReadLedgerMarker(row, &r)
+}*/
 
-	return t, rows.Err()
-}
-
-// GetGLAccountMap returns a map of all GLAccounts for the supplied business
-func GetGLAccountMap(ctx context.Context, bid int64) (map[int64]GLAccount, error) {
+// GetRALedgerMarkerOnOrBeforeDeprecated returns the LedgerMarker struct for the GLAccount with
+// the supplied LID filtered for the supplied RentalAgreement raid
+// THIS HAS BEEN DEPRECATED  7/27/2017
+func GetRALedgerMarkerOnOrBeforeDeprecated(ctx context.Context, bid, lid, raid int64, dt *time.Time) (LedgerMarker, error) {
 
 	var (
 		// err error
-		t = make(map[int64]GLAccount)
+		r LedgerMarker
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return t, ErrSessionRequired
+			return r, ErrSessionRequired
 		}
 	}
 
-	return getLedgerMap(bid)
+	var row *sql.Row
+	fields := []interface{}{bid, lid, raid, dt}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRALedgerMarkerOnOrBeforeDeprecated)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetRALedgerMarkerOnOrBeforeDeprecated.QueryRow(fields...)
+	}
+	return r, ReadLedgerMarker(row, &r)
 }
 
-// GetLedger returns the GLAccount struct for the supplied LID
-func GetLedger(ctx context.Context, lid int64) (GLAccount, error) {
+// GetRALedgerMarkerOnOrBefore returns the LedgerMarker struct for the RAID with
+// the supplied LID filtered for the supplied RentalAgreement raid
+//=============================================================================
+func GetRALedgerMarkerOnOrBefore(ctx context.Context, raid int64, dt *time.Time) (LedgerMarker, error) {
 
 	var (
 		// err error
-		a GLAccount
+		r LedgerMarker
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return a, ErrSessionRequired
+			return r, ErrSessionRequired
 		}
 	}
 
 	var row *sql.Row
-	fields := []interface{}{lid}
+	fields := []interface{}{raid, dt}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetLedger)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRALedgerMarkerOnOrBefore)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetLedger.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetRALedgerMarkerOnOrBefore.QueryRow(fields...)
 	}
-	return a, ReadGLAccount(row, &a)
+	return r, ReadLedgerMarker(row, &r)
 }
 
-// GetLedgerEntryByJAID returns the GLAccount struct for the supplied LID
-func GetLedgerEntryByJAID(ctx context.Context, bid, lid, jaid int64) (LedgerEntry, error) {
+// GetRALedgerMarkerOnOrAfter returns the LedgerMarker struct for the RAID with
+// the supplied LID filtered for the supplied RentalAgreement raid
+//=============================================================================
+func GetRALedgerMarkerOnOrAfter(ctx context.Context, raid int64, dt *time.Time) (LedgerMarker, error) {
 
 	var (
 		// err error
-		a LedgerEntry
+		r LedgerMarker
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return a, ErrSessionRequired
+			return r, ErrSessionRequired
 		}
 	}
 
 	var row *sql.Row
-	fields := []interface{}{bid, lid, jaid}
+	fields := []interface{}{raid, dt}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetLedgerEntryByJAID)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRALedgerMarkerOnOrAfter)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetLedgerEntryByJAID.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetRALedgerMarkerOnOrAfter.QueryRow(fields...)
 	}
-	return a, ReadLedgerEntry(row, &a)
+	return r, ReadLedgerMarker(row, &r)
 }
 
-// GetLedgerEntriesByJAID returns the GLAccount struct for the supplied LID
-func GetLedgerEntriesByJAID(ctx context.Context, bid, jaid int64) ([]LedgerEntry, error) {
+// GetTCLedgerMarkerOnOrBefore returns the LedgerMarker struct for the TCID
+// filtered for the supplied date
+//=============================================================================
+func GetTCLedgerMarkerOnOrBefore(ctx context.Context, tcid int64, dt *time.Time) (LedgerMarker, error) {
 
 	var (
-		err error
-		m   []LedgerEntry
+		// err error
+		r LedgerMarker
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return m, ErrSessionRequired
+			return r, ErrSessionRequired
 		}
 	}
 
-	var rows *sql.Rows
-	fields := []interface{}{bid, jaid}
+	var row *sql.Row
+	fields := []interface{}{tcid, dt}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetLedgerEntriesByJAID)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetTCLedgerMarkerOnOrBefore)
 		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
+		row = stmt.QueryRow(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetLedgerEntriesByJAID.Query(fields...)
-	}
-
-	if err != nil {
-		return m, err
-	}
-
-	for rows.Next() {
-		var le LedgerEntry
-		err = ReadLedgerEntries(rows, &le)
-		if err != nil {
-			return m, err
-		}
-		m = append(m, le)
+		row = RRdb.Prepstmt.GetTCLedgerMarkerOnOrBefore.QueryRow(fields...)
 	}
-
-	return m, rows.Err()
+	return r, ReadLedgerMarker(row, &r)
 }
 
-// GetCountLedgerEntries get total count for LedgerEntries
-// with particular associated business
-func GetCountLedgerEntries(ctx context.Context, lid, bid int64) (int64, error) {
+// GetTCLedgerMarkerOnOrAfter returns the LedgerMarker struct for the TCID
+// filtered for the supplied date
+//=============================================================================
+func GetTCLedgerMarkerOnOrAfter(ctx context.Context, tcid int64, dt *time.Time) (LedgerMarker, error) {
 
 	var (
-		// err   error
-		count int64
+		// err error
+		r LedgerMarker
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return count, ErrSessionRequired
+			return r, ErrSessionRequired
 		}
 	}
 
 	var row *sql.Row
-	fields := []interface{}{lid, bid}
+	fields := []interface{}{tcid, dt}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.CountLedgerEntries)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetTCLedgerMarkerOnOrAfter)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.CountLedgerEntries.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetTCLedgerMarkerOnOrAfter.QueryRow(fields...)
 	}
-	return count, row.Scan(&count)
+	return r, ReadLedgerMarker(row, &r)
 }
 
-// GetLedgerByGLNo returns the GLAccount struct for the supplied GLNo
-func GetLedgerByGLNo(ctx context.Context, bid int64, s string) (GLAccount, error) {
+// GetRentableLedgerMarkerOnOrBefore returns the LedgerMarker struct for the GLAccount with
+// the supplied LID filtered for the supplied Rentable rid
+func GetRentableLedgerMarkerOnOrBefore(ctx context.Context, bid, lid, rid int64, dt *time.Time) (LedgerMarker, error) {
 
 	var (
 		// err error
-		a GLAccount
+		r LedgerMarker
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return a, ErrSessionRequired
+			return r, ErrSessionRequired
 		}
 	}
 
 	var row *sql.Row
-	fields := []interface{}{bid, s}
+	fields := []interface{}{bid, lid, rid, dt}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetLedgerByGLNo)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableLedgerMarkerOnOrBefore)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetLedgerByGLNo.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetRentableLedgerMarkerOnOrBefore.QueryRow(fields...)
 	}
-	return a, ReadGLAccount(row, &a)
+	return r, ReadLedgerMarker(row, &r)
 }
 
-// GetLedgerByName returns the GLAccount struct for the supplied Name
-func GetLedgerByName(ctx context.Context, bid int64, s string) (GLAccount, error) {
+// GetRARentableLedgerMarkerOnOrBefore returns the LedgerMarker struct for the GLAccount with
+// the supplied LID filtered for the supplied Rentable rid
+func GetRARentableLedgerMarkerOnOrBefore(ctx context.Context, raid, rid int64, dt *time.Time) (LedgerMarker, error) {
 
 	var (
 		// err error
-		a GLAccount
+		r LedgerMarker
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return a, ErrSessionRequired
+			return r, ErrSessionRequired
 		}
 	}
 
 	var row *sql.Row
-	fields := []interface{}{bid, s}
+	fields := []interface{}{raid, rid, dt}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetLedgerByName)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRARentableLedgerMarkerOnOrBefore)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetLedgerByName.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetRARentableLedgerMarkerOnOrBefore.QueryRow(fields...)
 	}
-	return a, ReadGLAccount(row, &a)
+	return r, ReadLedgerMarker(row, &r)
 }
 
-/*// GetLedgerByType returns the GLAccount struct for the supplied Type
-func GetLedgerByType(ctx context.Context, bid, t int64) (GLAccount, error) {
+/*// LoadPayorLedgerMarker returns the LedgerMarker for the supplied bid,tcid
+// values. It loads the marker on-or-before dt.  If no such LedgerMarker exists,
+// then one will be created.
+//
+// TODO:  If a subsequent LedgerMarker exists and it is marked as the epoch (3) then
+// then it will be updated to normal status as the LedgerMarker just being will
+// created will be the new epoch.
+//
+// INPUTS
+//		bid  - business id
+//		tcid - which payor
+//		dt   - the ledger marker on this date, or the first prior LedgerMarker
+//			   will be loaded and returned.
+//-----------------------------------------------------------------------------
+func LoadPayorLedgerMarker(ctx context.Context, bid, tcid int64, dt *time.Time) (LedgerMarker, error) {
 
 	var (
-		// err error
-		a GLAccount
+		err error
+		lm LedgerMarker
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return a, ErrSessionRequired
+			return lm, ErrSessionRequired
 		}
 	}
 
-	var row *sql.Row
-	fields := []interface{}{bid, t}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetLedgerByType)
-		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
-	} else {
-		row = RRdb.Prepstmt.GetLedgerByType.QueryRow(fields...)
+	lm, err = GetPayorLedgerMarkerOnOrBefore(ctx, bid, tcid, dt)
+	if err != nil {
+		lm.BID = bid
+		lm.TCID = tcid
+		lm.Dt = *dt
+		lm.State = LMINITIAL
+		err = InsertLedgerMarker(ctx, &lm)
+		if nil != err {
+			fmt.Printf("LoadRALedgerMarker: Error creating LedgerMarker: %s\n", err.Error())
+		}
 	}
-	return a, ReadGLAccount(row, &a)
+	return lm, err
 }*/
 
-/*// GetRABalanceLedger returns the GLAccount struct for the supplied Type
-func GetRABalanceLedger(ctx context.Context, bid, RAID int64) (GLAccount, error) {
+// LoadRALedgerMarker returns the LedgerMarker for the supplied bid,lid,raid
+// values. It loads the marker on-or-before dt.  If no such LedgerMarker exists,
+// then one will be created.
+//
+// TODO:  If a subsequent LedgerMarker exists and it is marked as the epoch (3) then
+// then it will be updated to normal status as the LedgerMarker just being will
+// created will be the new epoch.
+//
+// INPUTS
+//		bid  - business id
+//		lid  - parent ledger id
+//		raid - which RentalAgreement
+//		dt   - the ledger marker on this date, or the first prior LedgerMarker
+//			   will be loaded and returned.
+//-----------------------------------------------------------------------------
+func LoadRALedgerMarker(ctx context.Context, bid, lid, raid int64, dt *time.Time) (LedgerMarker, error) {
 
 	var (
-		// err error
-		a   GLAccount
+		err error
+		lm  LedgerMarker
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return a, ErrSessionRequired
+			return lm, ErrSessionRequired
 		}
 	}
 
-	var row *sql.Row
-	fields := []interface{}{bid}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRABalanceLedger)
-		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
-	} else {
-		row = RRdb.Prepstmt.GetRABalanceLedger.QueryRow(fields...)
+	lm, err = GetRALedgerMarkerOnOrBeforeDeprecated(ctx, bid, lid, raid, dt)
+	if err != nil {
+		lm.LID = lid
+		lm.BID = bid
+		lm.RAID = raid
+		lm.Dt = time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)
+		lm.State = LMINITIAL
+		_, err = InsertLedgerMarker(ctx, &lm)
+		if nil != err {
+			fmt.Printf("LoadRALedgerMarker: Error creating LedgerMarker: %s\n", err.Error())
+		}
 	}
-	return a, ReadGLAccount(row, &a)
-}*/
+	return lm, err
+}
 
-/*// GetSecDepBalanceLedger returns the GLAccount struct for the supplied Type
-func GetSecDepBalanceLedger(ctx context.Context, bid, RAID int64) (GLAccount, error) {
+// GetLatestLedgerMarkerByGLNo returns the LedgerMarker struct for the GLNo with the supplied name
+func GetLatestLedgerMarkerByGLNo(ctx context.Context, bid int64, s string) (LedgerMarker, error) {
 
 	var (
-		// err error
-		a   GLAccount
+		err error
+		lm  LedgerMarker
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return a, ErrSessionRequired
+			return lm, ErrSessionRequired
 		}
 	}
 
-	var row *sql.Row
-	fields := []interface{}{bid, RAID}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetSecDepBalanceLedger)
-		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
-	} else {
-		row = RRdb.Prepstmt.GetSecDepBalanceLedger.QueryRow(fields...)
+	l, err := GetLedgerByGLNo(ctx, bid, s)
+	if err != nil {
+		return lm, err
 	}
-	return a, ReadGLAccount(row, &a)
+	return GetLatestLedgerMarkerByLID(ctx, bid, l.LID)
+}
+
+/*// GetLatestLedgerMarkerByType returns the LedgerMarker struct for the supplied type
+func GetLatestLedgerMarkerByType(ctx context.Context, bid int64, t int64) (LedgerMarker, error) {
+
+	var (
+		err error
+		lm LedgerMarker
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return lm, ErrSessionRequired
+		}
+	}
+
+	l, err := GetLedgerByType(ctx, bid, t)
+	if err != nil {
+		return lm, err
+	}
+	if 0 == l.LID {
+		return lm, err
+	}
+	return GetLatestLedgerMarkerByLID(ctx, bid, l.LID)
 }*/
 
-/*// GetDefaultLedgers loads the default GLAccount for the supplied Business bid
-func GetDefaultLedgers(ctx context.Context, bid int64) error {
+/*// GetAllLedgerMarkersOnOrBefore returns a map of all ledgermarkers for the supplied business and dat
+func GetAllLedgerMarkersOnOrBefore(ctx context.Context, bid int64, dt *time.Time) (map[int64]LedgerMarker, error) {
 
 	var (
 		err error
+		t   = make(map[int64]LedgerMarker)
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return ErrSessionRequired
+			return t, ErrSessionRequired
 		}
+
 	}
 
 
 	var rows *sql.Rows
-	(bid)
+	(bid, dt)
 	fields := []interface{}{}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(Prepstmt.GetDefaultLedgers.Query)
+		stmt := tx.Stmt(Prepstmt.GetAllLedgerMarkersOnOrBefore.Query)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = Prepstmt.GetDefaultLedgers.Query.Query(fields...)
+		rows, err = Prepstmt.GetAllLedgerMarkersOnOrBefore.Query.Query(fields...)
 	}
 
 	if err != nil {
-		return err
+		return t, err
 	}
 	defer rows.Close()
+
+	// fmt.Printf("%4s  %4s  %4s  %5s  %10s  %8s\n", "LMID", "LID", "BID", "State", "Dt", "Balance")
 	for rows.Next() {
-		var r GLAccount
-		err = ReadGLAccounts(rows, &r)
+		var r LedgerMarker
+		err = ReadLedgerMarkers(rows, &r)
 		if err != nil {
-			return err
+			return t, err
 		}
-		RRdb.BizTypes[bid].DefaultAccts[r.Type] = &r
+		t[r.LID] = r
+		// fmt.Printf("%4d  %4d  %4d  %5d  %10s  %8.2f\n", r.LMID, r.LID, r.BID, r.State, r.Dt, r.Balance)
 	}
 
-	return err
+	return t, rows.Err()
 }*/
 
 //=======================================================
-//  LEDGER ENTRY
+//  L E D G E R
 //=======================================================
 
-// getLedgerEntryArray returns a list of Ledger Entries for the supplied rows value
-func getLedgerEntryArray(ctx context.Context, rows *sql.Rows) ([]LedgerEntry, error) {
+// GetLedgerList returns an array of all GLAccount
+// this is essentially a way to get the exhaustive list of GLAccount numbers for a Business
+func GetLedgerList(ctx context.Context, bid int64) ([]GLAccount, error) {
 
 	var (
 		err error
-		m   []LedgerEntry
+		t   []GLAccount
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return m, ErrSessionRequired
+			return t, ErrSessionRequired
 		}
 	}
 
+	var rows *sql.Rows
+	fields := []interface{}{bid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetLedgerList)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetLedgerList.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
 	for rows.Next() {
-		var le LedgerEntry
-		err = ReadLedgerEntries(rows, &le)
+		var r GLAccount
+		err = ReadGLAccounts(rows, &r)
 		if err != nil {
-			return m, err
+			return t, err
 		}
-		m = append(m, le)
+		t = append(t, r)
 	}
 
-	return m, rows.Err()
+	return t, rows.Err()
 }
 
-// GetLedgerEntriesInRange returns a list of Ledger Entries for the supplied Ledger during the supplied range
-func GetLedgerEntriesInRange(ctx context.Context, d1, d2 *time.Time, bid, lid int64) ([]LedgerEntry, error) {
+// getLedgerMap returns a map of all GLAccounts for the supplied business
+func getLedgerMap(bid int64) (map[int64]GLAccount, error) {
 
 	var (
 		err error
-		m   []LedgerEntry
+		t   = make(map[int64]GLAccount)
 	)
 
-	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return m, ErrSessionRequired
-		}
-	}
-
 	var rows *sql.Rows
-	fields := []interface{}{bid, lid, d1, d2}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetLedgerEntriesInRangeByLID)
+	fields := []interface{}{bid}
+	/*if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetLedgerList)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetLedgerEntriesInRangeByLID.Query(fields...)
-	}
+		rows, err = RRdb.Prepstmt.GetLedgerList.Query(fields...)
+	}*/
+	rows, err = RRdb.Prepstmt.GetLedgerList.Query(fields...)
 
 	if err != nil {
-		return m, err
+		return t, err
 	}
 	defer rows.Close()
 
-	return getLedgerEntryArray(ctx, rows)
+	for rows.Next() {
+		var r GLAccount
+		err = ReadGLAccounts(rows, &r)
+		if err != nil {
+			return t, err
+		}
+		t[r.LID] = r
+	}
+
+	return t, rows.Err()
 }
 
-// GetLedgerEntriesForRAID returns a list of Ledger Entries for the supplied RentalAgreement and Ledger
-func GetLedgerEntriesForRAID(ctx context.Context, d1, d2 *time.Time, raid, lid int64) ([]LedgerEntry, error) {
+// GetGLAccountMap returns a map of all GLAccounts for the supplied business
+func GetGLAccountMap(ctx context.Context, bid int64) (map[int64]GLAccount, error) {
 
 	var (
-		err error
-		m   []LedgerEntry
+		// err error
+		t = make(map[int64]GLAccount)
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return m, ErrSessionRequired
+			return t, ErrSessionRequired
 		}
 	}
 
-	var rows *sql.Rows
-	fields := []interface{}{d1, d2, raid, lid}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetLedgerEntriesForRAID)
-		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
-	} else {
-		rows, err = RRdb.Prepstmt.GetLedgerEntriesForRAID.Query(fields...)
-	}
-
-	if err != nil {
-		return m, err
-	}
-	defer rows.Close()
-
-	return getLedgerEntryArray(ctx, rows)
+	return getLedgerMap(bid)
 }
 
-// GetLedgerEntriesForRentable returns a list of Ledger Entries for the supplied Rentable (rid) and Ledger (lid)
-func GetLedgerEntriesForRentable(ctx context.Context, d1, d2 *time.Time, rid, lid int64) ([]LedgerEntry, error) {
+// GetLedger returns the GLAccount struct for the supplied LID
+func GetLedger(ctx context.Context, lid int64) (GLAccount, error) {
 
 	var (
-		err error
-		m   []LedgerEntry
+		// err error
+		a GLAccount
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return m, ErrSessionRequired
+			return a, ErrSessionRequired
 		}
 	}
 
-	var rows *sql.Rows
-	fields := []interface{}{d1, d2, rid, lid}
+	var row *sql.Row
+	fields := []interface{}{lid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetLedgerEntriesForRentable)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetLedger)
 		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
+		row = stmt.QueryRow(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetLedgerEntriesForRentable.Query(fields...)
-	}
-
-	if err != nil {
-		return m, err
+		row = RRdb.Prepstmt.GetLedger.QueryRow(fields...)
 	}
-	defer rows.Close()
-
-	return getLedgerEntryArray(ctx, rows)
+	return a, ReadGLAccount(row, &a)
 }
 
-// GetAllLedgerEntriesForRAID returns a list of Ledger Entries for the supplied RentalAgreement
-func GetAllLedgerEntriesForRAID(ctx context.Context, d1, d2 *time.Time, raid int64) ([]LedgerEntry, error) {
+// GetLedgerEntryByJAID returns the GLAccount struct for the supplied LID
+func GetLedgerEntryByJAID(ctx context.Context, bid, lid, jaid int64) (LedgerEntry, error) {
 
 	var (
-		err error
-		m   []LedgerEntry
+		// err error
+		a LedgerEntry
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return m, ErrSessionRequired
+			return a, ErrSessionRequired
 		}
 	}
 
-	var rows *sql.Rows
-	fields := []interface{}{d1, d2, raid}
+	var row *sql.Row
+	fields := []interface{}{bid, lid, jaid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetAllLedgerEntriesForRAID)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetLedgerEntryByJAID)
 		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
+		row = stmt.QueryRow(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetAllLedgerEntriesForRAID.Query(fields...)
-	}
-
-	if err != nil {
-		return m, err
+		row = RRdb.Prepstmt.GetLedgerEntryByJAID.QueryRow(fields...)
 	}
-	defer rows.Close()
-
-	return getLedgerEntryArray(ctx, rows)
+	return a, ReadLedgerEntry(row, &a)
 }
 
-// GetAllLedgerEntriesForRID returns a list of Ledger Entries for the supplied Rentable ID
-func GetAllLedgerEntriesForRID(ctx context.Context, d1, d2 *time.Time, rid int64) ([]LedgerEntry, error) {
+// GetLedgerEntriesByJAID returns the GLAccount struct for the supplied LID
+func GetLedgerEntriesByJAID(ctx context.Context, bid, jaid int64) ([]LedgerEntry, error) {
 
 	var (
 		err error
@@ -3841,248 +4595,205 @@ func GetAllLedgerEntriesForRID(ctx context.Context, d1, d2 *time.Time, rid int64
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{d1, d2, rid}
+	fields := []interface{}{bid, jaid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetAllLedgerEntriesForRID)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetLedgerEntriesByJAID)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetAllLedgerEntriesForRID.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetLedgerEntriesByJAID.Query(fields...)
 	}
 
 	if err != nil {
 		return m, err
 	}
-	defer rows.Close()
 
-	return getLedgerEntryArray(ctx, rows)
+	for rows.Next() {
+		var le LedgerEntry
+		err = ReadLedgerEntries(rows, &le)
+		if err != nil {
+			return m, err
+		}
+		m = append(m, le)
+	}
+
+	return m, rows.Err()
 }
 
-// GetAllLedgerEntriesInRange returns a list of Ledger Entries for the supplied business and time period
-func GetAllLedgerEntriesInRange(ctx context.Context, bid int64, d1, d2 *time.Time) ([]LedgerEntry, error) {
+// GetCountLedgerEntries get total count for LedgerEntries
+// with particular associated business
+func GetCountLedgerEntries(ctx context.Context, lid, bid int64) (int64, error) {
 
 	var (
-		err error
-		m   []LedgerEntry
+		// err   error
+		count int64
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return m, ErrSessionRequired
+			return count, ErrSessionRequired
 		}
 	}
 
-	var rows *sql.Rows
-	fields := []interface{}{bid, d1, d2}
+	var row *sql.Row
+	fields := []interface{}{lid, bid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetAllLedgerEntriesInRange)
+		stmt := tx.Stmt(RRdb.Prepstmt.CountLedgerEntries)
 		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
+		row = stmt.QueryRow(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetAllLedgerEntriesInRange.Query(fields...)
-	}
-
-	if err != nil {
-		return m, err
+		row = RRdb.Prepstmt.CountLedgerEntries.QueryRow(fields...)
 	}
-	defer rows.Close()
-
-	return getLedgerEntryArray(ctx, rows)
+	return count, row.Scan(&count)
 }
 
-/*// GetLedgerEntriesInRange returns a list of Ledger Entries for the supplied business and time period
-func GetLedgerEntriesInRange(ctx context.Context, bid, lid, raid int64, d1, d2 *time.Time) ([]LedgerEntry, error) {
+// GetLedgerByGLNo returns the GLAccount struct for the supplied GLNo
+func GetLedgerByGLNo(ctx context.Context, bid int64, s string) (GLAccount, error) {
 
 	var (
-		err error
-		m   []LedgerEntry
+		// err error
+		a GLAccount
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return m, ErrSessionRequired
+			return a, ErrSessionRequired
 		}
 	}
 
-
-	var rows *sql.Rows
-	(bid, lid, raid, d1, d2)
-	fields := []interface{}{}
+	var row *sql.Row
+	fields := []interface{}{bid, s}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(Prepstmt.GetLedgerEntriesInRange.Query)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetLedgerByGLNo)
 		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
+		row = stmt.QueryRow(fields...)
 	} else {
-		rows, err = Prepstmt.GetLedgerEntriesInRange.Query.Query(fields...)
-	}
-
-	if err != nil {
-		return m, err
+		row = RRdb.Prepstmt.GetLedgerByGLNo.QueryRow(fields...)
 	}
-	defer rows.Close()
-
-	return getLedgerEntryArray(ctx, rows)
-}*/
-
-//=======================================================
-//  NOTES
-//=======================================================
+	return a, ReadGLAccount(row, &a)
+}
 
-// GetNote reads a Note structure based on the supplied Note id
-func GetNote(ctx context.Context, tid int64, t *Note) error {
+// GetLedgerByName returns the GLAccount struct for the supplied Name
+func GetLedgerByName(ctx context.Context, bid int64, s string) (GLAccount, error) {
 
 	var (
-	// err error
+		// err error
+		a GLAccount
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return ErrSessionRequired
+			return a, ErrSessionRequired
 		}
 	}
 
 	var row *sql.Row
-	fields := []interface{}{tid}
+	fields := []interface{}{bid, s}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetNote)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetLedgerByName)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetNote.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetLedgerByName.QueryRow(fields...)
 	}
-	return ReadNote(row, t)
+	return a, ReadGLAccount(row, &a)
 }
 
-// GetNoteAndChildNotes reads a Note structure based on the supplied Note id, then it reads all its child notes, organizes them by Date
-// and returns them in an array
-func GetNoteAndChildNotes(ctx context.Context, nid int64) (Note, error) {
+/*// GetLedgerByType returns the GLAccount struct for the supplied Type
+func GetLedgerByType(ctx context.Context, bid, t int64) (GLAccount, error) {
 
 	var (
-		err error
-		n   Note
+		// err error
+		a GLAccount
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return n, ErrSessionRequired
+			return a, ErrSessionRequired
 		}
 	}
 
-	err = GetNote(ctx, nid, &n)
-	if err != nil {
-		return n, err
-	}
-
-	var rows *sql.Rows
-	fields := []interface{}{nid}
+	var row *sql.Row
+	fields := []interface{}{bid, t}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetNoteAndChildNotes)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetLedgerByType)
 		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
+		row = stmt.QueryRow(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetNoteAndChildNotes.Query(fields...)
-	}
-
-	if err != nil {
-		return n, err
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var p Note
-		err = ReadNotes(rows, &p)
-		if err != nil {
-			return n, err
-		}
-		n.CN = append(n.CN, p)
+		row = RRdb.Prepstmt.GetLedgerByType.QueryRow(fields...)
 	}
+	return a, ReadGLAccount(row, &a)
+}*/
 
-	return n, rows.Err()
-}
-
-//=======================================================
-//  NOTELIST
-//=======================================================
-
-// GetNoteList reads a NoteList structure based on the supplied NoteList id
-func GetNoteList(ctx context.Context, nlid int64) (NoteList, error) {
+/*// GetRABalanceLedger returns the GLAccount struct for the supplied Type
+func GetRABalanceLedger(ctx context.Context, bid, RAID int64) (GLAccount, error) {
 
 	var (
-		err error
-		m   NoteList
+		// err error
+		a   GLAccount
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return m, ErrSessionRequired
+			return a, ErrSessionRequired
 		}
 	}
 
 	var row *sql.Row
-	fields := []interface{}{nlid}
+	fields := []interface{}{bid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetNoteList)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRABalanceLedger)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetNoteList.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetRABalanceLedger.QueryRow(fields...)
 	}
-	err = ReadNoteList(row, &m)
-	if err != nil {
-		return m, err
+	return a, ReadGLAccount(row, &a)
+}*/
+
+/*// GetSecDepBalanceLedger returns the GLAccount struct for the supplied Type
+func GetSecDepBalanceLedger(ctx context.Context, bid, RAID int64) (GLAccount, error) {
+
+	var (
+		// err error
+		a   GLAccount
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return a, ErrSessionRequired
+		}
 	}
 
-	var rows *sql.Rows
-	fields = []interface{}{nlid}
+	var row *sql.Row
+	fields := []interface{}{bid, RAID}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetNoteListMembers)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetSecDepBalanceLedger)
 		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
+		row = stmt.QueryRow(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetNoteListMembers.Query(fields...)
+		row = RRdb.Prepstmt.GetSecDepBalanceLedger.QueryRow(fields...)
 	}
+	return a, ReadGLAccount(row, &a)
+}*/
 
-	if err != nil {
-		return m, err
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var nid int64
-
-		err = rows.Scan(&nid)
-		if err != nil {
-			return m, err
-		}
-		p, err := GetNoteAndChildNotes(ctx, nid)
-		if err != nil {
-			return m, err
-		}
-		m.N = append(m.N, p)
-	}
-
-	return m, rows.Err()
-}
-
-//=======================================================
-//  NOTE TYPE
-//=======================================================
-
-// GetNoteType reads a NoteType structure based on the supplied NoteType id
-func GetNoteType(ctx context.Context, ntid int64, t *NoteType) error {
+/*// GetDefaultLedgers loads the default GLAccount for the supplied Business bid
+func GetDefaultLedgers(ctx context.Context, bid int64) error {
 
 	var (
-	// err error
+		err error
 	)
 
 	// session... context
@@ -4093,60 +4804,44 @@ func GetNoteType(ctx context.Context, ntid int64, t *NoteType) error {
 		}
 	}
 
-	var row *sql.Row
-	fields := []interface{}{ntid}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetNoteType)
-		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
-	} else {
-		row = RRdb.Prepstmt.GetNoteType.QueryRow(fields...)
-	}
-	return ReadNoteType(row, t)
-}
-
-// getBusinessAllNoteTypes reads a NoteType structure based for all NoteTypes in the supplied bid
-func getBusinessAllNoteTypes(bid int64) ([]NoteType, error) {
-
-	var (
-		err error
-		m   []NoteType
-	)
 
 	var rows *sql.Rows
-	fields := []interface{}{bid}
-	/*if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetAllNoteTypes)
+	(bid)
+	fields := []interface{}{}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(Prepstmt.GetDefaultLedgers.Query)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetAllNoteTypes.Query(fields...)
-	}*/
-	rows, err = RRdb.Prepstmt.GetAllNoteTypes.Query(fields...)
+		rows, err = Prepstmt.GetDefaultLedgers.Query.Query(fields...)
+	}
 
 	if err != nil {
-		return m, err
+		return err
 	}
 	defer rows.Close()
-
 	for rows.Next() {
-		var p NoteType
-		err = ReadNoteTypes(rows, &p)
+		var r GLAccount
+		err = ReadGLAccounts(rows, &r)
 		if err != nil {
-			return m, err
+			return err
 		}
-		m = append(m, p)
+		RRdb.BizTypes[bid].DefaultAccts[r.Type] = &r
 	}
 
-	return m, rows.Err()
-}
+	return err
+}*/
 
-// GetAllNoteTypes reads a NoteType structure based for all NoteTypes in the supplied bid
-func GetAllNoteTypes(ctx context.Context, bid int64) ([]NoteType, error) {
+//=======================================================
+//  LEDGER ENTRY
+//=======================================================
+
+// getLedgerEntryArray returns a list of Ledger Entries for the supplied rows value
+func getLedgerEntryArray(ctx context.Context, rows *sql.Rows) ([]LedgerEntry, error) {
 
 	var (
-		// err error
-		m []NoteType
+		err error
+		m   []LedgerEntry
 	)
 
 	// session... context
@@ -4157,174 +4852,194 @@ func GetAllNoteTypes(ctx context.Context, bid int64) ([]NoteType, error) {
 		}
 	}
 
-	return getBusinessAllNoteTypes(bid)
-}
+	for rows.Next() {
+		var le LedgerEntry
+		err = ReadLedgerEntries(rows, &le)
+		if err != nil {
+			return m, err
+		}
+		m = append(m, le)
+	}
 
-//=======================================================
-//  P A Y M E N T   T Y P E S
-//=======================================================
+	return m, rows.Err()
+}
 
-// GetPaymentType reads a PaymentType structure based on the supplied bid and na
-func GetPaymentType(ctx context.Context, id int64, a *PaymentType) error {
+// GetLedgerEntriesInRange returns a list of Ledger Entries for the supplied Ledger during the supplied range
+func GetLedgerEntriesInRange(ctx context.Context, d1, d2 *time.Time, bid, lid int64) ([]LedgerEntry, error) {
 
 	var (
-	// err error
+		err error
+		m   []LedgerEntry
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return ErrSessionRequired
+			return m, ErrSessionRequired
 		}
 	}
 
-	var row *sql.Row
-	fields := []interface{}{id}
+	var rows *sql.Rows
+	fields := []interface{}{bid, lid, d1, d2}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetPaymentType)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetLedgerEntriesInRangeByLID)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetPaymentType.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetLedgerEntriesInRangeByLID.Query(fields...)
 	}
-	return ReadPaymentType(row, a)
+
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+
+	return getLedgerEntryArray(ctx, rows)
 }
 
-// GetPaymentTypeByName reads a PaymentType structure based on the supplied bid and na
-func GetPaymentTypeByName(ctx context.Context, bid int64, name string, a *PaymentType) error {
+// GetLedgerEntriesForRAID returns a list of Ledger Entries for the supplied RentalAgreement and Ledger
+func GetLedgerEntriesForRAID(ctx context.Context, d1, d2 *time.Time, raid, lid int64) ([]LedgerEntry, error) {
 
 	var (
-	// err error
+		err error
+		m   []LedgerEntry
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return ErrSessionRequired
+			return m, ErrSessionRequired
 		}
 	}
 
-	var row *sql.Row
-	fields := []interface{}{bid, name}
+	var rows *sql.Rows
+	fields := []interface{}{d1, d2, raid, lid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetPaymentTypeByName)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetLedgerEntriesForRAID)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetPaymentTypeByName.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetLedgerEntriesForRAID.Query(fields...)
 	}
-	return ReadPaymentType(row, a)
+
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+
+	return getLedgerEntryArray(ctx, rows)
 }
 
-// GetPaymentTypesByBusiness returns a slice of payment types indexed by the PMTID for the supplied Business
-func GetPaymentTypesByBusiness(ctx context.Context, bid int64) (map[int64]PaymentType, error) {
+// GetLedgerEntriesForRentable returns a list of Ledger Entries for the supplied Rentable (rid) and Ledger (lid)
+func GetLedgerEntriesForRentable(ctx context.Context, d1, d2 *time.Time, rid, lid int64) ([]LedgerEntry, error) {
 
 	var (
 		err error
-		t   = make(map[int64]PaymentType)
+		m   []LedgerEntry
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return t, ErrSessionRequired
+			return m, ErrSessionRequired
 		}
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{bid}
+	fields := []interface{}{d1, d2, rid, lid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetPaymentTypesByBusiness)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetLedgerEntriesForRentable)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetPaymentTypesByBusiness.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetLedgerEntriesForRentable.Query(fields...)
 	}
 
 	if err != nil {
-		return t, err
+		return m, err
 	}
 	defer rows.Close()
 
-	for rows.Next() {
-		var a PaymentType
-		err = ReadPaymentTypes(rows, &a)
-		if err != nil {
-			return t, err
-		}
-		t[a.PMTID] = a
-	}
-
-	return t, rows.Err()
+	return getLedgerEntryArray(ctx, rows)
 }
 
-//=======================================================
-//  RATE PLAN
-//=======================================================
-
-// GetRatePlan reads a RatePlan structure based on the supplied RatePlan id
-func GetRatePlan(ctx context.Context, id int64, a *RatePlan) error {
+// GetAllLedgerEntriesForRAID returns a list of Ledger Entries for the supplied RentalAgreement
+func GetAllLedgerEntriesForRAID(ctx context.Context, d1, d2 *time.Time, raid int64) ([]LedgerEntry, error) {
 
 	var (
-	// err error
+		err error
+		m   []LedgerEntry
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return ErrSessionRequired
+			return m, ErrSessionRequired
 		}
 	}
 
-	var row *sql.Row
-	fields := []interface{}{id}
+	var rows *sql.Rows
+	fields := []interface{}{d1, d2, raid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRatePlan)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAllLedgerEntriesForRAID)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRatePlan.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetAllLedgerEntriesForRAID.Query(fields...)
 	}
-	return ReadRatePlan(row, a)
+
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+
+	return getLedgerEntryArray(ctx, rows)
 }
 
-// GetRatePlanByName reads a RatePlan structure based on the supplied RatePlan id
-func GetRatePlanByName(ctx context.Context, id int64, s string, a *RatePlan) error {
+// GetAllLedgerEntriesForRID returns a list of Ledger Entries for the supplied Rentable ID
+func GetAllLedgerEntriesForRID(ctx context.Context, d1, d2 *time.Time, rid int64) ([]LedgerEntry, error) {
 
 	var (
-	// err error
+		err error
+		m   []LedgerEntry
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return ErrSessionRequired
+			return m, ErrSessionRequired
 		}
 	}
 
-	var row *sql.Row
-	fields := []interface{}{id, s}
+	var rows *sql.Rows
+	fields := []interface{}{d1, d2, rid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRatePlanByName)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAllLedgerEntriesForRID)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRatePlanByName.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetAllLedgerEntriesForRID.Query(fields...)
 	}
-	return ReadRatePlan(row, a)
+
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+
+	return getLedgerEntryArray(ctx, rows)
 }
 
-// GetAllRatePlans reads all RatePlan structures based on the supplied bid
-func GetAllRatePlans(ctx context.Context, id int64) ([]RatePlan, error) {
+// GetAllLedgerEntriesInRange returns a list of Ledger Entries for the supplied business and time period
+func GetAllLedgerEntriesInRange(ctx context.Context, bid int64, d1, d2 *time.Time) ([]LedgerEntry, error) {
 
 	var (
 		err error
-		m   []RatePlan
+		m   []LedgerEntry
 	)
 
 	// session... context
@@ -4336,13 +5051,13 @@ func GetAllRatePlans(ctx context.Context, id int64) ([]RatePlan, error) {
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{id}
+	fields := []interface{}{bid, d1, d2}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetAllRatePlans)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAllLedgerEntriesInRange)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetAllRatePlans.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetAllLedgerEntriesInRange.Query(fields...)
 	}
 
 	if err != nil {
@@ -4350,259 +5065,156 @@ func GetAllRatePlans(ctx context.Context, id int64) ([]RatePlan, error) {
 	}
 	defer rows.Close()
 
-	for rows.Next() {
-		var p RatePlan
-		err = ReadRatePlans(rows, &p)
-		if err != nil {
-			return m, err
-		}
-		m = append(m, p)
-	}
-
-	return m, rows.Err()
-}
-
-// GetRatePlanRef reads a RatePlanRef structure based on the supplied RatePlanRef id
-func GetRatePlanRef(ctx context.Context, id int64, a *RatePlanRef) error {
-
-	var (
-	// err error
-	)
-
-	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return ErrSessionRequired
-		}
-	}
-
-	var row *sql.Row
-	fields := []interface{}{id}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRatePlanRef)
-		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
-	} else {
-		row = RRdb.Prepstmt.GetRatePlanRef.QueryRow(fields...)
-	}
-	return ReadRatePlanRef(row, a)
+	return getLedgerEntryArray(ctx, rows)
 }
 
-// GetRatePlanRefFull reads a RatePlanRef structure based on the supplied RatePlanRef id and
-// pulls in all the RTRate and SPRate structure arrays
-func GetRatePlanRefFull(ctx context.Context, id int64, a *RatePlanRef) error {
+/*// GetLedgerEntriesInRange returns a list of Ledger Entries for the supplied business and time period
+func GetLedgerEntriesInRange(ctx context.Context, bid, lid, raid int64, d1, d2 *time.Time) ([]LedgerEntry, error) {
 
 	var (
 		err error
+		m   []LedgerEntry
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return ErrSessionRequired
-		}
-	}
-
-	if a.RPRID == 0 {
-		var row *sql.Row
-		fields := []interface{}{id}
-		if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-			stmt := tx.Stmt(RRdb.Prepstmt.GetRatePlanRef)
-			defer stmt.Close()
-			row = stmt.QueryRow(fields...)
-		} else {
-			row = RRdb.Prepstmt.GetRatePlanRef.QueryRow(fields...)
-		}
-		err = ReadRatePlanRef(row, a)
-		if err != nil {
-			return err
+			return m, ErrSessionRequired
 		}
 	}
 
-	// =====================================
-	// LOAD ALL Rate Plan RT RATES
-	// =====================================
 
 	var rows *sql.Rows
-	fields := []interface{}{id}
+	(bid, lid, raid, d1, d2)
+	fields := []interface{}{}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetAllRatePlanRefRTRates)
+		stmt := tx.Stmt(Prepstmt.GetLedgerEntriesInRange.Query)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetAllRatePlanRefRTRates.Query(fields...)
+		rows, err = Prepstmt.GetLedgerEntriesInRange.Query.Query(fields...)
 	}
 
 	if err != nil {
-		Ulog("GetRatePlanRefFull:   GetAllRatePlanRefRTRates - error = %s\n", err.Error())
-		return err
+		return m, err
 	}
+	defer rows.Close()
 
-	for rows.Next() {
-		var p RatePlanRefRTRate
-		err = ReadRatePlanRefRTRates(rows, &p)
-		if err != nil {
-			return err
-		}
-		a.RT = append(a.RT, p)
-	}
+	return getLedgerEntryArray(ctx, rows)
+}*/
 
-	err = rows.Err()
-	if err != nil {
-		return err
-	}
-	rows.Close() // REMEMBER TO CLOSE IT HERE, Before re-assinging something new in rows itself
+//=======================================================
+//  MAINTENANCE REQUEST
+//=======================================================
 
-	// =====================================
-	// LOAD ALL Rate Plan SPECIALTY RATES
-	// =====================================
+// GetMaintenanceRequest reads a MaintenanceRequest structure based on the supplied MRID
+func GetMaintenanceRequest(ctx context.Context, id int64) (MaintenanceRequest, error) {
+	var a MaintenanceRequest
 
-	// var rows *sql.Rows
-	fields = []interface{}{a.RPRID, a.RPID}
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{id}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetAllRatePlanRefSPRates)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetMaintenanceRequest)
 		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
+		row = stmt.QueryRow(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetAllRatePlanRefSPRates.Query(fields...)
-	}
-
-	if err != nil {
-		Ulog("GetRatePlanRefFull: GetAllRatePlanRefSPRates - error = %s\n", err.Error())
-		return err
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var p RatePlanRefSPRate
-		err = ReadRatePlanRefSPRates(rows, &p)
-		if err != nil {
-			return err
-		}
-		a.SP = append(a.SP, p)
+		row = RRdb.Prepstmt.GetMaintenanceRequest.QueryRow(fields...)
 	}
-	return rows.Err()
+	return a, ReadMaintenanceRequest(row, &a)
 }
 
-// GetRatePlanRefsInRange reads a RatePlanRef structure based on the supplied RatePlan id and the date.
-func GetRatePlanRefsInRange(ctx context.Context, id int64, d1, d2 *time.Time) ([]RatePlanRef, error) {
-
+// GetMaintenanceRequests returns the MaintenanceRequests of business bid
+// submitted in d1 - d2
+func GetMaintenanceRequests(ctx context.Context, bid int64, d1 *time.Time, d2 *time.Time) ([]MaintenanceRequest, error) {
 	var (
 		err error
-		m   []RatePlanRef
+		t   []MaintenanceRequest
 	)
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return m, ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{id, d1, d2}
+	fields := []interface{}{bid, d1, d2}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRatePlanRefsInRange)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetMaintenanceRequests)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetRatePlanRefsInRange.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetMaintenanceRequests.Query(fields...)
 	}
 
 	if err != nil {
-		Ulog("GetRatePlanRefsInRange: error = %s\n", err.Error())
-		return m, err
+		return t, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var a RatePlanRef
-		err = ReadRatePlanRefs(rows, &a)
+		var a MaintenanceRequest
+		err = ReadMaintenanceRequests(rows, &a)
 		if err != nil {
-			return m, err
+			return t, err
 		}
-		m = append(m, a)
+		t = append(t, a)
 	}
 
-	return m, rows.Err()
+	return t, rows.Err()
 }
 
-// GetAllRatePlanRefsInRange reads all RatePlanRef structure based on the supplied date range
-func GetAllRatePlanRefsInRange(ctx context.Context, d1, d2 *time.Time) ([]RatePlanRef, error) {
-
+// GetMaintenanceRequestsByRAID returns the MaintenanceRequests submitted for
+// Rental Agreement raid, most recent first
+func GetMaintenanceRequestsByRAID(ctx context.Context, raid int64) ([]MaintenanceRequest, error) {
 	var (
 		err error
-		m   []RatePlanRef
+		t   []MaintenanceRequest
 	)
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return m, ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{d1, d2}
+	fields := []interface{}{raid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetAllRatePlanRefsInRange)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetMaintenanceRequestsByRAID)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetAllRatePlanRefsInRange.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetMaintenanceRequestsByRAID.Query(fields...)
 	}
 
 	if err != nil {
-		Ulog("GetAllRatePlanRefsInRange: error = %s\n", err.Error())
-		return m, err
+		return t, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var a RatePlanRef
-		err = ReadRatePlanRefs(rows, &a)
+		var a MaintenanceRequest
+		err = ReadMaintenanceRequests(rows, &a)
 		if err != nil {
-			return m, err
+			return t, err
 		}
-		m = append(m, a)
+		t = append(t, a)
 	}
 
-	return m, rows.Err()
+	return t, rows.Err()
 }
 
-// GetRatePlanRefRTRate reads the RatePlanRefRTRate struct for the supplied rprid and rtid
-func GetRatePlanRefRTRate(ctx context.Context, rprid, rtid int64, a *RatePlanRefRTRate) error {
-
-	var (
-	// err error
-	)
-
-	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return ErrSessionRequired
-		}
-	}
-
-	var row *sql.Row
-	fields := []interface{}{rprid, rtid}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRatePlanRefRTRate)
-		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
-	} else {
-		row = RRdb.Prepstmt.GetRatePlanRefRTRate.QueryRow(fields...)
-	}
-	return ReadRatePlanRefRTRate(row, a)
-}
+//=======================================================
+//  NOTES
+//=======================================================
 
-// GetRatePlanRefSPRate reads the RatePlanRefSPRate struct for the supplied rprid and rtid
-func GetRatePlanRefSPRate(ctx context.Context, rprid, rtid int64, a *RatePlanRefSPRate) error {
+// GetNote reads a Note structure based on the supplied Note id
+func GetNote(ctx context.Context, tid int64, t *Note) error {
 
 	var (
 	// err error
@@ -4617,180 +5229,254 @@ func GetRatePlanRefSPRate(ctx context.Context, rprid, rtid int64, a *RatePlanRef
 	}
 
 	var row *sql.Row
-	fields := []interface{}{rprid, rtid}
+	fields := []interface{}{tid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRatePlanRefSPRate)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetNote)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRatePlanRefSPRate.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetNote.QueryRow(fields...)
 	}
-	return ReadRatePlanRefSPRate(row, a)
+	return ReadNote(row, t)
 }
 
-// GetAllRatePlanRefSPRates reads all RatePlanRefSPRate structures based on the supplied RatePlan id and the date.
-func GetAllRatePlanRefSPRates(ctx context.Context, rprid, rtid int64) ([]RatePlanRefSPRate, error) {
+// GetNoteAndChildNotes reads a Note structure based on the supplied Note id, then it reads all its child notes, organizes them by Date
+// and returns them in an array
+func GetNoteAndChildNotes(ctx context.Context, nid int64) (Note, error) {
 
 	var (
 		err error
-		m   []RatePlanRefSPRate
+		n   Note
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return m, ErrSessionRequired
+			return n, ErrSessionRequired
 		}
 	}
 
+	err = GetNote(ctx, nid, &n)
+	if err != nil {
+		return n, err
+	}
+
 	var rows *sql.Rows
-	fields := []interface{}{rprid, rtid}
+	fields := []interface{}{nid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetAllRatePlanRefSPRates)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetNoteAndChildNotes)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetAllRatePlanRefSPRates.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetNoteAndChildNotes.Query(fields...)
 	}
 
 	if err != nil {
-		Ulog("GetAllRatePlanRefSPRates: error = %s\n", err.Error())
-		return m, err
+		return n, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var a RatePlanRefSPRate
-		err = ReadRatePlanRefSPRates(rows, &a)
+		var p Note
+		err = ReadNotes(rows, &p)
 		if err != nil {
-			return m, err
+			return n, err
 		}
-		m = append(m, a)
+		n.CN = append(n.CN, p)
 	}
 
-	return m, rows.Err()
+	return n, rows.Err()
 }
 
 //=======================================================
-//  RECEIPT ALLOCATION
+//  NOTELIST
 //=======================================================
 
-// GetReceipt returns a Receipt structure for the supplied RCPTID
-func GetReceipt(ctx context.Context, rcptid int64) (Receipt, error) {
+// GetNoteList reads a NoteList structure based on the supplied NoteList id
+func GetNoteList(ctx context.Context, nlid int64) (NoteList, error) {
 
 	var (
 		err error
-		r   Receipt
+		m   NoteList
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return r, ErrSessionRequired
+			return m, ErrSessionRequired
 		}
 	}
 
-	r, err = GetReceiptNoAllocations(ctx, rcptid)
-	if err != nil {
-		return r, err
+	var row *sql.Row
+	fields := []interface{}{nlid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetNoteList)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetNoteList.QueryRow(fields...)
+	}
+	err = ReadNoteList(row, &m)
+	if err != nil {
+		return m, err
 	}
 
-	return r, GetReceiptAllocations(ctx, rcptid, &r)
+	var rows *sql.Rows
+	fields = []interface{}{nlid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetNoteListMembers)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetNoteListMembers.Query(fields...)
+	}
+
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var nid int64
+
+		err = rows.Scan(&nid)
+		if err != nil {
+			return m, err
+		}
+		p, err := GetNoteAndChildNotes(ctx, nid)
+		if err != nil {
+			return m, err
+		}
+		m.N = append(m.N, p)
+	}
+
+	return m, rows.Err()
 }
 
-// GetReceiptAllocation returns a ReceiptAllocation structure for the supplied RCPTID
-func GetReceiptAllocation(ctx context.Context, id int64) (ReceiptAllocation, error) {
+//=======================================================
+//  NOTE TYPE
+//=======================================================
+
+// GetNoteType reads a NoteType structure based on the supplied NoteType id
+func GetNoteType(ctx context.Context, ntid int64, t *NoteType) error {
 
 	var (
-		// err error
-		r ReceiptAllocation
+	// err error
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return r, ErrSessionRequired
+			return ErrSessionRequired
 		}
 	}
 
 	var row *sql.Row
-	fields := []interface{}{id}
+	fields := []interface{}{ntid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetReceiptAllocation)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetNoteType)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetReceiptAllocation.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetNoteType.QueryRow(fields...)
 	}
-	return r, ReadReceiptAllocation(row, &r)
+	return ReadNoteType(row, t)
 }
 
-// GetReceiptNoAllocations returns a Receipt structure for the supplied RCPTID.
-// It does not get the receipt allocations
-func GetReceiptNoAllocations(ctx context.Context, rcptid int64) (Receipt, error) {
+// getBusinessAllNoteTypes reads a NoteType structure based for all NoteTypes in the supplied bid
+func getBusinessAllNoteTypes(bid int64) ([]NoteType, error) {
+
+	var (
+		err error
+		m   []NoteType
+	)
+
+	var rows *sql.Rows
+	fields := []interface{}{bid}
+	/*if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAllNoteTypes)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetAllNoteTypes.Query(fields...)
+	}*/
+	rows, err = RRdb.Prepstmt.GetAllNoteTypes.Query(fields...)
+
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p NoteType
+		err = ReadNoteTypes(rows, &p)
+		if err != nil {
+			return m, err
+		}
+		m = append(m, p)
+	}
+
+	return m, rows.Err()
+}
+
+// GetAllNoteTypes reads a NoteType structure based for all NoteTypes in the supplied bid
+func GetAllNoteTypes(ctx context.Context, bid int64) ([]NoteType, error) {
 
 	var (
 		// err error
-		r Receipt
+		m []NoteType
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return r, ErrSessionRequired
+			return m, ErrSessionRequired
 		}
 	}
 
-	var row *sql.Row
-	fields := []interface{}{rcptid}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetReceipt)
-		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
-	} else {
-		row = RRdb.Prepstmt.GetReceipt.QueryRow(fields...)
-	}
-	return r, ReadReceipt(row, &r)
+	return getBusinessAllNoteTypes(bid)
 }
 
-// GetReceiptDuplicate returns a Receipt structure for the supplied RCPTID
-func GetReceiptDuplicate(ctx context.Context, dt *time.Time, amt float64, docno string) (Receipt, error) {
+//=======================================================
+//  P A Y M E N T   T Y P E S
+//=======================================================
+
+// GetPaymentType reads a PaymentType structure based on the supplied bid and na
+func GetPaymentType(ctx context.Context, id int64, a *PaymentType) error {
 
 	var (
-		// err error
-		r Receipt
+	// err error
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return r, ErrSessionRequired
+			return ErrSessionRequired
 		}
 	}
 
 	var row *sql.Row
-	fields := []interface{}{dt, amt, docno}
+	fields := []interface{}{id}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetReceiptDuplicate)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetPaymentType)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetReceiptDuplicate.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetPaymentType.QueryRow(fields...)
 	}
-	return r, ReadReceipt(row, &r)
+	return ReadPaymentType(row, a)
 }
 
-// GetReceiptAllocations loads all Receipt allocations associated with the supplied Receipt id into
-// the RA array within a Receipt structure
-func GetReceiptAllocations(ctx context.Context, rcptid int64, r *Receipt) error {
+// GetPaymentTypeByName reads a PaymentType structure based on the supplied bid and na
+func GetPaymentTypeByName(ctx context.Context, bid int64, name string, a *PaymentType) error {
 
 	var (
-		err error
+	// err error
 	)
 
 	// session... context
@@ -4801,40 +5487,24 @@ func GetReceiptAllocations(ctx context.Context, rcptid int64, r *Receipt) error
 		}
 	}
 
-	var rows *sql.Rows
-	fields := []interface{}{rcptid}
+	var row *sql.Row
+	fields := []interface{}{bid, name}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetReceiptAllocations)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetPaymentTypeByName)
 		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
+		row = stmt.QueryRow(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetReceiptAllocations.Query(fields...)
-	}
-
-	if err != nil {
-		return err
-	}
-	defer rows.Close()
-
-	r.RA = make([]ReceiptAllocation, 0)
-	for rows.Next() {
-		var a ReceiptAllocation
-		err = ReadReceiptAllocations(rows, &a)
-		if err != nil {
-			return err
-		}
-		r.RA = append(r.RA, a)
+		row = RRdb.Prepstmt.GetPaymentTypeByName.QueryRow(fields...)
 	}
-
-	return rows.Err()
+	return ReadPaymentType(row, a)
 }
 
-// GetReceipts for the supplied Business (bid) in date range [d1 - d2)
-func GetReceipts(ctx context.Context, bid int64, d1, d2 *time.Time) ([]Receipt, error) {
+// GetPaymentTypesByBusiness returns a slice of payment types indexed by the PMTID for the supplied Business
+func GetPaymentTypesByBusiness(ctx context.Context, bid int64) (map[int64]PaymentType, error) {
 
 	var (
 		err error
-		t   []Receipt
+		t   = make(map[int64]PaymentType)
 	)
 
 	// session... context
@@ -4846,13 +5516,13 @@ func GetReceipts(ctx context.Context, bid int64, d1, d2 *time.Time) ([]Receipt,
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{bid, d1, d2}
+	fields := []interface{}{bid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetReceiptsInDateRange)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetPaymentTypesByBusiness)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetReceiptsInDateRange.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetPaymentTypesByBusiness.Query(fields...)
 	}
 
 	if err != nil {
@@ -4861,289 +5531,332 @@ func GetReceipts(ctx context.Context, bid int64, d1, d2 *time.Time) ([]Receipt,
 	defer rows.Close()
 
 	for rows.Next() {
-		var r Receipt
-		err = ReadReceipts(rows, &r)
-		if err != nil {
-			return t, err
-		}
-
-		r.RA = make([]ReceiptAllocation, 0)
-		err = GetReceiptAllocations(ctx, r.RCPTID, &r)
+		var a PaymentType
+		err = ReadPaymentTypes(rows, &a)
 		if err != nil {
 			return t, err
 		}
-		t = append(t, r)
+		t[a.PMTID] = a
 	}
 
 	return t, rows.Err()
 }
 
-// getReceiptAllocationList for the supplied rows variable
-func getReceiptAllocationList(ctx context.Context, rows *sql.Rows) ([]ReceiptAllocation, error) {
+//=======================================================
+//  RATE PLAN
+//=======================================================
+
+// GetRatePlan reads a RatePlan structure based on the supplied RatePlan id
+func GetRatePlan(ctx context.Context, id int64, a *RatePlan) error {
 
 	var (
-		err error
-		t   []ReceiptAllocation
+	// err error
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return t, ErrSessionRequired
+			return ErrSessionRequired
 		}
 	}
 
-	defer rows.Close()
-
-	for rows.Next() {
-		var r ReceiptAllocation
-		err = ReadReceiptAllocations(rows, &r)
-		if err != nil {
-			return t, err
-		}
-		t = append(t, r)
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRatePlan)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetRatePlan.QueryRow(fields...)
 	}
-
-	return t, rows.Err()
+	return ReadRatePlan(row, a)
 }
 
-// GetASMReceiptAllocationsInRAIDDateRange for the supplied RentalAgreement in date range [d1 - d2).
-// To do this we select all the ReceiptAllocations that occurred during d1-d2 that involved
-// raid.
-func GetASMReceiptAllocationsInRAIDDateRange(ctx context.Context, raid int64, d1, d2 *time.Time) ([]ReceiptAllocation, error) {
+// GetRatePlanByName reads a RatePlan structure based on the supplied RatePlan id
+func GetRatePlanByName(ctx context.Context, id int64, s string, a *RatePlan) error {
 
 	var (
-		err error
-		t   []ReceiptAllocation
+	// err error
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return t, ErrSessionRequired
+			return ErrSessionRequired
 		}
 	}
 
-	var rows *sql.Rows
-	fields := []interface{}{raid, d1, d2}
+	var row *sql.Row
+	fields := []interface{}{id, s}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetASMReceiptAllocationsInRAIDDateRange)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRatePlanByName)
 		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
+		row = stmt.QueryRow(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetASMReceiptAllocationsInRAIDDateRange.Query(fields...)
+		row = RRdb.Prepstmt.GetRatePlanByName.QueryRow(fields...)
 	}
-
-	if err != nil {
-		return t, err
-	}
-
-	return getReceiptAllocationList(ctx, rows)
+	return ReadRatePlan(row, a)
 }
 
-// GetReceiptAllocationsByASMID returns any payment allocation on targeted at the supplied ASMID.
-// This call is used primarily to determine how much payment is left to make on a partially paid
-// assessment.
-func GetReceiptAllocationsByASMID(ctx context.Context, bid, asmid int64) ([]ReceiptAllocation, error) {
+// GetAllRatePlans reads all RatePlan structures based on the supplied bid
+func GetAllRatePlans(ctx context.Context, id int64) ([]RatePlan, error) {
 
 	var (
 		err error
-		t   []ReceiptAllocation
+		m   []RatePlan
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return t, ErrSessionRequired
+			return m, ErrSessionRequired
 		}
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{bid, asmid}
+	fields := []interface{}{id}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetReceiptAllocationsByASMID)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAllRatePlans)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetReceiptAllocationsByASMID.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetAllRatePlans.Query(fields...)
 	}
 
 	if err != nil {
-		return t, err
+		return m, err
 	}
-	return getReceiptAllocationList(ctx, rows)
+	defer rows.Close()
+
+	for rows.Next() {
+		var p RatePlan
+		err = ReadRatePlans(rows, &p)
+		if err != nil {
+			return m, err
+		}
+		m = append(m, p)
+	}
+
+	return m, rows.Err()
 }
 
-// GetReceiptAllocationsThroughDate selects the ReceiptAllocations associated with receipt id
-// and that happened on or before the supplied date
-// @params
-//	 id = RCPTID of desired allocations
-//   dt = date for all allocations to be on or prior to
-// @returns  []ReceiptAllocation
-func GetReceiptAllocationsThroughDate(ctx context.Context, id int64, dt *time.Time) ([]ReceiptAllocation, error) {
+// GetRatePlanRef reads a RatePlanRef structure based on the supplied RatePlanRef id
+func GetRatePlanRef(ctx context.Context, id int64, a *RatePlanRef) error {
 
 	var (
-		err error
-		t   []ReceiptAllocation
+	// err error
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return t, ErrSessionRequired
+			return ErrSessionRequired
 		}
 	}
 
-	var rows *sql.Rows
-	fields := []interface{}{id, dt}
+	var row *sql.Row
+	fields := []interface{}{id}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetReceiptAllocationsThroughDate)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRatePlanRef)
 		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
+		row = stmt.QueryRow(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetReceiptAllocationsThroughDate.Query(fields...)
-	}
-
-	if err != nil {
-		return t, err
+		row = RRdb.Prepstmt.GetRatePlanRef.QueryRow(fields...)
 	}
-	return getReceiptAllocationList(ctx, rows)
+	return ReadRatePlanRef(row, a)
 }
 
-// GetReceiptAllocationAmountsOnDate returns the amount of unallocated funds in id on the
-// supplied date
-// @params
-//	 id = RCPTID of the receipt in question
-//   dt = date on which the unallocated amount is desired
-// @returns  float64 of:
-//   receipt amount
-//   amount allocated as of dt
-//   amount unallocated as of dt
-func GetReceiptAllocationAmountsOnDate(ctx context.Context, id int64, dt *time.Time) (float64, float64, float64, error) {
+// GetRatePlanRefFull reads a RatePlanRef structure based on the supplied RatePlanRef id and
+// pulls in all the RTRate and SPRate structure arrays
+func GetRatePlanRefFull(ctx context.Context, id int64, a *RatePlanRef) error {
 
 	var (
-		err     error
-		amt     float64
-		alloc   float64
-		unalloc float64
+		err error
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return amt, alloc, unalloc, ErrSessionRequired
+			return ErrSessionRequired
 		}
 	}
 
-	// get receipt
-	rcpt, err := GetReceipt(ctx, id)
+	if a.RPRID == 0 {
+		var row *sql.Row
+		fields := []interface{}{id}
+		if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+			stmt := tx.Stmt(RRdb.Prepstmt.GetRatePlanRef)
+			defer stmt.Close()
+			row = stmt.QueryRow(fields...)
+		} else {
+			row = RRdb.Prepstmt.GetRatePlanRef.QueryRow(fields...)
+		}
+		err = ReadRatePlanRef(row, a)
+		if err != nil {
+			return err
+		}
+	}
+
+	// =====================================
+	// LOAD ALL Rate Plan RT RATES
+	// =====================================
+
+	var rows *sql.Rows
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAllRatePlanRefRTRates)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetAllRatePlanRefRTRates.Query(fields...)
+	}
+
 	if err != nil {
-		return amt, alloc, unalloc, err
+		Ulog("GetRatePlanRefFull:   GetAllRatePlanRefRTRates - error = %s\n", err.Error())
+		return err
 	}
-	amt = rcpt.Amount
-	unalloc = rcpt.Amount
 
-	m, err := GetReceiptAllocationsThroughDate(ctx, id, dt)
+	for rows.Next() {
+		var p RatePlanRefRTRate
+		err = ReadRatePlanRefRTRates(rows, &p)
+		if err != nil {
+			return err
+		}
+		a.RT = append(a.RT, p)
+	}
+
+	err = rows.Err()
 	if err != nil {
-		return amt, alloc, unalloc, err
+		return err
 	}
+	rows.Close() // REMEMBER TO CLOSE IT HERE, Before re-assinging something new in rows itself
 
-	for i := 0; i < len(m); i++ {
-		unalloc -= m[i].Amount
-		alloc += m[i].Amount
+	// =====================================
+	// LOAD ALL Rate Plan SPECIALTY RATES
+	// =====================================
+
+	// var rows *sql.Rows
+	fields = []interface{}{a.RPRID, a.RPID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAllRatePlanRefSPRates)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetAllRatePlanRefSPRates.Query(fields...)
 	}
-	return amt, alloc, unalloc, err
+
+	if err != nil {
+		Ulog("GetRatePlanRefFull: GetAllRatePlanRefSPRates - error = %s\n", err.Error())
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p RatePlanRefSPRate
+		err = ReadRatePlanRefSPRates(rows, &p)
+		if err != nil {
+			return err
+		}
+		a.SP = append(a.SP, p)
+	}
+	return rows.Err()
 }
 
-// GetUnallocatedReceiptsByPayor returns the receipts paid by the supplied payor tcid that
-// have not yet been fully allocated.
-func GetUnallocatedReceiptsByPayor(ctx context.Context, bid, tcid int64) ([]Receipt, error) {
+// GetRatePlanRefsInRange reads a RatePlanRef structure based on the supplied RatePlan id and the date.
+func GetRatePlanRefsInRange(ctx context.Context, id int64, d1, d2 *time.Time) ([]RatePlanRef, error) {
 
 	var (
 		err error
-		t   []Receipt
+		m   []RatePlanRef
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return t, ErrSessionRequired
+			return m, ErrSessionRequired
 		}
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{bid, tcid}
+	fields := []interface{}{id, d1, d2}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetUnallocatedReceiptsByPayor)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRatePlanRefsInRange)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetUnallocatedReceiptsByPayor.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetRatePlanRefsInRange.Query(fields...)
 	}
 
 	if err != nil {
-		return t, err
+		Ulog("GetRatePlanRefsInRange: error = %s\n", err.Error())
+		return m, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var r Receipt
-		err = ReadReceipts(rows, &r)
-		if err != nil {
-			return t, err
-		}
-
-		r.RA = make([]ReceiptAllocation, 0) // the receipt may be partially allocated
-		err = GetReceiptAllocations(ctx, r.RCPTID, &r)
+		var a RatePlanRef
+		err = ReadRatePlanRefs(rows, &a)
 		if err != nil {
-			return t, err
+			return m, err
 		}
-		t = append(t, r)
+		m = append(m, a)
 	}
 
-	return t, rows.Err()
+	return m, rows.Err()
 }
 
-// GetPayorUnallocatedReceiptsCount returns a count of unallocated receipts for the supplied bid & tcid
-func GetPayorUnallocatedReceiptsCount(ctx context.Context, bid, tcid int64) (int, error) {
+// GetAllRatePlanRefsInRange reads all RatePlanRef structure based on the supplied date range
+func GetAllRatePlanRefsInRange(ctx context.Context, d1, d2 *time.Time) ([]RatePlanRef, error) {
 
 	var (
-		// err   error
-		count int
+		err error
+		m   []RatePlanRef
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return count, ErrSessionRequired
+			return m, ErrSessionRequired
 		}
 	}
 
-	var row *sql.Row
-	fields := []interface{}{bid, tcid}
+	var rows *sql.Rows
+	fields := []interface{}{d1, d2}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetPayorUnallocatedReceiptsCount)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAllRatePlanRefsInRange)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetPayorUnallocatedReceiptsCount.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetAllRatePlanRefsInRange.Query(fields...)
 	}
-	return count, row.Scan(&count)
-}
 
-//=======================================================
-//  R E N T A B L E
-//=======================================================
+	if err != nil {
+		Ulog("GetAllRatePlanRefsInRange: error = %s\n", err.Error())
+		return m, err
+	}
+	defer rows.Close()
 
-// GetRentableByID reads a Rentable structure based on the supplied Rentable id
-func GetRentableByID(ctx context.Context, rid int64, r *Rentable) error {
+	for rows.Next() {
+		var a RatePlanRef
+		err = ReadRatePlanRefs(rows, &a)
+		if err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+
+	return m, rows.Err()
+}
+
+// GetRatePlanRefRTRate reads the RatePlanRefRTRate struct for the supplied rprid and rtid
+func GetRatePlanRefRTRate(ctx context.Context, rprid, rtid int64, a *RatePlanRefRTRate) error {
 
 	var (
 	// err error
@@ -5158,74 +5871,50 @@ func GetRentableByID(ctx context.Context, rid int64, r *Rentable) error {
 	}
 
 	var row *sql.Row
-	fields := []interface{}{rid}
+	fields := []interface{}{rprid, rtid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentable)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRatePlanRefRTRate)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRentable.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetRatePlanRefRTRate.QueryRow(fields...)
 	}
-	return ReadRentable(row, r)
-}
-
-// GetRentable reads and returns a Rentable structure based on the supplied Rentable id
-func GetRentable(ctx context.Context, rid int64) (Rentable, error) {
-
-	var (
-		// err error
-		r Rentable
-	)
-
-	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return r, ErrSessionRequired
-		}
-	}
-
-	return r, GetRentableByID(ctx, rid, &r)
+	return ReadRatePlanRefRTRate(row, a)
 }
 
-// GetRentableByName reads and returns a Rentable structure based on the supplied Rentable id
-func GetRentableByName(ctx context.Context, name string, bid int64) (Rentable, error) {
+// GetRatePlanRefSPRate reads the RatePlanRefSPRate struct for the supplied rprid and rtid
+func GetRatePlanRefSPRate(ctx context.Context, rprid, rtid int64, a *RatePlanRefSPRate) error {
 
 	var (
-		// err error
-		r Rentable
+	// err error
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return r, ErrSessionRequired
+			return ErrSessionRequired
 		}
 	}
 
 	var row *sql.Row
-	fields := []interface{}{name, bid}
+	fields := []interface{}{rprid, rtid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableByName)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRatePlanRefSPRate)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRentableByName.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetRatePlanRefSPRate.QueryRow(fields...)
 	}
-	return r, ReadRentable(row, &r)
+	return ReadRatePlanRefSPRate(row, a)
 }
 
-// GetRentableTypeDown returns the values needed for typedown controls:
-// input:   bid - business
-//            s - string or substring to search for
-//        limit - return no more than this many matches
-// return a slice of RentableTypeDowns and an error.
-func GetRentableTypeDown(ctx context.Context, bid int64, s string, limit int) ([]RentableTypeDown, error) {
+// GetAllRatePlanRefSPRates reads all RatePlanRefSPRate structures based on the supplied RatePlan id and the date.
+func GetAllRatePlanRefSPRates(ctx context.Context, rprid, rtid int64) ([]RatePlanRefSPRate, error) {
 
 	var (
 		err error
-		m   []RentableTypeDown
+		m   []RatePlanRefSPRate
 	)
 
 	// session... context
@@ -5236,68 +5925,68 @@ func GetRentableTypeDown(ctx context.Context, bid int64, s string, limit int) ([
 		}
 	}
 
-	s = "%" + s + "%"
-
 	var rows *sql.Rows
-	fields := []interface{}{bid, s, limit}
+	fields := []interface{}{rprid, rtid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableTypeDown)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAllRatePlanRefSPRates)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetRentableTypeDown.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetAllRatePlanRefSPRates.Query(fields...)
 	}
 
 	if err != nil {
+		Ulog("GetAllRatePlanRefSPRates: error = %s\n", err.Error())
 		return m, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var t RentableTypeDown
-		err = ReadRentableTypeDown(rows, &t)
-		t.Recid = t.RID
+		var a RatePlanRefSPRate
+		err = ReadRatePlanRefSPRates(rows, &a)
 		if err != nil {
 			return m, err
 		}
-		m = append(m, t)
+		m = append(m, a)
 	}
 
 	return m, rows.Err()
 }
 
-// GetXRentable reads an XRentable structure based on the RID.
-func GetXRentable(ctx context.Context, rid int64, x *XRentable) error {
+//=======================================================
+//  RECEIPT ALLOCATION
+//=======================================================
+
+// GetReceipt returns a Receipt structure for the supplied RCPTID
+func GetReceipt(ctx context.Context, rcptid int64) (Receipt, error) {
 
 	var (
 		err error
+		r   Receipt
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return ErrSessionRequired
+			return r, ErrSessionRequired
 		}
 	}
 
-	if x.R.RID == 0 && rid > 0 {
-		err = GetRentableByID(ctx, rid, &x.R)
-		if err != nil {
-			return err
-		}
+	r, err = GetReceiptNoAllocations(ctx, rcptid)
+	if err != nil {
+		return r, err
 	}
 
-	x.S, err = GetAllRentableSpecialtyRefs(ctx, x.R.BID, x.R.RID)
-	return err
+	return r, GetReceiptAllocations(ctx, rcptid, &r)
 }
 
-// GetRentableUser returns a Rentable User record with the supplied RUID
-func GetRentableUser(ctx context.Context, ruid int64) (RentableUser, error) {
+// GetReceiptAllocation returns a ReceiptAllocation structure for the supplied RCPTID
+func GetReceiptAllocation(ctx context.Context, id int64) (ReceiptAllocation, error) {
 
 	var (
 		// err error
-		r RentableUser
+		r ReceiptAllocation
 	)
 
 	// session... context
@@ -5309,22 +5998,27 @@ func GetRentableUser(ctx context.Context, ruid int64) (RentableUser, error) {
 	}
 
 	var row *sql.Row
-	fields := []interface{}{ruid}
+	fields := []interface{}{id}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableUser)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetReceiptAllocation)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRentableUser.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetReceiptAllocation.QueryRow(fields...)
 	}
-	return r, ReadRentableUser(row, &r)
+	return r, ReadReceiptAllocation(row, &r)
 }
 
-// GetRentableUserByRBT returns a Rentable User record matching the supplied
-// RID, BID, TCID
-func GetRentableUserByRBT(ctx context.Context, rid, bid, tcid int64) (RentableUser, error) {
-	var r RentableUser
+// GetReceiptNoAllocations returns a Receipt structure for the supplied RCPTID.
+// It does not get the receipt allocations
+func GetReceiptNoAllocations(ctx context.Context, rcptid int64) (Receipt, error) {
+
+	var (
+		// err error
+		r Receipt
+	)
 
+	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
@@ -5333,445 +6027,484 @@ func GetRentableUserByRBT(ctx context.Context, rid, bid, tcid int64) (RentableUs
 	}
 
 	var row *sql.Row
-	fields := []interface{}{rid, bid, tcid}
+	fields := []interface{}{rcptid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableUserByRBT)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetReceipt)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRentableUserByRBT.QueryRow(fields...)
-	}
-	return r, ReadRentableUser(row, &r)
-}
-
-// GetRentableUseStatusForDate returns the status of the Rentable on the supplied
-// date
-//=============================================================================
-func GetRentableUseStatusForDate(ctx context.Context, rid int64, dt *time.Time) (int64, error) {
-	if _, ok := SessionCheck(ctx); !ok {
-		return int64(-1), ErrSessionRequired
-	}
-
-	status := int64(RENTABLESTATUSUNKNOWN)
-	d2 := dt.Add(24 * time.Hour)
-
-	m, err := GetRentableUseStatusByRange(ctx, rid, dt, &d2)
-	if err != nil {
-		return status, err
-	}
-
-	if len(m) > 0 {
-		status = m[0].UseStatus
-	}
-	return status, err
-}
-
-// GetRentableUseTypeForDate returns the status of the Rentable on the supplied
-// date
-//=============================================================================
-func GetRentableUseTypeForDate(ctx context.Context, rid int64, dt *time.Time) (int64, error) {
-	if _, ok := SessionCheck(ctx); !ok {
-		return int64(-1), ErrSessionRequired
-	}
-
-	ut := int64(-1)
-	d2 := dt.Add(24 * time.Hour)
-
-	m, err := GetRentableUseTypeByRange(ctx, rid, dt, &d2)
-	if err != nil {
-		return ut, err
-	}
-
-	if len(m) > 0 {
-		ut = m[0].UseType
+		row = RRdb.Prepstmt.GetReceipt.QueryRow(fields...)
 	}
-	return ut, err
+	return r, ReadReceipt(row, &r)
 }
 
-// GetRentableSpecialtyTypeByName returns a list of specialties associated with the supplied Rentable
-func GetRentableSpecialtyTypeByName(ctx context.Context, bid int64, name string) (RentableSpecialty, error) {
+// GetReceiptDuplicate returns a Receipt structure for the supplied RCPTID
+func GetReceiptDuplicate(ctx context.Context, dt *time.Time, amt float64, docno string) (Receipt, error) {
 
 	var (
 		// err error
-		rsp RentableSpecialty
+		r Receipt
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return rsp, ErrSessionRequired
+			return r, ErrSessionRequired
 		}
 	}
 
 	var row *sql.Row
-	fields := []interface{}{bid, name}
+	fields := []interface{}{dt, amt, docno}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableSpecialtyTypeByName)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetReceiptDuplicate)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRentableSpecialtyTypeByName.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetReceiptDuplicate.QueryRow(fields...)
 	}
-	return rsp, ReadRentableSpecialty(row, &rsp)
+	return r, ReadReceipt(row, &r)
 }
 
-// GetRentableSpecialtyType returns the RentableSpecialty record for the supplied RSPID
-func GetRentableSpecialtyType(ctx context.Context, rspid int64) (RentableSpecialty, error) {
+// GetReceiptAllocations loads all Receipt allocations associated with the supplied Receipt id into
+// the RA array within a Receipt structure
+func GetReceiptAllocations(ctx context.Context, rcptid int64, r *Receipt) error {
 
 	var (
-		// err error
-		rs RentableSpecialty
+		err error
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return rs, ErrSessionRequired
+			return ErrSessionRequired
 		}
 	}
 
-	var row *sql.Row
-	fields := []interface{}{rspid}
+	var rows *sql.Rows
+	fields := []interface{}{rcptid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableSpecialtyType)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetReceiptAllocations)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRentableSpecialtyType.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetReceiptAllocations.Query(fields...)
 	}
-	return rs, ReadRentableSpecialty(row, &rs)
+
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	r.RA = make([]ReceiptAllocation, 0)
+	for rows.Next() {
+		var a ReceiptAllocation
+		err = ReadReceiptAllocations(rows, &a)
+		if err != nil {
+			return err
+		}
+		r.RA = append(r.RA, a)
+	}
+
+	return rows.Err()
 }
 
-// GetAllRentableSpecialtyRefs returns a list of specialties associated with the supplied Rentable
-func GetAllRentableSpecialtyRefs(ctx context.Context, bid, rid int64) ([]int64, error) {
+// GetReceipts for the supplied Business (bid) in date range [d1 - d2)
+func GetReceipts(ctx context.Context, bid int64, d1, d2 *time.Time) ([]Receipt, error) {
 
 	var (
 		err error
-		m   []int64
+		t   []Receipt
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return m, ErrSessionRequired
+			return t, ErrSessionRequired
 		}
 	}
 
-	// first, get the specialties for this Rentable
-
 	var rows *sql.Rows
-	fields := []interface{}{bid, rid}
+	fields := []interface{}{bid, d1, d2}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableSpecialtyRefs)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetReceiptsInDateRange)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetRentableSpecialtyRefs.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetReceiptsInDateRange.Query(fields...)
 	}
 
 	if err != nil {
-		return m, err
+		return t, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var uspid int64
-		err = rows.Scan(&uspid)
+		var r Receipt
+		err = ReadReceipts(rows, &r)
 		if err != nil {
-			return m, err
+			return t, err
 		}
-		m = append(m, uspid)
+
+		r.RA = make([]ReceiptAllocation, 0)
+		err = GetReceiptAllocations(ctx, r.RCPTID, &r)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, r)
 	}
 
-	return m, rows.Err()
+	return t, rows.Err()
 }
 
-/*// SelectRentableTypeRefForDate returns the first RTID of the list where the supplied date falls in range
-func SelectRentableTypeRefForDate(ctx context.Context, rsa *[]RentableSpecialty, dt *time.Time) (RentableSpecialty, error) {
-
+// GetReceiptsByRAIDRange returns the receipts of business bid in date range
+// [d1 - d2) that were received for Rental Agreement raid or that were
+// allocated to its assessments.  The allocations are loaded.
+func GetReceiptsByRAIDRange(ctx context.Context, bid, raid int64, d1, d2 *time.Time) ([]Receipt, error) {
 	var (
 		err error
-		r RentableSpecialty
+		t   []Receipt
 	)
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return r, ErrSessionRequired
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{bid, raid, raid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetReceiptsByRAIDRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetReceiptsByRAIDRange.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r Receipt
+		err = ReadReceipts(rows, &r)
+		if err != nil {
+			return t, err
 		}
+		t = append(t, r)
+	}
+	if err = rows.Err(); err != nil {
+		return t, err
 	}
 
-	for i := 0; i < len(*rsa); i++ {
-		if DateInRange(dt, &(*rsa)[i].DtStart, &(*rsa)[i].DtStop) {
-			return (*rsa)[i], err
+	for i := 0; i < len(t); i++ {
+		if err = GetReceiptAllocations(ctx, t[i].RCPTID, &t[i]); err != nil {
+			return t, err
 		}
 	}
-	return r, err // nothing matched
-}*/
+	return t, nil
+}
 
-// GetRentableSpecialtyTypesForRentableByRange returns an array of RentableSpecialty structures that
-// apply for the supplied time range d1,d2
-func GetRentableSpecialtyTypesForRentableByRange(ctx context.Context, bid, rid int64, d1, d2 *time.Time) ([]RentableSpecialty, error) {
+// getReceiptAllocationList for the supplied rows variable
+func getReceiptAllocationList(ctx context.Context, rows *sql.Rows) ([]ReceiptAllocation, error) {
 
 	var (
-		err  error
-		rsta []RentableSpecialty
+		err error
+		t   []ReceiptAllocation
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return rsta, ErrSessionRequired
+			return t, ErrSessionRequired
 		}
 	}
 
-	rsrefs, err := GetRentableSpecialtyRefsByRange(ctx, bid, rid, d1, d2)
-	if err != nil {
-		return rsta, err
-	}
+	defer rows.Close()
 
-	for i := 0; i < len(rsrefs); i++ {
-		rs, err := GetRentableSpecialtyType(ctx, rsrefs[i].RSPID)
+	for rows.Next() {
+		var r ReceiptAllocation
+		err = ReadReceiptAllocations(rows, &r)
 		if err != nil {
-			return rsta, err
+			return t, err
 		}
-		rsta = append(rsta, rs)
+		t = append(t, r)
 	}
 
-	return rsta, err
+	return t, rows.Err()
 }
 
-// GetRentableSpecialtyRefsByRange loads all the RentableSpecialtyRef records that overlap the supplied time range
-// and returns them in an array
-func GetRentableSpecialtyRefsByRange(ctx context.Context, bid, rid int64, d1, d2 *time.Time) ([]RentableSpecialtyRef, error) {
+// GetASMReceiptAllocationsInRAIDDateRange for the supplied RentalAgreement in date range [d1 - d2).
+// To do this we select all the ReceiptAllocations that occurred during d1-d2 that involved
+// raid.
+func GetASMReceiptAllocationsInRAIDDateRange(ctx context.Context, raid int64, d1, d2 *time.Time) ([]ReceiptAllocation, error) {
 
 	var (
 		err error
-		rs  []RentableSpecialtyRef
+		t   []ReceiptAllocation
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return rs, ErrSessionRequired
+			return t, ErrSessionRequired
 		}
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{bid, rid, d1, d2}
+	fields := []interface{}{raid, d1, d2}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableSpecialtyRefsByRange)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetASMReceiptAllocationsInRAIDDateRange)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetRentableSpecialtyRefsByRange.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetASMReceiptAllocationsInRAIDDateRange.Query(fields...)
 	}
 
 	if err != nil {
-		return rs, err
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var a RentableSpecialtyRef
-		err = ReadRentableSpecialtyRefs(rows, &a)
-		if err != nil {
-			return rs, err
-		}
-		rs = append(rs, a)
+		return t, err
 	}
 
-	return rs, rows.Err()
+	return getReceiptAllocationList(ctx, rows)
 }
 
-// GetRentableTypeRef gets RentableTypeRef record for given RTRID -- RentableTypeRef ID (unique ID)
-func GetRentableTypeRef(ctx context.Context, rtrid int64) (RentableTypeRef, error) {
+// GetReceiptAllocationsByASMID returns any payment allocation on targeted at the supplied ASMID.
+// This call is used primarily to determine how much payment is left to make on a partially paid
+// assessment.
+func GetReceiptAllocationsByASMID(ctx context.Context, bid, asmid int64) ([]ReceiptAllocation, error) {
 
 	var (
-		// err error
-		rtr RentableTypeRef
+		err error
+		t   []ReceiptAllocation
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return rtr, ErrSessionRequired
+			return t, ErrSessionRequired
 		}
 	}
 
-	var row *sql.Row
-	fields := []interface{}{rtrid}
+	var rows *sql.Rows
+	fields := []interface{}{bid, asmid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableTypeRef)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetReceiptAllocationsByASMID)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRentableTypeRef.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetReceiptAllocationsByASMID.Query(fields...)
 	}
-	return rtr, ReadRentableTypeRef(row, &rtr)
+
+	if err != nil {
+		return t, err
+	}
+	return getReceiptAllocationList(ctx, rows)
 }
 
-// SelectRentableTypeRefForDate returns the first RTID of the list where the supplied date falls in range
-func SelectRentableTypeRefForDate(ctx context.Context, rta *[]RentableTypeRef, dt *time.Time) (RentableTypeRef, error) {
+// GetReceiptAllocationsThroughDate selects the ReceiptAllocations associated with receipt id
+// and that happened on or before the supplied date
+// @params
+//	 id = RCPTID of desired allocations
+//   dt = date for all allocations to be on or prior to
+// @returns  []ReceiptAllocation
+func GetReceiptAllocationsThroughDate(ctx context.Context, id int64, dt *time.Time) ([]ReceiptAllocation, error) {
 
 	var (
 		err error
-		r   RentableTypeRef
+		t   []ReceiptAllocation
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return r, ErrSessionRequired
+			return t, ErrSessionRequired
 		}
 	}
 
-	for i := 0; i < len(*rta); i++ {
-		if DateInRange(dt, &(*rta)[i].DtStart, &(*rta)[i].DtStop) {
-			return (*rta)[i], err
-		}
+	var rows *sql.Rows
+	fields := []interface{}{id, dt}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetReceiptAllocationsThroughDate)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetReceiptAllocationsThroughDate.Query(fields...)
 	}
 
-	return r, err // nothing matched
+	if err != nil {
+		return t, err
+	}
+	return getReceiptAllocationList(ctx, rows)
 }
 
-// getRTRefs performs the query over the supplied rows and returns a slice of result records
-func getRTRefs(ctx context.Context, rows *sql.Rows) ([]RentableTypeRef, error) {
+// GetReceiptAllocationAmountsOnDate returns the amount of unallocated funds in id on the
+// supplied date
+// @params
+//	 id = RCPTID of the receipt in question
+//   dt = date on which the unallocated amount is desired
+// @returns  float64 of:
+//   receipt amount
+//   amount allocated as of dt
+//   amount unallocated as of dt
+func GetReceiptAllocationAmountsOnDate(ctx context.Context, id int64, dt *time.Time) (float64, float64, float64, error) {
 
 	var (
-		err error
-		rs  []RentableTypeRef
+		err     error
+		amt     float64
+		alloc   float64
+		unalloc float64
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return rs, ErrSessionRequired
+			return amt, alloc, unalloc, ErrSessionRequired
 		}
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var a RentableTypeRef
-		err = ReadRentableTypeRefs(rows, &a)
-		if err != nil {
-			return rs, err
-		}
-		rs = append(rs, a)
+	// get receipt
+	rcpt, err := GetReceipt(ctx, id)
+	if err != nil {
+		return amt, alloc, unalloc, err
 	}
+	amt = rcpt.Amount
+	unalloc = rcpt.Amount
 
-	return rs, rows.Err()
+	m, err := GetReceiptAllocationsThroughDate(ctx, id, dt)
+	if err != nil {
+		return amt, alloc, unalloc, err
+	}
+
+	for i := 0; i < len(m); i++ {
+		unalloc -= m[i].Amount
+		alloc += m[i].Amount
+	}
+	return amt, alloc, unalloc, err
 }
 
-// GetRentableTypeRefsByRange loads all the RentableTypeRef records that overlap the supplied time range
-// and returns them in an array
-func GetRentableTypeRefsByRange(ctx context.Context, RID int64, d1, d2 *time.Time) ([]RentableTypeRef, error) {
+// GetUnallocatedReceiptsByPayor returns the receipts paid by the supplied payor tcid that
+// have not yet been fully allocated.
+func GetUnallocatedReceiptsByPayor(ctx context.Context, bid, tcid int64) ([]Receipt, error) {
 
 	var (
 		err error
-		rtr []RentableTypeRef
+		t   []Receipt
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return rtr, ErrSessionRequired
+			return t, ErrSessionRequired
 		}
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{RID, d1, d2}
+	fields := []interface{}{bid, tcid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableTypeRefsByRange)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetUnallocatedReceiptsByPayor)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetRentableTypeRefsByRange.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetUnallocatedReceiptsByPayor.Query(fields...)
 	}
 
 	if err != nil {
-		return rtr, err
+		return t, err
 	}
-	return getRTRefs(ctx, rows)
+	defer rows.Close()
+
+	for rows.Next() {
+		var r Receipt
+		err = ReadReceipts(rows, &r)
+		if err != nil {
+			return t, err
+		}
+
+		r.RA = make([]ReceiptAllocation, 0) // the receipt may be partially allocated
+		err = GetReceiptAllocations(ctx, r.RCPTID, &r)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, r)
+	}
+
+	return t, rows.Err()
 }
 
-// GetRentableTypeRefs loads all the RentableTypeRef records for a particular
-func GetRentableTypeRefs(ctx context.Context, RID int64) ([]RentableTypeRef, error) {
+// GetPayorUnallocatedReceiptsCount returns a count of unallocated receipts for the supplied bid & tcid
+func GetPayorUnallocatedReceiptsCount(ctx context.Context, bid, tcid int64) (int, error) {
 
 	var (
-		err error
-		rtr []RentableTypeRef
+		// err   error
+		count int
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return rtr, ErrSessionRequired
+			return count, ErrSessionRequired
 		}
 	}
 
-	var rows *sql.Rows
-	fields := []interface{}{RID}
+	var row *sql.Row
+	fields := []interface{}{bid, tcid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableTypeRefs)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetPayorUnallocatedReceiptsCount)
 		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
+		row = stmt.QueryRow(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetRentableTypeRefs.Query(fields...)
+		row = RRdb.Prepstmt.GetPayorUnallocatedReceiptsCount.QueryRow(fields...)
 	}
-
-	if err != nil {
-		return rtr, err
-	}
-
-	return getRTRefs(ctx, rows)
+	return count, row.Scan(&count)
 }
 
-// GetRTIDForDate returns the RTID in effect on the supplied date
-func GetRTIDForDate(ctx context.Context, RID int64, d1 *time.Time) (int64, error) {
+//=======================================================
+//  R E N T A B L E
+//=======================================================
+
+// GetRentableByID reads a Rentable structure based on the supplied Rentable id
+func GetRentableByID(ctx context.Context, rid int64, r *Rentable) error {
 
 	var (
-		err  error
-		rtid int64
+	// err error
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return rtid, ErrSessionRequired
+			return ErrSessionRequired
 		}
 	}
 
-	DtStop, _ := StringToDate("1/1/9999")
-	m, err := GetRentableTypeRefsByRange(ctx, RID, d1, &DtStop)
-	if err != nil {
-		return rtid, err
-	}
-
-	if len(m) > 0 {
-		rtid = m[0].RTID
+	var row *sql.Row
+	fields := []interface{}{rid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentable)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetRentable.QueryRow(fields...)
 	}
-	return rtid, err
+	return ReadRentable(row, r)
 }
 
-// GetRentableTypeRefForDate returns the RTID in effect on the supplied date
-func GetRentableTypeRefForDate(ctx context.Context, RID int64, d1 *time.Time) (RentableTypeRef, error) {
+// GetRentable reads and returns a Rentable structure based on the supplied Rentable id
+func GetRentable(ctx context.Context, rid int64) (Rentable, error) {
 
 	var (
-		err error
-		r   RentableTypeRef
+		// err error
+		r Rentable
 	)
 
 	// session... context
@@ -5782,2266 +6515,4904 @@ func GetRentableTypeRefForDate(ctx context.Context, RID int64, d1 *time.Time) (R
 		}
 	}
 
-	DtStop, _ := StringToDate("1/1/9999")
-	m, err := GetRentableTypeRefsByRange(ctx, RID, d1, &DtStop)
-	if err != nil {
-		return r, err
-	}
-
-	if len(m) > 0 {
-		r = m[0]
-	}
-	return r, err
+	return r, GetRentableByID(ctx, rid, &r)
 }
 
-// GetRentableLeaseStatus gets RentableLeaseStatus record for given RSID -- RentableLeaseStatus ID (unique ID)
-func GetRentableLeaseStatus(ctx context.Context, rsid int64) (RentableLeaseStatus, error) {
-	var rs RentableLeaseStatus
+// GetRentablesByBusiness returns all the Rentables of business bid
+func GetRentablesByBusiness(ctx context.Context, bid int64) ([]Rentable, error) {
+	var (
+		err error
+		t   []Rentable
+	)
+
+	// session... context
 	if getSessionCheck(ctx) {
-		return rs, ErrSessionRequired
+		return t, ErrSessionRequired
 	}
 
-	var row *sql.Row
-	fields := []interface{}{rsid}
+	var rows *sql.Rows
+	fields := []interface{}{bid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableLeaseStatus)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAllRentablesByBusiness)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRentableLeaseStatus.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetAllRentablesByBusiness.Query(fields...)
 	}
-	return rs, ReadRentableLeaseStatus(row, &rs)
-}
-
-// getRentableLeaseStatusRows loads all the RentableLeaseStatus records for rows
-func getRentableLeaseStatusRows(ctx context.Context, rows *sql.Rows) ([]RentableLeaseStatus, error) {
 
-	var (
-		err error
-		rs  []RentableLeaseStatus
-	)
-
-	if getSessionCheck(ctx) {
-		return rs, ErrSessionRequired
+	if err != nil {
+		return t, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var a RentableLeaseStatus
-		err = ReadRentableLeaseStatuses(rows, &a)
+		var a Rentable
+		err = ReadRentables(rows, &a)
 		if err != nil {
-			return rs, err
+			return t, err
 		}
-		rs = append(rs, a)
+		t = append(t, a)
 	}
 
-	return rs, rows.Err()
+	return t, rows.Err()
 }
 
-// GetRentableLeaseStatusOnOrAfter loads all the RentableLeaseStatus records that overlap the supplied time range
-func GetRentableLeaseStatusOnOrAfter(ctx context.Context, RID int64, dt *time.Time) (RentableLeaseStatus, error) {
+// GetRentableByName reads and returns a Rentable structure based on the supplied Rentable id
+func GetRentableByName(ctx context.Context, name string, bid int64) (Rentable, error) {
 
-	var a RentableLeaseStatus
+	var (
+		// err error
+		r Rentable
+	)
 
-	if getSessionCheck(ctx) {
-		return a, ErrSessionRequired
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return r, ErrSessionRequired
+		}
 	}
 
 	var row *sql.Row
-	fields := []interface{}{RID, dt}
+	fields := []interface{}{name, bid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableLeaseStatusOnOrAfter)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableByName)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRentableLeaseStatusOnOrAfter.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetRentableByName.QueryRow(fields...)
 	}
-	return a, ReadRentableLeaseStatus(row, &a)
+	return r, ReadRentable(row, &r)
 }
 
-// GetRentableLeaseStatusByRange loads all the RentableLeaseStatus records that overlap the supplied time range
-func GetRentableLeaseStatusByRange(ctx context.Context, RID int64, d1, d2 *time.Time) ([]RentableLeaseStatus, error) {
-	var err error
-	var rs []RentableLeaseStatus
+// GetRentableTypeDown returns the values needed for typedown controls:
+// input:   bid - business
+//            s - string or substring to search for
+//        limit - return no more than this many matches
+// return a slice of RentableTypeDowns and an error.
+func GetRentableTypeDown(ctx context.Context, bid int64, s string, limit int) ([]RentableTypeDown, error) {
 
-	if getSessionCheck(ctx) {
-		return rs, ErrSessionRequired
+	var (
+		err error
+		m   []RentableTypeDown
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return m, ErrSessionRequired
+		}
 	}
 
+	s = "%" + s + "%"
+
 	var rows *sql.Rows
-	fields := []interface{}{RID, d1, d2}
+	fields := []interface{}{bid, s, limit}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableLeaseStatusByRange)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableTypeDown)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetRentableLeaseStatusByRange.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetRentableTypeDown.Query(fields...)
 	}
 
 	if err != nil {
-		return rs, err
+		return m, err
 	}
-	return getRentableLeaseStatusRows(ctx, rows)
+	defer rows.Close()
+
+	for rows.Next() {
+		var t RentableTypeDown
+		err = ReadRentableTypeDown(rows, &t)
+		t.Recid = t.RID
+		if err != nil {
+			return m, err
+		}
+		m = append(m, t)
+	}
+
+	return m, rows.Err()
 }
 
-// GetAllRentableLeaseStatus loads all the RentableLeaseStatus records that overlap the supplied time range
-func GetAllRentableLeaseStatus(ctx context.Context, RID int64) ([]RentableLeaseStatus, error) {
+// GetXRentable reads an XRentable structure based on the RID.
+func GetXRentable(ctx context.Context, rid int64, x *XRentable) error {
 
 	var (
 		err error
-		rs  []RentableLeaseStatus
 	)
 
-	if getSessionCheck(ctx) {
-		return rs, ErrSessionRequired
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return ErrSessionRequired
+		}
 	}
 
-	var rows *sql.Rows
-	fields := []interface{}{RID}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetAllRentableLeaseStatus)
-		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
-	} else {
-		rows, err = RRdb.Prepstmt.GetAllRentableLeaseStatus.Query(fields...)
+	if x.R.RID == 0 && rid > 0 {
+		err = GetRentableByID(ctx, rid, &x.R)
+		if err != nil {
+			return err
+		}
 	}
 
-	if err != nil {
-		return rs, err
-	}
-	return getRentableLeaseStatusRows(ctx, rows)
+	x.S, err = GetAllRentableSpecialtyRefs(ctx, x.R.BID, x.R.RID)
+	return err
 }
 
-// GetRentableUseStatus gets RentableUseStatus record for given RSID -- RentableUseStatus ID (unique ID)
-func GetRentableUseStatus(ctx context.Context, rsid int64) (RentableUseStatus, error) {
-	var rs RentableUseStatus
+// GetRentableUser returns a Rentable User record with the supplied RUID
+func GetRentableUser(ctx context.Context, ruid int64) (RentableUser, error) {
 
-	if getSessionCheck(ctx) {
-		return rs, ErrSessionRequired
+	var (
+		// err error
+		r RentableUser
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return r, ErrSessionRequired
+		}
 	}
 
 	var row *sql.Row
-	fields := []interface{}{rsid}
+	fields := []interface{}{ruid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableUseStatus)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableUser)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRentableUseStatus.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetRentableUser.QueryRow(fields...)
 	}
-	return rs, ReadRentableUseStatus(row, &rs)
+	return r, ReadRentableUser(row, &r)
 }
 
-// getRentableUseStatusRows loads all the RentableUseStatus records for rows
-func getRentableUseStatusRows(ctx context.Context, rows *sql.Rows) ([]RentableUseStatus, error) {
-	var err error
-	var rs []RentableUseStatus
-
-	if getSessionCheck(ctx) {
-		return rs, ErrSessionRequired
-	}
-	defer rows.Close()
+// GetRentableUserByRBT returns a Rentable User record matching the supplied
+// RID, BID, TCID
+func GetRentableUserByRBT(ctx context.Context, rid, bid, tcid int64) (RentableUser, error) {
+	var r RentableUser
 
-	for rows.Next() {
-		var a RentableUseStatus
-		if err = ReadRentableUseStatuses(rows, &a); err != nil {
-			return rs, err
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return r, ErrSessionRequired
 		}
-		rs = append(rs, a)
 	}
 
-	return rs, rows.Err()
-}
-
-// GetRentableUseStatusOnOrAfter loads all the RentableUseStatus records that overlap the supplied time range
-func GetRentableUseStatusOnOrAfter(ctx context.Context, RID int64, dt *time.Time) (RentableUseStatus, error) {
-	var a RentableUseStatus
-	if getSessionCheck(ctx) {
-		return a, ErrSessionRequired
-	}
 	var row *sql.Row
-	fields := []interface{}{RID, dt}
+	fields := []interface{}{rid, bid, tcid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableUseStatusOnOrAfter)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableUserByRBT)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRentableUseStatusOnOrAfter.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetRentableUserByRBT.QueryRow(fields...)
 	}
-	return a, ReadRentableUseStatus(row, &a)
+	return r, ReadRentableUser(row, &r)
 }
 
-// GetRentableUseStatusByRange loads all the RentableUseStatus records that overlap the supplied time range
-func GetRentableUseStatusByRange(ctx context.Context, RID int64, d1, d2 *time.Time) ([]RentableUseStatus, error) {
-	var err error
-	var rs []RentableUseStatus
-
-	if getSessionCheck(ctx) {
-		return rs, ErrSessionRequired
+// GetRentableUseStatusForDate returns the status of the Rentable on the supplied
+// date
+//=============================================================================
+func GetRentableUseStatusForDate(ctx context.Context, rid int64, dt *time.Time) (int64, error) {
+	if _, ok := SessionCheck(ctx); !ok {
+		return int64(-1), ErrSessionRequired
 	}
 
-	var rows *sql.Rows
-	fields := []interface{}{RID, d1, d2, d1}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableUseStatusByRange)
-		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
-	} else {
-		rows, err = RRdb.Prepstmt.GetRentableUseStatusByRange.Query(fields...)
-	}
+	status := int64(RENTABLESTATUSUNKNOWN)
+	d2 := dt.Add(24 * time.Hour)
 
+	m, err := GetRentableUseStatusByRange(ctx, rid, dt, &d2)
 	if err != nil {
-		return rs, err
+		return status, err
 	}
-	return getRentableUseStatusRows(ctx, rows)
-}
-
-// GetAllRentableUseStatus loads all the RentableUseStatus records that overlap the supplied time range
-func GetAllRentableUseStatus(ctx context.Context, RID int64) ([]RentableUseStatus, error) {
-	var err error
-	var rs []RentableUseStatus
 
-	if getSessionCheck(ctx) {
-		return rs, ErrSessionRequired
+	if len(m) > 0 {
+		status = m[0].UseStatus
 	}
+	return status, err
+}
 
-	var rows *sql.Rows
-	fields := []interface{}{RID}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetAllRentableUseStatus)
-		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
-	} else {
-		rows, err = RRdb.Prepstmt.GetAllRentableUseStatus.Query(fields...)
+// GetRentableUseTypeForDate returns the status of the Rentable on the supplied
+// date
+//=============================================================================
+func GetRentableUseTypeForDate(ctx context.Context, rid int64, dt *time.Time) (int64, error) {
+	if _, ok := SessionCheck(ctx); !ok {
+		return int64(-1), ErrSessionRequired
 	}
 
+	ut := int64(-1)
+	d2 := dt.Add(24 * time.Hour)
+
+	m, err := GetRentableUseTypeByRange(ctx, rid, dt, &d2)
 	if err != nil {
-		return rs, err
+		return ut, err
 	}
-	return getRentableUseStatusRows(ctx, rows)
+
+	if len(m) > 0 {
+		ut = m[0].UseType
+	}
+	return ut, err
 }
 
-// GetRentableUseType gets RentableUseType record for given RSID -- RentableUseType ID (unique ID)
-func GetRentableUseType(ctx context.Context, utid int64) (RentableUseType, error) {
-	var rs RentableUseType
+// GetRentableSpecialtyTypeByName returns a list of specialties associated with the supplied Rentable
+func GetRentableSpecialtyTypeByName(ctx context.Context, bid int64, name string) (RentableSpecialty, error) {
 
-	if getSessionCheck(ctx) {
-		return rs, ErrSessionRequired
+	var (
+		// err error
+		rsp RentableSpecialty
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return rsp, ErrSessionRequired
+		}
 	}
 
 	var row *sql.Row
-	fields := []interface{}{utid}
+	fields := []interface{}{bid, name}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableUseType)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableSpecialtyTypeByName)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRentableUseType.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetRentableSpecialtyTypeByName.QueryRow(fields...)
 	}
-	return rs, ReadRentableUseType(row, &rs)
+	return rsp, ReadRentableSpecialty(row, &rsp)
 }
 
-// getRentableUseTypeRows loads all the RentableUseType records for rows
-func getRentableUseTypeRows(ctx context.Context, rows *sql.Rows) ([]RentableUseType, error) {
-	var err error
-	var rs []RentableUseType
+// GetRentableSpecialtyType returns the RentableSpecialty record for the supplied RSPID
+func GetRentableSpecialtyType(ctx context.Context, rspid int64) (RentableSpecialty, error) {
 
-	if getSessionCheck(ctx) {
-		return rs, ErrSessionRequired
-	}
-	defer rows.Close()
+	var (
+		// err error
+		rs RentableSpecialty
+	)
 
-	for rows.Next() {
-		var a RentableUseType
-		if err = ReadRentableUseTypes(rows, &a); err != nil {
-			return rs, err
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return rs, ErrSessionRequired
 		}
-		rs = append(rs, a)
 	}
 
-	return rs, rows.Err()
-}
-
-// GetRentableUseTypeOnOrAfter loads all the RentableUseType records that overlap the supplied time range
-func GetRentableUseTypeOnOrAfter(ctx context.Context, RID int64, dt *time.Time) (RentableUseType, error) {
-	var a RentableUseType
-	if getSessionCheck(ctx) {
-		return a, ErrSessionRequired
-	}
 	var row *sql.Row
-	fields := []interface{}{RID, dt}
+	fields := []interface{}{rspid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableUseTypeOnOrAfter)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableSpecialtyType)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRentableUseTypeOnOrAfter.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetRentableSpecialtyType.QueryRow(fields...)
 	}
-	return a, ReadRentableUseType(row, &a)
+	return rs, ReadRentableSpecialty(row, &rs)
 }
 
-// GetRentableUseTypeByRange loads all the RentableUseType records that overlap the supplied time range
-func GetRentableUseTypeByRange(ctx context.Context, RID int64, d1, d2 *time.Time) ([]RentableUseType, error) {
-	var err error
-	var rs []RentableUseType
+// GetAllRentableSpecialtyRefs returns a list of specialties associated with the supplied Rentable
+func GetAllRentableSpecialtyRefs(ctx context.Context, bid, rid int64) ([]int64, error) {
 
-	if getSessionCheck(ctx) {
-		return rs, ErrSessionRequired
+	var (
+		err error
+		m   []int64
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return m, ErrSessionRequired
+		}
 	}
 
+	// first, get the specialties for this Rentable
+
 	var rows *sql.Rows
-	fields := []interface{}{RID, d1, d2, d1}
+	fields := []interface{}{bid, rid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableUseTypeByRange)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableSpecialtyRefs)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetRentableUseTypeByRange.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetRentableSpecialtyRefs.Query(fields...)
 	}
 
 	if err != nil {
-		return rs, err
+		return m, err
 	}
-	return getRentableUseTypeRows(ctx, rows)
+	defer rows.Close()
+
+	for rows.Next() {
+		var uspid int64
+		err = rows.Scan(&uspid)
+		if err != nil {
+			return m, err
+		}
+		m = append(m, uspid)
+	}
+
+	return m, rows.Err()
 }
 
-// GetAllRentableUseType loads all the RentableUseType records that overlap the supplied time range
-func GetAllRentableUseType(ctx context.Context, RID int64) ([]RentableUseType, error) {
-	var err error
-	var rs []RentableUseType
+/*// SelectRentableTypeRefForDate returns the first RTID of the list where the supplied date falls in range
+func SelectRentableTypeRefForDate(ctx context.Context, rsa *[]RentableSpecialty, dt *time.Time) (RentableSpecialty, error) {
 
-	if getSessionCheck(ctx) {
-		return rs, ErrSessionRequired
-	}
+	var (
+		err error
+		r RentableSpecialty
+	)
 
-	var rows *sql.Rows
-	fields := []interface{}{RID}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetAllRentableUseType)
-		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
-	} else {
-		rows, err = RRdb.Prepstmt.GetAllRentableUseType.Query(fields...)
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return r, ErrSessionRequired
+		}
 	}
 
-	if err != nil {
-		return rs, err
+	for i := 0; i < len(*rsa); i++ {
+		if DateInRange(dt, &(*rsa)[i].DtStart, &(*rsa)[i].DtStop) {
+			return (*rsa)[i], err
+		}
 	}
-	return getRentableUseTypeRows(ctx, rows)
-}
-
-//=======================================================
-//  R E N T A B L E   T Y P E
-//=======================================================
+	return r, err // nothing matched
+}*/
 
-// GetRentableType returns characteristics of the Rentable
-func GetRentableType(ctx context.Context, rtid int64, rt *RentableType) error {
+// GetRentableSpecialtyTypesForRentableByRange returns an array of RentableSpecialty structures that
+// apply for the supplied time range d1,d2
+func GetRentableSpecialtyTypesForRentableByRange(ctx context.Context, bid, rid int64, d1, d2 *time.Time) ([]RentableSpecialty, error) {
 
 	var (
-		err error
+		err  error
+		rsta []RentableSpecialty
 	)
 
-	if getSessionCheck(ctx) {
-		return ErrSessionRequired
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return rsta, ErrSessionRequired
+		}
 	}
 
-	var row *sql.Row
-	fields := []interface{}{rtid}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableType)
-		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
-	} else {
-		row = RRdb.Prepstmt.GetRentableType.QueryRow(fields...)
-	}
-	err = ReadRentableType(row, rt)
+	rsrefs, err := GetRentableSpecialtyRefsByRange(ctx, bid, rid, d1, d2)
 	if err != nil {
-		return err
+		return rsta, err
 	}
 
-	var cerr error
-	rt.CA, cerr = GetAllCustomAttributes(ctx, ELEMRENTABLETYPE, rtid)
-	if cerr != nil { // it's not really an error if we don't find any custom attributes
-		err = cerr
+	for i := 0; i < len(rsrefs); i++ {
+		rs, err := GetRentableSpecialtyType(ctx, rsrefs[i].RSPID)
+		if err != nil {
+			return rsta, err
+		}
+		rsta = append(rsta, rs)
 	}
 
-	return err
+	return rsta, err
 }
 
-// GetRentableTypeByStyle returns characteristics of the Rentable
-func GetRentableTypeByStyle(ctx context.Context, name string, bid int64) (RentableType, error) {
+// GetRentableSpecialtyRefsByRange loads all the RentableSpecialtyRef records that overlap the supplied time range
+// and returns them in an array
+func GetRentableSpecialtyRefsByRange(ctx context.Context, bid, rid int64, d1, d2 *time.Time) ([]RentableSpecialtyRef, error) {
 
 	var (
-		// err error
-		rt RentableType
+		err error
+		rs  []RentableSpecialtyRef
 	)
 
-	if getSessionCheck(ctx) {
-		return rt, ErrSessionRequired
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return rs, ErrSessionRequired
+		}
 	}
 
-	var row *sql.Row
-	fields := []interface{}{name, bid}
+	var rows *sql.Rows
+	fields := []interface{}{bid, rid, d1, d2}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableTypeByStyle)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableSpecialtyRefsByRange)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRentableTypeByStyle.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetRentableSpecialtyRefsByRange.Query(fields...)
 	}
-	return rt, ReadRentableType(row, &rt)
+
+	if err != nil {
+		return rs, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a RentableSpecialtyRef
+		err = ReadRentableSpecialtyRefs(rows, &a)
+		if err != nil {
+			return rs, err
+		}
+		rs = append(rs, a)
+	}
+
+	return rs, rows.Err()
 }
 
-// GetRentableTypeByName returns characteristics of the Rentable
-func GetRentableTypeByName(ctx context.Context, name string, bid int64) (RentableType, error) {
+// GetRentableTypeRef gets RentableTypeRef record for given RTRID -- RentableTypeRef ID (unique ID)
+func GetRentableTypeRef(ctx context.Context, rtrid int64) (RentableTypeRef, error) {
 
 	var (
 		// err error
-		rt RentableType
+		rtr RentableTypeRef
 	)
 
-	if getSessionCheck(ctx) {
-		return rt, ErrSessionRequired
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return rtr, ErrSessionRequired
+		}
 	}
 
 	var row *sql.Row
-	fields := []interface{}{name, bid}
+	fields := []interface{}{rtrid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableTypeByName)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableTypeRef)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRentableTypeByName.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetRentableTypeRef.QueryRow(fields...)
 	}
-	return rt, ReadRentableType(row, &rt)
+	return rtr, ReadRentableTypeRef(row, &rtr)
 }
 
-// getBizRentableTypes returns a slice of RentableType indexed by the RTID
-func getBizRentableTypes(bid int64) (map[int64]RentableType, error) {
+// SelectRentableTypeRefForDate returns the first RTID of the list where the supplied date falls in range
+func SelectRentableTypeRefForDate(ctx context.Context, rta *[]RentableTypeRef, dt *time.Time) (RentableTypeRef, error) {
 
 	var (
 		err error
-		t   = make(map[int64]RentableType)
+		r   RentableTypeRef
 	)
 
-	var rows *sql.Rows
-	fields := []interface{}{bid}
-	/*if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetAllBusinessRentableTypes)
-		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
-	} else {
-		rows, err = RRdb.Prepstmt.GetAllBusinessRentableTypes.Query(fields...)
-	}*/
-	rows, err = RRdb.Prepstmt.GetAllBusinessRentableTypes.Query(fields...)
-	if err != nil {
-		return t, err
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return r, ErrSessionRequired
+		}
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var a RentableType
-		err = ReadRentableTypes(rows, &a)
-		if err != nil {
-			return t, err
-		}
-		a.MR = []RentableMarketRate{}
-		err = getRentableMarketRates(&a)
-		if err != nil {
-			return t, err
+	for i := 0; i < len(*rta); i++ {
+		if DateInRange(dt, &(*rta)[i].DtStart, &(*rta)[i].DtStop) {
+			return (*rta)[i], err
 		}
-		t[a.RTID] = a
 	}
 
-	return t, rows.Err()
+	return r, err // nothing matched
 }
 
-// GetBusinessRentableTypes returns a slice of RentableType indexed by the RTID
-func GetBusinessRentableTypes(ctx context.Context, bid int64) (map[int64]RentableType, error) {
-	var t = make(map[int64]RentableType)
-	if getSessionCheck(ctx) {
-		return t, ErrSessionRequired
+// getRTRefs performs the query over the supplied rows and returns a slice of result records
+func getRTRefs(ctx context.Context, rows *sql.Rows) ([]RentableTypeRef, error) {
+
+	var (
+		err error
+		rs  []RentableTypeRef
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return rs, ErrSessionRequired
+		}
 	}
-	return getBizRentableTypes(bid)
-}
+	defer rows.Close()
 
-// getListOfRentableMarketRates returns a list of RentableMarketRate structs
-// based on the supplied rows.
-//-----------------------------------------------------------------------------
-func getListOfRentableMarketRates(ctx context.Context, rows *sql.Rows) ([]RentableMarketRate, error) {
-	var m []RentableMarketRate
-	var err error
 	for rows.Next() {
-		var a RentableMarketRate
-		if err = ReadRentableMarketRates(rows, &a); err != nil {
-			return m, err
+		var a RentableTypeRef
+		err = ReadRentableTypeRefs(rows, &a)
+		if err != nil {
+			return rs, err
 		}
-		m = append(m, a)
+		rs = append(rs, a)
 	}
-	return m, nil
+
+	return rs, rows.Err()
 }
 
-// GetRentableMarketRateByRange returns a list of RentableMarketRate structs
-// for a particular RentableType that occur in the supplied time range
-//-----------------------------------------------------------------------------
-func GetRentableMarketRateByRange(ctx context.Context, RTID int64, d1, d2 *time.Time) ([]RentableMarketRate, error) {
-	var err error
-	var rs []RentableMarketRate
+// GetRentableTypeRefsByRange loads all the RentableTypeRef records that overlap the supplied time range
+// and returns them in an array
+func GetRentableTypeRefsByRange(ctx context.Context, RID int64, d1, d2 *time.Time) ([]RentableTypeRef, error) {
 
-	if getSessionCheck(ctx) {
-		return rs, ErrSessionRequired
+	var (
+		err error
+		rtr []RentableTypeRef
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return rtr, ErrSessionRequired
+		}
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{RTID, d1, d2, d1}
+	fields := []interface{}{RID, d1, d2}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableMarketRateByRange)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableTypeRefsByRange)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetRentableMarketRateByRange.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetRentableTypeRefsByRange.Query(fields...)
 	}
 
 	if err != nil {
-		return rs, err
+		return rtr, err
 	}
-	return getListOfRentableMarketRates(ctx, rows)
+	return getRTRefs(ctx, rows)
 }
 
-// getRentableMarketRates loads all the MarketRate rent information for this
-// Rentable into an array
-func getRentableMarketRates(rt *RentableType) error {
-	var err error
-	var rows *sql.Rows
-	fields := []interface{}{rt.RTID}
+// GetRentableTypeRefs loads all the RentableTypeRef records for a particular
+func GetRentableTypeRefs(ctx context.Context, RID int64) ([]RentableTypeRef, error) {
 
-	rows, err = RRdb.Prepstmt.GetRentableMarketRates.Query(fields...)
-	if err != nil {
-		return err
-	}
-	defer rows.Close()
+	var (
+		err error
+		rtr []RentableTypeRef
+	)
 
-	LatestMRDTStart := time.Date(0, 0, 0, 0, 0, 0, 0, time.UTC)
-	for rows.Next() {
-		var a RentableMarketRate
-		err = ReadRentableMarketRates(rows, &a)
-		if err != nil {
-			return err
-		}
-		if a.DtStart.After(LatestMRDTStart) {
-			LatestMRDTStart = a.DtStart
-			rt.MRCurrent = a.MarketRate
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return rtr, ErrSessionRequired
 		}
-		rt.MR = append(rt.MR, a)
-	}
-
-	return rows.Err()
-}
-
-// GetRentableMarketRates loads all the MarketRate rent information for this Rentable into an array
-func GetRentableMarketRates(ctx context.Context, rt *RentableType) error {
-	if getSessionCheck(ctx) {
-		return ErrSessionRequired
-	}
-	return getRentableMarketRates(rt)
-}
-
-// GetRentableMarketRateInstance returns instance of rentableMarketRate for given RMRID
-func GetRentableMarketRateInstance(ctx context.Context, rmrid int64) (RentableMarketRate, error) {
-	var rmr RentableMarketRate
-	if getSessionCheck(ctx) {
-		return rmr, ErrSessionRequired
 	}
 
-	var row *sql.Row
-	fields := []interface{}{rmrid}
+	var rows *sql.Rows
+	fields := []interface{}{RID}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableMarketRateInstance)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableTypeRefs)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRentableMarketRateInstance.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetRentableTypeRefs.Query(fields...)
 	}
-	return rmr, ReadRentableMarketRate(row, &rmr)
-}
 
-// GetRentableMarketRate returns the market-rate rent amount for r during the
-// given time range. If the time range is large and spans multiple price
-// changes, the chronologically earliest price that fits in the time range
-// will be returned. It is best to provide as small a timerange d1-d2 as
-// possible to minimize risk of overlap
-//-----------------------------------------------------------------------------
-func GetRentableMarketRate(ctx context.Context, xbiz *XBusiness, RID int64, d1, d2 *time.Time) (float64, error) {
+	if err != nil {
+		return rtr, err
+	}
+
+	return getRTRefs(ctx, rows)
+}
+
+// GetRTIDForDate returns the RTID in effect on the supplied date
+func GetRTIDForDate(ctx context.Context, RID int64, d1 *time.Time) (int64, error) {
 
 	var (
-		err             error
-		marketRateValue float64
+		err  error
+		rtid int64
 	)
 
-	if getSessionCheck(ctx) {
-		return marketRateValue, ErrSessionRequired
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return rtid, ErrSessionRequired
+		}
 	}
 
-	rtid, err := GetRTIDForDate(ctx, RID, d1) // first thing... find the RTID for this time range
+	DtStop, _ := StringToDate("1/1/9999")
+	m, err := GetRentableTypeRefsByRange(ctx, RID, d1, &DtStop)
 	if err != nil {
-		return marketRateValue, err
+		return rtid, err
 	}
 
-	mr := xbiz.RT[rtid].MR
-	// Console("GetRentableMarketRate: len(mr) is %d\n", len(mr))
-	for i := 0; i < len(mr); i++ {
-		if DateRangeOverlap(d1, d2, &mr[i].DtStart, &mr[i].DtStop) {
-			return mr[i].MarketRate, err
-		}
+	if len(m) > 0 {
+		rtid = m[0].RTID
 	}
-	return marketRateValue, err
+	return rtid, err
 }
 
-// GetRentableUsersInRange returns an array of user (in the form of user)
-// associated with the supplied RentalAgreement ID during the time range d1-d2
-//-----------------------------------------------------------------------------
-func GetRentableUsersInRange(ctx context.Context, rid int64, d1, d2 *time.Time) ([]RentableUser, error) {
-	var err error
-	var t []RentableUser
+// GetRentableTypeRefForDate returns the RTID in effect on the supplied date
+func GetRentableTypeRefForDate(ctx context.Context, RID int64, d1 *time.Time) (RentableTypeRef, error) {
 
-	if _, ok := SessionCheck(ctx); !ok {
-		return t, ErrSessionRequired
-	}
+	var (
+		err error
+		r   RentableTypeRef
+	)
 
-	var rows *sql.Rows
-	fields := []interface{}{rid, d1, d2}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableUsersInRange)
-		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
-	} else {
-		rows, err = RRdb.Prepstmt.GetRentableUsersInRange.Query(fields...)
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return r, ErrSessionRequired
+		}
 	}
 
+	DtStop, _ := StringToDate("1/1/9999")
+	m, err := GetRentableTypeRefsByRange(ctx, RID, d1, &DtStop)
 	if err != nil {
-		return t, err
+		return r, err
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var r RentableUser
-		err = ReadRentableUsers(rows, &r)
-		if err != nil {
-			return t, err
-		}
-		t = append(t, r)
+	if len(m) > 0 {
+		r = m[0]
 	}
-
-	return t, rows.Err()
+	return r, err
 }
 
-//=======================================================
-//  R E N T A L   A G R E E M E N T
-//=======================================================
-
-// GetRentalAgreement returns the RentalAgreement struct for the supplied rental agreement id
-func GetRentalAgreement(ctx context.Context, raid int64) (RentalAgreement, error) {
-
-	var (
-		// err error
-		r RentalAgreement
-	)
-
+// GetRentableLeaseStatus gets RentableLeaseStatus record for given RSID -- RentableLeaseStatus ID (unique ID)
+func GetRentableLeaseStatus(ctx context.Context, rsid int64) (RentableLeaseStatus, error) {
+	var rs RentableLeaseStatus
 	if getSessionCheck(ctx) {
-		return r, ErrSessionRequired
+		return rs, ErrSessionRequired
 	}
 
 	var row *sql.Row
-	fields := []interface{}{raid}
+	fields := []interface{}{rsid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreement)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableLeaseStatus)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRentalAgreement.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetRentableLeaseStatus.QueryRow(fields...)
 	}
-	return r, ReadRentalAgreement(row, &r)
+	return rs, ReadRentableLeaseStatus(row, &rs)
 }
 
-// LoadXRentalAgreement is like GetXRentalAgreement except that it assumes that some of the structure may
-// already be loaded. It only loads those portions that appear not to already be loaded.
-func LoadXRentalAgreement(ctx context.Context, raid int64, r *RentalAgreement, d1, d2 *time.Time) error {
+// getRentableLeaseStatusRows loads all the RentableLeaseStatus records for rows
+func getRentableLeaseStatusRows(ctx context.Context, rows *sql.Rows) ([]RentableLeaseStatus, error) {
 
 	var (
 		err error
+		rs  []RentableLeaseStatus
 	)
 
 	if getSessionCheck(ctx) {
-		return ErrSessionRequired
+		return rs, ErrSessionRequired
 	}
+	defer rows.Close()
 
-	if r.RAID != raid {
-		(*r), err = GetRentalAgreement(ctx, raid)
+	for rows.Next() {
+		var a RentableLeaseStatus
+		err = ReadRentableLeaseStatuses(rows, &a)
 		if err != nil {
-			return err
+			return rs, err
 		}
+		rs = append(rs, a)
 	}
 
-	t, err := GetRentalAgreementRentables(ctx, raid, d1, d2)
-	if err != nil {
-		return err
-	}
+	return rs, rows.Err()
+}
 
-	r.R = make([]XRentable, 0)
-	for i := 0; i < len(t); i++ {
-		var xu XRentable
-		err = GetXRentable(ctx, t[i].RID, &xu)
-		if err != nil {
-			return err
-		}
-		r.R = append(r.R, xu)
-	}
+// GetRentableLeaseStatusOnOrAfter loads all the RentableLeaseStatus records that overlap the supplied time range
+func GetRentableLeaseStatusOnOrAfter(ctx context.Context, RID int64, dt *time.Time) (RentableLeaseStatus, error) {
 
-	m, err := GetRentalAgreementPayorsInRange(ctx, raid, d1, d2)
-	if err != nil {
-		return err
-	}
+	var a RentableLeaseStatus
 
-	r.P = make([]XPerson, 0)
-	for i := 0; i < len(m); i++ {
-		var xp XPerson
-		err = GetXPerson(ctx, m[i].TCID, &xp)
-		if err != nil {
-			return err
-		}
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
 
-		r.P = append(r.P, xp)
+	var row *sql.Row
+	fields := []interface{}{RID, dt}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableLeaseStatusOnOrAfter)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetRentableLeaseStatusOnOrAfter.QueryRow(fields...)
 	}
+	return a, ReadRentableLeaseStatus(row, &a)
+}
 
-	for j := 0; j < len(r.R); j++ {
-		n, err := GetRentableUsersInRange(ctx, r.R[j].R.RID, d1, d2)
-		if err != nil {
-			return err
-		}
+// GetRentableLeaseStatusByRange loads all the RentableLeaseStatus records that overlap the supplied time range
+func GetRentableLeaseStatusByRange(ctx context.Context, RID int64, d1, d2 *time.Time) ([]RentableLeaseStatus, error) {
+	var err error
+	var rs []RentableLeaseStatus
 
-		r.T = make([]XPerson, 0)
-		for i := 0; i < len(n); i++ {
-			var xp XPerson
-			err = GetXPerson(ctx, n[i].TCID, &xp)
-			if err != nil {
-				return err
-			}
+	if getSessionCheck(ctx) {
+		return rs, ErrSessionRequired
+	}
 
-			r.T = append(r.T, xp)
-		}
+	var rows *sql.Rows
+	fields := []interface{}{RID, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableLeaseStatusByRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetRentableLeaseStatusByRange.Query(fields...)
 	}
 
-	return err
+	if err != nil {
+		return rs, err
+	}
+	return getRentableLeaseStatusRows(ctx, rows)
 }
 
-// GetRentalAgreementEarliestDate returns the earliest of
-// AgreementStart, PossessionStart, and RentStart
-func GetRentalAgreementEarliestDate(ctx context.Context, a *RentalAgreement) (time.Time, error) {
+// GetAllRentableLeaseStatus loads all the RentableLeaseStatus records that overlap the supplied time range
+func GetAllRentableLeaseStatus(ctx context.Context, RID int64) ([]RentableLeaseStatus, error) {
 
 	var (
 		err error
-		dt  time.Time
+		rs  []RentableLeaseStatus
 	)
 
-	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return dt, ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return rs, ErrSessionRequired
 	}
 
-	dt = a.AgreementStart
-	if a.PossessionStart.Before(dt) {
-		dt = a.PossessionStart
+	var rows *sql.Rows
+	fields := []interface{}{RID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAllRentableLeaseStatus)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetAllRentableLeaseStatus.Query(fields...)
 	}
-	if a.RentStart.Before(dt) {
-		dt = a.RentStart
+
+	if err != nil {
+		return rs, err
 	}
-	return dt, err
+	return getRentableLeaseStatusRows(ctx, rows)
 }
 
-// GetXRentalAgreement gets the RentalAgreement plus the associated rentables and payors for the
-// time period specified
-func GetXRentalAgreement(ctx context.Context, raid int64, d1, d2 *time.Time) (RentalAgreement, error) {
+// GetRentableUseStatus gets RentableUseStatus record for given RSID -- RentableUseStatus ID (unique ID)
+func GetRentableUseStatus(ctx context.Context, rsid int64) (RentableUseStatus, error) {
+	var rs RentableUseStatus
 
-	var (
-		// err error
-		ra RentalAgreement
-	)
+	if getSessionCheck(ctx) {
+		return rs, ErrSessionRequired
+	}
 
-	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return ra, ErrSessionRequired
-		}
+	var row *sql.Row
+	fields := []interface{}{rsid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableUseStatus)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetRentableUseStatus.QueryRow(fields...)
 	}
-
-	return ra, LoadXRentalAgreement(ctx, raid, &ra, d1, d2)
+	return rs, ReadRentableUseStatus(row, &rs)
 }
 
-// GetRentalAgreementsFromList takes an array of RentalAgreementRentables and returns map of
-// all the rental agreements referenced. The map is indexed by the RAID
-func GetRentalAgreementsFromList(ctx context.Context, raa *[]RentalAgreementRentable) (map[int64]RentalAgreement, error) {
-
-	var (
-		err error
-		t   = make(map[int64]RentalAgreement)
-	)
+// getRentableUseStatusRows loads all the RentableUseStatus records for rows
+func getRentableUseStatusRows(ctx context.Context, rows *sql.Rows) ([]RentableUseStatus, error) {
+	var err error
+	var rs []RentableUseStatus
 
 	if getSessionCheck(ctx) {
-		return t, ErrSessionRequired
+		return rs, ErrSessionRequired
 	}
+	defer rows.Close()
 
-	for i := 0; i < len(*raa); i++ {
-		ra, err := GetRentalAgreement(ctx, (*raa)[i].RAID)
-		if err != nil {
-			return t, err
-		}
-
-		if ra.RAID > 0 {
-			t[ra.RAID] = ra
+	for rows.Next() {
+		var a RentableUseStatus
+		if err = ReadRentableUseStatuses(rows, &a); err != nil {
+			return rs, err
 		}
+		rs = append(rs, a)
 	}
 
-	return t, err
+	return rs, rows.Err()
 }
 
-// GetAgreementsForRentable returns an array of RentalAgreementRentables associated with the supplied RentableID
-// during the time range d1-d2
-func GetAgreementsForRentable(ctx context.Context, rid int64, d1, d2 *time.Time) ([]RentalAgreementRentable, error) {
+// GetRentableUseStatusOnOrAfter loads all the RentableUseStatus records that overlap the supplied time range
+func GetRentableUseStatusOnOrAfter(ctx context.Context, RID int64, dt *time.Time) (RentableUseStatus, error) {
+	var a RentableUseStatus
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{RID, dt}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableUseStatusOnOrAfter)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetRentableUseStatusOnOrAfter.QueryRow(fields...)
+	}
+	return a, ReadRentableUseStatus(row, &a)
+}
+
+// GetRentableUseStatusByRange loads all the RentableUseStatus records that overlap the supplied time range
+func GetRentableUseStatusByRange(ctx context.Context, RID int64, d1, d2 *time.Time) ([]RentableUseStatus, error) {
 	var err error
-	var t []RentalAgreementRentable
+	var rs []RentableUseStatus
 
 	if getSessionCheck(ctx) {
-		return t, ErrSessionRequired
+		return rs, ErrSessionRequired
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{rid, d1, d2}
+	fields := []interface{}{RID, d1, d2, d1}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementsForRentable)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableUseStatusByRange)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetRentalAgreementsForRentable.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetRentableUseStatusByRange.Query(fields...)
 	}
 
 	if err != nil {
-		return t, err
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var r RentalAgreementRentable
-		err = ReadRentalAgreementRentables(rows, &r)
-		if err != nil {
-			return t, err
-		}
-		t = append(t, r)
+		return rs, err
 	}
-
-	return t, rows.Err()
+	return getRentableUseStatusRows(ctx, rows)
 }
 
-// GetRentalAgreementChain returns the list of rental agreements that have
-// the ORIGIN matching the supplied ID as well as the RAID == ID
-//
-func GetRentalAgreementChain(ctx context.Context, origin int64) ([]RentalAgreement, error) {
+// GetAllRentableUseStatus loads all the RentableUseStatus records that overlap the supplied time range
+func GetAllRentableUseStatus(ctx context.Context, RID int64) ([]RentableUseStatus, error) {
 	var err error
-	var t []RentalAgreement
+	var rs []RentableUseStatus
 
 	if getSessionCheck(ctx) {
-		return t, ErrSessionRequired
+		return rs, ErrSessionRequired
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{origin, origin}
+	fields := []interface{}{RID}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementChain)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAllRentableUseStatus)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetRentalAgreementChain.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetAllRentableUseStatus.Query(fields...)
 	}
 
 	if err != nil {
-		return t, err
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var r RentalAgreement
-		err = ReadRentalAgreements(rows, &r)
-		if err != nil {
-			return t, err
-		}
-		t = append(t, r)
+		return rs, err
 	}
-
-	return t, rows.Err()
+	return getRentableUseStatusRows(ctx, rows)
 }
 
-// GetRARentableForDate gets the RentalAgreementRentable plus the associated rentables and payors for the
-// time period specified
-func GetRARentableForDate(ctx context.Context, raid int64, d1 *time.Time, rar *RentalAgreementRentable) error {
-
-	var (
-	// err error
-	)
+// GetRentableUseType gets RentableUseType record for given RSID -- RentableUseType ID (unique ID)
+func GetRentableUseType(ctx context.Context, utid int64) (RentableUseType, error) {
+	var rs RentableUseType
 
 	if getSessionCheck(ctx) {
-		return ErrSessionRequired
+		return rs, ErrSessionRequired
 	}
 
 	var row *sql.Row
-	fields := []interface{}{raid, d1, d1}
+	fields := []interface{}{utid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRARentableForDate)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableUseType)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRARentableForDate.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetRentableUseType.QueryRow(fields...)
 	}
-	return ReadRentalAgreementRentable(row, rar)
+	return rs, ReadRentableUseType(row, &rs)
 }
 
-// GetRentalAgreementRentable returns Rentable record matching the supplied RARID
-func GetRentalAgreementRentable(ctx context.Context, rarid int64) (RentalAgreementRentable, error) {
-
-	var (
-		// err error
-		r RentalAgreementRentable
-	)
+// getRentableUseTypeRows loads all the RentableUseType records for rows
+func getRentableUseTypeRows(ctx context.Context, rows *sql.Rows) ([]RentableUseType, error) {
+	var err error
+	var rs []RentableUseType
 
 	if getSessionCheck(ctx) {
-		return r, ErrSessionRequired
+		return rs, ErrSessionRequired
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a RentableUseType
+		if err = ReadRentableUseTypes(rows, &a); err != nil {
+			return rs, err
+		}
+		rs = append(rs, a)
 	}
 
+	return rs, rows.Err()
+}
+
+// GetRentableUseTypeOnOrAfter loads all the RentableUseType records that overlap the supplied time range
+func GetRentableUseTypeOnOrAfter(ctx context.Context, RID int64, dt *time.Time) (RentableUseType, error) {
+	var a RentableUseType
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
 	var row *sql.Row
-	fields := []interface{}{rarid}
+	fields := []interface{}{RID, dt}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementRentable)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableUseTypeOnOrAfter)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRentalAgreementRentable.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetRentableUseTypeOnOrAfter.QueryRow(fields...)
 	}
-	return r, ReadRentalAgreementRentable(row, &r)
+	return a, ReadRentableUseType(row, &a)
 }
 
-// GetRentalAgreementRentables returns an array of RentalAgreementRentables associated with the supplied RentalAgreement ID
-// during the time range d1-d2
-func GetRentalAgreementRentables(ctx context.Context, raid int64, d1, d2 *time.Time) ([]RentalAgreementRentable, error) {
-
-	var (
-		err error
-		t   []RentalAgreementRentable
-	)
+// GetRentableUseTypeByRange loads all the RentableUseType records that overlap the supplied time range
+func GetRentableUseTypeByRange(ctx context.Context, RID int64, d1, d2 *time.Time) ([]RentableUseType, error) {
+	var err error
+	var rs []RentableUseType
 
 	if getSessionCheck(ctx) {
-		return t, ErrSessionRequired
+		return rs, ErrSessionRequired
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{raid, d1, d2}
+	fields := []interface{}{RID, d1, d2, d1}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementRentables)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableUseTypeByRange)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetRentalAgreementRentables.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetRentableUseTypeByRange.Query(fields...)
 	}
 
 	if err != nil {
-		return t, err
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var r RentalAgreementRentable
-		err = ReadRentalAgreementRentables(rows, &r)
-		if err != nil {
-			return t, err
-		}
-		t = append(t, r)
+		return rs, err
 	}
-
-	return t, rows.Err()
+	return getRentableUseTypeRows(ctx, rows)
 }
 
-// GetAllRentalAgreementRentables returns an array of RentalAgreementRentables
-// associated with the supplied RentalAgreement ID
-//
-// INPUTS
-//  ctx - context for txn
-//  raid - which rental agreement
-//
-// RETURNS
-//  an array of rental agr. rentables
-//  any error encountered
-//-----------------------------------------------------------------------------
-func GetAllRentalAgreementRentables(ctx context.Context, raid int64) ([]RentalAgreementRentable, error) {
-
-	var (
-		err error
-		t   []RentalAgreementRentable
-	)
+// GetAllRentableUseType loads all the RentableUseType records that overlap the supplied time range
+func GetAllRentableUseType(ctx context.Context, RID int64) ([]RentableUseType, error) {
+	var err error
+	var rs []RentableUseType
 
 	if getSessionCheck(ctx) {
-		return t, ErrSessionRequired
+		return rs, ErrSessionRequired
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{raid}
+	fields := []interface{}{RID}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetAllRentalAgreementRentables)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAllRentableUseType)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetAllRentalAgreementRentables.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetAllRentableUseType.Query(fields...)
 	}
 
 	if err != nil {
-		return t, err
+		return rs, err
 	}
-	defer rows.Close()
+	return getRentableUseTypeRows(ctx, rows)
+}
 
-	for rows.Next() {
-		var r RentalAgreementRentable
-		err = ReadRentalAgreementRentables(rows, &r)
-		if err != nil {
-			return t, err
-		}
-		t = append(t, r)
-	}
-
-	return t, rows.Err()
-}
+//=======================================================
+//  R E N T A B L E   T Y P E
+//=======================================================
 
-// GetRentalAgreementPayorByRBT returns Rental Agreement Payor record matching the supplied
-// RAID, BID, TCID
-func GetRentalAgreementPayorByRBT(ctx context.Context, raid, bid, tcid int64) (RentalAgreementPayor, error) {
+// GetRentableType returns characteristics of the Rentable
+func GetRentableType(ctx context.Context, rtid int64, rt *RentableType) error {
 
 	var (
-		// err error
-		r RentalAgreementPayor
+		err error
 	)
 
 	if getSessionCheck(ctx) {
-		return r, ErrSessionRequired
+		return ErrSessionRequired
 	}
 
 	var row *sql.Row
-	fields := []interface{}{raid, bid, tcid}
+	fields := []interface{}{rtid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementPayorByRBT)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableType)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRentalAgreementPayorByRBT.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetRentableType.QueryRow(fields...)
 	}
-	return r, ReadRentalAgreementPayor(row, &r)
+	err = ReadRentableType(row, rt)
+	if err != nil {
+		return err
+	}
+
+	var cerr error
+	rt.CA, cerr = GetAllCustomAttributes(ctx, ELEMRENTABLETYPE, rtid)
+	if cerr != nil { // it's not really an error if we don't find any custom attributes
+		err = cerr
+	}
+
+	return err
 }
 
-// GetRentalAgreementPayor returns Rental Agreement Payor record matching the supplied id
-func GetRentalAgreementPayor(ctx context.Context, id int64) (RentalAgreementPayor, error) {
+// GetRentableTypeByStyle returns characteristics of the Rentable
+func GetRentableTypeByStyle(ctx context.Context, name string, bid int64) (RentableType, error) {
 
 	var (
 		// err error
-		r RentalAgreementPayor
+		rt RentableType
 	)
 
 	if getSessionCheck(ctx) {
-		return r, ErrSessionRequired
+		return rt, ErrSessionRequired
 	}
 
 	var row *sql.Row
-	fields := []interface{}{id}
+	fields := []interface{}{name, bid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementPayor)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableTypeByStyle)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRentalAgreementPayor.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetRentableTypeByStyle.QueryRow(fields...)
 	}
-	return r, ReadRentalAgreementPayor(row, &r)
+	return rt, ReadRentableType(row, &rt)
 }
 
-// GetRentalAgreementPayorsInRange returns an array of payors (in the form of payors) associated with the supplied RentalAgreement ID
-// during the time range d1-d2
-func GetRentalAgreementPayorsInRange(ctx context.Context, raid int64, d1, d2 *time.Time) ([]RentalAgreementPayor, error) {
+// GetRentableTypeByName returns characteristics of the Rentable
+func GetRentableTypeByName(ctx context.Context, name string, bid int64) (RentableType, error) {
 
 	var (
-		err error
-		t   []RentalAgreementPayor
+		// err error
+		rt RentableType
 	)
 
 	if getSessionCheck(ctx) {
-		return t, ErrSessionRequired
+		return rt, ErrSessionRequired
 	}
 
-	var rows *sql.Rows
-	fields := []interface{}{raid, d1, d2}
+	var row *sql.Row
+	fields := []interface{}{name, bid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementPayorsInRange)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableTypeByName)
 		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
+		row = stmt.QueryRow(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetRentalAgreementPayorsInRange.Query(fields...)
-	}
-
-	if err != nil {
-		return t, err
+		row = RRdb.Prepstmt.GetRentableTypeByName.QueryRow(fields...)
 	}
-	return getRentalAgreementPayorsByRows(ctx, rows)
+	return rt, ReadRentableType(row, &rt)
 }
 
-// GetRentalAgreementPayorsByRAID returns an array of payors (in the form of
-// payors) associated with the supplied RentalAgreement ID
-func GetRentalAgreementPayorsByRAID(ctx context.Context, raid int64) ([]RentalAgreementPayor, error) {
-	var t []RentalAgreementPayor
-	var err error
-	if _, ok := SessionCheck(ctx); !ok {
-		return t, ErrSessionRequired
-	}
+// getBizRentableTypes returns a slice of RentableType indexed by the RTID
+func getBizRentableTypes(bid int64) (map[int64]RentableType, error) {
+
+	var (
+		err error
+		t   = make(map[int64]RentableType)
+	)
 
 	var rows *sql.Rows
-	fields := []interface{}{raid}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementPayorsByRAID)
+	fields := []interface{}{bid}
+	/*if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAllBusinessRentableTypes)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetRentalAgreementPayorsByRAID.Query(fields...)
-	}
-
+		rows, err = RRdb.Prepstmt.GetAllBusinessRentableTypes.Query(fields...)
+	}*/
+	rows, err = RRdb.Prepstmt.GetAllBusinessRentableTypes.Query(fields...)
 	if err != nil {
 		return t, err
 	}
-	return getRentalAgreementPayorsByRows(ctx, rows)
-}
+	defer rows.Close()
 
-// GetRentalAgreementsByPayor returns an array of RentalAgreementPayor where the supplied
-// TCID is a payor on the specified date
-func GetRentalAgreementsByPayor(ctx context.Context, bid, tcid int64) ([]RentalAgreementPayor, error) {
-	var t []RentalAgreementPayor
-	var err error
-	if _, ok := SessionCheck(ctx); !ok {
-		return t, ErrSessionRequired
+	for rows.Next() {
+		var a RentableType
+		err = ReadRentableTypes(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		a.MR = []RentableMarketRate{}
+		err = getRentableMarketRates(&a)
+		if err != nil {
+			return t, err
+		}
+		t[a.RTID] = a
 	}
 
-	var rows *sql.Rows
-	fields := []interface{}{bid, tcid}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementsByPayor)
-		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
-	} else {
-		rows, err = RRdb.Prepstmt.GetRentalAgreementsByPayor.Query(fields...)
+	return t, rows.Err()
+}
+
+// GetBusinessRentableTypes returns a slice of RentableType indexed by the RTID
+func GetBusinessRentableTypes(ctx context.Context, bid int64) (map[int64]RentableType, error) {
+	var t = make(map[int64]RentableType)
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
 	}
+	return getBizRentableTypes(bid)
+}
 
-	if err != nil {
-		return t, err
+// getListOfRentableMarketRates returns a list of RentableMarketRate structs
+// based on the supplied rows.
+//-----------------------------------------------------------------------------
+func getListOfRentableMarketRates(ctx context.Context, rows *sql.Rows) ([]RentableMarketRate, error) {
+	var m []RentableMarketRate
+	var err error
+	for rows.Next() {
+		var a RentableMarketRate
+		if err = ReadRentableMarketRates(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
 	}
-	return getRentalAgreementPayorsByRows(ctx, rows)
+	return m, nil
 }
 
-// GetRentalAgreementsByPayorRange returns an array of RentalAgreementPayor where the supplied
-// TCID is a payor within the supplied range
-func GetRentalAgreementsByPayorRange(ctx context.Context, bid, tcid int64, d1, d2 *time.Time) ([]RentalAgreementPayor, error) {
-	var t []RentalAgreementPayor
+// GetRentableMarketRateByRange returns a list of RentableMarketRate structs
+// for a particular RentableType that occur in the supplied time range
+//-----------------------------------------------------------------------------
+func GetRentableMarketRateByRange(ctx context.Context, RTID int64, d1, d2 *time.Time) ([]RentableMarketRate, error) {
 	var err error
-	if _, ok := SessionCheck(ctx); !ok {
-		return t, ErrSessionRequired
+	var rs []RentableMarketRate
+
+	if getSessionCheck(ctx) {
+		return rs, ErrSessionRequired
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{bid, tcid}
+	fields := []interface{}{RTID, d1, d2, d1}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementsByPayor)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableMarketRateByRange)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetRentalAgreementsByPayor.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetRentableMarketRateByRange.Query(fields...)
 	}
 
 	if err != nil {
-		return t, err
+		return rs, err
 	}
-	return getRentalAgreementPayorsByRows(ctx, rows)
+	return getListOfRentableMarketRates(ctx, rows)
 }
 
-// getRentalAgreementPayorsByRows returns an array of RentalAgreementPayor records
-// that were matched by the supplied sql.Rows
-func getRentalAgreementPayorsByRows(ctx context.Context, rows *sql.Rows) ([]RentalAgreementPayor, error) {
-
-	var (
-		err error
-		t   []RentalAgreementPayor
-	)
+// getRentableMarketRates loads all the MarketRate rent information for this
+// Rentable into an array
+func getRentableMarketRates(rt *RentableType) error {
+	var err error
+	var rows *sql.Rows
+	fields := []interface{}{rt.RTID}
 
-	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return t, ErrSessionRequired
-		}
+	rows, err = RRdb.Prepstmt.GetRentableMarketRates.Query(fields...)
+	if err != nil {
+		return err
 	}
 	defer rows.Close()
 
+	LatestMRDTStart := time.Date(0, 0, 0, 0, 0, 0, 0, time.UTC)
 	for rows.Next() {
-		var r RentalAgreementPayor
-		err = ReadRentalAgreementPayors(rows, &r)
+		var a RentableMarketRate
+		err = ReadRentableMarketRates(rows, &a)
 		if err != nil {
-			return t, err
+			return err
 		}
-		t = append(t, r)
+		if a.DtStart.After(LatestMRDTStart) {
+			LatestMRDTStart = a.DtStart
+			rt.MRCurrent = a.MarketRate
+		}
+		rt.MR = append(rt.MR, a)
 	}
 
-	return t, rows.Err()
+	return rows.Err()
 }
 
-//=======================================================
-//  RENTAL AGREEMENT TEMPLATE
-//=======================================================
-
-// GetRentalAgreementTemplate returns the RentalAgreementTemplate struct for the supplied rental agreement id
-func GetRentalAgreementTemplate(ctx context.Context, ratid int64) (RentalAgreementTemplate, error) {
-
-	var (
-		// err error
-		r RentalAgreementTemplate
-	)
+// GetRentableMarketRates loads all the MarketRate rent information for this Rentable into an array
+func GetRentableMarketRates(ctx context.Context, rt *RentableType) error {
+	if getSessionCheck(ctx) {
+		return ErrSessionRequired
+	}
+	return getRentableMarketRates(rt)
+}
 
-	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return r, ErrSessionRequired
-		}
+// GetRentableMarketRateInstance returns instance of rentableMarketRate for given RMRID
+func GetRentableMarketRateInstance(ctx context.Context, rmrid int64) (RentableMarketRate, error) {
+	var rmr RentableMarketRate
+	if getSessionCheck(ctx) {
+		return rmr, ErrSessionRequired
 	}
 
 	var row *sql.Row
-	fields := []interface{}{ratid}
+	fields := []interface{}{rmrid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementTemplate)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableMarketRateInstance)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRentalAgreementTemplate.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetRentableMarketRateInstance.QueryRow(fields...)
 	}
-	return r, ReadRentalAgreementTemplate(row, &r)
+	return rmr, ReadRentableMarketRate(row, &rmr)
 }
 
-// GetRentalAgreementByRATemplateName returns the RentalAgreementTemplate struct for the supplied rental agreement id
-func GetRentalAgreementByRATemplateName(ctx context.Context, ref string) (RentalAgreementTemplate, error) {
+// GetRentableMarketRate returns the market-rate rent amount for r during the
+// given time range. If the time range is large and spans multiple price
+// changes, the chronologically earliest price that fits in the time range
+// will be returned. It is best to provide as small a timerange d1-d2 as
+// possible to minimize risk of overlap
+//-----------------------------------------------------------------------------
+func GetRentableMarketRate(ctx context.Context, xbiz *XBusiness, RID int64, d1, d2 *time.Time) (float64, error) {
 
 	var (
-		// err error
-		r RentalAgreementTemplate
+		err             error
+		marketRateValue float64
 	)
 
-	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return r, ErrSessionRequired
+	if getSessionCheck(ctx) {
+		return marketRateValue, ErrSessionRequired
+	}
+
+	rtid, err := GetRTIDForDate(ctx, RID, d1) // first thing... find the RTID for this time range
+	if err != nil {
+		return marketRateValue, err
+	}
+
+	mr := xbiz.RT[rtid].MR
+	// Console("GetRentableMarketRate: len(mr) is %d\n", len(mr))
+	for i := 0; i < len(mr); i++ {
+		if DateRangeOverlap(d1, d2, &mr[i].DtStart, &mr[i].DtStop) {
+			return mr[i].MarketRate, err
 		}
 	}
+	return marketRateValue, err
+}
 
-	var row *sql.Row
-	fields := []interface{}{ref}
+// GetRentableUsersInRange returns an array of user (in the form of user)
+// associated with the supplied RentalAgreement ID during the time range d1-d2
+//-----------------------------------------------------------------------------
+func GetRentableUsersInRange(ctx context.Context, rid int64, d1, d2 *time.Time) ([]RentableUser, error) {
+	var err error
+	var t []RentableUser
+
+	if _, ok := SessionCheck(ctx); !ok {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{rid, d1, d2}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementByRATemplateName)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableUsersInRange)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetRentalAgreementByRATemplateName.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetRentableUsersInRange.Query(fields...)
 	}
-	return r, ReadRentalAgreementTemplate(row, &r)
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r RentableUser
+		err = ReadRentableUsers(rows, &r)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, r)
+	}
+
+	return t, rows.Err()
 }
 
 //=======================================================
-//  STRING LIST
+//  R E N T A L   A G R E E M E N T
 //=======================================================
 
-// GetStringList reads a StringList structure based on the supplied StringList id
-func GetStringList(ctx context.Context, id int64, a *StringList) error {
+// GetRentalAgreement returns the RentalAgreement struct for the supplied rental agreement id
+func GetRentalAgreement(ctx context.Context, raid int64) (RentalAgreement, error) {
 
 	var (
-		err error
+		// err error
+		r RentalAgreement
 	)
 
-	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return r, ErrSessionRequired
 	}
 
 	var row *sql.Row
-	fields := []interface{}{id}
+	fields := []interface{}{raid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetStringList)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreement)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetStringList.QueryRow(fields...)
-	}
-	err = ReadStringList(row, a)
-	if err != nil {
-		return err
+		row = RRdb.Prepstmt.GetRentalAgreement.QueryRow(fields...)
 	}
-	return getSLStrings(ctx, a.SLID, a)
+	return r, ReadRentalAgreement(row, &r)
 }
 
-// GetAllStringLists reads all StringList structures belonging to the business with the the supplied id
-func GetAllStringLists(ctx context.Context, id int64) ([]StringList, error) {
-
-	var (
-		err error
-		m   []StringList
-	)
+// GetRentalAgreementsByRange returns all the Rental Agreements for business
+// bid whose agreement period overlaps d1 - d2
+//
+// INPUTS
+//    ctx   - context
+//    bid   - business id
+//    d1,d2 - time range of interest
+//
+// RETURNS
+//    array of rental agreements
+//    any error encountered
+//-----------------------------------------------------------------------------
+func GetRentalAgreementsByRange(ctx context.Context, bid int64, d1, d2 *time.Time) ([]RentalAgreement, error) {
+	var err error
+	var t []RentalAgreement
 
-	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return m, ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{id}
+	fields := []interface{}{bid, d1, d2}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetAllStringLists)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAllRentalAgreementsByRange)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetAllStringLists.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetAllRentalAgreementsByRange.Query(fields...)
 	}
 
 	if err != nil {
-		return m, err
+		return t, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var a StringList
-		err = ReadStringLists(rows, &a)
-		if err != nil {
-			return m, err
-		}
-
-		err = getSLStrings(ctx, a.SLID, &a)
+		var r RentalAgreement
+		err = ReadRentalAgreements(rows, &r)
 		if err != nil {
-			return m, err
+			return t, err
 		}
-		m = append(m, a)
+		t = append(t, r)
 	}
 
-	return m, rows.Err()
+	return t, rows.Err()
 }
 
-// GetStringListByName reads a StringList structure based on the supplied StringList id
-func GetStringListByName(ctx context.Context, bid int64, s string, a *StringList) error {
-
-	var (
-		err error
-	)
+// GetRentalAgreementsByNextRateChange returns the Rental Agreements of
+// business bid with a scheduled RateChange whose NextRateChange falls in
+// d1 - d2 and before the end of the agreement.  Only active agreements and
+// agreements with a notice to move are included.
+//
+// INPUTS
+//    ctx   - context
+//    bid   - business id
+//    d1,d2 - time range of interest
+//
+// RETURNS
+//    array of rental agreements, ordered by NextRateChange
+//    any error encountered
+//-----------------------------------------------------------------------------
+func GetRentalAgreementsByNextRateChange(ctx context.Context, bid int64, d1, d2 *time.Time) ([]RentalAgreement, error) {
+	var err error
+	var t []RentalAgreement
 
-	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
 	}
 
-	var row *sql.Row
-	fields := []interface{}{bid, s}
+	var rows *sql.Rows
+	fields := []interface{}{bid, d1, d2}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetStringListByName)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementsByNextRateChange)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetStringListByName.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetRentalAgreementsByNextRateChange.Query(fields...)
 	}
-	err = ReadStringList(row, a)
+
 	if err != nil {
-		return err
+		return t, err
 	}
+	defer rows.Close()
 
-	return getSLStrings(ctx, a.SLID, a)
-}
+	for rows.Next() {
+		var r RentalAgreement
+		err = ReadRentalAgreements(rows, &r)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, r)
+	}
 
-// GetSLStrings reads all strings with the supplid SLID into a
-func getSLStrings(ctx context.Context, id int64, a *StringList) error {
+	return t, rows.Err()
+}
 
-	var (
-		err error
-	)
+// GetRentalAgreementsByAgreementStop returns the Rental Agreements of
+// business bid whose AgreementStop falls in d1 - d2.  Only active agreements
+// and agreements with a notice to move are included.
+//
+// INPUTS
+//    ctx   - context
+//    bid   - business id
+//    d1,d2 - time range of interest
+//
+// RETURNS
+//    array of rental agreements, ordered by AgreementStop
+//    any error encountered
+//-----------------------------------------------------------------------------
+func GetRentalAgreementsByAgreementStop(ctx context.Context, bid int64, d1, d2 *time.Time) ([]RentalAgreement, error) {
+	var err error
+	var t []RentalAgreement
 
-	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{id}
+	fields := []interface{}{bid, d1, d2}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetSLStrings)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementsByAgreementStop)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetSLStrings.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetRentalAgreementsByAgreementStop.Query(fields...)
 	}
 
 	if err != nil {
-		return err
+		return t, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var p SLString
-		err = ReadSLStrings(rows, &p)
+		var r RentalAgreement
+		err = ReadRentalAgreements(rows, &r)
 		if err != nil {
-			return err
+			return t, err
 		}
-		a.S = append(a.S, p)
+		t = append(t, r)
 	}
 
-	return rows.Err()
+	return t, rows.Err()
 }
 
-//=======================================================
-//  SubAR
-//=======================================================
+// GetMonthToMonthRentalAgreements returns the active Rental Agreements of
+// business bid that are set for month to month renewal and whose
+// AgreementStop is before dt.
+//
+// INPUTS
+//    ctx   - context
+//    bid   - business id
+//    dt    - agreements that stop before this date are returned
+//
+// RETURNS
+//    array of rental agreements, ordered by AgreementStop
+//    any error encountered
+//-----------------------------------------------------------------------------
+func GetMonthToMonthRentalAgreements(ctx context.Context, bid int64, dt *time.Time) ([]RentalAgreement, error) {
+	var err error
+	var t []RentalAgreement
 
-// GetSubAR reads a SubAR structure based on the supplied SubAR id
-func GetSubAR(ctx context.Context, id int64, a *SubAR) error {
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
 
-	var (
-	// err error
+	var rows *sql.Rows
+	fields := []interface{}{bid, dt}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetMonthToMonthRentalAgreements)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetMonthToMonthRentalAgreements.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r RentalAgreement
+		err = ReadRentalAgreements(rows, &r)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, r)
+	}
+
+	return t, rows.Err()
+}
+
+// LoadXRentalAgreement is like GetXRentalAgreement except that it assumes that some of the structure may
+// already be loaded. It only loads those portions that appear not to already be loaded.
+func LoadXRentalAgreement(ctx context.Context, raid int64, r *RentalAgreement, d1, d2 *time.Time) error {
+
+	var (
+		err error
+	)
+
+	if getSessionCheck(ctx) {
+		return ErrSessionRequired
+	}
+
+	if r.RAID != raid {
+		(*r), err = GetRentalAgreement(ctx, raid)
+		if err != nil {
+			return err
+		}
+	}
+
+	t, err := GetRentalAgreementRentables(ctx, raid, d1, d2)
+	if err != nil {
+		return err
+	}
+
+	r.R = make([]XRentable, 0)
+	for i := 0; i < len(t); i++ {
+		var xu XRentable
+		err = GetXRentable(ctx, t[i].RID, &xu)
+		if err != nil {
+			return err
+		}
+		r.R = append(r.R, xu)
+	}
+
+	m, err := GetRentalAgreementPayorsInRange(ctx, raid, d1, d2)
+	if err != nil {
+		return err
+	}
+
+	r.P = make([]XPerson, 0)
+	for i := 0; i < len(m); i++ {
+		var xp XPerson
+		err = GetXPerson(ctx, m[i].TCID, &xp)
+		if err != nil {
+			return err
+		}
+
+		r.P = append(r.P, xp)
+	}
+
+	for j := 0; j < len(r.R); j++ {
+		n, err := GetRentableUsersInRange(ctx, r.R[j].R.RID, d1, d2)
+		if err != nil {
+			return err
+		}
+
+		r.T = make([]XPerson, 0)
+		for i := 0; i < len(n); i++ {
+			var xp XPerson
+			err = GetXPerson(ctx, n[i].TCID, &xp)
+			if err != nil {
+				return err
+			}
+
+			r.T = append(r.T, xp)
+		}
+	}
+
+	return err
+}
+
+// GetRentalAgreementEarliestDate returns the earliest of
+// AgreementStart, PossessionStart, and RentStart
+func GetRentalAgreementEarliestDate(ctx context.Context, a *RentalAgreement) (time.Time, error) {
+
+	var (
+		err error
+		dt  time.Time
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return dt, ErrSessionRequired
+		}
+	}
+
+	dt = a.AgreementStart
+	if a.PossessionStart.Before(dt) {
+		dt = a.PossessionStart
+	}
+	if a.RentStart.Before(dt) {
+		dt = a.RentStart
+	}
+	return dt, err
+}
+
+// GetXRentalAgreement gets the RentalAgreement plus the associated rentables and payors for the
+// time period specified
+func GetXRentalAgreement(ctx context.Context, raid int64, d1, d2 *time.Time) (RentalAgreement, error) {
+
+	var (
+		// err error
+		ra RentalAgreement
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return ra, ErrSessionRequired
+		}
+	}
+
+	return ra, LoadXRentalAgreement(ctx, raid, &ra, d1, d2)
+}
+
+// GetRentalAgreementsFromList takes an array of RentalAgreementRentables and returns map of
+// all the rental agreements referenced. The map is indexed by the RAID
+func GetRentalAgreementsFromList(ctx context.Context, raa *[]RentalAgreementRentable) (map[int64]RentalAgreement, error) {
+
+	var (
+		err error
+		t   = make(map[int64]RentalAgreement)
+	)
+
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	for i := 0; i < len(*raa); i++ {
+		ra, err := GetRentalAgreement(ctx, (*raa)[i].RAID)
+		if err != nil {
+			return t, err
+		}
+
+		if ra.RAID > 0 {
+			t[ra.RAID] = ra
+		}
+	}
+
+	return t, err
+}
+
+// GetAgreementsForRentable returns an array of RentalAgreementRentables associated with the supplied RentableID
+// during the time range d1-d2
+func GetAgreementsForRentable(ctx context.Context, rid int64, d1, d2 *time.Time) ([]RentalAgreementRentable, error) {
+	var err error
+	var t []RentalAgreementRentable
+
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{rid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementsForRentable)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetRentalAgreementsForRentable.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r RentalAgreementRentable
+		err = ReadRentalAgreementRentables(rows, &r)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, r)
+	}
+
+	return t, rows.Err()
+}
+
+// GetRentalAgreementChain returns the list of rental agreements that have
+// the ORIGIN matching the supplied ID as well as the RAID == ID
+//
+func GetRentalAgreementChain(ctx context.Context, origin int64) ([]RentalAgreement, error) {
+	var err error
+	var t []RentalAgreement
+
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{origin, origin}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementChain)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetRentalAgreementChain.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r RentalAgreement
+		err = ReadRentalAgreements(rows, &r)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, r)
+	}
+
+	return t, rows.Err()
+}
+
+// GetRARentableForDate gets the RentalAgreementRentable plus the associated rentables and payors for the
+// time period specified
+func GetRARentableForDate(ctx context.Context, raid int64, d1 *time.Time, rar *RentalAgreementRentable) error {
+
+	var (
+	// err error
+	)
+
+	if getSessionCheck(ctx) {
+		return ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{raid, d1, d1}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRARentableForDate)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetRARentableForDate.QueryRow(fields...)
+	}
+	return ReadRentalAgreementRentable(row, rar)
+}
+
+// GetRentalAgreementRentable returns Rentable record matching the supplied RARID
+func GetRentalAgreementRentable(ctx context.Context, rarid int64) (RentalAgreementRentable, error) {
+
+	var (
+		// err error
+		r RentalAgreementRentable
+	)
+
+	if getSessionCheck(ctx) {
+		return r, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{rarid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementRentable)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetRentalAgreementRentable.QueryRow(fields...)
+	}
+	return r, ReadRentalAgreementRentable(row, &r)
+}
+
+// GetRentalAgreementRentables returns an array of RentalAgreementRentables associated with the supplied RentalAgreement ID
+// during the time range d1-d2
+func GetRentalAgreementRentables(ctx context.Context, raid int64, d1, d2 *time.Time) ([]RentalAgreementRentable, error) {
+
+	var (
+		err error
+		t   []RentalAgreementRentable
+	)
+
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{raid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementRentables)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetRentalAgreementRentables.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r RentalAgreementRentable
+		err = ReadRentalAgreementRentables(rows, &r)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, r)
+	}
+
+	return t, rows.Err()
+}
+
+// GetAllRentalAgreementRentables returns an array of RentalAgreementRentables
+// associated with the supplied RentalAgreement ID
+//
+// INPUTS
+//  ctx - context for txn
+//  raid - which rental agreement
+//
+// RETURNS
+//  an array of rental agr. rentables
+//  any error encountered
+//-----------------------------------------------------------------------------
+func GetAllRentalAgreementRentables(ctx context.Context, raid int64) ([]RentalAgreementRentable, error) {
+
+	var (
+		err error
+		t   []RentalAgreementRentable
+	)
+
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{raid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAllRentalAgreementRentables)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetAllRentalAgreementRentables.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r RentalAgreementRentable
+		err = ReadRentalAgreementRentables(rows, &r)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, r)
+	}
+
+	return t, rows.Err()
+}
+
+// GetRentalAgreementPayorByRBT returns Rental Agreement Payor record matching the supplied
+// RAID, BID, TCID
+func GetRentalAgreementPayorByRBT(ctx context.Context, raid, bid, tcid int64) (RentalAgreementPayor, error) {
+
+	var (
+		// err error
+		r RentalAgreementPayor
+	)
+
+	if getSessionCheck(ctx) {
+		return r, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{raid, bid, tcid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementPayorByRBT)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetRentalAgreementPayorByRBT.QueryRow(fields...)
+	}
+	return r, ReadRentalAgreementPayor(row, &r)
+}
+
+// GetRentalAgreementPayor returns Rental Agreement Payor record matching the supplied id
+func GetRentalAgreementPayor(ctx context.Context, id int64) (RentalAgreementPayor, error) {
+
+	var (
+		// err error
+		r RentalAgreementPayor
+	)
+
+	if getSessionCheck(ctx) {
+		return r, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementPayor)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetRentalAgreementPayor.QueryRow(fields...)
+	}
+	return r, ReadRentalAgreementPayor(row, &r)
+}
+
+// GetRentalAgreementPayorsInRange returns an array of payors (in the form of payors) associated with the supplied RentalAgreement ID
+// during the time range d1-d2
+func GetRentalAgreementPayorsInRange(ctx context.Context, raid int64, d1, d2 *time.Time) ([]RentalAgreementPayor, error) {
+
+	var (
+		err error
+		t   []RentalAgreementPayor
+	)
+
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{raid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementPayorsInRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetRentalAgreementPayorsInRange.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	return getRentalAgreementPayorsByRows(ctx, rows)
+}
+
+// GetRentalAgreementPayorsByRAID returns an array of payors (in the form of
+// payors) associated with the supplied RentalAgreement ID
+func GetRentalAgreementPayorsByRAID(ctx context.Context, raid int64) ([]RentalAgreementPayor, error) {
+	var t []RentalAgreementPayor
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{raid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementPayorsByRAID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetRentalAgreementPayorsByRAID.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	return getRentalAgreementPayorsByRows(ctx, rows)
+}
+
+// GetRentalAgreementsByPayor returns an array of RentalAgreementPayor where the supplied
+// TCID is a payor on the specified date
+func GetRentalAgreementsByPayor(ctx context.Context, bid, tcid int64) ([]RentalAgreementPayor, error) {
+	var t []RentalAgreementPayor
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{bid, tcid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementsByPayor)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetRentalAgreementsByPayor.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	return getRentalAgreementPayorsByRows(ctx, rows)
+}
+
+// GetRentalAgreementsByPayorRange returns an array of RentalAgreementPayor where the supplied
+// TCID is a payor within the supplied range
+func GetRentalAgreementsByPayorRange(ctx context.Context, bid, tcid int64, d1, d2 *time.Time) ([]RentalAgreementPayor, error) {
+	var t []RentalAgreementPayor
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{bid, tcid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementsByPayor)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetRentalAgreementsByPayor.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	return getRentalAgreementPayorsByRows(ctx, rows)
+}
+
+// getRentalAgreementPayorsByRows returns an array of RentalAgreementPayor records
+// that were matched by the supplied sql.Rows
+func getRentalAgreementPayorsByRows(ctx context.Context, rows *sql.Rows) ([]RentalAgreementPayor, error) {
+
+	var (
+		err error
+		t   []RentalAgreementPayor
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return t, ErrSessionRequired
+		}
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r RentalAgreementPayor
+		err = ReadRentalAgreementPayors(rows, &r)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, r)
+	}
+
+	return t, rows.Err()
+}
+
+//=======================================================
+//  RENTAL AGREEMENT TEMPLATE
+//=======================================================
+
+// GetRentalAgreementTemplate returns the RentalAgreementTemplate struct for the supplied rental agreement id
+func GetRentalAgreementTemplate(ctx context.Context, ratid int64) (RentalAgreementTemplate, error) {
+
+	var (
+		// err error
+		r RentalAgreementTemplate
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return r, ErrSessionRequired
+		}
+	}
+
+	var row *sql.Row
+	fields := []interface{}{ratid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementTemplate)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetRentalAgreementTemplate.QueryRow(fields...)
+	}
+	return r, ReadRentalAgreementTemplate(row, &r)
+}
+
+// GetRentalAgreementByRATemplateName returns the RentalAgreementTemplate struct for the supplied rental agreement id
+func GetRentalAgreementByRATemplateName(ctx context.Context, ref string) (RentalAgreementTemplate, error) {
+
+	var (
+		// err error
+		r RentalAgreementTemplate
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return r, ErrSessionRequired
+		}
+	}
+
+	var row *sql.Row
+	fields := []interface{}{ref}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementByRATemplateName)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetRentalAgreementByRATemplateName.QueryRow(fields...)
+	}
+	return r, ReadRentalAgreementTemplate(row, &r)
+}
+
+//=======================================================
+//  R E N E W A L   O F F E R
+//=======================================================
+
+// GetRenewalOffer reads a RenewalOffer structure based on the supplied ROID
+func GetRenewalOffer(ctx context.Context, id int64) (RenewalOffer, error) {
+	var a RenewalOffer
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRenewalOffer)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetRenewalOffer.QueryRow(fields...)
+	}
+	return a, ReadRenewalOffer(row, &a)
+}
+
+// GetRenewalOffersByRAID returns the RenewalOffers of rental agreement
+// raid, by term
+func GetRenewalOffersByRAID(ctx context.Context, raid int64) ([]RenewalOffer, error) {
+	var (
+		err error
+		t   []RenewalOffer
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{raid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRenewalOffersByRAID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetRenewalOffersByRAID.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a RenewalOffer
+		err = ReadRenewalOffers(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+// GetRenewalOffersInRange returns the RenewalOffers of business bid for
+// the terms starting in d1 - d2
+func GetRenewalOffersInRange(ctx context.Context, bid int64, d1 *time.Time, d2 *time.Time) ([]RenewalOffer, error) {
+	var (
+		err error
+		t   []RenewalOffer
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{bid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRenewalOffersInRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetRenewalOffersInRange.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a RenewalOffer
+		err = ReadRenewalOffers(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+//=======================================================
+//  R A T E   R E C O M M E N D A T I O N
+//=======================================================
+
+// GetRateRecommendation reads a RateRecommendation structure based on the supplied RCMID
+func GetRateRecommendation(ctx context.Context, id int64) (RateRecommendation, error) {
+	var a RateRecommendation
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRateRecommendation)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetRateRecommendation.QueryRow(fields...)
+	}
+	return a, ReadRateRecommendation(row, &a)
+}
+
+// GetRateRecommendationsByRTID returns the RateRecommendations of rentable
+// type rtid, by DtStart
+func GetRateRecommendationsByRTID(ctx context.Context, rtid int64) ([]RateRecommendation, error) {
+	var (
+		err error
+		t   []RateRecommendation
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{rtid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRateRecommendationsByRTID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetRateRecommendationsByRTID.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a RateRecommendation
+		err = ReadRateRecommendations(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+// GetRateRecommendationsInRange returns the RateRecommendations of business
+// bid that start in d1 - d2
+func GetRateRecommendationsInRange(ctx context.Context, bid int64, d1 *time.Time, d2 *time.Time) ([]RateRecommendation, error) {
+	var (
+		err error
+		t   []RateRecommendation
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{bid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRateRecommendationsInRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetRateRecommendationsInRange.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a RateRecommendation
+		err = ReadRateRecommendations(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+//=======================================================
+//  O T A   R E S E R V A T I O N
+//=======================================================
+
+// GetOTAReservation reads a OTAReservation structure based on the supplied ORID
+func GetOTAReservation(ctx context.Context, id int64) (OTAReservation, error) {
+	var a OTAReservation
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetOTAReservation)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetOTAReservation.QueryRow(fields...)
+	}
+	return a, ReadOTAReservation(row, &a)
+}
+
+// GetOTAReservationByResID reads the OTAReservation that channel requestor
+// of business bid confirmed as resid
+func GetOTAReservationByResID(ctx context.Context, bid int64, requestor string, resid string) (OTAReservation, error) {
+	var a OTAReservation
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{bid, requestor, resid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetOTAReservationByResID)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetOTAReservationByResID.QueryRow(fields...)
+	}
+	return a, ReadOTAReservation(row, &a)
+}
+
+//=======================================================
+//  F O L I O
+//=======================================================
+
+// GetFolio reads a Folio structure based on the supplied FOLID
+func GetFolio(ctx context.Context, id int64) (Folio, error) {
+	var a Folio
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetFolio)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetFolio.QueryRow(fields...)
+	}
+	return a, ReadFolio(row, &a)
+}
+
+// GetFoliosByRAID returns the Folios of rental agreement raid, by arrival
+func GetFoliosByRAID(ctx context.Context, raid int64) ([]Folio, error) {
+	var (
+		err error
+		t   []Folio
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{raid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetFoliosByRAID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetFoliosByRAID.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a Folio
+		err = ReadFolios(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+// GetFoliosInRange returns the Folios of business bid whose stay overlaps
+// d1 - d2, by arrival
+func GetFoliosInRange(ctx context.Context, bid int64, d1 *time.Time, d2 *time.Time) ([]Folio, error) {
+	var (
+		err error
+		t   []Folio
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{bid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetFoliosInRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetFoliosInRange.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a Folio
+		err = ReadFolios(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+//=======================================================
+//  N I G H T   A U D I T
+//=======================================================
+
+// GetNightAudit reads a NightAudit structure based on the supplied NAID
+func GetNightAudit(ctx context.Context, id int64) (NightAudit, error) {
+	var a NightAudit
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetNightAudit)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetNightAudit.QueryRow(fields...)
+	}
+	return a, ReadNightAudit(row, &a)
+}
+
+// GetLastNightAudit reads the latest NightAudit of business bid.  NAID is
+// 0 if the business was never audited.
+func GetLastNightAudit(ctx context.Context, bid int64) (NightAudit, error) {
+	var a NightAudit
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{bid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetLastNightAudit)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetLastNightAudit.QueryRow(fields...)
+	}
+	return a, ReadNightAudit(row, &a)
+}
+
+// GetNightAuditsInRange returns the NightAudits of business bid for the
+// business dates in d1 - d2
+func GetNightAuditsInRange(ctx context.Context, bid int64, d1 *time.Time, d2 *time.Time) ([]NightAudit, error) {
+	var (
+		err error
+		t   []NightAudit
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{bid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetNightAuditsInRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetNightAuditsInRange.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a NightAudit
+		err = ReadNightAudits(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+//=======================================================
+//  T A X
+//=======================================================
+
+// GetTax reads a Tax structure based on the supplied TAXID
+func GetTax(ctx context.Context, id int64) (Tax, error) {
+	var a Tax
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetTax)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetTax.QueryRow(fields...)
+	}
+	return a, ReadTax(row, &a)
+}
+
+// GetTaxesByBusiness returns the Taxes of business bid by taxing authority
+// and name
+func GetTaxesByBusiness(ctx context.Context, bid int64) ([]Tax, error) {
+	var (
+		err error
+		t   []Tax
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{bid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetTaxesByBusiness)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetTaxesByBusiness.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a Tax
+		err = ReadTaxes(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+// GetTaxRate reads a TaxRate structure based on the supplied TRID
+func GetTaxRate(ctx context.Context, id int64) (TaxRate, error) {
+	var a TaxRate
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetTaxRate)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetTaxRate.QueryRow(fields...)
+	}
+	return a, ReadTaxRate(row, &a)
+}
+
+// GetTaxRates returns the TaxRates of tax taxid in order of their start date
+func GetTaxRates(ctx context.Context, taxid int64) ([]TaxRate, error) {
+	var (
+		err error
+		t   []TaxRate
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{taxid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetTaxRates)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetTaxRates.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a TaxRate
+		err = ReadTaxRates(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+// GetTaxRatesInRange returns the TaxRates of tax taxid in effect during
+// d1 - d2
+func GetTaxRatesInRange(ctx context.Context, taxid int64, d1 *time.Time, d2 *time.Time) ([]TaxRate, error) {
+	var (
+		err error
+		t   []TaxRate
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{taxid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetTaxRatesInRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetTaxRatesInRange.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a TaxRate
+		err = ReadTaxRates(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+// GetRentableTypeTax reads a RentableTypeTax structure based on the supplied RTTAXID
+func GetRentableTypeTax(ctx context.Context, id int64) (RentableTypeTax, error) {
+	var a RentableTypeTax
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableTypeTax)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetRentableTypeTax.QueryRow(fields...)
+	}
+	return a, ReadRentableTypeTax(row, &a)
+}
+
+// GetRentableTypeTaxesByBusiness returns the RentableTypeTaxes of business
+// bid
+func GetRentableTypeTaxesByBusiness(ctx context.Context, bid int64) ([]RentableTypeTax, error) {
+	var (
+		err error
+		t   []RentableTypeTax
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{bid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableTypeTaxesByBusiness)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetRentableTypeTaxesByBusiness.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a RentableTypeTax
+		err = ReadRentableTypeTaxes(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+// GetRentableTypeTaxesInRange returns the RentableTypeTaxes of rentable type
+// rtid in effect during d1 - d2
+func GetRentableTypeTaxesInRange(ctx context.Context, rtid int64, d1 *time.Time, d2 *time.Time) ([]RentableTypeTax, error) {
+	var (
+		err error
+		t   []RentableTypeTax
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{rtid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentableTypeTaxesInRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetRentableTypeTaxesInRange.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a RentableTypeTax
+		err = ReadRentableTypeTaxes(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+// GetRentalAgreementTax reads a RentalAgreementTax structure based on the supplied RATAXID
+func GetRentalAgreementTax(ctx context.Context, id int64) (RentalAgreementTax, error) {
+	var a RentalAgreementTax
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementTax)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetRentalAgreementTax.QueryRow(fields...)
+	}
+	return a, ReadRentalAgreementTax(row, &a)
+}
+
+// GetRentalAgreementTaxesInRange returns the RentalAgreementTaxes of rental
+// agreement raid in effect during d1 - d2
+func GetRentalAgreementTaxesInRange(ctx context.Context, raid int64, d1 *time.Time, d2 *time.Time) ([]RentalAgreementTax, error) {
+	var (
+		err error
+		t   []RentalAgreementTax
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{raid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementTaxesInRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetRentalAgreementTaxesInRange.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a RentalAgreementTax
+		err = ReadRentalAgreementTaxes(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+// GetAssessmentTax reads a AssessmentTax structure based on the supplied ATID
+func GetAssessmentTax(ctx context.Context, id int64) (AssessmentTax, error) {
+	var a AssessmentTax
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAssessmentTax)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetAssessmentTax.QueryRow(fields...)
+	}
+	return a, ReadAssessmentTax(row, &a)
+}
+
+// GetAssessmentTaxesByASMID returns the AssessmentTaxes of assessment asmid
+func GetAssessmentTaxesByASMID(ctx context.Context, asmid int64) ([]AssessmentTax, error) {
+	var (
+		err error
+		t   []AssessmentTax
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{asmid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAssessmentTaxesByASMID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetAssessmentTaxesByASMID.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a AssessmentTax
+		err = ReadAssessmentTaxes(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+// GetAssessmentTaxesByRAID returns the AssessmentTaxes of tax taxid on the
+// assessments of rental agreement raid, by date
+func GetAssessmentTaxesByRAID(ctx context.Context, raid int64, taxid int64) ([]AssessmentTax, error) {
+	var (
+		err error
+		t   []AssessmentTax
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{raid, taxid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAssessmentTaxesByRAID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetAssessmentTaxesByRAID.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a AssessmentTax
+		err = ReadAssessmentTaxes(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+// GetAssessmentTaxesInRange returns the AssessmentTaxes of business bid on
+// the assessments dated in d1 - d2
+func GetAssessmentTaxesInRange(ctx context.Context, bid int64, d1 *time.Time, d2 *time.Time) ([]AssessmentTax, error) {
+	var (
+		err error
+		t   []AssessmentTax
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{bid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAssessmentTaxesInRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetAssessmentTaxesInRange.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a AssessmentTax
+		err = ReadAssessmentTaxes(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+// GetAssessmentTaxesRefundedInRange returns the AssessmentTaxes of business
+// bid that were refunded in d1 - d2
+func GetAssessmentTaxesRefundedInRange(ctx context.Context, bid int64, d1 *time.Time, d2 *time.Time) ([]AssessmentTax, error) {
+	var (
+		err error
+		t   []AssessmentTax
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{bid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAssessmentTaxesRefundedInRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetAssessmentTaxesRefundedInRange.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a AssessmentTax
+		err = ReadAssessmentTaxes(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+//=======================================================
+//  SECURITY DEPOSIT SETTLEMENT
+//  SecDepSettlement, SecDepItem
+//=======================================================
+
+// GetSecDepSettlement reads a SecDepSettlement structure based on the supplied
+// SDSID. The items are not loaded, use GetSecDepItems for them.
+func GetSecDepSettlement(ctx context.Context, id int64) (SecDepSettlement, error) {
+	var a SecDepSettlement
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetSecDepSettlement)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetSecDepSettlement.QueryRow(fields...)
+	}
+	return a, ReadSecDepSettlement(row, &a)
+}
+
+// GetSecDepSettlementsByRAID returns the SecDepSettlements for Rental Agreement
+// raid, most recent first
+func GetSecDepSettlementsByRAID(ctx context.Context, raid int64) ([]SecDepSettlement, error) {
+	var (
+		err error
+		t   []SecDepSettlement
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{raid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetSecDepSettlementsByRAID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetSecDepSettlementsByRAID.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a SecDepSettlement
+		err = ReadSecDepSettlements(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+// GetSecDepItem reads a SecDepItem structure based on the supplied SDIID
+func GetSecDepItem(ctx context.Context, id int64) (SecDepItem, error) {
+	var a SecDepItem
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetSecDepItem)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetSecDepItem.QueryRow(fields...)
+	}
+	return a, ReadSecDepItem(row, &a)
+}
+
+// GetSecDepItems returns the items of SecDepSettlement sdsid. The unpaid
+// assessments are listed before the damages.
+func GetSecDepItems(ctx context.Context, sdsid int64) ([]SecDepItem, error) {
+	var (
+		err error
+		t   []SecDepItem
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{sdsid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetSecDepItems)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetSecDepItems.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a SecDepItem
+		err = ReadSecDepItems(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+//=======================================================
+//  STRING LIST
+//=======================================================
+
+// GetStringList reads a StringList structure based on the supplied StringList id
+func GetStringList(ctx context.Context, id int64, a *StringList) error {
+
+	var (
+		err error
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return ErrSessionRequired
+		}
+	}
+
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetStringList)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetStringList.QueryRow(fields...)
+	}
+	err = ReadStringList(row, a)
+	if err != nil {
+		return err
+	}
+	return getSLStrings(ctx, a.SLID, a)
+}
+
+// GetAllStringLists reads all StringList structures belonging to the business with the the supplied id
+func GetAllStringLists(ctx context.Context, id int64) ([]StringList, error) {
+
+	var (
+		err error
+		m   []StringList
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return m, ErrSessionRequired
+		}
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAllStringLists)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetAllStringLists.Query(fields...)
+	}
+
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a StringList
+		err = ReadStringLists(rows, &a)
+		if err != nil {
+			return m, err
+		}
+
+		err = getSLStrings(ctx, a.SLID, &a)
+		if err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+
+	return m, rows.Err()
+}
+
+// GetStringListByName reads a StringList structure based on the supplied StringList id
+func GetStringListByName(ctx context.Context, bid int64, s string, a *StringList) error {
+
+	var (
+		err error
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return ErrSessionRequired
+		}
+	}
+
+	var row *sql.Row
+	fields := []interface{}{bid, s}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetStringListByName)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetStringListByName.QueryRow(fields...)
+	}
+	err = ReadStringList(row, a)
+	if err != nil {
+		return err
+	}
+
+	return getSLStrings(ctx, a.SLID, a)
+}
+
+// GetSLStrings reads all strings with the supplid SLID into a
+func getSLStrings(ctx context.Context, id int64, a *StringList) error {
+
+	var (
+		err error
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return ErrSessionRequired
+		}
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetSLStrings)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetSLStrings.Query(fields...)
+	}
+
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p SLString
+		err = ReadSLStrings(rows, &p)
+		if err != nil {
+			return err
+		}
+		a.S = append(a.S, p)
+	}
+
+	return rows.Err()
+}
+
+//=======================================================
+//  SubAR
+//=======================================================
+
+// GetSubAR reads a SubAR structure based on the supplied SubAR id
+func GetSubAR(ctx context.Context, id int64, a *SubAR) error {
+
+	var (
+	// err error
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return ErrSessionRequired
+		}
+	}
+
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetSubAR)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetSubAR.QueryRow(fields...)
+	}
+	return ReadSubAR(row, a)
+}
+
+// GetSubARs reads all SubAR structures belonging to the business with the the supplied id
+func GetSubARs(ctx context.Context, id int64) ([]SubAR, error) {
+
+	var (
+		err error
+		m   []SubAR
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return m, ErrSessionRequired
+		}
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetSubARs)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetSubARs.Query(fields...)
+	}
+
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a SubAR
+		err = ReadSubARs(rows, &a)
+		if err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+
+	return m, rows.Err()
+}
+
+//============================================================
+//  TASKS
+//  TaskListDefintion, TaskListDescriptor, TaskList, Task
+//============================================================
+
+// GetTask returns the task with the supplied id
+func GetTask(ctx context.Context, id int64) (Task, error) {
+	var a Task
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetTask)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetTask.QueryRow(fields...)
+	}
+	return a, ReadTask(row, &a)
+}
+
+// GetLatestCompletedTaskList returns the latest completed task list
+// with the parent or epoch equal to id
+func GetLatestCompletedTaskList(ctx context.Context, id int64) (TaskList, error) {
+	var a TaskList
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id, id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetLatestCompletedTaskList)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetLatestCompletedTaskList.QueryRow(fields...)
+	}
+	return a, ReadTaskList(row, &a)
+}
+
+// GetTasks returns a slice of tasks with the supplied id
+func GetTasks(ctx context.Context, id int64) ([]Task, error) {
+	var m []Task
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetTasks)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetTasks.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a Task
+		if err = ReadTasks(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetTaskList returns the tasklist with the supplied id
+func GetTaskList(ctx context.Context, id int64) (TaskList, error) {
+	var a TaskList
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetTaskList)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetTaskList.QueryRow(fields...)
+	}
+	return a, ReadTaskList(row, &a)
+}
+
+// GetTaskListInstanceInRange returns a tasklist instance where
+// the PTLID matches the supplied ptlid and the due date falls in the
+// supplied date range.
+//
+// INPUTS
+//     ctx       - for transactions
+//     id        = Parent TLID - the head of the task list instances
+//     dt1 - dt2 = time period of instance for the due date
+//
+// RETURNS
+//     the tasklist if found, or an empty task list if not found
+//-----------------------------------------------------------------------------
+func GetTaskListInstanceInRange(ctx context.Context, id int64, dt1, dt2 *time.Time) (TaskList, error) {
+	var a TaskList
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id, id, dt1, dt2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetTaskListInstanceInRange)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetTaskListInstanceInRange.QueryRow(fields...)
+	}
+	return a, ReadTaskList(row, &a)
+}
+
+// GetTaskListTypeDown returns the values needed for typedown controls:
+//
+// INPUTS   bid - business
+//            s - string or substring to search for
+//        limit - return no more than this many matches
+//
+// RETURNS a slice of TaskListTypeDowns and an error.
+//----------------------------------------------------------------------------
+func GetTaskListTypeDown(ctx context.Context, bid int64, s string, limit int) ([]TaskListTypeDown, error) {
+	var err error
+	var m []TaskListTypeDown
+
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+
+	s = "%" + s + "%"
+
+	var rows *sql.Rows
+	fields := []interface{}{bid, s, limit}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetTaskListTypeDown)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetTaskListTypeDown.Query(fields...)
+	}
+
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t TaskListTypeDown
+		err = ReadTaskListTypeDown(rows, &t)
+		if err != nil {
+			return m, err
+		}
+		t.Recid = t.TLID
+		m = append(m, t)
+	}
+
+	return m, rows.Err()
+}
+
+// GetTaskDescriptor returns the tasklist with the supplied id
+//----------------------------------------------------------------------------
+func GetTaskDescriptor(ctx context.Context, id int64) (TaskDescriptor, error) {
+	var a TaskDescriptor
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetTaskDescriptor)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetTaskDescriptor.QueryRow(fields...)
+	}
+	return a, ReadTaskDescriptor(row, &a)
+}
+
+// GetTaskListDescriptors reads all TaskListDescriptor structures belonging to
+// the TaskListDefinition with the the supplied id
+//-----------------------------------------------------------------------------
+func GetTaskListDescriptors(ctx context.Context, id int64) ([]TaskDescriptor, error) {
+	var err error
+	var m []TaskDescriptor
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetTaskListDescriptors)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetTaskListDescriptors.Query(fields...)
+	}
+
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a TaskDescriptor
+		if err = ReadTaskDescriptors(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+
+	return m, rows.Err()
+}
+
+// GetAllTaskListDefinitions returns the active tasklist definitions for the
+// supplied bid
+//
+// INPUTS:
+//	id = BID of the business of interest
+//
+// RETURNS
+//  slice of active TaskListDefintions defined for the business
+//  any error encountered
+//-----------------------------------------------------------------------------
+func GetAllTaskListDefinitions(ctx context.Context, id int64) ([]TaskListDefinition, error) {
+	var m []TaskListDefinition
+	var err error
+
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{id}
+
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAllTaskListDefinitions)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetAllTaskListDefinitions.Query(fields...)
+	}
+
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a TaskListDefinition
+		if err = ReadTaskListDefinitions(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+
+	return m, rows.Err()
+}
+
+// GetTaskListDefinition returns the tasklist with the supplied id
+func GetTaskListDefinition(ctx context.Context, id int64) (TaskListDefinition, error) {
+	var a TaskListDefinition
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetTaskListDefinition)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetTaskListDefinition.QueryRow(fields...)
+	}
+	return a, ReadTaskListDefinition(row, &a)
+}
+
+// GetTaskListDefinitionByName returns the tasklist with the supplied namd in the BID
+func GetTaskListDefinitionByName(ctx context.Context, bid int64, name string) (TaskListDefinition, error) {
+	var a TaskListDefinition
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{bid, name}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetTaskListDefinitionByName)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetTaskListDefinitionByName.QueryRow(fields...)
+	}
+	return a, ReadTaskListDefinition(row, &a)
+}
+
+// CheckForTLDInstances returns true if there are any instances of the
+// supplied TLDID or false if there are none.
+//
+// INPUTS:
+//    id = the TLDID to search for
+//
+// RETURNS
+//    count: false if no instances found, true otherwise
+//    error: any error encountered.
+//-----------------------------------------------------------------------------
+func CheckForTLDInstances(ctx context.Context, id int64) (bool, error) {
+	var count int
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return false, ErrSessionRequired
+		}
+	}
+
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.CheckForTLDInstances)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.CheckForTLDInstances.QueryRow(fields...)
+	}
+	return (count > 0), row.Scan(&count)
+}
+
+//=======================================================
+//  TBIND
+//=======================================================
+
+// GetTBind returns the tasklist with the supplied id
+func GetTBind(ctx context.Context, id int64) (TBind, error) {
+	var a TBind
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetTBind)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetTBind.QueryRow(fields...)
+	}
+	return a, ReadTBind(row, &a)
+}
+
+// GetTBindsByRange returns the TBind records that match source type and id
+// as well as the assoc type in the supplied time range
+func GetTBindsByRange(ctx context.Context, bid int64, srcType, srcID, assocType int64, d1, d2 *time.Time) ([]TBind, error) {
+	var a []TBind
+	var rows *sql.Rows
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	fields := []interface{}{bid, srcType, srcID, assocType, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetTBindsByRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetTBindsByRange.Query(fields...)
+	}
+	if err != nil {
+		return a, err
+	}
+	return getTBindsForRows(ctx, rows)
+}
+
+// GetTBindAssocsByRange returns the TBind records that match assoc type and id
+// as well as the source type in the supplied time range
+func GetTBindAssocsByRange(ctx context.Context, bid int64, assocType, assocID, srcType int64, d1, d2 *time.Time) ([]TBind, error) {
+	var a []TBind
+	var rows *sql.Rows
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	fields := []interface{}{bid, assocType, assocID, srcType, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetTBindAssocsByRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetTBindAssocsByRange.Query(fields...)
+	}
+	if err != nil {
+		return a, err
+	}
+	return getTBindsForRows(ctx, rows)
+}
+
+// getTBindsForRows uses the supplied rows param, gets all the TBind records
+// and returns them in a slice of TBind structs
+func getTBindsForRows(ctx context.Context, rows *sql.Rows) ([]TBind, error) {
+	var err error
+	var t []TBind
+
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return t, ErrSessionRequired
+		}
+	}
+	defer rows.Close()
+
+	for i := 0; rows.Next(); i++ {
+		var a TBind
+		err = ReadTBinds(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+//=======================================================
+//  TENANT LOGIN
+//=======================================================
+
+// GetTenantLogin reads a TenantLogin structure based on the supplied TLID
+func GetTenantLogin(ctx context.Context, id int64) (TenantLogin, error) {
+	var a TenantLogin
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetTenantLogin)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetTenantLogin.QueryRow(fields...)
+	}
+	return a, ReadTenantLogin(row, &a)
+}
+
+// GetTenantLoginByUsername reads the TenantLogin of business bid with the
+// supplied username
+func GetTenantLoginByUsername(ctx context.Context, bid int64, username string) (TenantLogin, error) {
+	var a TenantLogin
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{bid, username}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetTenantLoginByUsername)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetTenantLoginByUsername.QueryRow(fields...)
+	}
+	return a, ReadTenantLogin(row, &a)
+}
+
+// GetTenantLoginByTCID reads the TenantLogin of business bid for
+// transactant tcid
+func GetTenantLoginByTCID(ctx context.Context, bid int64, tcid int64) (TenantLogin, error) {
+	var a TenantLogin
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{bid, tcid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetTenantLoginByTCID)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetTenantLoginByTCID.QueryRow(fields...)
+	}
+	return a, ReadTenantLogin(row, &a)
+}
+
+// GetTenantLogins returns all the TenantLogins of business bid
+func GetTenantLogins(ctx context.Context, bid int64) ([]TenantLogin, error) {
+	var (
+		err error
+		t   []TenantLogin
+	)
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{bid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetTenantLogins)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetTenantLogins.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a TenantLogin
+		err = ReadTenantLogins(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+//=======================================================
+//  TRANSACTANT
+//  Transactant, Prospect, User, Payor, XPerson
+//=======================================================
+
+// GetTransactantTypeDown returns the values needed for typedown controls:
+// input:   bid - business
+//            s - string or substring to search for
+//        limit - return no more than this many matches
+// return a slice of TransactantTypeDowns and an error.
+func GetTransactantTypeDown(ctx context.Context, bid int64, s string, limit int) ([]TransactantTypeDown, error) {
+
+	var (
+		err error
+		m   []TransactantTypeDown
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return m, ErrSessionRequired
+		}
+	}
+
+	s = "%" + s + "%"
+
+	var rows *sql.Rows
+	fields := []interface{}{bid, s, s, s, s, limit}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetTransactantTypeDown)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetTransactantTypeDown.Query(fields...)
+	}
+
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t TransactantTypeDown
+		err = ReadTransactantTypeDowns(rows, &t)
+		if err != nil {
+			return m, err
+		}
+		m = append(m, t)
+	}
+
+	return m, rows.Err()
+}
+
+// GetTCIDByNote used to get TCID from Note Comment
+// originally to get it from people csv Notes field
+func GetTCIDByNote(ctx context.Context, cmt string) (int64, error) {
+
+	var (
+		// err  error
+		tcid int64
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return ErrSessionRequired
+			return tcid, ErrSessionRequired
 		}
 	}
 
+	// just return first, in case of duplicate
+	// TODO: need to verify
 	var row *sql.Row
-	fields := []interface{}{id}
+	fields := []interface{}{cmt}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetSubAR)
+		stmt := tx.Stmt(RRdb.Prepstmt.FindTCIDByNote)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetSubAR.QueryRow(fields...)
+		row = RRdb.Prepstmt.FindTCIDByNote.QueryRow(fields...)
 	}
-	return ReadSubAR(row, a)
+	return tcid, row.Scan(&tcid)
 }
 
-// GetSubARs reads all SubAR structures belonging to the business with the the supplied id
-func GetSubARs(ctx context.Context, id int64) ([]SubAR, error) {
+// GetTransactantByPhoneOrEmail searches for a transactoant match on the phone number or email
+func GetTransactantByPhoneOrEmail(ctx context.Context, BID int64, s string) (Transactant, error) {
 
 	var (
-		err error
-		m   []SubAR
+		// err error
+		t Transactant
 	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return m, ErrSessionRequired
+			return t, ErrSessionRequired
 		}
 	}
 
-	var rows *sql.Rows
-	fields := []interface{}{id}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetSubARs)
-		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
-	} else {
-		rows, err = RRdb.Prepstmt.GetSubARs.Query(fields...)
-	}
+	tq := `
+	SELECT
+		{{.SelectClause}}
+	FROM
+		Transactant
+	WHERE
+		BID={{.BID}} AND (
+			WorkPhone="{{.WorkPhone}}" OR
+			CellPhone="{{.CellPhone}}" OR
+			PrimaryEmail="{{.PrimaryEmail}}" OR
+			SecondaryEmail="{{.SecondaryEmail}}"
+		);`
 
-	if err != nil {
-		return m, err
+	qc := QueryClause{
+		"SelectClause":   TRNSfields,
+		"BID":            strconv.FormatInt(BID, 10),
+		"WorkPhone":      s,
+		"CellPhone":      s,
+		"PrimaryEmail":   s,
+		"SecondaryEmail": s,
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var a SubAR
-		err = ReadSubARs(rows, &a)
-		if err != nil {
-			return m, err
-		}
-		m = append(m, a)
-	}
+	// get formatted query
+	p := RenderSQLQuery(tq, qc)
 
-	return m, rows.Err()
+	// there could be multiple people with the same identifying number...
+	// to safeguard, we'll read as a query and accept first match
+	row := RRdb.Dbrr.QueryRow(p)
+	return t, ReadTransactant(row, &t)
 }
 
-//============================================================
-//  TASKS
-//  TaskListDefintion, TaskListDescriptor, TaskList, Task
-//============================================================
+// GetTransactant reads a Transactant structure based on the supplied Transactant id
+func GetTransactant(ctx context.Context, tid int64, t *Transactant) error {
 
-// GetTask returns the task with the supplied id
-func GetTask(ctx context.Context, id int64) (Task, error) {
-	var a Task
-	if _, ok := SessionCheck(ctx); !ok {
-		return a, ErrSessionRequired
+	var (
+	// err error
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return ErrSessionRequired
+		}
 	}
+
 	var row *sql.Row
-	fields := []interface{}{id}
+	fields := []interface{}{tid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetTask)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetTransactant)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetTask.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetTransactant.QueryRow(fields...)
 	}
-	return a, ReadTask(row, &a)
+	return ReadTransactant(row, t)
 }
 
-// GetLatestCompletedTaskList returns the latest completed task list
-// with the parent or epoch equal to id
-func GetLatestCompletedTaskList(ctx context.Context, id int64) (TaskList, error) {
-	var a TaskList
-	if _, ok := SessionCheck(ctx); !ok {
-		return a, ErrSessionRequired
+// GetProspect reads a Prospect structure based on the supplied Transactant id
+func GetProspect(ctx context.Context, id int64, p *Prospect) error {
+
+	var (
+	// err error
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return ErrSessionRequired
+		}
 	}
+
 	var row *sql.Row
-	fields := []interface{}{id, id}
+	fields := []interface{}{id}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetLatestCompletedTaskList)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetProspect)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetLatestCompletedTaskList.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetProspect.QueryRow(fields...)
 	}
-	return a, ReadTaskList(row, &a)
+	return ReadProspect(row, p)
 }
 
-// GetTasks returns a slice of tasks with the supplied id
-func GetTasks(ctx context.Context, id int64) ([]Task, error) {
-	var m []Task
-	var err error
-	if _, ok := SessionCheck(ctx); !ok {
-		return m, ErrSessionRequired
-	}
-	var rows *sql.Rows
-	fields := []interface{}{id}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetTasks)
-		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
-	} else {
-		rows, err = RRdb.Prepstmt.GetTasks.Query(fields...)
-	}
-	if err != nil {
-		return m, err
-	}
-	defer rows.Close()
-	for rows.Next() {
-		var a Task
-		if err = ReadTasks(rows, &a); err != nil {
-			return m, err
+// GetUser reads a User structure based on the supplied User id.
+// This call does not load the vehicle list.  You can use GetVehiclesByTransactant()
+// if you need them.  Or you can call GetXPerson, which loads all details about a Transactant.
+func GetUser(ctx context.Context, tcid int64, t *User) error {
+
+	var (
+	// err error
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return ErrSessionRequired
 		}
-		m = append(m, a)
 	}
-	return m, rows.Err()
-}
 
-// GetTaskList returns the tasklist with the supplied id
-func GetTaskList(ctx context.Context, id int64) (TaskList, error) {
-	var a TaskList
-	if _, ok := SessionCheck(ctx); !ok {
-		return a, ErrSessionRequired
-	}
 	var row *sql.Row
-	fields := []interface{}{id}
+	fields := []interface{}{tcid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetTaskList)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetUser)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetTaskList.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetUser.QueryRow(fields...)
 	}
-	return a, ReadTaskList(row, &a)
+	return ReadUser(row, t)
 }
 
-// GetTaskListInstanceInRange returns a tasklist instance where
-// the PTLID matches the supplied ptlid and the due date falls in the
-// supplied date range.
-//
-// INPUTS
-//     ctx       - for transactions
-//     id        = Parent TLID - the head of the task list instances
-//     dt1 - dt2 = time period of instance for the due date
-//
-// RETURNS
-//     the tasklist if found, or an empty task list if not found
-//-----------------------------------------------------------------------------
-func GetTaskListInstanceInRange(ctx context.Context, id int64, dt1, dt2 *time.Time) (TaskList, error) {
-	var a TaskList
-	if _, ok := SessionCheck(ctx); !ok {
-		return a, ErrSessionRequired
+// GetPayor reads a Payor structure based on the supplied Transactant id
+func GetPayor(ctx context.Context, pid int64, p *Payor) error {
+
+	var (
+	// err error
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return ErrSessionRequired
+		}
 	}
+
 	var row *sql.Row
-	fields := []interface{}{id, id, dt1, dt2}
+	fields := []interface{}{pid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetTaskListInstanceInRange)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetPayor)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetTaskListInstanceInRange.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetPayor.QueryRow(fields...)
 	}
-	return a, ReadTaskList(row, &a)
+	return ReadPayor(row, p)
 }
 
-// GetTaskListTypeDown returns the values needed for typedown controls:
-//
-// INPUTS   bid - business
-//            s - string or substring to search for
-//        limit - return no more than this many matches
-//
-// RETURNS a slice of TaskListTypeDowns and an error.
-//----------------------------------------------------------------------------
-func GetTaskListTypeDown(ctx context.Context, bid int64, s string, limit int) ([]TaskListTypeDown, error) {
-	var err error
-	var m []TaskListTypeDown
+// GetVehicle reads a Vehicle structure based on the supplied Vehicle id
+func GetVehicle(ctx context.Context, vid int64, t *Vehicle) error {
 
-	if _, ok := SessionCheck(ctx); !ok {
-		return m, ErrSessionRequired
-	}
+	var (
+	// err error
+	)
 
-	s = "%" + s + "%"
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return ErrSessionRequired
+		}
+	}
 
-	var rows *sql.Rows
-	fields := []interface{}{bid, s, limit}
+	var row *sql.Row
+	fields := []interface{}{vid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetTaskListTypeDown)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetVehicle)
 		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
+		row = stmt.QueryRow(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetTaskListTypeDown.Query(fields...)
+		row = RRdb.Prepstmt.GetVehicle.QueryRow(fields...)
 	}
+	return ReadVehicle(row, t)
+}
 
-	if err != nil {
-		return m, err
+func getVehicleList(ctx context.Context, rows *sql.Rows) ([]Vehicle, error) {
+
+	var (
+		err error
+		m   []Vehicle
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return m, ErrSessionRequired
+		}
 	}
-	defer rows.Close()
 
 	for rows.Next() {
-		var t TaskListTypeDown
-		err = ReadTaskListTypeDown(rows, &t)
+		var a Vehicle
+		err = ReadVehicles(rows, &a)
 		if err != nil {
 			return m, err
 		}
-		t.Recid = t.TLID
-		m = append(m, t)
+		m = append(m, a)
 	}
 
 	return m, rows.Err()
 }
 
-// GetTaskDescriptor returns the tasklist with the supplied id
-//----------------------------------------------------------------------------
-func GetTaskDescriptor(ctx context.Context, id int64) (TaskDescriptor, error) {
-	var a TaskDescriptor
-	if _, ok := SessionCheck(ctx); !ok {
-		return a, ErrSessionRequired
+// GetVehiclesByLicensePlate reads a Vehicle structure based on the supplied Vehicle id
+func GetVehiclesByLicensePlate(ctx context.Context, s string) ([]Vehicle, error) {
+
+	var (
+		err error
+		t   []Vehicle
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return t, ErrSessionRequired
+		}
 	}
-	var row *sql.Row
-	fields := []interface{}{id}
+
+	var rows *sql.Rows
+	fields := []interface{}{s}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetTaskDescriptor)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetVehiclesByLicensePlate)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetTaskDescriptor.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetVehiclesByLicensePlate.Query(fields...)
 	}
-	return a, ReadTaskDescriptor(row, &a)
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	return getVehicleList(ctx, rows)
 }
 
-// GetTaskListDescriptors reads all TaskListDescriptor structures belonging to
-// the TaskListDefinition with the the supplied id
-//-----------------------------------------------------------------------------
-func GetTaskListDescriptors(ctx context.Context, id int64) ([]TaskDescriptor, error) {
-	var err error
-	var m []TaskDescriptor
-	if _, ok := SessionCheck(ctx); !ok {
-		return m, ErrSessionRequired
+// GetVehiclesByTransactant reads a Vehicle structure based on the supplied Vehicle id
+func GetVehiclesByTransactant(ctx context.Context, tcid int64) ([]Vehicle, error) {
+
+	var (
+		err error
+		t   []Vehicle
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return t, ErrSessionRequired
+		}
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{id}
+	fields := []interface{}{tcid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetTaskListDescriptors)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetVehiclesByTransactant)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetTaskListDescriptors.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetVehiclesByTransactant.Query(fields...)
 	}
 
 	if err != nil {
-		return m, err
+		return t, err
 	}
 	defer rows.Close()
 
-	for rows.Next() {
-		var a TaskDescriptor
-		if err = ReadTaskDescriptors(rows, &a); err != nil {
-			return m, err
-		}
-		m = append(m, a)
-	}
-
-	return m, rows.Err()
+	return getVehicleList(ctx, rows)
 }
 
-// GetAllTaskListDefinitions returns the active tasklist definitions for the
-// supplied bid
-//
-// INPUTS:
-//	id = BID of the business of interest
-//
-// RETURNS
-//  slice of active TaskListDefintions defined for the business
-//  any error encountered
-//-----------------------------------------------------------------------------
-func GetAllTaskListDefinitions(ctx context.Context, id int64) ([]TaskListDefinition, error) {
-	var m []TaskListDefinition
-	var err error
+// GetVehiclesByBID reads a Vehicle structure based on the supplied Vehicle id
+func GetVehiclesByBID(ctx context.Context, bid int64) ([]Vehicle, error) {
 
-	if _, ok := SessionCheck(ctx); !ok {
-		return m, ErrSessionRequired
+	var (
+		err error
+		t   []Vehicle
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return t, ErrSessionRequired
+		}
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{id}
-
+	fields := []interface{}{bid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetAllTaskListDefinitions)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetVehiclesByBID)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetAllTaskListDefinitions.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetVehiclesByBID.Query(fields...)
 	}
 
 	if err != nil {
-		return m, err
+		return t, err
 	}
 	defer rows.Close()
 
-	for rows.Next() {
-		var a TaskListDefinition
-		if err = ReadTaskListDefinitions(rows, &a); err != nil {
-			return m, err
+	return getVehicleList(ctx, rows)
+}
+
+// GetXPerson will load a full XPerson given the trid
+func GetXPerson(ctx context.Context, tcid int64, x *XPerson) error {
+
+	var (
+		err error
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return ErrSessionRequired
 		}
-		m = append(m, a)
 	}
 
-	return m, rows.Err()
-}
+	if 0 == x.Trn.TCID {
+		err = GetTransactant(ctx, tcid, &x.Trn)
+		if err != nil {
+			return err
+		}
+	}
+	if 0 == x.Psp.TCID {
+		err = GetProspect(ctx, tcid, &x.Psp)
+		if err != nil {
+			return err
+		}
+	}
+	if 0 == x.Usr.TCID {
+		err = GetUser(ctx, tcid, &x.Usr)
+		if err != nil {
+			return err
+		}
 
-// GetTaskListDefinition returns the tasklist with the supplied id
-func GetTaskListDefinition(ctx context.Context, id int64) (TaskListDefinition, error) {
-	var a TaskListDefinition
-	if _, ok := SessionCheck(ctx); !ok {
-		return a, ErrSessionRequired
+		x.Usr.Vehicles, err = GetVehiclesByTransactant(ctx, tcid)
+		if err != nil {
+			return err
+		}
 	}
-	var row *sql.Row
-	fields := []interface{}{id}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetTaskListDefinition)
-		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
-	} else {
-		row = RRdb.Prepstmt.GetTaskListDefinition.QueryRow(fields...)
+	if 0 == x.Pay.TCID {
+		err = GetPayor(ctx, tcid, &x.Pay)
+		if err != nil {
+			return err
+		}
 	}
-	return a, ReadTaskListDefinition(row, &a)
+
+	return err
 }
 
-// GetTaskListDefinitionByName returns the tasklist with the supplied namd in the BID
-func GetTaskListDefinitionByName(ctx context.Context, bid int64, name string) (TaskListDefinition, error) {
-	var a TaskListDefinition
-	if _, ok := SessionCheck(ctx); !ok {
-		return a, ErrSessionRequired
+/*// GetDateOfLedgerMarkerOnOrBefore returns the Dt value of the last LedgerMarker set generated on or before d1
+func GetDateOfLedgerMarkerOnOrBefore(ctx context.Context, bid int64, d1 *time.Time) (time.Time, err) {
+
+	var (
+		err error
+		dt  time.Time
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return dt, ErrSessionRequired
+		}
+	}
+
+	var lm LedgerMarker
+	GenRcvLID := RRdb.BizTypes[bid].DefaultAccts[GLGENRCV].LID
+	lm, err = GetLedgerMarkerOnOrBefore(ctx, bid, GenRcvLID, d1)
+
+	// log the error
+	if err != nil {
+		Ulog("%s - SEVERE ERROR - unable to locate a LedgerMarker on or before %s\n", d1.Format(RRDATEFMT4))
+	}
+
+	return lm.Dt, err
+}*/
+
+// GetCountBusinessCustomAttrRefs get total count for CustomAttrRefs
+// with particular associated business
+func GetCountBusinessCustomAttrRefs(ctx context.Context, bid int64) (int, error) {
+
+	var (
+		// err   error
+		count int
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return count, ErrSessionRequired
+		}
 	}
+
 	var row *sql.Row
-	fields := []interface{}{bid, name}
+	fields := []interface{}{bid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetTaskListDefinitionByName)
+		stmt := tx.Stmt(RRdb.Prepstmt.CountBusinessCustomAttrRefs)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetTaskListDefinitionByName.QueryRow(fields...)
+		row = RRdb.Prepstmt.CountBusinessCustomAttrRefs.QueryRow(fields...)
 	}
-	return a, ReadTaskListDefinition(row, &a)
+	return count, row.Scan(&count)
 }
 
-// CheckForTLDInstances returns true if there are any instances of the
-// supplied TLDID or false if there are none.
-//
-// INPUTS:
-//    id = the TLDID to search for
-//
-// RETURNS
-//    count: false if no instances found, true otherwise
-//    error: any error encountered.
-//-----------------------------------------------------------------------------
-func CheckForTLDInstances(ctx context.Context, id int64) (bool, error) {
-	var count int
+// GetCountBusinessCustomAttributes get total count for CustomAttributes
+// with particular associated business
+func GetCountBusinessCustomAttributes(ctx context.Context, bid int64) (int, error) {
+
+	var (
+		// err   error
+		count int
+	)
 
 	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return false, ErrSessionRequired
+			return count, ErrSessionRequired
 		}
 	}
 
 	var row *sql.Row
-	fields := []interface{}{id}
+	fields := []interface{}{bid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.CheckForTLDInstances)
+		stmt := tx.Stmt(RRdb.Prepstmt.CountBusinessCustomAttributes)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.CheckForTLDInstances.QueryRow(fields...)
+		row = RRdb.Prepstmt.CountBusinessCustomAttributes.QueryRow(fields...)
 	}
-	return (count > 0), row.Scan(&count)
+	return count, row.Scan(&count)
 }
 
-//=======================================================
-//  TBIND
-//=======================================================
+// GetCountBusinessRentableTypes get total count for RentableTypes
+// with particular associated business
+func GetCountBusinessRentableTypes(ctx context.Context, bid int64) (int, error) {
 
-// GetTBind returns the tasklist with the supplied id
-func GetTBind(ctx context.Context, id int64) (TBind, error) {
-	var a TBind
-	if _, ok := SessionCheck(ctx); !ok {
-		return a, ErrSessionRequired
+	var (
+		// err   error
+		count int
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return count, ErrSessionRequired
+		}
 	}
+
 	var row *sql.Row
-	fields := []interface{}{id}
+	fields := []interface{}{bid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetTBind)
+		stmt := tx.Stmt(RRdb.Prepstmt.CountBusinessRentableTypes)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetTBind.QueryRow(fields...)
+		row = RRdb.Prepstmt.CountBusinessRentableTypes.QueryRow(fields...)
 	}
-	return a, ReadTBind(row, &a)
+	return count, row.Scan(&count)
 }
 
-// GetTBindsByRange returns the TBind records that match source type and id
-// as well as the assoc type in the supplied time range
-func GetTBindsByRange(ctx context.Context, bid int64, srcType, srcID, assocType int64, d1, d2 *time.Time) ([]TBind, error) {
-	var a []TBind
-	var rows *sql.Rows
-	var err error
-	if _, ok := SessionCheck(ctx); !ok {
-		return a, ErrSessionRequired
+// GetCountBusinessTransactants get total count for Transactants
+// with particular associated business
+func GetCountBusinessTransactants(ctx context.Context, bid int64) (int, error) {
+
+	var (
+		// err   error
+		count int
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return count, ErrSessionRequired
+		}
 	}
-	fields := []interface{}{bid, srcType, srcID, assocType, d1, d2}
+
+	var row *sql.Row
+	fields := []interface{}{bid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetTBindsByRange)
+		stmt := tx.Stmt(RRdb.Prepstmt.CountBusinessTransactants)
 		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
+		row = stmt.QueryRow(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetTBindsByRange.Query(fields...)
-	}
-	if err != nil {
-		return a, err
+		row = RRdb.Prepstmt.CountBusinessTransactants.QueryRow(fields...)
 	}
-	return getTBindsForRows(ctx, rows)
+	return count, row.Scan(&count)
 }
 
-// GetTBindAssocsByRange returns the TBind records that match assoc type and id
-// as well as the source type in the supplied time range
-func GetTBindAssocsByRange(ctx context.Context, bid int64, assocType, assocID, srcType int64, d1, d2 *time.Time) ([]TBind, error) {
-	var a []TBind
-	var rows *sql.Rows
-	var err error
-	if _, ok := SessionCheck(ctx); !ok {
-		return a, ErrSessionRequired
+// GetCountBusinessRentables get total count for Rentables
+// with particular associated business
+func GetCountBusinessRentables(ctx context.Context, bid int64) (int, error) {
+
+	var (
+		// err   error
+		count int
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return count, ErrSessionRequired
+		}
 	}
-	fields := []interface{}{bid, assocType, assocID, srcType, d1, d2}
+
+	var row *sql.Row
+	fields := []interface{}{bid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetTBindAssocsByRange)
+		stmt := tx.Stmt(RRdb.Prepstmt.CountBusinessRentables)
 		defer stmt.Close()
-		rows, err = stmt.Query(fields...)
+		row = stmt.QueryRow(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetTBindAssocsByRange.Query(fields...)
-	}
-	if err != nil {
-		return a, err
+		row = RRdb.Prepstmt.CountBusinessRentables.QueryRow(fields...)
 	}
-	return getTBindsForRows(ctx, rows)
+	return count, row.Scan(&count)
 }
 
-// getTBindsForRows uses the supplied rows param, gets all the TBind records
-// and returns them in a slice of TBind structs
-func getTBindsForRows(ctx context.Context, rows *sql.Rows) ([]TBind, error) {
-	var err error
-	var t []TBind
+// GetCountBusinessRentalAgreements get total count for RentalAgreements
+// with particular associated business
+func GetCountBusinessRentalAgreements(ctx context.Context, bid int64) (int, error) {
+
+	var (
+		// err   error
+		count int
+	)
 
+	// session... context
 	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
 		_, ok := SessionFromContext(ctx)
 		if !ok {
-			return t, ErrSessionRequired
+			return count, ErrSessionRequired
 		}
 	}
-	defer rows.Close()
 
-	for i := 0; rows.Next(); i++ {
-		var a TBind
-		err = ReadTBinds(rows, &a)
-		if err != nil {
-			return t, err
-		}
-		t = append(t, a)
+	var row *sql.Row
+	fields := []interface{}{bid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.CountBusinessRentalAgreements)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.CountBusinessRentalAgreements.QueryRow(fields...)
 	}
-
-	return t, rows.Err()
+	return count, row.Scan(&count)
 }
 
 //=======================================================
-//  TRANSACTANT
-//  Transactant, Prospect, User, Payor, XPerson
+//  WORK ORDER
 //=======================================================
 
-// GetTransactantTypeDown returns the values needed for typedown controls:
-// input:   bid - business
-//            s - string or substring to search for
-//        limit - return no more than this many matches
-// return a slice of TransactantTypeDowns and an error.
-func GetTransactantTypeDown(ctx context.Context, bid int64, s string, limit int) ([]TransactantTypeDown, error) {
+// GetWorkOrder reads a WorkOrder structure based on the supplied WOID
+func GetWorkOrder(ctx context.Context, id int64) (WorkOrder, error) {
+	var a WorkOrder
+
+	// session... context
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
+	}
+
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetWorkOrder)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetWorkOrder.QueryRow(fields...)
+	}
+	return a, ReadWorkOrder(row, &a)
+}
 
+// GetOpenWorkOrders returns the WorkOrders of business bid reported before
+// dt that are not yet completed, closed or canceled, oldest first
+func GetOpenWorkOrders(ctx context.Context, bid int64, dt *time.Time) ([]WorkOrder, error) {
 	var (
 		err error
-		m   []TransactantTypeDown
+		t   []WorkOrder
 	)
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return m, ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
 	}
 
-	s = "%" + s + "%"
-
 	var rows *sql.Rows
-	fields := []interface{}{bid, s, s, s, s, limit}
+	fields := []interface{}{bid, dt}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetTransactantTypeDown)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetOpenWorkOrders)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetTransactantTypeDown.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetOpenWorkOrders.Query(fields...)
 	}
 
 	if err != nil {
-		return m, err
+		return t, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var t TransactantTypeDown
-		err = ReadTransactantTypeDowns(rows, &t)
+		var a WorkOrder
+		err = ReadWorkOrders(rows, &a)
 		if err != nil {
-			return m, err
-		}
-		m = append(m, t)
-	}
-
-	return m, rows.Err()
-}
-
-// GetTCIDByNote used to get TCID from Note Comment
-// originally to get it from people csv Notes field
-func GetTCIDByNote(ctx context.Context, cmt string) (int64, error) {
-
-	var (
-		// err  error
-		tcid int64
-	)
-
-	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return tcid, ErrSessionRequired
+			return t, err
 		}
+		t = append(t, a)
 	}
 
-	// just return first, in case of duplicate
-	// TODO: need to verify
-	var row *sql.Row
-	fields := []interface{}{cmt}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.FindTCIDByNote)
-		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
-	} else {
-		row = RRdb.Prepstmt.FindTCIDByNote.QueryRow(fields...)
-	}
-	return tcid, row.Scan(&tcid)
+	return t, rows.Err()
 }
 
-// GetTransactantByPhoneOrEmail searches for a transactoant match on the phone number or email
-func GetTransactantByPhoneOrEmail(ctx context.Context, BID int64, s string) (Transactant, error) {
-
+// GetWorkOrdersByRID returns the WorkOrders of Rentable rid, most recent
+// first
+func GetWorkOrdersByRID(ctx context.Context, rid int64) ([]WorkOrder, error) {
 	var (
-		// err error
-		t Transactant
+		err error
+		t   []WorkOrder
 	)
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return t, ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
 	}
 
-	tq := `
-	SELECT
-		{{.SelectClause}}
-	FROM
-		Transactant
-	WHERE
-		BID={{.BID}} AND (
-			WorkPhone="{{.WorkPhone}}" OR
-			CellPhone="{{.CellPhone}}" OR
-			PrimaryEmail="{{.PrimaryEmail}}" OR
-			SecondaryEmail="{{.SecondaryEmail}}"
-		);`
+	var rows *sql.Rows
+	fields := []interface{}{rid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetWorkOrdersByRID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetWorkOrdersByRID.Query(fields...)
+	}
 
-	qc := QueryClause{
-		"SelectClause":   TRNSfields,
-		"BID":            strconv.FormatInt(BID, 10),
-		"WorkPhone":      s,
-		"CellPhone":      s,
-		"PrimaryEmail":   s,
-		"SecondaryEmail": s,
+	if err != nil {
+		return t, err
 	}
+	defer rows.Close()
 
-	// get formatted query
-	p := RenderSQLQuery(tq, qc)
+	for rows.Next() {
+		var a WorkOrder
+		err = ReadWorkOrders(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
 
-	// there could be multiple people with the same identifying number...
-	// to safeguard, we'll read as a query and accept first match
-	row := RRdb.Dbrr.QueryRow(p)
-	return t, ReadTransactant(row, &t)
+	return t, rows.Err()
 }
 
-// GetTransactant reads a Transactant structure based on the supplied Transactant id
-func GetTransactant(ctx context.Context, tid int64, t *Transactant) error {
-
+// GetWorkOrdersByMRID returns the WorkOrders opened for the tenant's
+// MaintenanceRequest mrid
+func GetWorkOrdersByMRID(ctx context.Context, mrid int64) ([]WorkOrder, error) {
 	var (
-	// err error
+		err error
+		t   []WorkOrder
 	)
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
 	}
 
-	var row *sql.Row
-	fields := []interface{}{tid}
+	var rows *sql.Rows
+	fields := []interface{}{mrid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetTransactant)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetWorkOrdersByMRID)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetTransactant.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetWorkOrdersByMRID.Query(fields...)
 	}
-	return ReadTransactant(row, t)
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a WorkOrder
+		err = ReadWorkOrders(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
 }
 
-// GetProspect reads a Prospect structure based on the supplied Transactant id
-func GetProspect(ctx context.Context, id int64, p *Prospect) error {
+//=======================================================
+//  ACCOUNTS PAYABLE
+//=======================================================
 
-	var (
-	// err error
-	)
+// GetVendor reads a Vendor structure based on the supplied VID
+func GetVendor(ctx context.Context, id int64) (Vendor, error) {
+	var a Vendor
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
 	}
 
 	var row *sql.Row
 	fields := []interface{}{id}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetProspect)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetVendor)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetProspect.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetVendor.QueryRow(fields...)
 	}
-	return ReadProspect(row, p)
+	return a, ReadVendor(row, &a)
 }
 
-// GetUser reads a User structure based on the supplied User id.
-// This call does not load the vehicle list.  You can use GetVehiclesByTransactant()
-// if you need them.  Or you can call GetXPerson, which loads all details about a Transactant.
-func GetUser(ctx context.Context, tcid int64, t *User) error {
-
+// GetVendorsByBusiness returns the Vendors of business bid ordered by name
+func GetVendorsByBusiness(ctx context.Context, bid int64) ([]Vendor, error) {
 	var (
-	// err error
+		err error
+		t   []Vendor
 	)
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
 	}
 
-	var row *sql.Row
-	fields := []interface{}{tcid}
+	var rows *sql.Rows
+	fields := []interface{}{bid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetUser)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetVendorsByBusiness)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetUser.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetVendorsByBusiness.Query(fields...)
 	}
-	return ReadUser(row, t)
-}
 
-// GetPayor reads a Payor structure based on the supplied Transactant id
-func GetPayor(ctx context.Context, pid int64, p *Payor) error {
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
 
-	var (
-	// err error
-	)
+	for rows.Next() {
+		var a Vendor
+		err = ReadVendors(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
+
+	return t, rows.Err()
+}
+
+// GetVendorBill reads a VendorBill structure based on the supplied VBID
+func GetVendorBill(ctx context.Context, id int64) (VendorBill, error) {
+	var a VendorBill
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
 	}
 
 	var row *sql.Row
-	fields := []interface{}{pid}
+	fields := []interface{}{id}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetPayor)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetVendorBill)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetPayor.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetVendorBill.QueryRow(fields...)
 	}
-	return ReadPayor(row, p)
+	return a, ReadVendorBill(row, &a)
 }
 
-// GetVehicle reads a Vehicle structure based on the supplied Vehicle id
-func GetVehicle(ctx context.Context, vid int64, t *Vehicle) error {
-
+// GetVendorBillsByStatus returns the VendorBills of business bid with the
+// supplied status, earliest due date first
+func GetVendorBillsByStatus(ctx context.Context, bid int64, status int64) ([]VendorBill, error) {
 	var (
-	// err error
+		err error
+		t   []VendorBill
 	)
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
 	}
 
-	var row *sql.Row
-	fields := []interface{}{vid}
+	var rows *sql.Rows
+	fields := []interface{}{bid, status}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetVehicle)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetVendorBillsByStatus)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.GetVehicle.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetVendorBillsByStatus.Query(fields...)
 	}
-	return ReadVehicle(row, t)
-}
-
-func getVehicleList(ctx context.Context, rows *sql.Rows) ([]Vehicle, error) {
 
-	var (
-		err error
-		m   []Vehicle
-	)
-
-	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return m, ErrSessionRequired
-		}
+	if err != nil {
+		return t, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
-		var a Vehicle
-		err = ReadVehicles(rows, &a)
+		var a VendorBill
+		err = ReadVendorBills(rows, &a)
 		if err != nil {
-			return m, err
+			return t, err
 		}
-		m = append(m, a)
+		t = append(t, a)
 	}
 
-	return m, rows.Err()
+	return t, rows.Err()
 }
 
-// GetVehiclesByLicensePlate reads a Vehicle structure based on the supplied Vehicle id
-func GetVehiclesByLicensePlate(ctx context.Context, s string) ([]Vehicle, error) {
-
+// GetVendorBillsByRange returns the VendorBills of business bid dated in
+// d1 - d2
+func GetVendorBillsByRange(ctx context.Context, bid int64, d1 *time.Time, d2 *time.Time) ([]VendorBill, error) {
 	var (
 		err error
-		t   []Vehicle
+		t   []VendorBill
 	)
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return t, ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{s}
+	fields := []interface{}{bid, d1, d2}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetVehiclesByLicensePlate)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetVendorBillsByRange)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetVehiclesByLicensePlate.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetVendorBillsByRange.Query(fields...)
 	}
 
 	if err != nil {
@@ -8049,33 +11420,39 @@ func GetVehiclesByLicensePlate(ctx context.Context, s string) ([]Vehicle, error)
 	}
 	defer rows.Close()
 
-	return getVehicleList(ctx, rows)
-}
+	for rows.Next() {
+		var a VendorBill
+		err = ReadVendorBills(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
 
-// GetVehiclesByTransactant reads a Vehicle structure based on the supplied Vehicle id
-func GetVehiclesByTransactant(ctx context.Context, tcid int64) ([]Vehicle, error) {
+	return t, rows.Err()
+}
 
+// GetUnpaidVendorBills returns the approved VendorBills of business bid
+// dated before dt that were not paid before dt, earliest due date first
+func GetUnpaidVendorBills(ctx context.Context, bid int64, dt *time.Time) ([]VendorBill, error) {
 	var (
 		err error
-		t   []Vehicle
+		t   []VendorBill
 	)
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return t, ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{tcid}
+	fields := []interface{}{bid, dt, dt}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetVehiclesByTransactant)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetUnpaidVendorBills)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetVehiclesByTransactant.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetUnpaidVendorBills.Query(fields...)
 	}
 
 	if err != nil {
@@ -8083,33 +11460,39 @@ func GetVehiclesByTransactant(ctx context.Context, tcid int64) ([]Vehicle, error
 	}
 	defer rows.Close()
 
-	return getVehicleList(ctx, rows)
-}
+	for rows.Next() {
+		var a VendorBill
+		err = ReadVendorBills(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
 
-// GetVehiclesByBID reads a Vehicle structure based on the supplied Vehicle id
-func GetVehiclesByBID(ctx context.Context, bid int64) ([]Vehicle, error) {
+	return t, rows.Err()
+}
 
+// GetPaidVendorBills returns the VendorBills of business bid paid in
+// d1 - d2, ordered by vendor
+func GetPaidVendorBills(ctx context.Context, bid int64, d1 *time.Time, d2 *time.Time) ([]VendorBill, error) {
 	var (
 		err error
-		t   []Vehicle
+		t   []VendorBill
 	)
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return t, ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
 	}
 
 	var rows *sql.Rows
-	fields := []interface{}{bid}
+	fields := []interface{}{bid, d1, d2}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.GetVehiclesByBID)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetPaidVendorBills)
 		defer stmt.Close()
 		rows, err = stmt.Query(fields...)
 	} else {
-		rows, err = RRdb.Prepstmt.GetVehiclesByBID.Query(fields...)
+		rows, err = RRdb.Prepstmt.GetPaidVendorBills.Query(fields...)
 	}
 
 	if err != nil {
@@ -8117,255 +11500,194 @@ func GetVehiclesByBID(ctx context.Context, bid int64) ([]Vehicle, error) {
 	}
 	defer rows.Close()
 
-	return getVehicleList(ctx, rows)
-}
+	for rows.Next() {
+		var a VendorBill
+		err = ReadVendorBills(rows, &a)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, a)
+	}
 
-// GetXPerson will load a full XPerson given the trid
-func GetXPerson(ctx context.Context, tcid int64, x *XPerson) error {
+	return t, rows.Err()
+}
 
+// GetVendorBillsByVID returns the VendorBills of Vendor vid, most recent
+// first
+func GetVendorBillsByVID(ctx context.Context, vid int64) ([]VendorBill, error) {
 	var (
 		err error
+		t   []VendorBill
 	)
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
 	}
 
-	if 0 == x.Trn.TCID {
-		err = GetTransactant(ctx, tcid, &x.Trn)
-		if err != nil {
-			return err
-		}
-	}
-	if 0 == x.Psp.TCID {
-		err = GetProspect(ctx, tcid, &x.Psp)
-		if err != nil {
-			return err
-		}
+	var rows *sql.Rows
+	fields := []interface{}{vid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetVendorBillsByVID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetVendorBillsByVID.Query(fields...)
 	}
-	if 0 == x.Usr.TCID {
-		err = GetUser(ctx, tcid, &x.Usr)
-		if err != nil {
-			return err
-		}
 
-		x.Usr.Vehicles, err = GetVehiclesByTransactant(ctx, tcid)
-		if err != nil {
-			return err
-		}
+	if err != nil {
+		return t, err
 	}
-	if 0 == x.Pay.TCID {
-		err = GetPayor(ctx, tcid, &x.Pay)
+	defer rows.Close()
+
+	for rows.Next() {
+		var a VendorBill
+		err = ReadVendorBills(rows, &a)
 		if err != nil {
-			return err
+			return t, err
 		}
+		t = append(t, a)
 	}
 
-	return err
+	return t, rows.Err()
 }
 
-/*// GetDateOfLedgerMarkerOnOrBefore returns the Dt value of the last LedgerMarker set generated on or before d1
-func GetDateOfLedgerMarkerOnOrBefore(ctx context.Context, bid int64, d1 *time.Time) (time.Time, err) {
-
+// GetVendorBillsByVPRID returns the VendorBills paid by VendorPaymentRun
+// vprid
+func GetVendorBillsByVPRID(ctx context.Context, vprid int64) ([]VendorBill, error) {
 	var (
 		err error
-		dt  time.Time
+		t   []VendorBill
 	)
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return dt, ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
 	}
 
-	var lm LedgerMarker
-	GenRcvLID := RRdb.BizTypes[bid].DefaultAccts[GLGENRCV].LID
-	lm, err = GetLedgerMarkerOnOrBefore(ctx, bid, GenRcvLID, d1)
+	var rows *sql.Rows
+	fields := []interface{}{vprid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetVendorBillsByVPRID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetVendorBillsByVPRID.Query(fields...)
+	}
 
-	// log the error
 	if err != nil {
-		Ulog("%s - SEVERE ERROR - unable to locate a LedgerMarker on or before %s\n", d1.Format(RRDATEFMT4))
+		return t, err
 	}
+	defer rows.Close()
 
-	return lm.Dt, err
-}*/
-
-// GetCountBusinessCustomAttrRefs get total count for CustomAttrRefs
-// with particular associated business
-func GetCountBusinessCustomAttrRefs(ctx context.Context, bid int64) (int, error) {
-
-	var (
-		// err   error
-		count int
-	)
-
-	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return count, ErrSessionRequired
+	for rows.Next() {
+		var a VendorBill
+		err = ReadVendorBills(rows, &a)
+		if err != nil {
+			return t, err
 		}
+		t = append(t, a)
 	}
 
-	var row *sql.Row
-	fields := []interface{}{bid}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.CountBusinessCustomAttrRefs)
-		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
-	} else {
-		row = RRdb.Prepstmt.CountBusinessCustomAttrRefs.QueryRow(fields...)
-	}
-	return count, row.Scan(&count)
+	return t, rows.Err()
 }
 
-// GetCountBusinessCustomAttributes get total count for CustomAttributes
-// with particular associated business
-func GetCountBusinessCustomAttributes(ctx context.Context, bid int64) (int, error) {
-
+// GetVendorBillLines returns the lines of VendorBill vbid
+func GetVendorBillLines(ctx context.Context, vbid int64) ([]VendorBillLine, error) {
 	var (
-		// err   error
-		count int
+		err error
+		t   []VendorBillLine
 	)
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return count, ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
 	}
 
-	var row *sql.Row
-	fields := []interface{}{bid}
+	var rows *sql.Rows
+	fields := []interface{}{vbid}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.CountBusinessCustomAttributes)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetVendorBillLines)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.CountBusinessCustomAttributes.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetVendorBillLines.Query(fields...)
 	}
-	return count, row.Scan(&count)
-}
-
-// GetCountBusinessRentableTypes get total count for RentableTypes
-// with particular associated business
-func GetCountBusinessRentableTypes(ctx context.Context, bid int64) (int, error) {
 
-	var (
-		// err   error
-		count int
-	)
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
 
-	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return count, ErrSessionRequired
+	for rows.Next() {
+		var a VendorBillLine
+		err = ReadVendorBillLines(rows, &a)
+		if err != nil {
+			return t, err
 		}
+		t = append(t, a)
 	}
 
-	var row *sql.Row
-	fields := []interface{}{bid}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.CountBusinessRentableTypes)
-		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
-	} else {
-		row = RRdb.Prepstmt.CountBusinessRentableTypes.QueryRow(fields...)
-	}
-	return count, row.Scan(&count)
+	return t, rows.Err()
 }
 
-// GetCountBusinessTransactants get total count for Transactants
-// with particular associated business
-func GetCountBusinessTransactants(ctx context.Context, bid int64) (int, error) {
-
-	var (
-		// err   error
-		count int
-	)
+// GetVendorPaymentRun reads a VendorPaymentRun structure based on the supplied VPRID
+func GetVendorPaymentRun(ctx context.Context, id int64) (VendorPaymentRun, error) {
+	var a VendorPaymentRun
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return count, ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return a, ErrSessionRequired
 	}
 
 	var row *sql.Row
-	fields := []interface{}{bid}
+	fields := []interface{}{id}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.CountBusinessTransactants)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetVendorPaymentRun)
 		defer stmt.Close()
 		row = stmt.QueryRow(fields...)
 	} else {
-		row = RRdb.Prepstmt.CountBusinessTransactants.QueryRow(fields...)
+		row = RRdb.Prepstmt.GetVendorPaymentRun.QueryRow(fields...)
 	}
-	return count, row.Scan(&count)
+	return a, ReadVendorPaymentRun(row, &a)
 }
 
-// GetCountBusinessRentables get total count for Rentables
-// with particular associated business
-func GetCountBusinessRentables(ctx context.Context, bid int64) (int, error) {
-
+// GetVendorPaymentRuns returns the VendorPaymentRuns of business bid
+// dated in d1 - d2
+func GetVendorPaymentRuns(ctx context.Context, bid int64, d1 *time.Time, d2 *time.Time) ([]VendorPaymentRun, error) {
 	var (
-		// err   error
-		count int
+		err error
+		t   []VendorPaymentRun
 	)
 
 	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return count, ErrSessionRequired
-		}
+	if getSessionCheck(ctx) {
+		return t, ErrSessionRequired
 	}
 
-	var row *sql.Row
-	fields := []interface{}{bid}
+	var rows *sql.Rows
+	fields := []interface{}{bid, d1, d2}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.CountBusinessRentables)
+		stmt := tx.Stmt(RRdb.Prepstmt.GetVendorPaymentRuns)
 		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
+		rows, err = stmt.Query(fields...)
 	} else {
-		row = RRdb.Prepstmt.CountBusinessRentables.QueryRow(fields...)
+		rows, err = RRdb.Prepstmt.GetVendorPaymentRuns.Query(fields...)
 	}
-	return count, row.Scan(&count)
-}
 
-// GetCountBusinessRentalAgreements get total count for RentalAgreements
-// with particular associated business
-func GetCountBusinessRentalAgreements(ctx context.Context, bid int64) (int, error) {
-
-	var (
-		// err   error
-		count int
-	)
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
 
-	// session... context
-	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
-		_, ok := SessionFromContext(ctx)
-		if !ok {
-			return count, ErrSessionRequired
+	for rows.Next() {
+		var a VendorPaymentRun
+		err = ReadVendorPaymentRuns(rows, &a)
+		if err != nil {
+			return t, err
 		}
+		t = append(t, a)
 	}
 
-	var row *sql.Row
-	fields := []interface{}{bid}
-	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.CountBusinessRentalAgreements)
-		defer stmt.Close()
-		row = stmt.QueryRow(fields...)
-	} else {
-		row = RRdb.Prepstmt.CountBusinessRentalAgreements.QueryRow(fields...)
-	}
-	return count, row.Scan(&count)
+	return t, rows.Err()
 }
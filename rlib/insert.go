@@ -125,6 +125,72 @@ func InsertAssessment(ctx context.Context, a *Assessment) (int64, error) {
 	return rid, err
 }
 
+// InsertAssessmentTax writes a new AssessmentTax record to the database
+func InsertAssessmentTax(ctx context.Context, a *AssessmentTax) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.ASMID, a.BID, a.RAID, a.TAXID, a.TaxASMID, a.Dt, a.Base, a.Amount, a.FLAGS, a.DtRefund, a.OverrideTaxApprover, a.OverrideAmount, a.Comment, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertAssessmentTax)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertAssessmentTax.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.ATID = rid
+		}
+	} else {
+		err = insertError(err, "AssessmentTax", *a)
+	}
+	return rid, err
+}
+
+// InsertBudget writes a new Budget record to the database
+func InsertBudget(ctx context.Context, a *Budget) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.BID, a.LID, a.Dt, a.Amount, a.Comment, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertBudget)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertBudget.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.BGTID = rid
+		}
+	} else {
+		err = insertError(err, "Budget", *a)
+	}
+	return rid, err
+}
+
 // InsertBuilding writes a new Building record to the database
 func InsertBuilding(ctx context.Context, a *Building) (int64, error) {
 	var rid = int64(0)
@@ -270,6 +336,72 @@ func InsertBusinessProperties(ctx context.Context, a *BusinessProperties) (int64
 	return rid, err
 }
 
+// InsertCAMReconLine writes a new CAMReconLine record to the database
+func InsertCAMReconLine(ctx context.Context, a *CAMReconLine) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.CAMRID, a.BID, a.LID, a.Expenses, a.BaseExpenses, a.Share, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertCAMReconLine)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertCAMReconLine.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.CAMRLID = rid
+		}
+	} else {
+		err = insertError(err, "CAMReconLine", *a)
+	}
+	return rid, err
+}
+
+// InsertCAMReconciliation writes a new CAMReconciliation record to the database
+func InsertCAMReconciliation(ctx context.Context, a *CAMReconciliation) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.BID, a.RAID, a.RID, a.TCID, a.Dt1, a.Dt2, a.LeaseType, a.ExpenseAdjustmentType, a.SqFt, a.TotalSqFt, a.Occupancy, a.Expenses, a.BaseExpenses, a.ExpensesStop, a.Share, a.Estimated, a.TrueUp, a.ASMID, a.RCPTID, a.FLAGS, a.Comment, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertCAMReconciliation)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertCAMReconciliation.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.CAMRID = rid
+		}
+	} else {
+		err = insertError(err, "CAMReconciliation", *a)
+	}
+	return rid, err
+}
+
 // InsertClosePeriod writes a new User record to the database
 func InsertClosePeriod(ctx context.Context, a *ClosePeriod) (int64, error) {
 	var rid = int64(0)
@@ -532,6 +664,191 @@ func InsertDepository(ctx context.Context, a *Depository) (int64, error) {
 	return rid, err
 }
 
+// InsertBankStatement writes a new BankStatement record to the database
+func InsertBankStatement(ctx context.Context, a *BankStatement) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.BID, a.DEPID, a.FileName, a.DtStart, a.DtStop, a.ClosingBalance, a.FLAGS, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertBankStatement)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertBankStatement.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.BSID = rid
+		}
+	} else {
+		err = insertError(err, "BankStatement", *a)
+	}
+	return rid, err
+}
+
+// InsertACHEnrollment writes a new ACHEnrollment record to the database.
+// The routing and account numbers are encrypted before they are saved.
+func InsertACHEnrollment(ctx context.Context, a *ACHEnrollment) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	r, n, err := encryptACHEnrollmentAcct(a)
+	if err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.BID, a.TCID, a.AccountName, r, n, a.MaxAmount, a.DtStart, a.DtStop, a.FLAGS, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertACHEnrollment)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertACHEnrollment.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.AEID = rid
+		}
+	} else {
+		err = insertError(err, "ACHEnrollment", *a)
+	}
+	return rid, err
+}
+
+// encryptACHEnrollmentAcct returns the encrypted, hex encoded routing and
+// account numbers of a
+func encryptACHEnrollmentAcct(a *ACHEnrollment) (string, string, error) {
+	r1, err := Encrypt(a.RoutingNumber)
+	if err != nil {
+		return "", "", err
+	}
+	n1, err := Encrypt(a.AccountNumber)
+	if err != nil {
+		return "", "", err
+	}
+	return hex.EncodeToString(r1), hex.EncodeToString(n1), nil
+}
+
+// InsertACHBatch writes a new ACHBatch record to the database
+func InsertACHBatch(ctx context.Context, a *ACHBatch) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.BID, a.Dt, a.EntryCount, a.TotalAmount, a.FLAGS, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertACHBatch)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertACHBatch.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.ABID = rid
+		}
+	} else {
+		err = insertError(err, "ACHBatch", *a)
+	}
+	return rid, err
+}
+
+// InsertACHEntry writes a new ACHEntry record to the database
+func InsertACHEntry(ctx context.Context, a *ACHEntry) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.ABID, a.BID, a.AEID, a.TCID, a.RCPTID, a.Amount, a.TraceNumber, a.ReturnCode, a.FLAGS, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertACHEntry)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertACHEntry.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.AENID = rid
+		}
+	} else {
+		err = insertError(err, "ACHEntry", *a)
+	}
+	return rid, err
+}
+
+// InsertBankStatementLine writes a new BankStatementLine record to the database
+func InsertBankStatementLine(ctx context.Context, a *BankStatementLine) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.BSID, a.BID, a.DEPID, a.Dt, a.Amount, a.FITID, a.Description, a.DID, a.FLAGS, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertBankStatementLine)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertBankStatementLine.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.BSLID = rid
+		}
+	} else {
+		err = insertError(err, "BankStatementLine", *a)
+	}
+	return rid, err
+}
+
 //======================================
 //  EXPENSE
 //======================================
@@ -570,6 +887,43 @@ func InsertExpense(ctx context.Context, a *Expense) (int64, error) {
 	return rid, err
 }
 
+//======================================
+//  FISCAL YEAR CLOSE
+//======================================
+
+// InsertFiscalYearClose writes a new FiscalYearClose record to the database
+func InsertFiscalYearClose(ctx context.Context, a *FiscalYearClose) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.BID, a.DtStart, a.DtStop, a.Dt, a.LID, a.JID, a.NetIncome, a.FLAGS, a.RevJID, a.DtReopened, a.ReopenedBy, a.Reason, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertFiscalYearClose)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertFiscalYearClose.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.FYCID = rid
+		}
+	} else {
+		err = insertError(err, "FiscalYearClose", *a)
+	}
+	return rid, err
+}
+
 //======================================
 //  FLOW
 //======================================
@@ -595,11 +949,81 @@ func InsertFlow(ctx context.Context, a *Flow) (int64, error) {
 	// in mysql `json` type column
 	fields := []interface{}{a.BID, a.UserRefNo, a.FlowType, a.ID, []byte(a.Data), a.CreateBy, a.LastModBy}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.InsertFlow)
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertFlow)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertFlow.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.FlowID = rid
+		}
+	} else {
+		err = insertError(err, "Flow", *a)
+	}
+	return rid, err
+}
+
+//======================================
+//  ACCOUNT MAP
+//======================================
+
+// InsertAcctMap writes a new AcctMap record to the database
+func InsertAcctMap(ctx context.Context, a *AcctMap) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.BID, a.LID, a.GLNumber, a.Name, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertAcctMap)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertAcctMap.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.AMID = rid
+		}
+	} else {
+		err = insertError(err, "AcctMap", *a)
+	}
+	return rid, err
+}
+
+// InsertFolio writes a new Folio record to the database
+func InsertFolio(ctx context.Context, a *Folio) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.BID, a.RAID, a.RID, a.RTID, a.TCID, a.DtArrive, a.DtDepart, a.Adults, a.Children, a.Rate, a.PostedThrough, a.Status, a.Comment, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertFolio)
 		defer stmt.Close()
 		res, err = stmt.Exec(fields...)
 	} else {
-		res, err = RRdb.Prepstmt.InsertFlow.Exec(fields...)
+		res, err = RRdb.Prepstmt.InsertFolio.Exec(fields...)
 	}
 
 	// After getting result...
@@ -607,10 +1031,10 @@ func InsertFlow(ctx context.Context, a *Flow) (int64, error) {
 		x, err := res.LastInsertId()
 		if err == nil {
 			rid = int64(x)
-			a.FlowID = rid
+			a.FOLID = rid
 		}
 	} else {
-		err = insertError(err, "Flow", *a)
+		err = insertError(err, "Folio", *a)
 	}
 	return rid, err
 }
@@ -931,6 +1355,72 @@ func InsertLedger(ctx context.Context, a *GLAccount) (int64, error) {
 // NOTE
 //======================================
 
+// InsertMaintenanceRequest writes a new MaintenanceRequest record to the database
+func InsertMaintenanceRequest(ctx context.Context, a *MaintenanceRequest) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.BID, a.RAID, a.RID, a.TCID, a.Dt, a.Category, a.Description, a.ContactPhone, a.Status, a.Response, a.FLAGS, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertMaintenanceRequest)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertMaintenanceRequest.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.MRID = rid
+		}
+	} else {
+		err = insertError(err, "MaintenanceRequest", *a)
+	}
+	return rid, err
+}
+
+// InsertNightAudit writes a new NightAudit record to the database
+func InsertNightAudit(ctx context.Context, a *NightAudit) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.BID, a.Dt, a.Rooms, a.Occupied, a.Arrivals, a.Departures, a.RoomRevenue, a.TaxRevenue, a.Comment, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertNightAudit)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertNightAudit.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.NAID = rid
+		}
+	} else {
+		err = insertError(err, "NightAudit", *a)
+	}
+	return rid, err
+}
+
 // InsertNote writes a new Note to the database
 func InsertNote(ctx context.Context, a *Note) (int64, error) {
 	var rid = int64(0)
@@ -1038,6 +1528,39 @@ func InsertNoteType(ctx context.Context, a *NoteType) (int64, error) {
 	return rid, err
 }
 
+// InsertOTAReservation writes a new OTAReservation record to the database
+func InsertOTAReservation(ctx context.Context, a *OTAReservation) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.BID, a.RequestorID, a.ResID, a.RID, a.RTID, a.DtStart, a.DtStop, a.ConfirmationCode, a.Status, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertOTAReservation)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertOTAReservation.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.ORID = rid
+		}
+	} else {
+		err = insertError(err, "OTAReservation", *a)
+	}
+	return rid, err
+}
+
 //=======================================================
 //  RATE PLAN
 //=======================================================
@@ -1174,6 +1697,39 @@ func InsertRatePlanRefSPRate(ctx context.Context, a *RatePlanRefSPRate) (int64,
 	return rid, err
 }
 
+// InsertRateRecommendation writes a new RateRecommendation record to the database
+func InsertRateRecommendation(ctx context.Context, a *RateRecommendation) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.BID, a.RTID, a.DtStart, a.DtStop, a.CurrentRate, a.RecommendedRate, a.AppliedRate, a.Rentables, a.Occupied, a.MoveOuts, a.AvgDaysVacant, a.SeasonalPct, a.Status, a.Comment, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertRateRecommendation)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertRateRecommendation.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.RCMID = rid
+		}
+	} else {
+		err = insertError(err, "RateRecommendation", *a)
+	}
+	return rid, err
+}
+
 //=======================================================
 //  PAYMENT
 //=======================================================
@@ -1317,6 +1873,39 @@ func InsertReceiptAllocation(ctx context.Context, a *ReceiptAllocation) (int64,
 	return rid, err
 }
 
+// InsertRentableTypeTax writes a new RentableTypeTax record to the database
+func InsertRentableTypeTax(ctx context.Context, a *RentableTypeTax) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.RTID, a.BID, a.TAXID, a.DtStart, a.DtStop, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertRentableTypeTax)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertRentableTypeTax.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.RTTAXID = rid
+		}
+	} else {
+		err = insertError(err, "RentableTypeTax", *a)
+	}
+	return rid, err
+}
+
 // InsertRentalAgreement writes a new RentalAgreement record to the database
 func InsertRentalAgreement(ctx context.Context, a *RentalAgreement) (int64, error) {
 	var rid = int64(0)
@@ -1508,6 +2097,76 @@ func InsertRentalAgreementRentable(ctx context.Context, a *RentalAgreementRentab
 	return rid, err
 }
 
+//=======================================================
+//  RENEWAL OFFER
+//=======================================================
+
+// InsertRenewalOffer writes a new RenewalOffer record to the database
+func InsertRenewalOffer(ctx context.Context, a *RenewalOffer) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.BID, a.RAID, a.RID, a.ASMID, a.ARID, a.CurrentRent, a.MarketRent, a.OfferRent, a.TermStart, a.TermStop, a.OfferDt, a.ExpireDt, a.Status, a.NewRAID, a.Comment, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertRenewalOffer)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertRenewalOffer.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.ROID = rid
+		}
+	} else {
+		err = insertError(err, "RenewalOffer", *a)
+	}
+	return rid, err
+}
+
+// InsertRentalAgreementTax writes a new RentalAgreementTax record to the database
+func InsertRentalAgreementTax(ctx context.Context, a *RentalAgreementTax) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.RAID, a.BID, a.DtStart, a.DtStop, a.FLAGS, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertRentalAgreementTax)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertRentalAgreementTax.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.RATAXID = rid
+		}
+	} else {
+		err = insertError(err, "RentalAgreementTax", *a)
+	}
+	return rid, err
+}
+
 //=======================================================
 //  RENTAL AGREEMENT TEMPLATE
 //=======================================================
@@ -1705,14 +2364,81 @@ func InsertRentableLeaseStatus(ctx context.Context, a *RentableLeaseStatus) (int
 			a.RLID = rid
 		}
 	} else {
-		err = insertError(err, "RentableLeaseStatus", *a)
+		err = insertError(err, "RentableLeaseStatus", *a)
+	}
+	return rid, err
+
+}
+
+// InsertRentableTypeRef writes a new RentableTypeRef record to the database
+func InsertRentableTypeRef(ctx context.Context, a *RentableTypeRef) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.RID, a.BID, a.RTID, a.OverrideRentCycle, a.OverrideProrationCycle, a.DtStart, a.DtStop, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertRentableTypeRef)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertRentableTypeRef.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.RTRID = rid
+		}
+	} else {
+		err = insertError(err, "RentableTypeRef", *a)
+	}
+	return rid, err
+}
+
+// InsertRentableUseStatus writes a new RentableUseStatus record to the database
+func InsertRentableUseStatus(ctx context.Context, a *RentableUseStatus) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.RID, a.BID, a.DtStart, a.DtStop, a.Comment, a.UseStatus /*a.LeaseStatus,*/, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertRentableUseStatus)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertRentableUseStatus.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.RSID = rid
+		}
+	} else {
+		err = insertError(err, "RentableUseStatus", *a)
 	}
 	return rid, err
 
 }
 
-// InsertRentableTypeRef writes a new RentableTypeRef record to the database
-func InsertRentableTypeRef(ctx context.Context, a *RentableTypeRef) (int64, error) {
+// InsertRentableUseType writes a new RentableUseType record to the database
+func InsertRentableUseType(ctx context.Context, a *RentableUseType) (int64, error) {
 	var rid = int64(0)
 	var err error
 	var res sql.Result
@@ -1722,13 +2448,13 @@ func InsertRentableTypeRef(ctx context.Context, a *RentableTypeRef) (int64, erro
 	}
 
 	// transaction... context
-	fields := []interface{}{a.RID, a.BID, a.RTID, a.OverrideRentCycle, a.OverrideProrationCycle, a.DtStart, a.DtStop, a.CreateBy, a.LastModBy}
+	fields := []interface{}{a.RID, a.BID, a.DtStart, a.DtStop, a.Comment, a.UseType, a.CreateBy, a.LastModBy}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.InsertRentableTypeRef)
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertRentableUseType)
 		defer stmt.Close()
 		res, err = stmt.Exec(fields...)
 	} else {
-		res, err = RRdb.Prepstmt.InsertRentableTypeRef.Exec(fields...)
+		res, err = RRdb.Prepstmt.InsertRentableUseType.Exec(fields...)
 	}
 
 	// After getting result...
@@ -1736,16 +2462,17 @@ func InsertRentableTypeRef(ctx context.Context, a *RentableTypeRef) (int64, erro
 		x, err := res.LastInsertId()
 		if err == nil {
 			rid = int64(x)
-			a.RTRID = rid
+			a.UTID = rid
 		}
 	} else {
-		err = insertError(err, "RentableTypeRef", *a)
+		err = insertError(err, "RentableUseType", *a)
 	}
 	return rid, err
+
 }
 
-// InsertRentableUseStatus writes a new RentableUseStatus record to the database
-func InsertRentableUseStatus(ctx context.Context, a *RentableUseStatus) (int64, error) {
+// InsertRentableUser writes a new User record to the database
+func InsertRentableUser(ctx context.Context, a *RentableUser) (int64, error) {
 	var rid = int64(0)
 	var err error
 	var res sql.Result
@@ -1755,13 +2482,13 @@ func InsertRentableUseStatus(ctx context.Context, a *RentableUseStatus) (int64,
 	}
 
 	// transaction... context
-	fields := []interface{}{a.RID, a.BID, a.DtStart, a.DtStop, a.Comment, a.UseStatus /*a.LeaseStatus,*/, a.CreateBy, a.LastModBy}
+	fields := []interface{}{a.RID, a.BID, a.TCID, a.DtStart, a.DtStop, a.CreateBy, a.LastModBy}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.InsertRentableUseStatus)
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertRentableUser)
 		defer stmt.Close()
 		res, err = stmt.Exec(fields...)
 	} else {
-		res, err = RRdb.Prepstmt.InsertRentableUseStatus.Exec(fields...)
+		res, err = RRdb.Prepstmt.InsertRentableUser.Exec(fields...)
 	}
 
 	// After getting result...
@@ -1769,17 +2496,16 @@ func InsertRentableUseStatus(ctx context.Context, a *RentableUseStatus) (int64,
 		x, err := res.LastInsertId()
 		if err == nil {
 			rid = int64(x)
-			a.RSID = rid
+			a.RUID = rid
 		}
 	} else {
-		err = insertError(err, "RentableUseStatus", *a)
+		err = insertError(err, "RentableUser", *a)
 	}
 	return rid, err
-
 }
 
-// InsertRentableUseType writes a new RentableUseType record to the database
-func InsertRentableUseType(ctx context.Context, a *RentableUseType) (int64, error) {
+// InsertSecDepSettlement writes a new SecDepSettlement record to the database
+func InsertSecDepSettlement(ctx context.Context, a *SecDepSettlement) (int64, error) {
 	var rid = int64(0)
 	var err error
 	var res sql.Result
@@ -1789,13 +2515,13 @@ func InsertRentableUseType(ctx context.Context, a *RentableUseType) (int64, erro
 	}
 
 	// transaction... context
-	fields := []interface{}{a.RID, a.BID, a.DtStart, a.DtStop, a.Comment, a.UseType, a.CreateBy, a.LastModBy}
+	fields := []interface{}{a.BID, a.RAID, a.TCID, a.Dt, a.DepositHeld, a.Charges, a.Damages, a.DepositApplied, a.Refund, a.BalanceDue, a.RCPTID, a.DMGEXPID, a.REFEXPID, a.BDASMID, a.ForwardAddress, a.FLAGS, a.Comment, a.CreateBy, a.LastModBy}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.InsertRentableUseType)
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertSecDepSettlement)
 		defer stmt.Close()
 		res, err = stmt.Exec(fields...)
 	} else {
-		res, err = RRdb.Prepstmt.InsertRentableUseType.Exec(fields...)
+		res, err = RRdb.Prepstmt.InsertSecDepSettlement.Exec(fields...)
 	}
 
 	// After getting result...
@@ -1803,17 +2529,16 @@ func InsertRentableUseType(ctx context.Context, a *RentableUseType) (int64, erro
 		x, err := res.LastInsertId()
 		if err == nil {
 			rid = int64(x)
-			a.UTID = rid
+			a.SDSID = rid
 		}
 	} else {
-		err = insertError(err, "RentableUseType", *a)
+		err = insertError(err, "SecDepSettlement", *a)
 	}
 	return rid, err
-
 }
 
-// InsertRentableUser writes a new User record to the database
-func InsertRentableUser(ctx context.Context, a *RentableUser) (int64, error) {
+// InsertSecDepItem writes a new SecDepItem record to the database
+func InsertSecDepItem(ctx context.Context, a *SecDepItem) (int64, error) {
 	var rid = int64(0)
 	var err error
 	var res sql.Result
@@ -1823,13 +2548,13 @@ func InsertRentableUser(ctx context.Context, a *RentableUser) (int64, error) {
 	}
 
 	// transaction... context
-	fields := []interface{}{a.RID, a.BID, a.TCID, a.DtStart, a.DtStop, a.CreateBy, a.LastModBy}
+	fields := []interface{}{a.SDSID, a.BID, a.ItemType, a.ASMID, a.RID, a.Description, a.Amount, a.CreateBy, a.LastModBy}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
-		stmt := tx.Stmt(RRdb.Prepstmt.InsertRentableUser)
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertSecDepItem)
 		defer stmt.Close()
 		res, err = stmt.Exec(fields...)
 	} else {
-		res, err = RRdb.Prepstmt.InsertRentableUser.Exec(fields...)
+		res, err = RRdb.Prepstmt.InsertSecDepItem.Exec(fields...)
 	}
 
 	// After getting result...
@@ -1837,10 +2562,10 @@ func InsertRentableUser(ctx context.Context, a *RentableUser) (int64, error) {
 		x, err := res.LastInsertId()
 		if err == nil {
 			rid = int64(x)
-			a.RUID = rid
+			a.SDIID = rid
 		}
 	} else {
-		err = insertError(err, "RentableUser", *a)
+		err = insertError(err, "SecDepItem", *a)
 	}
 	return rid, err
 }
@@ -2185,10 +2910,109 @@ func InsertTBind(ctx context.Context, a *TBind) (int64, error) {
 	return rid, err
 }
 
+// InsertTax writes a new Tax record to the database
+func InsertTax(ctx context.Context, a *Tax) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.BID, a.Name, a.TaxingAuthority, a.TaxingAuthorityAddress, a.FilingDate, a.FilingCycle, a.Instructions, a.ARID, a.ExemptDays, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertTax)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertTax.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.TAXID = rid
+		}
+	} else {
+		err = insertError(err, "Tax", *a)
+	}
+	return rid, err
+}
+
+// InsertTaxRate writes a new TaxRate record to the database
+func InsertTaxRate(ctx context.Context, a *TaxRate) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.TAXID, a.BID, a.DtStart, a.DtStop, a.Rate, a.Fee, a.Formula, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertTaxRate)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertTaxRate.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.TRID = rid
+		}
+	} else {
+		err = insertError(err, "TaxRate", *a)
+	}
+	return rid, err
+}
+
 //*****************************************************************************
 //  TRANSACTANT, PAYOR, USER, PROSPECT
 //*****************************************************************************
 
+// InsertTenantLogin writes a new TenantLogin record to the database
+func InsertTenantLogin(ctx context.Context, a *TenantLogin) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.BID, a.TCID, a.Username, a.PasswordHash, a.FailedLogins, a.LastLogin, a.FLAGS, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertTenantLogin)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertTenantLogin.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.TLID = rid
+		}
+	} else {
+		err = insertError(err, "TenantLogin", *a)
+	}
+	return rid, err
+}
+
 // InsertTransactant writes a new Transactant record to the database
 func InsertTransactant(ctx context.Context, a *Transactant) (int64, error) {
 	var rid = int64(0)
@@ -2440,3 +3264,168 @@ func InsertVehicle(ctx context.Context, a *Vehicle) (int64, error) {
 	}
 	return rid, err
 }
+
+// InsertVendor writes a new Vendor record to the database
+func InsertVendor(ctx context.Context, a *Vendor) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.BID, a.Name, a.ContactName, a.Email, a.Phone, a.Address, a.Address2, a.City, a.State, a.PostalCode, a.Country, a.TaxID, a.Terms, a.FLAGS, a.Comment, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertVendor)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertVendor.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.VID = rid
+		}
+	} else {
+		err = insertError(err, "Vendor", *a)
+	}
+	return rid, err
+}
+
+// InsertVendorBill writes a new VendorBill record to the database
+func InsertVendorBill(ctx context.Context, a *VendorBill) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.BID, a.VID, a.InvoiceNo, a.Dt, a.DueDt, a.Amount, a.Status, a.ApprovedBy, a.DtApproved, a.JID, a.VPRID, a.DtPaid, a.DocNo, a.PmtJID, a.FLAGS, a.Comment, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertVendorBill)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertVendorBill.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.VBID = rid
+		}
+	} else {
+		err = insertError(err, "VendorBill", *a)
+	}
+	return rid, err
+}
+
+// InsertVendorBillLine writes a new VendorBillLine record to the database
+func InsertVendorBillLine(ctx context.Context, a *VendorBillLine) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.VBID, a.BID, a.LID, a.RID, a.Amount, a.Description, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertVendorBillLine)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertVendorBillLine.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.VBLID = rid
+		}
+	} else {
+		err = insertError(err, "VendorBillLine", *a)
+	}
+	return rid, err
+}
+
+// InsertVendorPaymentRun writes a new VendorPaymentRun record to the database
+func InsertVendorPaymentRun(ctx context.Context, a *VendorPaymentRun) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.BID, a.DEPID, a.Dt, a.FirstCheckNo, a.Amount, a.BillCount, a.Comment, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertVendorPaymentRun)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertVendorPaymentRun.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.VPRID = rid
+		}
+	} else {
+		err = insertError(err, "VendorPaymentRun", *a)
+	}
+	return rid, err
+}
+
+// InsertWorkOrder writes a new WorkOrder record to the database
+func InsertWorkOrder(ctx context.Context, a *WorkOrder) (int64, error) {
+	var rid = int64(0)
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return rid, err
+	}
+
+	// transaction... context
+	fields := []interface{}{a.BID, a.RID, a.RAID, a.MRID, a.Category, a.Priority, a.Status, a.Vendor, a.Description, a.Dt, a.ScheduledStart, a.ScheduledStop, a.DtCompleted, a.LaborCost, a.LaborARID, a.LaborEXPID, a.MaterialCost, a.MaterialARID, a.MaterialEXPID, a.ChargebackAmount, a.ChargebackARID, a.ChargebackASMID, a.FLAGS, a.Comment, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertWorkOrder)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertWorkOrder.Exec(fields...)
+	}
+
+	// After getting result...
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(x)
+			a.WOID = rid
+		}
+	} else {
+		err = insertError(err, "WorkOrder", *a)
+	}
+	return rid, err
+}
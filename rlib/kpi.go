@@ -0,0 +1,328 @@
+package rlib
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+)
+
+// KPIMetrics are the occupancy and leasing measures of a group of rentables
+// over a period
+type KPIMetrics struct {
+	Rentables         int64   // rentables in the group
+	RentableDays      float64 // days of the period times the rentables
+	DownDays          float64 // rentable days not in service (RentableUseStatus)
+	OccupiedDays      float64 // rentable days covered by a rental agreement
+	PhysicalOccupancy float64 // OccupiedDays / RentableDays not down, percent
+	GSR               float64 // gross scheduled rent of the period
+	VacancyLoss       float64 // GSR of the vacant time
+	EconomicOccupancy float64 // (GSR - VacancyLoss) / GSR, percent
+	Leased            int64   // rentables leased at the end of the period (RentableLeaseStatus)
+	Reserved          int64   // rentables reserved at the end of the period
+	MoveIns           int64   // rentals starting in the period, not continuing a rental
+	MoveOuts          int64   // rentals ending in the period, not continued by a rental
+	Turnover          float64 // MoveOuts / Rentables, percent
+	VacanciesFilled   int64   // move-ins after an earlier rental of the rentable
+	AvgDaysVacant     float64 // average days vacant before the VacanciesFilled
+	TradeOut          float64 // average change of the ContractRent of the VacanciesFilled over the earlier rental, percent
+	vacantDays        float64 // sum of the days vacant of the VacanciesFilled
+	tradeOutSum       float64 // sum of the trade-out percents
+	tradeOuts         int64   // VacanciesFilled with a ContractRent to compare
+}
+
+// KPIPeriod is the KPI of a business over a period, in total and by
+// rentable type
+type KPIPeriod struct {
+	DtStart       time.Time
+	DtStop        time.Time
+	Business      KPIMetrics
+	RentableTypes map[int64]*KPIMetrics // by RTID
+}
+
+// LeasingKPI is the KPI of a business over a date range, for the range and
+// month by month
+type LeasingKPI struct {
+	BID     int64
+	DtStart time.Time
+	DtStop  time.Time
+	Total   KPIPeriod
+	Months  []KPIPeriod
+}
+
+// add adds the counts of b to a
+func (a *KPIMetrics) add(b *KPIMetrics) {
+	a.Rentables += b.Rentables
+	a.RentableDays += b.RentableDays
+	a.DownDays += b.DownDays
+	a.OccupiedDays += b.OccupiedDays
+	a.GSR += b.GSR
+	a.VacancyLoss += b.VacancyLoss
+	a.Leased += b.Leased
+	a.Reserved += b.Reserved
+	a.MoveIns += b.MoveIns
+	a.MoveOuts += b.MoveOuts
+	a.VacanciesFilled += b.VacanciesFilled
+	a.vacantDays += b.vacantDays
+	a.tradeOutSum += b.tradeOutSum
+	a.tradeOuts += b.tradeOuts
+}
+
+// finish computes the ratios of a from its counts
+func (a *KPIMetrics) finish() {
+	a.GSR = RoundToCent(a.GSR)
+	a.VacancyLoss = RoundToCent(a.VacancyLoss)
+	if d := a.RentableDays - a.DownDays; d > 0 {
+		a.PhysicalOccupancy = 100 * a.OccupiedDays / d
+	}
+	if a.GSR > 0 {
+		a.EconomicOccupancy = 100 * (a.GSR - a.VacancyLoss) / a.GSR
+	}
+	if a.Rentables > 0 {
+		a.Turnover = 100 * float64(a.MoveOuts) / float64(a.Rentables)
+	}
+	if a.VacanciesFilled > 0 {
+		a.AvgDaysVacant = a.vacantDays / float64(a.VacanciesFilled)
+	}
+	if a.tradeOuts > 0 {
+		a.TradeOut = a.tradeOutSum / float64(a.tradeOuts)
+	}
+}
+
+// add adds the metrics b of rentable type rtid to the period
+func (p *KPIPeriod) add(rtid int64, b *KPIMetrics) {
+	p.Business.add(b)
+	if _, ok := p.RentableTypes[rtid]; !ok {
+		p.RentableTypes[rtid] = &KPIMetrics{}
+	}
+	p.RentableTypes[rtid].add(b)
+}
+
+// finish computes the ratios of the period
+func (p *KPIPeriod) finish() {
+	p.Business.finish()
+	for _, v := range p.RentableTypes {
+		v.finish()
+	}
+}
+
+// RTIDs returns the rentable types of the period, in order
+func (p *KPIPeriod) RTIDs() []int64 {
+	var m []int64
+	for k := range p.RentableTypes {
+		m = append(m, k)
+	}
+	sort.Slice(m, func(i, j int) bool { return m[i] < m[j] })
+	return m
+}
+
+// kpiDays returns the number of days of d1 - d2 that fall within a1 - a2
+func kpiDays(d1, d2, a1, a2 *time.Time) float64 {
+	return Occupancy(d1, d2, a1, a2) * math.Round(d2.Sub(*d1).Hours()/24)
+}
+
+// GetLeasingKPI computes the occupancy and leasing KPI of business xbiz
+// over d1 - d2, in total and for each month, by rentable type.
+//
+// A rentable is occupied while a rental agreement covers it and down while
+// its RentableUseStatus is major repair, housekeeping or inactive.  The
+// vacancy loss of the rentable types managed to budget is the GSR of the
+// periods found by VacancyDetect, the one VacancyGSR uses for the rent roll.
+// For the other types it is the GSR prorated over the days not occupied.
+// A rental that starts on the day the previous one stops continues it, so
+// it is neither a move-out nor a move-in.
+//
+// INPUTS
+//     ctx  = db context
+//     xbiz = the business, its internals initialized
+//     d1   = start of the range
+//     d2   = stop of the range
+//
+// RETURNS
+//     the KPI
+//     any error encountered
+//-----------------------------------------------------------------------------
+func GetLeasingKPI(ctx context.Context, xbiz *XBusiness, d1, d2 *time.Time) (LeasingKPI, error) {
+	var k = LeasingKPI{BID: xbiz.P.BID, DtStart: *d1, DtStop: *d2}
+	k.Total = KPIPeriod{DtStart: *d1, DtStop: *d2, RentableTypes: map[int64]*KPIMetrics{}}
+	for dt := *d1; dt.Before(*d2); {
+		next := time.Date(dt.Year(), dt.Month()+1, 1, 0, 0, 0, 0, dt.Location())
+		if next.After(*d2) {
+			next = *d2
+		}
+		k.Months = append(k.Months, KPIPeriod{DtStart: dt, DtStop: next, RentableTypes: map[int64]*KPIMetrics{}})
+		dt = next
+	}
+	if len(k.Months) == 0 {
+		return k, nil
+	}
+
+	r, err := GetRentablesByBusiness(ctx, xbiz.P.BID)
+	if err != nil {
+		return k, err
+	}
+	for i := 0; i < len(r); i++ {
+		counted := map[int64]bool{} // the types under which the rentable was counted in the total
+		for j := 0; j < len(k.Months); j++ {
+			rtid, m, err := rentableKPI(ctx, xbiz, r[i].RID, &k.Months[j].DtStart, &k.Months[j].DtStop)
+			if err != nil {
+				return k, err
+			}
+			if rtid == 0 {
+				continue // the rentable does not exist in this month
+			}
+			k.Months[j].add(rtid, &m)
+			if j < len(k.Months)-1 { // the lease status of the total is the one at the end of the range
+				m.Leased = 0
+				m.Reserved = 0
+			}
+			if counted[rtid] {
+				m.Rentables = 0
+			}
+			counted[rtid] = true
+			k.Total.add(rtid, &m)
+		}
+	}
+
+	k.Total.finish()
+	for j := 0; j < len(k.Months); j++ {
+		k.Months[j].finish()
+	}
+	return k, nil
+}
+
+// rentableKPI computes the KPI counts of rentable rid over d1 - d2
+//
+// RETURNS
+//     the RTID of the rentable at d1, 0 if the rentable has no type over the
+//         period
+//     the counts
+//     any error encountered
+//-----------------------------------------------------------------------------
+func rentableKPI(ctx context.Context, xbiz *XBusiness, rid int64, d1, d2 *time.Time) (int64, KPIMetrics, error) {
+	var m KPIMetrics
+	rta, err := GetRentableTypeRefsByRange(ctx, rid, d1, d2)
+	if err != nil || len(rta) == 0 {
+		return 0, m, err
+	}
+	rtid := rta[0].RTID
+	days := math.Round(d2.Sub(*d1).Hours() / 24)
+	m.Rentables = 1
+	m.RentableDays = days
+
+	//-------------------------------------------------
+	// down time
+	//-------------------------------------------------
+	rsa, err := GetRentableUseStatusByRange(ctx, rid, d1, d2)
+	if err != nil {
+		return 0, m, err
+	}
+	for i := 0; i < len(rsa); i++ {
+		if rsa[i].UseStatus == USESTATUSmajorRepair || rsa[i].UseStatus == USESTATUShousekeeping || rsa[i].UseStatus == USESTATUSinactive {
+			m.DownDays += kpiDays(d1, d2, &rsa[i].DtStart, &rsa[i].DtStop)
+		}
+	}
+	m.DownDays = math.Min(m.DownDays, days)
+
+	//-------------------------------------------------
+	// occupancy, move-ins and move-outs
+	//-------------------------------------------------
+	t, err := GetAgreementsForRentable(ctx, rid, d1, d2)
+	if err != nil {
+		return 0, m, err
+	}
+	for i := 0; i < len(t); i++ {
+		m.OccupiedDays += kpiDays(d1, d2, &t[i].RARDtStart, &t[i].RARDtStop)
+		if !t[i].RARDtStart.Before(*d1) {
+			if err = kpiMoveIn(ctx, rid, &t[i], &m); err != nil {
+				return 0, m, err
+			}
+		}
+		if t[i].RARDtStop.After(*d1) && !t[i].RARDtStop.After(*d2) {
+			dt := t[i].RARDtStop.AddDate(0, 0, 1)
+			n, err := GetAgreementsForRentable(ctx, rid, &t[i].RARDtStop, &dt)
+			if err != nil {
+				return 0, m, err
+			}
+			continued := false
+			for j := 0; j < len(n); j++ {
+				if n[j].RARID != t[i].RARID && !n[j].RARDtStart.After(t[i].RARDtStop) {
+					continued = true
+				}
+			}
+			if !continued {
+				m.MoveOuts++
+			}
+		}
+	}
+	m.OccupiedDays = math.Min(m.OccupiedDays, days-m.DownDays)
+
+	//-------------------------------------------------
+	// GSR and vacancy loss
+	//-------------------------------------------------
+	m.GSR, _, _, err = CalculateLoadedGSR(ctx, xbiz.P.BID, rid, d1, d2, xbiz)
+	if err != nil {
+		return 0, m, err
+	}
+	if xbiz.RT[rtid].FLAGS&0x4 != 0 { // managed to budget
+		m.VacancyLoss, err = VacancyGSR(ctx, xbiz, rid, d1, d2)
+		if err != nil {
+			return 0, m, err
+		}
+	} else if days > 0 {
+		m.VacancyLoss = m.GSR * (days - m.OccupiedDays) / days
+	}
+
+	//-------------------------------------------------
+	// lease status at the end of the period
+	//-------------------------------------------------
+	last := d2.AddDate(0, 0, -1)
+	rls, err := GetRentableLeaseStatusByRange(ctx, rid, &last, d2)
+	if err != nil {
+		return 0, m, err
+	}
+	if n := len(rls); n > 0 {
+		switch rls[n-1].LeaseStatus {
+		case LEASESTATUSleased:
+			m.Leased = 1
+		case LEASESTATUSreserved:
+			m.Reserved = 1
+		}
+	}
+	return rtid, m, nil
+}
+
+// kpiMoveIn adds rental a of rentable rid, which starts in the period, to the
+// move-in counts of m.  It is a move-in unless it continues an earlier
+// rental.  When an earlier rental exists the days vacant between them and
+// the change of ContractRent are counted too.
+//-----------------------------------------------------------------------------
+func kpiMoveIn(ctx context.Context, rid int64, a *RentalAgreementRentable, m *KPIMetrics) error {
+	t, err := GetAgreementsForRentable(ctx, rid, &TIME0, &a.RARDtStart)
+	if err != nil {
+		return err
+	}
+	var p *RentalAgreementRentable // the latest earlier rental
+	for i := 0; i < len(t); i++ {
+		if t[i].RARID == a.RARID || t[i].RARDtStart.After(a.RARDtStart) {
+			continue
+		}
+		if !t[i].RARDtStop.Before(a.RARDtStart) {
+			return nil // it continues a rental
+		}
+		if p == nil || t[i].RARDtStop.After(p.RARDtStop) {
+			p = &t[i]
+		}
+	}
+	m.MoveIns++
+	if p == nil {
+		return nil // first rental of the rentable
+	}
+	m.VacanciesFilled++
+	m.vacantDays += math.Round(a.RARDtStart.Sub(p.RARDtStop).Hours() / 24)
+	if p.ContractRent > 0 {
+		m.tradeOuts++
+		m.tradeOutSum += 100 * (a.ContractRent - p.ContractRent) / p.ContractRent
+	}
+	return nil
+}
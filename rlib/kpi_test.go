@@ -0,0 +1,66 @@
+package rlib
+
+import (
+	"testing"
+)
+
+// TestKPIFinish checks the ratios computed from the KPI counts of two
+// rentable types added to a period
+func TestKPIFinish(t *testing.T) {
+	a := KPIMetrics{
+		Rentables:       2,
+		RentableDays:    60,
+		DownDays:        10,
+		OccupiedDays:    40,
+		GSR:             2000,
+		VacancyLoss:     400,
+		MoveIns:         1,
+		MoveOuts:        1,
+		VacanciesFilled: 1,
+		vacantDays:      12,
+		tradeOutSum:     5,
+		tradeOuts:       1,
+	}
+	b := KPIMetrics{
+		Rentables:       2,
+		RentableDays:    60,
+		OccupiedDays:    60,
+		GSR:             3000,
+		MoveIns:         1,
+		VacanciesFilled: 1,
+		vacantDays:      4,
+		tradeOutSum:     -1,
+		tradeOuts:       1,
+	}
+	p := KPIPeriod{RentableTypes: map[int64]*KPIMetrics{}}
+	p.add(2, &b)
+	p.add(1, &a)
+	p.finish()
+
+	m := p.Business
+	if m.Rentables != 4 || m.RentableDays != 120 || m.OccupiedDays != 100 {
+		t.Errorf("expected 4 rentables, 120 days, 100 occupied, got %d, %.0f, %.0f\n", m.Rentables, m.RentableDays, m.OccupiedDays)
+	}
+	var cases = []struct {
+		name      string
+		got, want float64
+	}{
+		{"physical occupancy", m.PhysicalOccupancy, 100 * 100.0 / 110},
+		{"economic occupancy", m.EconomicOccupancy, 92},
+		{"turnover", m.Turnover, 25},
+		{"average days vacant", m.AvgDaysVacant, 8},
+		{"trade-out", m.TradeOut, 2},
+		{"type 1 physical occupancy", p.RentableTypes[1].PhysicalOccupancy, 80},
+		{"type 1 economic occupancy", p.RentableTypes[1].EconomicOccupancy, 80},
+		{"type 2 average days vacant", p.RentableTypes[2].AvgDaysVacant, 4},
+	}
+	for i := 0; i < len(cases); i++ {
+		if d := cases[i].got - cases[i].want; d > 0.0001 || d < -0.0001 {
+			t.Errorf("%s: expected %.4f, got %.4f\n", cases[i].name, cases[i].want, cases[i].got)
+		}
+	}
+	rtids := p.RTIDs()
+	if len(rtids) != 2 || rtids[0] != 1 || rtids[1] != 2 {
+		t.Errorf("expected rentable types [1 2], got %v\n", rtids)
+	}
+}
@@ -0,0 +1,126 @@
+package rlib
+
+import (
+	"context"
+	"time"
+)
+
+// LATEFEEFLAT and LATEFEEPERCENT describe how the late fee amount is
+// computed.  A flat fee charges BizPropsLateFee.Amount for each late
+// assessment. A percent fee charges BizPropsLateFee.Amount percent of the
+// unpaid portion of the late assessment.
+const (
+	LATEFEEFLAT    = 0
+	LATEFEEPERCENT = 1
+)
+
+// LATEFEELOOKBACK is the number of days the late fee bot looks back when
+// the policy does not set LookbackDays
+const LATEFEELOOKBACK = 31
+
+// BizPropsLateFee is the late fee policy for a business.  It is stored as
+// part of the business properties (BizProps.LateFee) so each business can
+// configure its own policy.
+//
+//    Enabled    - the late fee bot only assesses fees when this is true
+//    ARName     - name of the Account Rule used for the late fee assessment
+//    GraceDays  - number of days after an assessment's Start date before it
+//                 is considered late
+//    Method     - LATEFEEFLAT or LATEFEEPERCENT
+//    Amount     - the flat fee, or the percentage (5 means 5%) of the unpaid
+//                 portion
+//    MinUnpaid  - no fee is assessed if the unpaid portion is less than this
+//    MinFee     - smallest fee that will be assessed (0 = no minimum)
+//    MaxFee     - cap on the fee for a single late assessment (0 = no cap)
+//    AppliesTo  - AR names of the assessments the policy applies to. If
+//                 empty, the policy applies to all Rent assessments
+//                 (ARs with the IsRentASM flag set)
+//    LookbackDays - only assessments that became late within this many days
+//                 get a late fee, so that turning the policy on does not
+//                 charge the whole history.  0 means LATEFEELOOKBACK.
+//-----------------------------------------------------------------------------
+type BizPropsLateFee struct {
+	Enabled      bool
+	ARName       string
+	GraceDays    int64
+	Method       int64
+	Amount       float64
+	MinUnpaid    float64
+	MinFee       float64
+	MaxFee       float64
+	AppliesTo    []string
+	LookbackDays int64
+}
+
+// GetLateFeePolicy returns the late fee policy configured in the business
+// properties named bizPropName for business BID.
+//
+// INPUTS
+//     ctx         = context
+//     BID         = business id
+//     bizPropName = name of the business properties, usually "general"
+//
+// RETURNS
+//     the late fee policy
+//     any error encountered
+//-----------------------------------------------------------------------------
+func GetLateFeePolicy(ctx context.Context, BID int64, bizPropName string) (BizPropsLateFee, error) {
+	bizPropJSON, err := GetDataFromBusinessPropertyName(ctx, bizPropName, BID)
+	if err != nil {
+		return BizPropsLateFee{}, err
+	}
+	return bizPropJSON.LateFee, nil
+}
+
+// LateFeeDate returns the date on which an assessment that starts on dt
+// becomes late under policy p. It is the first day after the grace period.
+//-----------------------------------------------------------------------------
+func (p *BizPropsLateFee) LateFeeDate(dt *time.Time) time.Time {
+	d := DateAtTimeZero(*dt)
+	return d.AddDate(0, 0, int(p.GraceDays)+1)
+}
+
+// LateFeeLookback returns the earliest late date (see LateFeeDate) of the
+// assessments that get a late fee when the policy is applied on dt.
+//-----------------------------------------------------------------------------
+func (p *BizPropsLateFee) LateFeeLookback(dt *time.Time) time.Time {
+	n := p.LookbackDays
+	if n <= 0 {
+		n = LATEFEELOOKBACK
+	}
+	return DateAtTimeZero(*dt).AddDate(0, 0, -int(n))
+}
+
+// LateFeeAmount computes the late fee for an assessment with the supplied
+// unpaid amount. It returns 0 if no fee should be charged.
+//
+// INPUTS
+//     unpaid = the unpaid portion of the late assessment
+//
+// RETURNS
+//     the late fee, rounded to the nearest cent
+//-----------------------------------------------------------------------------
+func (p *BizPropsLateFee) LateFeeAmount(unpaid float64) float64 {
+	if unpaid < 0.005 || unpaid < p.MinUnpaid {
+		return float64(0)
+	}
+
+	var fee float64
+	switch p.Method {
+	case LATEFEEPERCENT:
+		fee = unpaid * p.Amount / float64(100)
+	default:
+		fee = p.Amount
+	}
+
+	if p.MinFee > 0 && fee < p.MinFee {
+		fee = p.MinFee
+	}
+	if p.MaxFee > 0 && fee > p.MaxFee {
+		fee = p.MaxFee
+	}
+	if fee < 0 {
+		fee = 0
+	}
+	return RoundToCent(fee)
+}
@@ -0,0 +1,63 @@
+package rlib
+
+import "testing"
+
+// lateFeeCase holds a late fee policy, the unpaid amount and the expected fee
+type lateFeeCase struct {
+	p      BizPropsLateFee
+	unpaid float64
+	expect float64
+}
+
+// TestLateFeeAmount tests flat and percentage late fees along with the
+// minimum unpaid threshold and the min/max fee caps
+func TestLateFeeAmount(t *testing.T) {
+	var cases = []lateFeeCase{
+		{BizPropsLateFee{Method: LATEFEEFLAT, Amount: 50}, 1000, 50},
+		{BizPropsLateFee{Method: LATEFEEFLAT, Amount: 50}, 0, 0},
+		{BizPropsLateFee{Method: LATEFEEFLAT, Amount: 50, MinUnpaid: 25}, 20, 0},
+		{BizPropsLateFee{Method: LATEFEEPERCENT, Amount: 5}, 1000, 50},
+		{BizPropsLateFee{Method: LATEFEEPERCENT, Amount: 5}, 333.33, 16.67},
+		{BizPropsLateFee{Method: LATEFEEPERCENT, Amount: 5, MaxFee: 40}, 1000, 40},
+		{BizPropsLateFee{Method: LATEFEEPERCENT, Amount: 5, MinFee: 25}, 100, 25},
+	}
+
+	for i := 0; i < len(cases); i++ {
+		fee := cases[i].p.LateFeeAmount(cases[i].unpaid)
+		t.Logf("info: case[%d] : LateFeeAmount( %8.2f ) expect %8.2f, got %8.2f\n", i, cases[i].unpaid, cases[i].expect, fee)
+		if fee != cases[i].expect {
+			t.Errorf("case[%d] : LateFeeAmount( %8.2f ) expect %8.2f, got %8.2f\n", i, cases[i].unpaid, cases[i].expect, fee)
+		}
+	}
+}
+
+// TestLateFeeDate verifies the first day after the grace period
+func TestLateFeeDate(t *testing.T) {
+	p := BizPropsLateFee{GraceDays: 5}
+	d, _ := StringToDate("2018-03-01")
+	expect, _ := StringToDate("2018-03-07")
+	got := p.LateFeeDate(&d)
+	if !got.Equal(expect) {
+		t.Errorf("LateFeeDate( %s ) expect %s, got %s\n", d.Format(RRDATEFMT4), expect.Format(RRDATEFMT4), got.Format(RRDATEFMT4))
+	}
+}
+
+// TestLateFeeLookback verifies the default and the configured lookback
+func TestLateFeeLookback(t *testing.T) {
+	d, _ := StringToDate("2018-03-31")
+	var cases = []struct {
+		days   int64
+		expect string
+	}{
+		{0, "2018-02-28"},
+		{10, "2018-03-21"},
+		{-5, "2018-02-28"},
+	}
+	for i := 0; i < len(cases); i++ {
+		p := BizPropsLateFee{LookbackDays: cases[i].days}
+		expect, _ := StringToDate(cases[i].expect)
+		if got := p.LateFeeLookback(&d); !got.Equal(expect) {
+			t.Errorf("case[%d] : LateFeeLookback( %s ) expect %s, got %s\n", i, d.Format(RRDATEFMT4), expect.Format(RRDATEFMT4), got.Format(RRDATEFMT4))
+		}
+	}
+}
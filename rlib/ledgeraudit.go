@@ -0,0 +1,326 @@
+package rlib
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Ledger audit problem kinds, see LedgerAuditProblem
+const (
+	LAJournalUnbalanced = iota // the allocations do not add up to the journal amount
+	LALedgerUnbalanced         // the ledger entries of a journal do not net to zero
+	LANoLedgerEntries          // the journal was never posted to the ledgers
+	LAOrphanEntry              // the ledger entry has no journal or allocation
+	LAMarkerDrift              // the marker balance is not the prior balance plus the entries since
+)
+
+// LedgerAuditNames are the names of the ledger audit problem kinds
+var LedgerAuditNames = []string{
+	"journal unbalanced",
+	"ledger unbalanced",
+	"not posted",
+	"orphan entry",
+	"marker drift",
+}
+
+// LedgerAuditProblem is one problem found by AuditLedgers.  Only the ids
+// that apply to the problem are set.
+type LedgerAuditProblem struct {
+	Kind     int64     // LAJournalUnbalanced ... LAMarkerDrift
+	Dt       time.Time // date of the journal, entry or marker
+	JID      int64     // journal
+	LEID     int64     // ledger entry
+	LID      int64     // GL account
+	LMID     int64     // ledger marker
+	Amount   float64   // the amount or balance found
+	Expected float64   // the amount or balance it should be
+	Message  string    // description of the problem
+}
+
+// LedgerAudit is the result of auditing the journals and ledgers of a
+// business over a period of time
+type LedgerAudit struct {
+	BID      int64
+	DtStart  time.Time
+	DtStop   time.Time
+	Journals int // number of journals checked
+	Entries  int // number of ledger entries checked
+	Markers  int // number of ledger markers checked
+	Problems []LedgerAuditProblem
+}
+
+// KindName returns the name of the kind of problem p is
+func (p *LedgerAuditProblem) KindName() string {
+	if p.Kind < 0 || int(p.Kind) >= len(LedgerAuditNames) {
+		return "unknown"
+	}
+	return LedgerAuditNames[p.Kind]
+}
+
+// laDiffers returns true if the amounts differ by a cent or more
+func laDiffers(a, b float64) bool {
+	return math.Abs(a-b) >= 0.005
+}
+
+// AuditLedgers checks the double-entry integrity of business bid over the
+// period d1 - d2.  It verifies that:
+//
+//     * the allocations of each journal add up to the journal amount
+//     * the ledger entries of each journal net to zero
+//     * each ledger entry belongs to an existing journal and allocation
+//     * each general ledger marker dated after d1 and up to d2 holds the
+//       balance of the prior marker plus the ledger entries since it
+//
+// Only the accounts that allow posting and have no child accounts have
+// their markers checked, the balance of a parent account is computed from
+// its children.  Nothing is changed, see RepairLedgerMarkers.
+//
+// INPUTS
+//     ctx    - db context
+//     bid    - the business
+//     d1, d2 - the period to audit
+//
+// RETURNS
+//     the audit
+//     any error encountered
+//-----------------------------------------------------------------------------
+func AuditLedgers(ctx context.Context, bid int64, d1, d2 *time.Time) (LedgerAudit, error) {
+	a := LedgerAudit{BID: bid, DtStart: *d1, DtStop: *d2}
+
+	if err := auditJournals(ctx, &a); err != nil {
+		return a, err
+	}
+	if err := auditLedgerMarkers(ctx, &a); err != nil {
+		return a, err
+	}
+	return a, nil
+}
+
+// auditJournals checks the journals and ledger entries of a.BID dated in
+// a.DtStart - a.DtStop
+func auditJournals(ctx context.Context, a *LedgerAudit) error {
+	jnls, err := GetAllJournalsInRange(ctx, a.BID, &a.DtStart, &a.DtStop)
+	if err != nil {
+		return err
+	}
+	le, err := GetAllLedgerEntriesInRange(ctx, a.BID, &a.DtStart, &a.DtStop)
+	if err != nil {
+		return err
+	}
+	a.Journals = len(jnls)
+	a.Entries = len(le)
+
+	byJID := map[int64][]int{} // indices of the ledger entries of each journal
+	for i := 0; i < len(le); i++ {
+		byJID[le[i].JID] = append(byJID[le[i].JID], i)
+	}
+
+	//---------------------------------------------------
+	// the allocations and the entries of each journal
+	//---------------------------------------------------
+	jmap := map[int64]*Journal{}
+	for i := 0; i < len(jnls); i++ {
+		j := &jnls[i]
+		if err = GetJournalAllocations(ctx, j); err != nil {
+			return err
+		}
+		jmap[j.JID] = j
+
+		tot := float64(0)
+		for k := 0; k < len(j.JA); k++ {
+			tot += j.JA[k].Amount
+		}
+		if laDiffers(tot, j.Amount) {
+			a.Problems = append(a.Problems, LedgerAuditProblem{
+				Kind:     LAJournalUnbalanced,
+				Dt:       j.Dt,
+				JID:      j.JID,
+				Amount:   RoundToCent(tot),
+				Expected: j.Amount,
+				Message:  fmt.Sprintf("%d allocations total %.2f, journal amount is %.2f", len(j.JA), tot, j.Amount),
+			})
+		}
+
+		m := byJID[j.JID]
+		if len(m) == 0 {
+			if laDiffers(j.Amount, 0) {
+				a.Problems = append(a.Problems, LedgerAuditProblem{
+					Kind:     LANoLedgerEntries,
+					Dt:       j.Dt,
+					JID:      j.JID,
+					Expected: j.Amount,
+					Message:  fmt.Sprintf("journal amount %.2f has no ledger entries", j.Amount),
+				})
+			}
+			continue
+		}
+		net := float64(0)
+		for k := 0; k < len(m); k++ {
+			net += le[m[k]].Amount
+		}
+		if laDiffers(net, 0) {
+			a.Problems = append(a.Problems, LedgerAuditProblem{
+				Kind:    LALedgerUnbalanced,
+				Dt:      j.Dt,
+				JID:     j.JID,
+				Amount:  RoundToCent(net),
+				Message: fmt.Sprintf("%d ledger entries net to %.2f instead of 0", len(m), net),
+			})
+		}
+	}
+
+	//---------------------------------------------------
+	// every entry must trace back to a journal and one
+	// of its allocations
+	//---------------------------------------------------
+	for i := 0; i < len(le); i++ {
+		j, ok := jmap[le[i].JID]
+		if !ok {
+			// the journal may be dated outside the period
+			x, err := GetJournal(ctx, le[i].JID)
+			if err != nil {
+				return err
+			}
+			if x.JID > 0 && x.BID == a.BID {
+				if err = GetJournalAllocations(ctx, &x); err != nil {
+					return err
+				}
+				j = &x
+			} else {
+				j = nil
+			}
+			jmap[le[i].JID] = j
+		}
+		msg := ""
+		if j == nil {
+			msg = fmt.Sprintf("journal %d does not exist", le[i].JID)
+		} else {
+			found := false
+			for k := 0; k < len(j.JA) && !found; k++ {
+				found = j.JA[k].JAID == le[i].JAID
+			}
+			if !found {
+				msg = fmt.Sprintf("allocation %d is not part of journal %d", le[i].JAID, le[i].JID)
+			}
+		}
+		if len(msg) > 0 {
+			a.Problems = append(a.Problems, LedgerAuditProblem{
+				Kind:    LAOrphanEntry,
+				Dt:      le[i].Dt,
+				JID:     le[i].JID,
+				LEID:    le[i].LEID,
+				LID:     le[i].LID,
+				Amount:  le[i].Amount,
+				Message: msg,
+			})
+		}
+	}
+	return nil
+}
+
+// auditLedgerMarkers checks the general ledger markers of a.BID dated after
+// a.DtStart and up to a.DtStop.  The expected balance is carried forward so
+// that every marker built on a drifted one is reported too.
+func auditLedgerMarkers(ctx context.Context, a *LedgerAudit) error {
+	t, err := GetLedgerList(ctx, a.BID)
+	if err != nil {
+		return err
+	}
+	parent := map[int64]bool{}
+	for i := 0; i < len(t); i++ {
+		parent[t[i].PLID] = true
+	}
+
+	for i := 0; i < len(t); i++ {
+		if parent[t[i].LID] || !t[i].AllowPost {
+			continue
+		}
+		prev, err := GetLedgerMarkerOnOrBefore(ctx, a.BID, t[i].LID, &a.DtStart)
+		if err != nil {
+			return err
+		}
+		m, err := GetLedgerMarkersInRange(ctx, a.BID, t[i].LID, &a.DtStart, &a.DtStop)
+		if err != nil {
+			return err
+		}
+		for k := 0; k < len(m); k++ {
+			a.Markers++
+			if prev.LMID == 0 || m[k].State == LMINITIAL {
+				prev = m[k] // nothing before it to check against
+				continue
+			}
+			activity, err := GetAccountActivity(ctx, a.BID, t[i].LID, &prev.Dt, &m[k].Dt)
+			if err != nil {
+				return err
+			}
+			expected := RoundToCent(prev.Balance + activity)
+			if laDiffers(m[k].Balance, expected) {
+				a.Problems = append(a.Problems, LedgerAuditProblem{
+					Kind:     LAMarkerDrift,
+					Dt:       m[k].Dt,
+					LID:      t[i].LID,
+					LMID:     m[k].LMID,
+					Amount:   m[k].Balance,
+					Expected: expected,
+					Message:  fmt.Sprintf("%s balance is %.2f, expected %.2f", t[i].GLNumber, m[k].Balance, expected),
+				})
+			}
+			prev = m[k]
+			prev.Balance = expected
+		}
+	}
+	return nil
+}
+
+// RepairLedgerMarkers regenerates the ledger markers on each date where the
+// audit a found a drifted marker, oldest first.  The open and closed markers
+// on that date are removed and GenerateLedgerMarkers creates them again from
+// the prior markers and the ledger entries.  Markers in a closed period
+// cannot be regenerated: if any drifted marker is in a closed period the
+// repair fails, nothing is changed and an error is returned.
+//
+// INPUTS
+//     ctx  - db context
+//     xbiz - the business audited
+//     a    - the audit, as returned by AuditLedgers
+//
+// RETURNS
+//     the dates whose markers were regenerated
+//     any error encountered
+//-----------------------------------------------------------------------------
+func RepairLedgerMarkers(ctx context.Context, xbiz *XBusiness, a *LedgerAudit) ([]time.Time, error) {
+	var dts []time.Time
+	seen := map[time.Time]bool{}
+	for i := 0; i < len(a.Problems); i++ {
+		if a.Problems[i].Kind != LAMarkerDrift || seen[a.Problems[i].Dt] {
+			continue
+		}
+		seen[a.Problems[i].Dt] = true
+		dts = append(dts, a.Problems[i].Dt)
+	}
+	if len(dts) == 0 {
+		return dts, nil
+	}
+	sort.Slice(dts, func(i, j int) bool { return dts[i].Before(dts[j]) })
+
+	cp, err := GetLastClosePeriod(ctx, xbiz.P.BID)
+	if err != nil {
+		return nil, err
+	}
+	if cp.CPID > 0 && !dts[0].After(cp.Dt) {
+		return nil, fmt.Errorf("the ledger markers of %s are in the period closed on %s, they cannot be repaired", dts[0].Format(RRDATEFMT4), cp.Dt.Format(RRDATEFMT4))
+	}
+
+	for i := 0; i < len(dts); i++ {
+		if err = DeleteLedgerMarkersOnDate(ctx, xbiz.P.BID, &dts[i]); err != nil {
+			return dts[:i], err
+		}
+		if err = GenerateLedgerMarkers(ctx, xbiz, &dts[i]); err != nil {
+			return dts[:i], err
+		}
+	}
+	return dts, nil
+}
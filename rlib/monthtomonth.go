@@ -0,0 +1,103 @@
+package rlib
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// RAMonthToMonth is the RentalAgreement FLAGS bit that says the agreement
+// went month to month when its term ended.  Its rent includes the month to
+// month premium.
+const RAMonthToMonth = uint64(1 << 8)
+
+// BizPropsMonthToMonth holds the month to month renewal settings for a
+// business.  It is stored as part of the business properties
+// (BizProps.MonthToMonth).  They apply to the rental agreements whose
+// Renewal is 1, month to month automatic renewal.
+//
+//    Enabled      - the month to month bot only extends agreements when this
+//                   is true
+//    Premium      - added to the rent when the agreement goes month to
+//                   month, a percentage (10 means 10%) unless PremiumFixed
+//    PremiumFixed - Premium is an amount added to the rent
+//-----------------------------------------------------------------------------
+type BizPropsMonthToMonth struct {
+	Enabled      bool
+	Premium      float64
+	PremiumFixed bool
+}
+
+// GetMonthToMonthPolicy returns the month to month renewal settings
+// configured in the business properties named bizPropName for business BID.
+//
+// INPUTS
+//     ctx         = context
+//     BID         = business id
+//     bizPropName = name of the business properties, usually "general"
+//
+// RETURNS
+//     the month to month settings
+//     any error encountered
+//-----------------------------------------------------------------------------
+func GetMonthToMonthPolicy(ctx context.Context, BID int64, bizPropName string) (BizPropsMonthToMonth, error) {
+	bizPropJSON, err := GetDataFromBusinessPropertyName(ctx, bizPropName, BID)
+	if err != nil {
+		return BizPropsMonthToMonth{}, err
+	}
+	return bizPropJSON.MonthToMonth, nil
+}
+
+// MonthToMonthRent returns the rent that replaces amt when a rental
+// agreement goes month to month.  The result is never negative.
+//
+// INPUTS
+//     amt = the rent at the end of the term
+//
+// RETURNS
+//     the month to month rent, rounded to the cent
+//-----------------------------------------------------------------------------
+func (p *BizPropsMonthToMonth) MonthToMonthRent(amt float64) float64 {
+	var x float64
+	if p.PremiumFixed {
+		x = amt + p.Premium
+	} else {
+		x = amt * (1 + p.Premium/100)
+	}
+	return RoundToCent(math.Max(x, 0))
+}
+
+// MonthToMonthTerm is one month by which a month to month agreement is
+// extended: DtStart - DtStop.  The recurring charges of the month that are
+// due by the date of the extension fall in DtStart - ChargeStop.
+type MonthToMonthTerm struct {
+	DtStart    time.Time
+	DtStop     time.Time
+	ChargeStop time.Time
+}
+
+// MonthToMonthTerms returns the months by which an agreement that stops on
+// stop must be extended so that it runs past dt.  An agreement that stopped
+// several months before dt is extended one month at a time, and the charges
+// of each month up to dt are due.
+//
+// INPUTS
+//     stop = the AgreementStop of the agreement
+//     dt   = the date of the extension
+//
+// RETURNS
+//     the months of the extension, in order, none if stop is after dt
+//-----------------------------------------------------------------------------
+func MonthToMonthTerms(stop, dt *time.Time) []MonthToMonthTerm {
+	var m []MonthToMonthTerm
+	due := dt.AddDate(0, 0, 1)
+	for d := *stop; !d.After(*dt); d = d.AddDate(0, 1, 0) {
+		t := MonthToMonthTerm{DtStart: d, DtStop: d.AddDate(0, 1, 0)}
+		t.ChargeStop = t.DtStop
+		if due.Before(t.ChargeStop) {
+			t.ChargeStop = due
+		}
+		m = append(m, t)
+	}
+	return m
+}
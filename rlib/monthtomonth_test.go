@@ -0,0 +1,62 @@
+package rlib
+
+import (
+	"testing"
+	"time"
+)
+
+// TestMonthToMonthRent checks percentage and fixed month to month premiums
+func TestMonthToMonthRent(t *testing.T) {
+	var cases = []struct {
+		rent, premium float64
+		fixed         bool
+		expect        float64
+	}{
+		{1000, 10, false, 1100},     // 10%
+		{1250, 7.5, false, 1343.75}, // 7.5%
+		{999.99, 5, false, 1049.99}, // rounded to the cent
+		{1000, 75, true, 1075},      // fixed premium
+		{1000, 0, false, 1000},      // no premium
+		{50, -100, true, 0},         // never negative
+	}
+	for i := 0; i < len(cases); i++ {
+		p := BizPropsMonthToMonth{Premium: cases[i].premium, PremiumFixed: cases[i].fixed}
+		if x := p.MonthToMonthRent(cases[i].rent); x != cases[i].expect {
+			t.Errorf("case %d: expected %.2f, got %.2f\n", i, cases[i].expect, x)
+		}
+	}
+}
+
+// TestMonthToMonthTerms checks the catch up of an agreement that stopped
+// several months ago: each month is a term, and the charges of all of them
+// are due up to the date of the extension
+func TestMonthToMonthTerms(t *testing.T) {
+	stop := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	dt := time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)
+	m := MonthToMonthTerms(&stop, &dt)
+	if len(m) != 3 {
+		t.Fatalf("expected 3 terms, got %d\n", len(m))
+	}
+	for i := 0; i < len(m); i++ {
+		d1 := stop.AddDate(0, i, 0)
+		d2 := d1.AddDate(0, 1, 0)
+		if !m[i].DtStart.Equal(d1) || !m[i].DtStop.Equal(d2) {
+			t.Errorf("term %d: expected %s - %s, got %s - %s\n", i, d1.Format(RRDATEFMTSQL), d2.Format(RRDATEFMTSQL), m[i].DtStart.Format(RRDATEFMTSQL), m[i].DtStop.Format(RRDATEFMTSQL))
+		}
+	}
+	if !m[0].ChargeStop.Equal(m[0].DtStop) || !m[1].ChargeStop.Equal(m[1].DtStop) {
+		t.Errorf("expected the past months to be charged in full, got %#v\n", m[:2])
+	}
+	if x := dt.AddDate(0, 0, 1); !m[2].ChargeStop.Equal(x) {
+		t.Errorf("expected the current month to be charged up to %s, got %s\n", x.Format(RRDATEFMTSQL), m[2].ChargeStop.Format(RRDATEFMTSQL))
+	}
+
+	// an agreement stopping on dt is extended once, one stopping after it is not
+	if m = MonthToMonthTerms(&dt, &dt); len(m) != 1 {
+		t.Errorf("expected 1 term for an agreement stopping on dt, got %d\n", len(m))
+	}
+	next := dt.AddDate(0, 0, 1)
+	if m = MonthToMonthTerms(&next, &dt); len(m) != 0 {
+		t.Errorf("expected no term for an agreement stopping after dt, got %d\n", len(m))
+	}
+}
@@ -0,0 +1,378 @@
+package rlib
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+)
+
+// NACHA file constants
+const (
+	NACHARecordSize      = 94    // every record is 94 characters
+	NACHABlockingFactor  = 10    // records per block
+	NACHADebitsOnly      = "225" // service class code: debits only
+	NACHASECPPD          = "PPD" // standard entry class: prearranged payment and deposit
+	NACHACheckingDebit   = "27"  // transaction code: checking account debit
+	NACHASavingsDebit    = "37"  // transaction code: savings account debit
+	NACHAOriginatorDepFI = "1"   // originator status code: depository financial institution
+)
+
+// NACHAEntry is a single debit in a NACHA file
+type NACHAEntry struct {
+	RoutingNumber  string  // the receiving bank's 9 digit routing number
+	AccountNumber  string  // the account to debit
+	Savings        bool    // true if the account is a savings account, otherwise checking
+	Amount         float64 // the amount to debit
+	IndividualID   string  // our id for the payor
+	IndividualName string  // name on the account
+	TraceNumber    string  // 15 digit trace number, see NACHATraceNumber
+}
+
+// NACHAFile contains the information needed to write a NACHA file with a
+// single PPD batch of debits.
+type NACHAFile struct {
+	ImmediateDestination     string       // routing number of the bank receiving the file
+	ImmediateDestinationName string       // name of the bank receiving the file
+	ImmediateOrigin          string       // our id as assigned by the bank, usually the company id
+	ImmediateOriginName      string       // our name
+	FileIDModifier           string       // A-Z, 0-9: distinguishes multiple files created on the same day
+	CompanyName              string       // company name as it appears on the payor's statement
+	CompanyID                string       // company identification assigned by the bank
+	ODFI                     string       // routing number of the originating bank, the first 8 digits are used
+	EntryDescription         string       // shows on the payor's statement, for example RENT
+	BatchNumber              int64        // batch number
+	CreateTime               time.Time    // when the file was created
+	EffectiveDt              time.Time    // the date the debits should settle
+	Entries                  []NACHAEntry // the debits
+}
+
+// ValidRoutingNumber returns true if s is a 9 digit ABA routing number with
+// a valid check digit.
+//-----------------------------------------------------------------------------
+func ValidRoutingNumber(s string) bool {
+	if len(s) != 9 {
+		return false
+	}
+	var w = []int{3, 7, 1, 3, 7, 1, 3, 7, 1}
+	sum := 0
+	for i := 0; i < 9; i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+		sum += int(s[i]-'0') * w[i]
+	}
+	return sum%10 == 0
+}
+
+// NACHATraceNumber returns the 15 digit trace number for the entry with
+// sequence number seq in a file originated by bank odfi.
+//-----------------------------------------------------------------------------
+func NACHATraceNumber(odfi string, seq int64) string {
+	return nachaNum(odfi8(odfi), 8) + fmt.Sprintf("%07d", seq%10000000)
+}
+
+// NACHACents returns amt in cents, which is how NACHA represents amounts
+//-----------------------------------------------------------------------------
+func NACHACents(amt float64) int64 {
+	return int64(RoundToCent(amt)*100 + 0.5)
+}
+
+// odfi8 returns the first 8 digits of a routing number
+func odfi8(s string) string {
+	if len(s) > 8 {
+		return s[:8]
+	}
+	return s
+}
+
+// nachaAlpha returns s in upper case, left justified in a field of width n
+func nachaAlpha(s string, n int) string {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if len(s) > n {
+		return s[:n]
+	}
+	return s + strings.Repeat(" ", n-len(s))
+}
+
+// nachaNum returns s right justified and zero filled in a field of width n.
+// If s is too long, its rightmost n characters are used.
+func nachaNum(s string, n int) string {
+	s = strings.TrimSpace(s)
+	if len(s) > n {
+		return s[len(s)-n:]
+	}
+	return strings.Repeat("0", n-len(s)) + s
+}
+
+// nachaInt returns x zero filled in a field of width n
+func nachaInt(x int64, n int) string {
+	return nachaNum(fmt.Sprintf("%d", x), n)
+}
+
+// Write writes the NACHA file to w.  The file has a file header, a single
+// batch of debits, the batch and file controls, and enough filler records to
+// complete the last block.
+//
+// INPUTS
+//    w = where to write the file
+//
+// RETURNS
+//    any error encountered
+//-----------------------------------------------------------------------------
+func (f *NACHAFile) Write(w io.Writer) error {
+	if !ValidRoutingNumber(f.ImmediateDestination) {
+		return fmt.Errorf("invalid immediate destination routing number: %q", f.ImmediateDestination)
+	}
+	if !ValidRoutingNumber(f.ODFI) {
+		return fmt.Errorf("invalid originating bank routing number: %q", f.ODFI)
+	}
+	mod := f.FileIDModifier
+	if len(mod) == 0 {
+		mod = "A"
+	}
+
+	var recs []string
+
+	//-------------------------------
+	// File Header Record
+	//-------------------------------
+	recs = append(recs, "101"+
+		" "+nachaNum(f.ImmediateDestination, 9)+
+		nachaAlpha(f.ImmediateOrigin, 10)+
+		f.CreateTime.Format("060102")+
+		f.CreateTime.Format("1504")+
+		nachaAlpha(mod, 1)+
+		"094"+
+		"10"+
+		"1"+
+		nachaAlpha(f.ImmediateDestinationName, 23)+
+		nachaAlpha(f.ImmediateOriginName, 23)+
+		nachaAlpha("", 8))
+
+	//-------------------------------
+	// Company / Batch Header Record
+	//-------------------------------
+	recs = append(recs, "5"+
+		NACHADebitsOnly+
+		nachaAlpha(f.CompanyName, 16)+
+		nachaAlpha("", 20)+
+		nachaAlpha(f.CompanyID, 10)+
+		NACHASECPPD+
+		nachaAlpha(f.EntryDescription, 10)+
+		f.EffectiveDt.Format("060102")+
+		f.EffectiveDt.Format("060102")+
+		"   "+ // settlement date, inserted by the ACH operator
+		NACHAOriginatorDepFI+
+		nachaNum(odfi8(f.ODFI), 8)+
+		nachaInt(f.BatchNumber, 7))
+
+	//-------------------------------
+	// Entry Detail Records
+	//-------------------------------
+	var hash, total int64
+	for i := 0; i < len(f.Entries); i++ {
+		e := &f.Entries[i]
+		if !ValidRoutingNumber(e.RoutingNumber) {
+			return fmt.Errorf("entry %d: invalid routing number: %q", i+1, e.RoutingNumber)
+		}
+		if len(e.TraceNumber) != 15 {
+			return fmt.Errorf("entry %d: invalid trace number: %q", i+1, e.TraceNumber)
+		}
+		if len(e.AccountNumber) == 0 || len(e.AccountNumber) > 17 {
+			return fmt.Errorf("entry %d: invalid account number", i+1)
+		}
+		cents := NACHACents(e.Amount)
+		if cents <= 0 || cents > 9999999999 {
+			return fmt.Errorf("entry %d: invalid amount %.2f", i+1, e.Amount)
+		}
+		tc := NACHACheckingDebit
+		if e.Savings {
+			tc = NACHASavingsDebit
+		}
+		var rdfi int64
+		fmt.Sscanf(e.RoutingNumber[:8], "%d", &rdfi)
+		hash += rdfi
+		total += cents
+		recs = append(recs, "6"+
+			tc+
+			e.RoutingNumber+ // 8 digit RDFI id followed by the check digit
+			nachaAlpha(e.AccountNumber, 17)+
+			nachaInt(cents, 10)+
+			nachaAlpha(e.IndividualID, 15)+
+			nachaAlpha(e.IndividualName, 22)+
+			"  "+
+			"0"+
+			e.TraceNumber)
+	}
+	hashStr := nachaInt(hash, 10)
+
+	//-------------------------------
+	// Batch Control Record
+	//-------------------------------
+	recs = append(recs, "8"+
+		NACHADebitsOnly+
+		nachaInt(int64(len(f.Entries)), 6)+
+		hashStr+
+		nachaInt(total, 12)+
+		nachaInt(0, 12)+
+		nachaAlpha(f.CompanyID, 10)+
+		nachaAlpha("", 19)+
+		nachaAlpha("", 6)+
+		nachaNum(odfi8(f.ODFI), 8)+
+		nachaInt(f.BatchNumber, 7))
+
+	//-------------------------------
+	// File Control Record
+	//-------------------------------
+	n := len(recs) + 1
+	blocks := (n + NACHABlockingFactor - 1) / NACHABlockingFactor
+	recs = append(recs, "9"+
+		nachaInt(1, 6)+
+		nachaInt(int64(blocks), 6)+
+		nachaInt(int64(len(f.Entries)), 8)+
+		hashStr+
+		nachaInt(total, 12)+
+		nachaInt(0, 12)+
+		nachaAlpha("", 39))
+
+	//-------------------------------
+	// fill out the last block
+	//-------------------------------
+	for len(recs)%NACHABlockingFactor != 0 {
+		recs = append(recs, strings.Repeat("9", NACHARecordSize))
+	}
+
+	bw := bufio.NewWriter(w)
+	for i := 0; i < len(recs); i++ {
+		if len(recs[i]) != NACHARecordSize {
+			return fmt.Errorf("internal error: NACHA record %d has length %d", i+1, len(recs[i]))
+		}
+		if _, err := bw.WriteString(recs[i] + "\n"); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// NACHAReturnReasons describes the common ACH return reason codes
+var NACHAReturnReasons = map[string]string{
+	"R01": "Insufficient Funds",
+	"R02": "Account Closed",
+	"R03": "No Account/Unable to Locate Account",
+	"R04": "Invalid Account Number",
+	"R05": "Unauthorized Debit to Consumer Account",
+	"R06": "Returned per ODFI's Request",
+	"R07": "Authorization Revoked by Customer",
+	"R08": "Payment Stopped",
+	"R09": "Uncollected Funds",
+	"R10": "Customer Advises Not Authorized",
+	"R11": "Customer Advises Entry Not in Accordance with the Terms of the Authorization",
+	"R12": "Account Sold to Another DFI",
+	"R14": "Representative Payee Deceased",
+	"R15": "Beneficiary or Account Holder Deceased",
+	"R16": "Account Frozen",
+	"R20": "Non-Transaction Account",
+	"R23": "Credit Entry Refused by Receiver",
+	"R24": "Duplicate Entry",
+	"R29": "Corporate Customer Advises Not Authorized",
+}
+
+// NACHAReturnReason returns a description of the return reason code
+//-----------------------------------------------------------------------------
+func NACHAReturnReason(code string) string {
+	if s, ok := NACHAReturnReasons[code]; ok {
+		return s
+	}
+	return "Returned"
+}
+
+// NACHAReturn is one returned entry from a NACHA return file
+type NACHAReturn struct {
+	TraceNumber string    // trace number of the original entry
+	ReturnCode  string    // R-code, e.g. R01
+	Amount      float64   // amount of the returned entry
+	Dt          time.Time // date of the return
+	Info        string    // addenda information supplied by the bank
+}
+
+// ParseNACHAReturns reads a NACHA return file and returns the returned
+// entries. Each return is an entry detail record followed by a return
+// addenda record (type 7, addenda type 99) which holds the reason code and
+// the trace number of the original entry.  The return date is the
+// effective entry date of the batch, or the file creation date if the
+// batch does not have one.
+//
+// INPUTS
+//    r = the file contents
+//
+// RETURNS
+//    the returned entries
+//    any error encountered
+//-----------------------------------------------------------------------------
+func ParseNACHAReturns(r io.Reader) ([]NACHAReturn, error) {
+	var m []NACHAReturn
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return m, err
+	}
+
+	//-----------------------------------------------------------
+	// Records are usually one per line, but some banks send the
+	// file as one continuous stream of 94 character records.
+	//-----------------------------------------------------------
+	var recs []string
+	for _, l := range strings.Split(strings.Replace(string(b), "\r", "", -1), "\n") {
+		for len(l) > NACHARecordSize {
+			recs = append(recs, l[:NACHARecordSize])
+			l = l[NACHARecordSize:]
+		}
+		if len(strings.TrimSpace(l)) > 0 {
+			recs = append(recs, l)
+		}
+	}
+
+	var fileDt, batchDt time.Time
+	var amt float64
+	haveEntry := false
+	for i := 0; i < len(recs); i++ {
+		l := recs[i]
+		if len(l) != NACHARecordSize {
+			return m, fmt.Errorf("record %d: expected %d characters, found %d", i+1, NACHARecordSize, len(l))
+		}
+		switch l[0] {
+		case '1':
+			fileDt, _ = time.Parse("060102", l[23:29])
+		case '5':
+			batchDt, err = time.Parse("060102", l[69:75])
+			if err != nil {
+				batchDt = fileDt
+			}
+		case '6':
+			var cents int64
+			if _, err = fmt.Sscanf(l[29:39], "%d", &cents); err != nil {
+				return m, fmt.Errorf("record %d: invalid amount %q", i+1, l[29:39])
+			}
+			amt = float64(cents) / 100
+			haveEntry = true
+		case '7':
+			if l[1:3] != "99" {
+				continue // not a return addenda
+			}
+			if !haveEntry {
+				return m, fmt.Errorf("record %d: return addenda without an entry detail record", i+1)
+			}
+			m = append(m, NACHAReturn{
+				ReturnCode:  l[3:6],
+				TraceNumber: l[6:21],
+				Amount:      amt,
+				Dt:          batchDt,
+				Info:        strings.TrimSpace(l[35:79]),
+			})
+			haveEntry = false
+		}
+	}
+	return m, nil
+}
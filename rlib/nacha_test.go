@@ -0,0 +1,167 @@
+package rlib
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestValidRoutingNumber checks the ABA routing number check digit
+func TestValidRoutingNumber(t *testing.T) {
+	var cases = []struct {
+		s     string
+		valid bool
+	}{
+		{"011000015", true},
+		{"021000021", true},
+		{"123456780", true},
+		{"123456789", false}, // bad check digit
+		{"02100002", false},  // too short
+		{"02100002a", false}, // not a digit
+		{"", false},
+	}
+	for i := 0; i < len(cases); i++ {
+		if v := ValidRoutingNumber(cases[i].s); v != cases[i].valid {
+			t.Errorf("ValidRoutingNumber(%q): expected %t, got %t\n", cases[i].s, cases[i].valid, v)
+		}
+	}
+}
+
+// TestNACHAFileWrite writes a two entry file and checks the record layout,
+// the control totals and the block padding
+func TestNACHAFileWrite(t *testing.T) {
+	dt := time.Date(2018, time.March, 1, 0, 0, 0, 0, time.UTC)
+	f := NACHAFile{
+		ImmediateDestination:     "011000015",
+		ImmediateDestinationName: "Federal Reserve Bank",
+		ImmediateOrigin:          "1234567890",
+		ImmediateOriginName:      "Acme Properties",
+		CompanyName:              "Acme Properties",
+		CompanyID:                "1234567890",
+		ODFI:                     "011000015",
+		EntryDescription:         "Rent",
+		BatchNumber:              17,
+		CreateTime:               dt,
+		EffectiveDt:              dt,
+		Entries: []NACHAEntry{
+			{RoutingNumber: "011000015", AccountNumber: "12345", Amount: 1500, IndividualID: "TC00001", IndividualName: "Jane Doe", TraceNumber: NACHATraceNumber("011000015", 1)},
+			{RoutingNumber: "021000021", AccountNumber: "987654321", Savings: true, Amount: 250.75, IndividualID: "TC00002", IndividualName: "John Smith", TraceNumber: NACHATraceNumber("011000015", 2)},
+		},
+	}
+
+	var b bytes.Buffer
+	if err := f.Write(&b); err != nil {
+		t.Errorf("NACHAFile.Write: unexpected error: %s\n", err.Error())
+		return
+	}
+	lines := strings.Split(strings.TrimRight(b.String(), "\n"), "\n")
+	if len(lines) != 10 {
+		t.Errorf("NACHAFile.Write: expected 10 records, got %d\n", len(lines))
+		return
+	}
+	for i := 0; i < len(lines); i++ {
+		if len(lines[i]) != NACHARecordSize {
+			t.Errorf("NACHAFile.Write: record %d has length %d\n", i+1, len(lines[i]))
+		}
+	}
+	for i, c := range "1566899999" {
+		if rune(lines[i][0]) != c {
+			t.Errorf("NACHAFile.Write: record %d: expected type %c, got %c\n", i+1, c, lines[i][0])
+		}
+	}
+
+	var cases = []struct {
+		rec    int
+		i, j   int
+		expect string
+	}{
+		{2, 1, 3, "27"},                // checking debit
+		{3, 1, 3, "37"},                // savings debit
+		{2, 29, 39, "0000150000"},      // amount in cents
+		{3, 29, 39, "0000025075"},      // amount in cents
+		{2, 79, 94, "011000010000001"}, // trace number
+		{4, 4, 10, "000002"},           // batch entry count
+		{4, 10, 20, "0003200003"},      // entry hash
+		{4, 20, 32, "000000175075"},    // total debits
+		{4, 87, 94, "0000017"},         // batch number
+		{5, 7, 13, "000001"},           // block count
+		{5, 31, 43, "000000175075"},    // total debits
+	}
+	for _, c := range cases {
+		if s := lines[c.rec][c.i:c.j]; s != c.expect {
+			t.Errorf("NACHAFile.Write: record %d [%d:%d]: expected %q, got %q\n", c.rec+1, c.i, c.j, c.expect, s)
+		}
+	}
+
+	f.Entries[1].RoutingNumber = "123456789"
+	if err := f.Write(&b); err == nil {
+		t.Errorf("NACHAFile.Write: expected an error for an invalid routing number\n")
+	}
+}
+
+// TestParseNACHAReturns adds return addenda to a generated file and checks
+// that the returns are found with their reason codes, amounts and date
+func TestParseNACHAReturns(t *testing.T) {
+	dt := time.Date(2018, time.March, 5, 0, 0, 0, 0, time.UTC)
+	f := NACHAFile{
+		ImmediateDestination: "011000015",
+		ODFI:                 "011000015",
+		CompanyName:          "Acme Properties",
+		CompanyID:            "1234567890",
+		EntryDescription:     "Rent",
+		BatchNumber:          3,
+		CreateTime:           dt,
+		EffectiveDt:          dt,
+		Entries: []NACHAEntry{
+			{RoutingNumber: "011000015", AccountNumber: "12345", Amount: 1500, TraceNumber: NACHATraceNumber("011000015", 1)},
+			{RoutingNumber: "021000021", AccountNumber: "987654321", Amount: 250.75, TraceNumber: NACHATraceNumber("011000015", 2)},
+		},
+	}
+	var b bytes.Buffer
+	if err := f.Write(&b); err != nil {
+		t.Errorf("NACHAFile.Write: unexpected error: %s\n", err.Error())
+		return
+	}
+
+	var codes = []string{"R01", "R02"}
+	var out []string
+	k := 0
+	for _, l := range strings.Split(strings.TrimRight(b.String(), "\n"), "\n") {
+		out = append(out, l)
+		if l[0] == '6' {
+			out = append(out, "799"+codes[k]+l[79:94]+"      "+"02100002"+nachaAlpha("ORIGINAL ENTRY RETURNED", 44)+l[79:94])
+			k++
+		}
+	}
+
+	// records without line breaks are accepted as well
+	m, err := ParseNACHAReturns(strings.NewReader(strings.Join(out, "")))
+	if err != nil {
+		t.Errorf("ParseNACHAReturns: unexpected error: %s\n", err.Error())
+		return
+	}
+	if len(m) != 2 {
+		t.Errorf("ParseNACHAReturns: expected 2 returns, got %d\n", len(m))
+		return
+	}
+	var expect = []NACHAReturn{
+		{TraceNumber: "011000010000001", ReturnCode: "R01", Amount: 1500},
+		{TraceNumber: "011000010000002", ReturnCode: "R02", Amount: 250.75},
+	}
+	for i := 0; i < len(expect); i++ {
+		if m[i].TraceNumber != expect[i].TraceNumber || m[i].ReturnCode != expect[i].ReturnCode || m[i].Amount != expect[i].Amount {
+			t.Errorf("ParseNACHAReturns: return %d: expected %#v, got %#v\n", i, expect[i], m[i])
+		}
+		if !m[i].Dt.Equal(dt) {
+			t.Errorf("ParseNACHAReturns: return %d: expected date %s, got %s\n", i, dt.Format(RRDATEINPFMT), m[i].Dt.Format(RRDATEINPFMT))
+		}
+	}
+	if m[0].Info != "ORIGINAL ENTRY RETURNED" {
+		t.Errorf("ParseNACHAReturns: unexpected addenda info %q\n", m[0].Info)
+	}
+
+	if _, err = ParseNACHAReturns(strings.NewReader("5200 short record\n")); err == nil {
+		t.Errorf("ParseNACHAReturns: expected an error for a short record\n")
+	}
+}
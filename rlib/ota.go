@@ -0,0 +1,189 @@
+package rlib
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// OTANamespace is the XML namespace of the OpenTravel messages
+const OTANamespace = "http://www.opentravel.org/OTA/2003/05"
+
+// OTAVersion is the version of the OpenTravel messages produced
+const OTAVersion = "1.0"
+
+// OTAAgeAdult is the OpenTravel AgeQualifyingCode of adults
+const OTAAgeAdult = "10"
+
+// OTAStatusApplicationControl says which dates, room type and rate plan an
+// OpenTravel message applies to.  Start and End are inclusive.
+type OTAStatusApplicationControl struct {
+	Start        string `xml:"Start,attr"`
+	End          string `xml:"End,attr"`
+	InvTypeCode  string `xml:"InvTypeCode,attr"`
+	RatePlanCode string `xml:"RatePlanCode,attr,omitempty"`
+}
+
+// OTAHotelRateAmountNotifRQ is the OpenTravel message that publishes rates
+type OTAHotelRateAmountNotifRQ struct {
+	XMLName            xml.Name              `xml:"OTA_HotelRateAmountNotifRQ"`
+	Xmlns              string                `xml:"xmlns,attr"`
+	EchoToken          string                `xml:"EchoToken,attr"`
+	TimeStamp          string                `xml:"TimeStamp,attr"`
+	Version            string                `xml:"Version,attr"`
+	RateAmountMessages OTARateAmountMessages `xml:"RateAmountMessages"`
+}
+
+// OTARateAmountMessages are the rates of a hotel
+type OTARateAmountMessages struct {
+	HotelCode string                 `xml:"HotelCode,attr"`
+	Messages  []OTARateAmountMessage `xml:"RateAmountMessage"`
+}
+
+// OTARateAmountMessage is the rate of a room type under a rate plan for a
+// range of dates
+type OTARateAmountMessage struct {
+	StatusApplicationControl OTAStatusApplicationControl `xml:"StatusApplicationControl"`
+	Rates                    []OTARate                   `xml:"Rates>Rate"`
+}
+
+// OTARate is a nightly rate with the charge for additional guests
+type OTARate struct {
+	BaseByGuestAmts        []OTABaseByGuestAmt        `xml:"BaseByGuestAmts>BaseByGuestAmt"`
+	AdditionalGuestAmounts *OTAAdditionalGuestAmounts `xml:"AdditionalGuestAmounts,omitempty"`
+}
+
+// OTABaseByGuestAmt is the amount charged for up to NumberOfGuests guests
+type OTABaseByGuestAmt struct {
+	AmountBeforeTax string `xml:"AmountBeforeTax,attr"`
+	CurrencyCode    string `xml:"CurrencyCode,attr,omitempty"`
+	NumberOfGuests  int64  `xml:"NumberOfGuests,attr,omitempty"`
+}
+
+// OTAAdditionalGuestAmounts are the charges for additional guests
+type OTAAdditionalGuestAmounts struct {
+	Amounts []OTAAdditionalGuestAmount `xml:"AdditionalGuestAmount"`
+}
+
+// OTAAdditionalGuestAmount is the amount charged for each additional guest
+type OTAAdditionalGuestAmount struct {
+	Amount            string `xml:"Amount,attr"`
+	AgeQualifyingCode string `xml:"AgeQualifyingCode,attr"`
+}
+
+// OTAHotelAvailNotifRQ is the OpenTravel message that publishes availability
+type OTAHotelAvailNotifRQ struct {
+	XMLName             xml.Name               `xml:"OTA_HotelAvailNotifRQ"`
+	Xmlns               string                 `xml:"xmlns,attr"`
+	EchoToken           string                 `xml:"EchoToken,attr"`
+	TimeStamp           string                 `xml:"TimeStamp,attr"`
+	Version             string                 `xml:"Version,attr"`
+	AvailStatusMessages OTAAvailStatusMessages `xml:"AvailStatusMessages"`
+}
+
+// OTAAvailStatusMessages is the availability of a hotel
+type OTAAvailStatusMessages struct {
+	HotelCode string                  `xml:"HotelCode,attr"`
+	Messages  []OTAAvailStatusMessage `xml:"AvailStatusMessage"`
+}
+
+// OTAAvailStatusMessage is the number of rooms of a type that can be booked
+// for a range of dates
+type OTAAvailStatusMessage struct {
+	BookingLimit             int64                       `xml:"BookingLimit,attr"`
+	StatusApplicationControl OTAStatusApplicationControl `xml:"StatusApplicationControl"`
+	RestrictionStatus        OTARestrictionStatus        `xml:"RestrictionStatus"`
+}
+
+// OTARestrictionStatus opens or closes a room type for sale
+type OTARestrictionStatus struct {
+	Status string `xml:"Status,attr"` // Open or Close
+}
+
+// otaDates returns the inclusive OpenTravel dates of d1 - d2
+func otaDates(d1, d2 *time.Time) (string, string) {
+	return d1.Format(RRDATEFMTSQL), d2.AddDate(0, 0, -1).Format(RRDATEFMTSQL)
+}
+
+// otaAmount formats an amount for an OpenTravel message
+func otaAmount(x float64) string {
+	return fmt.Sprintf("%.2f", x)
+}
+
+// NewOTAHotelRateAmountNotif returns the OpenTravel message that publishes
+// rates m of hotel hotelCode.
+//
+// INPUTS
+//     hotelCode = the code of the business on the channel
+//     currency  = the currency code of the amounts, "" to leave it out
+//     m         = the rates
+//     now       = time stamp of the message
+//
+// RETURNS
+//     the message
+//-----------------------------------------------------------------------------
+func NewOTAHotelRateAmountNotif(hotelCode, currency string, m []ARIRate, now *time.Time) OTAHotelRateAmountNotifRQ {
+	var rq = OTAHotelRateAmountNotifRQ{
+		Xmlns:     OTANamespace,
+		EchoToken: GenerateUserRefNo(),
+		TimeStamp: now.Format(time.RFC3339),
+		Version:   OTAVersion,
+	}
+	rq.RateAmountMessages.HotelCode = hotelCode
+	for i := 0; i < len(m); i++ {
+		var msg OTARateAmountMessage
+		msg.StatusApplicationControl.Start, msg.StatusApplicationControl.End = otaDates(&m[i].DtStart, &m[i].DtStop)
+		msg.StatusApplicationControl.InvTypeCode = m[i].InvTypeCode
+		msg.StatusApplicationControl.RatePlanCode = m[i].RatePlanCode
+		var rate OTARate
+		rate.BaseByGuestAmts = []OTABaseByGuestAmt{{AmountBeforeTax: otaAmount(m[i].Amount), CurrencyCode: currency, NumberOfGuests: m[i].Guests}}
+		if m[i].AdditionalGuestFee > 0 {
+			rate.AdditionalGuestAmounts = &OTAAdditionalGuestAmounts{Amounts: []OTAAdditionalGuestAmount{{Amount: otaAmount(m[i].AdditionalGuestFee), AgeQualifyingCode: OTAAgeAdult}}}
+		}
+		msg.Rates = []OTARate{rate}
+		rq.RateAmountMessages.Messages = append(rq.RateAmountMessages.Messages, msg)
+	}
+	return rq
+}
+
+// NewOTAHotelAvailNotif returns the OpenTravel message that publishes the
+// availability m of hotel hotelCode.  Room types with nothing available are
+// closed.
+//
+// INPUTS
+//     hotelCode = the code of the business on the channel
+//     m         = the availability
+//     now       = time stamp of the message
+//
+// RETURNS
+//     the message
+//-----------------------------------------------------------------------------
+func NewOTAHotelAvailNotif(hotelCode string, m []ARIAvail, now *time.Time) OTAHotelAvailNotifRQ {
+	var rq = OTAHotelAvailNotifRQ{
+		Xmlns:     OTANamespace,
+		EchoToken: GenerateUserRefNo(),
+		TimeStamp: now.Format(time.RFC3339),
+		Version:   OTAVersion,
+	}
+	rq.AvailStatusMessages.HotelCode = hotelCode
+	for i := 0; i < len(m); i++ {
+		var msg = OTAAvailStatusMessage{BookingLimit: m[i].Available}
+		msg.StatusApplicationControl.Start, msg.StatusApplicationControl.End = otaDates(&m[i].DtStart, &m[i].DtStop)
+		msg.StatusApplicationControl.InvTypeCode = m[i].InvTypeCode
+		msg.RestrictionStatus.Status = "Open"
+		if m[i].Available <= 0 {
+			msg.RestrictionStatus.Status = "Close"
+		}
+		rq.AvailStatusMessages.Messages = append(rq.AvailStatusMessages.Messages, msg)
+	}
+	return rq
+}
+
+// MarshalOTA returns the XML document of OpenTravel message v
+func MarshalOTA(v interface{}) ([]byte, error) {
+	b, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return b, err
+	}
+	return append([]byte(xml.Header), b...), nil
+}
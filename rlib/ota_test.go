@@ -0,0 +1,155 @@
+package rlib
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestAppendARIRate checks that consecutive days with the same rate are
+// combined and that a change of amount or a gap starts a new rate
+func TestAppendARIRate(t *testing.T) {
+	d := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	day := func(n int, amt float64) ARIRate {
+		return ARIRate{RatePlanCode: "BAR", InvTypeCode: "KNG", DtStart: d.AddDate(0, 0, n), DtStop: d.AddDate(0, 0, n+1), Amount: amt}
+	}
+	var m []ARIRate
+	for _, r := range []ARIRate{day(0, 100), day(1, 100), day(2, 120), day(4, 120)} {
+		m = AppendARIRate(m, r)
+	}
+	if len(m) != 3 {
+		t.Fatalf("expected 3 rates, got %d\n", len(m))
+	}
+	if !m[0].DtStop.Equal(d.AddDate(0, 0, 2)) {
+		t.Errorf("expected first rate to stop %s, got %s\n", d.AddDate(0, 0, 2).Format(RRDATEFMTSQL), m[0].DtStop.Format(RRDATEFMTSQL))
+	}
+	if m[1].Amount != 120 || !m[2].DtStart.Equal(d.AddDate(0, 0, 4)) {
+		t.Errorf("unexpected rates: %#v\n", m[1:])
+	}
+
+	var a []ARIAvail
+	for n, x := range []int64{3, 3, 3, 0} {
+		a = AppendARIAvail(a, ARIAvail{InvTypeCode: "KNG", DtStart: d.AddDate(0, 0, n), DtStop: d.AddDate(0, 0, n+1), Available: x})
+	}
+	if len(a) != 2 || !a[0].DtStop.Equal(d.AddDate(0, 0, 3)) || a[1].Available != 0 {
+		t.Errorf("unexpected availability: %#v\n", a)
+	}
+}
+
+// TestOTAMessages checks the OpenTravel rate and availability messages.
+// End dates are inclusive and sold out room types are closed.
+func TestOTAMessages(t *testing.T) {
+	if RRdb.Rand == nil {
+		RRdb.Rand = rand.New(rand.NewSource(1))
+	}
+	d := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, time.February, 20, 8, 0, 0, 0, time.UTC)
+
+	rates := []ARIRate{{RatePlanCode: "BAR", InvTypeCode: "KNG", DtStart: d, DtStop: d.AddDate(0, 0, 3), Amount: 129.5, Guests: 2, AdditionalGuestFee: 15}}
+	b, err := MarshalOTA(NewOTAHotelRateAmountNotif("HTL1", "USD", rates, &now))
+	if err != nil {
+		t.Fatalf("MarshalOTA: %s\n", err.Error())
+	}
+	var rq OTAHotelRateAmountNotifRQ
+	if err = xml.Unmarshal(b, &rq); err != nil {
+		t.Fatalf("xml.Unmarshal: %s\n", err.Error())
+	}
+	if rq.RateAmountMessages.HotelCode != "HTL1" || len(rq.RateAmountMessages.Messages) != 1 {
+		t.Fatalf("unexpected message: %s\n", string(b))
+	}
+	msg := rq.RateAmountMessages.Messages[0]
+	if msg.StatusApplicationControl.Start != "2026-03-01" || msg.StatusApplicationControl.End != "2026-03-03" {
+		t.Errorf("expected 2026-03-01 - 2026-03-03, got %s - %s\n", msg.StatusApplicationControl.Start, msg.StatusApplicationControl.End)
+	}
+	amt := msg.Rates[0].BaseByGuestAmts[0]
+	if amt.AmountBeforeTax != "129.50" || amt.CurrencyCode != "USD" || amt.NumberOfGuests != 2 {
+		t.Errorf("unexpected amount: %#v\n", amt)
+	}
+	if a := msg.Rates[0].AdditionalGuestAmounts; a == nil || len(a.Amounts) != 1 || a.Amounts[0].Amount != "15.00" {
+		t.Errorf("unexpected additional guest amounts: %#v\n", msg.Rates[0].AdditionalGuestAmounts)
+	}
+
+	// without an additional guest fee the element is left out
+	rates[0].AdditionalGuestFee = 0
+	if b, err = MarshalOTA(NewOTAHotelRateAmountNotif("HTL1", "USD", rates, &now)); err != nil {
+		t.Fatalf("MarshalOTA: %s\n", err.Error())
+	}
+	if strings.Contains(string(b), "AdditionalGuestAmounts") {
+		t.Errorf("expected no AdditionalGuestAmounts element, got %s\n", string(b))
+	}
+
+	avail := []ARIAvail{
+		{InvTypeCode: "KNG", DtStart: d, DtStop: d.AddDate(0, 0, 1), Available: 4},
+		{InvTypeCode: "KNG", DtStart: d.AddDate(0, 0, 1), DtStop: d.AddDate(0, 0, 2), Available: 0},
+	}
+	if b, err = MarshalOTA(NewOTAHotelAvailNotif("HTL1", avail, &now)); err != nil {
+		t.Fatalf("MarshalOTA: %s\n", err.Error())
+	}
+	var aq OTAHotelAvailNotifRQ
+	if err = xml.Unmarshal(b, &aq); err != nil {
+		t.Fatalf("xml.Unmarshal: %s\n", err.Error())
+	}
+	if len(aq.AvailStatusMessages.Messages) != 2 {
+		t.Fatalf("unexpected message: %s\n", string(b))
+	}
+	if s := aq.AvailStatusMessages.Messages[0]; s.BookingLimit != 4 || s.RestrictionStatus.Status != "Open" || s.StatusApplicationControl.End != "2026-03-01" {
+		t.Errorf("unexpected availability: %#v\n", s)
+	}
+	if s := aq.AvailStatusMessages.Messages[1]; s.RestrictionStatus.Status != "Close" {
+		t.Errorf("expected sold out room type to be closed, got %s\n", s.RestrictionStatus.Status)
+	}
+}
+
+// TestARISinks checks that the file sink writes each message in its
+// directory and that the HTTP sink posts each message and reports failures
+func TestARISinks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ari")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir: %s\n", err.Error())
+	}
+	defer os.RemoveAll(dir)
+	s, err := NewARISink(&BizPropsARISink{Channel: "GDS", Dir: dir})
+	if err != nil {
+		t.Fatalf("NewARISink: %s\n", err.Error())
+	}
+	if err = s.Send("rates.xml", []byte("<x/>")); err != nil {
+		t.Fatalf("Send: %s\n", err.Error())
+	}
+	if b, err := ioutil.ReadFile(filepath.Join(dir, "rates.xml")); err != nil || string(b) != "<x/>" {
+		t.Errorf("expected the message in rates.xml, got %q (%v)\n", string(b), err)
+	}
+
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		got = string(b)
+		if strings.HasSuffix(r.URL.Path, "/fail") {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+	if s, err = NewARISink(&BizPropsARISink{Channel: "Sabre", URL: srv.URL + "/ari"}); err != nil {
+		t.Fatalf("NewARISink: %s\n", err.Error())
+	}
+	if err = s.Send("avail.xml", []byte("<y/>")); err != nil {
+		t.Errorf("Send: %s\n", err.Error())
+	}
+	if got != "<y/>" {
+		t.Errorf("expected the message to be posted, got %q\n", got)
+	}
+	s = &ARIHTTPSink{URL: srv.URL + "/fail"}
+	if err = s.Send("avail.xml", []byte("<y/>")); err == nil {
+		t.Errorf("expected an error for a failed post\n")
+	}
+
+	if _, err = NewARISink(&BizPropsARISink{Channel: "GDS"}); err == nil {
+		t.Errorf("expected an error for a destination without URL or directory\n")
+	}
+}
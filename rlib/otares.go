@@ -0,0 +1,259 @@
+package rlib
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// OTAReservation Status values
+const (
+	OTAReservationBooked    = 0
+	OTAReservationCancelled = 1
+)
+
+// OpenTravel UniqueID types (UIT) of the reservation ids
+const (
+	OTAUIDHotel       = "10" // the id the hotel gave the reservation
+	OTAUIDReservation = "14" // the id the channel gave the reservation
+)
+
+// OpenTravel error warning types (EWT)
+const (
+	OTAErrTypeUnknown    = "1"
+	OTAErrTypeBizRule    = "3"
+	OTAErrTypeAuth       = "4"
+	OTAErrTypeRequired   = "10"
+	OTAErrTypeProcessing = "12"
+)
+
+// OpenTravel error codes (ERR)
+const (
+	OTAErrInvalidDate   = "15"
+	OTAErrCancelled     = "95"
+	OTAErrResNotFound   = "97"
+	OTAErrRequired      = "321"
+	OTAErrNoAvail       = "322"
+	OTAErrHotelCode     = "392"
+	OTAErrRoomType      = "402"
+	OTAErrSystem        = "448"
+	OTAErrUnableToProc  = "450"
+	OTAErrAuthorization = "497"
+)
+
+// OTAError is an error reported to the sender of an OpenTravel message
+type OTAError struct {
+	Type string `xml:"Type,attr"`
+	Code string `xml:"Code,attr,omitempty"`
+	Text string `xml:",chardata"`
+}
+
+// NewOTAError returns the OpenTravel error of type typ, code code and text
+// formatted from format and a
+func NewOTAError(typ, code, format string, a ...interface{}) *OTAError {
+	return &OTAError{Type: typ, Code: code, Text: fmt.Sprintf(format, a...)}
+}
+
+// Error returns the text of the error
+func (e *OTAError) Error() string {
+	return fmt.Sprintf("OTA error %s/%s: %s", e.Type, e.Code, e.Text)
+}
+
+// OTAHotelResNotifRQ is the OpenTravel message a channel sends to book,
+// modify or cancel reservations.  The ResStatus of each HotelReservation
+// is Book, Modify or Cancel; if it is not set the ResStatus of the message
+// applies (Commit books).
+type OTAHotelResNotifRQ struct {
+	XMLName           xml.Name              `xml:"OTA_HotelResNotifRQ"`
+	EchoToken         string                `xml:"EchoToken,attr"`
+	TimeStamp         string                `xml:"TimeStamp,attr"`
+	Version           string                `xml:"Version,attr"`
+	ResStatus         string                `xml:"ResStatus,attr"`
+	RequestorID       OTARequestorID        `xml:"POS>Source>RequestorID"`
+	HotelReservations []OTAHotelReservation `xml:"HotelReservations>HotelReservation"`
+}
+
+// OTARequestorID identifies the sender of a message
+type OTARequestorID struct {
+	ID              string `xml:"ID,attr"`
+	MessagePassword string `xml:"MessagePassword,attr"`
+}
+
+// OTAUniqueID is an id of a reservation
+type OTAUniqueID struct {
+	Type string `xml:"Type,attr"`
+	ID   string `xml:"ID,attr"`
+}
+
+// OTAHotelReservation is a reservation of a message
+type OTAHotelReservation struct {
+	ResStatus string        `xml:"ResStatus,attr"`
+	UniqueID  OTAUniqueID   `xml:"UniqueID"`
+	RoomStays []OTARoomStay `xml:"RoomStays>RoomStay"`
+	ResGuests []OTAResGuest `xml:"ResGuests>ResGuest"`
+}
+
+// OTAHotelReservationRS is a reservation acknowledged in a response, with
+// the id the hotel gave it
+type OTAHotelReservationRS struct {
+	ResStatus           string                  `xml:"ResStatus,attr"`
+	UniqueID            OTAUniqueID             `xml:"UniqueID"`
+	HotelReservationIDs []OTAHotelReservationID `xml:"ResGlobalInfo>HotelReservationIDs>HotelReservationID"`
+}
+
+// OTARoomStay is the room type, dates and guests of a reservation
+type OTARoomStay struct {
+	RoomTypes         []OTARoomType        `xml:"RoomTypes>RoomType"`
+	RatePlans         []OTARatePlan        `xml:"RatePlans>RatePlan"`
+	GuestCounts       []OTAGuestCount      `xml:"GuestCounts>GuestCount"`
+	TimeSpan          OTATimeSpan          `xml:"TimeSpan"`
+	BasicPropertyInfo OTABasicPropertyInfo `xml:"BasicPropertyInfo"`
+}
+
+// OTARoomType is the room type reserved.  It is the InvTypeCode the room
+// type is exported with.
+type OTARoomType struct {
+	RoomTypeCode string `xml:"RoomTypeCode,attr"`
+}
+
+// OTARatePlan is the rate plan booked
+type OTARatePlan struct {
+	RatePlanCode string `xml:"RatePlanCode,attr"`
+}
+
+// OTAGuestCount is the number of guests of an age group
+type OTAGuestCount struct {
+	AgeQualifyingCode string `xml:"AgeQualifyingCode,attr"`
+	Count             int64  `xml:"Count,attr"`
+}
+
+// OTATimeSpan is the arrival and departure dates of a stay
+type OTATimeSpan struct {
+	Start string `xml:"Start,attr"`
+	End   string `xml:"End,attr"`
+}
+
+// OTABasicPropertyInfo identifies the hotel
+type OTABasicPropertyInfo struct {
+	HotelCode string `xml:"HotelCode,attr"`
+}
+
+// OTAResGuest is a guest of a reservation
+type OTAResGuest struct {
+	Customer OTACustomer `xml:"Profiles>ProfileInfo>Profile>Customer"`
+}
+
+// OTACustomer is the contact information of a guest
+type OTACustomer struct {
+	GivenName   string         `xml:"PersonName>GivenName"`
+	Surname     string         `xml:"PersonName>Surname"`
+	Telephone   []OTATelephone `xml:"Telephone"`
+	Email       string         `xml:"Email"`
+	AddressLine string         `xml:"Address>AddressLine"`
+	CityName    string         `xml:"Address>CityName"`
+	PostalCode  string         `xml:"Address>PostalCode"`
+	StateProv   string         `xml:"Address>StateProv"`
+	CountryName string         `xml:"Address>CountryName"`
+}
+
+// OTATelephone is a phone number
+type OTATelephone struct {
+	PhoneNumber string `xml:"PhoneNumber,attr"`
+}
+
+// OTAHotelReservationID is an id of a reservation
+type OTAHotelReservationID struct {
+	ResIDType  string `xml:"ResID_Type,attr"`
+	ResIDValue string `xml:"ResID_Value,attr"`
+}
+
+// OTASuccess marks a response as successful
+type OTASuccess struct{}
+
+// OTAErrors are the errors of a response
+type OTAErrors struct {
+	Errors []OTAError `xml:"Error"`
+}
+
+// OTAHotelReservationsRS are the reservations acknowledged in a response
+type OTAHotelReservationsRS struct {
+	HotelReservations []OTAHotelReservationRS `xml:"HotelReservation"`
+}
+
+// OTAHotelResNotifRS is the response to an OTAHotelResNotifRQ.  It has
+// either Success and the reservations processed, or Errors.
+type OTAHotelResNotifRS struct {
+	XMLName           xml.Name                `xml:"OTA_HotelResNotifRS"`
+	Xmlns             string                  `xml:"xmlns,attr"`
+	EchoToken         string                  `xml:"EchoToken,attr,omitempty"`
+	TimeStamp         string                  `xml:"TimeStamp,attr"`
+	Version           string                  `xml:"Version,attr"`
+	Success           *OTASuccess             `xml:"Success,omitempty"`
+	Errors            *OTAErrors              `xml:"Errors,omitempty"`
+	HotelReservations *OTAHotelReservationsRS `xml:"HotelReservations,omitempty"`
+}
+
+// NewOTAHotelResNotifRS returns the response to rq.  If err is nil the
+// response acknowledges the reservations m.  Otherwise it reports err; an
+// err that is not an *OTAError is reported as a system error.
+//
+// INPUTS
+//     rq  = the message answered
+//     m   = the reservations processed, with the ids to return
+//     err = the error, nil if the message was processed
+//     now = time stamp of the response
+//
+// RETURNS
+//     the response
+//-----------------------------------------------------------------------------
+func NewOTAHotelResNotifRS(rq *OTAHotelResNotifRQ, m []OTAHotelReservationRS, err error, now *time.Time) OTAHotelResNotifRS {
+	var rs = OTAHotelResNotifRS{
+		Xmlns:     OTANamespace,
+		EchoToken: rq.EchoToken,
+		TimeStamp: now.Format(time.RFC3339),
+		Version:   OTAVersion,
+	}
+	if err == nil {
+		rs.Success = &OTASuccess{}
+		rs.HotelReservations = &OTAHotelReservationsRS{HotelReservations: m}
+		return rs
+	}
+	e, ok := err.(*OTAError)
+	if !ok {
+		e = NewOTAError(OTAErrTypeProcessing, OTAErrSystem, "%s", err.Error())
+	}
+	rs.Errors = &OTAErrors{Errors: []OTAError{*e}}
+	return rs
+}
+
+// Action returns the status of reservation r of message rq: Book, Modify
+// or Cancel.  A reservation without ResStatus takes the ResStatus of the
+// message.
+func (rq *OTAHotelResNotifRQ) Action(r *OTAHotelReservation) (string, error) {
+	s := r.ResStatus
+	if len(s) == 0 {
+		s = rq.ResStatus
+	}
+	switch strings.ToLower(s) {
+	case "", "book", "commit":
+		return "Book", nil
+	case "modify":
+		return "Modify", nil
+	case "cancel":
+		return "Cancel", nil
+	}
+	return "", NewOTAError(OTAErrTypeBizRule, OTAErrUnableToProc, "unsupported ResStatus: %s", s)
+}
+
+// ParseOTADate returns the date of an OpenTravel date or date time s
+func ParseOTADate(s string) (time.Time, error) {
+	if len(s) > 10 {
+		s = s[:10]
+	}
+	dt, err := time.Parse(RRDATEFMTSQL, s)
+	if err != nil {
+		return dt, NewOTAError(OTAErrTypeBizRule, OTAErrInvalidDate, "invalid date: %s", s)
+	}
+	return dt, nil
+}
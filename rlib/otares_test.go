@@ -0,0 +1,165 @@
+package rlib
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+var otaResNotifRQ = `<?xml version="1.0" encoding="UTF-8"?>
+<OTA_HotelResNotifRQ xmlns="http://www.opentravel.org/OTA/2003/05" EchoToken="e1" TimeStamp="2026-02-20T08:00:00Z" Version="1.0" ResStatus="Commit">
+  <POS><Source><RequestorID ID="chan1" MessagePassword="secret"/></Source></POS>
+  <HotelReservations>
+    <HotelReservation ResStatus="Book">
+      <UniqueID Type="14" ID="ABC123"/>
+      <RoomStays>
+        <RoomStay>
+          <RoomTypes><RoomType RoomTypeCode="KNG"/></RoomTypes>
+          <RatePlans><RatePlan RatePlanCode="BAR"/></RatePlans>
+          <GuestCounts><GuestCount AgeQualifyingCode="10" Count="2"/></GuestCounts>
+          <TimeSpan Start="2026-03-01" End="2026-03-04"/>
+          <BasicPropertyInfo HotelCode="HTL1"/>
+        </RoomStay>
+      </RoomStays>
+      <ResGuests><ResGuest><Profiles><ProfileInfo><Profile><Customer>
+        <PersonName><GivenName>Jane</GivenName><Surname>Doe</Surname></PersonName>
+        <Telephone PhoneNumber="555-0100"/>
+        <Email>jane@example.com</Email>
+      </Customer></Profile></ProfileInfo></Profiles></ResGuest></ResGuests>
+    </HotelReservation>
+    <HotelReservation>
+      <UniqueID Type="14" ID="ABC124"/>
+    </HotelReservation>
+  </HotelReservations>
+</OTA_HotelResNotifRQ>`
+
+// TestOTAHotelResNotifRQ checks that a reservation message is read and that
+// each reservation gets the right action
+func TestOTAHotelResNotifRQ(t *testing.T) {
+	var rq OTAHotelResNotifRQ
+	if err := xml.Unmarshal([]byte(otaResNotifRQ), &rq); err != nil {
+		t.Fatalf("xml.Unmarshal: %s\n", err.Error())
+	}
+	if rq.RequestorID.ID != "chan1" || rq.RequestorID.MessagePassword != "secret" || len(rq.HotelReservations) != 2 {
+		t.Fatalf("unexpected message: %#v\n", rq)
+	}
+	r := &rq.HotelReservations[0]
+	if r.UniqueID.ID != "ABC123" || len(r.RoomStays) != 1 || len(r.ResGuests) != 1 {
+		t.Fatalf("unexpected reservation: %#v\n", r)
+	}
+	rs := r.RoomStays[0]
+	if rs.RoomTypes[0].RoomTypeCode != "KNG" || rs.TimeSpan.Start != "2026-03-01" || rs.TimeSpan.End != "2026-03-04" || rs.BasicPropertyInfo.HotelCode != "HTL1" {
+		t.Errorf("unexpected room stay: %#v\n", rs)
+	}
+	c := r.ResGuests[0].Customer
+	if c.GivenName != "Jane" || c.Surname != "Doe" || c.Email != "jane@example.com" || len(c.Telephone) != 1 || c.Telephone[0].PhoneNumber != "555-0100" {
+		t.Errorf("unexpected guest: %#v\n", c)
+	}
+
+	var cases = []struct {
+		rq, r  string
+		expect string
+	}{
+		{"Commit", "Book", "Book"},
+		{"Commit", "", "Book"},
+		{"", "", "Book"},
+		{"Modify", "", "Modify"},
+		{"Commit", "Modify", "Modify"},
+		{"", "Cancel", "Cancel"},
+		{"Cancel", "", "Cancel"},
+		{"", "Waitlisted", ""},
+	}
+	for i := 0; i < len(cases); i++ {
+		q := OTAHotelResNotifRQ{ResStatus: cases[i].rq}
+		a, err := q.Action(&OTAHotelReservation{ResStatus: cases[i].r})
+		if a != cases[i].expect {
+			t.Errorf("case %d: expected %q, got %q\n", i, cases[i].expect, a)
+		}
+		if (err != nil) != (len(cases[i].expect) == 0) {
+			t.Errorf("case %d: unexpected error %v\n", i, err)
+		}
+	}
+}
+
+// TestParseOTADate checks OpenTravel dates with and without a time
+func TestParseOTADate(t *testing.T) {
+	expect := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	for _, s := range []string{"2026-03-01", "2026-03-01T15:00:00", "2026-03-01T15:00:00-05:00"} {
+		dt, err := ParseOTADate(s)
+		if err != nil || !dt.Equal(expect) {
+			t.Errorf("%s: expected %s, got %s (%v)\n", s, expect.Format(RRDATEFMTSQL), dt.Format(RRDATEFMTSQL), err)
+		}
+	}
+	_, err := ParseOTADate("03/01/2026")
+	if e, ok := err.(*OTAError); !ok || e.Code != OTAErrInvalidDate {
+		t.Errorf("expected an invalid date error, got %v\n", err)
+	}
+}
+
+// TestOTAHotelResNotifRS checks the acknowledgement and the error responses
+func TestOTAHotelResNotifRS(t *testing.T) {
+	rq := OTAHotelResNotifRQ{EchoToken: "e1"}
+	now := time.Date(2026, time.February, 20, 8, 0, 0, 0, time.UTC)
+	m := []OTAHotelReservationRS{{
+		ResStatus:           "Book",
+		UniqueID:            OTAUniqueID{Type: OTAUIDReservation, ID: "ABC123"},
+		HotelReservationIDs: []OTAHotelReservationID{{ResIDType: OTAUIDHotel, ResIDValue: "RR123"}},
+	}}
+	b, err := MarshalOTA(NewOTAHotelResNotifRS(&rq, m, nil, &now))
+	if err != nil {
+		t.Fatalf("MarshalOTA: %s\n", err.Error())
+	}
+	s := string(b)
+	for _, x := range []string{`EchoToken="e1"`, "<Success></Success>", `<UniqueID Type="14" ID="ABC123">`, `ResID_Value="RR123"`} {
+		if !strings.Contains(s, x) {
+			t.Errorf("expected %s in\n%s\n", x, s)
+		}
+	}
+	if strings.Contains(s, "<Errors>") || strings.Contains(s, "<RoomStays>") {
+		t.Errorf("unexpected elements in\n%s\n", s)
+	}
+
+	var cases = []struct {
+		err  error
+		code string
+	}{
+		{NewOTAError(OTAErrTypeBizRule, OTAErrNoAvail, "no availability"), OTAErrNoAvail},
+		{fmt.Errorf("database is down"), OTAErrSystem},
+	}
+	for i := 0; i < len(cases); i++ {
+		var rs OTAHotelResNotifRS
+		if b, err = MarshalOTA(NewOTAHotelResNotifRS(&rq, m, cases[i].err, &now)); err != nil {
+			t.Fatalf("MarshalOTA: %s\n", err.Error())
+		}
+		if err = xml.Unmarshal(b, &rs); err != nil {
+			t.Fatalf("xml.Unmarshal: %s\n", err.Error())
+		}
+		if rs.Success != nil || rs.HotelReservations != nil || rs.Errors == nil || len(rs.Errors.Errors) != 1 || rs.Errors.Errors[0].Code != cases[i].code {
+			t.Errorf("case %d: unexpected response\n%s\n", i, string(b))
+		}
+	}
+}
+
+// TestIsRequestor checks the authentication of the channels
+func TestIsRequestor(t *testing.T) {
+	p := BizPropsARI{Requestors: []BizPropsARIRequestor{{ID: "chan1", Password: "secret"}, {ID: "", Password: ""}, {ID: "chan3", Password: ""}}}
+	var cases = []struct {
+		id, pw string
+		expect bool
+	}{
+		{"chan1", "secret", true},
+		{"chan1", "wrong", false},
+		{"chan2", "secret", false},
+		{"", "", false},
+		{"chan3", "", false},        // no password
+		{"chan1", "secretx", false}, // longer
+		{"chan1", "secre", false},   // shorter
+	}
+	for i := 0; i < len(cases); i++ {
+		if x := p.IsRequestor(cases[i].id, cases[i].pw); x != cases[i].expect {
+			t.Errorf("case %d: expected %t, got %t\n", i, cases[i].expect, x)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package rlib
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ParsePortfolio returns the businesses of a portfolio.  s is a comma
+// separated list of business designations (BUD) or BIDs.
+//
+// INPUTS
+//    ctx = context
+//    s   = the list, for example "REX,CCC" or "1,3"
+//
+// RETURNS
+//    the BIDs, in the order of the list, without duplicates
+//    any error encountered
+//-----------------------------------------------------------------------------
+func ParsePortfolio(ctx context.Context, s string) ([]int64, error) {
+	var m []int64
+	seen := map[int64]bool{}
+	sa := strings.Split(s, ",")
+	for i := 0; i < len(sa); i++ {
+		x := strings.TrimSpace(sa[i])
+		if len(x) == 0 {
+			continue
+		}
+		bid, ok := StringToInt64(x)
+		if !ok {
+			b, err := GetBusinessByDesignation(ctx, x)
+			if err != nil {
+				return m, err
+			}
+			bid = b.BID
+		}
+		if bid == 0 {
+			return m, fmt.Errorf("business %s not found", x)
+		}
+		if !seen[bid] {
+			seen[bid] = true
+			m = append(m, bid)
+		}
+	}
+	return m, nil
+}
+
+// GetConsolidatedAccounts returns the consolidated account of each GL
+// account of business bid.  The accounts without an AcctMap are
+// consolidated under their own GLNumber and Name.  The business internals
+// must be initialized.
+//
+// INPUTS
+//    ctx = context
+//    bid = business id
+//
+// RETURNS
+//    the consolidated accounts, by LID
+//    any error encountered
+//-----------------------------------------------------------------------------
+func GetConsolidatedAccounts(ctx context.Context, bid int64) (map[int64]AcctMap, error) {
+	var m = map[int64]AcctMap{}
+	for lid, gl := range RRdb.BizTypes[bid].GLAccounts {
+		m[lid] = AcctMap{BID: bid, LID: lid, GLNumber: gl.GLNumber, Name: gl.Name}
+	}
+	t, err := GetAcctMaps(ctx, bid)
+	if err != nil {
+		return m, err
+	}
+	for i := 0; i < len(t); i++ {
+		if _, ok := m[t[i].LID]; ok {
+			m[t[i].LID] = t[i]
+		}
+	}
+	return m, nil
+}
@@ -177,6 +177,19 @@ WHERE RAID=? AND RID=? AND Start >= ? AND Stop < ? AND RentCycle=0 ORDER By Asse
 	//--------------------------------------------------------------------------
 	RRdb.Prepstmt.GetUnpaidAssessmentsByRAID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Assessments WHERE RAID=? AND (FLAGS & 3)<2 AND (FLAGS & 4)=0 AND (PASMID!=0 OR RentCycle=0) ORDER BY Start ASC")
 	Errcheck(err)
+
+	//--------------------------------------------------------------------------
+	// Assessments created on behalf of another element (for example, a late
+	// fee assessed against an unpaid assessment) carry the element type and
+	// id in AssocElemType / AssocElemID. Reversed entries are included so
+	// that a waived charge is not assessed again.
+	//--------------------------------------------------------------------------
+	RRdb.Prepstmt.GetAssessmentByAssocElem, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Assessments WHERE BID=? AND AssocElemType=? AND AssocElemID=? ORDER BY ASMID ASC LIMIT 1")
+	Errcheck(err)
+	RRdb.Prepstmt.GetAssessmentsByAssocElem, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Assessments WHERE BID=? AND AssocElemType=? AND AssocElemID=? ORDER BY Start ASC, ASMID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetAssessmentChargesByRAID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Assessments WHERE RAID=? AND (RentCycle=0 OR PASMID!=0) AND ?<=Start AND Start<? AND FLAGS&4=0 ORDER BY Start ASC, ASMID ASC")
+	Errcheck(err)
 	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
 	RRdb.Prepstmt.InsertAssessment, err = RRdb.Dbrr.Prepare("INSERT INTO Assessments (" + s1 + ") VALUES(" + s2 + ")")
 	Errcheck(err)
@@ -235,6 +248,26 @@ WHERE RAID=? AND RID=? AND Start >= ? AND Stop < ? AND RentCycle=0 ORDER By Asse
 	RRdb.Prepstmt.DeleteBusinessProperties, err = RRdb.Dbrr.Prepare("DELETE from BusinessProperties WHERE BPID=?")
 	Errcheck(err)
 
+	//==========================================
+	// Budget
+	//==========================================
+	flds = "BGTID,BID,LID,Dt,Amount,Comment,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["Budget"] = flds
+	RRdb.Prepstmt.GetBudget, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Budget WHERE BGTID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetBudgetForAccount, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Budget WHERE BID=? AND LID=? AND Dt=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetBudgetsInRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Budget WHERE BID=? AND ?<=Dt AND Dt<? ORDER BY LID ASC, Dt ASC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertBudget, err = RRdb.Dbrr.Prepare("INSERT INTO Budget (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateBudget, err = RRdb.Dbrr.Prepare("UPDATE Budget SET " + s3 + " WHERE BGTID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteBudget, err = RRdb.Dbrr.Prepare("DELETE FROM Budget WHERE BGTID=?")
+	Errcheck(err)
+
 	//==========================================
 	// Close Period
 	//==========================================
@@ -244,6 +277,8 @@ WHERE RAID=? AND RID=? AND Start >= ? AND Stop < ? AND RentCycle=0 ORDER By Asse
 	Errcheck(err)
 	RRdb.Prepstmt.GetLastClosePeriod, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM ClosePeriod WHERE BID=? ORDER BY Dt DESC LIMIT 1")
 	Errcheck(err)
+	RRdb.Prepstmt.GetClosePeriodBefore, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM ClosePeriod WHERE BID=? AND Dt<? ORDER BY Dt DESC LIMIT 1")
+	Errcheck(err)
 
 	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
 	RRdb.Prepstmt.InsertClosePeriod, err = RRdb.Dbrr.Prepare("INSERT INTO ClosePeriod (" + s1 + ") VALUES(" + s2 + ")")
@@ -253,6 +288,26 @@ WHERE RAID=? AND RID=? AND Start >= ? AND Stop < ? AND RentCycle=0 ORDER By Asse
 	RRdb.Prepstmt.DeleteClosePeriod, err = RRdb.Dbrr.Prepare("DELETE FROM ClosePeriod WHERE CPID=?")
 	Errcheck(err)
 
+	//==========================================
+	// FISCAL YEAR CLOSE
+	//==========================================
+	flds = "FYCID,BID,DtStart,DtStop,Dt,LID,JID,NetIncome,FLAGS,RevJID,DtReopened,ReopenedBy,Reason,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["FiscalYearClose"] = flds
+	RRdb.Prepstmt.GetFiscalYearClose, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM FiscalYearClose WHERE FYCID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetLastFiscalYearClose, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM FiscalYearClose WHERE BID=? AND (FLAGS & 1)=0 ORDER BY DtStop DESC LIMIT 1")
+	Errcheck(err)
+	RRdb.Prepstmt.GetFiscalYearCloses, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM FiscalYearClose WHERE BID=? ORDER BY DtStart ASC, FYCID ASC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertFiscalYearClose, err = RRdb.Dbrr.Prepare("INSERT INTO FiscalYearClose (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateFiscalYearClose, err = RRdb.Dbrr.Prepare("UPDATE FiscalYearClose SET " + s3 + " WHERE FYCID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteFiscalYearClose, err = RRdb.Dbrr.Prepare("DELETE FROM FiscalYearClose WHERE FYCID=?")
+	Errcheck(err)
+
 	//==========================================
 	// Custom Attribute
 	//==========================================
@@ -304,6 +359,8 @@ WHERE RAID=? AND RID=? AND Start >= ? AND Stop < ? AND RentCycle=0 ORDER By Asse
 	Errcheck(err)
 	RRdb.Prepstmt.GetAllDepositsInRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Deposit WHERE BID=? AND ?<=Dt AND Dt<?")
 	Errcheck(err)
+	RRdb.Prepstmt.GetDepositsByDEPIDInRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Deposit WHERE BID=? AND DEPID=? AND ?<=Dt AND Dt<? ORDER BY Dt ASC, DID ASC")
+	Errcheck(err)
 
 	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
 	RRdb.Prepstmt.InsertDeposit, err = RRdb.Dbrr.Prepare("INSERT INTO Deposit (" + s1 + ") VALUES(" + s2 + ")")
@@ -313,6 +370,326 @@ WHERE RAID=? AND RID=? AND Start >= ? AND Stop < ? AND RentCycle=0 ORDER By Asse
 	RRdb.Prepstmt.UpdateDeposit, err = RRdb.Dbrr.Prepare("UPDATE Deposit SET " + s3 + " WHERE DID=?")
 	Errcheck(err)
 
+	//==========================================
+	// BANK STATEMENT
+	//==========================================
+	flds = "BSID,BID,DEPID,FileName,DtStart,DtStop,ClosingBalance,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["BankStatement"] = flds
+	RRdb.Prepstmt.GetBankStatement, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM BankStatement WHERE BSID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetBankStatementsByDEPID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM BankStatement WHERE BID=? AND DEPID=? ORDER BY DtStop DESC, BSID DESC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertBankStatement, err = RRdb.Dbrr.Prepare("INSERT INTO BankStatement (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateBankStatement, err = RRdb.Dbrr.Prepare("UPDATE BankStatement SET " + s3 + " WHERE BSID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteBankStatement, err = RRdb.Dbrr.Prepare("DELETE FROM BankStatement WHERE BSID=?")
+	Errcheck(err)
+
+	//==========================================
+	// BANK STATEMENT LINE
+	//==========================================
+	flds = "BSLID,BSID,BID,DEPID,Dt,Amount,FITID,Description,DID,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["BankStatementLine"] = flds
+	RRdb.Prepstmt.GetBankStatementLine, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM BankStatementLine WHERE BSLID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetBankStatementLines, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM BankStatementLine WHERE BSID=? ORDER BY Dt ASC, BSLID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetBankStatementLineByFITID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM BankStatementLine WHERE BID=? AND DEPID=? AND FITID=? LIMIT 1")
+	Errcheck(err)
+	RRdb.Prepstmt.GetBankStatementLinesInRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM BankStatementLine WHERE BID=? AND DEPID=? AND ?<=Dt AND Dt<? ORDER BY Dt ASC, BSLID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetBankStatementLineByDID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM BankStatementLine WHERE DID=? LIMIT 1")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertBankStatementLine, err = RRdb.Dbrr.Prepare("INSERT INTO BankStatementLine (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateBankStatementLine, err = RRdb.Dbrr.Prepare("UPDATE BankStatementLine SET " + s3 + " WHERE BSLID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteBankStatementLine, err = RRdb.Dbrr.Prepare("DELETE FROM BankStatementLine WHERE BSLID=?")
+	Errcheck(err)
+
+	//==========================================
+	// ACH ENROLLMENT
+	//==========================================
+	flds = "AEID,BID,TCID,AccountName,RoutingNumber,AccountNumber,MaxAmount,DtStart,DtStop,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["ACHEnrollment"] = flds
+	RRdb.Prepstmt.GetACHEnrollment, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM ACHEnrollment WHERE AEID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetACHEnrollmentsByTCID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM ACHEnrollment WHERE BID=? AND TCID=? ORDER BY DtStart ASC, AEID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetActiveACHEnrollments, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM ACHEnrollment WHERE BID=? AND DtStart<=? AND ?<DtStop AND 0=(FLAGS & 2) ORDER BY TCID ASC, AEID ASC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertACHEnrollment, err = RRdb.Dbrr.Prepare("INSERT INTO ACHEnrollment (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateACHEnrollment, err = RRdb.Dbrr.Prepare("UPDATE ACHEnrollment SET " + s3 + " WHERE AEID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteACHEnrollment, err = RRdb.Dbrr.Prepare("DELETE FROM ACHEnrollment WHERE AEID=?")
+	Errcheck(err)
+
+	//==========================================
+	// ACH BATCH
+	//==========================================
+	flds = "ABID,BID,Dt,EntryCount,TotalAmount,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["ACHBatch"] = flds
+	RRdb.Prepstmt.GetACHBatch, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM ACHBatch WHERE ABID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetACHBatches, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM ACHBatch WHERE BID=? AND ?<=Dt AND Dt<? ORDER BY Dt DESC, ABID DESC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertACHBatch, err = RRdb.Dbrr.Prepare("INSERT INTO ACHBatch (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateACHBatch, err = RRdb.Dbrr.Prepare("UPDATE ACHBatch SET " + s3 + " WHERE ABID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteACHBatch, err = RRdb.Dbrr.Prepare("DELETE FROM ACHBatch WHERE ABID=?")
+	Errcheck(err)
+
+	//==========================================
+	// ACH ENTRY
+	//==========================================
+	flds = "AENID,ABID,BID,AEID,TCID,RCPTID,Amount,TraceNumber,ReturnCode,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["ACHEntry"] = flds
+	RRdb.Prepstmt.GetACHEntry, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM ACHEntry WHERE AENID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetACHEntries, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM ACHEntry WHERE ABID=? ORDER BY AENID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetACHEntryByTraceNumber, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM ACHEntry WHERE BID=? AND TraceNumber=? ORDER BY AENID DESC LIMIT 1")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertACHEntry, err = RRdb.Dbrr.Prepare("INSERT INTO ACHEntry (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateACHEntry, err = RRdb.Dbrr.Prepare("UPDATE ACHEntry SET " + s3 + " WHERE AENID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteACHEntry, err = RRdb.Dbrr.Prepare("DELETE FROM ACHEntry WHERE AENID=?")
+	Errcheck(err)
+
+	//==========================================
+	// SECURITY DEPOSIT SETTLEMENT
+	//==========================================
+	flds = "SDSID,BID,RAID,TCID,Dt,DepositHeld,Charges,Damages,DepositApplied,Refund,BalanceDue,RCPTID,DMGEXPID,REFEXPID,BDASMID,ForwardAddress,FLAGS,Comment,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["SecDepSettlement"] = flds
+	RRdb.Prepstmt.GetSecDepSettlement, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM SecDepSettlement WHERE SDSID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetSecDepSettlementsByRAID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM SecDepSettlement WHERE RAID=? ORDER BY Dt DESC, SDSID DESC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertSecDepSettlement, err = RRdb.Dbrr.Prepare("INSERT INTO SecDepSettlement (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateSecDepSettlement, err = RRdb.Dbrr.Prepare("UPDATE SecDepSettlement SET " + s3 + " WHERE SDSID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteSecDepSettlement, err = RRdb.Dbrr.Prepare("DELETE FROM SecDepSettlement WHERE SDSID=?")
+	Errcheck(err)
+
+	//==========================================
+	// SECURITY DEPOSIT ITEM
+	//==========================================
+	flds = "SDIID,SDSID,BID,ItemType,ASMID,RID,Description,Amount,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["SecDepItem"] = flds
+	RRdb.Prepstmt.GetSecDepItem, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM SecDepItem WHERE SDIID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetSecDepItems, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM SecDepItem WHERE SDSID=? ORDER BY ItemType DESC, SDIID ASC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertSecDepItem, err = RRdb.Dbrr.Prepare("INSERT INTO SecDepItem (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateSecDepItem, err = RRdb.Dbrr.Prepare("UPDATE SecDepItem SET " + s3 + " WHERE SDIID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteSecDepItem, err = RRdb.Dbrr.Prepare("DELETE FROM SecDepItem WHERE SDIID=?")
+	Errcheck(err)
+
+	//==========================================
+	// CAM RECONCILIATION
+	//==========================================
+	flds = "CAMRID,BID,RAID,RID,TCID,Dt1,Dt2,LeaseType,ExpenseAdjustmentType,SqFt,TotalSqFt,Occupancy,Expenses,BaseExpenses,ExpensesStop,Share,Estimated,TrueUp,ASMID,RCPTID,FLAGS,Comment,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["CAMReconciliation"] = flds
+	RRdb.Prepstmt.GetCAMReconciliation, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM CAMReconciliation WHERE CAMRID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetCAMReconciliationsByRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM CAMReconciliation WHERE BID=? AND ?<=Dt2 AND Dt2<? ORDER BY RAID ASC, Dt2 ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetCAMReconciliationsByRAID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM CAMReconciliation WHERE RAID=? ORDER BY Dt2 DESC, CAMRID DESC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertCAMReconciliation, err = RRdb.Dbrr.Prepare("INSERT INTO CAMReconciliation (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateCAMReconciliation, err = RRdb.Dbrr.Prepare("UPDATE CAMReconciliation SET " + s3 + " WHERE CAMRID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteCAMReconciliation, err = RRdb.Dbrr.Prepare("DELETE FROM CAMReconciliation WHERE CAMRID=?")
+	Errcheck(err)
+
+	//==========================================
+	// CAM RECONCILIATION LINE
+	//==========================================
+	flds = "CAMRLID,CAMRID,BID,LID,Expenses,BaseExpenses,Share,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["CAMReconLine"] = flds
+	RRdb.Prepstmt.GetCAMReconLine, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM CAMReconLine WHERE CAMRLID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetCAMReconLines, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM CAMReconLine WHERE CAMRID=? ORDER BY LID ASC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertCAMReconLine, err = RRdb.Dbrr.Prepare("INSERT INTO CAMReconLine (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateCAMReconLine, err = RRdb.Dbrr.Prepare("UPDATE CAMReconLine SET " + s3 + " WHERE CAMRLID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteCAMReconLine, err = RRdb.Dbrr.Prepare("DELETE FROM CAMReconLine WHERE CAMRLID=?")
+	Errcheck(err)
+
+	//==========================================
+	// TENANT LOGIN
+	//==========================================
+	flds = "TLID,BID,TCID,Username,PasswordHash,FailedLogins,LastLogin,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["TenantLogin"] = flds
+	RRdb.Prepstmt.GetTenantLogin, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM TenantLogin WHERE TLID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetTenantLoginByUsername, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM TenantLogin WHERE BID=? AND Username=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetTenantLoginByTCID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM TenantLogin WHERE BID=? AND TCID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetTenantLogins, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM TenantLogin WHERE BID=? ORDER BY Username ASC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertTenantLogin, err = RRdb.Dbrr.Prepare("INSERT INTO TenantLogin (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateTenantLogin, err = RRdb.Dbrr.Prepare("UPDATE TenantLogin SET " + s3 + " WHERE TLID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteTenantLogin, err = RRdb.Dbrr.Prepare("DELETE FROM TenantLogin WHERE TLID=?")
+	Errcheck(err)
+
+	//==========================================
+	// MAINTENANCE REQUEST
+	//==========================================
+	flds = "MRID,BID,RAID,RID,TCID,Dt,Category,Description,ContactPhone,Status,Response,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["MaintenanceRequest"] = flds
+	RRdb.Prepstmt.GetMaintenanceRequest, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM MaintenanceRequest WHERE MRID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetMaintenanceRequests, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM MaintenanceRequest WHERE BID=? AND ?<=Dt AND Dt<? ORDER BY Dt ASC, MRID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetMaintenanceRequestsByRAID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM MaintenanceRequest WHERE RAID=? ORDER BY Dt DESC, MRID DESC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertMaintenanceRequest, err = RRdb.Dbrr.Prepare("INSERT INTO MaintenanceRequest (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateMaintenanceRequest, err = RRdb.Dbrr.Prepare("UPDATE MaintenanceRequest SET " + s3 + " WHERE MRID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteMaintenanceRequest, err = RRdb.Dbrr.Prepare("DELETE FROM MaintenanceRequest WHERE MRID=?")
+	Errcheck(err)
+
+	//==========================================
+	// WORK ORDER
+	//==========================================
+	flds = "WOID,BID,RID,RAID,MRID,Category,Priority,Status,Vendor,Description,Dt,ScheduledStart,ScheduledStop,DtCompleted,LaborCost,LaborARID,LaborEXPID,MaterialCost,MaterialARID,MaterialEXPID,ChargebackAmount,ChargebackARID,ChargebackASMID,FLAGS,Comment,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["WorkOrder"] = flds
+	RRdb.Prepstmt.GetWorkOrder, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM WorkOrder WHERE WOID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetOpenWorkOrders, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM WorkOrder WHERE BID=? AND Dt<? AND Status<4 ORDER BY Dt ASC, WOID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetWorkOrdersByRID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM WorkOrder WHERE RID=? ORDER BY Dt DESC, WOID DESC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetWorkOrdersByMRID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM WorkOrder WHERE MRID=? ORDER BY WOID ASC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertWorkOrder, err = RRdb.Dbrr.Prepare("INSERT INTO WorkOrder (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateWorkOrder, err = RRdb.Dbrr.Prepare("UPDATE WorkOrder SET " + s3 + " WHERE WOID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteWorkOrder, err = RRdb.Dbrr.Prepare("DELETE FROM WorkOrder WHERE WOID=?")
+	Errcheck(err)
+
+	//==========================================
+	// VENDOR
+	//==========================================
+	flds = "VID,BID,Name,ContactName,Email,Phone,Address,Address2,City,State,PostalCode,Country,TaxID,Terms,FLAGS,Comment,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["Vendor"] = flds
+	RRdb.Prepstmt.GetVendor, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Vendor WHERE VID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetVendorsByBusiness, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Vendor WHERE BID=? ORDER BY Name ASC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertVendor, err = RRdb.Dbrr.Prepare("INSERT INTO Vendor (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateVendor, err = RRdb.Dbrr.Prepare("UPDATE Vendor SET " + s3 + " WHERE VID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteVendor, err = RRdb.Dbrr.Prepare("DELETE FROM Vendor WHERE VID=?")
+	Errcheck(err)
+
+	//==========================================
+	// VENDOR BILL
+	//==========================================
+	flds = "VBID,BID,VID,InvoiceNo,Dt,DueDt,Amount,Status,ApprovedBy,DtApproved,JID,VPRID,DtPaid,DocNo,PmtJID,FLAGS,Comment,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["VendorBill"] = flds
+	RRdb.Prepstmt.GetVendorBill, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM VendorBill WHERE VBID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetVendorBillsByStatus, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM VendorBill WHERE BID=? AND Status=? ORDER BY DueDt ASC, VBID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetVendorBillsByRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM VendorBill WHERE BID=? AND Dt>=? AND Dt<? ORDER BY Dt ASC, VBID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetUnpaidVendorBills, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM VendorBill WHERE BID=? AND Dt<? AND (Status=1 OR (Status=2 AND DtPaid>=?)) ORDER BY DueDt ASC, VBID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetPaidVendorBills, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM VendorBill WHERE BID=? AND Status=2 AND DtPaid>=? AND DtPaid<? ORDER BY VID ASC, DtPaid ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetVendorBillsByVID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM VendorBill WHERE VID=? ORDER BY Dt DESC, VBID DESC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetVendorBillsByVPRID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM VendorBill WHERE VPRID=? ORDER BY VBID ASC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertVendorBill, err = RRdb.Dbrr.Prepare("INSERT INTO VendorBill (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateVendorBill, err = RRdb.Dbrr.Prepare("UPDATE VendorBill SET " + s3 + " WHERE VBID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteVendorBill, err = RRdb.Dbrr.Prepare("DELETE FROM VendorBill WHERE VBID=?")
+	Errcheck(err)
+
+	//==========================================
+	// VENDOR BILL LINE
+	//==========================================
+	flds = "VBLID,VBID,BID,LID,RID,Amount,Description,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["VendorBillLine"] = flds
+	RRdb.Prepstmt.GetVendorBillLine, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM VendorBillLine WHERE VBLID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetVendorBillLines, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM VendorBillLine WHERE VBID=? ORDER BY VBLID ASC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertVendorBillLine, err = RRdb.Dbrr.Prepare("INSERT INTO VendorBillLine (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateVendorBillLine, err = RRdb.Dbrr.Prepare("UPDATE VendorBillLine SET " + s3 + " WHERE VBLID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteVendorBillLine, err = RRdb.Dbrr.Prepare("DELETE FROM VendorBillLine WHERE VBLID=?")
+	Errcheck(err)
+
+	//==========================================
+	// VENDOR PAYMENT RUN
+	//==========================================
+	flds = "VPRID,BID,DEPID,Dt,FirstCheckNo,Amount,BillCount,Comment,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["VendorPaymentRun"] = flds
+	RRdb.Prepstmt.GetVendorPaymentRun, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM VendorPaymentRun WHERE VPRID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetVendorPaymentRuns, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM VendorPaymentRun WHERE BID=? AND Dt>=? AND Dt<? ORDER BY Dt ASC, VPRID ASC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertVendorPaymentRun, err = RRdb.Dbrr.Prepare("INSERT INTO VendorPaymentRun (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateVendorPaymentRun, err = RRdb.Dbrr.Prepare("UPDATE VendorPaymentRun SET " + s3 + " WHERE VPRID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteVendorPaymentRun, err = RRdb.Dbrr.Prepare("DELETE FROM VendorPaymentRun WHERE VPRID=?")
+	Errcheck(err)
+
 	//==========================================
 	// DEPOSIT METHOD
 	//==========================================
@@ -380,6 +757,8 @@ WHERE RAID=? AND RID=? AND Start >= ? AND Stop < ? AND RentCycle=0 ORDER By Asse
 
 	RRdb.Prepstmt.GetExpense, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Expense WHERE EXPID=?")
 	Errcheck(err)
+	RRdb.Prepstmt.GetExpensesByRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Expense WHERE BID=? AND ?<=Dt AND Dt<? AND (FLAGS & 4)=0 ORDER BY Dt ASC, EXPID ASC")
+	Errcheck(err)
 
 	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
 	RRdb.Prepstmt.InsertExpense, err = RRdb.Dbrr.Prepare("INSERT INTO Expense (" + s1 + ") VALUES(" + s2 + ")")
@@ -559,6 +938,26 @@ WHERE RAID=? AND RID=? AND Start >= ? AND Stop < ? AND RentCycle=0 ORDER By Asse
 	RRdb.Prepstmt.DeleteLedger, err = RRdb.Dbrr.Prepare("DELETE FROM GLAccount WHERE LID=?")
 	Errcheck(err)
 
+	//==========================================
+	// ACCOUNT MAP
+	//==========================================
+	flds = "AMID,BID,LID,GLNumber,Name,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["AcctMap"] = flds
+	RRdb.Prepstmt.GetAcctMap, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM AcctMap WHERE AMID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetAcctMapByLID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM AcctMap WHERE BID=? AND LID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetAcctMaps, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM AcctMap WHERE BID=? ORDER BY GLNumber ASC, LID ASC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertAcctMap, err = RRdb.Dbrr.Prepare("INSERT INTO AcctMap (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateAcctMap, err = RRdb.Dbrr.Prepare("UPDATE AcctMap SET " + s3 + " WHERE AMID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteAcctMap, err = RRdb.Dbrr.Prepare("DELETE FROM AcctMap WHERE AMID=?")
+	Errcheck(err)
+
 	//==========================================
 	// LEDGER ENTRY
 	//==========================================
@@ -616,6 +1015,8 @@ WHERE RAID=? AND RID=? AND Start >= ? AND Stop < ? AND RentCycle=0 ORDER By Asse
 	Errcheck(err)
 	RRdb.Prepstmt.GetLedgerMarkerOnOrBefore, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LedgerMarker WHERE BID=? AND RAID=0 AND RID=0 AND LID=? AND TCID=0 AND Dt<=? ORDER BY Dt DESC LIMIT 1")
 	Errcheck(err)
+	RRdb.Prepstmt.GetLedgerMarkersInRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LedgerMarker WHERE BID=? AND LID=? AND RAID=0 AND RID=0 AND TCID=0 AND ?<Dt AND Dt<=? ORDER BY Dt ASC, LMID ASC")
+	Errcheck(err)
 	RRdb.Prepstmt.GetRALedgerMarkerOnOrBefore, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LedgerMarker WHERE RAID=? AND Dt<=? ORDER BY Dt DESC LIMIT 1")
 	Errcheck(err)
 	RRdb.Prepstmt.GetRALedgerMarkerOnOrAfter, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LedgerMarker WHERE RAID=? AND Dt>=? ORDER BY Dt ASC LIMIT 1")
@@ -630,6 +1031,8 @@ WHERE RAID=? AND RID=? AND Start >= ? AND Stop < ? AND RentCycle=0 ORDER By Asse
 	Errcheck(err)
 	RRdb.Prepstmt.DeleteLedgerMarker, err = RRdb.Dbrr.Prepare("DELETE FROM LedgerMarker WHERE LMID=?")
 	Errcheck(err)
+	RRdb.Prepstmt.DeleteLedgerMarkersOnDate, err = RRdb.Dbrr.Prepare("DELETE FROM LedgerMarker WHERE BID=? AND RID=0 AND TCID=0 AND Dt=? AND (State=0 OR State=1)")
+	Errcheck(err)
 	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
 	RRdb.Prepstmt.InsertLedgerMarker, err = RRdb.Dbrr.Prepare("INSERT INTO LedgerMarker (" + s1 + ") VALUES(" + s2 + ")")
 	Errcheck(err)
@@ -840,6 +1243,8 @@ WHERE RAID=? AND RID=? AND Start >= ? AND Stop < ? AND RentCycle=0 ORDER By Asse
 	Errcheck(err)
 	RRdb.Prepstmt.GetReceiptsInDateRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Receipt WHERE BID=? AND Dt >= ? AND Dt < ? ORDER BY Dt ASC, Amount ASC")
 	Errcheck(err)
+	RRdb.Prepstmt.GetReceiptsByRAIDRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Receipt WHERE BID=? AND (RAID=? OR RCPTID IN (SELECT RCPTID FROM ReceiptAllocation WHERE RAID=?)) AND ?<=Dt AND Dt<? ORDER BY Dt ASC, RCPTID ASC")
+	Errcheck(err)
 
 	//  FLAGS bits 0-1:  0 unallocated, 1 = partially allocated, 2 = fully allocated,  bit 2: voided entry
 	RRdb.Prepstmt.GetUnallocatedReceipts, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Receipt WHERE BID=? AND (FLAGS & 7)<2 ORDER BY Dt ASC, Amount ASC")
@@ -927,6 +1332,12 @@ WHERE RAID=? AND RID=? AND Start >= ? AND Stop < ? AND RentCycle=0 ORDER By Asse
 	Errcheck(err)
 	RRdb.Prepstmt.GetRentalAgreementChain, err = RRdb.Dbrr.Prepare("SELECT " + flds + " from RentalAgreement WHERE ORIGIN=? OR RAID=? ORDER BY AgreementStart ASC")
 	Errcheck(err)
+	RRdb.Prepstmt.GetRentalAgreementsByNextRateChange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM RentalAgreement WHERE BID=? AND RateChange<>0 AND ?<=NextRateChange AND NextRateChange<? AND NextRateChange<AgreementStop AND (FLAGS & 64)=0 AND (FLAGS & 15) IN (4,5) ORDER BY NextRateChange ASC, RAID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetRentalAgreementsByAgreementStop, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM RentalAgreement WHERE BID=? AND ?<=AgreementStop AND AgreementStop<? AND (FLAGS & 64)=0 AND (FLAGS & 15) IN (4,5) ORDER BY AgreementStop ASC, RAID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetMonthToMonthRentalAgreements, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM RentalAgreement WHERE BID=? AND AgreementStop<? AND Renewal=1 AND (FLAGS & 64)=0 AND (FLAGS & 15)=4 ORDER BY AgreementStop ASC, RAID ASC")
+	Errcheck(err)
 
 	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
 	RRdb.Prepstmt.InsertRentalAgreement, err = RRdb.Dbrr.Prepare("INSERT INTO RentalAgreement (" + s1 + ") VALUES(" + s2 + ")")
@@ -1062,6 +1473,204 @@ WHERE RAID=? AND RID=? AND Start >= ? AND Stop < ? AND RentCycle=0 ORDER By Asse
 	RRdb.Prepstmt.InsertRentalAgreementTemplate, err = RRdb.Dbrr.Prepare("INSERT INTO RentalAgreementTemplate (" + s1 + ") VALUES(" + s2 + ")")
 	Errcheck(err)
 
+	//==========================================
+	// RENEWAL OFFER
+	//==========================================
+	flds = "ROID,BID,RAID,RID,ASMID,ARID,CurrentRent,MarketRent,OfferRent,TermStart,TermStop,OfferDt,ExpireDt,Status,NewRAID,Comment,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["RenewalOffer"] = flds
+	RRdb.Prepstmt.GetRenewalOffer, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM RenewalOffer WHERE ROID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetRenewalOffersByRAID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM RenewalOffer WHERE RAID=? ORDER BY TermStart ASC, RID ASC, ROID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetRenewalOffersInRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM RenewalOffer WHERE BID=? AND ?<=TermStart AND TermStart<? ORDER BY TermStart ASC, RAID ASC, RID ASC, ROID ASC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertRenewalOffer, err = RRdb.Dbrr.Prepare("INSERT INTO RenewalOffer (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateRenewalOffer, err = RRdb.Dbrr.Prepare("UPDATE RenewalOffer SET " + s3 + " WHERE ROID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteRenewalOffer, err = RRdb.Dbrr.Prepare("DELETE FROM RenewalOffer WHERE ROID=?")
+	Errcheck(err)
+
+	//==========================================
+	// RATE RECOMMENDATION
+	//==========================================
+	flds = "RCMID,BID,RTID,DtStart,DtStop,CurrentRate,RecommendedRate,AppliedRate,Rentables,Occupied,MoveOuts,AvgDaysVacant,SeasonalPct,Status,Comment,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["RateRecommendation"] = flds
+	RRdb.Prepstmt.GetRateRecommendation, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM RateRecommendation WHERE RCMID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetRateRecommendationsByRTID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM RateRecommendation WHERE RTID=? ORDER BY DtStart ASC, RCMID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetRateRecommendationsInRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM RateRecommendation WHERE BID=? AND ?<=DtStart AND DtStart<? ORDER BY DtStart ASC, RTID ASC, RCMID ASC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertRateRecommendation, err = RRdb.Dbrr.Prepare("INSERT INTO RateRecommendation (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateRateRecommendation, err = RRdb.Dbrr.Prepare("UPDATE RateRecommendation SET " + s3 + " WHERE RCMID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteRateRecommendation, err = RRdb.Dbrr.Prepare("DELETE FROM RateRecommendation WHERE RCMID=?")
+	Errcheck(err)
+
+	//==========================================
+	// OTA RESERVATION
+	//==========================================
+	flds = "ORID,BID,RequestorID,ResID,RID,RTID,DtStart,DtStop,ConfirmationCode,Status,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["OTAReservation"] = flds
+	RRdb.Prepstmt.GetOTAReservation, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM OTAReservation WHERE ORID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetOTAReservationByResID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM OTAReservation WHERE BID=? AND RequestorID=? AND ResID=?")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertOTAReservation, err = RRdb.Dbrr.Prepare("INSERT INTO OTAReservation (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateOTAReservation, err = RRdb.Dbrr.Prepare("UPDATE OTAReservation SET " + s3 + " WHERE ORID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteOTAReservation, err = RRdb.Dbrr.Prepare("DELETE FROM OTAReservation WHERE ORID=?")
+	Errcheck(err)
+
+	//==========================================
+	// FOLIO
+	//==========================================
+	flds = "FOLID,BID,RAID,RID,RTID,TCID,DtArrive,DtDepart,Adults,Children,Rate,PostedThrough,Status,Comment,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["Folio"] = flds
+	RRdb.Prepstmt.GetFolio, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Folio WHERE FOLID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetFoliosByRAID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Folio WHERE RAID=? ORDER BY DtArrive ASC, FOLID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetFoliosInRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Folio WHERE BID=? AND ?<DtDepart AND DtArrive<? ORDER BY DtArrive ASC, FOLID ASC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertFolio, err = RRdb.Dbrr.Prepare("INSERT INTO Folio (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateFolio, err = RRdb.Dbrr.Prepare("UPDATE Folio SET " + s3 + " WHERE FOLID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteFolio, err = RRdb.Dbrr.Prepare("DELETE FROM Folio WHERE FOLID=?")
+	Errcheck(err)
+
+	//==========================================
+	// NIGHT AUDIT
+	//==========================================
+	flds = "NAID,BID,Dt,Rooms,Occupied,Arrivals,Departures,RoomRevenue,TaxRevenue,Comment,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["NightAudit"] = flds
+	RRdb.Prepstmt.GetNightAudit, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM NightAudit WHERE NAID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetLastNightAudit, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM NightAudit WHERE BID=? ORDER BY Dt DESC LIMIT 1")
+	Errcheck(err)
+	RRdb.Prepstmt.GetNightAuditsInRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM NightAudit WHERE BID=? AND ?<=Dt AND Dt<? ORDER BY Dt ASC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertNightAudit, err = RRdb.Dbrr.Prepare("INSERT INTO NightAudit (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateNightAudit, err = RRdb.Dbrr.Prepare("UPDATE NightAudit SET " + s3 + " WHERE NAID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteNightAudit, err = RRdb.Dbrr.Prepare("DELETE FROM NightAudit WHERE NAID=?")
+	Errcheck(err)
+
+	//==========================================
+	// TAX
+	//==========================================
+	flds = "TAXID,BID,Name,TaxingAuthority,TaxingAuthorityAddress,FilingDate,FilingCycle,Instructions,ARID,ExemptDays,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["Tax"] = flds
+	RRdb.Prepstmt.GetTax, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Tax WHERE TAXID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetTaxesByBusiness, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Tax WHERE BID=? ORDER BY TaxingAuthority ASC, Name ASC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertTax, err = RRdb.Dbrr.Prepare("INSERT INTO Tax (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateTax, err = RRdb.Dbrr.Prepare("UPDATE Tax SET " + s3 + " WHERE TAXID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteTax, err = RRdb.Dbrr.Prepare("DELETE FROM Tax WHERE TAXID=?")
+	Errcheck(err)
+
+	//==========================================
+	// TAX RATE
+	//==========================================
+	flds = "TRID,TAXID,BID,DtStart,DtStop,Rate,Fee,Formula,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["TaxRate"] = flds
+	RRdb.Prepstmt.GetTaxRate, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM TaxRate WHERE TRID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetTaxRates, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM TaxRate WHERE TAXID=? ORDER BY DtStart ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetTaxRatesInRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM TaxRate WHERE TAXID=? AND ?<DtStop AND DtStart<? ORDER BY DtStart ASC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertTaxRate, err = RRdb.Dbrr.Prepare("INSERT INTO TaxRate (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateTaxRate, err = RRdb.Dbrr.Prepare("UPDATE TaxRate SET " + s3 + " WHERE TRID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteTaxRate, err = RRdb.Dbrr.Prepare("DELETE FROM TaxRate WHERE TRID=?")
+	Errcheck(err)
+
+	//==========================================
+	// RENTABLE TYPE TAX
+	//==========================================
+	flds = "RTTAXID,RTID,BID,TAXID,DtStart,DtStop,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["RentableTypeTax"] = flds
+	RRdb.Prepstmt.GetRentableTypeTax, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM RentableTypeTax WHERE RTTAXID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetRentableTypeTaxesByBusiness, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM RentableTypeTax WHERE BID=? ORDER BY RTID ASC, DtStart ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetRentableTypeTaxesInRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM RentableTypeTax WHERE RTID=? AND ?<DtStop AND DtStart<? ORDER BY TAXID ASC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertRentableTypeTax, err = RRdb.Dbrr.Prepare("INSERT INTO RentableTypeTax (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateRentableTypeTax, err = RRdb.Dbrr.Prepare("UPDATE RentableTypeTax SET " + s3 + " WHERE RTTAXID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteRentableTypeTax, err = RRdb.Dbrr.Prepare("DELETE FROM RentableTypeTax WHERE RTTAXID=?")
+	Errcheck(err)
+
+	//==========================================
+	// RENTAL AGREEMENT TAX
+	//==========================================
+	flds = "RATAXID,RAID,BID,DtStart,DtStop,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["RentalAgreementTax"] = flds
+	RRdb.Prepstmt.GetRentalAgreementTax, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM RentalAgreementTax WHERE RATAXID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetRentalAgreementTaxesInRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM RentalAgreementTax WHERE RAID=? AND ?<DtStop AND DtStart<? ORDER BY DtStart ASC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertRentalAgreementTax, err = RRdb.Dbrr.Prepare("INSERT INTO RentalAgreementTax (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateRentalAgreementTax, err = RRdb.Dbrr.Prepare("UPDATE RentalAgreementTax SET " + s3 + " WHERE RATAXID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteRentalAgreementTax, err = RRdb.Dbrr.Prepare("DELETE FROM RentalAgreementTax WHERE RATAXID=?")
+	Errcheck(err)
+
+	//==========================================
+	// ASSESSMENT TAX
+	//==========================================
+	flds = "ATID,ASMID,BID,RAID,TAXID,TaxASMID,Dt,Base,Amount,FLAGS,DtRefund,OverrideTaxApprover,OverrideAmount,Comment,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["AssessmentTax"] = flds
+	RRdb.Prepstmt.GetAssessmentTax, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM AssessmentTax WHERE ATID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetAssessmentTaxesByASMID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM AssessmentTax WHERE ASMID=? ORDER BY TAXID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetAssessmentTaxesByRAID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM AssessmentTax WHERE RAID=? AND TAXID=? ORDER BY Dt ASC, ATID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetAssessmentTaxesInRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM AssessmentTax WHERE BID=? AND ?<=Dt AND Dt<? ORDER BY TAXID ASC, Dt ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetAssessmentTaxesRefundedInRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM AssessmentTax WHERE BID=? AND (FLAGS & 8)<>0 AND ?<=DtRefund AND DtRefund<? ORDER BY TAXID ASC, DtRefund ASC")
+	Errcheck(err)
+
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertAssessmentTax, err = RRdb.Dbrr.Prepare("INSERT INTO AssessmentTax (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateAssessmentTax, err = RRdb.Dbrr.Prepare("UPDATE AssessmentTax SET " + s3 + " WHERE ATID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteAssessmentTax, err = RRdb.Dbrr.Prepare("DELETE FROM AssessmentTax WHERE ATID=?")
+	Errcheck(err)
+
 	//===============================
 	//  RentableTypeRef
 	//===============================
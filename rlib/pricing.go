@@ -0,0 +1,170 @@
+package rlib
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// RateRecommendation Status values
+const (
+	RateRecommendationOpen     = 0
+	RateRecommendationApproved = 1
+	RateRecommendationRejected = 2
+)
+
+// RateRecommendationStatus is the name of each RateRecommendation Status value
+var RateRecommendationStatus = []string{"open", "approved", "rejected"}
+
+// Defaults for the pricing settings that the business properties do not set
+const (
+	PricingTargetOccupancy  = 95  // percent of the rentables occupied
+	PricingOccupancyFactor  = 0.5 // rate change, percent, per point of occupancy
+	PricingTargetDaysVacant = 30  // days a rentable is expected to stay vacant
+	PricingVacancyFactor    = 0.1 // rate change, percent, per day of vacancy
+	PricingMaxChangePct     = 5   // largest change recommended at once
+	PricingLookAheadDays    = 60  // notices to move within this many days count
+	PricingHistoryMonths    = 12  // months of vacancy history used
+)
+
+// BizPropsPricing holds the market rate recommendation settings for a
+// business.  It is stored as part of the business properties
+// (BizProps.Pricing).  The recommended rate moves the current market rate of
+// a rentable type up when its projected occupancy is above TargetOccupancy
+// and its vacancies are shorter than TargetDaysVacant, and down otherwise.
+// The seasonal adjustment is added, and the total change is kept within
+// MaxChangePct.
+//
+//    TargetOccupancy  - percent of the rentables that should be occupied,
+//                       PricingTargetOccupancy if 0
+//    OccupancyFactor  - percent the rate changes for each point the
+//                       projected occupancy differs from TargetOccupancy,
+//                       PricingOccupancyFactor if 0
+//    TargetDaysVacant - expected length of a vacancy in days,
+//                       PricingTargetDaysVacant if 0
+//    VacancyFactor    - percent the rate changes for each day the average
+//                       vacancy differs from TargetDaysVacant,
+//                       PricingVacancyFactor if 0
+//    MaxChangePct     - the largest change recommended, 5 means 5%,
+//                       PricingMaxChangePct if 0
+//    LookAheadDays    - notices to move that take effect within this many
+//                       days reduce the projected occupancy,
+//                       PricingLookAheadDays if 0
+//    HistoryMonths    - months of vacancy history used,
+//                       PricingHistoryMonths if 0
+//    Seasonal         - demand of each month, January first, as a percent
+//                       above (or below, if negative) the average.  The
+//                       rate of a month changes by the difference between
+//                       its value and the value of the month before.
+//                       Missing months are 0
+//-----------------------------------------------------------------------------
+type BizPropsPricing struct {
+	TargetOccupancy  float64
+	OccupancyFactor  float64
+	TargetDaysVacant float64
+	VacancyFactor    float64
+	MaxChangePct     float64
+	LookAheadDays    int
+	HistoryMonths    int
+	Seasonal         []float64
+}
+
+// PricingFactors are the figures of a rentable type that a recommended
+// market rate is based on.
+type PricingFactors struct {
+	Rentables     int64   // rentables of the type
+	Occupied      int64   // how many of them are occupied
+	MoveOuts      int64   // occupied rentables with a notice to move in the look ahead period
+	Vacancies     int64   // vacancies of the history period
+	AvgDaysVacant float64 // their average length in days
+	SeasonalPct   float64 // seasonal adjustment, percent
+}
+
+// ProjectedOccupancy returns the percent of the rentables that will still
+// be occupied once the notices to move take effect, 0 if there are no
+// rentables.
+func (f *PricingFactors) ProjectedOccupancy() float64 {
+	if f.Rentables <= 0 {
+		return 0
+	}
+	return 100 * float64(f.Occupied-f.MoveOuts) / float64(f.Rentables)
+}
+
+// GetPricingPolicy returns the market rate recommendation settings
+// configured in the business properties named bizPropName for business BID.
+//
+// INPUTS
+//     ctx         = context
+//     BID         = business id
+//     bizPropName = name of the business properties, usually "general"
+//
+// RETURNS
+//     the pricing settings
+//     any error encountered
+//-----------------------------------------------------------------------------
+func GetPricingPolicy(ctx context.Context, BID int64, bizPropName string) (BizPropsPricing, error) {
+	bizPropJSON, err := GetDataFromBusinessPropertyName(ctx, bizPropName, BID)
+	if err != nil {
+		return BizPropsPricing{}, err
+	}
+	p := bizPropJSON.Pricing
+	if p.TargetOccupancy <= 0 {
+		p.TargetOccupancy = PricingTargetOccupancy
+	}
+	if p.OccupancyFactor <= 0 {
+		p.OccupancyFactor = PricingOccupancyFactor
+	}
+	if p.TargetDaysVacant <= 0 {
+		p.TargetDaysVacant = PricingTargetDaysVacant
+	}
+	if p.VacancyFactor <= 0 {
+		p.VacancyFactor = PricingVacancyFactor
+	}
+	if p.MaxChangePct <= 0 {
+		p.MaxChangePct = PricingMaxChangePct
+	}
+	if p.LookAheadDays <= 0 {
+		p.LookAheadDays = PricingLookAheadDays
+	}
+	if p.HistoryMonths <= 0 {
+		p.HistoryMonths = PricingHistoryMonths
+	}
+	return p, nil
+}
+
+// SeasonalPct returns the seasonal adjustment, in percent, of a rate that
+// starts on dt: the demand of its month less the demand of the month before.
+func (p *BizPropsPricing) SeasonalPct(dt *time.Time) float64 {
+	month := func(m int) float64 {
+		if m < len(p.Seasonal) {
+			return p.Seasonal[m]
+		}
+		return 0
+	}
+	m := int(dt.Month()) - 1
+	return month(m) - month((m+11)%12)
+}
+
+// RecommendedRate returns the market rate to recommend for a rentable type
+// whose market rate is current.  The occupancy adjustment applies only when
+// the type has rentables and the vacancy adjustment only when vacancies were
+// found in the history period.
+//
+// INPUTS
+//     current = the market rate in force
+//     f       = the figures of the rentable type
+//
+// RETURNS
+//     the recommended rate, rounded to the cent
+//-----------------------------------------------------------------------------
+func (p *BizPropsPricing) RecommendedRate(current float64, f *PricingFactors) float64 {
+	pct := f.SeasonalPct
+	if f.Rentables > 0 {
+		pct += p.OccupancyFactor * (f.ProjectedOccupancy() - p.TargetOccupancy)
+	}
+	if f.Vacancies > 0 {
+		pct += p.VacancyFactor * (p.TargetDaysVacant - f.AvgDaysVacant)
+	}
+	pct = math.Max(-p.MaxChangePct, math.Min(pct, p.MaxChangePct))
+	return RoundToCent(math.Max(current*(1+pct/100), 0))
+}
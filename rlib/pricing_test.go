@@ -0,0 +1,61 @@
+package rlib
+
+import (
+	"testing"
+	"time"
+)
+
+// TestRecommendedRate checks the occupancy, vacancy and seasonal
+// adjustments of a recommended market rate, and the cap on the change
+func TestRecommendedRate(t *testing.T) {
+	p := BizPropsPricing{
+		TargetOccupancy:  95,
+		OccupancyFactor:  0.5,
+		TargetDaysVacant: 30,
+		VacancyFactor:    0.1,
+		MaxChangePct:     5,
+	}
+	var cases = []struct {
+		current float64
+		f       PricingFactors
+		expect  float64
+	}{
+		{1000, PricingFactors{Rentables: 20, Occupied: 19}, 1000},                                                  // on target
+		{1000, PricingFactors{Rentables: 20, Occupied: 20}, 1025},                                                  // full
+		{1000, PricingFactors{Rentables: 20, Occupied: 19, MoveOuts: 1}, 975},                                      // a notice to move
+		{1000, PricingFactors{Rentables: 20, Occupied: 10}, 950},                                                   // capped decrease
+		{1000, PricingFactors{Vacancies: 2, AvgDaysVacant: 50}, 980},                                               // long vacancies
+		{1000, PricingFactors{Rentables: 20, Occupied: 19, SeasonalPct: 1.5}, 1015},                                // seasonal
+		{1000, PricingFactors{Rentables: 20, Occupied: 20, Vacancies: 3, AvgDaysVacant: 10, SeasonalPct: 1}, 1050}, // capped increase
+		{0, PricingFactors{Rentables: 20, Occupied: 20}, 0},                                                        // no market rate
+	}
+	for i := 0; i < len(cases); i++ {
+		if x := p.RecommendedRate(cases[i].current, &cases[i].f); x != cases[i].expect {
+			t.Errorf("case %d: expected %.2f, got %.2f\n", i, cases[i].expect, x)
+		}
+	}
+}
+
+// TestSeasonalPct checks the seasonal adjustment of each month, including
+// the wrap from December to January and months that are not configured
+func TestSeasonalPct(t *testing.T) {
+	var cases = []struct {
+		seasonal []float64
+		month    time.Month
+		expect   float64
+	}{
+		{[]float64{-2, -1, 0, 1, 2, 3, 3, 2, 1, 0, -1, -3}, time.January, 1},
+		{[]float64{-2, -1, 0, 1, 2, 3, 3, 2, 1, 0, -1, -3}, time.June, 1},
+		{[]float64{-2, -1, 0, 1, 2, 3, 3, 2, 1, 0, -1, -3}, time.August, -1},
+		{[]float64{1, 2}, time.March, -2},
+		{[]float64{1, 2}, time.January, 1},
+		{nil, time.July, 0},
+	}
+	for i := 0; i < len(cases); i++ {
+		p := BizPropsPricing{Seasonal: cases[i].seasonal}
+		dt := time.Date(2018, cases[i].month, 1, 0, 0, 0, 0, time.UTC)
+		if x := p.SeasonalPct(&dt); x != cases[i].expect {
+			t.Errorf("case %d: expected %.2f, got %.2f\n", i, cases[i].expect, x)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package rlib
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RateQuoteRequest describes the stay or rental to quote
+type RateQuoteRequest struct {
+	BID       int64     // business
+	RTID      int64     // the rentable type
+	RSPIDs    []int64   // the rentable specialties wanted
+	DtStart   time.Time // start of the stay
+	DtStop    time.Time // end of the stay
+	Ages      []int64   // age of each occupant
+	PromoCode string    // promo code entered by the customer, "" if none
+	RPID      int64     // quote this rate plan only, 0 for any rate plan of the business
+}
+
+// RateQuoteItem is one charge of a quoted period
+type RateQuoteItem struct {
+	Description string  // what is charged
+	RSPID       int64   // the specialty, 0 if the item is not a specialty
+	Amount      float64 // amount charged for the period
+}
+
+// RateQuotePeriod is the quote for one rent cycle (a night for rentable
+// types rented daily) of the stay
+type RateQuotePeriod struct {
+	DtStart    time.Time       // start of the period
+	DtStop     time.Time       // end of the period
+	RPID       int64           // rate plan applied, 0 if the market rate applies
+	RPRID      int64           // its RatePlanRef in effect on DtStart
+	MarketRate float64         // market rate of the rentable type
+	Items      []RateQuoteItem // the charges
+	Total      float64         // sum of the charges
+}
+
+// RateQuote is the itemized price of a stay
+type RateQuote struct {
+	RTID      int64             // the rentable type
+	RentCycle int64             // length of each period
+	Periods   []RateQuotePeriod // the periods of the stay
+	Total     float64           // sum of the periods
+}
+
+// ratePlanRefRates is a RatePlanRef with the rates it defines for one
+// rentable type
+type ratePlanRefRates struct {
+	Ref RatePlanRef
+	RT  RatePlanRefRTRate   // the rentable type rate, RPRID is 0 if there is none
+	SP  []RatePlanRefSPRate // the specialty rates of the rentable type
+}
+
+// GetRateQuote computes the price of the stay described by q.  The stay is
+// divided into periods of the RentCycle of the rentable type; the last
+// period is prorated if the stay ends before it does.  For each period the
+// RatePlanRefs in effect on its start date are priced and the best one is
+// used:
+//
+//    * a RatePlanRef with a PromoCode, or hidden from users, applies only
+//      when q.PromoCode matches its PromoCode.  When one applies it is
+//      preferred to the RatePlanRefs without a promo code.
+//    * the RatePlanRef must have a rate for the rentable type that is not
+//      marked not applicable.
+//    * of the RatePlanRefs left, the one with the lowest total wins.
+//
+// If no RatePlanRef applies, the period is quoted at the market rate.
+//
+// INPUTS
+//     ctx  = db context
+//     xbiz = the business, its internals initialized
+//     q    = what to quote
+//
+// RETURNS
+//     the quote
+//     any error encountered
+//-----------------------------------------------------------------------------
+func GetRateQuote(ctx context.Context, xbiz *XBusiness, q *RateQuoteRequest) (RateQuote, error) {
+	var quote = RateQuote{RTID: q.RTID}
+	rt, ok := xbiz.RT[q.RTID]
+	if !ok {
+		return quote, fmt.Errorf("rentable type %d not found", q.RTID)
+	}
+	if !q.DtStart.Before(q.DtStop) {
+		return quote, fmt.Errorf("the stay must end after it starts")
+	}
+	quote.RentCycle = rt.RentCycle
+	if quote.RentCycle < RECURDAILY {
+		quote.RentCycle = RECURDAILY
+	}
+
+	var rpids []int64
+	if q.RPID > 0 {
+		rpids = append(rpids, q.RPID)
+	} else {
+		m, err := GetAllRatePlans(ctx, q.BID)
+		if err != nil {
+			return quote, err
+		}
+		for i := 0; i < len(m); i++ {
+			rpids = append(rpids, m[i].RPID)
+		}
+	}
+
+	cache := map[int64]*ratePlanRefRates{} // by RPRID
+	for dt := q.DtStart; dt.Before(q.DtStop); {
+		next := NextPeriod(&dt, quote.RentCycle)
+		frac := float64(1)
+		if next.After(q.DtStop) {
+			frac = q.DtStop.Sub(dt).Hours() / next.Sub(dt).Hours()
+			next = q.DtStop
+		}
+		p := RateQuotePeriod{DtStart: dt, DtStop: next, MarketRate: rt.MarketRateOn(&dt)}
+
+		//-------------------------------------------------
+		// the RatePlanRefs in effect on dt
+		//-------------------------------------------------
+		var refs []*ratePlanRefRates
+		for _, rpid := range rpids {
+			m, err := GetRatePlanRefsInRange(ctx, rpid, &dt, &dt)
+			if err != nil {
+				return quote, err
+			}
+			for j := 0; j < len(m); j++ {
+				r, ok := cache[m[j].RPRID]
+				if !ok {
+					r = &ratePlanRefRates{Ref: m[j]}
+					if err = GetRatePlanRefRTRate(ctx, m[j].RPRID, q.RTID, &r.RT); err != nil {
+						return quote, err
+					}
+					if r.SP, err = GetAllRatePlanRefSPRates(ctx, m[j].RPRID, q.RTID); err != nil {
+						return quote, err
+					}
+					cache[m[j].RPRID] = r
+				}
+				refs = append(refs, r)
+			}
+		}
+
+		if r := selectRatePlanRef(refs, q, p.MarketRate, xbiz.US); r != nil {
+			p.RPID = r.Ref.RPID
+			p.RPRID = r.Ref.RPRID
+			p.Items, _ = r.price(q, p.MarketRate, xbiz.US)
+		} else {
+			p.Items = marketRateItems(q, p.MarketRate, xbiz.US)
+		}
+		for i := 0; i < len(p.Items); i++ {
+			p.Items[i].Amount = RoundToCent(p.Items[i].Amount * frac)
+			p.Total += p.Items[i].Amount
+		}
+		p.Total = RoundToCent(p.Total)
+		quote.Total += p.Total
+		quote.Periods = append(quote.Periods, p)
+		dt = next
+	}
+	quote.Total = RoundToCent(quote.Total)
+	return quote, nil
+}
+
+// selectRatePlanRef returns the RatePlanRef of refs to use for a period
+// whose market rate is market, nil if none applies.  See GetRateQuote for
+// the rules.
+//-----------------------------------------------------------------------------
+func selectRatePlanRef(refs []*ratePlanRefRates, q *RateQuoteRequest, market float64, us map[int64]RentableSpecialty) *ratePlanRefRates {
+	var best *ratePlanRefRates
+	var bestTotal float64
+	bestPromo := false
+	for _, r := range refs {
+		promo := len(r.Ref.PromoCode) > 0
+		if (promo || r.Ref.FLAGS&FlRTRRefHide != 0) && (len(q.PromoCode) == 0 || !strings.EqualFold(r.Ref.PromoCode, q.PromoCode)) {
+			continue
+		}
+		items, ok := r.price(q, market, us)
+		if !ok {
+			continue
+		}
+		var total float64
+		for i := 0; i < len(items); i++ {
+			total += items[i].Amount
+		}
+		if best == nil || (promo && !bestPromo) || (promo == bestPromo && (total < bestTotal || (total == bestTotal && r.Ref.RPRID < best.Ref.RPRID))) {
+			best, bestTotal, bestPromo = r, total, promo
+		}
+	}
+	return best
+}
+
+// price returns the charges of one full period under r.  Rates flagged as
+// a percent are a percent of the market rate.  A specialty without a rate
+// in r, or whose rate is marked not applicable, is charged its Fee.  The
+// occupants at least FeeAppliesAge old beyond MaxNoFeeUsers are each charged
+// AdditionalUserFee.
+//
+// RETURNS
+//     the charges
+//     false if r has no rate for the rentable type
+//-----------------------------------------------------------------------------
+func (r *ratePlanRefRates) price(q *RateQuoteRequest, market float64, us map[int64]RentableSpecialty) ([]RateQuoteItem, bool) {
+	var items []RateQuoteItem
+	if r.RT.RPRID == 0 || r.RT.FLAGS&FlRTRna != 0 {
+		return items, false
+	}
+	items = append(items, RateQuoteItem{Description: "rate", Amount: r.RT.Amount(market)})
+
+	for _, rspid := range q.RSPIDs {
+		it := RateQuoteItem{Description: us[rspid].Name, RSPID: rspid, Amount: us[rspid].Fee}
+		for j := 0; j < len(r.SP); j++ {
+			if r.SP[j].RSPID != rspid || r.SP[j].FLAGS&FlSPRna != 0 {
+				continue
+			}
+			it.Amount = r.SP[j].Amount(market)
+		}
+		items = append(items, it)
+	}
+
+	var users int64
+	for _, age := range q.Ages {
+		if age >= r.Ref.FeeAppliesAge {
+			users++
+		}
+	}
+	if extra := users - r.Ref.MaxNoFeeUsers; extra > 0 && r.Ref.AdditionalUserFee > 0 {
+		items = append(items, RateQuoteItem{
+			Description: fmt.Sprintf("%d additional users", extra),
+			Amount:      float64(extra) * r.Ref.AdditionalUserFee,
+		})
+	}
+	return items, true
+}
+
+// MarketRateOn returns the market rate of rt in effect on dt, 0 if there is
+// none.  rt.MR must be loaded.
+func (rt *RentableType) MarketRateOn(dt *time.Time) float64 {
+	for i := 0; i < len(rt.MR); i++ {
+		if !dt.Before(rt.MR[i].DtStart) && dt.Before(rt.MR[i].DtStop) {
+			return rt.MR[i].MarketRate
+		}
+	}
+	return 0
+}
+
+// Amount returns the rate of a for a period whose market rate is market
+func (a *RatePlanRefRTRate) Amount(market float64) float64 {
+	if a.FLAGS&FlRTRpct != 0 {
+		return market * a.Val / 100
+	}
+	return a.Val
+}
+
+// Amount returns the rate of a for a period whose market rate is market
+func (a *RatePlanRefSPRate) Amount(market float64) float64 {
+	if a.FLAGS&FlSPRpct != 0 {
+		return market * a.Val / 100
+	}
+	return a.Val
+}
+
+// marketRateItems returns the charges of one full period when no rate plan
+// applies: the market rate and the Fee of each specialty.
+//-----------------------------------------------------------------------------
+func marketRateItems(q *RateQuoteRequest, market float64, us map[int64]RentableSpecialty) []RateQuoteItem {
+	items := []RateQuoteItem{{Description: "market rate", Amount: market}}
+	for _, rspid := range q.RSPIDs {
+		items = append(items, RateQuoteItem{Description: us[rspid].Name, RSPID: rspid, Amount: us[rspid].Fee})
+	}
+	return items
+}
@@ -0,0 +1,92 @@
+package rlib
+
+import (
+	"testing"
+)
+
+// TestRatePlanRefPrice checks absolute and percent of market rates, the
+// specialty rates and fees, and the additional user fee
+func TestRatePlanRefPrice(t *testing.T) {
+	us := map[int64]RentableSpecialty{
+		1: {RSPID: 1, Name: "Lake View", Fee: 20},
+		2: {RSPID: 2, Name: "Fireplace", Fee: 10},
+	}
+	r := ratePlanRefRates{
+		Ref: RatePlanRef{RPRID: 1, FeeAppliesAge: 12, MaxNoFeeUsers: 2, AdditionalUserFee: 15},
+		RT:  RatePlanRefRTRate{RPRID: 1, RTID: 1, FLAGS: FlRTRpct, Val: 90},
+		SP: []RatePlanRefSPRate{
+			{RPRID: 1, RTID: 1, RSPID: 1, FLAGS: FlSPRpct, Val: 10},
+			{RPRID: 1, RTID: 1, RSPID: 2, FLAGS: FlSPRna, Val: 99},
+		},
+	}
+	var cases = []struct {
+		rspids []int64
+		ages   []int64
+		expect []float64
+	}{
+		{nil, []int64{30, 28}, []float64{90}},                           // 90% of market
+		{[]int64{1}, []int64{30}, []float64{90, 10}},                    // specialty at 10% of market
+		{[]int64{2}, []int64{30}, []float64{90, 10}},                    // specialty rate n/a, its fee applies
+		{nil, []int64{30, 28, 10, 8}, []float64{90}},                    // children do not count
+		{nil, []int64{30, 28, 14, 12}, []float64{90, 30}},               // two additional users
+		{[]int64{1, 2}, []int64{30, 28, 16}, []float64{90, 10, 10, 15}}, // everything
+	}
+	for i := 0; i < len(cases); i++ {
+		q := RateQuoteRequest{RTID: 1, RSPIDs: cases[i].rspids, Ages: cases[i].ages}
+		items, ok := r.price(&q, 100, us)
+		if !ok {
+			t.Errorf("case %d: rate plan does not apply\n", i)
+			continue
+		}
+		if len(items) != len(cases[i].expect) {
+			t.Errorf("case %d: expected %d items, got %d\n", i, len(cases[i].expect), len(items))
+			continue
+		}
+		for j := 0; j < len(items); j++ {
+			if items[j].Amount != cases[i].expect[j] {
+				t.Errorf("case %d item %d: expected %.2f, got %.2f\n", i, j, cases[i].expect[j], items[j].Amount)
+			}
+		}
+	}
+
+	r.RT.FLAGS = FlRTRna
+	if _, ok := r.price(&RateQuoteRequest{RTID: 1}, 100, us); ok {
+		t.Errorf("a rate marked not applicable was used\n")
+	}
+}
+
+// TestSelectRatePlanRef checks that promo codes and hidden rate plans are
+// honored and that the lowest price wins
+func TestSelectRatePlanRef(t *testing.T) {
+	rate := func(rprid int64, val float64, promo string, flags uint64) *ratePlanRefRates {
+		return &ratePlanRefRates{
+			Ref: RatePlanRef{RPRID: rprid, PromoCode: promo, FLAGS: flags, MaxNoFeeUsers: 10},
+			RT:  RatePlanRefRTRate{RPRID: rprid, RTID: 1, Val: val},
+		}
+	}
+	refs := []*ratePlanRefRates{
+		rate(1, 100, "", 0),
+		rate(2, 95, "", 0),
+		rate(3, 110, "SUMMER", 0),
+		rate(4, 50, "", FlRTRRefHide),
+		{Ref: RatePlanRef{RPRID: 5}}, // no rate for the type
+	}
+	var cases = []struct {
+		promo  string
+		expect int64
+	}{
+		{"", 2},       // lowest public rate
+		{"summer", 3}, // promo wins even when higher
+		{"WINTER", 2}, // unknown promo
+	}
+	for i := 0; i < len(cases); i++ {
+		q := RateQuoteRequest{RTID: 1, PromoCode: cases[i].promo}
+		r := selectRatePlanRef(refs, &q, 100, nil)
+		if r == nil || r.Ref.RPRID != cases[i].expect {
+			t.Errorf("case %d: expected RPRID %d, got %v\n", i, cases[i].expect, r)
+		}
+	}
+	if r := selectRatePlanRef(refs[4:], &RateQuoteRequest{RTID: 1}, 100, nil); r != nil {
+		t.Errorf("expected no rate plan, got RPRID %d\n", r.Ref.RPRID)
+	}
+}
@@ -13,6 +13,18 @@ import (
 // It is caller responsibility to check for zero-value for a resource (returned back from Get-* method) if
 // it's want to consider "no resource found" as an Error.
 
+// ReadAcctMap reads a full AcctMap structure from the database based on the supplied row object
+func ReadAcctMap(row *sql.Row, a *AcctMap) error {
+	err := row.Scan(&a.AMID, &a.BID, &a.LID, &a.GLNumber, &a.Name, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadAcctMaps reads a full AcctMap structure from the database based on the supplied rows object
+func ReadAcctMaps(rows *sql.Rows, a *AcctMap) error {
+	return rows.Scan(&a.AMID, &a.BID, &a.LID, &a.GLNumber, &a.Name, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
 // ReadAR reads a full AR structure from the database based on the supplied row object
 func ReadAR(row *sql.Row, a *AR) error {
 	err := row.Scan(&a.ARID, &a.BID, &a.Name, &a.ARType, &a.DebitLID, &a.CreditLID, &a.Description, &a.RARequired, &a.DtStart, &a.DtStop, &a.FLAGS, &a.DefaultAmount, &a.DefaultRentCycle, &a.DefaultProrationCycle, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
@@ -55,6 +67,18 @@ func ReadAssessment(row *sql.Row, a *Assessment) error {
 	return err
 }
 
+// ReadAssessmentTax reads a full AssessmentTax structure from the database based on the supplied row object
+func ReadAssessmentTax(row *sql.Row, a *AssessmentTax) error {
+	err := row.Scan(&a.ATID, &a.ASMID, &a.BID, &a.RAID, &a.TAXID, &a.TaxASMID, &a.Dt, &a.Base, &a.Amount, &a.FLAGS, &a.DtRefund, &a.OverrideTaxApprover, &a.OverrideAmount, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadAssessmentTaxes reads a full AssessmentTax structure from the database based on the supplied rows object
+func ReadAssessmentTaxes(rows *sql.Rows, a *AssessmentTax) error {
+	return rows.Scan(&a.ATID, &a.ASMID, &a.BID, &a.RAID, &a.TAXID, &a.TaxASMID, &a.Dt, &a.Base, &a.Amount, &a.FLAGS, &a.DtRefund, &a.OverrideTaxApprover, &a.OverrideAmount, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
 // ReadAssessments reads a full Assessment structure of data from the database based on the supplied Rows pointer.
 func ReadAssessments(rows *sql.Rows, a *Assessment) error {
 	return rows.Scan(
@@ -83,6 +107,18 @@ func ReadAssessments(rows *sql.Rows, a *Assessment) error {
 		&a.LastModBy)
 }
 
+// ReadBudget reads a full Budget structure from the database based on the supplied row object
+func ReadBudget(row *sql.Row, a *Budget) error {
+	err := row.Scan(&a.BGTID, &a.BID, &a.LID, &a.Dt, &a.Amount, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadBudgets reads a full Budget structure from the database based on the supplied rows object
+func ReadBudgets(rows *sql.Rows, a *Budget) error {
+	return rows.Scan(&a.BGTID, &a.BID, &a.LID, &a.Dt, &a.Amount, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
 // ReadBuildingData reads the data for a building object from db based on the supplied pointer
 func ReadBuildingData(row *sql.Row, a *Building) error {
 	err := row.Scan(&a.BLDGID, &a.BID, &a.Address, &a.Address2, &a.City, &a.State, &a.PostalCode, &a.Country, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
@@ -114,6 +150,30 @@ func ReadBusinessPropertiess(rows *sql.Rows, a *BusinessProperties) error {
 	return rows.Scan(&a.BPID, &a.BID, &a.Name, &a.Data, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
 }
 
+// ReadCAMReconLine reads a full CAMReconLine structure from the database based on the supplied row object
+func ReadCAMReconLine(row *sql.Row, a *CAMReconLine) error {
+	err := row.Scan(&a.CAMRLID, &a.CAMRID, &a.BID, &a.LID, &a.Expenses, &a.BaseExpenses, &a.Share, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadCAMReconLines reads a full CAMReconLine structure from the database based on the supplied rows object
+func ReadCAMReconLines(rows *sql.Rows, a *CAMReconLine) error {
+	return rows.Scan(&a.CAMRLID, &a.CAMRID, &a.BID, &a.LID, &a.Expenses, &a.BaseExpenses, &a.Share, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadCAMReconciliation reads a full CAMReconciliation structure from the database based on the supplied row object
+func ReadCAMReconciliation(row *sql.Row, a *CAMReconciliation) error {
+	err := row.Scan(&a.CAMRID, &a.BID, &a.RAID, &a.RID, &a.TCID, &a.Dt1, &a.Dt2, &a.LeaseType, &a.ExpenseAdjustmentType, &a.SqFt, &a.TotalSqFt, &a.Occupancy, &a.Expenses, &a.BaseExpenses, &a.ExpensesStop, &a.Share, &a.Estimated, &a.TrueUp, &a.ASMID, &a.RCPTID, &a.FLAGS, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadCAMReconciliations reads a full CAMReconciliation structure from the database based on the supplied rows object
+func ReadCAMReconciliations(rows *sql.Rows, a *CAMReconciliation) error {
+	return rows.Scan(&a.CAMRID, &a.BID, &a.RAID, &a.RID, &a.TCID, &a.Dt1, &a.Dt2, &a.LeaseType, &a.ExpenseAdjustmentType, &a.SqFt, &a.TotalSqFt, &a.Occupancy, &a.Expenses, &a.BaseExpenses, &a.ExpensesStop, &a.Share, &a.Estimated, &a.TrueUp, &a.ASMID, &a.RCPTID, &a.FLAGS, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
 // ReadClosePeriod reads a full ClosePeriod structure from the database based on the supplied row object
 func ReadClosePeriod(row *sql.Row, a *ClosePeriod) error {
 	err := row.Scan(&a.CPID, &a.BID, &a.TLID, &a.Dt, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
@@ -210,6 +270,93 @@ func ReadDepositParts(rows *sql.Rows, a *DepositPart) error {
 	return rows.Scan(&a.DPID, &a.DID, &a.BID, &a.RCPTID, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
 }
 
+// ReadBankStatement reads a full BankStatement structure from the database based on the supplied row object
+func ReadBankStatement(row *sql.Row, a *BankStatement) error {
+	err := row.Scan(&a.BSID, &a.BID, &a.DEPID, &a.FileName, &a.DtStart, &a.DtStop, &a.ClosingBalance, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadBankStatements reads a full BankStatement structure from the database based on the supplied rows object
+func ReadBankStatements(rows *sql.Rows, a *BankStatement) error {
+	return rows.Scan(&a.BSID, &a.BID, &a.DEPID, &a.FileName, &a.DtStart, &a.DtStop, &a.ClosingBalance, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadBankStatementLine reads a full BankStatementLine structure from the database based on the supplied row object
+func ReadBankStatementLine(row *sql.Row, a *BankStatementLine) error {
+	err := row.Scan(&a.BSLID, &a.BSID, &a.BID, &a.DEPID, &a.Dt, &a.Amount, &a.FITID, &a.Description, &a.DID, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadBankStatementLines reads a full BankStatementLine structure from the database based on the supplied rows object
+func ReadBankStatementLines(rows *sql.Rows, a *BankStatementLine) error {
+	return rows.Scan(&a.BSLID, &a.BSID, &a.BID, &a.DEPID, &a.Dt, &a.Amount, &a.FITID, &a.Description, &a.DID, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// readACHEnrollmentAcct decrypts the routing and account numbers of an
+// ACHEnrollment that were read from the database
+func readACHEnrollmentAcct(a *ACHEnrollment, r1, n1 string) error {
+	r, err := hex.DecodeString(r1)
+	if err != nil {
+		return err
+	}
+	if a.RoutingNumber, err = DecryptOrEmpty(r); err != nil {
+		return err
+	}
+	n, err := hex.DecodeString(n1)
+	if err != nil {
+		return err
+	}
+	a.AccountNumber, err = DecryptOrEmpty(n)
+	return err
+}
+
+// ReadACHEnrollment reads a full ACHEnrollment structure from the database based on the supplied row object
+func ReadACHEnrollment(row *sql.Row, a *ACHEnrollment) error {
+	var r1, n1 string
+	err := row.Scan(&a.AEID, &a.BID, &a.TCID, &a.AccountName, &r1, &n1, &a.MaxAmount, &a.DtStart, &a.DtStop, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	if err != nil || a.AEID == 0 {
+		return err
+	}
+	return readACHEnrollmentAcct(a, r1, n1)
+}
+
+// ReadACHEnrollments reads a full ACHEnrollment structure from the database based on the supplied rows object
+func ReadACHEnrollments(rows *sql.Rows, a *ACHEnrollment) error {
+	var r1, n1 string
+	err := rows.Scan(&a.AEID, &a.BID, &a.TCID, &a.AccountName, &r1, &n1, &a.MaxAmount, &a.DtStart, &a.DtStop, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	if err != nil {
+		return err
+	}
+	return readACHEnrollmentAcct(a, r1, n1)
+}
+
+// ReadACHBatch reads a full ACHBatch structure from the database based on the supplied row object
+func ReadACHBatch(row *sql.Row, a *ACHBatch) error {
+	err := row.Scan(&a.ABID, &a.BID, &a.Dt, &a.EntryCount, &a.TotalAmount, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadACHBatches reads a full ACHBatch structure from the database based on the supplied rows object
+func ReadACHBatches(rows *sql.Rows, a *ACHBatch) error {
+	return rows.Scan(&a.ABID, &a.BID, &a.Dt, &a.EntryCount, &a.TotalAmount, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadACHEntry reads a full ACHEntry structure from the database based on the supplied row object
+func ReadACHEntry(row *sql.Row, a *ACHEntry) error {
+	err := row.Scan(&a.AENID, &a.ABID, &a.BID, &a.AEID, &a.TCID, &a.RCPTID, &a.Amount, &a.TraceNumber, &a.ReturnCode, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadACHEntries reads a full ACHEntry structure from the database based on the supplied rows object
+func ReadACHEntries(rows *sql.Rows, a *ACHEntry) error {
+	return rows.Scan(&a.AENID, &a.ABID, &a.BID, &a.AEID, &a.TCID, &a.RCPTID, &a.Amount, &a.TraceNumber, &a.ReturnCode, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
 // ReadExpense reads a full Expense structure from the database based on the supplied row object
 func ReadExpense(row *sql.Row, a *Expense) error {
 	err := row.Scan(&a.EXPID, &a.RPEXPID, &a.BID, &a.RID, &a.RAID, &a.Amount, &a.Dt, &a.AcctRule, &a.ARID, &a.FLAGS, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
@@ -222,6 +369,22 @@ func ReadExpenses(rows *sql.Rows, a *Expense) error {
 	return rows.Scan(&a.EXPID, &a.RPEXPID, &a.BID, &a.RID, &a.RAID, &a.Amount, &a.Dt, &a.AcctRule, &a.ARID, &a.FLAGS, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
 }
 
+//------------------
+// FISCAL YEAR CLOSE
+//------------------
+
+// ReadFiscalYearClose reads a full FiscalYearClose structure from the database based on the supplied row object
+func ReadFiscalYearClose(row *sql.Row, a *FiscalYearClose) error {
+	err := row.Scan(&a.FYCID, &a.BID, &a.DtStart, &a.DtStop, &a.Dt, &a.LID, &a.JID, &a.NetIncome, &a.FLAGS, &a.RevJID, &a.DtReopened, &a.ReopenedBy, &a.Reason, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadFiscalYearCloses reads a full FiscalYearClose structure from the database based on the supplied rows object
+func ReadFiscalYearCloses(rows *sql.Rows, a *FiscalYearClose) error {
+	return rows.Scan(&a.FYCID, &a.BID, &a.DtStart, &a.DtStop, &a.Dt, &a.LID, &a.JID, &a.NetIncome, &a.FLAGS, &a.RevJID, &a.DtReopened, &a.ReopenedBy, &a.Reason, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
 //------------------
 // FLOW
 //------------------
@@ -242,6 +405,18 @@ func ReadFlows(rows *sql.Rows, a *Flow) error {
 // GLAccount
 //------------------
 
+// ReadFolio reads a full Folio structure from the database based on the supplied row object
+func ReadFolio(row *sql.Row, a *Folio) error {
+	err := row.Scan(&a.FOLID, &a.BID, &a.RAID, &a.RID, &a.RTID, &a.TCID, &a.DtArrive, &a.DtDepart, &a.Adults, &a.Children, &a.Rate, &a.PostedThrough, &a.Status, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadFolios reads a full Folio structure from the database based on the supplied rows object
+func ReadFolios(rows *sql.Rows, a *Folio) error {
+	return rows.Scan(&a.FOLID, &a.BID, &a.RAID, &a.RID, &a.RTID, &a.TCID, &a.DtArrive, &a.DtDepart, &a.Adults, &a.Children, &a.Rate, &a.PostedThrough, &a.Status, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
 // ReadGLAccount reads a full Ledger structure of data from the database based on the supplied Rows pointer.
 func ReadGLAccount(row *sql.Row, a *GLAccount) error {
 	err := row.Scan(&a.LID, &a.PLID, &a.BID, &a.RAID, &a.TCID, &a.GLNumber,
@@ -328,6 +503,66 @@ func ReadLedgerEntries(rows *sql.Rows, a *LedgerEntry) error {
 	return rows.Scan(&a.LEID, &a.BID, &a.JID, &a.JAID, &a.LID, &a.RAID, &a.RID, &a.TCID, &a.Dt, &a.Amount, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
 }
 
+// ReadMaintenanceRequest reads a full MaintenanceRequest structure from the database based on the supplied row object
+func ReadMaintenanceRequest(row *sql.Row, a *MaintenanceRequest) error {
+	err := row.Scan(&a.MRID, &a.BID, &a.RAID, &a.RID, &a.TCID, &a.Dt, &a.Category, &a.Description, &a.ContactPhone, &a.Status, &a.Response, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadMaintenanceRequests reads a full MaintenanceRequest structure from the database based on the supplied rows object
+func ReadMaintenanceRequests(rows *sql.Rows, a *MaintenanceRequest) error {
+	return rows.Scan(&a.MRID, &a.BID, &a.RAID, &a.RID, &a.TCID, &a.Dt, &a.Category, &a.Description, &a.ContactPhone, &a.Status, &a.Response, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadNightAudit reads a full NightAudit structure from the database based on the supplied row object
+func ReadNightAudit(row *sql.Row, a *NightAudit) error {
+	err := row.Scan(&a.NAID, &a.BID, &a.Dt, &a.Rooms, &a.Occupied, &a.Arrivals, &a.Departures, &a.RoomRevenue, &a.TaxRevenue, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadNightAudits reads a full NightAudit structure from the database based on the supplied rows object
+func ReadNightAudits(rows *sql.Rows, a *NightAudit) error {
+	return rows.Scan(&a.NAID, &a.BID, &a.Dt, &a.Rooms, &a.Occupied, &a.Arrivals, &a.Departures, &a.RoomRevenue, &a.TaxRevenue, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadOTAReservation reads a full OTAReservation structure from the database based on the supplied row object
+func ReadOTAReservation(row *sql.Row, a *OTAReservation) error {
+	err := row.Scan(&a.ORID, &a.BID, &a.RequestorID, &a.ResID, &a.RID, &a.RTID, &a.DtStart, &a.DtStop, &a.ConfirmationCode, &a.Status, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadOTAReservations reads a full OTAReservation structure from the database based on the supplied rows object
+func ReadOTAReservations(rows *sql.Rows, a *OTAReservation) error {
+	return rows.Scan(&a.ORID, &a.BID, &a.RequestorID, &a.ResID, &a.RID, &a.RTID, &a.DtStart, &a.DtStop, &a.ConfirmationCode, &a.Status, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadRateRecommendation reads a full RateRecommendation structure from the database based on the supplied row object
+func ReadRateRecommendation(row *sql.Row, a *RateRecommendation) error {
+	err := row.Scan(&a.RCMID, &a.BID, &a.RTID, &a.DtStart, &a.DtStop, &a.CurrentRate, &a.RecommendedRate, &a.AppliedRate, &a.Rentables, &a.Occupied, &a.MoveOuts, &a.AvgDaysVacant, &a.SeasonalPct, &a.Status, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadRateRecommendations reads a full RateRecommendation structure from the database based on the supplied rows object
+func ReadRateRecommendations(rows *sql.Rows, a *RateRecommendation) error {
+	return rows.Scan(&a.RCMID, &a.BID, &a.RTID, &a.DtStart, &a.DtStop, &a.CurrentRate, &a.RecommendedRate, &a.AppliedRate, &a.Rentables, &a.Occupied, &a.MoveOuts, &a.AvgDaysVacant, &a.SeasonalPct, &a.Status, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadRenewalOffer reads a full RenewalOffer structure from the database based on the supplied row object
+func ReadRenewalOffer(row *sql.Row, a *RenewalOffer) error {
+	err := row.Scan(&a.ROID, &a.BID, &a.RAID, &a.RID, &a.ASMID, &a.ARID, &a.CurrentRent, &a.MarketRent, &a.OfferRent, &a.TermStart, &a.TermStop, &a.OfferDt, &a.ExpireDt, &a.Status, &a.NewRAID, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadRenewalOffers reads a full RenewalOffer structure from the database based on the supplied rows object
+func ReadRenewalOffers(rows *sql.Rows, a *RenewalOffer) error {
+	return rows.Scan(&a.ROID, &a.BID, &a.RAID, &a.RID, &a.ASMID, &a.ARID, &a.CurrentRent, &a.MarketRent, &a.OfferRent, &a.TermStart, &a.TermStop, &a.OfferDt, &a.ExpireDt, &a.Status, &a.NewRAID, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
 // ReadRentableSpecialty read a full RentableSpecialty structure of data from db based on sql.Row pointer
 func ReadRentableSpecialty(row *sql.Row, a *RentableSpecialty) error {
 	err := row.Scan(&a.RSPID, &a.BID, &a.Name, &a.Fee, &a.Description, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
@@ -588,6 +823,18 @@ func ReadRentable(row *sql.Row, a *Rentable) error {
 	return err
 }
 
+// ReadRentableTypeTax reads a full RentableTypeTax structure from the database based on the supplied row object
+func ReadRentableTypeTax(row *sql.Row, a *RentableTypeTax) error {
+	err := row.Scan(&a.RTTAXID, &a.RTID, &a.BID, &a.TAXID, &a.DtStart, &a.DtStop, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadRentableTypeTaxes reads a full RentableTypeTax structure from the database based on the supplied rows object
+func ReadRentableTypeTaxes(rows *sql.Rows, a *RentableTypeTax) error {
+	return rows.Scan(&a.RTTAXID, &a.RTID, &a.BID, &a.TAXID, &a.DtStart, &a.DtStop, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
 // ReadRentables reads a full Rentable structure of data from the database based on the supplied Rows pointer.
 func ReadRentables(rows *sql.Rows, a *Rentable) error {
 	return rows.Scan(&a.RID, &a.BID, &a.PRID, &a.RentableName, &a.AssignmentTime, &a.MRStatus, &a.DtMRStart, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
@@ -800,6 +1047,18 @@ func ReadRentalAgreement(row *sql.Row, a *RentalAgreement) error {
 	return err
 }
 
+// ReadRentalAgreementTax reads a full RentalAgreementTax structure from the database based on the supplied row object
+func ReadRentalAgreementTax(row *sql.Row, a *RentalAgreementTax) error {
+	err := row.Scan(&a.RATAXID, &a.RAID, &a.BID, &a.DtStart, &a.DtStop, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadRentalAgreementTaxes reads a full RentalAgreementTax structure from the database based on the supplied rows object
+func ReadRentalAgreementTaxes(rows *sql.Rows, a *RentalAgreementTax) error {
+	return rows.Scan(&a.RATAXID, &a.RAID, &a.BID, &a.DtStart, &a.DtStop, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
 // ReadRentalAgreements reads a full RentalAgreement structure of data from the database based on the supplied Rows pointer.
 func ReadRentalAgreements(rows *sql.Rows, a *RentalAgreement) error {
 	return rows.Scan(
@@ -934,6 +1193,30 @@ func ReadRentableUsers(rows *sql.Rows, a *RentableUser) error {
 	return rows.Scan(&a.RUID, &a.RID, &a.BID, &a.TCID, &a.DtStart, &a.DtStop, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
 }
 
+// ReadSecDepSettlement reads a full SecDepSettlement structure from the database based on the supplied row object
+func ReadSecDepSettlement(row *sql.Row, a *SecDepSettlement) error {
+	err := row.Scan(&a.SDSID, &a.BID, &a.RAID, &a.TCID, &a.Dt, &a.DepositHeld, &a.Charges, &a.Damages, &a.DepositApplied, &a.Refund, &a.BalanceDue, &a.RCPTID, &a.DMGEXPID, &a.REFEXPID, &a.BDASMID, &a.ForwardAddress, &a.FLAGS, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadSecDepSettlements reads a full SecDepSettlement structure from the database based on the supplied rows object
+func ReadSecDepSettlements(rows *sql.Rows, a *SecDepSettlement) error {
+	return rows.Scan(&a.SDSID, &a.BID, &a.RAID, &a.TCID, &a.Dt, &a.DepositHeld, &a.Charges, &a.Damages, &a.DepositApplied, &a.Refund, &a.BalanceDue, &a.RCPTID, &a.DMGEXPID, &a.REFEXPID, &a.BDASMID, &a.ForwardAddress, &a.FLAGS, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadSecDepItem reads a full SecDepItem structure from the database based on the supplied row object
+func ReadSecDepItem(row *sql.Row, a *SecDepItem) error {
+	err := row.Scan(&a.SDIID, &a.SDSID, &a.BID, &a.ItemType, &a.ASMID, &a.RID, &a.Description, &a.Amount, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadSecDepItems reads a full SecDepItem structure from the database based on the supplied rows object
+func ReadSecDepItems(rows *sql.Rows, a *SecDepItem) error {
+	return rows.Scan(&a.SDIID, &a.SDSID, &a.BID, &a.ItemType, &a.ASMID, &a.RID, &a.Description, &a.Amount, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
 // ReadStringList reads a full StringList structure from the database based on the supplied row object
 func ReadStringList(row *sql.Row, a *StringList) error {
 	err := row.Scan(&a.SLID, &a.BID, &a.Name, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
@@ -1050,6 +1333,42 @@ func ReadTBinds(rows *sql.Rows, a *TBind) error {
 //  TRANSACTANT
 //---------------------
 
+// ReadTax reads a full Tax structure from the database based on the supplied row object
+func ReadTax(row *sql.Row, a *Tax) error {
+	err := row.Scan(&a.TAXID, &a.BID, &a.Name, &a.TaxingAuthority, &a.TaxingAuthorityAddress, &a.FilingDate, &a.FilingCycle, &a.Instructions, &a.ARID, &a.ExemptDays, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadTaxes reads a full Tax structure from the database based on the supplied rows object
+func ReadTaxes(rows *sql.Rows, a *Tax) error {
+	return rows.Scan(&a.TAXID, &a.BID, &a.Name, &a.TaxingAuthority, &a.TaxingAuthorityAddress, &a.FilingDate, &a.FilingCycle, &a.Instructions, &a.ARID, &a.ExemptDays, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadTaxRate reads a full TaxRate structure from the database based on the supplied row object
+func ReadTaxRate(row *sql.Row, a *TaxRate) error {
+	err := row.Scan(&a.TRID, &a.TAXID, &a.BID, &a.DtStart, &a.DtStop, &a.Rate, &a.Fee, &a.Formula, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadTaxRates reads a full TaxRate structure from the database based on the supplied rows object
+func ReadTaxRates(rows *sql.Rows, a *TaxRate) error {
+	return rows.Scan(&a.TRID, &a.TAXID, &a.BID, &a.DtStart, &a.DtStop, &a.Rate, &a.Fee, &a.Formula, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadTenantLogin reads a full TenantLogin structure from the database based on the supplied row object
+func ReadTenantLogin(row *sql.Row, a *TenantLogin) error {
+	err := row.Scan(&a.TLID, &a.BID, &a.TCID, &a.Username, &a.PasswordHash, &a.FailedLogins, &a.LastLogin, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadTenantLogins reads a full TenantLogin structure from the database based on the supplied rows object
+func ReadTenantLogins(rows *sql.Rows, a *TenantLogin) error {
+	return rows.Scan(&a.TLID, &a.BID, &a.TCID, &a.Username, &a.PasswordHash, &a.FailedLogins, &a.LastLogin, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
 // ReadTransactant reads a full Transactant structure from the database based on the supplied row object
 func ReadTransactant(row *sql.Row, a *Transactant) error {
 	err := row.Scan(&a.TCID, &a.BID, &a.NLID, &a.FirstName, &a.MiddleName, &a.LastName, &a.PreferredName,
@@ -1166,3 +1485,63 @@ func ReadVehicles(rows *sql.Rows, a *Vehicle) error {
 		&a.VIN, &a.LicensePlateState, &a.LicensePlateNumber, &a.ParkingPermitNumber, &a.DtStart, &a.DtStop,
 		&a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
 }
+
+// ReadVendor reads a full Vendor structure from the database based on the supplied row object
+func ReadVendor(row *sql.Row, a *Vendor) error {
+	err := row.Scan(&a.VID, &a.BID, &a.Name, &a.ContactName, &a.Email, &a.Phone, &a.Address, &a.Address2, &a.City, &a.State, &a.PostalCode, &a.Country, &a.TaxID, &a.Terms, &a.FLAGS, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadVendorBill reads a full VendorBill structure from the database based on the supplied row object
+func ReadVendorBill(row *sql.Row, a *VendorBill) error {
+	err := row.Scan(&a.VBID, &a.BID, &a.VID, &a.InvoiceNo, &a.Dt, &a.DueDt, &a.Amount, &a.Status, &a.ApprovedBy, &a.DtApproved, &a.JID, &a.VPRID, &a.DtPaid, &a.DocNo, &a.PmtJID, &a.FLAGS, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadVendorBillLine reads a full VendorBillLine structure from the database based on the supplied row object
+func ReadVendorBillLine(row *sql.Row, a *VendorBillLine) error {
+	err := row.Scan(&a.VBLID, &a.VBID, &a.BID, &a.LID, &a.RID, &a.Amount, &a.Description, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadVendorBillLines reads a full VendorBillLine structure from the database based on the supplied rows object
+func ReadVendorBillLines(rows *sql.Rows, a *VendorBillLine) error {
+	return rows.Scan(&a.VBLID, &a.VBID, &a.BID, &a.LID, &a.RID, &a.Amount, &a.Description, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadVendorBills reads a full VendorBill structure from the database based on the supplied rows object
+func ReadVendorBills(rows *sql.Rows, a *VendorBill) error {
+	return rows.Scan(&a.VBID, &a.BID, &a.VID, &a.InvoiceNo, &a.Dt, &a.DueDt, &a.Amount, &a.Status, &a.ApprovedBy, &a.DtApproved, &a.JID, &a.VPRID, &a.DtPaid, &a.DocNo, &a.PmtJID, &a.FLAGS, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadVendorPaymentRun reads a full VendorPaymentRun structure from the database based on the supplied row object
+func ReadVendorPaymentRun(row *sql.Row, a *VendorPaymentRun) error {
+	err := row.Scan(&a.VPRID, &a.BID, &a.DEPID, &a.Dt, &a.FirstCheckNo, &a.Amount, &a.BillCount, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadVendorPaymentRuns reads a full VendorPaymentRun structure from the database based on the supplied rows object
+func ReadVendorPaymentRuns(rows *sql.Rows, a *VendorPaymentRun) error {
+	return rows.Scan(&a.VPRID, &a.BID, &a.DEPID, &a.Dt, &a.FirstCheckNo, &a.Amount, &a.BillCount, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadVendors reads a full Vendor structure from the database based on the supplied rows object
+func ReadVendors(rows *sql.Rows, a *Vendor) error {
+	return rows.Scan(&a.VID, &a.BID, &a.Name, &a.ContactName, &a.Email, &a.Phone, &a.Address, &a.Address2, &a.City, &a.State, &a.PostalCode, &a.Country, &a.TaxID, &a.Terms, &a.FLAGS, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadWorkOrder reads a full WorkOrder structure from the database based on the supplied row object
+func ReadWorkOrder(row *sql.Row, a *WorkOrder) error {
+	err := row.Scan(&a.WOID, &a.BID, &a.RID, &a.RAID, &a.MRID, &a.Category, &a.Priority, &a.Status, &a.Vendor, &a.Description, &a.Dt, &a.ScheduledStart, &a.ScheduledStop, &a.DtCompleted, &a.LaborCost, &a.LaborARID, &a.LaborEXPID, &a.MaterialCost, &a.MaterialARID, &a.MaterialEXPID, &a.ChargebackAmount, &a.ChargebackARID, &a.ChargebackASMID, &a.FLAGS, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadWorkOrders reads a full WorkOrder structure from the database based on the supplied rows object
+func ReadWorkOrders(rows *sql.Rows, a *WorkOrder) error {
+	return rows.Scan(&a.WOID, &a.BID, &a.RID, &a.RAID, &a.MRID, &a.Category, &a.Priority, &a.Status, &a.Vendor, &a.Description, &a.Dt, &a.ScheduledStart, &a.ScheduledStop, &a.DtCompleted, &a.LaborCost, &a.LaborARID, &a.LaborEXPID, &a.MaterialCost, &a.MaterialARID, &a.MaterialEXPID, &a.ChargebackAmount, &a.ChargebackARID, &a.ChargebackASMID, &a.FLAGS, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
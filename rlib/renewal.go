@@ -0,0 +1,109 @@
+package rlib
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// RenewalOffer Status values
+const (
+	RenewalOfferOpen     = 0
+	RenewalOfferAccepted = 1
+	RenewalOfferDeclined = 2
+	RenewalOfferExpired  = 3
+)
+
+// RenewalOfferStatus is the name of each RenewalOffer Status value
+var RenewalOfferStatus = []string{"open", "accepted", "declined", "expired"}
+
+// Defaults for the renewal settings that the business properties do not set
+const (
+	RenewalTermMonths     = 12 // length of the new term
+	RenewalNoticeDays     = 90 // offers are made this many days before AgreementStop
+	RenewalOfferValidDays = 30 // an offer expires this many days after it is made
+)
+
+// BizPropsRenewal holds the lease renewal offer settings for a business.  It
+// is stored as part of the business properties (BizProps.Renewal).  The
+// offered rent moves the current rent toward the rentable's market rate,
+// but the change is kept within MinIncreasePct - MaxIncreasePct of the
+// current rent.
+//
+//    TermMonths     - months in the new term, RenewalTermMonths if 0
+//    NoticeDays     - offers are made for the agreements that stop within
+//                     this many days, RenewalNoticeDays if 0
+//    OfferValidDays - days an offer stays open, RenewalOfferValidDays if 0
+//    MaxIncreasePct - the largest increase offered, 5 means 5%.  0 means
+//                     there is no cap
+//    MinIncreasePct - the smallest change offered.  0 means the rent is
+//                     never lowered, a negative value allows decreases down
+//                     to that percent
+//-----------------------------------------------------------------------------
+type BizPropsRenewal struct {
+	TermMonths     int
+	NoticeDays     int
+	OfferValidDays int
+	MaxIncreasePct float64
+	MinIncreasePct float64
+}
+
+// GetRenewalPolicy returns the lease renewal settings configured in the
+// business properties named bizPropName for business BID.
+//
+// INPUTS
+//     ctx         = context
+//     BID         = business id
+//     bizPropName = name of the business properties, usually "general"
+//
+// RETURNS
+//     the renewal settings
+//     any error encountered
+//-----------------------------------------------------------------------------
+func GetRenewalPolicy(ctx context.Context, BID int64, bizPropName string) (BizPropsRenewal, error) {
+	bizPropJSON, err := GetDataFromBusinessPropertyName(ctx, bizPropName, BID)
+	if err != nil {
+		return BizPropsRenewal{}, err
+	}
+	p := bizPropJSON.Renewal
+	if p.TermMonths <= 0 {
+		p.TermMonths = RenewalTermMonths
+	}
+	if p.NoticeDays <= 0 {
+		p.NoticeDays = RenewalNoticeDays
+	}
+	if p.OfferValidDays <= 0 {
+		p.OfferValidDays = RenewalOfferValidDays
+	}
+	return p, nil
+}
+
+// TermStop returns the end of the renewal term that starts on dt.
+func (p *BizPropsRenewal) TermStop(dt *time.Time) time.Time {
+	return dt.AddDate(0, p.TermMonths, 0)
+}
+
+// ProposedRent returns the rent to offer for the renewal of a rentable now
+// rented at current whose market rate is market.  The market rate is
+// offered when it is within the caps, otherwise the rent changes by the
+// capped percentage.  If there is no market rate the current rent is
+// offered.
+//
+// INPUTS
+//     current = the rent at the end of the current term
+//     market  = the market rate for the new term
+//
+// RETURNS
+//     the rent to offer, rounded to the cent
+//-----------------------------------------------------------------------------
+func (p *BizPropsRenewal) ProposedRent(current, market float64) float64 {
+	if market <= 0 {
+		return RoundToCent(current)
+	}
+	x := market
+	if p.MaxIncreasePct > 0 {
+		x = math.Min(x, current*(1+p.MaxIncreasePct/100))
+	}
+	x = math.Max(x, current*(1+p.MinIncreasePct/100))
+	return RoundToCent(math.Max(x, 0))
+}
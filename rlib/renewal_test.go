@@ -0,0 +1,42 @@
+package rlib
+
+import (
+	"testing"
+	"time"
+)
+
+// TestProposedRent checks that renewal offers follow the market rate within
+// the configured caps
+func TestProposedRent(t *testing.T) {
+	var cases = []struct {
+		max, min        float64
+		current, market float64
+		expect          float64
+	}{
+		{0, 0, 1000, 1100, 1100},        // no cap, market rate
+		{5, 0, 1000, 1100, 1050},        // capped at 5%
+		{5, 0, 1000, 1030, 1030},        // market within the cap
+		{5, 0, 1000, 900, 1000},         // never lowered
+		{5, -3, 1000, 900, 970},         // lowered at most 3%
+		{5, -3, 1000, 980, 980},         // market within the decrease floor
+		{5, 2, 1000, 1000, 1020},        // minimum increase
+		{5, 0, 1000, 0, 1000},           // no market rate
+		{3.5, 0, 999.99, 2000, 1034.99}, // rounded to the cent
+	}
+	for i := 0; i < len(cases); i++ {
+		p := BizPropsRenewal{MaxIncreasePct: cases[i].max, MinIncreasePct: cases[i].min}
+		if x := p.ProposedRent(cases[i].current, cases[i].market); x != cases[i].expect {
+			t.Errorf("case %d: expected %.2f, got %.2f\n", i, cases[i].expect, x)
+		}
+	}
+}
+
+// TestRenewalTermStop checks the end of the renewal term
+func TestRenewalTermStop(t *testing.T) {
+	p := BizPropsRenewal{TermMonths: 12}
+	dt := time.Date(2018, time.July, 1, 0, 0, 0, 0, time.UTC)
+	expect := time.Date(2019, time.July, 1, 0, 0, 0, 0, time.UTC)
+	if d := p.TermStop(&dt); !d.Equal(expect) {
+		t.Errorf("expected %s, got %s\n", expect.Format(RRDATEFMT3), d.Format(RRDATEFMT3))
+	}
+}
@@ -0,0 +1,81 @@
+package rlib
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// RAFixedRateChange is the RentalAgreement FLAGS bit that says RateChange is
+// a fixed amount added to the rent rather than a percentage of it.
+const RAFixedRateChange = uint64(1 << 7)
+
+// RentEscalationMonths is the number of months between scheduled rent
+// escalations when the business properties do not say.
+const RentEscalationMonths = 12
+
+// BizPropsRentEscalation holds the scheduled rent escalation settings for a
+// business.  It is stored as part of the business properties
+// (BizProps.RentEscalation).  The amount of each escalation comes from the
+// RentalAgreement's RateChange, and its date from NextRateChange.
+//
+//    Enabled        - the rent escalation bot only escalates rents when this
+//                     is true
+//    IntervalMonths - months between escalations, NextRateChange is advanced
+//                     by this much after each one. RentEscalationMonths if 0
+//-----------------------------------------------------------------------------
+type BizPropsRentEscalation struct {
+	Enabled        bool
+	IntervalMonths int
+}
+
+// GetRentEscalationPolicy returns the rent escalation settings configured in
+// the business properties named bizPropName for business BID.
+//
+// INPUTS
+//     ctx         = context
+//     BID         = business id
+//     bizPropName = name of the business properties, usually "general"
+//
+// RETURNS
+//     the rent escalation settings
+//     any error encountered
+//-----------------------------------------------------------------------------
+func GetRentEscalationPolicy(ctx context.Context, BID int64, bizPropName string) (BizPropsRentEscalation, error) {
+	bizPropJSON, err := GetDataFromBusinessPropertyName(ctx, bizPropName, BID)
+	if err != nil {
+		return BizPropsRentEscalation{}, err
+	}
+	p := bizPropJSON.RentEscalation
+	if p.IntervalMonths <= 0 {
+		p.IntervalMonths = RentEscalationMonths
+	}
+	return p, nil
+}
+
+// NextEscalationDate returns the date of the escalation that follows the
+// one on dt.
+func (p *BizPropsRentEscalation) NextEscalationDate(dt *time.Time) time.Time {
+	return dt.AddDate(0, p.IntervalMonths, 0)
+}
+
+// EscalatedRent returns the rent that replaces amt when the rental
+// agreement's scheduled RateChange is applied.  RateChange is a percentage
+// (3 means 3%) unless the RAFixedRateChange flag is set, in which case it is
+// added to the rent.  The result is never negative.
+//
+// INPUTS
+//     amt = the current rent
+//
+// RETURNS
+//     the escalated rent, rounded to the cent
+//-----------------------------------------------------------------------------
+func (ra *RentalAgreement) EscalatedRent(amt float64) float64 {
+	var x float64
+	if ra.FLAGS&RAFixedRateChange != 0 {
+		x = amt + ra.RateChange
+	} else {
+		x = amt * (1 + ra.RateChange/100)
+	}
+	return RoundToCent(math.Max(x, 0))
+}
@@ -0,0 +1,50 @@
+package rlib
+
+import (
+	"testing"
+	"time"
+)
+
+// TestEscalatedRent checks percentage and fixed step escalations
+func TestEscalatedRent(t *testing.T) {
+	var cases = []struct {
+		rent, change float64
+		flags        uint64
+		expect       float64
+	}{
+		{1000, 3, 0, 1030},                     // 3%
+		{1250, 2.5, 0, 1281.25},                // 2.5%
+		{999.99, 3.333, 0, 1033.32},            // rounded to the cent
+		{1000, 50, RAFixedRateChange, 1050},    // fixed step
+		{1000, -25, RAFixedRateChange, 975},    // fixed reduction
+		{20, -50, RAFixedRateChange, 0},        // never negative
+		{1000, 0, 0, 1000},                     // no change
+		{800, -10, RAFixedRateChange | 4, 790}, // state bits do not matter
+	}
+	for i := 0; i < len(cases); i++ {
+		ra := RentalAgreement{RateChange: cases[i].change, FLAGS: cases[i].flags}
+		if x := ra.EscalatedRent(cases[i].rent); x != cases[i].expect {
+			t.Errorf("case %d: expected %.2f, got %.2f\n", i, cases[i].expect, x)
+		}
+	}
+}
+
+// TestNextEscalationDate checks that escalation dates advance by the
+// configured interval
+func TestNextEscalationDate(t *testing.T) {
+	var cases = []struct {
+		months int
+		dt     time.Time
+		expect time.Time
+	}{
+		{12, time.Date(2018, time.March, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{6, time.Date(2018, time.September, 15, 0, 0, 0, 0, time.UTC), time.Date(2019, time.March, 15, 0, 0, 0, 0, time.UTC)},
+		{1, time.Date(2018, time.December, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for i := 0; i < len(cases); i++ {
+		p := BizPropsRentEscalation{IntervalMonths: cases[i].months}
+		if d := p.NextEscalationDate(&cases[i].dt); !d.Equal(cases[i].expect) {
+			t.Errorf("case %d: expected %s, got %s\n", i, cases[i].expect.Format(RRDATEFMT3), d.Format(RRDATEFMT3))
+		}
+	}
+}
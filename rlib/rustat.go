@@ -81,216 +81,60 @@ func SetRentableUseStatusAbbr(ctx context.Context, bid, rid, us int64, d1, d2 *t
 
 }
 
-// SetRentableUseStatus implements the proper insertion of a use status
-//     under all the circumstances considered.
+// SetRentableUseStatus sets the use status rus.UseStatus for the range
+// rus.DtStart - rus.DtStop.  The use statuses it overlaps are trimmed,
+// split or merged with it as described in SetEffectiveDated.
 //
 // INPUTS
 //     ctx - db context
 //     rus - the new use status structure
 //-----------------------------------------------------------------------------
 func SetRentableUseStatus(ctx context.Context, rus *RentableUseStatus) error {
-	// funcname := "SetRentableUseStatus"
-	// Console("\nEntered %s.  range = %s, UseStatus = %d\n", funcname, ConsoleDRange(&rus.DtStart, &rus.DtStop), rus.UseStatus)
-
-	var err error
-	var b []RentableUseStatus
 	d1 := rus.DtStart
 	d2 := rus.DtStop
 	a, err := GetRentableUseStatusByRange(ctx, rus.RID, &d1, &d2)
 	if err != nil {
 		return err
 	}
+	return SetEffectiveDated(ctx, &useStatusSeries{n: rus, a: a}, &d1, &d2)
+}
 
-	// Console("%s: Range = %s    found %d records\n", funcname, ConsoleDRange(&d1, &d2), len(a))
-
-	//--------------------------------------------------------------------------
-	// Remove any status records that are fully encompassed by rus.
-	//--------------------------------------------------------------------------
-	for i := 0; i < len(a); i++ {
-		// Console("i = %d, RSID = %d\n", i, a[i].RSID)
-		if (d1.Before(a[i].DtStart) || d1.Equal(a[i].DtStart)) &&
-			(d2.After(a[i].DtStop) || d2.Equal(a[i].DtStop)) {
-			// Console("%s: deleting RSID = %d ------------------------------------\n", funcname, a[i].RSID)
-			if err = DeleteRentableUseStatus(ctx, a[i].RSID); err != nil {
-				return err
-			}
-		} else {
-			// Console("Appending RSID=%d to a[]\n", a[i].RSID)
-			b = append(b, a[i])
-		}
-	}
-
-	//-------------------------------------------------------------------
-	// We're left with 0 or 1 or 2 items in b.  The overlap cases are
-	// handled by this loop.  When it finishes, rus is is inserted.
-	//-------------------------------------------------------------------
-	if len(b) == 0 {
-		_, err = InsertRentableUseStatus(ctx, rus)
-		return err
-	}
-
-	//------------------------------------------------------------------------
-	// CASE 1  -  after simplification, there is overlap on only one record
-	//------------------------------------------------------------------------
-	if len(b) == 1 {
-		match := b[0].UseStatus == rus.UseStatus
-		before := b[0].DtStart.Before(d1)
-		after := b[0].DtStop.After(d2)
-		if match {
-			//-----------------------------------------------
-			// CASE 1a -  rus is contained by b[0] and statuses are equal
-			//-----------------------------------------------
-			//     b[0]: @@@@@@@@@@@@@@@@@@@@@
-			//      rus:      @@@@@@@@@@@@
-			//   Result: @@@@@@@@@@@@@@@@@@@@@
-			//-----------------------------------------------
-			// Console("%s: Case 1a\n", funcname)
-			if !before {
-				b[0].DtStart = d1
-			}
-			if !after {
-				b[0].DtStop = d2
-			}
-			return UpdateRentableUseStatus(ctx, &b[0])
-		}
-
-		if before && after {
-			//-----------------------------------------------
-			// CASE 1b -  rus contains b[0], match == false
-			//-----------------------------------------------
-			//     b[0]: @@@@@@@@@@@@@@@@@@@@@
-			//      rus:      ############
-			//   Result: @@@@@############@@@@
-			//-----------------------------------------------
-			// Console("%s: Case 1b\n", funcname)
-			n := b[0]
-			n.DtStart = d2
-			if _, err = InsertRentableUseStatus(ctx, &n); err != nil {
-				return err
-			}
-			b[0].DtStop = d1
-			if err = UpdateRentableUseStatus(ctx, &b[0]); err != nil {
-				return err
-			}
-		}
-		if !before {
-			//-----------------------------------------------
-			// CASE 1c -  rus prior to b[0], match == false
-			//-----------------------------------------------
-			//      rus: @@@@@@@@@@@@
-			//     b[0]:       ##########
-			//   Result: @@@@@@@@@@@@####
-			//-----------------------------------------------
-			// Console("%s: Case 1c\n", funcname)
-			b[0].DtStart = d2
-			if err = UpdateRentableUseStatus(ctx, &b[0]); err != nil {
-				return err
-			}
-		}
-		if !after {
-			//-----------------------------------------------
-			// CASE 1d -  rus prior to b[0], match == false
-			//-----------------------------------------------
-			//      rus:     @@@@@@@@@@@@
-			//     b[0]: ##########
-			//   Result: ####@@@@@@@@@@@@
-			//-----------------------------------------------
-			// Console("%s: Case 1d\n", funcname)
-			b[0].DtStop = d1
-			if err = UpdateRentableUseStatus(ctx, &b[0]); err != nil {
-				return err
-			}
-		}
-		// Console("%s: Inserting %s UseStatus = %d\n", funcname, ConsoleDRange(&rus.DtStart, &rus.DtStop), rus.UseStatus)
-		_, err = InsertRentableUseStatus(ctx, rus)
-		return err
-	}
+// useStatusSeries is the RentableUseStatus effective-dated series
+type useStatusSeries struct {
+	n *RentableUseStatus  // the new use status
+	a []RentableUseStatus // the use statuses it overlaps
+}
 
-	//------------------------------------------------------------------------
-	// CASE 2  -  after simplification, there is overlap with two records
-	//------------------------------------------------------------------------
-	if len(b) == 2 {
-		match0 := b[0].UseStatus == rus.UseStatus
-		match1 := b[1].UseStatus == rus.UseStatus
-		before := b[0].DtStart.Before(d1)
-		after := b[1].DtStop.After(d2)
-		// Console("%s: Case 2 and match0 = %t, match1 = %t\n", funcname, match0, match1)
-		if match0 && match1 {
-			// Case 2a
-			// all are the same, merge them all into b[0], delete b[1]
-			//  b[0:1]   ********* ************
-			//  rus            *******
-			//  Result   **********************
-			// Console("%s: Case 2a All match\n", funcname)
-			if !before {
-				b[0].DtStart = d1
-			}
-			b[0].DtStop = b[1].DtStop
-			if !after {
-				b[0].DtStop = d2
-			}
-			if err = UpdateRentableUseStatus(ctx, &b[0]); err != nil {
-				return err
-			}
-			return DeleteRentableUseStatus(ctx, b[1].RSID)
-		}
+// Len returns the number of overlapped records
+func (s *useStatusSeries) Len() int { return len(s.a) }
 
-		if !match0 && !match1 {
-			// Case 2b
-			// neither match. Update both b[0] and b[1], add new rus
-			//  b[0:1]   @@@@@@@@@@************
-			//  rus            #######
-			//  Result   @@@@@@#######*********
-			// Console("%s: Case 2b Both do not match\n", funcname)
-			if d1.After(b[0].DtStart) {
-				b[0].DtStop = d1
-				if err = UpdateRentableUseStatus(ctx, &b[0]); err != nil {
-					return err
-				}
-			}
-			if d2.Before(b[1].DtStop) {
-				b[1].DtStart = d2
-			}
-			if err = UpdateRentableUseStatus(ctx, &b[1]); err != nil {
-				return err
-			}
-			_, err = InsertRentableUseStatus(ctx, rus)
-			return err
-		}
+// Span returns the range of record i
+func (s *useStatusSeries) Span(i int) (time.Time, time.Time) { return s.a[i].DtStart, s.a[i].DtStop }
 
-		if match0 && !match1 {
-			// Case 2c
-			// merge rus and b[0], update b[1]
-			//  b[0:1]   @@@@@@@@@@************
-			//  rus            @@@@@@@
-			//  Result   @@@@@@@@@@@@@*********
-			// Console("%s: Case 2c b[0] matches\n", funcname)
-			b[0].DtStop = d2
-			if err = UpdateRentableUseStatus(ctx, &b[0]); err != nil {
-				return err
-			}
-			b[1].DtStart = d2
-			return UpdateRentableUseStatus(ctx, &b[1])
-		}
+// SameValue returns true if record i can be merged with the new one
+func (s *useStatusSeries) SameValue(i int) bool {
+	return s.a[i].UseStatus == s.n.UseStatus
+}
 
-		if !match0 && match1 {
-			// Case 2d
-			// merge rus and b[1], update b[0]
-			//  b[0:1]   @@@@@@@@@@************
-			//  rus            *******
-			//  Result   @@@@@@****************
-			// Console("%s: Case 2d b[0] matches\n", funcname)
-			b[1].DtStart = d1
-			if err = UpdateRentableUseStatus(ctx, &b[1]); err != nil {
-				return err
-			}
-			b[0].DtStop = d1
-			return UpdateRentableUseStatus(ctx, &b[0])
-		}
+// Update changes the range of record i
+func (s *useStatusSeries) Update(ctx context.Context, i int, d1, d2 *time.Time) error {
+	s.a[i].DtStart, s.a[i].DtStop = *d1, *d2
+	return UpdateRentableUseStatus(ctx, &s.a[i])
+}
 
-		// Console("%s: UNHANDLED CASE???\n", funcname)
+// Insert adds a copy of record i, or the new one if i is -1
+func (s *useStatusSeries) Insert(ctx context.Context, i int, d1, d2 *time.Time) error {
+	x := s.n
+	if i >= 0 {
+		c := s.a[i]
+		x = &c
 	}
+	x.DtStart, x.DtStop = *d1, *d2
+	_, err := InsertRentableUseStatus(ctx, x)
+	return err
+}
 
-	return nil
-
+// Delete removes record i
+func (s *useStatusSeries) Delete(ctx context.Context, i int) error {
+	return DeleteRentableUseStatus(ctx, s.a[i].RSID)
 }
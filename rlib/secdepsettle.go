@@ -0,0 +1,133 @@
+package rlib
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// SecDepPaymentTypeName is the name of the PaymentType used for the receipts
+// that apply security deposits to unpaid assessments when the business
+// properties do not name one.
+const SecDepPaymentTypeName = "Security Deposit"
+
+// SecDepReturnDays is the number of days after move-out in which the
+// disposition letter must be sent when the business properties do not say.
+const SecDepReturnDays = 30
+
+// BizPropsSecDep holds the security deposit disposition settings for a
+// business.  It is stored as part of the business properties
+// (BizProps.SecDep).  The account rules determine the journal entries made
+// when a settlement is posted:
+//
+//    PMTName          - name of the PaymentType for the receipts that apply
+//                       deposits to unpaid assessments,
+//                       SecDepPaymentTypeName if empty
+//    ApplyARName      - Receipt account rule that applies deposits to unpaid
+//                       assessments.  It should debit the security deposit
+//                       liability account.
+//    DamageARName     - Expense account rule that applies deposits to
+//                       damages.  It should debit the security deposit
+//                       liability account and credit damage income.
+//    BalanceDueARName - Assessment account rule for the damages that the
+//                       deposits do not cover
+//    RefundARName     - Expense account rule for the refund. It should debit
+//                       the security deposit liability account.
+//    ReturnDays       - days after move-out in which the letter and refund
+//                       must be sent, SecDepReturnDays if 0
+//    Jurisdiction     - state whose law governs the deposit
+//    StatuteText      - the notice that the law requires on the letter
+//-----------------------------------------------------------------------------
+type BizPropsSecDep struct {
+	PMTName          string
+	ApplyARName      string
+	DamageARName     string
+	BalanceDueARName string
+	RefundARName     string
+	ReturnDays       int
+	Jurisdiction     string
+	StatuteText      string
+}
+
+// GetSecDepPolicy returns the security deposit disposition settings
+// configured in the business properties named bizPropName for business BID.
+//
+// INPUTS
+//     ctx         = context
+//     BID         = business id
+//     bizPropName = name of the business properties, usually "general"
+//
+// RETURNS
+//     the security deposit settings
+//     any error encountered
+//-----------------------------------------------------------------------------
+func GetSecDepPolicy(ctx context.Context, BID int64, bizPropName string) (BizPropsSecDep, error) {
+	bizPropJSON, err := GetDataFromBusinessPropertyName(ctx, bizPropName, BID)
+	if err != nil {
+		return BizPropsSecDep{}, err
+	}
+	p := bizPropJSON.SecDep
+	if len(p.PMTName) == 0 {
+		p.PMTName = SecDepPaymentTypeName
+	}
+	if p.ReturnDays <= 0 {
+		p.ReturnDays = SecDepReturnDays
+	}
+	return p, nil
+}
+
+// ReturnDeadline returns the date by which the disposition letter and any
+// refund must be sent for a tenant who moved out on dt.
+func (p *BizPropsSecDep) ReturnDeadline(dt *time.Time) time.Time {
+	return dt.AddDate(0, 0, p.ReturnDays)
+}
+
+// IDtoString is the standard id string for a SecDepSettlement
+func (s *SecDepSettlement) IDtoString() string {
+	return IDtoString("SDS", s.SDSID)
+}
+
+// SecDepDisposition shows how the deposits of a SecDepSettlement are
+// divided between the unpaid charges, the damages and the refund.
+type SecDepDisposition struct {
+	ToCharges     float64 // deposits applied to unpaid assessments
+	ToDamages     float64 // deposits applied to damages
+	Refund        float64 // deposits returned to the payor
+	UnpaidCharges float64 // unpaid assessments the deposits did not cover
+	UnpaidDamages float64 // damages the deposits did not cover
+}
+
+// Disposition totals the items of the settlement into Charges and Damages
+// and then applies DepositHeld, first to the charges and then to the
+// damages.  DepositApplied, Refund and BalanceDue are updated to match.
+//
+// RETURNS
+//    the division of the deposits
+//-----------------------------------------------------------------------------
+func (s *SecDepSettlement) Disposition() SecDepDisposition {
+	var d SecDepDisposition
+	s.Charges = 0
+	s.Damages = 0
+	for i := 0; i < len(s.I); i++ {
+		switch s.I[i].ItemType {
+		case SecDepItemCharge:
+			s.Charges += s.I[i].Amount
+		case SecDepItemDamage:
+			s.Damages += s.I[i].Amount
+		}
+	}
+	s.Charges = RoundToCent(s.Charges)
+	s.Damages = RoundToCent(s.Damages)
+
+	held := RoundToCent(math.Max(s.DepositHeld, 0))
+	d.ToCharges = math.Min(held, s.Charges)
+	d.ToDamages = RoundToCent(math.Min(held-d.ToCharges, s.Damages))
+	d.Refund = RoundToCent(held - d.ToCharges - d.ToDamages)
+	d.UnpaidCharges = RoundToCent(s.Charges - d.ToCharges)
+	d.UnpaidDamages = RoundToCent(s.Damages - d.ToDamages)
+
+	s.DepositApplied = RoundToCent(d.ToCharges + d.ToDamages)
+	s.Refund = d.Refund
+	s.BalanceDue = RoundToCent(d.UnpaidCharges + d.UnpaidDamages)
+	return d
+}
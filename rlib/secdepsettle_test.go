@@ -0,0 +1,41 @@
+package rlib
+
+import "testing"
+
+// TestSecDepDisposition checks how the deposits are divided between the
+// unpaid charges, the damages and the refund
+func TestSecDepDisposition(t *testing.T) {
+	var cases = []struct {
+		held, charges, damages                     float64
+		toCharges, toDamages, refund, balance, dmg float64
+	}{
+		{1000, 0, 0, 0, 0, 1000, 0, 0},          // everything refunded
+		{1000, 300, 200, 300, 200, 500, 0, 0},   // partial refund
+		{1000, 600, 400, 600, 400, 0, 0, 0},     // exactly used up
+		{1000, 700, 500, 700, 300, 0, 200, 200}, // damages not fully covered
+		{500, 800, 150, 500, 0, 0, 450, 150},    // charges not fully covered
+		{0, 100, 50, 0, 0, 0, 150, 50},          // no deposit held
+	}
+	for i := 0; i < len(cases); i++ {
+		c := cases[i]
+		s := SecDepSettlement{DepositHeld: c.held}
+		if c.charges > 0 {
+			s.I = append(s.I, SecDepItem{ItemType: SecDepItemCharge, Amount: c.charges / 2}, SecDepItem{ItemType: SecDepItemCharge, Amount: c.charges / 2})
+		}
+		if c.damages > 0 {
+			s.I = append(s.I, SecDepItem{ItemType: SecDepItemDamage, Amount: c.damages})
+		}
+		d := s.Disposition()
+		if d.ToCharges != c.toCharges || d.ToDamages != c.toDamages || d.Refund != c.refund || d.UnpaidDamages != c.dmg {
+			t.Errorf("case %d: expected charges %.2f damages %.2f refund %.2f unpaid damages %.2f, got %.2f %.2f %.2f %.2f\n",
+				i, c.toCharges, c.toDamages, c.refund, c.dmg, d.ToCharges, d.ToDamages, d.Refund, d.UnpaidDamages)
+		}
+		if s.Charges != c.charges || s.Damages != c.damages {
+			t.Errorf("case %d: expected totals %.2f / %.2f, got %.2f / %.2f\n", i, c.charges, c.damages, s.Charges, s.Damages)
+		}
+		if s.BalanceDue != c.balance || s.Refund != c.refund || s.DepositApplied != c.toCharges+c.toDamages {
+			t.Errorf("case %d: expected balance %.2f refund %.2f applied %.2f, got %.2f %.2f %.2f\n",
+				i, c.balance, c.refund, c.toCharges+c.toDamages, s.BalanceDue, s.Refund, s.DepositApplied)
+		}
+	}
+}
@@ -152,7 +152,13 @@ func SessionCleanup() {
 					ss[k] = v // ...copy it to the new list
 				}
 			}
-			sessions = ss         // set the new list
+			sessions = ss // set the new list
+			for k, v := range tenantSessions {
+				if now.After(v.Expire) {
+					delete(tenantSessions, k)
+					n++
+				}
+			}
 			ReqSessionMemAck <- 1 // tell SessionDispatcher we're done with the data
 			// Console("SessionCleanup completed. %d removed. Current Session list size = %d\n", n, len(sessions))
 		}
@@ -170,6 +176,7 @@ func SessionCleanup() {
 //-----------------------------------------------------------------------------
 func SessionInit(timeout int) {
 	sessions = make(map[string]*Session)
+	tenantSessions = make(map[string]*TenantSession)
 	ReqSessionMem = make(chan int)
 	ReqSessionMemAck = make(chan int)
 	SessionCleanupTime = time.Duration(1)
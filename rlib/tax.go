@@ -0,0 +1,62 @@
+package rlib
+
+import (
+	"time"
+)
+
+// AssessmentTax FLAGS
+const (
+	ATaxDoNotApply = 1 << 0 // override, the tax does not apply
+	ATaxOverride   = 1 << 1 // override, the tax is OverrideAmount
+	ATaxExempt     = 1 << 2 // the stay was exempt, no tax was charged
+	ATaxRefunded   = 1 << 3 // the tax was refunded when the stay became exempt
+)
+
+// RentalAgreementTax FLAGS
+const (
+	RATaxable = 1 << 0 // the rental agreement is taxable
+)
+
+// Tax returns the tax at rate r on a charge of amt: Rate percent of amt
+// plus Fee, rounded to the cent.  A credit (negative amt) gets a negative
+// tax.
+func (r *TaxRate) Tax(amt float64) float64 {
+	x := amt*r.Rate/100 + r.Fee
+	if amt < 0 {
+		x = amt*r.Rate/100 - r.Fee
+	}
+	return RoundToCent(x)
+}
+
+// TaxRateOn returns the rate of m in effect on dt, nil if there is none
+func TaxRateOn(m []TaxRate, dt *time.Time) *TaxRate {
+	for i := 0; i < len(m); i++ {
+		if !dt.Before(m[i].DtStart) && dt.Before(m[i].DtStop) {
+			return &m[i]
+		}
+	}
+	return nil
+}
+
+// ExemptStay returns true if a stay that started on start is exempt from
+// tax t on dt: it has lasted more than t.ExemptDays days, counting the day
+// of dt.  A tax with no ExemptDays has no exemption.
+func (t *Tax) ExemptStay(start, dt *time.Time) bool {
+	if t.ExemptDays <= 0 || dt.Before(*start) {
+		return false
+	}
+	days := int64(DateAtTimeZero(*dt).Sub(DateAtTimeZero(*start)).Hours()/24 + 0.5)
+	return days+1 > t.ExemptDays
+}
+
+// IsTaxable returns true if a rental agreement with the RentalAgreementTax
+// records m is taxable on dt.  An agreement is taxable unless the record in
+// effect on dt clears RATaxable.
+func IsTaxable(m []RentalAgreementTax, dt *time.Time) bool {
+	for i := 0; i < len(m); i++ {
+		if !dt.Before(m[i].DtStart) && dt.Before(m[i].DtStop) {
+			return m[i].FLAGS&RATaxable != 0
+		}
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+package rlib
+
+import (
+	"testing"
+	"time"
+)
+
+// TestTaxRateTax checks percent and per charge taxes and their rounding
+func TestTaxRateTax(t *testing.T) {
+	var cases = []struct {
+		amt, rate, fee, expect float64
+	}{
+		{100, 12.5, 0, 12.5},
+		{89.99, 7, 0, 6.30}, // 6.2993
+		{120, 0, 2, 2},      // per charge fee
+		{120, 6, 2, 9.2},
+		{-100, 10, 1, -11}, // credit
+		{0, 10, 0, 0},
+	}
+	for i := 0; i < len(cases); i++ {
+		r := TaxRate{Rate: cases[i].rate, Fee: cases[i].fee}
+		if x := r.Tax(cases[i].amt); x != cases[i].expect {
+			t.Errorf("case %d: expected %.2f, got %.2f\n", i, cases[i].expect, x)
+		}
+	}
+}
+
+// TestTaxRateOn checks the effective dating of the tax rates
+func TestTaxRateOn(t *testing.T) {
+	m := []TaxRate{
+		{TRID: 1, DtStart: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), DtStop: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{TRID: 2, DtStart: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), DtStop: ENDOFTIME},
+	}
+	var cases = []struct {
+		dt     time.Time
+		expect int64
+	}{
+		{time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC), 0},
+		{time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC), 1},
+		{time.Date(2025, time.December, 31, 0, 0, 0, 0, time.UTC), 1},
+		{time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), 2},
+	}
+	for i := 0; i < len(cases); i++ {
+		var trid int64
+		if r := TaxRateOn(m, &cases[i].dt); r != nil {
+			trid = r.TRID
+		}
+		if trid != cases[i].expect {
+			t.Errorf("case %d: expected rate %d, got %d\n", i, cases[i].expect, trid)
+		}
+	}
+}
+
+// TestExemptStay checks stays become exempt after ExemptDays days
+func TestExemptStay(t *testing.T) {
+	start := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	var cases = []struct {
+		exemptDays int64
+		dt         time.Time
+		expect     bool
+	}{
+		{30, time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), false},  // day 1
+		{30, time.Date(2026, time.January, 30, 0, 0, 0, 0, time.UTC), false}, // day 30
+		{30, time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC), true},  // day 31
+		{30, time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC), true},
+		{0, time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC), false}, // no exemption
+		{30, time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC), false},
+	}
+	for i := 0; i < len(cases); i++ {
+		x := Tax{ExemptDays: cases[i].exemptDays}
+		if b := x.ExemptStay(&start, &cases[i].dt); b != cases[i].expect {
+			t.Errorf("case %d: expected %t, got %t\n", i, cases[i].expect, b)
+		}
+	}
+}
+
+// TestIsTaxable checks rental agreements are taxable unless flagged
+// otherwise
+func TestIsTaxable(t *testing.T) {
+	m := []RentalAgreementTax{
+		{DtStart: time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC), DtStop: time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{DtStart: time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC), DtStop: ENDOFTIME, FLAGS: RATaxable},
+	}
+	var cases = []struct {
+		dt     time.Time
+		expect bool
+	}{
+		{time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC), true}, // no record
+		{time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC), false},
+		{time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC), true},
+	}
+	for i := 0; i < len(cases); i++ {
+		if b := IsTaxable(m, &cases[i].dt); b != cases[i].expect {
+			t.Errorf("case %d: expected %t, got %t\n", i, cases[i].expect, b)
+		}
+	}
+	if !IsTaxable(nil, &cases[0].dt) {
+		t.Errorf("an agreement without records must be taxable\n")
+	}
+}
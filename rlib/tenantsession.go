@@ -0,0 +1,147 @@
+package rlib
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+)
+
+// TenantSessionCookieName is the name of the cookie where the token of a
+// tenant portal session is stored.  It is different from the staff cookie
+// so that a tenant session can never be used for the staff services.
+var TenantSessionCookieName = string("rrtenant")
+
+// TenantSession is a tenant's session with the portal. Unlike Session it is
+// not backed by the Accord Directory, it is created when the tenant logs in
+// with its TenantLogin.
+type TenantSession struct {
+	Token    string    // random, unique id
+	TLID     int64     // the TenantLogin
+	BID      int64     // business of the login
+	TCID     int64     // the tenant
+	Username string    // TenantLogin.Username
+	Name     string    // tenant's name
+	Expire   time.Time // when does the session expire
+}
+
+// tenantSessions is the list of tenant sessions. It is protected by the
+// same handshake as the staff sessions and cleaned up by SessionCleanup.
+var tenantSessions map[string]*TenantSession
+
+// TenantSessionNew creates a new session for TenantLogin tl and adds it to
+// the tenant session list.  The session token is written to a cookie.
+//
+// INPUT
+//  w    - where to write the cookie
+//  tl   - the login
+//  name - the tenant's name
+//
+// RETURNS
+//  session - pointer to the new session
+//  any error encountered
+//-----------------------------------------------------------------------------
+func TenantSessionNew(w http.ResponseWriter, tl *TenantLogin, name string) (*TenantSession, error) {
+	b := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return nil, err
+	}
+	s := TenantSession{
+		Token:    hex.EncodeToString(b),
+		TLID:     tl.TLID,
+		BID:      tl.BID,
+		TCID:     tl.TCID,
+		Username: tl.Username,
+		Name:     name,
+		Expire:   time.Now().Add(SessionTimeout),
+	}
+
+	ReqSessionMem <- 1 // ask to access the shared mem, blocks until granted
+	<-ReqSessionMemAck // make sure we got it
+	tenantSessions[s.Token] = &s
+	ReqSessionMemAck <- 1 // tell SessionDispatcher we're done with the data
+
+	cookie := http.Cookie{Name: TenantSessionCookieName, Value: s.Token, Expires: s.Expire, Path: "/", HttpOnly: true}
+	http.SetCookie(w, &cookie)
+	return &s, nil
+}
+
+// GetTenantSession returns the tenant session identified by the cookie in
+// r, or nil if there is no cookie or the session has expired.
+//
+// INPUT
+//  r - the request where we look for the cookie
+//
+// RETURNS
+//  session - pointer to the session or nil
+//-----------------------------------------------------------------------------
+func GetTenantSession(r *http.Request) *TenantSession {
+	cookie, err := r.Cookie(TenantSessionCookieName)
+	if err != nil {
+		return nil
+	}
+	var expire time.Time
+	ReqSessionMem <- 1 // ask to access the shared mem, blocks until granted
+	<-ReqSessionMemAck // make sure we got it
+	s, ok := tenantSessions[cookie.Value]
+	if ok && s != nil {
+		expire = s.Expire // Refresh writes it, read it while we hold the data
+	}
+	ReqSessionMemAck <- 1 // tell SessionDispatcher we're done with the data
+	if !ok || s == nil || time.Now().After(expire) {
+		return nil
+	}
+	return s
+}
+
+// Refresh extends the expire time of the tenant session and its cookie.
+//
+// INPUT
+//  w - where to write the cookie
+//-----------------------------------------------------------------------------
+func (s *TenantSession) Refresh(w http.ResponseWriter) {
+	expire := time.Now().Add(SessionTimeout)
+	ReqSessionMem <- 1 // ask to access the shared mem, blocks until granted
+	<-ReqSessionMemAck // make sure we got it
+	s.Expire = expire
+	ReqSessionMemAck <- 1 // tell SessionDispatcher we're done with the data
+	cookie := http.Cookie{Name: TenantSessionCookieName, Value: s.Token, Expires: expire, Path: "/", HttpOnly: true}
+	http.SetCookie(w, &cookie)
+}
+
+// TenantSessionDelete removes the supplied tenant session and expires its
+// cookie.
+//
+// INPUT
+//  s - the session to delete
+//  w - where to write the cookie
+//-----------------------------------------------------------------------------
+func TenantSessionDelete(s *TenantSession, w http.ResponseWriter) {
+	if s == nil {
+		return
+	}
+	ReqSessionMem <- 1 // ask to access the shared mem, blocks until granted
+	<-ReqSessionMemAck // make sure we got it
+	delete(tenantSessions, s.Token)
+	ReqSessionMemAck <- 1 // tell SessionDispatcher we're done with the data
+	cookie := http.Cookie{Name: TenantSessionCookieName, Value: "", Expires: time.Now(), Path: "/", HttpOnly: true}
+	http.SetCookie(w, &cookie)
+}
+
+// Session returns the session to put in the context of the tenant's
+// requests so that the database routines can be used.  The records written
+// on behalf of the tenant are attributed to TenantPortalApp.
+//
+// RETURNS
+//  a session for the request context
+//-----------------------------------------------------------------------------
+func (s *TenantSession) Session() *Session {
+	return &Session{
+		Token:    s.Token,
+		Username: s.Username,
+		Name:     s.Name,
+		UID:      TenantPortalApp,
+		Expire:   s.Expire,
+	}
+}
@@ -34,6 +34,25 @@ func updateError(err error, n string, a interface{}) error {
 	return err
 }
 
+// UpdateAcctMap updates an AcctMap record
+func UpdateAcctMap(ctx context.Context, a *AcctMap) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.BID, a.LID, a.GLNumber, a.Name, a.LastModBy, a.AMID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateAcctMap)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateAcctMap.Exec(fields...)
+	}
+	return updateError(err, "AcctMap", *a)
+}
+
 // UpdateAR updates an AR record
 func UpdateAR(ctx context.Context, a *AR) error {
 	var err error
@@ -102,6 +121,44 @@ func UpdateAssessment(ctx context.Context, a *Assessment) error {
 	return updateError(err, "Assessment", *a)
 }
 
+// UpdateAssessmentTax updates a AssessmentTax record
+func UpdateAssessmentTax(ctx context.Context, a *AssessmentTax) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.ASMID, a.BID, a.RAID, a.TAXID, a.TaxASMID, a.Dt, a.Base, a.Amount, a.FLAGS, a.DtRefund, a.OverrideTaxApprover, a.OverrideAmount, a.Comment, a.LastModBy, a.ATID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateAssessmentTax)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateAssessmentTax.Exec(fields...)
+	}
+	return updateError(err, "AssessmentTax", *a)
+}
+
+// UpdateBudget updates a Budget record
+func UpdateBudget(ctx context.Context, a *Budget) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.BID, a.LID, a.Dt, a.Amount, a.Comment, a.LastModBy, a.BGTID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateBudget)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateBudget.Exec(fields...)
+	}
+	return updateError(err, "Budget", *a)
+}
+
 // UpdateBusiness updates an Business record
 func UpdateBusiness(ctx context.Context, a *Business) error {
 	var err error
@@ -152,6 +209,44 @@ func UpdateBusinessPropertiesData(ctx context.Context, jsonDataKey string, jsonD
 	return updateError(err, "BusinessProperties", *a)
 }
 
+// UpdateCAMReconLine updates a CAMReconLine record
+func UpdateCAMReconLine(ctx context.Context, a *CAMReconLine) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.CAMRID, a.BID, a.LID, a.Expenses, a.BaseExpenses, a.Share, a.LastModBy, a.CAMRLID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateCAMReconLine)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateCAMReconLine.Exec(fields...)
+	}
+	return updateError(err, "CAMReconLine", *a)
+}
+
+// UpdateCAMReconciliation updates a CAMReconciliation record
+func UpdateCAMReconciliation(ctx context.Context, a *CAMReconciliation) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.BID, a.RAID, a.RID, a.TCID, a.Dt1, a.Dt2, a.LeaseType, a.ExpenseAdjustmentType, a.SqFt, a.TotalSqFt, a.Occupancy, a.Expenses, a.BaseExpenses, a.ExpensesStop, a.Share, a.Estimated, a.TrueUp, a.ASMID, a.RCPTID, a.FLAGS, a.Comment, a.LastModBy, a.CAMRID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateCAMReconciliation)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateCAMReconciliation.Exec(fields...)
+	}
+	return updateError(err, "CAMReconciliation", *a)
+}
+
 // UpdateClosePeriod updates an ClosePeriod record
 func UpdateClosePeriod(ctx context.Context, a *ClosePeriod) error {
 	var err error
@@ -286,6 +381,107 @@ func UpdateDepositPart(ctx context.Context, a *DepositPart) error {
 	return updateError(err, "DepositPart", *a)
 }
 
+// UpdateBankStatement updates a BankStatement record
+func UpdateBankStatement(ctx context.Context, a *BankStatement) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.BID, a.DEPID, a.FileName, a.DtStart, a.DtStop, a.ClosingBalance, a.FLAGS, a.LastModBy, a.BSID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateBankStatement)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateBankStatement.Exec(fields...)
+	}
+	return updateError(err, "BankStatement", *a)
+}
+
+// UpdateACHEnrollment updates an ACHEnrollment record. The routing and
+// account numbers are encrypted before they are saved.
+func UpdateACHEnrollment(ctx context.Context, a *ACHEnrollment) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	r, n, err := encryptACHEnrollmentAcct(a)
+	if err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.BID, a.TCID, a.AccountName, r, n, a.MaxAmount, a.DtStart, a.DtStop, a.FLAGS, a.LastModBy, a.AEID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateACHEnrollment)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateACHEnrollment.Exec(fields...)
+	}
+	return updateError(err, "ACHEnrollment", *a)
+}
+
+// UpdateACHBatch updates an ACHBatch record
+func UpdateACHBatch(ctx context.Context, a *ACHBatch) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.BID, a.Dt, a.EntryCount, a.TotalAmount, a.FLAGS, a.LastModBy, a.ABID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateACHBatch)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateACHBatch.Exec(fields...)
+	}
+	return updateError(err, "ACHBatch", *a)
+}
+
+// UpdateACHEntry updates an ACHEntry record
+func UpdateACHEntry(ctx context.Context, a *ACHEntry) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.ABID, a.BID, a.AEID, a.TCID, a.RCPTID, a.Amount, a.TraceNumber, a.ReturnCode, a.FLAGS, a.LastModBy, a.AENID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateACHEntry)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateACHEntry.Exec(fields...)
+	}
+	return updateError(err, "ACHEntry", *a)
+}
+
+// UpdateBankStatementLine updates a BankStatementLine record
+func UpdateBankStatementLine(ctx context.Context, a *BankStatementLine) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.BSID, a.BID, a.DEPID, a.Dt, a.Amount, a.FITID, a.Description, a.DID, a.FLAGS, a.LastModBy, a.BSLID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateBankStatementLine)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateBankStatementLine.Exec(fields...)
+	}
+	return updateError(err, "BankStatementLine", *a)
+}
+
 // UpdateExpense updates a Expense record
 func UpdateExpense(ctx context.Context, a *Expense) error {
 	var err error
@@ -306,6 +502,101 @@ func UpdateExpense(ctx context.Context, a *Expense) error {
 	return updateError(err, "Expense", *a)
 }
 
+// UpdateFiscalYearClose updates a FiscalYearClose record
+func UpdateFiscalYearClose(ctx context.Context, a *FiscalYearClose) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.BID, a.DtStart, a.DtStop, a.Dt, a.LID, a.JID, a.NetIncome, a.FLAGS, a.RevJID, a.DtReopened, a.ReopenedBy, a.Reason, a.LastModBy, a.FYCID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateFiscalYearClose)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateFiscalYearClose.Exec(fields...)
+	}
+	return updateError(err, "FiscalYearClose", *a)
+}
+
+// UpdateFolio updates a Folio record
+func UpdateFolio(ctx context.Context, a *Folio) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.BID, a.RAID, a.RID, a.RTID, a.TCID, a.DtArrive, a.DtDepart, a.Adults, a.Children, a.Rate, a.PostedThrough, a.Status, a.Comment, a.LastModBy, a.FOLID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateFolio)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateFolio.Exec(fields...)
+	}
+	return updateError(err, "Folio", *a)
+}
+
+// UpdateMaintenanceRequest updates a MaintenanceRequest record
+func UpdateMaintenanceRequest(ctx context.Context, a *MaintenanceRequest) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.BID, a.RAID, a.RID, a.TCID, a.Dt, a.Category, a.Description, a.ContactPhone, a.Status, a.Response, a.FLAGS, a.LastModBy, a.MRID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateMaintenanceRequest)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateMaintenanceRequest.Exec(fields...)
+	}
+	return updateError(err, "MaintenanceRequest", *a)
+}
+
+// UpdateNightAudit updates a NightAudit record
+func UpdateNightAudit(ctx context.Context, a *NightAudit) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.BID, a.Dt, a.Rooms, a.Occupied, a.Arrivals, a.Departures, a.RoomRevenue, a.TaxRevenue, a.Comment, a.LastModBy, a.NAID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateNightAudit)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateNightAudit.Exec(fields...)
+	}
+	return updateError(err, "NightAudit", *a)
+}
+
+// UpdateOTAReservation updates a OTAReservation record
+func UpdateOTAReservation(ctx context.Context, a *OTAReservation) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.BID, a.RequestorID, a.ResID, a.RID, a.RTID, a.DtStart, a.DtStop, a.ConfirmationCode, a.Status, a.LastModBy, a.ORID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateOTAReservation)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateOTAReservation.Exec(fields...)
+	}
+	return updateError(err, "OTAReservation", *a)
+}
+
 // UpdateRAFlowWithInitState updates the flow record with resetting it's state
 // to application being complete
 func UpdateRAFlowWithInitState(ctx context.Context, a *Flow) error {
@@ -585,6 +876,44 @@ func UpdateProspect(ctx context.Context, a *Prospect) error {
 	return updateError(err, "Prospect", *a)
 }
 
+// UpdateRateRecommendation updates a RateRecommendation record
+func UpdateRateRecommendation(ctx context.Context, a *RateRecommendation) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.BID, a.RTID, a.DtStart, a.DtStop, a.CurrentRate, a.RecommendedRate, a.AppliedRate, a.Rentables, a.Occupied, a.MoveOuts, a.AvgDaysVacant, a.SeasonalPct, a.Status, a.Comment, a.LastModBy, a.RCMID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateRateRecommendation)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateRateRecommendation.Exec(fields...)
+	}
+	return updateError(err, "RateRecommendation", *a)
+}
+
+// UpdateRenewalOffer updates a RenewalOffer record
+func UpdateRenewalOffer(ctx context.Context, a *RenewalOffer) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.BID, a.RAID, a.RID, a.ASMID, a.ARID, a.CurrentRent, a.MarketRent, a.OfferRent, a.TermStart, a.TermStop, a.OfferDt, a.ExpireDt, a.Status, a.NewRAID, a.Comment, a.LastModBy, a.ROID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateRenewalOffer)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateRenewalOffer.Exec(fields...)
+	}
+	return updateError(err, "RenewalOffer", *a)
+}
+
 // UpdateRentable updates a Rentable record in the database
 func UpdateRentable(ctx context.Context, a *Rentable) error {
 	var err error
@@ -628,6 +957,25 @@ func UpdateRentableLeaseStatus(ctx context.Context, a *RentableLeaseStatus) erro
 	return updateError(err, "RentableLeaseStatus", *a)
 }
 
+// UpdateRentableTypeTax updates a RentableTypeTax record
+func UpdateRentableTypeTax(ctx context.Context, a *RentableTypeTax) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.RTID, a.BID, a.TAXID, a.DtStart, a.DtStop, a.LastModBy, a.RTTAXID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateRentableTypeTax)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateRentableTypeTax.Exec(fields...)
+	}
+	return updateError(err, "RentableTypeTax", *a)
+}
+
 // UpdateRentableUseStatus updates a RentableUseStatus record in the database
 func UpdateRentableUseStatus(ctx context.Context, a *RentableUseStatus) error {
 	var err error
@@ -1090,6 +1438,63 @@ func UpdateRentableUserByRBT(ctx context.Context, a *RentableUser) error {
 	return updateError(err, "RentableUser", *a)
 }
 
+// UpdateRentalAgreementTax updates a RentalAgreementTax record
+func UpdateRentalAgreementTax(ctx context.Context, a *RentalAgreementTax) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.RAID, a.BID, a.DtStart, a.DtStop, a.FLAGS, a.LastModBy, a.RATAXID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateRentalAgreementTax)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateRentalAgreementTax.Exec(fields...)
+	}
+	return updateError(err, "RentalAgreementTax", *a)
+}
+
+// UpdateSecDepSettlement updates a SecDepSettlement record
+func UpdateSecDepSettlement(ctx context.Context, a *SecDepSettlement) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.BID, a.RAID, a.TCID, a.Dt, a.DepositHeld, a.Charges, a.Damages, a.DepositApplied, a.Refund, a.BalanceDue, a.RCPTID, a.DMGEXPID, a.REFEXPID, a.BDASMID, a.ForwardAddress, a.FLAGS, a.Comment, a.LastModBy, a.SDSID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateSecDepSettlement)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateSecDepSettlement.Exec(fields...)
+	}
+	return updateError(err, "SecDepSettlement", *a)
+}
+
+// UpdateSecDepItem updates a SecDepItem record
+func UpdateSecDepItem(ctx context.Context, a *SecDepItem) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.SDSID, a.BID, a.ItemType, a.ASMID, a.RID, a.Description, a.Amount, a.LastModBy, a.SDIID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateSecDepItem)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateSecDepItem.Exec(fields...)
+	}
+	return updateError(err, "SecDepItem", *a)
+}
+
 // UpdateStringList updates a StringList record in the database. It also updates the string list. It does this by
 // deleting all the strings first, then inserting the ones it has.
 func UpdateStringList(ctx context.Context, a *StringList) error {
@@ -1263,6 +1668,63 @@ func UpdateTBind(ctx context.Context, a *TBind) error {
 //    TRANSACTANT
 //*****************************************************************************
 
+// UpdateTax updates a Tax record
+func UpdateTax(ctx context.Context, a *Tax) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.BID, a.Name, a.TaxingAuthority, a.TaxingAuthorityAddress, a.FilingDate, a.FilingCycle, a.Instructions, a.ARID, a.ExemptDays, a.LastModBy, a.TAXID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateTax)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateTax.Exec(fields...)
+	}
+	return updateError(err, "Tax", *a)
+}
+
+// UpdateTaxRate updates a TaxRate record
+func UpdateTaxRate(ctx context.Context, a *TaxRate) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.TAXID, a.BID, a.DtStart, a.DtStop, a.Rate, a.Fee, a.Formula, a.LastModBy, a.TRID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateTaxRate)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateTaxRate.Exec(fields...)
+	}
+	return updateError(err, "TaxRate", *a)
+}
+
+// UpdateTenantLogin updates a TenantLogin record
+func UpdateTenantLogin(ctx context.Context, a *TenantLogin) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.BID, a.TCID, a.Username, a.PasswordHash, a.FailedLogins, a.LastLogin, a.FLAGS, a.LastModBy, a.TLID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateTenantLogin)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateTenantLogin.Exec(fields...)
+	}
+	return updateError(err, "TenantLogin", *a)
+}
+
 // UpdateTransactant updates a Transactant record in the database
 func UpdateTransactant(ctx context.Context, a *Transactant) error {
 	var err error
@@ -1340,3 +1802,98 @@ func UpdateVehicle(ctx context.Context, a *Vehicle) error {
 	}
 	return updateError(err, "Vehicle", *a)
 }
+
+// UpdateVendor updates a Vendor record
+func UpdateVendor(ctx context.Context, a *Vendor) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.BID, a.Name, a.ContactName, a.Email, a.Phone, a.Address, a.Address2, a.City, a.State, a.PostalCode, a.Country, a.TaxID, a.Terms, a.FLAGS, a.Comment, a.LastModBy, a.VID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateVendor)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateVendor.Exec(fields...)
+	}
+	return updateError(err, "Vendor", *a)
+}
+
+// UpdateVendorBill updates a VendorBill record
+func UpdateVendorBill(ctx context.Context, a *VendorBill) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.BID, a.VID, a.InvoiceNo, a.Dt, a.DueDt, a.Amount, a.Status, a.ApprovedBy, a.DtApproved, a.JID, a.VPRID, a.DtPaid, a.DocNo, a.PmtJID, a.FLAGS, a.Comment, a.LastModBy, a.VBID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateVendorBill)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateVendorBill.Exec(fields...)
+	}
+	return updateError(err, "VendorBill", *a)
+}
+
+// UpdateVendorBillLine updates a VendorBillLine record
+func UpdateVendorBillLine(ctx context.Context, a *VendorBillLine) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.VBID, a.BID, a.LID, a.RID, a.Amount, a.Description, a.LastModBy, a.VBLID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateVendorBillLine)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateVendorBillLine.Exec(fields...)
+	}
+	return updateError(err, "VendorBillLine", *a)
+}
+
+// UpdateVendorPaymentRun updates a VendorPaymentRun record
+func UpdateVendorPaymentRun(ctx context.Context, a *VendorPaymentRun) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.BID, a.DEPID, a.Dt, a.FirstCheckNo, a.Amount, a.BillCount, a.Comment, a.LastModBy, a.VPRID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateVendorPaymentRun)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateVendorPaymentRun.Exec(fields...)
+	}
+	return updateError(err, "VendorPaymentRun", *a)
+}
+
+// UpdateWorkOrder updates a WorkOrder record
+func UpdateWorkOrder(ctx context.Context, a *WorkOrder) error {
+	var err error
+
+	if err = updateSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+
+	fields := []interface{}{a.BID, a.RID, a.RAID, a.MRID, a.Category, a.Priority, a.Status, a.Vendor, a.Description, a.Dt, a.ScheduledStart, a.ScheduledStop, a.DtCompleted, a.LaborCost, a.LaborARID, a.LaborEXPID, a.MaterialCost, a.MaterialARID, a.MaterialEXPID, a.ChargebackAmount, a.ChargebackARID, a.ChargebackASMID, a.FLAGS, a.Comment, a.LastModBy, a.WOID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateWorkOrder)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateWorkOrder.Exec(fields...)
+	}
+	return updateError(err, "WorkOrder", *a)
+}
@@ -239,4 +239,5 @@ var RcptFLAGS = Str2Int64Map{
 	"PARTIALALLOCATED": int64(RCPTPARTIALALLOCATED),
 	"FULLYALLOCATED":   int64(RCPTFULLYALLOCATED),
 	"REVERSED":         int64(RCPTREVERSED),
+	"ACHPENDING":       int64(RCPTACHPENDING),
 }
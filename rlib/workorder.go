@@ -0,0 +1,37 @@
+package rlib
+
+// IDtoString is the standard id string for a WorkOrder
+func (wo *WorkOrder) IDtoString() string {
+	return IDtoString("WO", wo.WOID)
+}
+
+// IDtoShortString is the short id string for a WorkOrder
+func (wo *WorkOrder) IDtoShortString() string {
+	return IDtoShortString("WO", wo.WOID)
+}
+
+// StatusName returns the display name of the work order's status
+func (wo *WorkOrder) StatusName() string {
+	if wo.Status < 0 || wo.Status > WOStatusLast {
+		return "Unknown"
+	}
+	return WOStatusNames[wo.Status]
+}
+
+// PriorityName returns the display name of the work order's priority
+func (wo *WorkOrder) PriorityName() string {
+	if wo.Priority < 0 || wo.Priority > WOPriorityLast {
+		return "Unknown"
+	}
+	return WOPriorityNames[wo.Priority]
+}
+
+// IsOpen returns true until the work order is completed, closed or canceled
+func (wo *WorkOrder) IsOpen() bool {
+	return wo.Status < WOStatusCompleted
+}
+
+// TotalCost returns the labor and material costs of the work order
+func (wo *WorkOrder) TotalCost() float64 {
+	return wo.LaborCost + wo.MaterialCost
+}
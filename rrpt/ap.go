@@ -0,0 +1,265 @@
+package rrpt
+
+import (
+	"context"
+	"fmt"
+	"gotable"
+	"rentroll/rlib"
+	"strings"
+	"time"
+)
+
+// APAgingBuckets are the age ranges, in days past the due date, used by the
+// accounts payable aging report.  Bills that are not yet due are Current.
+// The last bucket has no upper limit.
+var APAgingBuckets = []struct {
+	Name string
+	Max  int64 // the bucket holds the ages up to and including Max
+}{
+	{"Current", 0},
+	{"1-30", 30},
+	{"31-60", 60},
+	{"61-90", 90},
+	{"Over 90", -1},
+}
+
+// apAgingBucket returns the index of the bucket for a bill that is days
+// past due
+func apAgingBucket(days int64) int {
+	for i := 0; i < len(APAgingBuckets)-1; i++ {
+		if days <= APAgingBuckets[i].Max {
+			return i
+		}
+	}
+	return len(APAgingBuckets) - 1
+}
+
+// APAgingTable generates the accounts payable aging report: the approved
+// vendor bills dated before ri.D2 that were not paid before ri.D2, with the
+// number of days they are past due as of ri.D2.  It ends with the amount
+// owed in each aging bucket.
+//
+// INPUTS
+//    ctx = db context
+//    ri  = reporter info, ri.D2 is the as-of date
+//
+// RETURNS
+//    the report table
+//-----------------------------------------------------------------------------
+func APAgingTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "APAgingTable"
+
+	// prepare and init some values
+	ri.RptHeaderD1 = false
+	ri.RptHeaderD2 = true
+
+	const (
+		Vendor    = 0
+		VBID      = iota
+		InvoiceNo = iota
+		BillDt    = iota
+		DueDt     = iota
+		DaysPast  = iota
+		Aging     = iota
+		Amount    = iota
+	)
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("Vendor", 25, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Bill", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Invoice", 15, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Bill Date", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Due Date", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Days Past Due", 9, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Aging", 8, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Amount", 10, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	errReturn := func(err error) gotable.Table {
+		rlib.LogAndPrintError(funcname, err)
+		// set errors in section3 and return
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	err := TableReportHeaderBlock(ctx, &tbl, "Accounts Payable Aging", funcname, ri)
+	if err != nil {
+		return errReturn(err)
+	}
+
+	dt := ri.D2
+	m, err := rlib.GetUnpaidVendorBills(ctx, ri.Bid, &dt)
+	if err != nil {
+		return errReturn(err)
+	}
+
+	owed := make([]float64, len(APAgingBuckets))
+	total := float64(0)
+	vnames := map[int64]string{}
+	for i := 0; i < len(m); i++ {
+		name, ok := vnames[m[i].VID]
+		if !ok {
+			v, err := rlib.GetVendor(ctx, m[i].VID)
+			if err != nil {
+				return errReturn(err)
+			}
+			name = v.Name
+			vnames[m[i].VID] = name
+		}
+		days := int64(dt.Sub(m[i].DueDt) / (24 * time.Hour))
+		if days < 0 {
+			days = 0
+		}
+		b := apAgingBucket(days)
+		owed[b] += m[i].Amount
+		total += m[i].Amount
+
+		tbl.AddRow()
+		tbl.Puts(-1, Vendor, name)
+		tbl.Puts(-1, VBID, m[i].IDtoShortString())
+		tbl.Puts(-1, InvoiceNo, m[i].InvoiceNo)
+		tbl.Putd(-1, BillDt, m[i].Dt)
+		tbl.Putd(-1, DueDt, m[i].DueDt)
+		tbl.Puti(-1, DaysPast, days)
+		tbl.Puts(-1, Aging, APAgingBuckets[b].Name)
+		tbl.Putf(-1, Amount, m[i].Amount)
+	}
+
+	//---------------------------------
+	// summary by aging bucket
+	//---------------------------------
+	if len(m) > 0 {
+		tbl.AddLineAfter(len(tbl.Row) - 1)
+	}
+	for b := 0; b < len(APAgingBuckets); b++ {
+		tbl.AddRow()
+		tbl.Puts(-1, Aging, APAgingBuckets[b].Name)
+		tbl.Putf(-1, Amount, owed[b])
+	}
+	tbl.AddRow()
+	tbl.Puts(-1, Vendor, fmt.Sprintf("%d unpaid bills", len(m)))
+	tbl.Puts(-1, Aging, "Total")
+	tbl.Putf(-1, Amount, total)
+
+	tbl.TightenColumns()
+	return tbl
+}
+
+// APAging generates a report
+func APAging(ctx context.Context, ri *ReporterInfo) string {
+	tbl := APAgingTable(ctx, ri)
+	return ReportToString(&tbl, ri)
+}
+
+// Vendor1099Table generates the totals paid in ri.D1 - ri.D2 to each 1099
+// eligible vendor of the business, usually for a calendar year.  Vendors
+// paid rlib.Vendor1099Threshold or more are marked as needing a 1099.
+//
+// INPUTS
+//    ctx = db context
+//    ri  = reporter info, ri.D1 - ri.D2 is the reporting period
+//
+// RETURNS
+//    the report table
+//-----------------------------------------------------------------------------
+func Vendor1099Table(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "Vendor1099Table"
+
+	// prepare and init some values
+	ri.RptHeaderD1 = true
+	ri.RptHeaderD2 = true
+
+	const (
+		Vendor   = 0
+		TaxID    = iota
+		Address  = iota
+		Bills    = iota
+		Paid     = iota
+		Required = iota
+	)
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("Vendor", 25, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Tax ID", 12, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Address", 40, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Bills Paid", 6, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Total Paid", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("1099", 5, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+
+	errReturn := func(err error) gotable.Table {
+		rlib.LogAndPrintError(funcname, err)
+		// set errors in section3 and return
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	err := TableReportHeaderBlock(ctx, &tbl, "Vendor 1099 Totals", funcname, ri)
+	if err != nil {
+		return errReturn(err)
+	}
+
+	m, err := rlib.GetPaidVendorBills(ctx, ri.Bid, &ri.D1, &ri.D2)
+	if err != nil {
+		return errReturn(err)
+	}
+
+	//-------------------------------------------------------------
+	// the bills are ordered by vendor, total each vendor's bills
+	//-------------------------------------------------------------
+	total := float64(0)
+	required := 0
+	for i := 0; i < len(m); {
+		vid := m[i].VID
+		v, err := rlib.GetVendor(ctx, vid)
+		if err != nil {
+			return errReturn(err)
+		}
+		n := int64(0)
+		paid := float64(0)
+		for ; i < len(m) && m[i].VID == vid; i++ {
+			n++
+			paid += m[i].Amount
+		}
+		if !v.Is1099() {
+			continue
+		}
+		var parts []string
+		for _, a := range []string{v.Address, v.Address2, strings.TrimSpace(v.City + " " + v.State + " " + v.PostalCode)} {
+			if a = strings.TrimSpace(a); len(a) > 0 {
+				parts = append(parts, a)
+			}
+		}
+		addr := strings.Join(parts, ", ")
+		total += paid
+
+		tbl.AddRow()
+		tbl.Puts(-1, Vendor, v.Name)
+		tbl.Puts(-1, TaxID, v.TaxID)
+		tbl.Puts(-1, Address, addr)
+		tbl.Puti(-1, Bills, n)
+		tbl.Putf(-1, Paid, paid)
+		if paid >= rlib.Vendor1099Threshold {
+			tbl.Puts(-1, Required, "Yes")
+			required++
+		}
+	}
+
+	if len(tbl.Row) > 0 {
+		tbl.AddLineAfter(len(tbl.Row) - 1)
+	}
+	tbl.AddRow()
+	tbl.Puts(-1, Vendor, fmt.Sprintf("%d 1099s required", required))
+	tbl.Putf(-1, Paid, total)
+
+	tbl.TightenColumns()
+	return tbl
+}
+
+// Vendor1099 generates a report
+func Vendor1099(ctx context.Context, ri *ReporterInfo) string {
+	tbl := Vendor1099Table(ctx, ri)
+	return ReportToString(&tbl, ri)
+}
@@ -0,0 +1,187 @@
+package rrpt
+
+import (
+	"context"
+	"fmt"
+	"gotable"
+	"rentroll/rlib"
+)
+
+// BankReconciliationTable generates the bank reconciliation report for the
+// Depository ri.ID.  If ri.ID is 0 and the business has only one depository,
+// that depository is used.
+//
+// The report is as of the closing date of the most recent bank statement
+// that ends before ri.D2.  It lists the deposits that the bank has not yet
+// cleared (deposits in transit), the bank statement lines in the report
+// period whose match to a deposit has not been confirmed, and compares the
+// adjusted bank balance with the adjusted balance of the depository's GL
+// account.
+//
+// INPUTS
+//    ctx = db context
+//    ri  = reporter info, ri.ID is the DEPID
+//
+// RETURNS
+//    the report table
+//-----------------------------------------------------------------------------
+func BankReconciliationTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "BankReconciliationTable"
+	var (
+		err error
+		dep rlib.Depository
+	)
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("Item", 25, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Date", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("ID", 12, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Description", 40, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Amount", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	errReturn := func(err error) gotable.Table {
+		rlib.LogAndPrintError(funcname, err)
+		// set errors in section3 and return
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	//------------------------------------------------
+	// Which depository...
+	//------------------------------------------------
+	if ri.ID > 0 {
+		dep, err = rlib.GetDepository(ctx, ri.ID)
+		if err != nil {
+			return errReturn(err)
+		}
+	} else {
+		m, err := rlib.GetAllDepositories(ctx, ri.Bid)
+		if err != nil {
+			return errReturn(err)
+		}
+		if len(m) == 1 {
+			dep = m[0]
+		}
+	}
+
+	ri.RptHeaderD2 = true
+	err = TableReportHeaderBlock(ctx, &tbl, "Bank Reconciliation", funcname, ri)
+	if err != nil {
+		return errReturn(err)
+	}
+	if dep.DEPID == 0 || dep.BID != ri.Bid {
+		return errReturn(fmt.Errorf("please select a depository"))
+	}
+
+	//------------------------------------------------
+	// Find the statement to reconcile against
+	//------------------------------------------------
+	bsl, err := rlib.GetBankStatementsByDEPID(ctx, ri.Bid, dep.DEPID)
+	if err != nil {
+		return errReturn(err)
+	}
+	var bs rlib.BankStatement
+	for i := 0; i < len(bsl); i++ {
+		if bsl[i].DtStop.Before(ri.D2) {
+			bs = bsl[i]
+			break
+		}
+	}
+	dtStmt := ri.D2.AddDate(0, 0, -1)
+	if bs.BSID > 0 {
+		dtStmt = bs.DtStop
+	}
+	d2 := dtStmt.AddDate(0, 0, 1) // up to but not including
+
+	//------------------------------------------------
+	// Deposits in transit
+	//------------------------------------------------
+	d1 := rlib.TIME0
+	m, err := rlib.GetDepositsByDEPIDInRange(ctx, ri.Bid, dep.DEPID, &d1, &d2)
+	if err != nil {
+		return errReturn(err)
+	}
+	inTransit := float64(0)
+	for i := 0; i < len(m); i++ {
+		if m[i].FLAGS&rlib.FlDepositCleared != 0 {
+			continue
+		}
+		tbl.AddRow()
+		tbl.Puts(-1, 0, "Uncleared Deposit")
+		tbl.Putd(-1, 1, m[i].Dt)
+		tbl.Puts(-1, 2, rlib.IDtoShortString("D", m[i].DID))
+		tbl.Putf(-1, 4, m[i].Amount)
+		inTransit += m[i].Amount
+	}
+
+	//------------------------------------------------
+	// Bank activity not matched to any deposit.  A proposed
+	// match is unmatched until it is confirmed, its deposit is
+	// still in transit.
+	//------------------------------------------------
+	n, err := rlib.GetBankStatementLinesInRange(ctx, ri.Bid, dep.DEPID, &ri.D1, &d2)
+	if err != nil {
+		return errReturn(err)
+	}
+	unmatched := float64(0)
+	for i := 0; i < len(n); i++ {
+		if n[i].DID > 0 && n[i].FLAGS&rlib.FlBankLineConfirmed != 0 {
+			continue // its deposit has cleared
+		}
+		tbl.AddRow()
+		tbl.Puts(-1, 0, "Unmatched Bank Line")
+		tbl.Putd(-1, 1, n[i].Dt)
+		tbl.Puts(-1, 2, n[i].FITID)
+		tbl.Puts(-1, 3, n[i].Description)
+		tbl.Putf(-1, 4, n[i].Amount)
+		unmatched += n[i].Amount
+	}
+
+	//------------------------------------------------
+	// Balances
+	//------------------------------------------------
+	var gl rlib.GLAccount
+	glBal := float64(0)
+	if dep.LID > 0 {
+		if gl, err = rlib.GetLedger(ctx, dep.LID); err != nil {
+			return errReturn(err)
+		}
+		if glBal, err = rlib.GetAccountBalance(ctx, ri.Bid, dep.LID, &d2); err != nil {
+			return errReturn(err)
+		}
+	}
+
+	if len(tbl.Row) > 0 {
+		tbl.AddLineAfter(len(tbl.Row) - 1)
+	}
+	bankBal := bs.ClosingBalance
+	summary := func(item, descr string, amt float64) {
+		tbl.AddRow()
+		tbl.Puts(-1, 0, item)
+		tbl.Putd(-1, 1, dtStmt)
+		tbl.Puts(-1, 3, descr)
+		tbl.Putf(-1, 4, amt)
+	}
+	if bs.FLAGS&rlib.FlBankStmtBalance != 0 {
+		summary("Balance Per Bank", bs.FileName, bankBal)
+	} else {
+		summary("Balance Per Bank", "no bank statement balance available", bankBal)
+	}
+	summary("Deposits In Transit", "", inTransit)
+	summary("Adjusted Bank Balance", "", bankBal+inTransit)
+	summary("Balance Per Books", fmt.Sprintf("%s (%s)", gl.GLNumber, gl.Name), glBal)
+	summary("Unmatched Bank Activity", "", unmatched)
+	summary("Adjusted Book Balance", "", glBal+unmatched)
+	summary("Difference", "", rlib.RoundToCent(bankBal+inTransit-glBal-unmatched))
+
+	tbl.TightenColumns()
+	return tbl
+}
+
+// BankReconciliationReport generates the bank reconciliation report as a string
+func BankReconciliationReport(ctx context.Context, ri *ReporterInfo) string {
+	tbl := BankReconciliationTable(ctx, ri)
+	return ReportToString(&tbl, ri)
+}
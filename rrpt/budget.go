@@ -0,0 +1,208 @@
+package rrpt
+
+import (
+	"context"
+	"fmt"
+	"gotable"
+	"math"
+	"rentroll/rlib"
+	"strings"
+	"time"
+)
+
+// The budget variance report compares the budget of each income and expense
+// account with its activity, month by month.  It is built on a finStmt whose
+// value columns are the budget and the actual of each month, so the accounts
+// roll up through their parents the same way as on the income statement.
+
+// incomeStmtSign returns the sign of the income statement section of
+// account type t, 0 if t is not on the income statement
+func incomeStmtSign(t string) float64 {
+	for i := 0; i < len(incomeStmtSections); i++ {
+		for k := 0; k < len(incomeStmtSections[i].Types); k++ {
+			if t == incomeStmtSections[i].Types[k] {
+				return incomeStmtSections[i].Sign
+			}
+		}
+	}
+	return 0
+}
+
+// putBudgetRow adds a row to tbl with the budget b, the actual a and the
+// variance between them
+func putBudgetRow(tbl *gotable.Table, glno, name, month string, b, a float64) {
+	b = rlib.RoundToCent(b)
+	a = rlib.RoundToCent(a)
+	tbl.AddRow()
+	tbl.Puts(-1, 0, glno)
+	tbl.Puts(-1, 1, name)
+	tbl.Puts(-1, 2, month)
+	tbl.Putf(-1, 3, b)
+	tbl.Putf(-1, 4, a)
+	tbl.Putf(-1, 5, rlib.RoundToCent(a-b))
+	if b != 0 {
+		tbl.Puts(-1, 6, fmt.Sprintf("%.1f%%", 100*(a-b)/math.Abs(b)))
+	}
+}
+
+// putBudgetRows adds a row per month with the budget and actual values v
+// of an account, multiplied by sign, followed by their total
+func putBudgetRows(tbl *gotable.Table, mon []time.Time, glno, name string, v []float64, sign float64) {
+	var tb, ta float64
+	for i := 0; i < len(mon); i++ {
+		putBudgetRow(tbl, glno, name, mon[i].Format("Jan 2006"), sign*v[2*i], sign*v[2*i+1])
+		tb += sign * v[2*i]
+		ta += sign * v[2*i+1]
+	}
+	putBudgetRow(tbl, glno, name, "Total", tb, ta)
+	tbl.AddLineAfter(len(tbl.Row) - 1)
+}
+
+// addBudgetAccounts adds the rows of each account of m and their children
+// in section s, names indented by depth
+func addBudgetAccounts(tbl *gotable.Table, fs *finStmt, s *finStmtSection, mon []time.Time, m []rlib.GLAccount, depth int) {
+	for i := 0; i < len(m); i++ {
+		t := fs.total(s, &m[i])
+		if isZero(t) {
+			continue
+		}
+		putBudgetRows(tbl, mon, m[i].GLNumber, strings.Repeat("   ", depth)+m[i].Name, t, s.Sign)
+		addBudgetAccounts(tbl, fs, s, mon, fs.children(s, m[i].LID), depth+1)
+	}
+}
+
+// BudgetVarianceTable generates the budget variance report of the business:
+// for each month holding ri.D1 - ri.D2, the budget and the activity of each
+// income and expense account, and the variance between them.  Parent
+// accounts include their children.  The variance is actual minus budget,
+// the percent is relative to the budget.  The journals closing a fiscal
+// year are not included.
+//
+// INPUTS
+//    ctx = db context
+//    ri  = reporter info, ri.D1 - ri.D2 is the period
+//
+// RETURNS
+//    the report table
+//-----------------------------------------------------------------------------
+func BudgetVarianceTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "BudgetVarianceTable"
+
+	// prepare and init some values
+	ri.RptHeaderD1 = true
+	ri.RptHeaderD2 = true
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("GLNumber", 8, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Account", 40, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Month", 8, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Budget", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Actual", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Variance", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Variance %", 10, gotable.CELLSTRING, gotable.COLJUSTIFYRIGHT)
+
+	errReturn := func(err error) gotable.Table {
+		rlib.LogAndPrintError(funcname, err)
+		// set errors in section3 and return
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	err := TableReportHeaderBlock(ctx, &tbl, "Budget Variance", funcname, ri)
+	if err != nil {
+		return errReturn(err)
+	}
+
+	//----------------------------------------------------
+	// the months of the report, the budgets and the
+	// closing journals in them
+	//----------------------------------------------------
+	var mon []time.Time
+	for dt := time.Date(ri.D1.Year(), ri.D1.Month(), 1, 0, 0, 0, 0, time.UTC); dt.Before(ri.D2); dt = dt.AddDate(0, 1, 0) {
+		mon = append(mon, dt)
+	}
+	if len(mon) == 0 {
+		tbl.TightenColumns()
+		return tbl
+	}
+	stop := mon[len(mon)-1].AddDate(0, 1, 0)
+	bgt, err := rlib.GetBudgetsInRange(ctx, ri.Bid, &mon[0], &stop)
+	if err != nil {
+		return errReturn(err)
+	}
+	budget := map[int64][]float64{}
+	for i := 0; i < len(bgt); i++ {
+		if _, ok := budget[bgt[i].LID]; !ok {
+			budget[bgt[i].LID] = make([]float64, len(mon))
+		}
+		if k := rlib.BudgetMonth(&mon[0], &bgt[i].Dt); k >= 0 && k < len(mon) {
+			budget[bgt[i].LID][k] += bgt[i].Amount
+		}
+	}
+	var closing []map[int64]float64
+	for i := 0; i < len(mon); i++ {
+		d2 := mon[i].AddDate(0, 1, 0)
+		c, err := rlib.GetFiscalYearClosingAmounts(ctx, ri.Bid, &mon[i], &d2)
+		if err != nil {
+			return errReturn(err)
+		}
+		closing = append(closing, c)
+	}
+
+	// The budget is entered with the sign the account has on the income
+	// statement.  It is stored in the finStmt with the ledger sign, like the
+	// activity.
+	fs, err := newFinStmt(ri.Bid, 2*len(mon), 3, func(lid int64) ([]float64, error) {
+		v := make([]float64, 2*len(mon))
+		sign := incomeStmtSign(rlib.RRdb.BizTypes[ri.Bid].GLAccounts[lid].AcctType)
+		if sign == 0 {
+			return v, nil
+		}
+		for i := 0; i < len(mon); i++ {
+			d2 := mon[i].AddDate(0, 1, 0)
+			a, err := rlib.GetAccountActivity(ctx, ri.Bid, lid, &mon[i], &d2)
+			if err != nil {
+				return v, err
+			}
+			if b, ok := budget[lid]; ok {
+				v[2*i] = sign * b[i]
+			}
+			v[2*i+1] = a - closing[i][lid]
+		}
+		return v, nil
+	})
+	if err != nil {
+		return errReturn(err)
+	}
+
+	net := make([]float64, 2*len(mon))
+	for k := 0; k < len(incomeStmtSections); k++ {
+		s := &incomeStmtSections[k]
+		tbl.AddRow()
+		tbl.Puts(-1, 1, s.Name)
+		m := fs.children(s, 0)
+		addBudgetAccounts(&tbl, &fs, s, mon, m, 1)
+		t := make([]float64, 2*len(mon))
+		for i := 0; i < len(m); i++ {
+			v := fs.total(s, &m[i])
+			for j := 0; j < len(t); j++ {
+				t[j] += s.Sign * v[j]
+				net[j] += v[j]
+			}
+		}
+		putBudgetRows(&tbl, mon, "", "Total "+s.Name, t, 1)
+		tbl.AddRow()
+	}
+	putBudgetRows(&tbl, mon, "", "Net Income", net, -1)
+
+	tbl.TightenColumns()
+	return tbl
+}
+
+// BudgetVariance generates a report
+func BudgetVariance(ctx context.Context, ri *ReporterInfo) string {
+	tbl := BudgetVarianceTable(ctx, ri)
+	return ReportToString(&tbl, ri)
+}
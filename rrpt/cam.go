@@ -0,0 +1,134 @@
+package rrpt
+
+import (
+	"context"
+	"fmt"
+	"gotable"
+	"rentroll/rlib"
+	"time"
+)
+
+// CAMStatementPDFProps holds the override properties needed for the CAM
+// reconciliation statement.  It is a letter, like the security deposit
+// disposition.
+var CAMStatementPDFProps = SecDepLetterPDFProps
+
+// CAMStatementTable generates the tenant's statement for the CAM
+// reconciliation ri.ID.  It lists the recoverable operating expenses of the
+// period by GL account with the base year amounts, the tenant's pro-rata
+// share, the estimated charges already billed and the amount due or
+// credited.  A reconciliation that has not been posted is marked as a draft.
+//
+// INPUTS
+//    ctx = db context
+//    ri  = reporter info, ri.ID is the CAMRID
+//
+// RETURNS
+//    the report table
+//-----------------------------------------------------------------------------
+func CAMStatementTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "CAMStatementTable"
+	var (
+		err error
+		t   rlib.Transactant
+	)
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("Operating Expense", 40, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Expenses", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Base Year", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Your Share", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	errReturn := func(err error) gotable.Table {
+		rlib.LogAndPrintError(funcname, err)
+		// set errors in section3 and return
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	c, err := rlib.GetCAMReconciliation(ctx, ri.ID)
+	if err != nil {
+		return errReturn(err)
+	}
+	if c.CAMRID == 0 || c.BID != ri.Bid {
+		return errReturn(fmt.Errorf("CAM reconciliation %d not found", ri.ID))
+	}
+	if c.L, err = rlib.GetCAMReconLines(ctx, c.CAMRID); err != nil {
+		return errReturn(err)
+	}
+	if c.TCID > 0 {
+		if err = rlib.GetTransactant(ctx, c.TCID, &t); err != nil {
+			return errReturn(err)
+		}
+	}
+
+	rn := "Operating Expense Reconciliation"
+	if c.FLAGS&rlib.FlCAMPosted == 0 {
+		rn += " (DRAFT)"
+	}
+	err = TableReportHeaderBlock(ctx, &tbl, rn, funcname, ri)
+	if err != nil {
+		return errReturn(err)
+	}
+
+	last := c.Dt2.AddDate(0, 0, -1)
+	s1 := time.Now().Format(rlib.RRDATERECEIPTFMT) + "\n\n"
+	s1 += t.GetFullTransactantName() + "\n\n"
+	s1 += fmt.Sprintf("Re: Rental Agreement %s, operating expenses %s through %s\n\n",
+		rlib.IDtoShortString("RA", c.RAID), c.Dt1.Format(rlib.RRDATERECEIPTFMT), last.Format(rlib.RRDATERECEIPTFMT))
+	s1 += fmt.Sprintf("Your share of the operating expenses is based on %d of %d square feet", c.SqFt, c.TotalSqFt)
+	if c.Occupancy < 1 {
+		s1 += fmt.Sprintf(" for %.1f%% of the period", c.Occupancy*100)
+	}
+	s1 += fmt.Sprintf(", or %.4f%% of the expenses.\n", c.ProRata()*100)
+	tbl.SetSection1(s1)
+
+	for i := 0; i < len(c.L); i++ {
+		tbl.AddRow()
+		tbl.Puts(-1, 0, rlib.RRdb.BizTypes[c.BID].GLAccounts[c.L[i].LID].Name)
+		tbl.Putf(-1, 1, c.L[i].Expenses)
+		tbl.Putf(-1, 2, c.L[i].BaseExpenses)
+		tbl.Putf(-1, 3, c.L[i].Share)
+	}
+	tbl.AddLineAfter(len(tbl.Row) - 1)
+	tbl.AddRow()
+	tbl.Puts(-1, 0, "Total")
+	tbl.Putf(-1, 1, c.Expenses)
+	tbl.Putf(-1, 2, c.BaseExpenses)
+	tbl.Putf(-1, 3, c.Share)
+
+	tbl.AddRow()
+	tbl.AddRow()
+	if c.ExpenseAdjustmentType != rlib.ExpAdjPassThrough && c.ExpensesStop > 0 {
+		tbl.Puts(-1, 0, "Your share, limited to the expense stop")
+	} else {
+		tbl.Puts(-1, 0, "Your share")
+	}
+	tbl.Putf(-1, 3, c.Share)
+	tbl.AddRow()
+	tbl.Puts(-1, 0, "Estimated charges billed")
+	tbl.Putf(-1, 3, c.Estimated)
+	tbl.AddRow()
+	if c.TrueUp >= 0 {
+		tbl.Puts(-1, 0, "Balance due from you")
+		tbl.Putf(-1, 3, c.TrueUp)
+	} else {
+		tbl.Puts(-1, 0, "Credit to your account")
+		tbl.Putf(-1, 3, -c.TrueUp)
+	}
+
+	tbl.AddRow()
+	tbl.AddRow()
+	tbl.Puts(-1, 0, ri.Xbiz.P.Name)
+
+	tbl.TightenColumns()
+	return tbl
+}
+
+// CAMStatement generates a report
+func CAMStatement(ctx context.Context, ri *ReporterInfo) string {
+	tbl := CAMStatementTable(ctx, ri)
+	return ReportToString(&tbl, ri)
+}
@@ -0,0 +1,406 @@
+package rrpt
+
+import (
+	"context"
+	"fmt"
+	"gotable"
+	"rentroll/rlib"
+	"sort"
+	"strings"
+	"time"
+)
+
+// A financial statement lists the GL accounts of some account types in
+// sections, each account under its parent (GLAccount.PLID) with the value
+// of the account and all its children.  The accounts whose value is 0 in
+// every column are left out.
+
+// finStmtSection is a section of a financial statement
+type finStmtSection struct {
+	Name  string   // section title
+	Types []string // account types in the section
+	Sign  float64  // 1 for accounts with a debit balance, -1 for a credit balance
+}
+
+// incomeStmtSections are the sections of the income statement
+var incomeStmtSections = []finStmtSection{
+	{"Income", []string{"Income", "Income Offset", "Other Income"}, -1},
+	{"Expenses", []string{"Expense", "Other Expense"}, 1},
+}
+
+// balanceSheetSections are the sections of the balance sheet
+var balanceSheetSections = []finStmtSection{
+	{"Assets", []string{"Asset", rlib.AccountsReceivable, "Cash"}, 1},
+	{"Liabilities", []string{"Liabilities", rlib.LiabilitySecDep}, -1},
+	{"Equity", []string{"Equity"}, -1},
+}
+
+// finStmt holds the values of the GL accounts of a business for each column
+// of a financial statement
+type finStmt struct {
+	bid   int64
+	ncols int                 // number of value columns
+	first int                 // table column of the first value column
+	own   map[int64][]float64 // the value of each account without its children
+}
+
+// fsValueFunc returns the value of GL account lid, without its children,
+// for each column of a financial statement
+type fsValueFunc func(lid int64) ([]float64, error)
+
+// newFinStmt returns the financial statement of business bid with ncols
+// value columns starting at table column first.  f is called for each
+// account.
+func newFinStmt(bid int64, ncols, first int, f fsValueFunc) (finStmt, error) {
+	fs := finStmt{bid: bid, ncols: ncols, first: first, own: map[int64][]float64{}}
+	for lid := range rlib.RRdb.BizTypes[bid].GLAccounts {
+		v, err := f(lid)
+		if err != nil {
+			return fs, err
+		}
+		fs.own[lid] = v
+	}
+	return fs, nil
+}
+
+// inSection returns true if GL account lid is of one of the types of s
+func (fs *finStmt) inSection(s *finStmtSection, lid int64) bool {
+	gl, ok := rlib.RRdb.BizTypes[fs.bid].GLAccounts[lid]
+	if !ok {
+		return false
+	}
+	for i := 0; i < len(s.Types); i++ {
+		if gl.AcctType == s.Types[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// children returns the accounts of section s whose parent is plid, by
+// GLNumber.  With plid 0 it returns the accounts of s whose parent is not in
+// s.
+func (fs *finStmt) children(s *finStmtSection, plid int64) []rlib.GLAccount {
+	var m []rlib.GLAccount
+	for _, gl := range rlib.RRdb.BizTypes[fs.bid].GLAccounts {
+		if !fs.inSection(s, gl.LID) {
+			continue
+		}
+		if gl.PLID == plid || (plid == 0 && !fs.inSection(s, gl.PLID)) {
+			m = append(m, gl)
+		}
+	}
+	sort.Slice(m, func(i, j int) bool {
+		if m[i].GLNumber == m[j].GLNumber {
+			return m[i].LID < m[j].LID
+		}
+		return m[i].GLNumber < m[j].GLNumber
+	})
+	return m
+}
+
+// total returns the value of account gl and its children in section s
+func (fs *finStmt) total(s *finStmtSection, gl *rlib.GLAccount) []float64 {
+	t := make([]float64, fs.ncols)
+	for i := 0; i < fs.ncols && i < len(fs.own[gl.LID]); i++ {
+		t[i] = fs.own[gl.LID][i]
+	}
+	m := fs.children(s, gl.LID)
+	for i := 0; i < len(m); i++ {
+		c := fs.total(s, &m[i])
+		for k := 0; k < fs.ncols; k++ {
+			t[k] += c[k]
+		}
+	}
+	return t
+}
+
+// isZero returns true if all the values of v round to 0
+func isZero(v []float64) bool {
+	for i := 0; i < len(v); i++ {
+		if rlib.RoundToCent(v[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// putValues puts the values v, multiplied by sign, in the value columns of
+// the last row of tbl
+func (fs *finStmt) putValues(tbl *gotable.Table, v []float64, sign float64) {
+	for i := 0; i < fs.ncols; i++ {
+		tbl.Putf(-1, fs.first+i, rlib.RoundToCent(sign*v[i]))
+	}
+}
+
+// addAccounts adds a row for each account of m and their children in
+// section s, names indented by depth
+func (fs *finStmt) addAccounts(tbl *gotable.Table, s *finStmtSection, m []rlib.GLAccount, depth int) {
+	for i := 0; i < len(m); i++ {
+		t := fs.total(s, &m[i])
+		if isZero(t) {
+			continue
+		}
+		tbl.AddRow()
+		tbl.Puts(-1, 0, m[i].GLNumber)
+		tbl.Puts(-1, 1, strings.Repeat("   ", depth)+m[i].Name)
+		fs.putValues(tbl, t, s.Sign)
+		fs.addAccounts(tbl, s, fs.children(s, m[i].LID), depth+1)
+	}
+}
+
+// addSection adds the accounts of section s to tbl, followed by their
+// total.  It returns the total, with the sign of s applied.
+func (fs *finStmt) addSection(tbl *gotable.Table, s *finStmtSection) []float64 {
+	tbl.AddRow()
+	tbl.Puts(-1, 1, s.Name)
+	m := fs.children(s, 0)
+	fs.addAccounts(tbl, s, m, 1)
+	t := make([]float64, fs.ncols)
+	for i := 0; i < len(m); i++ {
+		v := fs.total(s, &m[i])
+		for k := 0; k < fs.ncols; k++ {
+			t[k] += s.Sign * v[k]
+		}
+	}
+	tbl.AddLineAfter(len(tbl.Row) - 1)
+	tbl.AddRow()
+	tbl.Puts(-1, 1, "Total "+s.Name)
+	fs.putValues(tbl, t, 1)
+	tbl.AddRow()
+	return t
+}
+
+// fiscalYearStart returns the first day of the fiscal year of business bid
+// that holds dt
+func fiscalYearStart(ctx context.Context, bid int64, dt *time.Time) (time.Time, error) {
+	p, err := rlib.GetFiscalYearPolicy(ctx, bid, "general")
+	if err != nil {
+		return *dt, err
+	}
+	d1, _ := p.YearContaining(dt)
+	return d1, nil
+}
+
+// IncomeStatementTable generates the income statement of the business:
+// the activity of the income and expense accounts for ri.D1 - ri.D2, for
+// the fiscal year to date, and for the same two periods of the prior year.
+// The journals closing a fiscal year are not included.
+//
+// INPUTS
+//    ctx = db context
+//    ri  = reporter info, ri.D1 - ri.D2 is the period
+//
+// RETURNS
+//    the report table
+//-----------------------------------------------------------------------------
+func IncomeStatementTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "IncomeStatementTable"
+
+	// prepare and init some values
+	ri.RptHeaderD1 = true
+	ri.RptHeaderD2 = true
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("GLNumber", 8, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Account", 40, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Period", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Year To Date", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Prior Year Period", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Prior Year To Date", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	errReturn := func(err error) gotable.Table {
+		rlib.LogAndPrintError(funcname, err)
+		// set errors in section3 and return
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	err := TableReportHeaderBlock(ctx, &tbl, "Income Statement", funcname, ri)
+	if err != nil {
+		return errReturn(err)
+	}
+
+	//----------------------------------------------------
+	// the period, the year to date, and both of them a
+	// year earlier
+	//----------------------------------------------------
+	last := ri.D2.AddDate(0, 0, -1)
+	ytd, err := fiscalYearStart(ctx, ri.Bid, &last)
+	if err != nil {
+		return errReturn(err)
+	}
+	dt := [][2]time.Time{
+		{ri.D1, ri.D2},
+		{ytd, ri.D2},
+		{ri.D1.AddDate(-1, 0, 0), ri.D2.AddDate(-1, 0, 0)},
+		{ytd.AddDate(-1, 0, 0), ri.D2.AddDate(-1, 0, 0)},
+	}
+	var closing []map[int64]float64
+	for i := 0; i < len(dt); i++ {
+		c, err := rlib.GetFiscalYearClosingAmounts(ctx, ri.Bid, &dt[i][0], &dt[i][1])
+		if err != nil {
+			return errReturn(err)
+		}
+		closing = append(closing, c)
+	}
+
+	fs, err := newFinStmt(ri.Bid, len(dt), 2, func(lid int64) ([]float64, error) {
+		v := make([]float64, len(dt))
+		if !rlib.IsIncomeStatementAcctType(rlib.RRdb.BizTypes[ri.Bid].GLAccounts[lid].AcctType) {
+			return v, nil
+		}
+		for i := 0; i < len(dt); i++ {
+			a, err := rlib.GetAccountActivity(ctx, ri.Bid, lid, &dt[i][0], &dt[i][1])
+			if err != nil {
+				return v, err
+			}
+			v[i] = a - closing[i][lid]
+		}
+		return v, nil
+	})
+	if err != nil {
+		return errReturn(err)
+	}
+
+	income := fs.addSection(&tbl, &incomeStmtSections[0])
+	expenses := fs.addSection(&tbl, &incomeStmtSections[1])
+	tbl.AddRow()
+	tbl.Puts(-1, 1, "Net Income")
+	for i := 0; i < len(dt); i++ {
+		tbl.Putf(-1, 2+i, rlib.RoundToCent(income[i]-expenses[i]))
+	}
+
+	tbl.TightenColumns()
+	return tbl
+}
+
+// IncomeStatement generates a report
+func IncomeStatement(ctx context.Context, ri *ReporterInfo) string {
+	tbl := IncomeStatementTable(ctx, ri)
+	return ReportToString(&tbl, ri)
+}
+
+// BalanceSheetTable generates the balance sheet of the business: the
+// balances of the asset, liability and equity accounts on ri.D2 and a year
+// earlier.  The income and expense accounts not yet closed into retained
+// earnings are shown as a single line of equity.
+//
+// INPUTS
+//    ctx = db context
+//    ri  = reporter info, ri.D2 is the as-of date
+//
+// RETURNS
+//    the report table
+//-----------------------------------------------------------------------------
+func BalanceSheetTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "BalanceSheetTable"
+
+	// prepare and init some values
+	ri.RptHeaderD1 = false
+	ri.RptHeaderD2 = true
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("GLNumber", 8, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Account", 40, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Balance", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Prior Year", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	errReturn := func(err error) gotable.Table {
+		rlib.LogAndPrintError(funcname, err)
+		// set errors in section3 and return
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	err := TableReportHeaderBlock(ctx, &tbl, "Balance Sheet", funcname, ri)
+	if err != nil {
+		return errReturn(err)
+	}
+
+	dt := []time.Time{ri.D2, ri.D2.AddDate(-1, 0, 0)}
+	net := make([]float64, len(dt)) // income and expense balances, not yet closed
+	fs, err := newFinStmt(ri.Bid, len(dt), 2, func(lid int64) ([]float64, error) {
+		v := make([]float64, len(dt))
+		c, err := rlib.GetGLAccountChildAccts(ctx, ri.Bid, lid)
+		if err != nil {
+			return v, err
+		}
+		for i := 0; i < len(dt); i++ {
+			b, err := rlib.GetAccountBalance(ctx, ri.Bid, lid, &dt[i])
+			if err != nil {
+				return v, err
+			}
+			for k := 0; k < len(c); k++ { // the balance includes the children
+				x, err := rlib.GetAccountBalance(ctx, ri.Bid, c[k], &dt[i])
+				if err != nil {
+					return v, err
+				}
+				b -= x
+			}
+			v[i] = b
+			if rlib.IsIncomeStatementAcctType(rlib.RRdb.BizTypes[ri.Bid].GLAccounts[lid].AcctType) {
+				net[i] -= b
+			}
+		}
+		return v, nil
+	})
+	if err != nil {
+		return errReturn(err)
+	}
+
+	assets := fs.addSection(&tbl, &balanceSheetSections[0])
+	liabilities := fs.addSection(&tbl, &balanceSheetSections[1])
+
+	//----------------------------------------------------
+	// equity, with the earnings not yet closed
+	//----------------------------------------------------
+	s := &balanceSheetSections[2]
+	tbl.AddRow()
+	tbl.Puts(-1, 1, s.Name)
+	m := fs.children(s, 0)
+	fs.addAccounts(&tbl, s, m, 1)
+	equity := make([]float64, len(dt))
+	copy(equity, net)
+	for i := 0; i < len(m); i++ {
+		v := fs.total(s, &m[i])
+		for k := 0; k < len(dt); k++ {
+			equity[k] += s.Sign * v[k]
+		}
+	}
+	tbl.AddRow()
+	tbl.Puts(-1, 1, "   Net Income (not closed)")
+	fs.putValues(&tbl, net, 1)
+	tbl.AddLineAfter(len(tbl.Row) - 1)
+	tbl.AddRow()
+	tbl.Puts(-1, 1, "Total "+s.Name)
+	fs.putValues(&tbl, equity, 1)
+	tbl.AddRow()
+
+	tbl.AddRow()
+	tbl.Puts(-1, 1, "Total Liabilities and Equity")
+	var msg []string
+	for i := 0; i < len(dt); i++ {
+		t := rlib.RoundToCent(liabilities[i] + equity[i])
+		tbl.Putf(-1, 2+i, t)
+		if d := rlib.RoundToCent(assets[i] - t); d != 0 {
+			msg = append(msg, fmt.Sprintf("On %s the assets differ from the liabilities and equity by %.2f", dt[i].Format(rlib.RRDATEFMT3), d))
+		}
+	}
+	if len(msg) > 0 {
+		tbl.SetSection3(strings.Join(msg, "\n"))
+	}
+
+	tbl.TightenColumns()
+	return tbl
+}
+
+// BalanceSheet generates a report
+func BalanceSheet(ctx context.Context, ri *ReporterInfo) string {
+	tbl := BalanceSheetTable(ctx, ri)
+	return ReportToString(&tbl, ri)
+}
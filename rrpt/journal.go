@@ -410,6 +410,65 @@ func textPrintJournalUnassociated(ctx context.Context, tbl *gotable.Table, xbiz
 	tbl.AddRow() // separater line
 }
 
+func textPrintJournalVendor(ctx context.Context, tbl *gotable.Table, xbiz *rlib.XBusiness, j *rlib.Journal) {
+	const funcname = "textPrintJournalVendor"
+
+	vb, err := rlib.GetVendorBill(ctx, j.ID) // j.ID is the VBID for vendor bills and payments
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		return
+	}
+	v, err := rlib.GetVendor(ctx, vb.VID)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		return
+	}
+	s := "Vendor Bill"
+	if j.Type == rlib.JNLTYPEVPMT {
+		s = "Vendor Payment"
+	}
+	tbl.AddRow()
+	tbl.Puts(-1, JournalID, j.IDtoShortString())
+	tbl.Puts(-1, JDescr, fmt.Sprintf("%s %s: %s %s", s, vb.IDtoShortString(), v.Name, j.Comment))
+	for i := 0; i < len(j.JA); i++ {
+		var r rlib.Rentable
+		if j.JA[i].RID > 0 {
+			if err = rlib.GetRentableByID(ctx, j.JA[i].RID, &r); err != nil {
+				rlib.LogAndPrintError(funcname, err)
+				continue
+			}
+		}
+		err = processAcctRuleAmount(ctx, tbl, xbiz, j.JA[i].RID, j.Dt, j.JA[i].AcctRule, 0, &r, j.JA[i].Amount, false)
+		if err != nil {
+			rlib.LogAndPrintError(funcname, err)
+			continue
+		}
+	}
+	tbl.AddRow() // separater line
+}
+
+func textPrintJournalClose(ctx context.Context, tbl *gotable.Table, xbiz *rlib.XBusiness, j *rlib.Journal) {
+	const funcname = "textPrintJournalClose"
+
+	fyc, err := rlib.GetFiscalYearClose(ctx, j.ID) // j.ID is the FYCID for closing and reopening journals
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		return
+	}
+	tbl.AddRow()
+	tbl.Puts(-1, JournalID, j.IDtoShortString())
+	tbl.Puts(-1, JDescr, fmt.Sprintf("Fiscal Year Close %s: %s", fyc.IDtoShortString(), j.Comment))
+	for i := 0; i < len(j.JA); i++ {
+		var r rlib.Rentable
+		err = processAcctRuleAmount(ctx, tbl, xbiz, j.JA[i].RID, j.Dt, j.JA[i].AcctRule, 0, &r, j.JA[i].Amount, false)
+		if err != nil {
+			rlib.LogAndPrintError(funcname, err)
+			continue
+		}
+	}
+	tbl.AddRow() // separater line
+}
+
 func textPrintJournalXfer(tbl *gotable.Table, ri *ReporterInfo, jctx *jprintctx, j *rlib.Journal) {
 	tbl.AddRow()
 	tbl.Puts(-1, JournalID, j.IDtoShortString())
@@ -477,6 +536,10 @@ func textPrintJournalEntry(ctx context.Context, tbl *gotable.Table, ri *Reporter
 		textPrintJournalReceipt(ctx, tbl, ri, jctx, j, &rcpt)
 	case rlib.JNLTYPEXFER:
 		textPrintJournalXfer(tbl, ri, jctx, j)
+	case rlib.JNLTYPEVBIL, rlib.JNLTYPEVPMT:
+		textPrintJournalVendor(ctx, tbl, ri.Xbiz, j)
+	case rlib.JNLTYPECLOS:
+		textPrintJournalClose(ctx, tbl, ri.Xbiz, j)
 	case rlib.JNLTYPEASMT:
 		a, err := rlib.GetAssessment(ctx, j.ID) // TODO(Steve): ignore error?
 		if err != nil {
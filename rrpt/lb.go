@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"gotable"
 	"rentroll/rlib"
+	"time"
 )
 
-// LedgerBalanceReportTable builds a table of trial balance information
+// LedgerBalanceReportTable builds a table of trial balance information.  If
+// a fiscal year closed before ri.D2 the table also shows the balances
+// before its closing journal, the pre-close trial balance.
 func LedgerBalanceReportTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
 	const funcname = "LedgerBalanceReportTable"
 	var (
@@ -35,6 +38,18 @@ func LedgerBalanceReportTable(ctx context.Context, ri *ReporterInfo) gotable.Tab
 		return tbl
 	}
 
+	closing, found, err := getYearClosingAmounts(ctx, bid, &ri.D2)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+	sum := []int{3}
+	if found {
+		tbl.AddColumn("Pre-Close Balance", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+		sum = append(sum, 4)
+	}
+
 	for _, acct := range rlib.RRdb.BizTypes[bid].GLAccounts {
 		tbl.AddRow()
 		tbl.Puts(-1, 0, acct.GLNumber)
@@ -47,6 +62,9 @@ func LedgerBalanceReportTable(ctx context.Context, ri *ReporterInfo) gotable.Tab
 				return tbl
 			}
 			tbl.Putf(-1, 3, b)
+			if found {
+				tbl.Putf(-1, 4, b-closing[acct.LID])
+			}
 		} else {
 			b, err := rlib.GetAccountBalance(ctx, bid, acct.LID, &ri.D2)
 			if err != nil {
@@ -58,11 +76,50 @@ func LedgerBalanceReportTable(ctx context.Context, ri *ReporterInfo) gotable.Tab
 		}
 	}
 	tbl.Sort(0, len(tbl.Row)-1, 0)
-	tbl.AddLineAfter(len(tbl.Row) - 1)                          // a line after the last row in the table
-	tbl.InsertSumRow(len(tbl.Row), 0, len(tbl.Row)-1, []int{3}) // insert @ len essentially adds a row.  Only want to sum column 3
+	tbl.AddLineAfter(len(tbl.Row) - 1)                     // a line after the last row in the table
+	tbl.InsertSumRow(len(tbl.Row), 0, len(tbl.Row)-1, sum) // insert @ len essentially adds a row.  Only want to sum the balances
 	return tbl
 }
 
+// getYearClosingAmounts returns the amount the closing journal of the last
+// fiscal year closed before dt added to each GL account, parent accounts
+// include their children.  found is false if no fiscal year closed before
+// dt.
+func getYearClosingAmounts(ctx context.Context, bid int64, dt *time.Time) (map[int64]float64, bool, error) {
+	var m = map[int64]float64{}
+	var fyc rlib.FiscalYearClose
+	t, err := rlib.GetFiscalYearCloses(ctx, bid)
+	if err != nil {
+		return m, false, err
+	}
+	for i := 0; i < len(t); i++ {
+		if !t[i].IsReopened() && t[i].Dt.Before(*dt) {
+			fyc = t[i]
+		}
+	}
+	if fyc.FYCID == 0 {
+		return m, false, nil
+	}
+	d2 := fyc.Dt.AddDate(0, 0, 1)
+	c, err := rlib.GetFiscalYearClosingAmounts(ctx, bid, &fyc.Dt, &d2)
+	if err != nil {
+		return m, false, err
+	}
+	return addToParentAccounts(bid, c), true, nil
+}
+
+// addToParentAccounts returns the amounts of m, by LID, with each amount also
+// added to all the parents of its account
+func addToParentAccounts(bid int64, m map[int64]float64) map[int64]float64 {
+	var p = map[int64]float64{}
+	for lid, amt := range m {
+		for ; lid > 0; lid = rlib.RRdb.BizTypes[bid].GLAccounts[lid].PLID {
+			p[lid] += amt
+		}
+	}
+	return p
+}
+
 //PrintLedgerBalanceReport prints a report of data that will be used to format a ledger UI.
 // This routine is primarily for testing
 func PrintLedgerBalanceReport(ctx context.Context, ri *ReporterInfo) {
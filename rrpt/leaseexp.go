@@ -0,0 +1,215 @@
+package rrpt
+
+import (
+	"context"
+	"fmt"
+	"gotable"
+	"rentroll/rlib"
+	"strings"
+	"time"
+)
+
+// leaseExpRent is the rent of one rentable of an expiring rental agreement
+type leaseExpRent struct {
+	RID         int64
+	CurrentRent float64
+	MarketRent  float64
+	OfferRent   float64
+	Status      string // status of the renewal offer, "" if there is none
+}
+
+// leaseExpRents returns the rent of each rentable of ra at the end of its
+// term.  The renewal offers for the term that follows are used when there
+// are any, otherwise the rents come from the recurring rent assessments in
+// force on the last day of the agreement.
+//-----------------------------------------------------------------------------
+func leaseExpRents(ctx context.Context, xbiz *rlib.XBusiness, ra *rlib.RentalAgreement) ([]leaseExpRent, error) {
+	var t []leaseExpRent
+	n, err := rlib.GetRenewalOffersByRAID(ctx, ra.RAID)
+	if err != nil {
+		return t, err
+	}
+	for i := 0; i < len(n); i++ {
+		if n[i].TermStart.Equal(ra.AgreementStop) {
+			t = append(t, leaseExpRent{n[i].RID, n[i].CurrentRent, n[i].MarketRent, n[i].OfferRent, rlib.RenewalOfferStatus[n[i].Status]})
+		}
+	}
+	if len(t) > 0 {
+		return t, nil
+	}
+
+	d1 := ra.AgreementStop.AddDate(0, 0, -1)
+	d2 := ra.AgreementStop
+	m, err := rlib.GetRecurringAssessmentDefsByRAID(ctx, ra.RAID, &d1, &d2)
+	if err != nil {
+		return t, err
+	}
+	d3 := d2.AddDate(0, 0, 1)
+	ars := rlib.RRdb.BizTypes[ra.BID].AR
+	for i := 0; i < len(m); i++ {
+		if ars[m[i].ARID].FLAGS&(1<<rlib.ARIsRentASM) == 0 || m[i].FLAGS&rlib.ASMREVERSED != 0 {
+			continue
+		}
+		mr, err := rlib.GetRentableMarketRate(ctx, xbiz, m[i].RID, &d2, &d3)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, leaseExpRent{RID: m[i].RID, CurrentRent: m[i].Amount, MarketRent: mr})
+	}
+	return t, nil
+}
+
+// LeaseExpirationTable generates the lease expiration report: the active
+// rental agreements of the business whose AgreementStop falls in
+// ri.D1 - ri.D2, grouped by the month they expire.  Each rentable rent is
+// listed with the market rate and, if one was made, the renewal offer for
+// the next term.  Each month ends with the number of agreements expiring and
+// their rents.
+//
+// INPUTS
+//    ctx = db context
+//    ri  = reporter info
+//
+// RETURNS
+//    the report table
+//-----------------------------------------------------------------------------
+func LeaseExpirationTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "LeaseExpirationTable"
+	var xbiz rlib.XBusiness
+
+	// prepare and init some values
+	ri.RptHeaderD1 = true
+	ri.RptHeaderD2 = true
+
+	const (
+		RAID          = 0
+		Payors        = iota
+		Rentable      = iota
+		AgreementStop = iota
+		CurrentRent   = iota
+		MarketRent    = iota
+		OfferRent     = iota
+		Change        = iota
+		Offer         = iota
+	)
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("Rental Agreement", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Payors", 30, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Rentable", 15, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Agreement Stop", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Current Rent", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Market Rent", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Offered Rent", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Change", 8, gotable.CELLSTRING, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Offer", 9, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+
+	errReturn := func(err error) gotable.Table {
+		rlib.LogAndPrintError(funcname, err)
+		// set errors in section3 and return
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	err := TableReportHeaderBlock(ctx, &tbl, "Lease Expirations", funcname, ri)
+	if err != nil {
+		return errReturn(err)
+	}
+	if err = rlib.InitBizInternals(ri.Bid, &xbiz); err != nil {
+		return errReturn(err)
+	}
+
+	m, err := rlib.GetRentalAgreementsByAgreementStop(ctx, ri.Bid, &ri.D1, &ri.D2)
+	if err != nil {
+		return errReturn(err)
+	}
+
+	var month time.Time
+	var count, total int64
+	var cur, offer, totalCur, totalOffer float64
+	subtotal := func(name string, n int64, c, o float64) {
+		tbl.AddLineAfter(len(tbl.Row) - 1)
+		tbl.AddRow()
+		tbl.Puts(-1, RAID, name)
+		tbl.Puts(-1, Payors, fmt.Sprintf("%d expiring", n))
+		tbl.Putf(-1, CurrentRent, c)
+		tbl.Putf(-1, OfferRent, o)
+		tbl.AddRow()
+	}
+	rnames := map[int64]string{}
+	for i := 0; i < len(m); i++ {
+		if m[i].FLAGS&0xf != rlib.RASTATEActive {
+			continue
+		}
+		mo := time.Date(m[i].AgreementStop.Year(), m[i].AgreementStop.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if !mo.Equal(month) {
+			if count > 0 {
+				subtotal(month.Format("January 2006"), count, cur, offer)
+			}
+			month = mo
+			count, cur, offer = 0, 0, 0
+		}
+		count++
+		total++
+
+		t, err := leaseExpRents(ctx, &xbiz, &m[i])
+		if err != nil {
+			return errReturn(err)
+		}
+		payors, err := m[i].GetPayorNameList(ctx, &m[i].AgreementStart, &m[i].AgreementStop)
+		if err != nil {
+			return errReturn(err)
+		}
+		tbl.AddRow()
+		tbl.Puts(-1, RAID, m[i].IDtoShortString())
+		tbl.Puts(-1, Payors, strings.Join(payors, ", "))
+		tbl.Putd(-1, AgreementStop, m[i].AgreementStop)
+		for j := 0; j < len(t); j++ {
+			name, ok := rnames[t[j].RID]
+			if !ok {
+				rnt, err := rlib.GetRentable(ctx, t[j].RID)
+				if err != nil {
+					return errReturn(err)
+				}
+				name = rnt.RentableName
+				rnames[t[j].RID] = name
+			}
+			if j > 0 {
+				tbl.AddRow()
+			}
+			tbl.Puts(-1, Rentable, name)
+			tbl.Putf(-1, CurrentRent, t[j].CurrentRent)
+			tbl.Putf(-1, MarketRent, t[j].MarketRent)
+			cur += t[j].CurrentRent
+			totalCur += t[j].CurrentRent
+			if len(t[j].Status) > 0 {
+				tbl.Putf(-1, OfferRent, t[j].OfferRent)
+				if t[j].CurrentRent > 0 {
+					tbl.Puts(-1, Change, fmt.Sprintf("%.1f%%", 100*(t[j].OfferRent-t[j].CurrentRent)/t[j].CurrentRent))
+				}
+				tbl.Puts(-1, Offer, t[j].Status)
+				offer += t[j].OfferRent
+				totalOffer += t[j].OfferRent
+			}
+		}
+	}
+	if count > 0 {
+		subtotal(month.Format("January 2006"), count, cur, offer)
+	}
+	tbl.AddRow()
+	tbl.Puts(-1, RAID, "Total")
+	tbl.Puts(-1, Payors, fmt.Sprintf("%d expiring", total))
+	tbl.Putf(-1, CurrentRent, totalCur)
+	tbl.Putf(-1, OfferRent, totalOffer)
+
+	tbl.TightenColumns()
+	return tbl
+}
+
+// LeaseExpiration generates a report
+func LeaseExpiration(ctx context.Context, ri *ReporterInfo) string {
+	tbl := LeaseExpirationTable(ctx, ri)
+	return ReportToString(&tbl, ri)
+}
@@ -105,6 +105,34 @@ func getLedgerEntryDescription(ctx context.Context, l *rlib.LedgerEntry) (string
 		return "Expense - " + reason, r.RentableName, sra
 	case rlib.JNLTYPEXFER:
 		return "Transfer", "", sra
+	case rlib.JNLTYPEVBIL, rlib.JNLTYPEVPMT:
+		vb, err := rlib.GetVendorBill(ctx, j.ID)
+		if err != nil {
+			return "x", "x", "x"
+		}
+		v, err := rlib.GetVendor(ctx, vb.VID)
+		if err != nil {
+			return "x", "x", "x"
+		}
+		rn := ""
+		if l.RID > 0 {
+			r, err := rlib.GetRentable(ctx, l.RID)
+			if err != nil {
+				return "x", "x", "x"
+			}
+			rn = r.RentableName
+		}
+		p := "Vendor Bill - "
+		if j.Type == rlib.JNLTYPEVPMT {
+			p = "Vendor Payment - "
+		}
+		return p + v.Name, rn, sra
+	case rlib.JNLTYPECLOS:
+		fyc, err := rlib.GetFiscalYearClose(ctx, j.ID)
+		if err != nil {
+			return "x", "x", "x"
+		}
+		return "Fiscal Year Close - " + fyc.IDtoShortString(), "", sra
 
 	default:
 		fmt.Printf("getLedgerEntryDescription: unrecognized type: %d\n", j.Type)
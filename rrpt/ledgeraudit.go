@@ -0,0 +1,111 @@
+package rrpt
+
+import (
+	"context"
+	"fmt"
+	"gotable"
+	"rentroll/rlib"
+)
+
+// LedgerAuditTable generates the double-entry integrity report of the
+// business for ri.D1 - ri.D2: every unbalanced journal, orphan ledger entry
+// and drifted ledger marker found by rlib.AuditLedgers.  For a drifted
+// marker, Amount is its balance and Expected the balance it would get if it
+// were regenerated, so the report is the dry run of rlib.RepairLedgerMarkers.
+//
+// INPUTS
+//    ctx = db context
+//    ri  = reporter info, ri.D1 - ri.D2 is the period to audit
+//
+// RETURNS
+//    the report table
+//-----------------------------------------------------------------------------
+func LedgerAuditTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "LedgerAuditTable"
+
+	// prepare and init some values
+	ri.RptHeaderD1 = true
+	ri.RptHeaderD2 = true
+
+	const (
+		Problem  = 0
+		Dt       = iota
+		Journal  = iota
+		Entry    = iota
+		Account  = iota
+		Marker   = iota
+		Amount   = iota
+		Expected = iota
+		Descr    = iota
+	)
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("Problem", 18, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Date", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Journal", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Ledger Entry", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Account", 12, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Marker", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Amount", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Expected", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Description", 50, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+
+	errReturn := func(err error) gotable.Table {
+		rlib.LogAndPrintError(funcname, err)
+		// set errors in section3 and return
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	err := TableReportHeaderBlock(ctx, &tbl, "Ledger Audit", funcname, ri)
+	if err != nil {
+		return errReturn(err)
+	}
+
+	a, err := rlib.AuditLedgers(ctx, ri.Bid, &ri.D1, &ri.D2)
+	if err != nil {
+		return errReturn(err)
+	}
+
+	for i := 0; i < len(a.Problems); i++ {
+		p := &a.Problems[i]
+		tbl.AddRow()
+		tbl.Puts(-1, Problem, p.KindName())
+		tbl.Putd(-1, Dt, p.Dt)
+		if p.JID > 0 {
+			tbl.Puts(-1, Journal, rlib.IDtoShortString("J", p.JID))
+		}
+		if p.LEID > 0 {
+			tbl.Puts(-1, Entry, rlib.IDtoShortString("LE", p.LEID))
+		}
+		if p.LID > 0 {
+			if gl, ok := rlib.RRdb.BizTypes[ri.Bid].GLAccounts[p.LID]; ok {
+				tbl.Puts(-1, Account, gl.GLNumber)
+			}
+		}
+		if p.LMID > 0 {
+			tbl.Puts(-1, Marker, rlib.IDtoShortString("LM", p.LMID))
+		}
+		tbl.Putf(-1, Amount, p.Amount)
+		tbl.Putf(-1, Expected, p.Expected)
+		tbl.Puts(-1, Descr, p.Message)
+	}
+
+	if len(a.Problems) > 0 {
+		tbl.AddLineAfter(len(tbl.Row) - 1)
+	}
+	tbl.AddRow()
+	tbl.Puts(-1, Problem, fmt.Sprintf("%d problems", len(a.Problems)))
+	tbl.Puts(-1, Descr, fmt.Sprintf("checked %d journals, %d ledger entries, %d ledger markers", a.Journals, a.Entries, a.Markers))
+
+	tbl.TightenColumns()
+	return tbl
+}
+
+// LedgerAudit generates a report
+func LedgerAudit(ctx context.Context, ri *ReporterInfo) string {
+	tbl := LedgerAuditTable(ctx, ri)
+	return ReportToString(&tbl, ri)
+}
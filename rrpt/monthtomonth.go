@@ -0,0 +1,164 @@
+package rrpt
+
+import (
+	"context"
+	"fmt"
+	"gotable"
+	"rentroll/rlib"
+	"strings"
+)
+
+// MonthToMonthTable generates the month to month report: the rental
+// agreements of the business that went month to month when their term
+// ended and that are in force during ri.D1 - ri.D2.  For each rent it lists
+// the date the term ended, the rent under the lease and the month to month
+// rent in force at the end of the period.
+//
+// INPUTS
+//    ctx = db context
+//    ri  = reporter info
+//
+// RETURNS
+//    the report table
+//-----------------------------------------------------------------------------
+func MonthToMonthTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "MonthToMonthTable"
+	var xbiz rlib.XBusiness
+
+	// prepare and init some values
+	ri.RptHeaderD1 = true
+	ri.RptHeaderD2 = true
+
+	const (
+		RAID          = 0
+		Payors        = iota
+		Rentable      = iota
+		TermEnded     = iota
+		AgreementStop = iota
+		LeaseRent     = iota
+		MTMRent       = iota
+		Premium       = iota
+	)
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("Rental Agreement", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Payors", 30, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Rentable", 15, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Term Ended", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Agreement Stop", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Lease Rent", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Month To Month Rent", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Premium", 10, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	errReturn := func(err error) gotable.Table {
+		rlib.LogAndPrintError(funcname, err)
+		// set errors in section3 and return
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	err := TableReportHeaderBlock(ctx, &tbl, "Month To Month Agreements", funcname, ri)
+	if err != nil {
+		return errReturn(err)
+	}
+
+	if err = rlib.InitBizInternals(ri.Bid, &xbiz); err != nil {
+		return errReturn(err)
+	}
+
+	m, err := rlib.GetRentalAgreementsByRange(ctx, ri.Bid, &ri.D1, &ri.D2)
+	if err != nil {
+		return errReturn(err)
+	}
+	ars := rlib.RRdb.BizTypes[ri.Bid].AR
+	isRent := func(a *rlib.Assessment) bool {
+		return ars[a.ARID].FLAGS&(1<<rlib.ARIsRentASM) != 0 && a.FLAGS&rlib.ASMREVERSED == 0
+	}
+
+	var count int64
+	var leaseTotal, mtmTotal float64
+	rnames := map[int64]string{}
+	for i := 0; i < len(m); i++ {
+		if m[i].FLAGS&rlib.RAMonthToMonth == 0 || m[i].FLAGS&(1<<6) != 0 {
+			continue
+		}
+		count++
+
+		//--------------------------------------------------------
+		// The month to month rents in force at the end of the
+		// period, and the lease rents they replaced
+		//--------------------------------------------------------
+		d1 := rlib.Earliest(&ri.D2, &m[i].AgreementStop).AddDate(0, 0, -1)
+		d2 := d1.AddDate(0, 0, 1)
+		n, err := rlib.GetRecurringAssessmentDefsByRAID(ctx, m[i].RAID, &d1, &d2)
+		if err != nil {
+			return errReturn(err)
+		}
+		payors, err := m[i].GetPayorNameList(ctx, &m[i].AgreementStart, &m[i].AgreementStop)
+		if err != nil {
+			return errReturn(err)
+		}
+		tbl.AddRow()
+		tbl.Puts(-1, RAID, m[i].IDtoShortString())
+		tbl.Puts(-1, Payors, strings.Join(payors, ", "))
+		tbl.Putd(-1, AgreementStop, m[i].AgreementStop)
+		rows := 0
+		for j := 0; j < len(n); j++ {
+			if !isRent(&n[j]) {
+				continue
+			}
+			lease := float64(0)
+			d3 := n[j].Start.AddDate(0, 0, -1)
+			p, err := rlib.GetRecurringAssessmentDefsByRAID(ctx, m[i].RAID, &d3, &n[j].Start)
+			if err != nil {
+				return errReturn(err)
+			}
+			for k := 0; k < len(p); k++ {
+				if isRent(&p[k]) && p[k].RID == n[j].RID && p[k].ARID == n[j].ARID && p[k].Stop.Equal(n[j].Start) {
+					lease = p[k].Amount
+				}
+			}
+			name, ok := rnames[n[j].RID]
+			if !ok {
+				rnt, err := rlib.GetRentable(ctx, n[j].RID)
+				if err != nil {
+					return errReturn(err)
+				}
+				name = rnt.RentableName
+				rnames[n[j].RID] = name
+			}
+			if rows > 0 {
+				tbl.AddRow()
+			}
+			rows++
+			tbl.Puts(-1, Rentable, name)
+			tbl.Puts(-1, TermEnded, n[j].Start.Format(rlib.RRDATEFMT3))
+			tbl.Putf(-1, LeaseRent, lease)
+			tbl.Putf(-1, MTMRent, n[j].Amount)
+			tbl.Putf(-1, Premium, n[j].Amount-lease)
+			leaseTotal += lease
+			mtmTotal += n[j].Amount
+		}
+	}
+
+	if len(tbl.Row) > 0 {
+		tbl.AddLineAfter(len(tbl.Row) - 1)
+	}
+	tbl.AddRow()
+	tbl.Puts(-1, RAID, "Total")
+	tbl.Puts(-1, Payors, fmt.Sprintf("%d month to month", count))
+	tbl.Putf(-1, LeaseRent, leaseTotal)
+	tbl.Putf(-1, MTMRent, mtmTotal)
+	tbl.Putf(-1, Premium, mtmTotal-leaseTotal)
+
+	tbl.TightenColumns()
+	return tbl
+}
+
+// MonthToMonth generates a report
+func MonthToMonth(ctx context.Context, ri *ReporterInfo) string {
+	tbl := MonthToMonthTable(ctx, ri)
+	return ReportToString(&tbl, ri)
+}
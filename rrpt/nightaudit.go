@@ -0,0 +1,200 @@
+package rrpt
+
+import (
+	"context"
+	"fmt"
+	"gotable"
+	"rentroll/rlib"
+	"sort"
+)
+
+// NightAuditReportTable generates the night audit summary report for the
+// business dates in ri.D1 - ri.D2.  The first table lists each night
+// audited with its occupancy, average daily rate (ADR), revenue per
+// available room (RevPAR) and the room and tax charges posted, followed by
+// the figures of the whole period.  The second table is the revenue of the
+// period by account rule: all the charges posted to the guest folios,
+// incidentals included.  Reversed charges are left out.
+//
+// INPUTS
+//    ctx = db context
+//    ri  = reporter info
+//
+// RETURNS
+//    the report tables
+//    any error encountered
+//-----------------------------------------------------------------------------
+func NightAuditReportTable(ctx context.Context, ri *ReporterInfo) ([]gotable.Table, error) {
+	const funcname = "NightAuditReportTable"
+	var m []gotable.Table
+
+	ri.RptHeaderD1 = true
+	ri.RptHeaderD2 = true
+
+	const (
+		Dt          = 0
+		Rooms       = iota
+		Occupied    = iota
+		Arrivals    = iota
+		Departures  = iota
+		Occupancy   = iota
+		ADR         = iota
+		RevPAR      = iota
+		RoomRevenue = iota
+		TaxRevenue  = iota
+	)
+
+	tbl := getRRTable()
+	tbl.AddColumn("Date", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Rooms", 6, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Occupied", 8, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Arrivals", 8, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Departures", 10, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Occupancy", 9, gotable.CELLSTRING, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("ADR", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("RevPAR", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Room Revenue", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Tax", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	err := TableReportHeaderBlock(ctx, &tbl, "Night Audit", funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return append(m, tbl), err
+	}
+
+	n, err := rlib.GetNightAuditsInRange(ctx, ri.Bid, &ri.D1, &ri.D2)
+	if err != nil {
+		tbl.SetSection3(err.Error())
+		return append(m, tbl), err
+	}
+	var total rlib.NightAudit
+	for i := 0; i < len(n); i++ {
+		tbl.AddRow()
+		tbl.Putd(-1, Dt, n[i].Dt)
+		tbl.Puti(-1, Rooms, n[i].Rooms)
+		tbl.Puti(-1, Occupied, n[i].Occupied)
+		tbl.Puti(-1, Arrivals, n[i].Arrivals)
+		tbl.Puti(-1, Departures, n[i].Departures)
+		tbl.Puts(-1, Occupancy, fmt.Sprintf("%.1f%%", n[i].Occupancy()))
+		tbl.Putf(-1, ADR, n[i].ADR())
+		tbl.Putf(-1, RevPAR, n[i].RevPAR())
+		tbl.Putf(-1, RoomRevenue, n[i].RoomRevenue)
+		tbl.Putf(-1, TaxRevenue, n[i].TaxRevenue)
+		total.Rooms += n[i].Rooms
+		total.Occupied += n[i].Occupied
+		total.Arrivals += n[i].Arrivals
+		total.Departures += n[i].Departures
+		total.RoomRevenue += n[i].RoomRevenue
+		total.TaxRevenue += n[i].TaxRevenue
+	}
+	if len(n) > 0 {
+		tbl.AddLineAfter(len(tbl.Row) - 1)
+		tbl.AddRow()
+		tbl.Puts(-1, Dt, "Period")
+		tbl.Puti(-1, Rooms, total.Rooms)
+		tbl.Puti(-1, Occupied, total.Occupied)
+		tbl.Puti(-1, Arrivals, total.Arrivals)
+		tbl.Puti(-1, Departures, total.Departures)
+		tbl.Puts(-1, Occupancy, fmt.Sprintf("%.1f%%", total.Occupancy()))
+		tbl.Putf(-1, ADR, total.ADR())
+		tbl.Putf(-1, RevPAR, total.RevPAR())
+		tbl.Putf(-1, RoomRevenue, total.RoomRevenue)
+		tbl.Putf(-1, TaxRevenue, total.TaxRevenue)
+	}
+	tbl.TightenColumns()
+	m = append(m, tbl)
+
+	t, err := nightAuditRevenueTable(ctx, ri)
+	m = append(m, t)
+	return m, err
+}
+
+// nightAuditRevenueTable returns the table of the folio charges posted in
+// ri.D1 - ri.D2, totaled by account rule
+//-----------------------------------------------------------------------------
+func nightAuditRevenueTable(ctx context.Context, ri *ReporterInfo) (gotable.Table, error) {
+	const funcname = "nightAuditRevenueTable"
+
+	const (
+		AccountRule = 0
+		Charges     = iota
+		Amount      = iota
+	)
+
+	tbl := getRRTable()
+	tbl.AddColumn("Account Rule", 30, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Charges", 8, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Amount", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	errReturn := func(err error) (gotable.Table, error) {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl, err
+	}
+
+	err := TableReportHeaderBlock(ctx, &tbl, "Night Audit Revenue By Account Rule", funcname, ri)
+	if err != nil {
+		return errReturn(err)
+	}
+
+	f, err := rlib.GetFoliosInRange(ctx, ri.Bid, &ri.D1, &ri.D2)
+	if err != nil {
+		return errReturn(err)
+	}
+	count := map[int64]int64{}
+	amount := map[int64]float64{}
+	for i := 0; i < len(f); i++ {
+		n, err := rlib.GetAssessmentsByAssocElem(ctx, ri.Bid, rlib.ELEMFOLIO, f[i].FOLID)
+		if err != nil {
+			return errReturn(err)
+		}
+		for j := 0; j < len(n); j++ {
+			if n[j].FLAGS&4 != 0 || n[j].Start.Before(ri.D1) || !n[j].Start.Before(ri.D2) {
+				continue
+			}
+			count[n[j].ARID]++
+			amount[n[j].ARID] += n[j].Amount
+		}
+	}
+
+	var arids []int64
+	for k := range count {
+		arids = append(arids, k)
+	}
+	sort.Slice(arids, func(i, j int) bool { return arids[i] < arids[j] })
+	var total float64
+	for _, arid := range arids {
+		ar, err := rlib.GetAR(ctx, arid)
+		if err != nil {
+			return errReturn(err)
+		}
+		tbl.AddRow()
+		tbl.Puts(-1, AccountRule, ar.Name)
+		tbl.Puti(-1, Charges, count[arid])
+		tbl.Putf(-1, Amount, rlib.RoundToCent(amount[arid]))
+		total += amount[arid]
+	}
+	if len(arids) > 0 {
+		tbl.AddLineAfter(len(tbl.Row) - 1)
+		tbl.AddRow()
+		tbl.Puts(-1, AccountRule, "Total")
+		tbl.Putf(-1, Amount, rlib.RoundToCent(total))
+	}
+	tbl.TightenColumns()
+	return tbl, nil
+}
+
+// NightAuditReport returns text based report from NightAuditReportTable
+func NightAuditReport(ctx context.Context, ri *ReporterInfo) string {
+	m, err := NightAuditReportTable(ctx, ri)
+	if err != nil {
+		return "Error while creating night audit report: " + err.Error()
+	}
+
+	var s string
+	for _, tbl := range m {
+		s += ReportToString(&tbl, ri) + "\n"
+	}
+	return s
+}
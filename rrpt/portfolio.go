@@ -0,0 +1,525 @@
+package rrpt
+
+import (
+	"context"
+	"fmt"
+	"gotable"
+	"rentroll/rlib"
+	"sort"
+	"strings"
+	"time"
+)
+
+// The portfolio reports consolidate several businesses.  The businesses are
+// ri.Portfolio, set by the batch commands, or the "bids" query parameter of
+// the report request, a comma separated list of BUDs or BIDs.  Without
+// either the report covers the business of the request alone.
+
+// portfolioBusinesses returns the businesses of portfolio report ri, with
+// their internals initialized
+func portfolioBusinesses(ctx context.Context, ri *ReporterInfo) ([]rlib.XBusiness, error) {
+	var m []rlib.XBusiness
+	bids := ri.Portfolio
+	if len(bids) == 0 && ri.QueryParams != nil {
+		if s := ri.QueryParams.Get("bids"); len(s) > 0 {
+			var err error
+			if bids, err = rlib.ParsePortfolio(ctx, s); err != nil {
+				return m, err
+			}
+		}
+	}
+	if len(bids) == 0 {
+		bids = []int64{ri.Bid}
+	}
+	for i := 0; i < len(bids); i++ {
+		var xbiz rlib.XBusiness
+		if err := rlib.InitBizInternals(bids[i], &xbiz); err != nil {
+			return m, err
+		}
+		if xbiz.P.BID == 0 {
+			return m, fmt.Errorf("business %d not found", bids[i])
+		}
+		m = append(m, xbiz)
+	}
+	return m, nil
+}
+
+// portfolioTitle returns the report name rn followed by the designations of
+// the businesses m
+func portfolioTitle(rn string, m []rlib.XBusiness) string {
+	var sa []string
+	for i := 0; i < len(m); i++ {
+		sa = append(sa, m[i].P.Designation)
+	}
+	return rn + ": " + strings.Join(sa, ", ")
+}
+
+// percent returns x / y as a percent string, blank if y is 0
+func percent(x, y float64) string {
+	if y == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%.1f%%", 100*x/y)
+}
+
+// PortfolioRentRollTable generates the rent roll summary of a portfolio:
+// the rent roll totals of each business for ri.D1 - ri.D2 and their sum.
+//
+// INPUTS
+//    ctx = db context
+//    ri  = reporter info, ri.D1 - ri.D2 is the period
+//
+// RETURNS
+//    the report table
+//-----------------------------------------------------------------------------
+func PortfolioRentRollTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "PortfolioRentRollTable"
+
+	const (
+		BUD       = 0
+		Name      = iota
+		Rentables = iota
+		PeriodGSR = iota
+		Offsets   = iota
+		AmtDue    = iota
+		Payments  = iota
+		BeginRcv  = iota
+		DeltaRcv  = iota
+		EndRcv    = iota
+		EndSecDep = iota
+	)
+
+	// prepare and init some values
+	ri.RptHeaderD1 = true
+	ri.RptHeaderD2 = true
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("Business", 8, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Name", 30, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Rentables", 9, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Period GSR", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Income Offsets", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Amount Due", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Payments Applied", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Beginning Receivable", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Change In Receivable", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Ending Receivable", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Ending Security Deposit", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	errReturn := func(err error) gotable.Table {
+		rlib.LogAndPrintError(funcname, err)
+		// set errors in section3 and return
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	m, err := portfolioBusinesses(ctx, ri)
+	if err != nil {
+		return errReturn(err)
+	}
+	err = TableReportHeaderBlock(ctx, &tbl, portfolioTitle("Portfolio Rent Roll", m), funcname, ri)
+	if err != nil {
+		return errReturn(err)
+	}
+
+	n := int64(0)
+	for i := 0; i < len(m); i++ {
+		rows, _, _, err := rlib.GetRentRollRows(ctx, m[i].P.BID, ri.D1, ri.D2, -1, -1)
+		if err != nil {
+			return errReturn(err)
+		}
+		var t rlib.RentRollStaticInfo
+		cnt := int64(0)
+		for k := 0; k < len(rows); k++ {
+			if rows[k].FLAGS&rlib.RentRollMainRow != 0 && rows[k].RID.Valid && rows[k].RID.Int64 > 0 {
+				cnt++
+			}
+			if rows[k].FLAGS&rlib.RentRollGrandTotalRow != 0 {
+				t = rows[k]
+			}
+		}
+		n += cnt
+		tbl.AddRow()
+		tbl.Puts(-1, BUD, m[i].P.Designation)
+		tbl.Puts(-1, Name, m[i].P.Name)
+		tbl.Puti(-1, Rentables, cnt)
+		tbl.Putf(-1, PeriodGSR, rlib.RoundToCent(t.PeriodGSR.Float64))
+		tbl.Putf(-1, Offsets, rlib.RoundToCent(t.IncomeOffsets.Float64))
+		tbl.Putf(-1, AmtDue, rlib.RoundToCent(t.AmountDue.Float64))
+		tbl.Putf(-1, Payments, rlib.RoundToCent(t.PaymentsApplied.Float64))
+		tbl.Putf(-1, BeginRcv, rlib.RoundToCent(t.BeginReceivable))
+		tbl.Putf(-1, DeltaRcv, rlib.RoundToCent(t.DeltaReceivable))
+		tbl.Putf(-1, EndRcv, rlib.RoundToCent(t.EndReceivable))
+		tbl.Putf(-1, EndSecDep, rlib.RoundToCent(t.EndSecDep))
+	}
+	tbl.AddLineAfter(len(tbl.Row) - 1)
+	tbl.InsertSumRow(len(tbl.Row), 0, len(tbl.Row)-1, []int{PeriodGSR, Offsets, AmtDue, Payments, BeginRcv, DeltaRcv, EndRcv, EndSecDep})
+	tbl.Puts(-1, Name, "Total")
+	tbl.Puti(-1, Rentables, n)
+
+	tbl.TightenColumns()
+	return tbl
+}
+
+// PortfolioRentRoll generates a report
+func PortfolioRentRoll(ctx context.Context, ri *ReporterInfo) string {
+	tbl := PortfolioRentRollTable(ctx, ri)
+	return ReportToString(&tbl, ri)
+}
+
+// ConsolidatedTrialBalanceTable generates the trial balance of a portfolio
+// on ri.D2.  The GL accounts of each business are reported under their
+// consolidated account (AcctMap), so businesses with different charts
+// of accounts can be combined.  There is a column per business and a total.
+//
+// INPUTS
+//    ctx = db context
+//    ri  = reporter info, ri.D2 is the as-of date
+//
+// RETURNS
+//    the report table
+//-----------------------------------------------------------------------------
+func ConsolidatedTrialBalanceTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "ConsolidatedTrialBalanceTable"
+
+	// prepare and init some values
+	ri.RptHeaderD1 = false
+	ri.RptHeaderD2 = true
+
+	// table init
+	tbl := getRRTable()
+
+	errReturn := func(err error) gotable.Table {
+		rlib.LogAndPrintError(funcname, err)
+		// set errors in section3 and return
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	m, err := portfolioBusinesses(ctx, ri)
+	if err != nil {
+		return errReturn(err)
+	}
+
+	tbl.AddColumn("GLNumber", 8, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Account", 40, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	for i := 0; i < len(m); i++ {
+		tbl.AddColumn(m[i].P.Designation, 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	}
+	tbl.AddColumn("Total", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	err = TableReportHeaderBlock(ctx, &tbl, portfolioTitle("Consolidated Trial Balance", m), funcname, ri)
+	if err != nil {
+		return errReturn(err)
+	}
+
+	//----------------------------------------------------
+	// the balance of each account, without its children,
+	// added to its consolidated account
+	//----------------------------------------------------
+	names := map[string]string{}
+	bal := map[string][]float64{}
+	for i := 0; i < len(m); i++ {
+		bid := m[i].P.BID
+		cons, err := rlib.GetConsolidatedAccounts(ctx, bid)
+		if err != nil {
+			return errReturn(err)
+		}
+		for lid, a := range cons {
+			b, err := rlib.GetAccountBalance(ctx, bid, lid, &ri.D2)
+			if err != nil {
+				return errReturn(err)
+			}
+			c, err := rlib.GetGLAccountChildAccts(ctx, bid, lid)
+			if err != nil {
+				return errReturn(err)
+			}
+			for k := 0; k < len(c); k++ {
+				x, err := rlib.GetAccountBalance(ctx, bid, c[k], &ri.D2)
+				if err != nil {
+					return errReturn(err)
+				}
+				b -= x
+			}
+			if _, ok := bal[a.GLNumber]; !ok {
+				bal[a.GLNumber] = make([]float64, len(m))
+				names[a.GLNumber] = a.Name
+			}
+			bal[a.GLNumber][i] += b
+		}
+	}
+
+	var glnos []string
+	for k := range bal {
+		glnos = append(glnos, k)
+	}
+	sort.Strings(glnos)
+
+	tot := make([]float64, len(m))
+	for _, k := range glnos {
+		if isZero(bal[k]) {
+			continue
+		}
+		tbl.AddRow()
+		tbl.Puts(-1, 0, k)
+		tbl.Puts(-1, 1, names[k])
+		s := float64(0)
+		for i := 0; i < len(m); i++ {
+			tbl.Putf(-1, 2+i, rlib.RoundToCent(bal[k][i]))
+			tot[i] += bal[k][i]
+			s += bal[k][i]
+		}
+		tbl.Putf(-1, 2+len(m), rlib.RoundToCent(s))
+	}
+	tbl.AddLineAfter(len(tbl.Row) - 1)
+	tbl.AddRow()
+	tbl.Puts(-1, 1, "Total")
+	var msg []string
+	s := float64(0)
+	for i := 0; i < len(m); i++ {
+		tbl.Putf(-1, 2+i, rlib.RoundToCent(tot[i]))
+		s += tot[i]
+		if d := rlib.RoundToCent(tot[i]); d != 0 {
+			msg = append(msg, fmt.Sprintf("The debits and credits of %s differ by %.2f", m[i].P.Designation, d))
+		}
+	}
+	tbl.Putf(-1, 2+len(m), rlib.RoundToCent(s))
+	if len(msg) > 0 {
+		tbl.SetSection3(strings.Join(msg, "\n"))
+	}
+
+	tbl.TightenColumns()
+	return tbl
+}
+
+// ConsolidatedTrialBalance generates a report
+func ConsolidatedTrialBalance(ctx context.Context, ri *ReporterInfo) string {
+	tbl := ConsolidatedTrialBalanceTable(ctx, ri)
+	return ReportToString(&tbl, ri)
+}
+
+// PortfolioOccupancyTable generates the occupancy of each business of a
+// portfolio: the rentables occupied at the end of ri.D1 - ri.D2 and the
+// average occupancy over the period, the fraction of the rentable days
+// covered by a rental agreement.
+//
+// INPUTS
+//    ctx = db context
+//    ri  = reporter info, ri.D1 - ri.D2 is the period
+//
+// RETURNS
+//    the report table
+//-----------------------------------------------------------------------------
+func PortfolioOccupancyTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "PortfolioOccupancyTable"
+
+	const (
+		BUD       = 0
+		Name      = iota
+		Rentables = iota
+		Occupied  = iota
+		Vacant    = iota
+		EndOcc    = iota
+		AvgOcc    = iota
+	)
+
+	// prepare and init some values
+	ri.RptHeaderD1 = true
+	ri.RptHeaderD2 = true
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("Business", 8, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Name", 30, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Rentables", 9, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Occupied", 9, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Vacant", 9, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Occupancy", 9, gotable.CELLSTRING, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Average Occupancy", 9, gotable.CELLSTRING, gotable.COLJUSTIFYRIGHT)
+
+	errReturn := func(err error) gotable.Table {
+		rlib.LogAndPrintError(funcname, err)
+		// set errors in section3 and return
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	m, err := portfolioBusinesses(ctx, ri)
+	if err != nil {
+		return errReturn(err)
+	}
+	err = TableReportHeaderBlock(ctx, &tbl, portfolioTitle("Portfolio Occupancy", m), funcname, ri)
+	if err != nil {
+		return errReturn(err)
+	}
+
+	last := ri.D2.AddDate(0, 0, -1) // the last day of the period
+	var tn, to int64
+	var tavg float64
+	for i := 0; i < len(m); i++ {
+		r, err := rlib.GetRentablesByBusiness(ctx, m[i].P.BID)
+		if err != nil {
+			return errReturn(err)
+		}
+		occ := int64(0)
+		avg := float64(0)
+		for k := 0; k < len(r); k++ {
+			rra, err := rlib.GetAgreementsForRentable(ctx, r[k].RID, &ri.D1, &ri.D2)
+			if err != nil {
+				return errReturn(err)
+			}
+			f := float64(0)
+			end := false
+			for j := 0; j < len(rra); j++ {
+				f += rlib.Occupancy(&ri.D1, &ri.D2, &rra[j].RARDtStart, &rra[j].RARDtStop)
+				if !rra[j].RARDtStart.After(last) && rra[j].RARDtStop.After(last) {
+					end = true
+				}
+			}
+			if f > 1 {
+				f = 1
+			}
+			avg += f
+			if end {
+				occ++
+			}
+		}
+		n := int64(len(r))
+		tn += n
+		to += occ
+		tavg += avg
+		tbl.AddRow()
+		tbl.Puts(-1, BUD, m[i].P.Designation)
+		tbl.Puts(-1, Name, m[i].P.Name)
+		tbl.Puti(-1, Rentables, n)
+		tbl.Puti(-1, Occupied, occ)
+		tbl.Puti(-1, Vacant, n-occ)
+		tbl.Puts(-1, EndOcc, percent(float64(occ), float64(n)))
+		tbl.Puts(-1, AvgOcc, percent(avg, float64(n)))
+	}
+	tbl.AddLineAfter(len(tbl.Row) - 1)
+	tbl.AddRow()
+	tbl.Puts(-1, Name, "Total")
+	tbl.Puti(-1, Rentables, tn)
+	tbl.Puti(-1, Occupied, to)
+	tbl.Puti(-1, Vacant, tn-to)
+	tbl.Puts(-1, EndOcc, percent(float64(to), float64(tn)))
+	tbl.Puts(-1, AvgOcc, percent(tavg, float64(tn)))
+
+	tbl.TightenColumns()
+	return tbl
+}
+
+// PortfolioOccupancy generates a report
+func PortfolioOccupancy(ctx context.Context, ri *ReporterInfo) string {
+	tbl := PortfolioOccupancyTable(ctx, ri)
+	return ReportToString(&tbl, ri)
+}
+
+// PortfolioDelinquencyTable generates the delinquency of each business of a
+// portfolio: the receivable balances owed by its rentables on ri.D2 and 30,
+// 60 and 90 days earlier, and the number of rentables owing on ri.D2.  Only
+// the balances owed are summed, credit balances are left out.
+//
+// INPUTS
+//    ctx = db context
+//    ri  = reporter info, ri.D2 is the as-of date
+//
+// RETURNS
+//    the report table
+//-----------------------------------------------------------------------------
+func PortfolioDelinquencyTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "PortfolioDelinquencyTable"
+
+	const (
+		BUD        = 0
+		Name       = iota
+		Delinquent = iota
+		D0         = iota
+	)
+
+	// prepare and init some values
+	ri.RptHeaderD1 = false
+	ri.RptHeaderD2 = true
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("Business", 8, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Name", 30, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Delinquent Rentables", 11, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("As of "+ri.D2.Format(rlib.RRDATEFMT3), 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("30 Days Prior", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("60 Days Prior", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("90 Days Prior", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	errReturn := func(err error) gotable.Table {
+		rlib.LogAndPrintError(funcname, err)
+		// set errors in section3 and return
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	m, err := portfolioBusinesses(ctx, ri)
+	if err != nil {
+		return errReturn(err)
+	}
+	err = TableReportHeaderBlock(ctx, &tbl, portfolioTitle("Portfolio Delinquency", m), funcname, ri)
+	if err != nil {
+		return errReturn(err)
+	}
+
+	dt := []time.Time{ri.D2, ri.D2.AddDate(0, 0, -30), ri.D2.AddDate(0, 0, -60), ri.D2.AddDate(0, 0, -90)}
+	n := int64(0)
+	for i := 0; i < len(m); i++ {
+		bid := m[i].P.BID
+		owed := make([]float64, len(dt))
+		cnt := int64(0)
+		if rcv := rlib.GetReceivableAccounts(bid); len(rcv) > 0 {
+			lid := rcv[0] // the first receivable account, as the delinquency report
+			r, err := rlib.GetRentablesByBusiness(ctx, bid)
+			if err != nil {
+				return errReturn(err)
+			}
+			for k := 0; k < len(r); k++ {
+				for j := 0; j < len(dt); j++ {
+					b, err := rlib.GetRentableAccountBalance(ctx, bid, lid, r[k].RID, &dt[j])
+					if err != nil {
+						return errReturn(err)
+					}
+					if b = rlib.RoundToCent(b); b <= 0 {
+						continue
+					}
+					owed[j] += b
+					if j == 0 {
+						cnt++
+					}
+				}
+			}
+		}
+		n += cnt
+		tbl.AddRow()
+		tbl.Puts(-1, BUD, m[i].P.Designation)
+		tbl.Puts(-1, Name, m[i].P.Name)
+		tbl.Puti(-1, Delinquent, cnt)
+		for j := 0; j < len(dt); j++ {
+			tbl.Putf(-1, D0+j, rlib.RoundToCent(owed[j]))
+		}
+	}
+	tbl.AddLineAfter(len(tbl.Row) - 1)
+	tbl.InsertSumRow(len(tbl.Row), 0, len(tbl.Row)-1, []int{D0, D0 + 1, D0 + 2, D0 + 3})
+	tbl.Puts(-1, Name, "Total")
+	tbl.Puti(-1, Delinquent, n)
+
+	tbl.TightenColumns()
+	return tbl
+}
+
+// PortfolioDelinquency generates a report
+func PortfolioDelinquency(ctx context.Context, ri *ReporterInfo) string {
+	tbl := PortfolioDelinquencyTable(ctx, ri)
+	return ReportToString(&tbl, ri)
+}
@@ -0,0 +1,181 @@
+package rrpt
+
+import (
+	"context"
+	"fmt"
+	"gotable"
+	"rentroll/rlib"
+	"time"
+)
+
+// achievedRent returns the number of rentals of the rentables of type rtid
+// that start in d1 - d2, and their average ContractRent.
+//
+// INPUTS
+//    ctx  = db context
+//    r    = the rentables of the business
+//    refs = the rentable type references of each rentable, by RID
+//    rtid = the rentable type
+//    d1   = start of the range
+//    d2   = stop of the range
+//
+// RETURNS
+//    the number of rentals
+//    their average rent
+//    any error encountered
+//-----------------------------------------------------------------------------
+func achievedRent(ctx context.Context, r []rlib.Rentable, refs map[int64][]rlib.RentableTypeRef, rtid int64, d1, d2 *time.Time) (int64, float64, error) {
+	var count int64
+	var total float64
+	for i := 0; i < len(r); i++ {
+		rta := refs[r[i].RID]
+		typed := false
+		for j := 0; j < len(rta); j++ {
+			typed = typed || (rta[j].RTID == rtid && rlib.DateRangeOverlap(&rta[j].DtStart, &rta[j].DtStop, d1, d2))
+		}
+		if !typed {
+			continue
+		}
+		t, err := rlib.GetAgreementsForRentable(ctx, r[i].RID, d1, d2)
+		if err != nil {
+			return count, total, err
+		}
+		for j := 0; j < len(t); j++ {
+			if t[j].RARDtStart.Before(*d1) || !t[j].RARDtStart.Before(*d2) || t[j].ContractRent <= 0 {
+				continue
+			}
+			count++
+			total += t[j].ContractRent
+		}
+	}
+	if count == 0 {
+		return count, 0, nil
+	}
+	return count, rlib.RoundToCent(total / float64(count)), nil
+}
+
+// RateRecommendationTable generates the rate recommendation history report:
+// the market rate recommendations of the business that start in
+// ri.D1 - ri.D2.  Each one lists the rate in force when it was made, the
+// rate recommended and the rate applied, with the figures it was based on.
+// The achieved rent is the average ContractRent of the rentals of the type
+// that start in the month the recommendation took effect.
+//
+// INPUTS
+//    ctx = db context
+//    ri  = reporter info
+//
+// RETURNS
+//    the report table
+//-----------------------------------------------------------------------------
+func RateRecommendationTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "RateRecommendationTable"
+
+	// prepare and init some values
+	ri.RptHeaderD1 = true
+	ri.RptHeaderD2 = true
+
+	const (
+		RentableType = 0
+		DtStart      = iota
+		Status       = iota
+		Occupancy    = iota
+		DaysVacant   = iota
+		Seasonal     = iota
+		CurrentRate  = iota
+		Recommended  = iota
+		Applied      = iota
+		Rentals      = iota
+		Achieved     = iota
+		Variance     = iota
+	)
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("Rentable Type", 15, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Start", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Status", 9, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Projected Occupancy", 10, gotable.CELLSTRING, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Avg Days Vacant", 8, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Seasonal", 8, gotable.CELLSTRING, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Current Rate", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Recommended Rate", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Applied Rate", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("New Rentals", 7, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Achieved Rent", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Achieved vs Rate", 8, gotable.CELLSTRING, gotable.COLJUSTIFYRIGHT)
+
+	errReturn := func(err error) gotable.Table {
+		rlib.LogAndPrintError(funcname, err)
+		// set errors in section3 and return
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	err := TableReportHeaderBlock(ctx, &tbl, "Rate Recommendations", funcname, ri)
+	if err != nil {
+		return errReturn(err)
+	}
+
+	m, err := rlib.GetRateRecommendationsInRange(ctx, ri.Bid, &ri.D1, &ri.D2)
+	if err != nil {
+		return errReturn(err)
+	}
+	rtm, err := rlib.GetBusinessRentableTypes(ctx, ri.Bid)
+	if err != nil {
+		return errReturn(err)
+	}
+	r, err := rlib.GetRentablesByBusiness(ctx, ri.Bid)
+	if err != nil {
+		return errReturn(err)
+	}
+	refs := map[int64][]rlib.RentableTypeRef{}
+	for i := 0; i < len(r); i++ {
+		if refs[r[i].RID], err = rlib.GetRentableTypeRefs(ctx, r[i].RID); err != nil {
+			return errReturn(err)
+		}
+	}
+
+	for i := 0; i < len(m); i++ {
+		d2 := m[i].DtStart.AddDate(0, 1, 0)
+		n, achieved, err := achievedRent(ctx, r, refs, m[i].RTID, &m[i].DtStart, &d2)
+		if err != nil {
+			return errReturn(err)
+		}
+		f := rlib.PricingFactors{Rentables: m[i].Rentables, Occupied: m[i].Occupied, MoveOuts: m[i].MoveOuts}
+		rate := m[i].CurrentRate // the rate in force in the month
+		if m[i].Status == rlib.RateRecommendationApproved {
+			rate = m[i].AppliedRate
+		}
+
+		tbl.AddRow()
+		tbl.Puts(-1, RentableType, rtm[m[i].RTID].Name)
+		tbl.Putd(-1, DtStart, m[i].DtStart)
+		tbl.Puts(-1, Status, rlib.RateRecommendationStatus[m[i].Status])
+		tbl.Puts(-1, Occupancy, fmt.Sprintf("%.1f%%", f.ProjectedOccupancy()))
+		tbl.Putf(-1, DaysVacant, m[i].AvgDaysVacant)
+		tbl.Puts(-1, Seasonal, fmt.Sprintf("%.1f%%", m[i].SeasonalPct))
+		tbl.Putf(-1, CurrentRate, m[i].CurrentRate)
+		tbl.Putf(-1, Recommended, m[i].RecommendedRate)
+		if m[i].Status == rlib.RateRecommendationApproved {
+			tbl.Putf(-1, Applied, m[i].AppliedRate)
+		}
+		tbl.Puti(-1, Rentals, n)
+		if n > 0 {
+			tbl.Putf(-1, Achieved, achieved)
+			if rate > 0 {
+				tbl.Puts(-1, Variance, fmt.Sprintf("%.1f%%", 100*(achieved-rate)/rate))
+			}
+		}
+	}
+
+	tbl.TightenColumns()
+	return tbl
+}
+
+// RateRecommendation generates a report
+func RateRecommendation(ctx context.Context, ri *ReporterInfo) string {
+	tbl := RateRecommendationTable(ctx, ri)
+	return ReportToString(&tbl, ri)
+}
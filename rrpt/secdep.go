@@ -0,0 +1,189 @@
+package rrpt
+
+import (
+	"context"
+	"fmt"
+	"gotable"
+	"rentroll/rlib"
+	"time"
+)
+
+// SecDepLetterPDFProps holds the override properties needed for the security
+// deposit disposition letter
+var SecDepLetterPDFProps = []*gotable.PDFProperty{
+	// top margin
+	{Option: "-T", Value: "20"},
+	// header font size
+	{Option: "--header-font-size", Value: "7"},
+	// header font
+	{Option: "--header-font-name", Value: "opensans"},
+	// header spacing
+	{Option: "--header-spacing", Value: "3"},
+	// bottom margin
+	{Option: "-B", Value: "20"},
+	// footer spacing
+	{Option: "--footer-spacing", Value: "5"},
+	// footer font
+	{Option: "--footer-font-name", Value: "opensans"},
+	// footer font size
+	{Option: "--footer-font-size", Value: "7"},
+	// footer right content
+	{Option: "--footer-right", Value: "Page [page] of [toPage]"},
+	// page size
+	{Option: "--page-size", Value: "Letter"},
+	// orientation
+	{Option: "--orientation", Value: "Portrait"},
+}
+
+// SecDepLetterTable generates the itemized security deposit disposition
+// letter for the SecDepSettlement ri.ID. It lists the deposits held, each
+// unpaid charge and damage deducted from them, and the refund or balance
+// due.  The deadline and the statutory notice come from the business's
+// security deposit settings.  A settlement that has not been posted is
+// marked as a draft.
+//
+// INPUTS
+//    ctx = db context
+//    ri  = reporter info, ri.ID is the SDSID
+//
+// RETURNS
+//    the report table
+//-----------------------------------------------------------------------------
+func SecDepLetterTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "SecDepLetterTable"
+	var (
+		err error
+		t   rlib.Transactant
+	)
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("Item", 60, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Amount", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	errReturn := func(err error) gotable.Table {
+		rlib.LogAndPrintError(funcname, err)
+		// set errors in section3 and return
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	s, err := rlib.GetSecDepSettlement(ctx, ri.ID)
+	if err != nil {
+		return errReturn(err)
+	}
+	if s.SDSID == 0 || s.BID != ri.Bid {
+		return errReturn(fmt.Errorf("security deposit settlement %d not found", ri.ID))
+	}
+	if s.I, err = rlib.GetSecDepItems(ctx, s.SDSID); err != nil {
+		return errReturn(err)
+	}
+	p, err := rlib.GetSecDepPolicy(ctx, s.BID, "general")
+	if err != nil {
+		return errReturn(err)
+	}
+	if s.TCID > 0 {
+		if err = rlib.GetTransactant(ctx, s.TCID, &t); err != nil {
+			return errReturn(err)
+		}
+	}
+
+	rn := "Security Deposit Disposition"
+	if s.FLAGS&rlib.FlSecDepPosted == 0 {
+		rn += " (DRAFT)"
+	}
+	err = TableReportHeaderBlock(ctx, &tbl, rn, funcname, ri)
+	if err != nil {
+		return errReturn(err)
+	}
+
+	deadline := p.ReturnDeadline(&s.Dt)
+	s1 := time.Now().Format(rlib.RRDATERECEIPTFMT) + "\n\n"
+	s1 += t.GetFullTransactantName() + "\n"
+	s1 += s.ForwardAddress + "\n\n"
+	s1 += fmt.Sprintf("Re: Rental Agreement %s, move-out date %s\n\n", rlib.IDtoShortString("RA", s.RAID), s.Dt.Format(rlib.RRDATERECEIPTFMT))
+	s1 += "This is an itemized statement of the security deposit held for the rental agreement above "
+	s1 += "and of the amounts deducted from it.\n"
+	tbl.SetSection1(s1)
+
+	tbl.AddRow()
+	tbl.Puts(-1, 0, "Security deposit held")
+	tbl.Putf(-1, 1, s.DepositHeld)
+
+	d := s.Disposition()
+	for _, it := range []int64{rlib.SecDepItemCharge, rlib.SecDepItemDamage} {
+		first := true
+		for i := 0; i < len(s.I); i++ {
+			if s.I[i].ItemType != it {
+				continue
+			}
+			if first {
+				tbl.AddRow()
+				tbl.AddRow()
+				if it == rlib.SecDepItemCharge {
+					tbl.Puts(-1, 0, "Unpaid rent and charges")
+				} else {
+					tbl.Puts(-1, 0, "Damages beyond normal wear and tear")
+				}
+				first = false
+			}
+			tbl.AddRow()
+			tbl.Puts(-1, 0, "    "+s.I[i].Description)
+			tbl.Putf(-1, 1, s.I[i].Amount)
+		}
+	}
+
+	tbl.AddRow()
+	tbl.AddLineAfter(len(tbl.Row) - 1)
+	tbl.AddRow()
+	tbl.Puts(-1, 0, "Total deductions")
+	tbl.Putf(-1, 1, s.Charges+s.Damages)
+	tbl.AddRow()
+	tbl.Puts(-1, 0, "Deposit applied to deductions")
+	tbl.Putf(-1, 1, s.DepositApplied)
+
+	tbl.AddRow()
+	if s.BalanceDue > 0 {
+		tbl.Puts(-1, 0, "Balance due from you")
+		tbl.Putf(-1, 1, s.BalanceDue)
+	} else {
+		tbl.Puts(-1, 0, "Refund due to you")
+		tbl.Putf(-1, 1, d.Refund)
+	}
+
+	tbl.AddRow()
+	tbl.AddRow()
+	if s.BalanceDue > 0 {
+		tbl.Puts(-1, 0, "The deposit did not cover the deductions. Please pay the balance due.")
+	} else if d.Refund > 0 {
+		tbl.Puts(-1, 0, fmt.Sprintf("The refund will be sent to the address above no later than %s.", deadline.Format(rlib.RRDATERECEIPTFMT)))
+	}
+	tbl.AddRow()
+	j := fmt.Sprintf("This statement is provided within %d days of the move-out date", p.ReturnDays)
+	if len(p.Jurisdiction) > 0 {
+		j += " as required by the laws of " + p.Jurisdiction
+	}
+	tbl.Puts(-1, 0, j+".")
+	if len(p.StatuteText) > 0 {
+		tbl.AddRow()
+		tbl.AddRow()
+		tbl.Puts(-1, 0, p.StatuteText)
+	}
+
+	tbl.AddRow()
+	tbl.AddRow()
+	tbl.AddRow()
+	tbl.Puts(-1, 0, "____________________________________________________")
+	tbl.AddRow()
+	tbl.Puts(-1, 0, ri.Xbiz.P.Name)
+
+	tbl.TightenColumns()
+	return tbl
+}
+
+// SecDepLetter generates a report
+func SecDepLetter(ctx context.Context, ri *ReporterInfo) string {
+	tbl := SecDepLetterTable(ctx, ri)
+	return ReportToString(&tbl, ri)
+}
@@ -0,0 +1,144 @@
+package rrpt
+
+import (
+	"context"
+	"gotable"
+	"rentroll/rlib"
+	"sort"
+)
+
+// taxLiability is the liability of one tax for the period
+type taxLiability struct {
+	Taxable  float64 // the charges taxed
+	Exempt   float64 // the charges not taxed: exempt agreements and stays
+	Charged  float64 // the tax charged
+	Refunded float64 // the tax refunded to stays that became exempt
+}
+
+// TaxLiabilityTable generates the tax liability report for filing: for each
+// tax, grouped by jurisdiction (taxing authority), the charges of ri.D1 -
+// ri.D2 that were taxed and exempt, the tax charged on them, the tax
+// refunded when stays became exempt and the net tax due.  A refund is
+// counted in the period it was posted, the date of the reversal, not in the
+// period of the charge, so the figures of a period already filed do not
+// change.
+//
+// INPUTS
+//    ctx = db context
+//    ri  = reporter info
+//
+// RETURNS
+//    the report table
+//-----------------------------------------------------------------------------
+func TaxLiabilityTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "TaxLiabilityTable"
+
+	// prepare and init some values
+	ri.RptHeaderD1 = true
+	ri.RptHeaderD2 = true
+
+	const (
+		Jurisdiction = 0
+		Tax          = iota
+		Taxable      = iota
+		Exempt       = iota
+		Charged      = iota
+		Refunded     = iota
+		Net          = iota
+	)
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("Jurisdiction", 25, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Tax", 20, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Taxable", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Exempt", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Tax Charged", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Refunded", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Net Tax Due", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	errReturn := func(err error) gotable.Table {
+		rlib.LogAndPrintError(funcname, err)
+		// set errors in section3 and return
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	err := TableReportHeaderBlock(ctx, &tbl, "Tax Liability", funcname, ri)
+	if err != nil {
+		return errReturn(err)
+	}
+
+	taxes, err := rlib.GetTaxesByBusiness(ctx, ri.Bid)
+	if err != nil {
+		return errReturn(err)
+	}
+	m, err := rlib.GetAssessmentTaxesInRange(ctx, ri.Bid, &ri.D1, &ri.D2)
+	if err != nil {
+		return errReturn(err)
+	}
+	l := map[int64]*taxLiability{}
+	for i := 0; i < len(taxes); i++ {
+		l[taxes[i].TAXID] = &taxLiability{}
+	}
+	for i := 0; i < len(m); i++ {
+		t, ok := l[m[i].TAXID]
+		if !ok {
+			continue
+		}
+		switch {
+		case m[i].FLAGS&(rlib.ATaxDoNotApply|rlib.ATaxExempt) != 0:
+			t.Exempt += m[i].Base
+		case m[i].TaxASMID != 0:
+			t.Taxable += m[i].Base
+			t.Charged += m[i].Amount
+		}
+	}
+	m, err = rlib.GetAssessmentTaxesRefundedInRange(ctx, ri.Bid, &ri.D1, &ri.D2)
+	if err != nil {
+		return errReturn(err)
+	}
+	for i := 0; i < len(m); i++ {
+		if t, ok := l[m[i].TAXID]; ok {
+			t.Refunded += m[i].Amount
+		}
+	}
+
+	sort.SliceStable(taxes, func(i, j int) bool { return taxes[i].TaxingAuthority < taxes[j].TaxingAuthority })
+	var total taxLiability
+	for i := 0; i < len(taxes); i++ {
+		t := l[taxes[i].TAXID]
+		tbl.AddRow()
+		tbl.Puts(-1, Jurisdiction, taxes[i].TaxingAuthority)
+		tbl.Puts(-1, Tax, taxes[i].Name)
+		tbl.Putf(-1, Taxable, rlib.RoundToCent(t.Taxable))
+		tbl.Putf(-1, Exempt, rlib.RoundToCent(t.Exempt))
+		tbl.Putf(-1, Charged, rlib.RoundToCent(t.Charged))
+		tbl.Putf(-1, Refunded, rlib.RoundToCent(t.Refunded))
+		tbl.Putf(-1, Net, rlib.RoundToCent(t.Charged-t.Refunded))
+		total.Taxable += t.Taxable
+		total.Exempt += t.Exempt
+		total.Charged += t.Charged
+		total.Refunded += t.Refunded
+	}
+	if len(taxes) > 0 {
+		tbl.AddLineAfter(len(tbl.Row) - 1)
+		tbl.AddRow()
+		tbl.Puts(-1, Jurisdiction, "Total")
+		tbl.Putf(-1, Taxable, rlib.RoundToCent(total.Taxable))
+		tbl.Putf(-1, Exempt, rlib.RoundToCent(total.Exempt))
+		tbl.Putf(-1, Charged, rlib.RoundToCent(total.Charged))
+		tbl.Putf(-1, Refunded, rlib.RoundToCent(total.Refunded))
+		tbl.Putf(-1, Net, rlib.RoundToCent(total.Charged-total.Refunded))
+	}
+
+	tbl.TightenColumns()
+	return tbl
+}
+
+// TaxLiability generates a report
+func TaxLiability(ctx context.Context, ri *ReporterInfo) string {
+	tbl := TaxLiabilityTable(ctx, ri)
+	return ReportToString(&tbl, ri)
+}
@@ -165,6 +165,7 @@ type ReporterInfo struct {
 	BlankLineAfterRptName bool            // true if a blank line should be added after the Report Name
 	Style                 int             // some printouts may have multiple styles. This is the selector
 	Xbiz                  *rlib.XBusiness // may not be set in all cases
+	Portfolio             []int64         // businesses of a portfolio report, if not set they are read from QueryParams
 	Handler               func(context.Context, *ReporterInfo) string
 	QueryParams           *url.Values
 }
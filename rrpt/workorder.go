@@ -0,0 +1,163 @@
+package rrpt
+
+import (
+	"context"
+	"fmt"
+	"gotable"
+	"rentroll/rlib"
+	"time"
+)
+
+// WorkOrderAgingBuckets are the age ranges, in days since the work order was
+// reported, used by the work order aging report.  The last bucket has no
+// upper limit.
+var WorkOrderAgingBuckets = []struct {
+	Name string
+	Max  int64 // the bucket holds the ages up to and including Max
+}{
+	{"0-7", 7},
+	{"8-14", 14},
+	{"15-30", 30},
+	{"31-60", 60},
+	{"Over 60", -1},
+}
+
+// workOrderAgingBucket returns the index of the bucket for a work order
+// reported days ago
+func workOrderAgingBucket(days int64) int {
+	for i := 0; i < len(WorkOrderAgingBuckets)-1; i++ {
+		if days <= WorkOrderAgingBuckets[i].Max {
+			return i
+		}
+	}
+	return len(WorkOrderAgingBuckets) - 1
+}
+
+// WorkOrderAgingTable generates the work order aging report: the work
+// orders of the business reported before ri.D2 that are not yet completed,
+// with the number of days they have been open as of ri.D2.  It ends with the
+// count and the estimated cost of the work orders in each aging bucket.
+//
+// INPUTS
+//    ctx = db context
+//    ri  = reporter info, ri.D2 is the as-of date
+//
+// RETURNS
+//    the report table
+//-----------------------------------------------------------------------------
+func WorkOrderAgingTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "WorkOrderAgingTable"
+
+	// prepare and init some values
+	ri.RptHeaderD1 = false
+	ri.RptHeaderD2 = true
+
+	const (
+		WOID      = 0
+		Rentable  = iota
+		Category  = iota
+		Priority  = iota
+		Status    = iota
+		Vendor    = iota
+		Reported  = iota
+		Scheduled = iota
+		DaysOpen  = iota
+		Aging     = iota
+		Cost      = iota
+	)
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("Work Order", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Rentable", 15, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Category", 15, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Priority", 9, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Status", 11, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Vendor", 20, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Reported", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Scheduled", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Days Open", 9, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Aging", 8, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Est. Cost", 10, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	errReturn := func(err error) gotable.Table {
+		rlib.LogAndPrintError(funcname, err)
+		// set errors in section3 and return
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	err := TableReportHeaderBlock(ctx, &tbl, "Work Order Aging", funcname, ri)
+	if err != nil {
+		return errReturn(err)
+	}
+
+	dt := ri.D2
+	m, err := rlib.GetOpenWorkOrders(ctx, ri.Bid, &dt)
+	if err != nil {
+		return errReturn(err)
+	}
+
+	count := make([]int64, len(WorkOrderAgingBuckets))
+	cost := make([]float64, len(WorkOrderAgingBuckets))
+	total := float64(0)
+	rnames := map[int64]string{}
+	for i := 0; i < len(m); i++ {
+		name, ok := rnames[m[i].RID]
+		if !ok {
+			rnt, err := rlib.GetRentable(ctx, m[i].RID)
+			if err != nil {
+				return errReturn(err)
+			}
+			name = rnt.RentableName
+			rnames[m[i].RID] = name
+		}
+		days := int64(dt.Sub(m[i].Dt) / (24 * time.Hour))
+		b := workOrderAgingBucket(days)
+		count[b]++
+		cost[b] += m[i].TotalCost()
+		total += m[i].TotalCost()
+
+		tbl.AddRow()
+		tbl.Puts(-1, WOID, m[i].IDtoShortString())
+		tbl.Puts(-1, Rentable, name)
+		tbl.Puts(-1, Category, m[i].Category)
+		tbl.Puts(-1, Priority, m[i].PriorityName())
+		tbl.Puts(-1, Status, m[i].StatusName())
+		tbl.Puts(-1, Vendor, m[i].Vendor)
+		tbl.Putd(-1, Reported, m[i].Dt)
+		if m[i].ScheduledStart.Year() > 1970 {
+			tbl.Puts(-1, Scheduled, m[i].ScheduledStart.Format(rlib.RRDATEFMT3))
+		}
+		tbl.Puti(-1, DaysOpen, days)
+		tbl.Puts(-1, Aging, WorkOrderAgingBuckets[b].Name)
+		tbl.Putf(-1, Cost, m[i].TotalCost())
+	}
+
+	//---------------------------------
+	// summary by aging bucket
+	//---------------------------------
+	if len(m) > 0 {
+		tbl.AddLineAfter(len(tbl.Row) - 1)
+	}
+	for b := 0; b < len(WorkOrderAgingBuckets); b++ {
+		tbl.AddRow()
+		tbl.Puts(-1, WOID, fmt.Sprintf("%d open", count[b]))
+		tbl.Puts(-1, Aging, WorkOrderAgingBuckets[b].Name)
+		tbl.Putf(-1, Cost, cost[b])
+	}
+	tbl.AddRow()
+	tbl.Puts(-1, WOID, fmt.Sprintf("%d open", len(m)))
+	tbl.Puts(-1, Aging, "Total")
+	tbl.Putf(-1, Cost, total)
+
+	tbl.TightenColumns()
+	return tbl
+}
+
+// WorkOrderAging generates a report
+func WorkOrderAging(ctx context.Context, ri *ReporterInfo) string {
+	tbl := WorkOrderAgingTable(ctx, ri)
+	return ReportToString(&tbl, ri)
+}
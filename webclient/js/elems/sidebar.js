@@ -73,15 +73,22 @@ window.buildSidebar = function(flag) {
                            //{ id: 'RPTasmrpt',     text: 'Assessments',                     icon: 'far fa-file-alt' },
                            //{ id: 'RPTb',          text: 'Business Units',                  icon: 'far fa-file-alt' },
                            { id: 'RPTar',           text: 'Account Rules',                   icon: 'far fa-file-alt' },
+                           { id: 'RPTbs',           text: 'Balance Sheet',                   icon: 'far fa-file-alt' },
+                           { id: 'RPTbva',          text: 'Budget Variance',                 icon: 'far fa-file-alt' },
                            { id: 'RPTcoa',          text: 'Chart Of Accounts',               icon: 'far fa-file-alt' },
                            //{ id: 'RPTdpm',        text: 'Deposit Methods',                 icon: 'far fa-file-alt' },
                            //{ id: 'RPTdep',        text: 'Depository Accounts',             icon: 'far fa-file-alt' },
                            { id: 'RPTdelinq',       text: 'Delinquency',                     icon: 'far fa-file-alt' },
                            { id: 'RPTgsr',          text: 'GSR',                             icon: 'far fa-file-alt' },
+                           { id: 'RPTis',           text: 'Income Statement',                icon: 'far fa-file-alt' },
                            { id: 'RPTj',            text: 'Journal',                         icon: 'far fa-file-alt' },
                            { id: 'RPTl',            text: 'Ledger',                          icon: 'far fa-file-alt' },
                            { id: 'RPTla',           text: 'Ledger Activity',                 icon: 'far fa-file-alt' },
+                           { id: 'RPTleaseexp',     text: 'Lease Expirations',               icon: 'far fa-file-alt' },
+                           { id: 'RPTmtm',          text: 'Month To Month',                  icon: 'far fa-file-alt' },
+                           { id: 'RPTnightaudit',   text: 'Night Audit',                     icon: 'far fa-file-alt' },
                            { id: 'RPTpeople',       text: app.sTransactant,                  icon: 'far fa-file-alt' },
+                           { id: 'RPTraterec',      text: 'Rate Recommendations',            icon: 'far fa-file-alt' },
                            //{ id: 'RPTpmt',        text: 'Payment Types',                   icon: 'far fa-file-alt' },
                            //{ id: 'RPTrcptlist',   text: 'Receipts List',                    icon: 'far fa-file-alt' },
                            { id: 'RPTrcbt',         text: app.sRentable+' Type Counts',      icon: 'far fa-file-alt' },
@@ -90,6 +97,7 @@ window.buildSidebar = function(flag) {
                            { id: 'RPTrat',          text: app.sRentalAgreement+' Templates', icon: 'far fa-file-alt' },
                            { id: 'RPTrt',           text: app.sRentable+' Types',            icon: 'far fa-file-alt' },
                            { id: 'RPTrr',           text: 'RentRoll',                        icon: 'far fa-file-alt' },
+                           { id: 'RPTtax',          text: 'Tax Liability',                   icon: 'far fa-file-alt' },
                            //{ id: 'RPTstatements', text: 'Statements',                      icon: 'far fa-file-alt' },
                            //{ id: 'RPTsl',         text: 'String Lists',                    icon: 'far fa-file-alt' },
                            { id: 'RPTtb',           text: 'Trial Balance',                   icon: 'far fa-file-alt' },//
@@ -272,19 +280,26 @@ window.buildSidebar = function(flag) {
                         case 'RPTar':
                         case 'RPTasmrpt':
                         case 'RPTb':
+                        case 'RPTbs':
+                        case 'RPTbva':
                         case 'RPTcoa':
                         case 'RPTdelinq':
                         case 'RPTdep':
                         case 'RPTdpm':
                         case 'RPTgsr':
+                        case 'RPTis':
                         case 'RPTj':
                         case 'RPTl':
                         case 'RPTla':
+                        case 'RPTleaseexp':
+                        case 'RPTmtm':
+                        case 'RPTnightaudit':
                         case 'RPTpeople':
                         case 'RPTpmt':
                         case 'RPTr':
                         case 'RPTra':
                         case 'RPTrat':
+                        case 'RPTraterec':
                         case 'RPTrcbt':  // rentable count by type
                         case 'RPTrcptlist':
                         case 'RPTrcpt':
@@ -292,6 +307,7 @@ window.buildSidebar = function(flag) {
                         case 'RPTrt':
                         case 'RPTsl':
                         case 'RPTstatements':
+                        case 'RPTtax':
                         case 'RPTtb':
                             showReport(target);
                             app.last.report = target;
@@ -0,0 +1,55 @@
+package worker
+
+import (
+	"context"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+	"tws"
+)
+
+// ExportARI is a worker that is called by TWS periodically to send the
+// rates and availability of the rate plans flagged for the GDS and Sabre
+// channels to the export destinations set in each business's properties.
+// After processing all businesses it reschedules itself to be called again
+// the next day.
+//-----------------------------------------------------------------------------
+func ExportARI(item *tws.Item) {
+	tws.ItemWorking(item)
+	now := time.Now()
+	ctx := context.Background()
+	ExportARICore(ctx, &now)
+
+	// reschedule for tomorrow...
+	resched := now.AddDate(0, 0, 1)
+	tws.RescheduleItem(item, resched)
+}
+
+// ExportARICore provides a more testable calling routine for the rate and
+// availability export
+//-----------------------------------------------------------------------------
+func ExportARICore(ctx context.Context, now *time.Time) {
+	expire := now.Add(10 * time.Minute)
+	s := rlib.SessionNew("BotToken-"+rlib.BotReg[rlib.ARIExportBot].Designator,
+		rlib.BotReg[rlib.ARIExportBot].Designator,
+		rlib.BotReg[rlib.ARIExportBot].Designator,
+		rlib.ARIExportBot, "", -1, &expire)
+	ctx = rlib.SetSessionContextKey(ctx, s)
+
+	m, err := rlib.GetAllBusinesses(ctx)
+	if err != nil {
+		rlib.Ulog("Error with rlib.GetAllBusinesses: %s\n", err.Error())
+		return
+	}
+	dt := rlib.DateAtTimeZero(*now)
+	for i := 0; i < len(m); i++ {
+		n, err := bizlogic.ExportARI(ctx, m[i].BID, &dt)
+		if err != nil {
+			rlib.Ulog("Error with bizlogic.ExportARI for BID %d: %s\n", m[i].BID, err.Error())
+			continue
+		}
+		if n > 0 {
+			rlib.Ulog("ARIExportBot: %d messages sent for %s\n", n, m[i].Designation)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package worker
 
 import (
 	"context"
+	"rentroll/bizlogic"
 	"rentroll/rlib"
 	"time"
 	"tws"
@@ -10,8 +11,9 @@ import (
 // CreateAssessmentInstances is a worker that is called by TWS periodically to
 // check for recurring assessments that have instances needing to be created.
 // When their instance date arrives, this routine will generate the new instance.
-// After generating all instances whose time has arrived it will reschedule itself
-// to be called again the next day.
+// After generating all instances whose time has arrived, and taxing the rent
+// charges of the period, it will reschedule itself to be called again the
+// next day.
 //-----------------------------------------------------------------------------
 func CreateAssessmentInstances(item *tws.Item) {
 	tws.ItemWorking(item)
@@ -54,6 +56,22 @@ func CreateAsmInstCore(ctx context.Context, now *time.Time) {
 			if err != nil {
 				rlib.Ulog("Error with rlib.GenerateRecurInstances: %s\n", err.Error())
 			}
+
+			// tax the rent charges of the period...
+			tx, tctx, err := rlib.NewTransactionWithContext(ctx)
+			if err != nil {
+				rlib.Ulog("Error with rlib.NewTransactionWithContext: %s\n", err.Error())
+				continue
+			}
+			if _, err = bizlogic.AssessTaxes(tctx, m[i].BID, &d1, &d2); err != nil {
+				tx.Rollback()
+				rlib.Ulog("Error with bizlogic.AssessTaxes for BID %d: %s\n", m[i].BID, err.Error())
+				continue
+			}
+			if err = tx.Commit(); err != nil {
+				tx.Rollback()
+				rlib.Ulog("Error committing taxes for BID %d: %s\n", m[i].BID, err.Error())
+			}
 		}
 	}
 }
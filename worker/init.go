@@ -26,6 +26,11 @@ var WorkerRegistry = map[string]Worker{
 	rlib.BotReg[rlib.ARSliceCacheBot].Designator:   {rlib.BotReg[rlib.ARSliceCacheBot], uint64(0), CleanARSliceCache},
 	rlib.BotReg[rlib.TLReportBot].Designator:       {rlib.BotReg[rlib.TLReportBot], uint64(0), TLChecker},
 	rlib.BotReg[rlib.TLInstanceBot].Designator:     {rlib.BotReg[rlib.TLInstanceBot], uint64(0), TLInstanceBot},
+	rlib.BotReg[rlib.LateFeeBot].Designator:        {rlib.BotReg[rlib.LateFeeBot], uint64(0), AssessLateFees},
+	rlib.BotReg[rlib.RentEscalationBot].Designator: {rlib.BotReg[rlib.RentEscalationBot], uint64(0), EscalateRents},
+	rlib.BotReg[rlib.MonthToMonthBot].Designator:   {rlib.BotReg[rlib.MonthToMonthBot], uint64(0), ConvertMonthToMonth},
+	rlib.BotReg[rlib.ARIExportBot].Designator:      {rlib.BotReg[rlib.ARIExportBot], uint64(0), ExportARI},
+	rlib.BotReg[rlib.NightAuditBot].Designator:     {rlib.BotReg[rlib.NightAuditBot], uint64(0), RunNightAudits},
 
 	//------------------------------------------------------------------
 	// The following workers ARE available to users for tasklists
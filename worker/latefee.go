@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"context"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+	"tws"
+)
+
+// AssessLateFees is a worker that is called by TWS periodically to assess
+// late fees on unpaid assessments. Each business defines its own late fee
+// policy in its business properties. After processing all businesses it
+// reschedules itself to be called again the next day.
+//-----------------------------------------------------------------------------
+func AssessLateFees(item *tws.Item) {
+	tws.ItemWorking(item)
+	now := time.Now()
+	ctx := context.Background()
+	AssessLateFeesCore(ctx, &now)
+
+	// reschedule for tomorrow...
+	resched := now.AddDate(0, 0, 1)
+	tws.RescheduleItem(item, resched)
+}
+
+// AssessLateFeesCore provides a more testable calling routine for assessing
+// late fees.  The late fees of each business are assessed in a transaction
+// of their own.
+//-----------------------------------------------------------------------------
+func AssessLateFeesCore(ctx context.Context, now *time.Time) {
+	expire := now.Add(10 * time.Minute)
+	s := rlib.SessionNew("BotToken-"+rlib.BotReg[rlib.LateFeeBot].Designator,
+		rlib.BotReg[rlib.LateFeeBot].Designator,
+		rlib.BotReg[rlib.LateFeeBot].Designator,
+		rlib.LateFeeBot, "", -1, &expire)
+	ctx = rlib.SetSessionContextKey(ctx, s)
+
+	m, err := rlib.GetAllBusinesses(ctx)
+	if err != nil {
+		rlib.Ulog("Error with rlib.GetAllBusinesses: %s\n", err.Error())
+		return
+	}
+	dt := rlib.DateAtTimeZero(*now)
+	for i := 0; i < len(m); i++ {
+		tx, tctx, err := rlib.NewTransactionWithContext(ctx)
+		if err != nil {
+			rlib.Ulog("Error with rlib.NewTransactionWithContext: %s\n", err.Error())
+			return
+		}
+		n, err := bizlogic.AssessLateFees(tctx, m[i].BID, &dt)
+		if err != nil {
+			tx.Rollback()
+			rlib.Ulog("Error with bizlogic.AssessLateFees for BID %d: %s\n", m[i].BID, err.Error())
+			continue
+		}
+		if err = tx.Commit(); err != nil {
+			tx.Rollback()
+			rlib.Ulog("Error committing late fees for BID %d: %s\n", m[i].BID, err.Error())
+			continue
+		}
+		if n > 0 {
+			rlib.Ulog("LateFeeBot: %d late fee assessments created for %s\n", n, m[i].Designation)
+		}
+	}
+}
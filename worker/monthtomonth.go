@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"context"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+	"tws"
+)
+
+// ConvertMonthToMonth is a worker that is called by TWS periodically to
+// extend month to month the rental agreements set for month to month
+// renewal whose term has ended.  Each business enables it and sets the
+// month to month premium in its business properties. After processing all
+// businesses it reschedules itself to be called again the next day.
+//-----------------------------------------------------------------------------
+func ConvertMonthToMonth(item *tws.Item) {
+	tws.ItemWorking(item)
+	now := time.Now()
+	ctx := context.Background()
+	ConvertMonthToMonthCore(ctx, &now)
+
+	// reschedule for tomorrow...
+	resched := now.AddDate(0, 0, 1)
+	tws.RescheduleItem(item, resched)
+}
+
+// ConvertMonthToMonthCore provides a more testable calling routine for the
+// month to month extensions
+//-----------------------------------------------------------------------------
+func ConvertMonthToMonthCore(ctx context.Context, now *time.Time) {
+	expire := now.Add(10 * time.Minute)
+	s := rlib.SessionNew("BotToken-"+rlib.BotReg[rlib.MonthToMonthBot].Designator,
+		rlib.BotReg[rlib.MonthToMonthBot].Designator,
+		rlib.BotReg[rlib.MonthToMonthBot].Designator,
+		rlib.MonthToMonthBot, "", -1, &expire)
+	ctx = rlib.SetSessionContextKey(ctx, s)
+
+	m, err := rlib.GetAllBusinesses(ctx)
+	if err != nil {
+		rlib.Ulog("Error with rlib.GetAllBusinesses: %s\n", err.Error())
+		return
+	}
+	dt := rlib.DateAtTimeZero(*now)
+	for i := 0; i < len(m); i++ {
+		tx, tctx, err := rlib.NewTransactionWithContext(ctx)
+		if err != nil {
+			rlib.Ulog("Error with rlib.NewTransactionWithContext: %s\n", err.Error())
+			return
+		}
+		n, err := bizlogic.ConvertMonthToMonth(tctx, m[i].BID, &dt)
+		if err != nil {
+			tx.Rollback()
+			rlib.Ulog("Error with bizlogic.ConvertMonthToMonth for BID %d: %s\n", m[i].BID, err.Error())
+			continue
+		}
+		if err = tx.Commit(); err != nil {
+			tx.Rollback()
+			rlib.Ulog("Error committing month to month extensions for BID %d: %s\n", m[i].BID, err.Error())
+			continue
+		}
+		if n > 0 {
+			rlib.Ulog("MonthToMonthBot: %d month to month extensions for %s\n", n, m[i].Designation)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"context"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+	"tws"
+)
+
+// nightAuditMaxDays limits the business dates the night audit bot audits
+// for one business in one run
+const nightAuditMaxDays = 31
+
+// RunNightAudits is a worker that is called by TWS periodically to run the
+// night audit of the businesses whose folio settings enable AutoAudit.
+// Each business date that has ended is audited.  After processing all
+// businesses it reschedules itself to be called again the next day.
+//-----------------------------------------------------------------------------
+func RunNightAudits(item *tws.Item) {
+	tws.ItemWorking(item)
+	now := time.Now()
+	ctx := context.Background()
+	RunNightAuditsCore(ctx, &now)
+
+	// reschedule for tomorrow...
+	resched := now.AddDate(0, 0, 1)
+	tws.RescheduleItem(item, resched)
+}
+
+// RunNightAuditsCore provides a more testable calling routine for the night
+// audits
+//-----------------------------------------------------------------------------
+func RunNightAuditsCore(ctx context.Context, now *time.Time) {
+	expire := now.Add(10 * time.Minute)
+	s := rlib.SessionNew("BotToken-"+rlib.BotReg[rlib.NightAuditBot].Designator,
+		rlib.BotReg[rlib.NightAuditBot].Designator,
+		rlib.BotReg[rlib.NightAuditBot].Designator,
+		rlib.NightAuditBot, "", -1, &expire)
+	ctx = rlib.SetSessionContextKey(ctx, s)
+
+	m, err := rlib.GetAllBusinesses(ctx)
+	if err != nil {
+		rlib.Ulog("Error with rlib.GetAllBusinesses: %s\n", err.Error())
+		return
+	}
+	today := rlib.DateAtTimeZero(*now)
+	for i := 0; i < len(m); i++ {
+		p, err := rlib.GetFolioPolicy(ctx, m[i].BID, "general")
+		if err != nil {
+			rlib.Ulog("Error with rlib.GetFolioPolicy for BID %d: %s\n", m[i].BID, err.Error())
+			continue
+		}
+		if !p.AutoAudit {
+			continue
+		}
+		for j := 0; j < nightAuditMaxDays; j++ {
+			bd, err := rlib.GetBusinessDate(ctx, m[i].BID)
+			if err != nil {
+				rlib.Ulog("Error with rlib.GetBusinessDate for BID %d: %s\n", m[i].BID, err.Error())
+				break
+			}
+			if !bd.Before(today) {
+				break // the business date has not ended
+			}
+			tx, tctx, err := rlib.NewTransactionWithContext(ctx)
+			if err != nil {
+				rlib.Ulog("Error with rlib.NewTransactionWithContext: %s\n", err.Error())
+				return
+			}
+			na, err := bizlogic.RunNightAudit(tctx, m[i].BID, now)
+			if err != nil {
+				tx.Rollback()
+				rlib.Ulog("Error with bizlogic.RunNightAudit for BID %d: %s\n", m[i].BID, err.Error())
+				break
+			}
+			if err = tx.Commit(); err != nil {
+				tx.Rollback()
+				rlib.Ulog("Error committing night audit for BID %d: %s\n", m[i].BID, err.Error())
+				break
+			}
+			rlib.Ulog("NightAuditBot: %s audited %s, %d of %d rooms occupied\n", m[i].Designation, na.Dt.Format(rlib.RRDATEFMT4), na.Occupied, na.Rooms)
+		}
+	}
+}
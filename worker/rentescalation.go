@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"context"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+	"tws"
+)
+
+// EscalateRents is a worker that is called by TWS periodically to apply the
+// scheduled rent escalations of the rental agreements. Each business
+// enables escalations and sets their interval in its business properties.
+// After processing all businesses it reschedules itself to be called again
+// the next day.
+//-----------------------------------------------------------------------------
+func EscalateRents(item *tws.Item) {
+	tws.ItemWorking(item)
+	now := time.Now()
+	ctx := context.Background()
+	EscalateRentsCore(ctx, &now)
+
+	// reschedule for tomorrow...
+	resched := now.AddDate(0, 0, 1)
+	tws.RescheduleItem(item, resched)
+}
+
+// EscalateRentsCore provides a more testable calling routine for applying
+// rent escalations
+//-----------------------------------------------------------------------------
+func EscalateRentsCore(ctx context.Context, now *time.Time) {
+	expire := now.Add(10 * time.Minute)
+	s := rlib.SessionNew("BotToken-"+rlib.BotReg[rlib.RentEscalationBot].Designator,
+		rlib.BotReg[rlib.RentEscalationBot].Designator,
+		rlib.BotReg[rlib.RentEscalationBot].Designator,
+		rlib.RentEscalationBot, "", -1, &expire)
+	ctx = rlib.SetSessionContextKey(ctx, s)
+
+	m, err := rlib.GetAllBusinesses(ctx)
+	if err != nil {
+		rlib.Ulog("Error with rlib.GetAllBusinesses: %s\n", err.Error())
+		return
+	}
+	dt := rlib.DateAtTimeZero(*now)
+	for i := 0; i < len(m); i++ {
+		tx, tctx, err := rlib.NewTransactionWithContext(ctx)
+		if err != nil {
+			rlib.Ulog("Error with rlib.NewTransactionWithContext: %s\n", err.Error())
+			return
+		}
+		n, err := bizlogic.EscalateRents(tctx, m[i].BID, &dt)
+		if err != nil {
+			tx.Rollback()
+			rlib.Ulog("Error with bizlogic.EscalateRents for BID %d: %s\n", m[i].BID, err.Error())
+			continue
+		}
+		if err = tx.Commit(); err != nil {
+			tx.Rollback()
+			rlib.Ulog("Error committing rent escalations for BID %d: %s\n", m[i].BID, err.Error())
+			continue
+		}
+		if n > 0 {
+			rlib.Ulog("RentEscalationBot: %d rent assessments escalated for %s\n", n, m[i].Designation)
+		}
+	}
+}
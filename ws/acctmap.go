@@ -0,0 +1,203 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"sort"
+)
+
+// AcctMapGrid is a GL account of the business with its consolidated
+// account
+type AcctMapGrid struct {
+	Recid            int64 `json:"recid"`
+	AMID             int64 // 0 if the account is not mapped
+	BID              int64
+	BUD              rlib.XJSONBud
+	LID              int64
+	GLNumber         string
+	Name             string
+	ConsolidatedGLNo string
+	ConsolidatedName string
+}
+
+// AcctMapListResponse is the response to the list command
+type AcctMapListResponse struct {
+	Status  string        `json:"status"`
+	Total   int64         `json:"total"`
+	Records []AcctMapGrid `json:"records"`
+}
+
+// AcctMapSaveForm maps a GL account to a consolidated account
+type AcctMapSaveForm struct {
+	GLNumber         string
+	ConsolidatedGLNo string
+	ConsolidatedName string
+}
+
+// AcctMapSave is the input data format for the save command
+type AcctMapSave struct {
+	Cmd    string          `json:"cmd"`
+	Record AcctMapSaveForm `json:"record"`
+}
+
+// SvcHandlerAcctMap handles the maps of the GL accounts of a business
+// to the consolidated chart of accounts used by the portfolio reports, for
+// the map d.ID
+//
+// The server command can be:
+//
+//	list    - every GL account with its consolidated account
+//	save    - map a GL account to a consolidated account
+//	delete  - remove a map, the account is consolidated as itself
+//
+// -----------------------------------------------------------------------------
+func SvcHandlerAcctMap(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerAcctMap"
+	var (
+		err error
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("Request: %s:  BID = %d,  AMID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get", "list":
+		listAcctMaps(w, r, d)
+	case "save":
+		saveAcctMap(w, r, d)
+	case "delete":
+		deleteAcctMap(w, r, d)
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// listAcctMaps lists the GL accounts of a business with their
+// consolidated accounts
+//
+//	wsdoc {
+//	 @Title  List GL Account Maps
+//		@URL /v1/acctmap/:BUI
+//	 @Method  POST
+//		@Synopsis List the consolidated account of each GL account
+//	 @Description  Returns every GL account of the business, by GL number, with
+//	 @Description  the consolidated account it is reported under in the
+//	 @Description  portfolio reports.  AMID is 0 for the accounts that are not
+//	 @Description  mapped, they are consolidated as themselves.
+//		@Input WebGridSearchRequest
+//	 @Response AcctMapListResponse
+//
+// wsdoc }
+func listAcctMaps(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "listAcctMaps"
+	var g AcctMapListResponse
+
+	m, err := rlib.GetConsolidatedAccounts(r.Context(), d.BID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	bud := rlib.GetBUDFromBIDList(d.BID)
+	for lid, a := range m {
+		gl := rlib.RRdb.BizTypes[d.BID].GLAccounts[lid]
+		g.Records = append(g.Records, AcctMapGrid{
+			Recid:            lid,
+			AMID:             a.AMID,
+			BID:              d.BID,
+			BUD:              bud,
+			LID:              lid,
+			GLNumber:         gl.GLNumber,
+			Name:             gl.Name,
+			ConsolidatedGLNo: a.GLNumber,
+			ConsolidatedName: a.Name,
+		})
+	}
+	sort.Slice(g.Records, func(i, j int) bool { return g.Records[i].GLNumber < g.Records[j].GLNumber })
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveAcctMap maps a GL account to a consolidated account
+//
+//	wsdoc {
+//	 @Title  Save GL Account Map
+//		@URL /v1/acctmap/:BUI
+//	 @Method  POST
+//		@Synopsis Map a GL account to a consolidated account
+//	 @Description  Reports GLNumber under ConsolidatedGLNo in the portfolio
+//	 @Description  reports.  ConsolidatedName defaults to the name of the
+//	 @Description  account.  An existing map of the account is replaced.
+//		@Input AcctMapSave
+//	 @Response SvcStatusResponse
+//
+// wsdoc }
+func saveAcctMap(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveAcctMap"
+	var foo AcctMapSave
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	a, errlist := bizlogic.SaveAcctMap(ctx, d.BID, foo.Record.GLNumber, foo.Record.ConsolidatedGLNo, foo.Record.ConsolidatedName)
+	if len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, a.AMID)
+}
+
+// deleteAcctMap removes the map of a GL account
+//
+//	wsdoc {
+//	 @Title  Delete GL Account Map
+//		@URL /v1/acctmap/:BUI/:AMID
+//	 @Method  POST
+//		@Synopsis Delete a GL account map
+//	 @Description  Removes the map AMID.  The account is then consolidated
+//	 @Description  under its own GL number and name.
+//		@Input WebGridSearchRequest
+//	 @Response SvcStatusResponse
+//
+// wsdoc }
+func deleteAcctMap(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "deleteAcctMap"
+
+	a, err := rlib.GetAcctMap(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.AMID == 0 || a.BID != d.BID {
+		err = fmt.Errorf("GL account map %d not found", d.ID)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = rlib.DeleteAcctMap(r.Context(), a.AMID); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
@@ -0,0 +1,572 @@
+package ws
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"strings"
+	"time"
+)
+
+// ACHEnrollmentForm is a payor's ACH autopay enrollment as seen by the UI.
+// The account number is masked when it is sent to the UI.  If a masked
+// account number is sent back on save, the saved account number is kept.
+type ACHEnrollmentForm struct {
+	Recid         int64 `json:"recid"`
+	AEID          int64
+	BID           int64
+	BUD           rlib.XJSONBud
+	TCID          int64
+	AccountName   string
+	RoutingNumber string
+	AccountNumber string
+	MaxAmount     float64
+	DtStart       rlib.JSONDate
+	DtStop        rlib.JSONDate
+	FLAGS         uint64 // 1<<0 = savings account, 1<<1 = suspended
+	LastModTime   rlib.JSONDateTime
+	LastModBy     int64
+	CreateTS      rlib.JSONDateTime
+	CreateBy      int64
+}
+
+// ACHEnrollmentGetResponse is the response to an ACH enrollment get request
+type ACHEnrollmentGetResponse struct {
+	Status string            `json:"status"`
+	Record ACHEnrollmentForm `json:"record"`
+}
+
+// ACHEnrollmentSave is the input data format for an ACH enrollment save
+type ACHEnrollmentSave struct {
+	Cmd    string            `json:"cmd"`
+	Record ACHEnrollmentForm `json:"record"`
+}
+
+// ACHEntryGrid is one debit in an ACH batch
+type ACHEntryGrid struct {
+	Recid       int64 `json:"recid"`
+	AENID       int64
+	AEID        int64
+	TCID        int64
+	RCPTID      int64
+	Amount      float64
+	TraceNumber string
+	ReturnCode  string
+	FLAGS       uint64 // 1<<0 = returned
+}
+
+// ACHBatchForm is an ACH batch and its debits
+type ACHBatchForm struct {
+	Recid       int64 `json:"recid"`
+	ABID        int64
+	BID         int64
+	BUD         rlib.XJSONBud
+	Dt          rlib.JSONDate
+	EntryCount  int64
+	TotalAmount float64
+	FLAGS       uint64 // 1<<0 = settled
+	Entries     []ACHEntryGrid
+}
+
+// ACHBatchResponse is the response to the ACH batch requests
+type ACHBatchResponse struct {
+	Status string       `json:"status"`
+	Record ACHBatchForm `json:"record"`
+}
+
+// ACHBatchCreate is the input data format for the ACH batch create command
+type ACHBatchCreate struct {
+	Cmd string        `json:"cmd"`
+	Dt  rlib.JSONDate // effective entry date of the debits
+}
+
+// maskACHAccount returns s with all but its last 4 characters replaced by *
+func maskACHAccount(s string) string {
+	if len(s) <= 4 {
+		return s
+	}
+	return strings.Repeat("*", len(s)-4) + s[len(s)-4:]
+}
+
+// SvcHandlerACHEnrollment handles the ACH enrollment requests for the
+// enrollment d.ID
+//
+// The server command can be:
+//      get     - read the enrollment
+//      save    - insert or update the enrollment
+//      delete  - delete the enrollment
+//-----------------------------------------------------------------------------------
+func SvcHandlerACHEnrollment(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerACHEnrollment"
+	var (
+		err error
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("Request: %s:  BID = %d,  AEID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		if d.ID <= 0 {
+			err = fmt.Errorf("ACHEnrollmentID is required but was not specified")
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		getACHEnrollment(w, r, d)
+	case "save":
+		saveACHEnrollment(w, r, d)
+	case "delete":
+		deleteACHEnrollment(w, r, d)
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getACHEnrollment returns the requested ACH enrollment
+// wsdoc {
+//  @Title  Get ACH Enrollment
+//	@URL /v1/achenroll/:BUI/:AEID
+//  @Method  POST
+//	@Synopsis Get a payor's ACH autopay enrollment
+//  @Description  Returns the enrollment with the account number masked
+//	@Input WebGridSearchRequest
+//  @Response ACHEnrollmentGetResponse
+// wsdoc }
+func getACHEnrollment(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getACHEnrollment"
+	var g ACHEnrollmentGetResponse
+
+	rlib.Console("entered %s\n", funcname)
+
+	a, err := rlib.GetACHEnrollment(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.AEID == 0 || a.BID != d.BID {
+		err = fmt.Errorf("ACH enrollment %d not found", d.ID)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	rlib.MigrateStructVals(&a, &g.Record)
+	g.Record.Recid = a.AEID
+	g.Record.BUD = rlib.GetBUDFromBIDList(a.BID)
+	g.Record.AccountNumber = maskACHAccount(a.AccountNumber)
+
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveACHEnrollment saves an ACH enrollment
+// wsdoc {
+//  @Title  Save ACH Enrollment
+//	@URL /v1/achenroll/:BUI/:AEID
+//  @Method  POST
+//	@Synopsis Insert or update a payor's ACH autopay enrollment
+//  @Description  The routing and account numbers are validated and stored
+//  @Description  encrypted. Use AEID 0 to add a new enrollment.
+//	@Input ACHEnrollmentSave
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveACHEnrollment(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveACHEnrollment"
+	var foo ACHEnrollmentSave
+
+	rlib.Console("Entered %s\n", funcname)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+
+	var a rlib.ACHEnrollment
+	rlib.MigrateStructVals(&foo.Record, &a)
+	a.BID = d.BID
+	if time.Time(foo.Record.DtStop).IsZero() {
+		a.DtStop = rlib.ENDOFTIME
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	//------------------------------------------------------------
+	// the UI only ever sees a masked account number, keep the
+	// saved one unless a new number was entered
+	//------------------------------------------------------------
+	if a.AEID > 0 {
+		old, err := rlib.GetACHEnrollment(ctx, a.AEID)
+		if err != nil {
+			tx.Rollback()
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if old.AEID == 0 || old.BID != d.BID {
+			tx.Rollback()
+			SvcErrorReturn(w, fmt.Errorf("ACH enrollment %d not found", a.AEID), funcname)
+			return
+		}
+		if strings.HasPrefix(a.AccountNumber, "*") {
+			a.AccountNumber = old.AccountNumber
+		}
+	}
+
+	if errlist := bizlogic.ValidateACHEnrollment(ctx, &a); len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrorReturn(w, bizlogic.BizErrorListToError(errlist), funcname)
+		return
+	}
+
+	if a.AEID == 0 {
+		_, err = rlib.InsertACHEnrollment(ctx, &a)
+	} else {
+		err = rlib.UpdateACHEnrollment(ctx, &a)
+	}
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, a.AEID)
+}
+
+// deleteACHEnrollment deletes an ACH enrollment
+// wsdoc {
+//  @Title  Delete ACH Enrollment
+//	@URL /v1/achenroll/:BUI/:AEID
+//  @Method  POST
+//	@Synopsis Delete a payor's ACH autopay enrollment
+//  @Description  Batches that were already created are not affected.
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteACHEnrollment(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "deleteACHEnrollment"
+
+	rlib.Console("Entered %s\n", funcname)
+
+	a, err := rlib.GetACHEnrollment(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.AEID == 0 || a.BID != d.BID {
+		err = fmt.Errorf("ACH enrollment %d not found", d.ID)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = rlib.DeleteACHEnrollment(r.Context(), d.ID); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// SvcHandlerACHBatch handles the ACH batch requests for the batch d.ID
+//
+// The server command can be:
+//      get     - read the batch and its debits
+//      create  - create a new batch of debits
+//      settle  - mark the batch as settled
+//-----------------------------------------------------------------------------------
+func SvcHandlerACHBatch(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerACHBatch"
+	var (
+		err error
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("Request: %s:  BID = %d,  ABID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		achBatchResponse(w, r, d, d.ID)
+	case "create":
+		createACHBatch(w, r, d)
+	case "settle":
+		settleACHBatch(w, r, d)
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// achBatchResponse writes batch abid and its debits as the response
+// wsdoc {
+//  @Title  Get ACH Batch
+//	@URL /v1/achbatch/:BUI/:ABID
+//  @Method  POST
+//	@Synopsis Get an ACH batch and its debits
+//  @Description  Returns the batch along with each debit, its receipt and trace number
+//	@Input WebGridSearchRequest
+//  @Response ACHBatchResponse
+// wsdoc }
+func achBatchResponse(w http.ResponseWriter, r *http.Request, d *ServiceData, abid int64) {
+	const funcname = "achBatchResponse"
+	var g ACHBatchResponse
+
+	b, err := rlib.GetACHBatch(r.Context(), abid)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if b.ABID == 0 || b.BID != d.BID {
+		err = fmt.Errorf("ACH batch %d not found", abid)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	m, err := rlib.GetACHEntries(r.Context(), abid)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	g.Record = ACHBatchForm{
+		Recid:       b.ABID,
+		ABID:        b.ABID,
+		BID:         b.BID,
+		BUD:         rlib.GetBUDFromBIDList(b.BID),
+		Dt:          rlib.JSONDate(b.Dt),
+		EntryCount:  b.EntryCount,
+		TotalAmount: b.TotalAmount,
+		FLAGS:       b.FLAGS,
+	}
+	for i := 0; i < len(m); i++ {
+		g.Record.Entries = append(g.Record.Entries, ACHEntryGrid{
+			Recid:       int64(i),
+			AENID:       m[i].AENID,
+			AEID:        m[i].AEID,
+			TCID:        m[i].TCID,
+			RCPTID:      m[i].RCPTID,
+			Amount:      m[i].Amount,
+			TraceNumber: m[i].TraceNumber,
+			ReturnCode:  m[i].ReturnCode,
+			FLAGS:       m[i].FLAGS,
+		})
+	}
+
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// createACHBatch creates a batch of ACH debits
+// wsdoc {
+//  @Title  Create ACH Batch
+//	@URL /v1/achbatch/:BUI
+//  @Method  POST
+//	@Synopsis Debit every enrolled payor for the amount they owe
+//  @Description  A pending receipt is created and allocated for each debit.
+//  @Description  The NACHA file is downloaded with /v1/achfile/:BUI/:ABID.
+//  @Description  If no payor owes anything, the returned ABID is 0.
+//	@Input ACHBatchCreate
+//  @Response ACHBatchResponse
+// wsdoc }
+func createACHBatch(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "createACHBatch"
+	var foo ACHBatchCreate
+
+	rlib.Console("Entered %s\n", funcname)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	dt := time.Time(foo.Dt)
+	if dt.IsZero() {
+		SvcErrorReturn(w, fmt.Errorf("the effective date of the batch is required"), funcname)
+		return
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	b, err := bizlogic.CreateACHBatch(ctx, d.BID, &dt)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	if b.ABID == 0 {
+		var g ACHBatchResponse
+		g.Status = "success"
+		SvcWriteResponse(d.BID, &g, w)
+		return
+	}
+	achBatchResponse(w, r, d, b.ABID)
+}
+
+// settleACHBatch marks an ACH batch as settled
+// wsdoc {
+//  @Title  Settle ACH Batch
+//	@URL /v1/achbatch/:BUI/:ABID
+//  @Method  POST
+//	@Synopsis Mark an ACH batch as settled by the bank
+//  @Description  The receipts for debits that were not returned are no longer pending.
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func settleACHBatch(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "settleACHBatch"
+
+	rlib.Console("Entered %s\n", funcname)
+
+	b, err := rlib.GetACHBatch(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if b.ABID == 0 || b.BID != d.BID {
+		err = fmt.Errorf("ACH batch %d not found", d.ID)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = bizlogic.SettleACHBatch(ctx, d.ID); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// SvcACHFile downloads the NACHA file for ACH batch d.ID
+// wsdoc {
+//  @Title  Download ACH File
+//	@URL /v1/achfile/:BUI/:ABID
+//  @Method  GET
+//	@Synopsis Download the NACHA file for an ACH batch
+//  @Description  The file is generated each time it is requested, the bank
+//  @Description  account numbers are never stored with the batch.
+//	@Input WebGridSearchRequest
+//  @Response text/plain NACHA file
+// wsdoc }
+func SvcACHFile(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcACHFile"
+	var buf bytes.Buffer
+
+	rlib.Console("Entered %s\n", funcname)
+
+	b, err := rlib.GetACHBatch(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if b.ABID == 0 || b.BID != d.BID {
+		err = fmt.Errorf("ACH batch %d not found", d.ID)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = bizlogic.WriteACHBatchFile(r.Context(), d.ID, &buf); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	fname := fmt.Sprintf("%s_ACH_%d.txt", rlib.GetBUDFromBIDList(d.BID), d.ID)
+	w.Header().Set("Content-Type", "text/plain")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment;filename=%s", fname))
+	w.Write(buf.Bytes())
+}
+
+// ACHReturnImportResponse is the response to an ACH return file import
+type ACHReturnImportResponse struct {
+	Status string                   `json:"status"`
+	Record bizlogic.ACHReturnResult `json:"record"`
+}
+
+// SvcImportACHReturns imports a NACHA return file. The receipt for each
+// returned debit is reversed, and NSF fees are assessed if the business's
+// ACH settings call for them.
+// wsdoc {
+//  @Title  Import ACH Returns
+//	@URL /v1/importachreturns/:BUI
+//  @Method  POST
+//	@Synopsis Import a NACHA return file
+//  @Description  The file is sent as multipart form data named ACHReturnFile.
+//  @Description  Each return is matched to its receipt by trace number, the
+//  @Description  receipt is reversed and the return reason is noted.  Returns
+//  @Description  that were already processed are skipped.
+//	@Input multipart/form-data
+//  @Response ACHReturnImportResponse
+// wsdoc }
+func SvcImportACHReturns(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcImportACHReturns"
+	var g ACHReturnImportResponse
+
+	rlib.Console("Entered %s\n", funcname)
+
+	fheaders, ok := d.Files["ACHReturnFile"]
+	if !ok { // if not found file then just return
+		err := fmt.Errorf("file is missing")
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	inf, err := fheaders[0].Open() // get File (multipart.File)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	defer inf.Close()
+
+	// ------------------
+	// START TRANSACTION
+	// ------------------
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	g.Record, err = bizlogic.ImportACHReturns(ctx, d.BID, inf)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	// ------------------
+	// COMMIT TRANSACTION
+	// ------------------
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(d.BID, &g, w)
+}
@@ -108,19 +108,7 @@ func SvcAvailable(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	//---------------------------------------
 	dtStart := time.Time(res.DtStart)
 	dtStop := time.Time(res.DtStop)
-	srch := fmt.Sprintf(`RentableTypeRef.BID=%d AND
-        RentableLeaseStatus.DtStart <= %q AND RentableLeaseStatus.DtStop >= %q AND RentableLeaseStatus.LeaseStatus = 0 AND
-		RentableTypeRef.DtStart <= %q AND RentableTypeRef.DtStop >= %q AND RentableTypeRef.RTID = %d AND
-		RentableUseStatus.DtStart <= %q AND RentableUseStatus.DtStop >= %q AND RentableUseStatus.UseStatus = 0`,
-		res.BID,
-		dtStop.Format(rlib.RRDATEFMTSQL),
-		dtStart.Format(rlib.RRDATEFMTSQL),
-		dtStop.Format(rlib.RRDATEFMTSQL),
-		dtStart.Format(rlib.RRDATEFMTSQL),
-		res.RTID,
-		dtStop.Format(rlib.RRDATEFMTSQL),
-		dtStart.Format(rlib.RRDATEFMTSQL),
-	)
+	srch := rlib.AvailableRentablesWhere(res.BID, res.RTID, &dtStart, &dtStop)
 	order := "RentableLeaseStatus.DtStart ASC" // default ORDER
 
 	//--------------------------------------------------
@@ -137,15 +125,7 @@ func SvcAvailable(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	//--------------------------------------------------
 	// Transactant Query Text Template
 	//--------------------------------------------------
-	mainQuery := `
-SELECT DISTINCT {{.SelectClause}}
-FROM RentableTypeRef
-LEFT JOIN RentableLeaseStatus on RentableLeaseStatus.RID = RentableTypeRef.RID
-LEFT JOIN RentableUseStatus on RentableUseStatus.RID = RentableTypeRef.RID
-LEFT JOIN Rentable on Rentable.RID = RentableTypeRef.RID
-WHERE {{.WhereClause}}
-ORDER BY {{.OrderClause}}
-` // don't add ';', later some parts will be added in query
+	mainQuery := rlib.AvailableRentablesQuery
 
 	// select clause
 	// RentableTypeRef.RTRID,
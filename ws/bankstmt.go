@@ -0,0 +1,443 @@
+package ws
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"strconv"
+	"strings"
+)
+
+// BankStmtLineGrid is a bank statement line along with the Deposit it has
+// been matched to, for review in the UI grid
+type BankStmtLineGrid struct {
+	Recid       int64 `json:"recid"`
+	BSLID       int64
+	BSID        int64
+	BID         int64
+	BUD         rlib.XJSONBud
+	DEPID       int64
+	Dt          rlib.JSONDate
+	Amount      float64
+	FITID       string
+	Description string
+	DID         int64         // matched deposit, 0 = unmatched
+	FLAGS       uint64        // 1<<0 = match confirmed
+	DepDt       rlib.JSONDate // date of the matched deposit
+	DepAmount   float64       // amount of the matched deposit
+	LastModTime rlib.JSONDateTime
+	LastModBy   int64
+	CreateTS    rlib.JSONDateTime
+	CreateBy    int64
+}
+
+// BankStmtSearchResponse is the response to a search for bank statement lines
+type BankStmtSearchResponse struct {
+	Status  string             `json:"status"`
+	Total   int64              `json:"total"`
+	Records []BankStmtLineGrid `json:"records"`
+}
+
+// BankStmtMatch confirms that bank statement line BSLID is Deposit DID. If
+// DID is 0, the proposed match is confirmed.
+type BankStmtMatch struct {
+	BSLID int64
+	DID   int64
+}
+
+// BankStmtSave is the input data format for a bank statement save command.
+// Matches in Confirm are confirmed, and the matches of the BSLIDs in Unmatch
+// are removed.
+type BankStmtSave struct {
+	Cmd     string          `json:"cmd"`
+	Confirm []BankStmtMatch `json:"Confirm"`
+	Unmatch []int64         `json:"Unmatch"`
+}
+
+// BankStmtAutoMatchResponse is the response to an automatch command
+type BankStmtAutoMatchResponse struct {
+	Status  string `json:"status"`
+	Matched int    `json:"matched"`
+}
+
+// BankStmtImportResponse is the response to a bank statement import
+type BankStmtImportResponse struct {
+	Status string                    `json:"status"`
+	Record bizlogic.BankImportResult `json:"record"`
+}
+
+// SvcHandlerBankStatement dispatches the bank reconciliation requests for
+// the Depository d.ID
+//
+// The server command can be:
+//      get        - search the statement lines and their matches
+//      save       - confirm or remove matches
+//      automatch  - rerun the automatic matching for the date range
+//-----------------------------------------------------------------------------------
+func SvcHandlerBankStatement(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerBankStatement"
+	var (
+		err error
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("Request: %s:  BID = %d,  DEPID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	if d.ID <= 0 {
+		err = fmt.Errorf("DepositoryID is required but was not specified")
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		SvcSearchHandlerBankStatementLines(w, r, d)
+	case "save":
+		saveBankStatementMatches(w, r, d)
+	case "automatch":
+		autoMatchBankStatement(w, r, d)
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+var bankStmtSearchFieldMap = rlib.SelectQueryFieldMap{
+	"BSLID":       {"BankStatementLine.BSLID"},
+	"BSID":        {"BankStatementLine.BSID"},
+	"BID":         {"BankStatementLine.BID"},
+	"DEPID":       {"BankStatementLine.DEPID"},
+	"Dt":          {"BankStatementLine.Dt"},
+	"Amount":      {"BankStatementLine.Amount"},
+	"FITID":       {"BankStatementLine.FITID"},
+	"Description": {"BankStatementLine.Description"},
+	"DID":         {"BankStatementLine.DID"},
+	"FLAGS":       {"BankStatementLine.FLAGS"},
+	"LastModTime": {"BankStatementLine.LastModTime"},
+	"LastModBy":   {"BankStatementLine.LastModBy"},
+	"CreateTS":    {"BankStatementLine.CreateTS"},
+	"CreateBy":    {"BankStatementLine.CreateBy"},
+}
+
+// which fields needs to be fetch to satisfy the struct
+var bankStmtSearchSelectQueryFields = rlib.SelectQueryFields{
+	"BankStatementLine.BSLID",
+	"BankStatementLine.BSID",
+	"BankStatementLine.DEPID",
+	"BankStatementLine.Dt",
+	"BankStatementLine.Amount",
+	"BankStatementLine.FITID",
+	"BankStatementLine.Description",
+	"BankStatementLine.DID",
+	"BankStatementLine.FLAGS",
+	"BankStatementLine.LastModTime",
+	"BankStatementLine.LastModBy",
+	"BankStatementLine.CreateTS",
+	"BankStatementLine.CreateBy",
+}
+
+// bankStmtGridRowScan scans a result from sql row and dump it in a BankStmtLineGrid struct
+func bankStmtGridRowScan(rows *sql.Rows, a *BankStmtLineGrid) error {
+	return rows.Scan(&a.BSLID, &a.BSID, &a.DEPID, &a.Dt, &a.Amount, &a.FITID, &a.Description, &a.DID, &a.FLAGS, &a.LastModTime, &a.LastModBy, &a.CreateTS, &a.CreateBy)
+}
+
+// SvcSearchHandlerBankStatementLines returns the bank statement lines for a
+// depository along with their matched deposits
+// wsdoc {
+//  @Title  Search Bank Statement Lines
+//	@URL /v1/bankstmt/:BUI/:DEPID
+//  @Method  POST
+//	@Synopsis Search bank statement lines for a depository
+//  @Descr  Search the imported bank statement lines for Depository DEPID whose
+//  @Descr  date is in the search range. Each line includes the Deposit it has
+//  @Descr  been matched to, if any.
+//	@Input WebGridSearchRequest
+//  @Response BankStmtSearchResponse
+// wsdoc }
+func SvcSearchHandlerBankStatementLines(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcSearchHandlerBankStatementLines"
+	var (
+		g     BankStmtSearchResponse
+		err   error
+		order = "BankStatementLine.Dt ASC, BankStatementLine.BSLID ASC" // default ORDER
+		whr   = fmt.Sprintf("BankStatementLine.BID=%d AND BankStatementLine.DEPID=%d AND %q <= BankStatementLine.Dt AND BankStatementLine.Dt < %q",
+			d.BID, d.ID, d.wsSearchReq.SearchDtStart.Format(rlib.RRDATEFMTSQL),
+			d.wsSearchReq.SearchDtStop.Format(rlib.RRDATEFMTSQL))
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+
+	// get where clause and order clause for sql query
+	whereClause, orderClause := GetSearchAndSortSQL(d, bankStmtSearchFieldMap)
+	if len(whereClause) > 0 {
+		whr += " AND (" + whereClause + ")"
+	}
+	if len(orderClause) > 0 {
+		order = orderClause
+	}
+
+	theQuery := `
+	SELECT {{.SelectClause}}
+	FROM BankStatementLine
+	WHERE {{.WhereClause}}
+	ORDER BY {{.OrderClause}}`
+
+	qc := rlib.QueryClause{
+		"SelectClause": strings.Join(bankStmtSearchSelectQueryFields, ","),
+		"WhereClause":  whr,
+		"OrderClause":  order,
+	}
+
+	// get TOTAL COUNT First
+	countQuery := rlib.RenderSQLQuery(theQuery, qc)
+	g.Total, err = rlib.GetQueryCount(countQuery)
+	if err != nil {
+		rlib.Console("%s: Error from rlib.GetQueryCount: %s\n", funcname, err.Error())
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	// FETCH the records WITH LIMIT AND OFFSET
+	limitAndOffsetClause := `
+	LIMIT {{.LimitClause}}
+	OFFSET {{.OffsetClause}};`
+	theQueryWithLimit := theQuery + limitAndOffsetClause
+	qc["LimitClause"] = strconv.Itoa(d.wsSearchReq.Limit)
+	qc["OffsetClause"] = strconv.Itoa(d.wsSearchReq.Offset)
+	qry := rlib.RenderSQLQuery(theQueryWithLimit, qc)
+	rlib.Console("db query = %s\n", qry)
+
+	rows, err := rlib.RRdb.Dbrr.Query(qry)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	defer rows.Close()
+
+	i := int64(d.wsSearchReq.Offset)
+	count := 0
+	for rows.Next() {
+		var q BankStmtLineGrid
+		q.Recid = i
+		q.BID = d.BID
+		q.BUD = rlib.GetBUDFromBIDList(q.BID)
+
+		if err = bankStmtGridRowScan(rows, &q); err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if q.DID > 0 {
+			dep, err := rlib.GetDeposit(r.Context(), q.DID)
+			if err != nil {
+				SvcErrorReturn(w, err, funcname)
+				return
+			}
+			q.DepDt = rlib.JSONDate(dep.Dt)
+			q.DepAmount = dep.Amount
+		}
+
+		g.Records = append(g.Records, q)
+		count++ // update the count only after adding the record
+		if count >= d.wsSearchReq.Limit {
+			break // if we've added the max number requested, then exit
+		}
+		i++
+	}
+
+	if err = rows.Err(); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveBankStatementMatches confirms and removes bank statement matches
+// wsdoc {
+//  @Title  Save Bank Statement Matches
+//	@URL /v1/bankstmt/:BUI/:DEPID
+//  @Method  POST
+//	@Synopsis Confirm or remove matches between bank statement lines and deposits
+//  @Description  Each match in Confirm marks its Deposit as cleared with the
+//  @Description  amount reported by the bank.  Each BSLID in Unmatch has its
+//  @Description  match removed, and its Deposit returns to uncleared.
+//	@Input BankStmtSave
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveBankStatementMatches(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveBankStatementMatches"
+	var foo BankStmtSave
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	for i := 0; i < len(foo.Unmatch); i++ {
+		if err = bankStmtLineCheck(ctx, d, foo.Unmatch[i]); err == nil {
+			err = bizlogic.UnmatchBankStatementLine(ctx, foo.Unmatch[i])
+		}
+		if err != nil {
+			tx.Rollback()
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+	}
+	for i := 0; i < len(foo.Confirm); i++ {
+		if err = bankStmtLineCheck(ctx, d, foo.Confirm[i].BSLID); err == nil {
+			err = bizlogic.ConfirmBankStatementLine(ctx, foo.Confirm[i].BSLID, foo.Confirm[i].DID)
+		}
+		if err != nil {
+			tx.Rollback()
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// bankStmtLineCheck makes sure that the bank statement line bslid belongs to
+// the business and depository of the request
+func bankStmtLineCheck(ctx context.Context, d *ServiceData, bslid int64) error {
+	l, err := rlib.GetBankStatementLine(ctx, bslid)
+	if err != nil {
+		return err
+	}
+	if l.BSLID == 0 || l.BID != d.BID || l.DEPID != d.ID {
+		return fmt.Errorf("bank statement line %d not found in depository %d", bslid, d.ID)
+	}
+	return nil
+}
+
+// autoMatchBankStatement reruns the automatic matching of bank statement
+// lines to deposits
+// wsdoc {
+//  @Title  Auto Match Bank Statement
+//	@URL /v1/bankstmt/:BUI/:DEPID
+//  @Method  POST
+//	@Synopsis Match unmatched bank statement lines to deposits
+//  @Description  Proposes a Deposit for each unmatched bank statement line of
+//  @Description  Depository DEPID in the search date range. Matches must still be
+//  @Description  confirmed with the save command.
+//	@Input WebGridSearchRequest
+//  @Response BankStmtAutoMatchResponse
+// wsdoc }
+func autoMatchBankStatement(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "autoMatchBankStatement"
+	var g BankStmtAutoMatchResponse
+
+	rlib.Console("Entered %s\n", funcname)
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Matched, err = bizlogic.AutoMatchBankStatementLines(ctx, d.BID, d.ID, &d.wsSearchReq.SearchDtStart, &d.wsSearchReq.SearchDtStop)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// SvcImportBankStatement imports a bank statement file (OFX, QFX, or CSV)
+// for Depository d.ID and automatically matches its lines to deposits
+// wsdoc {
+//  @Title  Import Bank Statement
+//	@URL /v1/importbankstmt/:BUI/:DEPID
+//  @Method  POST
+//	@Synopsis Import a bank statement file for a depository
+//  @Description  The file is sent as multipart form data named BankStatementFile.
+//  @Description  Lines that were imported previously are skipped. The remaining
+//  @Description  lines are matched to deposits; the matches must be confirmed.
+//	@Input multipart/form-data
+//  @Response BankStmtImportResponse
+// wsdoc }
+func SvcImportBankStatement(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcImportBankStatement"
+	var g BankStmtImportResponse
+
+	rlib.Console("Entered %s\n", funcname)
+
+	if d.ID <= 0 {
+		err := fmt.Errorf("DepositoryID is required but was not specified")
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	fheaders, ok := d.Files["BankStatementFile"]
+	if !ok { // if not found file then just return
+		err := fmt.Errorf("file is missing")
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	fh := fheaders[0]     // get one file
+	inf, err := fh.Open() // get File (multipart.File)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	defer inf.Close()
+
+	// ------------------
+	// START TRANSACTION
+	// ------------------
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	g.Record, err = bizlogic.ImportBankStatement(ctx, d.BID, d.ID, inf, fh.Filename)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	// ------------------
+	// COMMIT TRANSACTION
+	// ------------------
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(d.BID, &g, w)
+}
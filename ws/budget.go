@@ -0,0 +1,238 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"sort"
+	"time"
+)
+
+// BudgetGrid is the budget of a GL account for a fiscal year
+type BudgetGrid struct {
+	Recid    int64 `json:"recid"`
+	BID      int64
+	BUD      rlib.XJSONBud
+	LID      int64
+	GLNumber string
+	Name     string
+	DtStart  rlib.JSONDate // first day of the fiscal year
+	DtStop   rlib.JSONDate // first day of the next fiscal year
+	Amounts  []float64     // monthly amounts, Amounts[0] is the first month of the year
+	Total    float64
+}
+
+// BudgetListResponse is the response to the get command
+type BudgetListResponse struct {
+	Status  string       `json:"status"`
+	Total   int64        `json:"total"`
+	Records []BudgetGrid `json:"records"`
+}
+
+// BudgetSaveAccount is the budget of one account in a save request
+type BudgetSaveAccount struct {
+	GLNumber string
+	Amounts  []float64 // monthly amounts, Amounts[0] is the first month of the year
+	Comment  string
+}
+
+// BudgetSaveForm is the budget of a fiscal year for a list of accounts
+type BudgetSaveForm struct {
+	Dt       rlib.JSONDate // any date in the fiscal year
+	Accounts []BudgetSaveAccount
+}
+
+// BudgetSave is the input data format for the save command
+type BudgetSave struct {
+	Cmd    string         `json:"cmd"`
+	Record BudgetSaveForm `json:"record"`
+}
+
+// BudgetDeleteForm identifies the budget to delete
+type BudgetDeleteForm struct {
+	Dt       rlib.JSONDate // any date in the fiscal year
+	GLNumber string
+}
+
+// BudgetDelete is the input data format for the delete command
+type BudgetDelete struct {
+	Cmd    string           `json:"cmd"`
+	Record BudgetDeleteForm `json:"record"`
+}
+
+// SvcHandlerBudget handles the budgets of a business.
+//
+// The server command can be:
+//      get     - the budget of the fiscal year holding searchDtStart
+//      save    - set the budget of accounts for a fiscal year
+//      delete  - remove the budget of an account for a fiscal year
+//-----------------------------------------------------------------------------
+func SvcHandlerBudget(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerBudget"
+	var (
+		err error
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getBudget(w, r, d)
+	case "save":
+		saveBudget(w, r, d)
+	case "delete":
+		deleteBudget(w, r, d)
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getBudget returns the budget of a fiscal year, one record per account
+// wsdoc {
+//  @Title  Get Budget
+//	@URL /v1/budget/:BUI
+//  @Method  POST
+//	@Synopsis Get the budget of a fiscal year
+//  @Description  Returns the monthly budget of every budgeted GL account for
+//  @Description  the fiscal year holding searchDtStart, ordered by GL number.
+//	@Input WebGridSearchRequest
+//  @Response BudgetListResponse
+// wsdoc }
+func getBudget(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getBudget"
+	var g BudgetListResponse
+
+	p, err := rlib.GetFiscalYearPolicy(r.Context(), d.BID, "general")
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	d1, d2 := p.YearContaining(&d.wsSearchReq.SearchDtStart)
+	m, err := rlib.GetBudgetYear(r.Context(), d.BID, &d1)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	bud := rlib.GetBUDFromBIDList(d.BID)
+	for lid, amt := range m {
+		q := BudgetGrid{
+			Recid:   lid,
+			BID:     d.BID,
+			BUD:     bud,
+			LID:     lid,
+			DtStart: rlib.JSONDate(d1),
+			DtStop:  rlib.JSONDate(d2),
+			Amounts: amt,
+		}
+		if gl, ok := rlib.RRdb.BizTypes[d.BID].GLAccounts[lid]; ok {
+			q.GLNumber = gl.GLNumber
+			q.Name = gl.Name
+		}
+		for i := 0; i < len(amt); i++ {
+			q.Total += amt[i]
+		}
+		q.Total = rlib.RoundToCent(q.Total)
+		g.Records = append(g.Records, q)
+	}
+	sort.Slice(g.Records, func(i, j int) bool { return g.Records[i].GLNumber < g.Records[j].GLNumber })
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveBudget sets the budget of accounts for a fiscal year
+// wsdoc {
+//  @Title  Save Budget
+//	@URL /v1/budget/:BUI
+//  @Method  POST
+//	@Synopsis Set the budget of GL accounts for a fiscal year
+//  @Description  Replaces the monthly budget of each account in Accounts for
+//  @Description  the fiscal year holding Dt.  Each account has 12 amounts, the
+//  @Description  first is the first month of the fiscal year.  Months with a
+//  @Description  zero amount are removed.  Only posting accounts can be
+//  @Description  budgeted.
+//	@Input BudgetSave
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveBudget(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveBudget"
+	var foo BudgetSave
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	dt := time.Time(foo.Record.Dt)
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	for i := 0; i < len(foo.Record.Accounts); i++ {
+		a := &foo.Record.Accounts[i]
+		if errlist := bizlogic.SaveBudgetYear(ctx, d.BID, a.GLNumber, &dt, a.Amounts, a.Comment); len(errlist) > 0 {
+			tx.Rollback()
+			SvcErrListReturn(w, errlist, funcname)
+			return
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// deleteBudget removes the budget of an account for a fiscal year
+// wsdoc {
+//  @Title  Delete Budget
+//	@URL /v1/budget/:BUI
+//  @Method  POST
+//	@Synopsis Delete the budget of a GL account for a fiscal year
+//  @Description  Removes every monthly budget of GLNumber in the fiscal year
+//  @Description  holding Dt.
+//	@Input BudgetDelete
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteBudget(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "deleteBudget"
+	var foo BudgetDelete
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	dt := time.Time(foo.Record.Dt)
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if errlist := bizlogic.DeleteBudgetYear(ctx, d.BID, foo.Record.GLNumber, &dt); len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
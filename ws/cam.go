@@ -0,0 +1,478 @@
+package ws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"gotable"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"rentroll/rrpt"
+	"time"
+)
+
+// CAMReconLineGrid is the expense total of one GL account in a CAM
+// reconciliation
+type CAMReconLineGrid struct {
+	Recid        int64 `json:"recid"`
+	CAMRLID      int64
+	LID          int64
+	GLName       string
+	Expenses     float64
+	BaseExpenses float64
+	Share        float64
+}
+
+// CAMReconciliationForm is a CAM reconciliation and its lines
+type CAMReconciliationForm struct {
+	Recid                 int64 `json:"recid"`
+	CAMRID                int64
+	BID                   int64
+	BUD                   rlib.XJSONBud
+	RAID                  int64
+	RID                   int64
+	TCID                  int64
+	Payor                 string
+	Dt1                   rlib.JSONDate
+	Dt2                   rlib.JSONDate
+	LeaseType             int64
+	ExpenseAdjustmentType int64
+	SqFt                  int64
+	TotalSqFt             int64
+	Occupancy             float64
+	Expenses              float64
+	BaseExpenses          float64
+	ExpensesStop          float64
+	Share                 float64
+	Estimated             float64
+	TrueUp                float64
+	ASMID                 int64
+	RCPTID                int64
+	FLAGS                 uint64 // 1<<0 = posted
+	Comment               string
+	Lines                 []CAMReconLineGrid
+}
+
+// CAMReconciliationResponse is the response to the CAM reconciliation
+// requests for a single reconciliation
+type CAMReconciliationResponse struct {
+	Status string                `json:"status"`
+	Record CAMReconciliationForm `json:"record"`
+}
+
+// CAMReconciliationListResponse is the response to the list and create
+// commands
+type CAMReconciliationListResponse struct {
+	Status  string                  `json:"status"`
+	Total   int64                   `json:"total"`
+	Records []CAMReconciliationForm `json:"records"`
+}
+
+// CAMReconciliationCreate is the input data format for the preview and
+// create commands. RAID is only used by preview.
+type CAMReconciliationCreate struct {
+	Cmd  string        `json:"cmd"`
+	RAID int64         `json:"RAID"`
+	Dt1  rlib.JSONDate `json:"Dt1"`
+	Dt2  rlib.JSONDate `json:"Dt2"`
+}
+
+// SvcHandlerCAMReconciliation handles the CAM (common area maintenance)
+// reconciliation requests for the reconciliation d.ID
+//
+// The server command can be:
+//      get      - read the reconciliation
+//      list     - the reconciliations ending in searchDtStart - searchDtStop
+//      preview  - compute the reconciliation of one rental agreement
+//      create   - create the reconciliations of all the agreements
+//      post     - assess the balance due or credit the overpayment
+//      delete   - delete a reconciliation that has not been posted
+//-----------------------------------------------------------------------------------
+func SvcHandlerCAMReconciliation(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerCAMReconciliation"
+	var (
+		err error
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("Request: %s:  BID = %d,  CAMRID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		camReconciliationResponse(w, r, d, d.ID)
+	case "list":
+		listCAMReconciliations(w, r, d)
+	case "preview":
+		previewCAMReconciliation(w, r, d)
+	case "create":
+		createCAMReconciliations(w, r, d)
+	case "post":
+		postCAMReconciliation(w, r, d)
+	case "delete":
+		deleteCAMReconciliation(w, r, d)
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// camReconciliationForm converts reconciliation c to its UI form
+func camReconciliationForm(ctx context.Context, c *rlib.CAMReconciliation) CAMReconciliationForm {
+	var f CAMReconciliationForm
+	rlib.MigrateStructVals(c, &f)
+	f.Recid = c.CAMRID
+	f.BUD = rlib.GetBUDFromBIDList(c.BID)
+	if c.TCID > 0 {
+		var t rlib.Transactant
+		if err := rlib.GetTransactant(ctx, c.TCID, &t); err == nil {
+			f.Payor = t.GetFullTransactantName()
+		}
+	}
+	for i := 0; i < len(c.L); i++ {
+		f.Lines = append(f.Lines, CAMReconLineGrid{
+			Recid:        int64(i),
+			CAMRLID:      c.L[i].CAMRLID,
+			LID:          c.L[i].LID,
+			GLName:       rlib.RRdb.BizTypes[c.BID].GLAccounts[c.L[i].LID].Name,
+			Expenses:     c.L[i].Expenses,
+			BaseExpenses: c.L[i].BaseExpenses,
+			Share:        c.L[i].Share,
+		})
+	}
+	return f
+}
+
+// camReconciliationResponse writes reconciliation camrid and its lines as
+// the response
+// wsdoc {
+//  @Title  Get CAM Reconciliation
+//	@URL /v1/camrecon/:BUI/:CAMRID
+//  @Method  POST
+//	@Synopsis Get a CAM reconciliation
+//  @Description  Returns the reconciliation with the expenses by GL account
+//	@Input WebGridSearchRequest
+//  @Response CAMReconciliationResponse
+// wsdoc }
+func camReconciliationResponse(w http.ResponseWriter, r *http.Request, d *ServiceData, camrid int64) {
+	const funcname = "camReconciliationResponse"
+	var g CAMReconciliationResponse
+
+	c, err := rlib.GetCAMReconciliation(r.Context(), camrid)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if c.CAMRID == 0 || c.BID != d.BID {
+		err = fmt.Errorf("CAM reconciliation %d not found", camrid)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if c.L, err = rlib.GetCAMReconLines(r.Context(), camrid); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	g.Record = camReconciliationForm(r.Context(), &c)
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// listCAMReconciliations lists the reconciliations of a business
+// wsdoc {
+//  @Title  List CAM Reconciliations
+//	@URL /v1/camrecon/:BUI
+//  @Method  POST
+//	@Synopsis List the CAM reconciliations of a business
+//  @Description  Returns the reconciliations whose period ends in
+//  @Description  searchDtStart - searchDtStop. The lines are not included.
+//	@Input WebGridSearchRequest
+//  @Response CAMReconciliationListResponse
+// wsdoc }
+func listCAMReconciliations(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "listCAMReconciliations"
+	var g CAMReconciliationListResponse
+
+	rlib.Console("Entered %s\n", funcname)
+
+	m, err := rlib.GetCAMReconciliationsByRange(r.Context(), d.BID, &d.wsSearchReq.SearchDtStart, &d.wsSearchReq.SearchDtStop)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	for i := 0; i < len(m); i++ {
+		g.Records = append(g.Records, camReconciliationForm(r.Context(), &m[i]))
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// previewCAMReconciliation computes the reconciliation of a rental
+// agreement without saving it
+// wsdoc {
+//  @Title  Preview CAM Reconciliation
+//	@URL /v1/camrecon/:BUI
+//  @Method  POST
+//	@Synopsis Compute the CAM reconciliation of a rental agreement
+//  @Description  Send RAID and the period Dt1 - Dt2. The expenses, the base
+//  @Description  year, the tenant's share and the estimated charges billed
+//  @Description  are computed.  Nothing is saved.
+//	@Input CAMReconciliationCreate
+//  @Response CAMReconciliationResponse
+// wsdoc }
+func previewCAMReconciliation(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "previewCAMReconciliation"
+	var (
+		g   CAMReconciliationResponse
+		foo CAMReconciliationCreate
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	c := rlib.CAMReconciliation{
+		BID:  d.BID,
+		RAID: foo.RAID,
+		Dt1:  time.Time(foo.Dt1),
+		Dt2:  time.Time(foo.Dt2),
+	}
+	if err := bizlogic.InitCAMReconciliation(r.Context(), &c); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if c.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("rental agreement %d not found", c.RAID), funcname)
+		return
+	}
+
+	g.Record = camReconciliationForm(r.Context(), &c)
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// createCAMReconciliations creates the reconciliations of a business
+// wsdoc {
+//  @Title  Create CAM Reconciliations
+//	@URL /v1/camrecon/:BUI
+//  @Method  POST
+//	@Synopsis Create the CAM reconciliations for a period
+//  @Description  A reconciliation is created for each rental agreement that
+//  @Description  passes through its operating expenses during Dt1 - Dt2,
+//  @Description  unless it already has one for the period.  They are not
+//  @Description  posted.
+//	@Input CAMReconciliationCreate
+//  @Response CAMReconciliationListResponse
+// wsdoc }
+func createCAMReconciliations(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "createCAMReconciliations"
+	var (
+		g   CAMReconciliationListResponse
+		foo CAMReconciliationCreate
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	d1 := time.Time(foo.Dt1)
+	d2 := time.Time(foo.Dt2)
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	m, err := bizlogic.CreateCAMReconciliations(ctx, d.BID, &d1, &d2)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	for i := 0; i < len(m); i++ {
+		g.Records = append(g.Records, camReconciliationForm(r.Context(), &m[i]))
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// postCAMReconciliation posts a CAM reconciliation
+// wsdoc {
+//  @Title  Post CAM Reconciliation
+//	@URL /v1/camrecon/:BUI/:CAMRID
+//  @Method  POST
+//	@Synopsis Assess the balance due or credit the overpayment
+//  @Description  A tenant who paid less than its share is assessed the
+//  @Description  difference, a tenant who paid more is credited with a
+//  @Description  receipt.  The statement is downloaded with /v1/camstatement.
+//	@Input WebGridSearchRequest
+//  @Response CAMReconciliationResponse
+// wsdoc }
+func postCAMReconciliation(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "postCAMReconciliation"
+
+	rlib.Console("Entered %s\n", funcname)
+
+	c, err := rlib.GetCAMReconciliation(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if c.CAMRID == 0 || c.BID != d.BID {
+		err = fmt.Errorf("CAM reconciliation %d not found", d.ID)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if _, err = bizlogic.PostCAMReconciliation(ctx, d.ID); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	camReconciliationResponse(w, r, d, d.ID)
+}
+
+// deleteCAMReconciliation deletes a CAM reconciliation
+// wsdoc {
+//  @Title  Delete CAM Reconciliation
+//	@URL /v1/camrecon/:BUI/:CAMRID
+//  @Method  POST
+//	@Synopsis Delete a CAM reconciliation that has not been posted
+//  @Description  Posted reconciliations cannot be deleted.
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteCAMReconciliation(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "deleteCAMReconciliation"
+
+	rlib.Console("Entered %s\n", funcname)
+
+	c, err := rlib.GetCAMReconciliation(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if c.CAMRID == 0 || c.BID != d.BID {
+		err = fmt.Errorf("CAM reconciliation %d not found", d.ID)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if c.FLAGS&rlib.FlCAMPosted != 0 {
+		err = fmt.Errorf("CAM reconciliation %s has been posted, it cannot be deleted", c.IDtoString())
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = rlib.DeleteCAMReconciliation(ctx, d.ID); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// SvcCAMStatement downloads the tenant's statement for CAM reconciliation
+// d.ID as a PDF
+// wsdoc {
+//  @Title  Download CAM Reconciliation Statement
+//	@URL /v1/camstatement/:BUI/:CAMRID
+//  @Method  GET
+//	@Synopsis Download the operating expense reconciliation statement
+//  @Description  The statement lists the expenses by GL account, the
+//  @Description  tenant's share, the estimated charges billed and the
+//  @Description  balance due or credit. It is marked as a draft until the
+//  @Description  reconciliation is posted.
+//	@Input WebGridSearchRequest
+//  @Response application/pdf
+// wsdoc }
+func SvcCAMStatement(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcCAMStatement"
+	var (
+		buf  bytes.Buffer
+		xbiz rlib.XBusiness
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+
+	c, err := rlib.GetCAMReconciliation(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if c.CAMRID == 0 || c.BID != d.BID {
+		err = fmt.Errorf("CAM reconciliation %d not found", d.ID)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = rlib.GetXBusiness(r.Context(), d.BID, &xbiz); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	ri := rrpt.ReporterInfo{
+		OutputFormat: gotable.TABLEOUTPDF,
+		Bid:          d.BID,
+		Raid:         c.RAID,
+		ID:           c.CAMRID,
+		Xbiz:         &xbiz,
+	}
+	tsh := rrpt.SingleTableReportHandler{
+		ReportNames:             []string{"RPTcam", "cam reconciliation statement"},
+		TableHandler:            rrpt.CAMStatementTable,
+		PDFprops:                rrpt.CAMStatementPDFProps,
+		HTMLTemplate:            "",
+		NeedsCustomPDFDimension: false,
+		NeedsPDFTitle:           false,
+	}
+	rctx := rrpt.ReportContext{
+		PDFPageSizeUnit: "in",
+		PDFPageWidth:    float64(8.5),
+		PDFPageHeight:   float64(11),
+	}
+	tbl := rrpt.CAMStatementTable(r.Context(), &ri)
+	if err = rrpt.WritePDFReport(&buf, &tsh, &ri, &rctx, &tbl); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	fname := fmt.Sprintf("%s_CAM_%s.pdf", rlib.GetBUDFromBIDList(d.BID), c.IDtoString())
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment;filename=%s", fname))
+	w.Write(buf.Bytes())
+}
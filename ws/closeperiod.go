@@ -318,7 +318,9 @@ EXITNOW:
 //  @Method  POST
 //	@Synopsis Reopen ClosePeriod with the supplied CPID
 //  @Desc  This service deletes the ClosePeriod with the supplied CPID.
-//  @Desc  The CPID must be that of the last closed period.
+//  @Desc  The CPID must be that of the last closed period.  A period
+//  @Desc  through the end of a closed fiscal year cannot be reopened until
+//  @Desc  the fiscal year is reopened.
 //	@Input DeletePmtForm
 //  @Response SvcStatusResponse
 // wsdoc }
@@ -373,6 +375,28 @@ func deleteClosePeriod(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 		return
 	}
 
+	//-----------------------------------------
+	// the periods of a closed fiscal year
+	// stay closed until the year is reopened
+	//-----------------------------------------
+	fyc, err := rlib.GetLastFiscalYearClose(r.Context(), d.BID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if fyc.FYCID > 0 {
+		prev, err := rlib.GetClosePeriodBefore(r.Context(), d.BID, &cp.Dt)
+		if err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if prev.CPID == 0 || prev.Dt.Before(fyc.Dt) {
+			err = fmt.Errorf("This period is part of the fiscal year closed by %s, reopen the fiscal year first", fyc.IDtoShortString())
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+	}
+
 	if err = rlib.DeleteClosePeriod(r.Context(), d.ID); err != nil {
 		SvcErrorReturn(w, err, funcname)
 		return
@@ -0,0 +1,260 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// FiscalYearCloseGrid is the close of a fiscal year
+type FiscalYearCloseGrid struct {
+	Recid      int64 `json:"recid"`
+	FYCID      int64
+	BID        int64
+	BUD        rlib.XJSONBud
+	DtStart    rlib.JSONDate // first day of the fiscal year
+	DtStop     rlib.JSONDate // first day of the next fiscal year
+	Dt         rlib.JSONDate // date of the closing journal
+	LID        int64
+	GLNumber   string // retained earnings account
+	JID        int64
+	NetIncome  float64
+	Reopened   bool
+	RevJID     int64
+	DtReopened rlib.JSONDateTime
+	ReopenedBy int64
+	Reason     string
+}
+
+// FiscalYearCloseResponse is the response to the get command
+type FiscalYearCloseResponse struct {
+	Status string              `json:"status"`
+	Record FiscalYearCloseGrid `json:"record"`
+}
+
+// FiscalYearCloseListResponse is the response to the list command
+type FiscalYearCloseListResponse struct {
+	Status  string                `json:"status"`
+	Total   int64                 `json:"total"`
+	Records []FiscalYearCloseGrid `json:"records"`
+}
+
+// FiscalYearCloseSaveForm describes the fiscal year to close
+type FiscalYearCloseSaveForm struct {
+	Dt rlib.JSONDate // any date in the fiscal year
+}
+
+// FiscalYearCloseSave is the input data format for the save command
+type FiscalYearCloseSave struct {
+	Cmd    string                  `json:"cmd"`
+	Record FiscalYearCloseSaveForm `json:"record"`
+}
+
+// FiscalYearReopenForm describes why a fiscal year is reopened
+type FiscalYearReopenForm struct {
+	Reason string
+}
+
+// FiscalYearReopen is the input data format for the reopen command
+type FiscalYearReopen struct {
+	Cmd    string               `json:"cmd"`
+	Record FiscalYearReopenForm `json:"record"`
+}
+
+// SvcHandlerFiscalYearClose handles the fiscal year closes of a business
+// for the close d.ID
+//
+// The server command can be:
+//      get     - read the fiscal year close
+//      list    - all fiscal year closes, including the reopened ones
+//      save    - close the fiscal year holding a date
+//      reopen  - reverse the closing journal of the last closed year
+//-----------------------------------------------------------------------------
+func SvcHandlerFiscalYearClose(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerFiscalYearClose"
+	var (
+		err error
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("Request: %s:  BID = %d,  FYCID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getFiscalYearClose(w, r, d)
+	case "list":
+		listFiscalYearCloses(w, r, d)
+	case "save":
+		saveFiscalYearClose(w, r, d)
+	case "reopen":
+		reopenFiscalYear(w, r, d)
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// fiscalYearCloseGrid converts fiscal year close a to its grid form
+func fiscalYearCloseGrid(a *rlib.FiscalYearClose) FiscalYearCloseGrid {
+	var g FiscalYearCloseGrid
+	rlib.MigrateStructVals(a, &g)
+	g.Recid = a.FYCID
+	g.BUD = rlib.GetBUDFromBIDList(a.BID)
+	g.Reopened = a.IsReopened()
+	if gl, ok := rlib.RRdb.BizTypes[a.BID].GLAccounts[a.LID]; ok {
+		g.GLNumber = gl.GLNumber
+	}
+	return g
+}
+
+// getFiscalYearClose reads a fiscal year close
+// wsdoc {
+//  @Title  Get Fiscal Year Close
+//	@URL /v1/fiscalyear/:BUI/:FYCID
+//  @Method  POST
+//	@Synopsis Get a fiscal year close
+//  @Description  Returns the fiscal year close FYCID
+//	@Input WebGridSearchRequest
+//  @Response FiscalYearCloseResponse
+// wsdoc }
+func getFiscalYearClose(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getFiscalYearClose"
+	var g FiscalYearCloseResponse
+
+	a, err := rlib.GetFiscalYearClose(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.FYCID == 0 || a.BID != d.BID {
+		err = fmt.Errorf("fiscal year close %d not found", d.ID)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Record = fiscalYearCloseGrid(&a)
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// listFiscalYearCloses lists the fiscal year closes of a business
+// wsdoc {
+//  @Title  List Fiscal Year Closes
+//	@URL /v1/fiscalyear/:BUI
+//  @Method  POST
+//	@Synopsis List the fiscal year closes of a business
+//  @Description  Returns every fiscal year close, oldest first.  The
+//  @Description  reopened ones are included with who reopened them, when
+//  @Description  and why.
+//	@Input WebGridSearchRequest
+//  @Response FiscalYearCloseListResponse
+// wsdoc }
+func listFiscalYearCloses(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "listFiscalYearCloses"
+	var g FiscalYearCloseListResponse
+
+	m, err := rlib.GetFiscalYearCloses(r.Context(), d.BID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	for i := 0; i < len(m); i++ {
+		g.Records = append(g.Records, fiscalYearCloseGrid(&m[i]))
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveFiscalYearClose closes a fiscal year
+// wsdoc {
+//  @Title  Close Fiscal Year
+//	@URL /v1/fiscalyear/:BUI
+//  @Method  POST
+//	@Synopsis Close the fiscal year holding a date
+//  @Description  Posts a journal on the last day of the fiscal year holding
+//  @Description  Dt that moves the balance of every income and expense
+//  @Description  account into the retained earnings account of the business
+//  @Description  properties.  The monthly periods must be closed through the
+//  @Description  end of the year and the years are closed in order.
+//	@Input FiscalYearCloseSave
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveFiscalYearClose(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveFiscalYearClose"
+	var foo FiscalYearCloseSave
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	dt := time.Time(foo.Record.Dt)
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	a, errlist := bizlogic.CloseFiscalYear(ctx, d.BID, &dt)
+	if len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, a.FYCID)
+}
+
+// reopenFiscalYear reopens the last closed fiscal year
+// wsdoc {
+//  @Title  Reopen Fiscal Year
+//	@URL /v1/fiscalyear/:BUI/:FYCID
+//  @Method  POST
+//	@Synopsis Reopen the last closed fiscal year
+//  @Description  Posts a journal that reverses the closing journal of FYCID,
+//  @Description  which must be the last fiscal year closed.  The close is
+//  @Description  kept, marked reopened with the Reason, who and when.
+//	@Input FiscalYearReopen
+//  @Response SvcStatusResponse
+// wsdoc }
+func reopenFiscalYear(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "reopenFiscalYear"
+	var foo FiscalYearReopen
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if errlist := bizlogic.ReopenFiscalYear(ctx, d.BID, d.ID, foo.Record.Reason); len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
@@ -0,0 +1,372 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"strings"
+	"time"
+)
+
+// FolioGrid is a guest folio
+type FolioGrid struct {
+	Recid         int64 `json:"recid"`
+	FOLID         int64
+	RAID          int64
+	RID           int64
+	RentableName  string
+	RTID          int64
+	TCID          int64
+	Guest         string
+	DtArrive      rlib.JSONDate
+	DtDepart      rlib.JSONDate
+	Adults        int64
+	Children      int64
+	Rate          float64 // 0 = priced by the rate plans
+	PostedThrough rlib.JSONDate
+	Status        int64 // 0 = open, 1 = closed
+	Comment       string
+}
+
+// FolioCharge is a charge posted to a folio
+type FolioCharge struct {
+	Recid   int64 `json:"recid"`
+	ASMID   int64
+	Dt      rlib.JSONDate
+	ARID    int64
+	Name    string // name of the account rule
+	Amount  float64
+	Unpaid  float64
+	Comment string
+}
+
+// FolioResponse is the response to the get command: the folio and its
+// charges
+type FolioResponse struct {
+	Status  string        `json:"status"`
+	Record  FolioGrid     `json:"record"`
+	Charges []FolioCharge `json:"charges"`
+	Total   float64       // sum of the charges
+	Balance float64       // sum of the unpaid charges
+}
+
+// FolioListResponse is the response to the list command
+type FolioListResponse struct {
+	Status  string      `json:"status"`
+	Total   int64       `json:"total"`
+	Records []FolioGrid `json:"records"`
+}
+
+// FolioCheckIn is the input data of the checkin command.  Only RAID is
+// required, see bizlogic.OpenFolio for the defaults.
+type FolioCheckIn struct {
+	Cmd      string        `json:"cmd"`
+	RAID     int64         // the rental agreement of the stay
+	RID      int64         // the rentable, 0 for the rentable of the agreement
+	DtArrive rlib.JSONDate // arrival, the business date if not set
+	DtDepart rlib.JSONDate // departure, the end of possession if not set
+	Adults   int64
+	Children int64
+	Rate     float64 // nightly rate, 0 to use the rate plans
+	Comment  string
+}
+
+// FolioChargeInput is the input data of the charge command
+type FolioChargeInput struct {
+	Cmd     string  `json:"cmd"`
+	ARID    int64   // account rule of the charge
+	Amount  float64 // amount charged
+	Comment string  // what is charged
+}
+
+// SvcHandlerFolio handles the guest folios of a business.  d.ID is the FOLID
+// of the folio for the get, charge and checkout commands.
+//
+// The server command can be:
+//      get      - the folio and its charges, or if d.ID is 0, list
+//      list     - the folios of the stays in searchDtStart - searchDtStop
+//      checkin  - open the folio of a stay
+//      charge   - post an incidental charge to the folio
+//      checkout - close the folio
+//-----------------------------------------------------------------------------
+func SvcHandlerFolio(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerFolio"
+	var (
+		err error
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("Request: %s:  BID = %d,  FOLID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		if d.ID > 0 {
+			getFolio(w, r, d)
+			return
+		}
+		listFolios(w, r, d)
+	case "list":
+		listFolios(w, r, d)
+	case "checkin":
+		checkInFolio(w, r, d)
+	case "charge":
+		chargeFolio(w, r, d)
+	case "checkout":
+		checkOutFolio(w, r, d)
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// folioGrid converts folio f to its grid form
+func folioGrid(ctx context.Context, f *rlib.Folio) FolioGrid {
+	var g FolioGrid
+	var t rlib.Transactant
+	rlib.MigrateStructVals(f, &g)
+	g.Recid = f.FOLID
+	if f.TCID > 0 {
+		if err := rlib.GetTransactant(ctx, f.TCID, &t); err == nil {
+			g.Guest = t.GetFullTransactantName()
+		}
+	}
+	if rnt, err := rlib.GetRentable(ctx, f.RID); err == nil {
+		g.RentableName = rnt.RentableName
+	}
+	return g
+}
+
+// getFolio reads a folio and its charges
+// wsdoc {
+//  @Title  Get Folio
+//	@URL /v1/folio/:BUI/:FOLID
+//  @Method  POST
+//	@Synopsis Get a guest folio
+//  @Description  Returns the folio with its room, tax and incidental charges,
+//  @Description  their total and the balance left unpaid.  Reversed charges
+//  @Description  are left out.
+//	@Input WebGridSearchRequest
+//  @Response FolioResponse
+// wsdoc }
+func getFolio(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getFolio"
+	var g FolioResponse
+
+	f, err := getBizFolio(r, d)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Record = folioGrid(r.Context(), &f)
+	m, err := rlib.GetAssessmentsByAssocElem(r.Context(), d.BID, rlib.ELEMFOLIO, f.FOLID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	for i := 0; i < len(m); i++ {
+		if m[i].FLAGS&4 != 0 { // reversed
+			continue
+		}
+		c := FolioCharge{
+			Recid:   m[i].ASMID,
+			ASMID:   m[i].ASMID,
+			Dt:      rlib.JSONDate(m[i].Start),
+			ARID:    m[i].ARID,
+			Amount:  m[i].Amount,
+			Unpaid:  bizlogic.AssessmentUnpaidPortion(r.Context(), &m[i]),
+			Comment: m[i].Comment,
+		}
+		if ar, err := rlib.GetAR(r.Context(), m[i].ARID); err == nil {
+			c.Name = ar.Name
+		}
+		g.Total += c.Amount
+		g.Balance += c.Unpaid
+		g.Charges = append(g.Charges, c)
+	}
+	g.Total = rlib.RoundToCent(g.Total)
+	g.Balance = rlib.RoundToCent(g.Balance)
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// listFolios lists the folios of a business
+// wsdoc {
+//  @Title  List Folios
+//	@URL /v1/folio/:BUI
+//  @Method  POST
+//	@Synopsis List the guest folios
+//  @Description  Returns the folios of the stays that overlap
+//  @Description  searchDtStart - searchDtStop, by arrival date.
+//	@Input WebGridSearchRequest
+//  @Response FolioListResponse
+// wsdoc }
+func listFolios(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "listFolios"
+	var g FolioListResponse
+
+	m, err := rlib.GetFoliosInRange(r.Context(), d.BID, &d.wsSearchReq.SearchDtStart, &d.wsSearchReq.SearchDtStop)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	for i := 0; i < len(m); i++ {
+		g.Records = append(g.Records, folioGrid(r.Context(), &m[i]))
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// checkInFolio opens the folio of a stay
+// wsdoc {
+//  @Title  Check In
+//	@URL /v1/folio/:BUI
+//  @Method  POST
+//	@Synopsis Open the folio of a stay
+//  @Description  Opens a folio for the stay of a rental agreement.  The
+//  @Description  rentable, guest, dates and occupants default from the
+//  @Description  agreement.  The stay cannot start before the business date
+//  @Description  and the rentable cannot be in another open folio.  The night
+//  @Description  audit posts the room charges.
+//	@Input FolioCheckIn
+//  @Response SvcStatusResponse
+// wsdoc }
+func checkInFolio(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "checkInFolio"
+	var foo FolioCheckIn
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	f := rlib.Folio{
+		BID:      d.BID,
+		RAID:     foo.RAID,
+		RID:      foo.RID,
+		DtArrive: time.Time(foo.DtArrive),
+		DtDepart: time.Time(foo.DtDepart),
+		Adults:   foo.Adults,
+		Children: foo.Children,
+		Rate:     foo.Rate,
+		Comment:  strings.TrimSpace(foo.Comment),
+	}
+	if f.Rate < 0 {
+		SvcErrorReturn(w, fmt.Errorf("the rate cannot be negative"), funcname)
+		return
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = bizlogic.OpenFolio(ctx, &f); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, f.FOLID)
+}
+
+// chargeFolio posts an incidental charge to a folio
+// wsdoc {
+//  @Title  Post Folio Charge
+//	@URL /v1/folio/:BUI/:FOLID
+//  @Method  POST
+//	@Synopsis Post an incidental charge to a folio
+//  @Description  Posts a charge to the open folio on the business date.  The
+//  @Description  charge is an assessment of the rental agreement of the stay.
+//	@Input FolioChargeInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func chargeFolio(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "chargeFolio"
+	var foo FolioChargeInput
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	if _, err := getBizFolio(r, d); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	ar, err := rlib.GetAR(r.Context(), foo.ARID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if ar.ARID == 0 || ar.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("account rule %d not found", foo.ARID), funcname)
+		return
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	a, err := bizlogic.PostFolioCharge(ctx, d.ID, foo.ARID, foo.Amount, strings.TrimSpace(foo.Comment))
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, a.ASMID)
+}
+
+// checkOutFolio closes a folio
+// wsdoc {
+//  @Title  Check Out
+//	@URL /v1/folio/:BUI/:FOLID
+//  @Method  POST
+//	@Synopsis Close a guest folio
+//  @Description  Closes the folio.  A guest leaving before the departure
+//  @Description  date departs on the business date and the nights left are
+//  @Description  not posted.
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func checkOutFolio(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "checkOutFolio"
+
+	if _, err := getBizFolio(r, d); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if _, err := bizlogic.CloseFolio(r.Context(), d.ID); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// getBizFolio returns folio d.ID, or an error unless it belongs to business
+// d.BID
+//-----------------------------------------------------------------------------
+func getBizFolio(r *http.Request, d *ServiceData) (rlib.Folio, error) {
+	f, err := rlib.GetFolio(r.Context(), d.ID)
+	if err != nil {
+		return f, err
+	}
+	if f.FOLID == 0 || f.BID != d.BID {
+		return f, fmt.Errorf("folio %d not found", d.ID)
+	}
+	return f, nil
+}
@@ -0,0 +1,147 @@
+package ws
+
+import (
+	"fmt"
+	"net/http"
+	"rentroll/rlib"
+)
+
+// KPIMetricsGrid is the occupancy and leasing KPI of the business or of one
+// of its rentable types.  The percents are 0 - 100.
+type KPIMetricsGrid struct {
+	Recid             int64 `json:"recid"`
+	RTID              int64 // 0 for the whole business
+	Style             string
+	Name              string
+	Rentables         int64
+	RentableDays      float64
+	DownDays          float64
+	OccupiedDays      float64
+	PhysicalOccupancy float64
+	GSR               float64
+	VacancyLoss       float64
+	EconomicOccupancy float64
+	Leased            int64
+	Reserved          int64
+	MoveIns           int64
+	MoveOuts          int64
+	Turnover          float64
+	VacanciesFilled   int64
+	AvgDaysVacant     float64
+	TradeOut          float64
+}
+
+// KPIPeriodForm is the KPI of a period, for the business and for each
+// rentable type
+type KPIPeriodForm struct {
+	DtStart       rlib.JSONDate
+	DtStop        rlib.JSONDate
+	Business      KPIMetricsGrid
+	RentableTypes []KPIMetricsGrid
+}
+
+// KPIForm is the KPI of a business over searchDtStart - searchDtStop, with
+// the monthly trend
+type KPIForm struct {
+	BID     int64
+	BUD     rlib.XJSONBud
+	DtStart rlib.JSONDate
+	DtStop  rlib.JSONDate
+	Total   KPIPeriodForm
+	Months  []KPIPeriodForm
+}
+
+// KPIResponse is the response to the get command
+type KPIResponse struct {
+	Status string  `json:"status"`
+	Record KPIForm `json:"record"`
+}
+
+// SvcHandlerKPI returns the occupancy and leasing KPI of a business
+//
+// The server command can be:
+//      get     - the KPI over searchDtStart - searchDtStop
+//-----------------------------------------------------------------------------
+func SvcHandlerKPI(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerKPI"
+	var (
+		err error
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getKPI(w, r, d)
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// kpiPeriodForm converts period p of business xbiz to its response form
+func kpiPeriodForm(xbiz *rlib.XBusiness, p *rlib.KPIPeriod) KPIPeriodForm {
+	f := KPIPeriodForm{
+		DtStart: rlib.JSONDate(p.DtStart),
+		DtStop:  rlib.JSONDate(p.DtStop),
+	}
+	rlib.MigrateStructVals(&p.Business, &f.Business)
+	f.Business.Recid = 1
+	f.Business.Name = xbiz.P.Name
+	rtids := p.RTIDs()
+	for i := 0; i < len(rtids); i++ {
+		var g KPIMetricsGrid
+		rlib.MigrateStructVals(p.RentableTypes[rtids[i]], &g)
+		g.Recid = int64(i + 2)
+		g.RTID = rtids[i]
+		g.Style = xbiz.RT[rtids[i]].Style
+		g.Name = xbiz.RT[rtids[i]].Name
+		f.RentableTypes = append(f.RentableTypes, g)
+	}
+	return f
+}
+
+// getKPI computes the occupancy and leasing KPI of a business
+// wsdoc {
+//  @Title  Occupancy And Leasing KPI
+//	@URL /v1/kpi/:BUI
+//  @Method  POST
+//	@Synopsis Get the occupancy and leasing KPI of a business
+//  @Description  Returns the physical and economic occupancy, vacancy loss,
+//  @Description  move-ins, move-outs, turnover, average days vacant and lease
+//  @Description  trade-out of the business and of each rentable type over
+//  @Description  searchDtStart - searchDtStop.  Months has the same measures
+//  @Description  for each month of the range, for trend charts.  Leased and
+//  @Description  Reserved count the rentables at the end of each period.
+//	@Input WebGridSearchRequest
+//  @Response KPIResponse
+// wsdoc }
+func getKPI(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getKPI"
+	var g KPIResponse
+	var xbiz rlib.XBusiness
+
+	if err := rlib.InitBizInternals(d.BID, &xbiz); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	k, err := rlib.GetLeasingKPI(r.Context(), &xbiz, &d.wsSearchReq.SearchDtStart, &d.wsSearchReq.SearchDtStop)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Record = KPIForm{
+		BID:     d.BID,
+		BUD:     rlib.GetBUDFromBIDList(d.BID),
+		DtStart: rlib.JSONDate(k.DtStart),
+		DtStop:  rlib.JSONDate(k.DtStop),
+		Total:   kpiPeriodForm(&xbiz, &k.Total),
+	}
+	for i := 0; i < len(k.Months); i++ {
+		g.Record.Months = append(g.Record.Months, kpiPeriodForm(&xbiz, &k.Months[i]))
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
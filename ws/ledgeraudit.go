@@ -0,0 +1,184 @@
+package ws
+
+import (
+	"fmt"
+	"net/http"
+	"rentroll/rlib"
+)
+
+// LedgerAuditGrid is one problem found by the ledger audit
+type LedgerAuditGrid struct {
+	Recid    int64 `json:"recid"`
+	Kind     int64 // 0 = journal unbalanced, 1 = ledger unbalanced, 2 = not posted, 3 = orphan entry, 4 = marker drift
+	KindName string
+	Dt       rlib.JSONDate
+	JID      int64
+	LEID     int64
+	LID      int64
+	GLNumber string
+	LMID     int64
+	Amount   float64 // the amount or balance found
+	Expected float64 // the amount or balance it should be
+	Message  string
+}
+
+// LedgerAuditForm is the result of a ledger audit
+type LedgerAuditForm struct {
+	BID      int64
+	BUD      rlib.XJSONBud
+	DtStart  rlib.JSONDate
+	DtStop   rlib.JSONDate
+	Journals int64
+	Entries  int64
+	Markers  int64
+	Repaired []rlib.JSONDate // the dates whose ledger markers were regenerated
+	Problems []LedgerAuditGrid
+}
+
+// LedgerAuditResponse is the response to the get and repair commands
+type LedgerAuditResponse struct {
+	Status string          `json:"status"`
+	Record LedgerAuditForm `json:"record"`
+}
+
+// SvcHandlerLedgerAudit checks the double-entry integrity of the journals
+// and ledgers of a business over searchDtStart - searchDtStop
+//
+// The server command can be:
+//      get     - audit, nothing is changed
+//      repair  - regenerate the drifted ledger markers, then audit again
+//-----------------------------------------------------------------------------
+func SvcHandlerLedgerAudit(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerLedgerAudit"
+	var (
+		err error
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getLedgerAudit(w, r, d)
+	case "repair":
+		repairLedgerAudit(w, r, d)
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// ledgerAuditForm converts audit a to its response form
+func ledgerAuditForm(a *rlib.LedgerAudit) LedgerAuditForm {
+	f := LedgerAuditForm{
+		BID:      a.BID,
+		BUD:      rlib.GetBUDFromBIDList(a.BID),
+		DtStart:  rlib.JSONDate(a.DtStart),
+		DtStop:   rlib.JSONDate(a.DtStop),
+		Journals: int64(a.Journals),
+		Entries:  int64(a.Entries),
+		Markers:  int64(a.Markers),
+	}
+	for i := 0; i < len(a.Problems); i++ {
+		var g LedgerAuditGrid
+		rlib.MigrateStructVals(&a.Problems[i], &g)
+		g.Recid = int64(i + 1)
+		g.KindName = a.Problems[i].KindName()
+		if gl, ok := rlib.RRdb.BizTypes[a.BID].GLAccounts[g.LID]; ok {
+			g.GLNumber = gl.GLNumber
+		}
+		f.Problems = append(f.Problems, g)
+	}
+	return f
+}
+
+// getLedgerAudit audits the journals and ledgers of a business
+// wsdoc {
+//  @Title  Ledger Audit
+//	@URL /v1/ledgeraudit/:BUI
+//  @Method  POST
+//	@Synopsis Check the journals and ledgers of a business
+//  @Description  Reports the journals whose allocations do not add up to
+//  @Description  their amount or whose ledger entries do not net to zero,
+//  @Description  the ledger entries without a journal or allocation, and the
+//  @Description  ledger markers dated in searchDtStart - searchDtStop whose
+//  @Description  balance is not the prior balance plus the entries since.
+//  @Description  Nothing is changed.
+//	@Input WebGridSearchRequest
+//  @Response LedgerAuditResponse
+// wsdoc }
+func getLedgerAudit(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getLedgerAudit"
+	var g LedgerAuditResponse
+
+	a, err := rlib.AuditLedgers(r.Context(), d.BID, &d.wsSearchReq.SearchDtStart, &d.wsSearchReq.SearchDtStop)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Record = ledgerAuditForm(&a)
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// repairLedgerAudit regenerates the drifted ledger markers of a business
+// wsdoc {
+//  @Title  Ledger Audit Repair
+//	@URL /v1/ledgeraudit/:BUI
+//  @Method  POST
+//	@Synopsis Regenerate the drifted ledger markers of a business
+//  @Description  Audits searchDtStart - searchDtStop, then regenerates the
+//  @Description  ledger markers on each date where a marker drifted.  The
+//  @Description  response is the audit after the repair, Repaired lists the
+//  @Description  dates regenerated.  Use cmd "get" first to see the changes.
+//  @Description  If a drifted marker is in a closed period the repair fails
+//  @Description  and nothing is changed.
+//	@Input WebGridSearchRequest
+//  @Response LedgerAuditResponse
+// wsdoc }
+func repairLedgerAudit(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "repairLedgerAudit"
+	var g LedgerAuditResponse
+	var xbiz rlib.XBusiness
+
+	rlib.Console("Entered %s\n", funcname)
+
+	if err := rlib.InitBizInternals(d.BID, &xbiz); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	a, err := rlib.AuditLedgers(ctx, d.BID, &d.wsSearchReq.SearchDtStart, &d.wsSearchReq.SearchDtStop)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	dts, err := rlib.RepairLedgerMarkers(ctx, &xbiz, &a)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a, err = rlib.AuditLedgers(ctx, d.BID, &d.wsSearchReq.SearchDtStart, &d.wsSearchReq.SearchDtStop); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Record = ledgerAuditForm(&a)
+	for i := 0; i < len(dts); i++ {
+		g.Record.Repaired = append(g.Record.Repaired, rlib.JSONDate(dts[i]))
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
@@ -0,0 +1,210 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/rlib"
+	"strings"
+)
+
+// MaintenanceRequestGrid is a maintenance request submitted by a tenant
+type MaintenanceRequestGrid struct {
+	Recid        int64 `json:"recid"`
+	MRID         int64
+	BID          int64
+	BUD          rlib.XJSONBud
+	RAID         int64
+	RID          int64
+	RentableName string
+	TCID         int64
+	Tenant       string
+	Dt           rlib.JSONDateTime
+	Category     string
+	Description  string
+	ContactPhone string
+	Status       int64 // 0 = open, 1 = accepted, 2 = closed
+	Response     string
+	FLAGS        uint64 // 1<<0 = permission to enter
+}
+
+// MaintenanceRequestResponse is the response to the get command
+type MaintenanceRequestResponse struct {
+	Status string                 `json:"status"`
+	Record MaintenanceRequestGrid `json:"record"`
+}
+
+// MaintenanceRequestListResponse is the response to the list command
+type MaintenanceRequestListResponse struct {
+	Status  string                   `json:"status"`
+	Total   int64                    `json:"total"`
+	Records []MaintenanceRequestGrid `json:"records"`
+}
+
+// MaintenanceRequestSaveForm is the property manager's answer to a request
+type MaintenanceRequestSaveForm struct {
+	Recid    int64 `json:"recid"`
+	MRID     int64
+	Status   int64
+	Response string
+}
+
+// MaintenanceRequestSave is the input data format for the save command
+type MaintenanceRequestSave struct {
+	Cmd    string                     `json:"cmd"`
+	Record MaintenanceRequestSaveForm `json:"record"`
+}
+
+// SvcHandlerMaintenanceRequest handles the maintenance requests submitted
+// through the tenant portal for the request d.ID
+//
+// The server command can be:
+//      get   - read the request
+//      list  - the requests submitted in searchDtStart - searchDtStop
+//      save  - set the status of the request and the response to the tenant
+//-----------------------------------------------------------------------------
+func SvcHandlerMaintenanceRequest(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerMaintenanceRequest"
+	var (
+		err error
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("Request: %s:  BID = %d,  MRID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getMaintenanceRequest(w, r, d)
+	case "list":
+		listMaintenanceRequests(w, r, d)
+	case "save":
+		saveMaintenanceRequest(w, r, d)
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// maintenanceRequestGrid converts request mr to its grid form
+func maintenanceRequestGrid(ctx context.Context, mr *rlib.MaintenanceRequest) MaintenanceRequestGrid {
+	var g MaintenanceRequestGrid
+	var t rlib.Transactant
+	rlib.MigrateStructVals(mr, &g)
+	g.Recid = mr.MRID
+	g.BUD = rlib.GetBUDFromBIDList(mr.BID)
+	if err := rlib.GetTransactant(ctx, mr.TCID, &t); err == nil {
+		g.Tenant = t.GetFullTransactantName()
+	}
+	if mr.RID > 0 {
+		if rnt, err := rlib.GetRentable(ctx, mr.RID); err == nil {
+			g.RentableName = rnt.RentableName
+		}
+	}
+	return g
+}
+
+// getMaintenanceRequest reads a maintenance request
+// wsdoc {
+//  @Title  Get Maintenance Request
+//	@URL /v1/maintreq/:BUI/:MRID
+//  @Method  POST
+//	@Synopsis Get a maintenance request
+//  @Description  Returns the request submitted by the tenant
+//	@Input WebGridSearchRequest
+//  @Response MaintenanceRequestResponse
+// wsdoc }
+func getMaintenanceRequest(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getMaintenanceRequest"
+	var g MaintenanceRequestResponse
+
+	mr, err := rlib.GetMaintenanceRequest(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if mr.MRID == 0 || mr.BID != d.BID {
+		err = fmt.Errorf("maintenance request %d not found", d.ID)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Record = maintenanceRequestGrid(r.Context(), &mr)
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// listMaintenanceRequests lists the maintenance requests of a business
+// wsdoc {
+//  @Title  List Maintenance Requests
+//	@URL /v1/maintreq/:BUI
+//  @Method  POST
+//	@Synopsis List the maintenance requests of a business
+//  @Description  Returns the requests submitted in searchDtStart -
+//  @Description  searchDtStop, oldest first
+//	@Input WebGridSearchRequest
+//  @Response MaintenanceRequestListResponse
+// wsdoc }
+func listMaintenanceRequests(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "listMaintenanceRequests"
+	var g MaintenanceRequestListResponse
+
+	m, err := rlib.GetMaintenanceRequests(r.Context(), d.BID, &d.wsSearchReq.SearchDtStart, &d.wsSearchReq.SearchDtStop)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	for i := 0; i < len(m); i++ {
+		g.Records = append(g.Records, maintenanceRequestGrid(r.Context(), &m[i]))
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveMaintenanceRequest saves the status of a maintenance request and the
+// response that the tenant sees in the portal
+// wsdoc {
+//  @Title  Save Maintenance Request
+//	@URL /v1/maintreq/:BUI/:MRID
+//  @Method  POST
+//	@Synopsis Answer a maintenance request
+//  @Description  Only the Status and the Response can be changed, the rest
+//  @Description  of the request is the tenant's.
+//	@Input MaintenanceRequestSave
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveMaintenanceRequest(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveMaintenanceRequest"
+	var foo MaintenanceRequestSave
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	if foo.Record.Status < rlib.MRStatusOpen || foo.Record.Status > rlib.MRStatusLast {
+		SvcErrorReturn(w, fmt.Errorf("invalid status: %d", foo.Record.Status), funcname)
+		return
+	}
+	mr, err := rlib.GetMaintenanceRequest(r.Context(), foo.Record.MRID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if mr.MRID == 0 || mr.BID != d.BID {
+		err = fmt.Errorf("maintenance request %d not found", foo.Record.MRID)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	mr.Status = foo.Record.Status
+	mr.Response = strings.TrimSpace(foo.Record.Response)
+	if err = rlib.UpdateMaintenanceRequest(r.Context(), &mr); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, mr.MRID)
+}
@@ -0,0 +1,153 @@
+package ws
+
+import (
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// NightAuditGrid is the night audit of one business date
+type NightAuditGrid struct {
+	Recid       int64 `json:"recid"`
+	NAID        int64
+	Dt          rlib.JSONDate
+	Rooms       int64
+	Occupied    int64
+	Arrivals    int64
+	Departures  int64
+	Occupancy   float64 // percent of the rooms occupied
+	ADR         float64 // average daily rate
+	RevPAR      float64 // revenue per available room
+	RoomRevenue float64
+	TaxRevenue  float64
+	Comment     string
+}
+
+// NightAuditListResponse is the response to the list command
+type NightAuditListResponse struct {
+	Status       string           `json:"status"`
+	Total        int64            `json:"total"`
+	Records      []NightAuditGrid `json:"records"`
+	BusinessDate rlib.JSONDate    // the next business date to audit
+}
+
+// NightAuditRunResponse is the response to the run command
+type NightAuditRunResponse struct {
+	Status string         `json:"status"`
+	Record NightAuditGrid `json:"record"`
+}
+
+// SvcHandlerNightAudit handles the night audits of a business.
+//
+// The server command can be:
+//      get, list - the night audits of the business dates in
+//                  searchDtStart - searchDtStop
+//      run       - audit the business date
+//-----------------------------------------------------------------------------
+func SvcHandlerNightAudit(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerNightAudit"
+	var (
+		err error
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get", "list":
+		listNightAudits(w, r, d)
+	case "run":
+		runNightAudit(w, r, d)
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// nightAuditGrid converts night audit a to its grid form
+func nightAuditGrid(a *rlib.NightAudit) NightAuditGrid {
+	var g NightAuditGrid
+	rlib.MigrateStructVals(a, &g)
+	g.Recid = a.NAID
+	g.Occupancy = a.Occupancy()
+	g.ADR = a.ADR()
+	g.RevPAR = a.RevPAR()
+	return g
+}
+
+// listNightAudits lists the night audits of a business
+// wsdoc {
+//  @Title  List Night Audits
+//	@URL /v1/nightaudit/:BUI
+//  @Method  POST
+//	@Synopsis List the night audits
+//  @Description  Returns the night audits of the business dates in
+//  @Description  searchDtStart - searchDtStop with their occupancy, ADR and
+//  @Description  RevPAR, and the business date the next audit is for.
+//	@Input WebGridSearchRequest
+//  @Response NightAuditListResponse
+// wsdoc }
+func listNightAudits(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "listNightAudits"
+	var g NightAuditListResponse
+
+	m, err := rlib.GetNightAuditsInRange(r.Context(), d.BID, &d.wsSearchReq.SearchDtStart, &d.wsSearchReq.SearchDtStop)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	for i := 0; i < len(m); i++ {
+		g.Records = append(g.Records, nightAuditGrid(&m[i]))
+	}
+	bd, err := rlib.GetBusinessDate(r.Context(), d.BID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.BusinessDate = rlib.JSONDate(bd)
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// runNightAudit audits the business date of a business
+// wsdoc {
+//  @Title  Run Night Audit
+//	@URL /v1/nightaudit/:BUI
+//  @Method  POST
+//	@Synopsis Audit the business date
+//  @Description  Posts the room charge of the night, and its taxes, to each
+//  @Description  open folio in house on the business date, records the
+//  @Description  night's figures and rolls the business date to the next day.
+//  @Description  A business date cannot be audited before it starts.
+//	@Input WebGridSearchRequest
+//  @Response NightAuditRunResponse
+// wsdoc }
+func runNightAudit(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "runNightAudit"
+	var g NightAuditRunResponse
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	now := time.Now()
+	na, err := bizlogic.RunNightAudit(ctx, d.BID, &now)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Record = nightAuditGrid(&na)
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
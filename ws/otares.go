@@ -0,0 +1,135 @@
+package ws
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"strings"
+	"time"
+)
+
+// otaMaxMessage is the largest OpenTravel message accepted
+const otaMaxMessage = 1 << 20
+
+// OTAServiceHandler is the dispatch point for the OpenTravel messages sent
+// by channels.  Unlike the other services the messages are XML, and the
+// response is an OpenTravel XML response, even when the message fails.  The
+// channel is authenticated by the RequestorID of the message rather than a
+// session.
+//
+//       0   1  2         3
+//      /ota/v1/{message}/{BUI}
+//
+// The message can be:
+//      hotelresnotif  - OTA_HotelResNotifRQ, book, modify or cancel
+//                       reservations
+//-----------------------------------------------------------------------------
+func OTAServiceHandler(w http.ResponseWriter, r *http.Request) {
+	const funcname = "OTAServiceHandler"
+	var d ServiceData
+	var err error
+
+	ss := strings.Split(r.RequestURI[1:], "?")
+	d.pathElements = strings.Split(ss[0], "/")
+	if len(d.pathElements) < 4 {
+		SvcErrorReturn(w, fmt.Errorf("Service not recognized: %s", r.RequestURI), funcname)
+		return
+	}
+	d.Service = d.pathElements[2]
+	d.BID, err = getBIDfromBUI(d.pathElements[3])
+	if err != nil || d.BID <= 0 {
+		SvcErrorReturn(w, fmt.Errorf("Could not determine business from %s", d.pathElements[3]), funcname)
+		return
+	}
+	if r.Method != "POST" {
+		SvcErrorReturn(w, fmt.Errorf("%s requires POST", d.Service), funcname)
+		return
+	}
+	b, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, otaMaxMessage))
+	if err != nil {
+		SvcErrorReturn(w, fmt.Errorf("Error reading message body: %s", err.Error()), funcname)
+		return
+	}
+	d.data = string(b)
+
+	s := &rlib.Session{
+		Username: rlib.BotReg[rlib.OTAChannelApp].Designator,
+		Name:     rlib.BotReg[rlib.OTAChannelApp].Name,
+		UID:      rlib.OTAChannelApp,
+	}
+	r = r.WithContext(rlib.SetSessionContextKey(r.Context(), s))
+
+	switch d.Service {
+	case "hotelresnotif":
+		svcOTAHotelResNotif(w, r, &d)
+	default:
+		e := fmt.Errorf("Service not recognized: %s", d.Service)
+		rlib.Console("***ERROR IN URL***  %s\n", e.Error())
+		SvcErrorReturn(w, e, funcname)
+	}
+}
+
+// svcOTAHotelResNotif books, modifies or cancels the reservations of an
+// OTA_HotelResNotifRQ message.  The whole message is processed in one
+// transaction; if any reservation fails, none of them are changed.
+//
+// wsdoc {
+//  @Title  OTA Hotel Reservation Notification
+//	@URL /ota/v1/hotelresnotif/:BUI
+//  @Method  POST
+//	@Synopsis Book, modify or cancel reservations sent by a channel
+//  @Description  Each HotelReservation is booked, modified or cancelled
+//  @Description  as its ResStatus says.  The channel's confirmation number
+//  @Description  (UniqueID) identifies the reservation, so a message can be
+//  @Description  sent again safely.  The RoomTypeCode is the InvTypeCode of
+//  @Description  the rentable type in the rate and availability export.
+//	@Input OTA_HotelResNotifRQ
+//  @Response OTA_HotelResNotifRS
+// wsdoc }
+//-----------------------------------------------------------------------------
+func svcOTAHotelResNotif(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "svcOTAHotelResNotif"
+	var rq rlib.OTAHotelResNotifRQ
+	var m []rlib.OTAHotelReservationRS
+	now := time.Now()
+
+	rlib.Console("Entered %s\n", funcname)
+	err := xml.Unmarshal([]byte(d.data), &rq)
+	if err != nil {
+		err = rlib.NewOTAError(rlib.OTAErrTypeUnknown, rlib.OTAErrUnableToProc, "Error with xml.Unmarshal: %s", err.Error())
+	} else {
+		m, err = otaHotelResNotif(r, d, &rq)
+	}
+	if err != nil {
+		rlib.Ulog("%s: BID %d, %s: %s\n", funcname, d.BID, rq.RequestorID.ID, err.Error())
+	}
+	rs := rlib.NewOTAHotelResNotifRS(&rq, m, err, &now)
+	b, err := rlib.MarshalOTA(&rs)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	w.Write(b)
+}
+
+// otaHotelResNotif processes rq in a transaction
+func otaHotelResNotif(r *http.Request, d *ServiceData, rq *rlib.OTAHotelResNotifRQ) ([]rlib.OTAHotelReservationRS, error) {
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	m, err := bizlogic.ProcessOTAHotelResNotif(ctx, d.BID, rq)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,636 @@
+package ws
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"gotable"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"rentroll/rrpt"
+	"strings"
+	"time"
+)
+
+// The tenant portal is a separate surface from the staff services in Svcs.
+// Its requests have the form /portal/v1/{cmd}/{BUI}/{ID} and they are
+// authorized by a tenant session (rlib.TenantSession) instead of a staff
+// session.  Every request that names a rental agreement or a receipt is
+// checked against the agreements for which the tenant is a payor, so a
+// tenant can only ever see its own RAIDs.
+
+// PortalSvcs is the table of the tenant portal service handlers.
+// NeedSession means a tenant session is required.
+var PortalSvcs = []ServiceHandler{
+	{Cmd: "balance", Handler: SvcPortalBalance, NeedBiz: true, NeedSession: true},
+	{Cmd: "login", Handler: SvcPortalLogin, NeedBiz: true, NeedSession: false},
+	{Cmd: "logoff", Handler: SvcPortalLogoff, NeedBiz: true, NeedSession: true},
+	{Cmd: "maintreq", Handler: SvcPortalMaintenanceRequest, NeedBiz: true, NeedSession: true},
+	{Cmd: "receipt", Handler: SvcPortalReceipt, NeedBiz: true, NeedSession: true},
+	{Cmd: "receipts", Handler: SvcPortalReceipts, NeedBiz: true, NeedSession: true},
+	{Cmd: "stmt", Handler: SvcPortalStatement, NeedBiz: true, NeedSession: true},
+}
+
+// PortalLoginData is the input data format for the tenant login
+type PortalLoginData struct {
+	User string `json:"user"`
+	Pass string `json:"pass"`
+}
+
+// PortalLoginResponse is the response to a successful tenant login
+type PortalLoginResponse struct {
+	Status   string        `json:"status"`
+	BUD      rlib.XJSONBud `json:"BUD"`
+	TCID     int64         `json:"TCID"`
+	Name     string        `json:"Name"`
+	Username string        `json:"Username"`
+}
+
+// PortalRentalAgreement is the balance of one of the tenant's rental
+// agreements
+type PortalRentalAgreement struct {
+	Recid          int64         `json:"recid"`
+	RAID           int64         // the rental agreement
+	BUD            rlib.XJSONBud // business
+	AgreementStart rlib.JSONDate // start of the agreement
+	AgreementStop  rlib.JSONDate // end of the agreement
+	Rentables      string        // names of the rentables, comma separated
+	Balance        float64       // amount owed as of today, < 0 is a credit
+}
+
+// PortalBalanceResponse is the response to the balance request
+type PortalBalanceResponse struct {
+	Status  string                  `json:"status"`
+	Total   int64                   `json:"total"`
+	Records []PortalRentalAgreement `json:"records"`
+}
+
+// PortalReceipt is one receipt in the tenant's receipt history
+type PortalReceipt struct {
+	Recid       int64         `json:"recid"`
+	RCPTID      int64         // the receipt, use it to download the PDF
+	ID          string        // receipt number
+	Dt          rlib.JSONDate // date the payment was received
+	PaymentType string        // check, ACH, ...
+	DocNo       string        // check number, etc.
+	Amount      float64       // amount of the payment
+	Reversed    bool          // the receipt has been reversed
+}
+
+// PortalReceiptsResponse is the response to the receipts request
+type PortalReceiptsResponse struct {
+	Status  string          `json:"status"`
+	Total   int64           `json:"total"`
+	Records []PortalReceipt `json:"records"`
+}
+
+// PortalMaintenanceRequest is a maintenance request as seen by the tenant
+type PortalMaintenanceRequest struct {
+	Recid          int64         `json:"recid"`
+	MRID           int64         // the request
+	RAID           int64         // rental agreement
+	RID            int64         // rentable, 0 if not known
+	Dt             rlib.JSONDate // when it was submitted
+	Category       string        // plumbing, electrical, ...
+	Description    string        // what needs to be repaired
+	ContactPhone   string        // where the tenant can be reached
+	EntryPermitted bool          // staff may enter when the tenant is away
+	Status         int64         // 0 = open, 1 = accepted, 2 = closed
+	Response       string        // the property manager's answer
+}
+
+// PortalMaintenanceRequestsResponse is the response to the maintenance
+// request list
+type PortalMaintenanceRequestsResponse struct {
+	Status  string                     `json:"status"`
+	Total   int64                      `json:"total"`
+	Records []PortalMaintenanceRequest `json:"records"`
+}
+
+// PortalMaintenanceRequestSave is the input data format to submit a
+// maintenance request
+type PortalMaintenanceRequestSave struct {
+	Cmd    string                   `json:"cmd"`
+	Record PortalMaintenanceRequest `json:"record"`
+}
+
+// PortalServiceHandler is the main dispatch point for the tenant portal
+// requests.  The expected URL is:
+//
+//		/portal/v1/{cmd}/{BUI}/{ID}
+//
+// The request data has the same format as the staff services (see
+// V1ServiceHandler).  The staff session cookie is never looked at, a
+// tenant session for the same business is required for everything but
+// the login.
+//-----------------------------------------------------------------------------
+func PortalServiceHandler(w http.ResponseWriter, r *http.Request) {
+	const funcname = "PortalServiceHandler"
+	var err error
+	var d ServiceData
+
+	d.ID = -1  // indicates it has not been set
+	d.BID = -1 // indicates it has not been set
+
+	//-----------------------------------------------------------------------
+	// pathElements:  0      1   2     3     4
+	//               /portal/v1/{cmd}/{BUI}/{ID}
+	//-----------------------------------------------------------------------
+	ss := strings.Split(r.RequestURI[1:], "?") // it could be GET command
+	d.pathElements = strings.Split(ss[0], "/")
+	if len(d.pathElements) < 4 {
+		SvcErrorReturn(w, fmt.Errorf("Service not recognized: %s", r.RequestURI), funcname)
+		return
+	}
+	d.Service = d.pathElements[2]
+	d.BID, err = getBIDfromBUI(d.pathElements[3])
+	if err != nil || d.BID <= 0 {
+		e := fmt.Errorf("Could not determine business from %s", d.pathElements[3])
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	if len(d.pathElements) >= 5 {
+		d.DetVal = d.pathElements[4]
+		d.ID, err = rlib.IntFromString(d.DetVal, "bad request integer value")
+		if err != nil {
+			d.ID = 0
+		}
+	}
+
+	switch r.Method {
+	case "POST":
+		if nil != getPOSTdata(w, r, &d) {
+			return
+		}
+	case "GET":
+		if nil != getGETdata(w, r, &d) {
+			return
+		}
+	}
+
+	for i := 0; i < len(PortalSvcs); i++ {
+		if PortalSvcs[i].Cmd != d.Service {
+			continue
+		}
+		var s *rlib.Session
+		if PortalSvcs[i].NeedSession {
+			d.tsess = rlib.GetTenantSession(r)
+			if d.tsess == nil || d.tsess.BID != d.BID {
+				SvcErrorReturn(w, fmt.Errorf("session required, please log in"), funcname)
+				return
+			}
+			d.tsess.Refresh(w)
+			s = d.tsess.Session()
+		} else {
+			s = &rlib.Session{
+				Username: rlib.BotReg[rlib.TenantPortalApp].Designator,
+				Name:     rlib.BotReg[rlib.TenantPortalApp].Name,
+				UID:      rlib.TenantPortalApp,
+			}
+		}
+		r = r.WithContext(rlib.SetSessionContextKey(r.Context(), s))
+		PortalSvcs[i].Handler(w, r, &d)
+		return
+	}
+	e := fmt.Errorf("Service not recognized: %s", d.Service)
+	rlib.Console("***ERROR IN URL***  %s\n", e.Error())
+	SvcErrorReturn(w, e, funcname)
+}
+
+// portalCheckRAID writes an error response and returns false unless the
+// tenant of the session can see rental agreement raid
+func portalCheckRAID(w http.ResponseWriter, r *http.Request, d *ServiceData, raid int64, funcname string) bool {
+	ok, err := bizlogic.TenantCanAccessRAID(r.Context(), d.BID, d.tsess.TCID, raid)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return false
+	}
+	if !ok {
+		rlib.Ulog("%s: tenant login %d denied access to RAID %d\n", funcname, d.tsess.TLID, raid)
+		SvcErrorReturn(w, fmt.Errorf("rental agreement %d not found", raid), funcname)
+		return false
+	}
+	return true
+}
+
+// SvcPortalLogin logs a tenant in
+// wsdoc {
+//  @Title  Tenant Login
+//	@URL /portal/v1/login/:BUI
+//  @Method  POST
+//	@Synopsis Log a tenant in to the portal
+//  @Description  Checks the username and password of the tenant's portal
+//  @Description  login and creates a tenant session.  The session token is
+//  @Description  returned in the rrtenant cookie.  The login is locked after
+//  @Description  5 consecutive failures.
+//	@Input PortalLoginData
+//  @Response PortalLoginResponse
+// wsdoc }
+func SvcPortalLogin(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcPortalLogin"
+	var (
+		a PortalLoginData
+		g PortalLoginResponse
+		t rlib.Transactant
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+	// do not print d.data, it has the tenant's password
+
+	if err := json.Unmarshal([]byte(d.data), &a); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	tl, err := bizlogic.AuthenticateTenant(ctx, d.BID, a.User, a.Pass)
+	if err != nil && err != bizlogic.ErrTenantLogin {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if e := tx.Commit(); e != nil { // the failed login count must be saved
+		tx.Rollback()
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	if err = rlib.GetTransactant(r.Context(), tl.TCID, &t); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	s, err := rlib.TenantSessionNew(w, &tl, t.GetUserName())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	rlib.Ulog("tenant %s (TLID %d) logged in to the portal\n", s.Username, s.TLID)
+
+	g.BUD = rlib.GetBUDFromBIDList(d.BID)
+	g.TCID = s.TCID
+	g.Name = s.Name
+	g.Username = s.Username
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// SvcPortalLogoff ends the tenant's session
+// wsdoc {
+//  @Title  Tenant Logoff
+//	@URL /portal/v1/logoff/:BUI
+//  @Method  POST
+//	@Synopsis Log a tenant out of the portal
+//  @Description  Deletes the tenant session and expires its cookie
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func SvcPortalLogoff(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	rlib.TenantSessionDelete(d.tsess, w)
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// SvcPortalBalance returns the tenant's rental agreements and their balances
+// wsdoc {
+//  @Title  Tenant Balance
+//	@URL /portal/v1/balance/:BUI
+//  @Method  POST
+//	@Synopsis Get the balance of each of the tenant's rental agreements
+//  @Description  Returns every rental agreement for which the tenant is a
+//  @Description  payor with its balance as of today.
+//	@Input WebGridSearchRequest
+//  @Response PortalBalanceResponse
+// wsdoc }
+func SvcPortalBalance(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcPortalBalance"
+	var g PortalBalanceResponse
+
+	rlib.Console("Entered %s\n", funcname)
+
+	m, err := bizlogic.TenantRentalAgreements(r.Context(), d.BID, d.tsess.TCID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	now := time.Now()
+	for i := 0; i < len(m); i++ {
+		bal, err := rlib.GetRAIDBalance(r.Context(), m[i].RAID, &now)
+		if err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		rentables := []string{}
+		rar, err := rlib.GetRentalAgreementRentables(r.Context(), m[i].RAID, &m[i].AgreementStart, &m[i].AgreementStop)
+		if err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		for j := 0; j < len(rar); j++ {
+			rnt, err := rlib.GetRentable(r.Context(), rar[j].RID)
+			if err != nil {
+				SvcErrorReturn(w, err, funcname)
+				return
+			}
+			rentables = append(rentables, rnt.RentableName)
+		}
+		g.Records = append(g.Records, PortalRentalAgreement{
+			Recid:          m[i].RAID,
+			RAID:           m[i].RAID,
+			BUD:            rlib.GetBUDFromBIDList(d.BID),
+			AgreementStart: rlib.JSONDate(m[i].AgreementStart),
+			AgreementStop:  rlib.JSONDate(m[i].AgreementStop),
+			Rentables:      strings.Join(rentables, ", "),
+			Balance:        bal,
+		})
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// SvcPortalStatement returns the statement of one of the tenant's rental
+// agreements
+// wsdoc {
+//  @Title  Tenant Statement
+//	@URL /portal/v1/stmt/:BUI/:RAID
+//  @Method  POST
+//	@Synopsis Get the statement of a rental agreement
+//  @Description  Returns the opening balance, the assessments and receipts
+//  @Description  in searchDtStart - searchDtStop and the closing balance,
+//  @Description  exactly as the staff statement detail.
+//	@Input WebGridSearchRequest
+//  @Response StmtDetailResponse
+// wsdoc }
+func SvcPortalStatement(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcPortalStatement"
+
+	rlib.Console("Entered %s\n", funcname)
+
+	if !portalCheckRAID(w, r, d, d.ID, funcname) {
+		return
+	}
+	d1 := d.wsSearchReq.SearchDtStart
+	d2 := d.wsSearchReq.SearchDtStop
+	limit := d.wsSearchReq.Limit
+	if limit <= 0 {
+		limit = 1000
+	}
+	g, err := getStatementDetail(r.Context(), d.BID, d.ID, &d1, &d2, d.wsSearchReq.Offset, limit)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// SvcPortalReceipts returns the receipt history of one of the tenant's
+// rental agreements
+// wsdoc {
+//  @Title  Tenant Receipts
+//	@URL /portal/v1/receipts/:BUI/:RAID
+//  @Method  POST
+//	@Synopsis Get the receipts of a rental agreement
+//  @Description  Returns the receipts in searchDtStart - searchDtStop that
+//  @Description  were received for the rental agreement or applied to its
+//  @Description  assessments.  Without dates the last 12 months are returned.
+//	@Input WebGridSearchRequest
+//  @Response PortalReceiptsResponse
+// wsdoc }
+func SvcPortalReceipts(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcPortalReceipts"
+	var g PortalReceiptsResponse
+
+	rlib.Console("Entered %s\n", funcname)
+
+	if !portalCheckRAID(w, r, d, d.ID, funcname) {
+		return
+	}
+	d1 := d.wsSearchReq.SearchDtStart
+	d2 := d.wsSearchReq.SearchDtStop
+	if d1.IsZero() || d2.IsZero() {
+		now := time.Now()
+		d2 = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, rlib.RRdb.Zone).AddDate(0, 0, 1)
+		d1 = d2.AddDate(-1, 0, 0)
+	}
+	m, err := rlib.GetReceiptsByRAIDRange(r.Context(), d.BID, d.ID, &d1, &d2)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	pmt, err := rlib.GetPaymentTypesByBusiness(r.Context(), d.BID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	for i := 0; i < len(m); i++ {
+		g.Records = append(g.Records, PortalReceipt{
+			Recid:       m[i].RCPTID,
+			RCPTID:      m[i].RCPTID,
+			ID:          rlib.IDtoShortString("RCPT", m[i].RCPTID),
+			Dt:          rlib.JSONDate(m[i].Dt),
+			PaymentType: pmt[m[i].PMTID].Name,
+			DocNo:       m[i].DocNo,
+			Amount:      m[i].Amount,
+			Reversed:    m[i].FLAGS&rlib.RCPTREVERSED != 0,
+		})
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// SvcPortalReceipt downloads one of the tenant's receipts as a PDF
+// wsdoc {
+//  @Title  Download Tenant Receipt
+//	@URL /portal/v1/receipt/:BUI/:RCPTID
+//  @Method  GET
+//	@Synopsis Download a receipt
+//  @Description  The receipt must have been paid by the tenant or applied to
+//  @Description  one of the tenant's rental agreements.
+//	@Input WebGridSearchRequest
+//  @Response application/pdf
+// wsdoc }
+func SvcPortalReceipt(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcPortalReceipt"
+	var (
+		buf  bytes.Buffer
+		xbiz rlib.XBusiness
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+
+	rcpt, err := rlib.GetReceipt(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	ok, err := bizlogic.TenantCanAccessReceipt(r.Context(), d.BID, d.tsess.TCID, &rcpt)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if !ok {
+		rlib.Ulog("%s: tenant login %d denied access to RCPTID %d\n", funcname, d.tsess.TLID, d.ID)
+		SvcErrorReturn(w, fmt.Errorf("receipt %d not found", d.ID), funcname)
+		return
+	}
+	if err = rlib.GetXBusiness(r.Context(), d.BID, &xbiz); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	ri := rrpt.ReporterInfo{
+		OutputFormat: gotable.TABLEOUTPDF,
+		Bid:          d.BID,
+		Raid:         rcpt.RAID,
+		ID:           rcpt.RCPTID,
+		Xbiz:         &xbiz,
+	}
+	tsh := rrpt.SingleTableReportHandler{
+		ReportNames:             []string{"RPTrcpt", "receipt"},
+		TableHandler:            rrpt.RRRcptOnlyReceiptTable,
+		PDFprops:                rrpt.ReceiptPDFProps,
+		HTMLTemplate:            "",
+		NeedsCustomPDFDimension: false,
+		NeedsPDFTitle:           false,
+	}
+	rctx := rrpt.ReportContext{
+		PDFPageSizeUnit: "in",
+		PDFPageWidth:    float64(8.5),
+		PDFPageHeight:   float64(11),
+	}
+	tbl := rrpt.RRRcptOnlyReceiptTable(r.Context(), &ri)
+	if err = rrpt.WritePDFReport(&buf, &tsh, &ri, &rctx, &tbl); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	fname := fmt.Sprintf("%s_%s.pdf", rlib.GetBUDFromBIDList(d.BID), rlib.IDtoShortString("RCPT", rcpt.RCPTID))
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment;filename=%s", fname))
+	w.Write(buf.Bytes())
+}
+
+// SvcPortalMaintenanceRequest handles the tenant's maintenance requests for
+// rental agreement d.ID
+//
+// The server command can be:
+//      get   - list the requests submitted for the rental agreement
+//      save  - submit a new request
+//-----------------------------------------------------------------------------
+func SvcPortalMaintenanceRequest(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcPortalMaintenanceRequest"
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("Request: %s:  BID = %d,  RAID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	if !portalCheckRAID(w, r, d, d.ID, funcname) {
+		return
+	}
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		portalMaintenanceRequests(w, r, d)
+	case "save":
+		submitPortalMaintenanceRequest(w, r, d)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// portalMaintenanceRequests lists the tenant's maintenance requests
+// wsdoc {
+//  @Title  Tenant Maintenance Requests
+//	@URL /portal/v1/maintreq/:BUI/:RAID
+//  @Method  POST
+//	@Synopsis List the maintenance requests of a rental agreement
+//  @Description  Returns the requests submitted for the rental agreement,
+//  @Description  most recent first, with the property manager's response.
+//	@Input WebGridSearchRequest
+//  @Response PortalMaintenanceRequestsResponse
+// wsdoc }
+func portalMaintenanceRequests(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "portalMaintenanceRequests"
+	var g PortalMaintenanceRequestsResponse
+
+	m, err := rlib.GetMaintenanceRequestsByRAID(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	for i := 0; i < len(m); i++ {
+		if m[i].BID != d.BID {
+			continue
+		}
+		var q PortalMaintenanceRequest
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].MRID
+		q.EntryPermitted = m[i].FLAGS&rlib.FlMREntryPermitted != 0
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// submitPortalMaintenanceRequest submits a maintenance request
+// wsdoc {
+//  @Title  Submit Maintenance Request
+//	@URL /portal/v1/maintreq/:BUI/:RAID
+//  @Method  POST
+//	@Synopsis Submit a maintenance request for a rental agreement
+//  @Description  Send the Description and optionally the RID, Category,
+//  @Description  ContactPhone and EntryPermitted.  Submitted requests cannot
+//  @Description  be changed by the tenant.
+//	@Input PortalMaintenanceRequestSave
+//  @Response SvcStatusResponse
+// wsdoc }
+func submitPortalMaintenanceRequest(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "submitPortalMaintenanceRequest"
+	var foo PortalMaintenanceRequestSave
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	mr := rlib.MaintenanceRequest{
+		BID:          d.BID,
+		RAID:         d.ID,
+		RID:          foo.Record.RID,
+		TCID:         d.tsess.TCID,
+		Category:     foo.Record.Category,
+		Description:  foo.Record.Description,
+		ContactPhone: foo.Record.ContactPhone,
+	}
+	if foo.Record.EntryPermitted {
+		mr.FLAGS |= rlib.FlMREntryPermitted
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if errlist := bizlogic.SubmitMaintenanceRequest(ctx, &mr); len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, mr.MRID)
+}
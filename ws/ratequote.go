@@ -0,0 +1,136 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/rlib"
+	"time"
+)
+
+// RateQuoteInput describes the stay to quote
+type RateQuoteInput struct {
+	Cmd       string        `json:"cmd"`
+	RTID      int64         // the rentable type
+	RSPIDs    []int64       // the rentable specialties wanted
+	DtStart   rlib.JSONDate // start of the stay
+	DtStop    rlib.JSONDate // end of the stay
+	Ages      []int64       // age of each occupant
+	PromoCode string        // promo code, "" if none
+	RPID      int64         // quote this rate plan only, 0 for any
+}
+
+// RateQuotePeriodGrid is the quote for one period of the stay
+type RateQuotePeriodGrid struct {
+	Recid      int64 `json:"recid"`
+	DtStart    rlib.JSONDate
+	DtStop     rlib.JSONDate
+	RPID       int64
+	RPRID      int64
+	MarketRate float64
+	Items      []rlib.RateQuoteItem
+	Total      float64
+}
+
+// RateQuoteResponse is the response to the quote command
+type RateQuoteResponse struct {
+	Status    string                `json:"status"`
+	Total     int64                 `json:"total"`
+	Records   []RateQuotePeriodGrid `json:"records"`
+	RentCycle int64                 // length of each period
+	Amount    float64               // price of the stay
+}
+
+// SvcHandlerRateQuote quotes the price of a stay from the rate plans of a
+// business.
+//
+// The server command can be:
+//      get, quote - price the stay described in the request data
+//-----------------------------------------------------------------------------
+func SvcHandlerRateQuote(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerRateQuote"
+	var (
+		err error
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get", "quote":
+		getRateQuote(w, r, d)
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getRateQuote prices a stay
+// wsdoc {
+//  @Title  Rate Quote
+//	@URL /v1/ratequote/:BUI
+//  @Method  POST
+//	@Synopsis Quote the price of a stay
+//  @Description  Prices a stay in a rentable type with the specialties, the
+//  @Description  occupants and the promo code given.  The stay is divided in
+//  @Description  periods of the rent cycle of the rentable type, nights for
+//  @Description  the types rented daily.  For each period the rate plan in
+//  @Description  effect that applies is used: rate plans with a promo code
+//  @Description  apply only when it matches, and are preferred when they do,
+//  @Description  otherwise the lowest price wins.  Without a rate plan the
+//  @Description  market rate is quoted.  Each period lists its charges.
+//	@Input RateQuoteInput
+//  @Response RateQuoteResponse
+// wsdoc }
+func getRateQuote(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getRateQuote"
+	var (
+		foo  RateQuoteInput
+		g    RateQuoteResponse
+		xbiz rlib.XBusiness
+	)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	if err := rlib.InitBizInternals(d.BID, &xbiz); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	q := rlib.RateQuoteRequest{
+		BID:       d.BID,
+		RTID:      foo.RTID,
+		RSPIDs:    foo.RSPIDs,
+		DtStart:   time.Time(foo.DtStart),
+		DtStop:    time.Time(foo.DtStop),
+		Ages:      foo.Ages,
+		PromoCode: foo.PromoCode,
+		RPID:      foo.RPID,
+	}
+	quote, err := rlib.GetRateQuote(r.Context(), &xbiz, &q)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	for i := 0; i < len(quote.Periods); i++ {
+		p := &quote.Periods[i]
+		g.Records = append(g.Records, RateQuotePeriodGrid{
+			Recid:      int64(i + 1),
+			DtStart:    rlib.JSONDate(p.DtStart),
+			DtStop:     rlib.JSONDate(p.DtStop),
+			RPID:       p.RPID,
+			RPRID:      p.RPRID,
+			MarketRate: p.MarketRate,
+			Items:      p.Items,
+			Total:      p.Total,
+		})
+	}
+	g.Total = int64(len(g.Records))
+	g.RentCycle = quote.RentCycle
+	g.Amount = quote.Total
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
@@ -0,0 +1,252 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// RateRecommendationGrid is one market rate recommendation
+type RateRecommendationGrid struct {
+	Recid              int64 `json:"recid"`
+	RCMID              int64
+	RTID               int64
+	RentableTypeName   string
+	DtStart            rlib.JSONDate
+	DtStop             rlib.JSONDate
+	CurrentRate        float64
+	RecommendedRate    float64
+	AppliedRate        float64
+	Rentables          int64
+	Occupied           int64
+	MoveOuts           int64
+	ProjectedOccupancy float64
+	AvgDaysVacant      float64
+	SeasonalPct        float64
+	Status             int64
+	StatusName         string
+	Comment            string
+}
+
+// RateRecommendationListResponse is the response to the list command
+type RateRecommendationListResponse struct {
+	Status  string                   `json:"status"`
+	Total   int64                    `json:"total"`
+	Records []RateRecommendationGrid `json:"records"`
+}
+
+// RateRecommendationGenerateResponse is the response to the generate command
+type RateRecommendationGenerateResponse struct {
+	Status string `json:"status"`
+	Count  int    `json:"count"` // number of recommendations made
+}
+
+// RateRecommendationApprove is the input data of the approve command
+type RateRecommendationApprove struct {
+	Cmd         string  `json:"cmd"`
+	AppliedRate float64 `json:"AppliedRate"` // 0 to apply the recommended rate
+}
+
+// SvcHandlerRateRecommendation handles the market rate recommendations of
+// the rentable types of a business.  d.ID is the RCMID of the
+// recommendation for the approve and reject commands.
+//
+// The server command can be:
+//      list     - the recommendations starting in searchDtStart - searchDtStop
+//      generate - recommend the market rates for next month
+//      approve  - apply a recommendation to the market rate of its type
+//      reject   - reject a recommendation
+//-----------------------------------------------------------------------------
+func SvcHandlerRateRecommendation(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerRateRecommendation"
+	var (
+		err error
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("Request: %s:  BID = %d,  RCMID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get", "list":
+		listRateRecommendations(w, r, d)
+	case "generate":
+		generateRateRecommendations(w, r, d)
+	case "approve":
+		approveRateRecommendation(w, r, d)
+	case "reject":
+		rejectRateRecommendation(w, r, d)
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// listRateRecommendations lists the market rate recommendations of a
+// business
+// wsdoc {
+//  @Title  List Rate Recommendations
+//	@URL /v1/raterec/:BUI
+//  @Method  POST
+//	@Synopsis List the market rate recommendations
+//  @Description  Returns the market rate recommendations that start in
+//  @Description  searchDtStart - searchDtStop, with the figures each one is
+//  @Description  based on and the rate applied to those approved.
+//	@Input WebGridSearchRequest
+//  @Response RateRecommendationListResponse
+// wsdoc }
+func listRateRecommendations(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "listRateRecommendations"
+	var g RateRecommendationListResponse
+
+	m, err := rlib.GetRateRecommendationsInRange(r.Context(), d.BID, &d.wsSearchReq.SearchDtStart, &d.wsSearchReq.SearchDtStop)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	rtm, err := rlib.GetBusinessRentableTypes(r.Context(), d.BID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	for i := 0; i < len(m); i++ {
+		var q RateRecommendationGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].RCMID
+		q.RentableTypeName = rtm[m[i].RTID].Name
+		q.StatusName = rlib.RateRecommendationStatus[m[i].Status]
+		f := rlib.PricingFactors{Rentables: m[i].Rentables, Occupied: m[i].Occupied, MoveOuts: m[i].MoveOuts}
+		q.ProjectedOccupancy = f.ProjectedOccupancy()
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// generateRateRecommendations recommends the market rates of a business
+// wsdoc {
+//  @Title  Generate Rate Recommendations
+//	@URL /v1/raterec/:BUI
+//  @Method  POST
+//	@Synopsis Recommend the market rates for next month
+//  @Description  Recommends a market rate for each active rentable type,
+//  @Description  starting on the first day of next month.  The rate is based
+//  @Description  on the occupancy of the type, its notices to move, the
+//  @Description  length of its recent vacancies and the seasonal adjustment
+//  @Description  of the month.  Types that already have a recommendation for
+//  @Description  that date are skipped.
+//	@Input WebGridSearchRequest
+//  @Response RateRecommendationGenerateResponse
+// wsdoc }
+func generateRateRecommendations(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "generateRateRecommendations"
+	var g RateRecommendationGenerateResponse
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	dt := rlib.DateAtTimeZero(time.Now())
+	g.Count, err = bizlogic.GenerateRateRecommendations(ctx, d.BID, &dt)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// approveRateRecommendation applies a market rate recommendation
+// wsdoc {
+//  @Title  Approve Rate Recommendation
+//	@URL /v1/raterec/:BUI/:RCMID
+//  @Method  POST
+//	@Synopsis Apply a market rate recommendation
+//  @Description  Sets the market rate of the rentable type from the start of
+//  @Description  the recommendation on.  AppliedRate overrides the
+//  @Description  recommended rate when it is not 0.  The rate applied is
+//  @Description  recorded in the recommendation.
+//	@Input RateRecommendationApprove
+//  @Response SvcStatusResponse
+// wsdoc }
+func approveRateRecommendation(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "approveRateRecommendation"
+	var foo RateRecommendationApprove
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	if err := checkRateRecommendationBiz(r, d); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = bizlogic.ApproveRateRecommendation(ctx, d.ID, foo.AppliedRate); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// rejectRateRecommendation rejects a market rate recommendation
+// wsdoc {
+//  @Title  Reject Rate Recommendation
+//	@URL /v1/raterec/:BUI/:RCMID
+//  @Method  POST
+//	@Synopsis Reject a market rate recommendation
+//  @Description  Marks the recommendation rejected.  The market rate of the
+//  @Description  rentable type is not changed.
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func rejectRateRecommendation(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "rejectRateRecommendation"
+
+	if err := checkRateRecommendationBiz(r, d); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err := bizlogic.RejectRateRecommendation(r.Context(), d.ID); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// checkRateRecommendationBiz returns an error unless rate recommendation
+// d.ID belongs to business d.BID
+//-----------------------------------------------------------------------------
+func checkRateRecommendationBiz(r *http.Request, d *ServiceData) error {
+	a, err := rlib.GetRateRecommendation(r.Context(), d.ID)
+	if err != nil {
+		return err
+	}
+	if a.RCMID == 0 || a.BID != d.BID {
+		return fmt.Errorf("rate recommendation %d not found", d.ID)
+	}
+	return nil
+}
@@ -0,0 +1,340 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// RenewalOfferGrid is one renewal offer
+type RenewalOfferGrid struct {
+	Recid        int64 `json:"recid"`
+	ROID         int64
+	RAID         int64
+	RID          int64
+	RentableName string
+	ASMID        int64
+	ARID         int64
+	CurrentRent  float64
+	MarketRent   float64
+	OfferRent    float64
+	TermStart    rlib.JSONDate
+	TermStop     rlib.JSONDate
+	OfferDt      rlib.JSONDate
+	ExpireDt     rlib.JSONDate
+	Status       int64
+	StatusName   string
+	NewRAID      int64
+	Comment      string
+}
+
+// RenewalOfferListResponse is the response to the list command
+type RenewalOfferListResponse struct {
+	Status  string             `json:"status"`
+	Total   int64              `json:"total"`
+	Records []RenewalOfferGrid `json:"records"`
+}
+
+// RenewalGenerateResponse is the response to the generate command
+type RenewalGenerateResponse struct {
+	Status string `json:"status"`
+	Count  int    `json:"count"` // number of offers made
+}
+
+// SvcHandlerRenewal handles the renewal offers of the rental agreements of
+// a business.  d.ID is the RAID of the expiring rental agreement for the
+// accept and decline commands.
+//
+// The server command can be:
+//      list     - the offers for the terms starting in searchDtStart - searchDtStop
+//      generate - make offers for the agreements that are about to expire
+//      accept   - accept the open offers of an agreement
+//      decline  - decline the open offers of an agreement
+//-----------------------------------------------------------------------------
+func SvcHandlerRenewal(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerRenewal"
+	var (
+		err error
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("Request: %s:  BID = %d,  RAID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get", "list":
+		listRenewalOffers(w, r, d)
+	case "generate":
+		generateRenewalOffers(w, r, d)
+	case "accept":
+		acceptRenewalOffers(w, r, d)
+	case "decline":
+		declineRenewalOffers(w, r, d)
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// listRenewalOffers lists the renewal offers of a business
+// wsdoc {
+//  @Title  List Renewal Offers
+//	@URL /v1/renewal/:BUI
+//  @Method  POST
+//	@Synopsis List the renewal offers
+//  @Description  Returns the renewal offers for the terms that start in
+//  @Description  searchDtStart - searchDtStop, with the current rent, the
+//  @Description  market rate and the offered rent of each rentable.
+//	@Input WebGridSearchRequest
+//  @Response RenewalOfferListResponse
+// wsdoc }
+func listRenewalOffers(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "listRenewalOffers"
+	var g RenewalOfferListResponse
+
+	m, err := rlib.GetRenewalOffersInRange(r.Context(), d.BID, &d.wsSearchReq.SearchDtStart, &d.wsSearchReq.SearchDtStop)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	names := map[int64]string{}
+	for i := 0; i < len(m); i++ {
+		if _, ok := names[m[i].RID]; !ok {
+			rnt, err := rlib.GetRentable(r.Context(), m[i].RID)
+			if err != nil {
+				SvcErrorReturn(w, err, funcname)
+				return
+			}
+			names[m[i].RID] = rnt.RentableName
+		}
+		var q RenewalOfferGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].ROID
+		q.RentableName = names[m[i].RID]
+		q.StatusName = rlib.RenewalOfferStatus[m[i].Status]
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// generateRenewalOffers makes the renewal offers of a business
+// wsdoc {
+//  @Title  Generate Renewal Offers
+//	@URL /v1/renewal/:BUI
+//  @Method  POST
+//	@Synopsis Make renewal offers for the expiring rental agreements
+//  @Description  Makes an offer for each rentable of the active rental
+//  @Description  agreements that stop within the renewal notice period of the
+//  @Description  business.  The offered rent is the market rate of the
+//  @Description  rentable kept within the renewal caps.  Agreements that
+//  @Description  already have offers for their next term are skipped, and
+//  @Description  open offers past their expiration date are marked expired.
+//	@Input WebGridSearchRequest
+//  @Response RenewalGenerateResponse
+// wsdoc }
+func generateRenewalOffers(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "generateRenewalOffers"
+	var g RenewalGenerateResponse
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	dt := rlib.DateAtTimeZero(time.Now())
+	g.Count, err = bizlogic.GenerateRenewalOffers(ctx, d.BID, &dt)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// acceptRenewalOffers accepts the open renewal offers of a rental agreement
+// wsdoc {
+//  @Title  Accept Renewal Offers
+//	@URL /v1/renewal/:BUI/:RAID
+//  @Method  POST
+//	@Synopsis Accept the renewal offers of a rental agreement
+//  @Description  Creates the amended rental agreement for the new term at the
+//  @Description  offered rents.  The agreement is converted to a flow, its
+//  @Description  dates are moved to the new term, and the flow is saved as an
+//  @Description  active rental agreement, which creates its assessments.  The
+//  @Description  RAID of the amended agreement is returned and recorded in
+//  @Description  the offers.
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func acceptRenewalOffers(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "acceptRenewalOffers"
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	nraid, err := RenewRentalAgreement(ctx, d, d.ID)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, nraid)
+}
+
+// declineRenewalOffers declines the open renewal offers of a rental
+// agreement
+// wsdoc {
+//  @Title  Decline Renewal Offers
+//	@URL /v1/renewal/:BUI/:RAID
+//  @Method  POST
+//	@Synopsis Decline the renewal offers of a rental agreement
+//  @Description  Marks the open offers of the rental agreement declined.  The
+//  @Description  agreement stops at the end of its current term.
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func declineRenewalOffers(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "declineRenewalOffers"
+
+	m, err := bizlogic.OpenRenewalOffers(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if m[0].BID != d.BID {
+		err = fmt.Errorf("rental agreement %d not found", d.ID)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = bizlogic.SetRenewalOffersStatus(r.Context(), m, rlib.RenewalOfferDeclined, 0); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// RenewRentalAgreement accepts the open renewal offers of rental agreement
+// raid.  The agreement is converted to a flow as it is for an amendment.
+// Its dates are moved to the new term and its recurring rent fees are set
+// to the offered rents, then the flow is made active and saved, which
+// creates the amended rental agreement and its assessments.
+//
+// INPUTS
+//     ctx  - db context for transactions
+//     d    - service data, its session is the user accepting the offers
+//     raid - the expiring rental agreement
+//
+// RETURNS
+//     RAID of the amended rental agreement
+//     any error encountered
+//-----------------------------------------------------------------------------
+func RenewRentalAgreement(ctx context.Context, d *ServiceData, raid int64) (int64, error) {
+	var nraid int64
+
+	m, err := bizlogic.OpenRenewalOffers(ctx, raid)
+	if err != nil {
+		return nraid, err
+	}
+	ra, err := rlib.GetRentalAgreement(ctx, raid)
+	if err != nil {
+		return nraid, err
+	}
+	if ra.RAID == 0 || ra.BID != d.BID {
+		return nraid, fmt.Errorf("rental agreement %d not found", raid)
+	}
+	if now := rlib.DateAtTimeZero(time.Now()); !m[0].ExpireDt.After(now) {
+		return nraid, fmt.Errorf("the renewal offers of rental agreement %d expired on %s", raid, m[0].ExpireDt.Format(rlib.RRDATEFMT3))
+	}
+
+	//--------------------------------------------------------------
+	// Flow2RA works on the flow of the agreement, there must not
+	// be an amendment of this agreement in progress
+	//--------------------------------------------------------------
+	flow, err := rlib.GetFlowForRAID(ctx, rlib.RAFlow, raid)
+	if err != nil {
+		return nraid, err
+	}
+	if flow.FlowID > 0 {
+		return nraid, fmt.Errorf("rental agreement %d is being amended in flow %s", raid, flow.UserRefNo)
+	}
+
+	raf, err := rlib.ConvertRA2Flow(ctx, &ra, true)
+	if err != nil {
+		return nraid, err
+	}
+	for i := 0; i < len(raf.Rentables); i++ {
+		for j := 0; j < len(raf.Rentables[i].Fees); j++ {
+			fee := &raf.Rentables[i].Fees[j]
+			if fee.RentCycle == rlib.RECURNONE {
+				continue
+			}
+			for k := 0; k < len(m); k++ {
+				if m[k].RID == raf.Rentables[i].RID && m[k].ARID == fee.ARID {
+					fee.ContractAmount = m[k].OfferRent
+				}
+			}
+		}
+	}
+
+	start, stop := m[0].TermStart, m[0].TermStop
+	raf.Dates.AgreementStart = rlib.JSONDate(start)
+	raf.Dates.AgreementStop = rlib.JSONDate(stop)
+	raf.Dates.RentStart = rlib.JSONDate(start)
+	raf.Dates.RentStop = rlib.JSONDate(stop)
+	raf.Dates.PossessionStart = rlib.JSONDate(start)
+	raf.Dates.PossessionStop = rlib.JSONDate(stop)
+	if err = rlib.RentDateChangeRAFlowUpdates(ctx, ra.BID, start, stop, &raf); err != nil {
+		return nraid, err
+	}
+
+	//--------------------------------------------------------------
+	// The renewal is approved, save the flow as an active
+	// rental agreement
+	//--------------------------------------------------------------
+	action := int64(rlib.RAActionCompleteMoveIn)
+	ActionResetMetaData(action, raf.Meta.RAFLAGS&uint64(0xF), &raf.Meta)
+	if err = SetActionMetaData(ctx, d, action, &raf.Meta); err != nil {
+		return nraid, err
+	}
+	data, err := json.Marshal(&raf)
+	if err != nil {
+		return nraid, err
+	}
+	a := rlib.Flow{
+		BID:       ra.BID,
+		FlowID:    0, // it's new flowID,
+		UserRefNo: rlib.GenerateUserRefNo(),
+		FlowType:  rlib.RAFlow,
+		ID:        ra.RAID,
+		Data:      data,
+		CreateBy:  d.sess.UID,
+		LastModBy: d.sess.UID,
+	}
+	flowID, err := rlib.InsertFlow(ctx, &a)
+	if err != nil {
+		return nraid, err
+	}
+	if nraid, err = Flow2RA(ctx, flowID); err != nil {
+		return nraid, err
+	}
+	return nraid, bizlogic.SetRenewalOffersStatus(ctx, m, rlib.RenewalOfferAccepted, nraid)
+}
@@ -0,0 +1,114 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// RentEscalationDays is the number of days previewed when the request does
+// not say
+const RentEscalationDays = 30
+
+// RentEscalationGrid is one scheduled rent escalation
+type RentEscalationGrid struct {
+	Recid        int64 `json:"recid"`
+	RAID         int64
+	ASMID        int64
+	RID          int64
+	RentableName string
+	ARID         int64
+	Dt           rlib.JSONDate
+	Amount       float64
+	NewAmount    float64
+	RateChange   float64
+	Fixed        bool // true if RateChange is an amount, false if a percentage
+}
+
+// RentEscalationRequest is the input data format for the rent escalation
+// preview
+type RentEscalationRequest struct {
+	Cmd  string `json:"cmd"`
+	Days int    // number of days to preview, RentEscalationDays if 0
+}
+
+// RentEscalationResponse is the response to the rent escalation preview
+type RentEscalationResponse struct {
+	Status  string               `json:"status"`
+	Total   int64                `json:"total"`
+	Records []RentEscalationGrid `json:"records"`
+}
+
+// SvcRentEscalations lists the rent escalations scheduled in the next N
+// days, including those that are due but not yet applied.
+// wsdoc {
+//  @Title  Upcoming Rent Escalations
+//	@URL /v1/rentescalations/:BUI
+//  @Method  POST
+//	@Synopsis Preview the scheduled rent escalations
+//  @Description  Lists each recurring rent assessment that will be escalated
+//  @Description  in the next Days days, with its current and escalated rent.
+//  @Description  Escalations come from the RateChange and NextRateChange of
+//  @Description  each rental agreement.
+//	@Input RentEscalationRequest
+//  @Response RentEscalationResponse
+// wsdoc }
+//-----------------------------------------------------------------------------
+func SvcRentEscalations(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcRentEscalations"
+	var (
+		foo RentEscalationRequest
+		g   RentEscalationResponse
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+
+	if len(d.data) > 0 {
+		if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+			e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+			SvcErrorReturn(w, e, funcname)
+			return
+		}
+	}
+	if foo.Days <= 0 {
+		foo.Days = RentEscalationDays
+	}
+	dt := rlib.DateAtTimeZero(time.Now()).AddDate(0, 0, foo.Days+1)
+
+	m, err := bizlogic.GetRentEscalations(r.Context(), d.BID, &dt)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	names := map[int64]string{}
+	for i := 0; i < len(m); i++ {
+		if _, ok := names[m[i].RID]; !ok {
+			rnt, err := rlib.GetRentable(r.Context(), m[i].RID)
+			if err != nil {
+				SvcErrorReturn(w, err, funcname)
+				return
+			}
+			names[m[i].RID] = rnt.RentableName
+		}
+		g.Records = append(g.Records, RentEscalationGrid{
+			Recid:        int64(i),
+			RAID:         m[i].RAID,
+			ASMID:        m[i].ASMID,
+			RID:          m[i].RID,
+			RentableName: names[m[i].RID],
+			ARID:         m[i].ARID,
+			Dt:           rlib.JSONDate(m[i].Dt),
+			Amount:       m[i].Amount,
+			NewAmount:    m[i].NewAmount,
+			RateChange:   m[i].RateChange,
+			Fixed:        m[i].Fixed,
+		})
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
@@ -222,39 +222,58 @@ func v1ReportHandler(ctx context.Context, reportname string, xbiz *rlib.XBusines
 	// handler for reports which has single table
 	var wsr = []rrpt.SingleTableReportHandler{
 		{ReportNames: []string{"RPTar", "account rules"}, TableHandler: rrpt.RRARTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+		{ReportNames: []string{"RPTapaging", "accounts payable aging"}, TableHandler: rrpt.APAgingTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
 		{ReportNames: []string{"RPTasmrpt", "assessments"}, TableHandler: rrpt.RRAssessmentsTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
 		{ReportNames: []string{"RPTb", "business"}, TableHandler: rrpt.RRreportBusinessTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+		{ReportNames: []string{"RPTbankrec", "bank reconciliation"}, TableHandler: rrpt.BankReconciliationTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+		{ReportNames: []string{"RPTbs", "balance sheet"}, TableHandler: rrpt.BalanceSheetTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+		{ReportNames: []string{"RPTbva", "budget variance"}, TableHandler: rrpt.BudgetVarianceTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+		{ReportNames: []string{"RPTcam", "cam reconciliation statement"}, TableHandler: rrpt.CAMStatementTable, PDFprops: rrpt.CAMStatementPDFProps, HTMLTemplate: "", NeedsCustomPDFDimension: false, NeedsPDFTitle: false},
 		{ReportNames: []string{"RPTc", "custom attributes"}, TableHandler: rrpt.RRreportCustomAttributesTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
 		{ReportNames: []string{"RPTcoa", "chart of accounts"}, TableHandler: rrpt.RRreportChartOfAccountsTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
 		{ReportNames: []string{"RPTcr", "custom attribute refs"}, TableHandler: rrpt.RRreportCustomAttributeRefsTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+		{ReportNames: []string{"RPTctb", "consolidated trial balance"}, TableHandler: rrpt.ConsolidatedTrialBalanceTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
 		{ReportNames: []string{"RPTdelinq", "delinquency"}, TableHandler: rrpt.DelinquencyReportTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
 		{ReportNames: []string{"RPTdep", "depositories"}, TableHandler: rrpt.RRreportDepositoryTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
 		{ReportNames: []string{"RPTdpm", "deposit methods"}, TableHandler: rrpt.RRreportDepositMethodsTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
 		{ReportNames: []string{"RPTgsr", "gsr"}, TableHandler: rrpt.GSRReportTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+		{ReportNames: []string{"RPTis", "income statement"}, TableHandler: rrpt.IncomeStatementTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
 		{ReportNames: []string{"RPTj", "journals"}, TableHandler: rrpt.JournalReportTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+		{ReportNames: []string{"RPTlaudit", "ledger audit"}, TableHandler: rrpt.LedgerAuditTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+		{ReportNames: []string{"RPTleaseexp", "lease expirations"}, TableHandler: rrpt.LeaseExpirationTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+		{ReportNames: []string{"RPTmtm", "month to month"}, TableHandler: rrpt.MonthToMonthTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
 		{ReportNames: []string{"RPTpayorstmt", "payor statements"}, TableHandler: rrpt.RRPayorStatement, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+		{ReportNames: []string{"RPTpdelinq", "portfolio delinquency"}, TableHandler: rrpt.PortfolioDelinquencyTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
 		{ReportNames: []string{"RPTpeople", "people"}, TableHandler: rrpt.RRreportPeopleTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
 		{ReportNames: []string{"RPTpmt", "payment types"}, TableHandler: rrpt.RRreportPaymentTypesTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+		{ReportNames: []string{"RPTpocc", "portfolio occupancy"}, TableHandler: rrpt.PortfolioOccupancyTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+		{ReportNames: []string{"RPTprr", "portfolio rentroll"}, TableHandler: rrpt.PortfolioRentRollTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
 		{ReportNames: []string{"RPTr", "rentables"}, TableHandler: rrpt.RRreportRentablesTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
 		{ReportNames: []string{"RPTra", "rental agreements"}, TableHandler: rrpt.RRreportRentalAgreementsTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
 		{ReportNames: []string{"RPTrastmt", "rental agreement statements"}, TableHandler: rrpt.RRRentalAgreementStatements, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
 		{ReportNames: []string{"RPTrat", "rental agreement templates"}, TableHandler: rrpt.RRreportRentalAgreementTemplatesTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+		{ReportNames: []string{"RPTraterec", "rate recommendations"}, TableHandler: rrpt.RateRecommendationTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
 		{ReportNames: []string{"RPTrcbt", "rentable type counts"}, TableHandler: rrpt.RentableCountByRentableTypeReportTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
 		{ReportNames: []string{"RPTrcpt", "receipt"}, TableHandler: rrpt.RRRcptOnlyReceiptTable, PDFprops: rrpt.ReceiptPDFProps, HTMLTemplate: "receipt.html", NeedsCustomPDFDimension: false, NeedsPDFTitle: false},
 		{ReportNames: []string{"RPTrcpthotel", ""}, TableHandler: rrpt.RRRcptHotelReceiptTable, PDFprops: rrpt.ReceiptPDFProps, HTMLTemplate: "rcpthotel.html", NeedsCustomPDFDimension: false, NeedsPDFTitle: false},
 		{ReportNames: []string{"RPTrcptlist", "receipts"}, TableHandler: rrpt.RRReceiptsTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
 		{ReportNames: []string{"RPTrr", "rentroll"}, TableHandler: rrpt.RRReportTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
 		{ReportNames: []string{"RPTrt", "rentable types"}, TableHandler: rrpt.RRreportRentableTypesTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+		{ReportNames: []string{"RPTsecdep", "security deposit disposition"}, TableHandler: rrpt.SecDepLetterTable, PDFprops: rrpt.SecDepLetterPDFProps, HTMLTemplate: "", NeedsCustomPDFDimension: false, NeedsPDFTitle: false},
 		{ReportNames: []string{"RPTsl", "string lists"}, TableHandler: rrpt.RRreportStringListsTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
 		{ReportNames: []string{"RPTt", "people"}, TableHandler: rrpt.RRreportPeopleTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+		{ReportNames: []string{"RPTtax", "tax liability"}, TableHandler: rrpt.TaxLiabilityTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
 		{ReportNames: []string{"RPTtb", "trial balance"}, TableHandler: rrpt.LedgerBalanceReportTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
 		{ReportNames: []string{"RPTtl", "task list"}, TableHandler: rrpt.TaskListReportTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+		{ReportNames: []string{"RPTv1099", "vendor 1099 totals"}, TableHandler: rrpt.Vendor1099Table, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+		{ReportNames: []string{"RPTwoaging", "work order aging"}, TableHandler: rrpt.WorkOrderAgingTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
 	}
 
 	// handler for reports which has more than one table
 	var wmr = []rrpt.MultiTableReportHandler{
 		{ReportTitle: "Ledger", ReportNames: []string{"RPTl", "ledger"}, TableHandler: rrpt.LedgerReportTable, PDFprops: nil, NeedsCustomPDFDimension: true},
 		{ReportTitle: "Ledger Activity", ReportNames: []string{"RPTla", "ledger activity"}, TableHandler: rrpt.LedgerActivityReportTable, PDFprops: nil, NeedsCustomPDFDimension: true},
+		{ReportTitle: "Night Audit", ReportNames: []string{"RPTnightaudit", "night audit"}, TableHandler: rrpt.NightAuditReportTable, PDFprops: nil, NeedsCustomPDFDimension: true},
 		{ReportTitle: "Report Statements", ReportNames: []string{"RPTstatements", "report statements"}, TableHandler: rrpt.RptStatementReportTable, PDFprops: nil, NeedsCustomPDFDimension: true},
 	}
 
@@ -0,0 +1,438 @@
+package ws
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"gotable"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"rentroll/rrpt"
+)
+
+// SecDepItemGrid is one line of a security deposit settlement, either an
+// unpaid assessment (ItemType 1) or an itemized damage (ItemType 0)
+type SecDepItemGrid struct {
+	Recid       int64 `json:"recid"`
+	SDIID       int64
+	ItemType    int64 // 0 = damage, 1 = unpaid assessment
+	ASMID       int64
+	RID         int64
+	Description string
+	Amount      float64
+}
+
+// SecDepSettlementForm is a security deposit settlement and its items
+type SecDepSettlementForm struct {
+	Recid          int64 `json:"recid"`
+	SDSID          int64
+	BID            int64
+	BUD            rlib.XJSONBud
+	RAID           int64
+	TCID           int64
+	Dt             rlib.JSONDate
+	DepositHeld    float64
+	Charges        float64
+	Damages        float64
+	DepositApplied float64
+	Refund         float64
+	BalanceDue     float64
+	RCPTID         int64
+	DMGEXPID       int64
+	REFEXPID       int64
+	BDASMID        int64
+	ForwardAddress string
+	FLAGS          uint64 // 1<<0 = posted
+	Comment        string
+	Items          []SecDepItemGrid
+}
+
+// SecDepSettlementResponse is the response to the security deposit
+// settlement requests
+type SecDepSettlementResponse struct {
+	Status string               `json:"status"`
+	Record SecDepSettlementForm `json:"record"`
+}
+
+// SecDepSettlementSave is the input data format for the preview and save
+// commands.  Only the damage items are used, the unpaid assessments are
+// always determined by the server.
+type SecDepSettlementSave struct {
+	Cmd    string               `json:"cmd"`
+	Record SecDepSettlementForm `json:"record"`
+}
+
+// SvcHandlerSecDepSettlement handles the security deposit settlement
+// requests for the settlement d.ID
+//
+// The server command can be:
+//      get      - read the settlement
+//      preview  - compute a settlement without saving it
+//      save     - insert or update a settlement that has not been posted
+//      post     - make the journal entries for the settlement
+//      delete   - delete a settlement that has not been posted
+//-----------------------------------------------------------------------------------
+func SvcHandlerSecDepSettlement(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerSecDepSettlement"
+	var (
+		err error
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("Request: %s:  BID = %d,  SDSID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		secDepSettlementResponse(w, r, d, d.ID)
+	case "preview":
+		previewSecDepSettlement(w, r, d)
+	case "save":
+		saveSecDepSettlement(w, r, d)
+	case "post":
+		postSecDepSettlement(w, r, d)
+	case "delete":
+		deleteSecDepSettlement(w, r, d)
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// secDepSettlementForm converts settlement s to its UI form
+func secDepSettlementForm(s *rlib.SecDepSettlement) SecDepSettlementForm {
+	var f SecDepSettlementForm
+	rlib.MigrateStructVals(s, &f)
+	f.Recid = s.SDSID
+	f.BUD = rlib.GetBUDFromBIDList(s.BID)
+	for i := 0; i < len(s.I); i++ {
+		f.Items = append(f.Items, SecDepItemGrid{
+			Recid:       int64(i),
+			SDIID:       s.I[i].SDIID,
+			ItemType:    s.I[i].ItemType,
+			ASMID:       s.I[i].ASMID,
+			RID:         s.I[i].RID,
+			Description: s.I[i].Description,
+			Amount:      s.I[i].Amount,
+		})
+	}
+	return f
+}
+
+// secDepSettlementFromRequest reads the settlement sent by the UI. Only the
+// damage items are kept.
+func secDepSettlementFromRequest(d *ServiceData) (rlib.SecDepSettlement, error) {
+	var foo SecDepSettlementSave
+	var s rlib.SecDepSettlement
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		return s, fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+	}
+	rlib.MigrateStructVals(&foo.Record, &s)
+	s.BID = d.BID
+	for i := 0; i < len(foo.Record.Items); i++ {
+		if foo.Record.Items[i].ItemType != rlib.SecDepItemDamage {
+			continue
+		}
+		s.I = append(s.I, rlib.SecDepItem{
+			BID:         d.BID,
+			ItemType:    rlib.SecDepItemDamage,
+			RID:         foo.Record.Items[i].RID,
+			Description: foo.Record.Items[i].Description,
+			Amount:      foo.Record.Items[i].Amount,
+		})
+	}
+	return s, nil
+}
+
+// secDepSettlementResponse writes settlement sdsid and its items as the
+// response
+// wsdoc {
+//  @Title  Get Security Deposit Settlement
+//	@URL /v1/secdep/:BUI/:SDSID
+//  @Method  POST
+//	@Synopsis Get a security deposit settlement
+//  @Description  Returns the settlement with its unpaid assessments and damages
+//	@Input WebGridSearchRequest
+//  @Response SecDepSettlementResponse
+// wsdoc }
+func secDepSettlementResponse(w http.ResponseWriter, r *http.Request, d *ServiceData, sdsid int64) {
+	const funcname = "secDepSettlementResponse"
+	var g SecDepSettlementResponse
+
+	s, err := rlib.GetSecDepSettlement(r.Context(), sdsid)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if s.SDSID == 0 || s.BID != d.BID {
+		err = fmt.Errorf("security deposit settlement %d not found", sdsid)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if s.I, err = rlib.GetSecDepItems(r.Context(), sdsid); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	g.Record = secDepSettlementForm(&s)
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// previewSecDepSettlement computes a settlement without saving it
+// wsdoc {
+//  @Title  Preview Security Deposit Settlement
+//	@URL /v1/secdep/:BUI
+//  @Method  POST
+//	@Synopsis Compute the settlement for a rental agreement and move-out date
+//  @Description  Send RAID, Dt and the damage items. The deposits held and
+//  @Description  the unpaid assessments are filled in and the deposits are
+//  @Description  applied.  Nothing is saved.
+//	@Input SecDepSettlementSave
+//  @Response SecDepSettlementResponse
+// wsdoc }
+func previewSecDepSettlement(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "previewSecDepSettlement"
+	var g SecDepSettlementResponse
+
+	rlib.Console("Entered %s\n", funcname)
+
+	s, err := secDepSettlementFromRequest(d)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if errlist := bizlogic.ValidateSecDepSettlement(r.Context(), &s); len(errlist) > 0 {
+		SvcErrorReturn(w, bizlogic.BizErrorListToError(errlist), funcname)
+		return
+	}
+	if err = bizlogic.InitSecDepSettlement(r.Context(), &s); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if s.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("rental agreement %d not found", s.RAID), funcname)
+		return
+	}
+
+	g.Record = secDepSettlementForm(&s)
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveSecDepSettlement saves a security deposit settlement
+// wsdoc {
+//  @Title  Save Security Deposit Settlement
+//	@URL /v1/secdep/:BUI/:SDSID
+//  @Method  POST
+//	@Synopsis Insert or update a security deposit settlement
+//  @Description  The settlement is computed as in preview and saved. Use
+//  @Description  SDSID 0 to add a new settlement. Posted settlements cannot
+//  @Description  be changed.
+//	@Input SecDepSettlementSave
+//  @Response SecDepSettlementResponse
+// wsdoc }
+func saveSecDepSettlement(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveSecDepSettlement"
+
+	rlib.Console("Entered %s\n", funcname)
+
+	s, err := secDepSettlementFromRequest(d)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	ra, err := rlib.GetRentalAgreement(r.Context(), s.RAID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if ra.RAID == 0 || ra.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("rental agreement %d not found", s.RAID), funcname)
+		return
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = bizlogic.SaveSecDepSettlement(ctx, &s); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	secDepSettlementResponse(w, r, d, s.SDSID)
+}
+
+// postSecDepSettlement posts a security deposit settlement
+// wsdoc {
+//  @Title  Post Security Deposit Settlement
+//	@URL /v1/secdep/:BUI/:SDSID
+//  @Method  POST
+//	@Synopsis Apply the deposits and refund the rest or assess the shortfall
+//  @Description  A receipt applies the deposits to the unpaid assessments, an
+//  @Description  expense applies them to the damages, and the rest is either
+//  @Description  refunded with an expense or the uncovered damages are
+//  @Description  assessed.  The letter is downloaded with /v1/secdepletter.
+//	@Input WebGridSearchRequest
+//  @Response SecDepSettlementResponse
+// wsdoc }
+func postSecDepSettlement(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "postSecDepSettlement"
+
+	rlib.Console("Entered %s\n", funcname)
+
+	s, err := rlib.GetSecDepSettlement(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if s.SDSID == 0 || s.BID != d.BID {
+		err = fmt.Errorf("security deposit settlement %d not found", d.ID)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if _, err = bizlogic.PostSecDepSettlement(ctx, d.ID); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	secDepSettlementResponse(w, r, d, d.ID)
+}
+
+// deleteSecDepSettlement deletes a security deposit settlement
+// wsdoc {
+//  @Title  Delete Security Deposit Settlement
+//	@URL /v1/secdep/:BUI/:SDSID
+//  @Method  POST
+//	@Synopsis Delete a security deposit settlement that has not been posted
+//  @Description  Posted settlements cannot be deleted.
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteSecDepSettlement(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "deleteSecDepSettlement"
+
+	rlib.Console("Entered %s\n", funcname)
+
+	s, err := rlib.GetSecDepSettlement(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if s.SDSID == 0 || s.BID != d.BID {
+		err = fmt.Errorf("security deposit settlement %d not found", d.ID)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if s.FLAGS&rlib.FlSecDepPosted != 0 {
+		err = fmt.Errorf("security deposit settlement %s has been posted, it cannot be deleted", s.IDtoString())
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = rlib.DeleteSecDepSettlement(ctx, d.ID); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// SvcSecDepLetter downloads the itemized disposition letter for security
+// deposit settlement d.ID as a PDF
+// wsdoc {
+//  @Title  Download Security Deposit Disposition Letter
+//	@URL /v1/secdepletter/:BUI/:SDSID
+//  @Method  GET
+//	@Synopsis Download the itemized security deposit disposition letter
+//  @Description  The letter lists the deposits held, each deduction and the
+//  @Description  refund or balance due. It is marked as a draft until the
+//  @Description  settlement is posted.
+//	@Input WebGridSearchRequest
+//  @Response application/pdf
+// wsdoc }
+func SvcSecDepLetter(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcSecDepLetter"
+	var (
+		buf  bytes.Buffer
+		xbiz rlib.XBusiness
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+
+	s, err := rlib.GetSecDepSettlement(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if s.SDSID == 0 || s.BID != d.BID {
+		err = fmt.Errorf("security deposit settlement %d not found", d.ID)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = rlib.GetXBusiness(r.Context(), d.BID, &xbiz); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	ri := rrpt.ReporterInfo{
+		OutputFormat: gotable.TABLEOUTPDF,
+		Bid:          d.BID,
+		Raid:         s.RAID,
+		ID:           s.SDSID,
+		Xbiz:         &xbiz,
+	}
+	tsh := rrpt.SingleTableReportHandler{
+		ReportNames:             []string{"RPTsecdep", "security deposit disposition"},
+		TableHandler:            rrpt.SecDepLetterTable,
+		PDFprops:                rrpt.SecDepLetterPDFProps,
+		HTMLTemplate:            "",
+		NeedsCustomPDFDimension: false,
+		NeedsPDFTitle:           false,
+	}
+	rctx := rrpt.ReportContext{
+		PDFPageSizeUnit: "in",
+		PDFPageWidth:    float64(8.5),
+		PDFPageHeight:   float64(11),
+	}
+	tbl := rrpt.SecDepLetterTable(r.Context(), &ri)
+	if err = rrpt.WritePDFReport(&buf, &tsh, &ri, &rctx, &tbl); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	fname := fmt.Sprintf("%s_SecDep_%s.pdf", rlib.GetBUDFromBIDList(d.BID), s.IDtoString())
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment;filename=%s", fname))
+	w.Write(buf.Bytes())
+}
@@ -3,9 +3,11 @@ package ws
 //
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"rentroll/rlib"
+	"time"
 )
 
 // StatementDetail is a structure to fill the statement detail grid
@@ -46,35 +48,61 @@ type StmtDetailResponse struct {
 func SvcStatementDetail(w http.ResponseWriter, r *http.Request, sd *ServiceData) {
 	const funcname = "SvcStatementDetails"
 	rlib.Console("Entered %s\n", funcname)
-	var g StmtDetailResponse
-	var xbiz rlib.XBusiness
 
-	bud, err := BIDToBUD(sd.BID)
+	d1 := sd.wsSearchReq.SearchDtStart
+	d2 := sd.wsSearchReq.SearchDtStop
+	g, err := getStatementDetail(r.Context(), sd.BID, sd.ID, &d1, &d2, sd.wsSearchReq.Offset, sd.wsSearchReq.Limit)
 	if err != nil {
 		SvcErrorReturn(w, err, funcname)
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(sd.BID, &g, w)
+
+}
+
+// getStatementDetail returns the statement of rental agreement raid for the
+// period d1 - d2: the opening balance, up to limit assessments and receipts
+// starting at offset, and the closing balance.  It is shared by the staff
+// statement detail and the tenant portal.
+//
+// INPUTS
+//  ctx    - db context
+//  bid    - business id
+//  raid   - rental agreement
+//  d1, d2 - the period
+//  offset - index of the first assessment or receipt to return
+//  limit  - max number of assessments and receipts to return
+//
+// RETURNS
+//  the statement detail
+//  any error encountered
+//-----------------------------------------------------------------------------
+func getStatementDetail(ctx context.Context, bid, raid int64, d1, d2 *time.Time, offset, limit int) (StmtDetailResponse, error) {
+	var g StmtDetailResponse
+	var xbiz rlib.XBusiness
+
+	bud, err := BIDToBUD(bid)
+	if err != nil {
+		return g, err
+	}
+
 	//
 	// UGH!
 	//=======================================================================
-	err = rlib.InitBizInternals(sd.BID, &xbiz)
+	err = rlib.InitBizInternals(bid, &xbiz)
 	if err != nil {
-		SvcErrorReturn(w, err, funcname)
-		return
+		return g, err
 	}
 
-	rlib.Console("sd.BID = %d\n", sd.BID)
-	_, ok := rlib.RRdb.BizTypes[sd.BID]
+	rlib.Console("bid = %d\n", bid)
+	_, ok := rlib.RRdb.BizTypes[bid]
 	if !ok {
-		e := fmt.Errorf("nothing exists in rlib.RRdb.BizTypes[%d]", sd.BID)
-		SvcErrorReturn(w, e, funcname)
-		return
+		return g, fmt.Errorf("nothing exists in rlib.RRdb.BizTypes[%d]", bid)
 	}
-	if len(rlib.RRdb.BizTypes[sd.BID].GLAccounts) == 0 {
-		e := fmt.Errorf("nothing exists in rlib.RRdb.BizTypes[%d].GLAccounts", sd.BID)
-		SvcErrorReturn(w, e, funcname)
-		return
+	if len(rlib.RRdb.BizTypes[bid].GLAccounts) == 0 {
+		return g, fmt.Errorf("nothing exists in rlib.RRdb.BizTypes[%d].GLAccounts", bid)
 	}
 	//=======================================================================
 	// UGH!
@@ -82,16 +110,12 @@ func SvcStatementDetail(w http.ResponseWriter, r *http.Request, sd *ServiceData)
 	//--------------------------------------------
 	// Get the statement data...
 	//--------------------------------------------
-	d1 := sd.wsSearchReq.SearchDtStart
-	d2 := sd.wsSearchReq.SearchDtStop
-	m, err := rlib.GetRAIDStatementInfo(r.Context(), sd.ID, &d1, &d2)
+	m, err := rlib.GetRAIDStatementInfo(ctx, raid, d1, d2)
 	if err != nil {
 		// e := fmt.Errorf("GetRAIDAccountBalance returned error: %s", err.Error())
-		// SvcErrorReturn(w, e, funcname)
 		g.Total = 0
 		g.Status = "success"
-		SvcWriteResponse(sd.BID, &g, w)
-		return
+		return g, nil
 	}
 
 	//--------------------------------------------
@@ -99,9 +123,9 @@ func SvcStatementDetail(w http.ResponseWriter, r *http.Request, sd *ServiceData)
 	//--------------------------------------------
 	var b, c, d float64
 	var a = StatementDetail{
-		BID:     sd.BID,
+		BID:     bid,
 		BUD:     rlib.XJSONBud(bud),
-		RAID:    sd.ID,
+		RAID:    raid,
 		Dt:      rlib.JSONDate(m.DtStart),
 		Descr:   "Opening Balance",
 		Balance: m.OpeningBal,
@@ -109,13 +133,12 @@ func SvcStatementDetail(w http.ResponseWriter, r *http.Request, sd *ServiceData)
 	g.Records = append(g.Records, a)
 	b = m.OpeningBal
 	count := 0
-	offset := sd.wsSearchReq.Offset
-	for i := sd.wsSearchReq.Offset; i < len(m.Stmt); i++ {
+	for i := offset; i < len(m.Stmt); i++ {
 
 		var a = StatementDetail{
-			BID:  sd.BID,
+			BID:  bid,
 			BUD:  rlib.XJSONBud(bud),
-			RAID: sd.ID,
+			RAID: raid,
 			Dt:   rlib.JSONDate(m.Stmt[i].Dt),
 		}
 
@@ -130,7 +153,7 @@ func SvcStatementDetail(w http.ResponseWriter, r *http.Request, sd *ServiceData)
 		descr := ""
 		if m.Stmt[i].T == 1 || m.Stmt[i].T == 2 {
 			if m.Stmt[i].A.ARID > 0 {
-				descr = rlib.RRdb.BizTypes[sd.BID].AR[m.Stmt[i].A.ARID].Name
+				descr = rlib.RRdb.BizTypes[bid].AR[m.Stmt[i].A.ARID].Name
 			} /* else {descr = rlib.RRdb.BizTypes[sd.BID].GLAccounts[m.Stmt[i].A.ATypeLID].Name }*/
 		}
 		switch m.Stmt[i].T {
@@ -161,7 +184,7 @@ func SvcStatementDetail(w http.ResponseWriter, r *http.Request, sd *ServiceData)
 				d += amt
 				b -= amt
 			} else {
-				rcpt, _ := rlib.GetReceipt(r.Context(), m.Stmt[i].R.RCPTID)
+				rcpt, _ := rlib.GetReceipt(ctx, m.Stmt[i].R.RCPTID)
 				comment := ""
 				if rcpt.RCPTID > 0 {
 					comment += rcpt.Comment
@@ -179,16 +202,16 @@ func SvcStatementDetail(w http.ResponseWriter, r *http.Request, sd *ServiceData)
 			a.Recid = int64(i)
 			g.Records = append(g.Records, a)
 			count++
-			if count >= sd.wsSearchReq.Limit { // terminate the loop if we've hit the max count
+			if count >= limit { // terminate the loop if we've hit the max count
 				break
 			}
 		}
 	}
 
 	a = StatementDetail{
-		BID:        sd.BID,
+		BID:        bid,
 		BUD:        rlib.XJSONBud(bud),
-		RAID:       sd.ID,
+		RAID:       raid,
 		Dt:         rlib.JSONDate(m.DtStop.AddDate(0, 0, -1)),
 		Descr:      "Closing Balance",
 		Balance:    m.ClosingBal,
@@ -198,8 +221,5 @@ func SvcStatementDetail(w http.ResponseWriter, r *http.Request, sd *ServiceData)
 	g.Records = append(g.Records, a)
 	g.Total = int64(len(m.Stmt) + 2)
 	g.Status = "success"
-
-	w.Header().Set("Content-Type", "application/json")
-	SvcWriteResponse(sd.BID, &g, w)
-
+	return g, nil
 }
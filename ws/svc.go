@@ -140,6 +140,7 @@ type ServiceData struct {
 	wsTypeDownReq WebTypeDownRequest   // fast for typedown
 	data          string               // the raw unparsed data
 	sess          *rlib.Session        // the caller's session
+	tsess         *rlib.TenantSession  // the tenant's session, tenant portal requests only
 	QueryParams   map[string][]string  // parameters when HTTP GET is used
 	Files         map[string][]*multipart.FileHeader
 	MFValues      map[string][]string
@@ -150,6 +151,10 @@ var Svcs = []ServiceHandler{
 	{Cmd: "account", Handler: SvcFormHandlerGLAccounts, NeedBiz: true, NeedSession: true},
 	{Cmd: "accountlist", Handler: SvcAccountsList, NeedBiz: true, NeedSession: true},
 	{Cmd: "accounts", Handler: SvcSearchHandlerGLAccounts, NeedBiz: true, NeedSession: true},
+	{Cmd: "acctmap", Handler: SvcHandlerAcctMap, NeedBiz: true, NeedSession: true},
+	{Cmd: "achbatch", Handler: SvcHandlerACHBatch, NeedBiz: true, NeedSession: true},
+	{Cmd: "achenroll", Handler: SvcHandlerACHEnrollment, NeedBiz: true, NeedSession: true},
+	{Cmd: "achfile", Handler: SvcACHFile, NeedBiz: true, NeedSession: true},
 	{Cmd: "allocfunds", Handler: SvcSearchHandlerAllocFunds, NeedBiz: true, NeedSession: true},
 	{Cmd: "ar", Handler: SvcFormHandlerAR, NeedBiz: true, NeedSession: true},
 	{Cmd: "ars", Handler: SvcSearchHandlerARs, NeedBiz: true, NeedSession: true},
@@ -158,9 +163,13 @@ var Svcs = []ServiceHandler{
 	{Cmd: "asms", Handler: SvcSearchHandlerAssessments, NeedBiz: true, NeedSession: true},
 	{Cmd: "authn", Handler: SvcAuthenticate, NeedBiz: false, NeedSession: false},
 	{Cmd: "available", Handler: SvcAvailable, NeedBiz: true, NeedSession: true},
+	{Cmd: "bankstmt", Handler: SvcHandlerBankStatement, NeedBiz: true, NeedSession: true},
+	{Cmd: "budget", Handler: SvcHandlerBudget, NeedBiz: true, NeedSession: true},
 	{Cmd: "buildtime", Handler: SvcHandlerBuildTime, NeedBiz: false, NeedSession: false},
 	{Cmd: "buildmachine", Handler: SvcHandlerBuildMachine, NeedBiz: false, NeedSession: false},
 	{Cmd: "business", Handler: SvcHandlerBusiness, NeedBiz: false, NeedSession: true},
+	{Cmd: "camrecon", Handler: SvcHandlerCAMReconciliation, NeedBiz: true, NeedSession: true},
+	{Cmd: "camstatement", Handler: SvcCAMStatement, NeedBiz: true, NeedSession: true},
 	{Cmd: "closeinfo", Handler: SvcGetCloseInfo, NeedBiz: true, NeedSession: true},
 	{Cmd: "closeperiod", Handler: SvcHandlerClosePeriod, NeedBiz: true, NeedSession: true},
 	{Cmd: "dep", Handler: SvcHandlerDepository, NeedBiz: true, NeedSession: true},
@@ -171,11 +180,19 @@ var Svcs = []ServiceHandler{
 	{Cmd: "encon", Handler: SvcEnableConsole, NeedBiz: false, NeedSession: true},
 	{Cmd: "expense", Handler: SvcHandlerExpense, NeedBiz: false, NeedSession: true},
 	{Cmd: "exportaccounts", Handler: SvcExportGLAccounts, NeedBiz: true, NeedSession: true},
+	{Cmd: "fiscalyear", Handler: SvcHandlerFiscalYearClose, NeedBiz: true, NeedSession: true},
 	{Cmd: "flow", Handler: SvcHandlerFlow, NeedBiz: true, NeedSession: true},
+	{Cmd: "folio", Handler: SvcHandlerFolio, NeedBiz: true, NeedSession: true},
 	{Cmd: "importaccounts", Handler: SvcImportGLAccounts, NeedBiz: true, NeedSession: true},
+	{Cmd: "importachreturns", Handler: SvcImportACHReturns, NeedBiz: true, NeedSession: true},
+	{Cmd: "importbankstmt", Handler: SvcImportBankStatement, NeedBiz: true, NeedSession: true},
+	{Cmd: "kpi", Handler: SvcHandlerKPI, NeedBiz: true, NeedSession: true},
 	{Cmd: "ledger", Handler: SvcLedgerHandler, NeedBiz: true, NeedSession: true},
+	{Cmd: "ledgeraudit", Handler: SvcHandlerLedgerAudit, NeedBiz: true, NeedSession: true},
 	{Cmd: "ledgers", Handler: SvcLedgerHandler, NeedBiz: true, NeedSession: true},
 	{Cmd: "logoff", Handler: SvcLogoff, NeedBiz: false, NeedSession: true},
+	{Cmd: "maintreq", Handler: SvcHandlerMaintenanceRequest, NeedBiz: true, NeedSession: true},
+	{Cmd: "nightaudit", Handler: SvcHandlerNightAudit, NeedBiz: true, NeedSession: true},
 	{Cmd: "parentaccounts", Handler: SvcParentAccountsList, NeedBiz: true, NeedSession: true},
 	{Cmd: "payorfund", Handler: SvcHandlerTotalUnallocFund, NeedBiz: true, NeedSession: true},
 	{Cmd: "payorstmt", Handler: SvcPayorStmtDispatch, NeedBiz: true, NeedSession: true},
@@ -190,8 +207,11 @@ var Svcs = []ServiceHandler{
 	{Cmd: "raflow-rentable", Handler: SvcRAFlowRentableHandler, NeedBiz: true, NeedSession: true},
 	{Cmd: "raflow-vehicles", Handler: SvcRAFlowVehiclesHandler, NeedBiz: true, NeedSession: true},
 	{Cmd: "rar", Handler: SvcRARentables, NeedBiz: true, NeedSession: true},
+	{Cmd: "ratequote", Handler: SvcHandlerRateQuote, NeedBiz: true, NeedSession: true},
+	{Cmd: "raterec", Handler: SvcHandlerRateRecommendation, NeedBiz: true, NeedSession: true},
 	{Cmd: "receipt", Handler: SvcFormHandlerReceipt, NeedBiz: true, NeedSession: true},
 	{Cmd: "receipts", Handler: SvcSearchHandlerReceipts, NeedBiz: true, NeedSession: true},
+	{Cmd: "renewal", Handler: SvcHandlerRenewal, NeedBiz: true, NeedSession: true},
 	{Cmd: "rentable", Handler: SvcFormHandlerRentable, NeedBiz: true, NeedSession: true},
 	{Cmd: "rentables", Handler: SvcSearchHandlerRentables, NeedBiz: true, NeedSession: true},
 	{Cmd: "rentableusestatus", Handler: SvcHandlerRentableUseStatus, NeedBiz: true, NeedSession: true},
@@ -199,6 +219,7 @@ var Svcs = []ServiceHandler{
 	{Cmd: "rentableleasestatus", Handler: SvcHandlerRentableLeaseStatus, NeedBiz: true, NeedSession: true}, //add by lina
 	{Cmd: "rentablestd", Handler: SvcRentableTypeDown, NeedBiz: true, NeedSession: true},
 	{Cmd: "rentabletyperef", Handler: SvcHandlerRentableTypeRef, NeedBiz: true, NeedSession: true},
+	{Cmd: "rentescalations", Handler: SvcRentEscalations, NeedBiz: true, NeedSession: true},
 	{Cmd: "rentalagrtd", Handler: SvcRentalAgreementTypeDown, NeedBiz: true, NeedSession: true},
 	{Cmd: "report", Handler: ReportServiceHandler, NeedBiz: true, NeedSession: true},
 	{Cmd: "reservation", Handler: SvcReservationDispatch, NeedBiz: true, NeedSession: true},
@@ -207,12 +228,16 @@ var Svcs = []ServiceHandler{
 	{Cmd: "rr", Handler: SvcRR, NeedBiz: true, NeedSession: true},
 	{Cmd: "rt", Handler: SvcHandlerRentableType, NeedBiz: true, NeedSession: true},
 	{Cmd: "rtlist", Handler: SvcRentableTypesTD, NeedBiz: true, NeedSession: true},
+	{Cmd: "secdep", Handler: SvcHandlerSecDepSettlement, NeedBiz: true, NeedSession: true},
+	{Cmd: "secdepletter", Handler: SvcSecDepLetter, NeedBiz: true, NeedSession: true},
 	{Cmd: "sessions", Handler: SvcHandlerSessions, NeedBiz: true, NeedSession: true},
 	{Cmd: "stmt", Handler: SvcStatement, NeedBiz: false, NeedSession: true},
 	{Cmd: "stmtdetail", Handler: SvcStatementDetail, NeedBiz: true, NeedSession: true},
 	{Cmd: "stmtinfo", Handler: SvcGetStatementInfo, NeedBiz: true, NeedSession: true},
 	{Cmd: "task", Handler: SvcHandlerTask, NeedBiz: true, NeedSession: true},
+	{Cmd: "tax", Handler: SvcHandlerTax, NeedBiz: true, NeedSession: true},
 	{Cmd: "tasks", Handler: SvcSearchTaskHandler, NeedBiz: true, NeedSession: true},
+	{Cmd: "tenantlogin", Handler: SvcHandlerTenantLogin, NeedBiz: true, NeedSession: true},
 	{Cmd: "td", Handler: SvcHandlerTaskDescriptor, NeedBiz: true, NeedSession: true},
 	{Cmd: "tds", Handler: SvcSearchTDHandler, NeedBiz: true, NeedSession: true},
 	{Cmd: "tl", Handler: SvcHandlerTaskList, NeedBiz: true, NeedSession: true},
@@ -228,7 +253,12 @@ var Svcs = []ServiceHandler{
 	{Cmd: "unpaidasms", Handler: SvcHandlerGetUnpaidAsms, NeedBiz: true, NeedSession: true},
 	{Cmd: "userprofile", Handler: SvcUserProfile, NeedBiz: false, NeedSession: true},
 	{Cmd: "validate-raflow", Handler: SvcValidateRAFlow, NeedBiz: true, NeedSession: true},
+	{Cmd: "vendor", Handler: SvcHandlerVendor, NeedBiz: true, NeedSession: true},
+	{Cmd: "vendorbill", Handler: SvcHandlerVendorBill, NeedBiz: true, NeedSession: true},
+	{Cmd: "vendorpmt", Handler: SvcHandlerVendorPaymentRun, NeedBiz: true, NeedSession: true},
 	{Cmd: "version", Handler: SvcHandlerVersion, NeedBiz: false, NeedSession: false},
+	{Cmd: "workorder", Handler: SvcHandlerWorkOrder, NeedBiz: true, NeedSession: true},
+	{Cmd: "workorders", Handler: SvcSearchHandlerWorkOrders, NeedBiz: true, NeedSession: true},
 }
 
 // SvcCtx contains information global to the Svc handlers
@@ -0,0 +1,499 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"strings"
+	"time"
+)
+
+// TaxGrid is a tax with its rates and the rentable types it applies to
+type TaxGrid struct {
+	Recid                  int64 `json:"recid"`
+	TAXID                  int64
+	Name                   string
+	TaxingAuthority        string // the jurisdiction
+	TaxingAuthorityAddress string
+	FilingDate             rlib.JSONDate
+	FilingCycle            int64
+	Instructions           string
+	ARID                   int64 // account rule of the tax assessments
+	ExemptDays             int64 // stays longer than this are exempt, 0 = never
+	Rates                  []TaxRateGrid
+	RentableTypes          []TaxRentableTypeGrid
+}
+
+// TaxRateGrid is a rate of a tax
+type TaxRateGrid struct {
+	TRID    int64
+	DtStart rlib.JSONDate
+	DtStop  rlib.JSONDate
+	Rate    float64 // percent
+	Fee     float64 // per charge
+}
+
+// TaxRentableTypeGrid is a rentable type a tax applies to
+type TaxRentableTypeGrid struct {
+	RTTAXID          int64
+	RTID             int64
+	RentableTypeName string
+	DtStart          rlib.JSONDate
+	DtStop           rlib.JSONDate
+}
+
+// TaxListResponse is the response to the list command
+type TaxListResponse struct {
+	Status  string    `json:"status"`
+	Total   int64     `json:"total"`
+	Records []TaxGrid `json:"records"`
+}
+
+// TaxSave is the input data of the save command
+type TaxSave struct {
+	Cmd                    string `json:"cmd"`
+	Name                   string
+	TaxingAuthority        string
+	TaxingAuthorityAddress string
+	FilingDate             rlib.JSONDate
+	FilingCycle            int64
+	Instructions           string
+	ARID                   int64
+	ExemptDays             int64
+}
+
+// TaxRateSave is the input data of the saverate command
+type TaxRateSave struct {
+	Cmd     string `json:"cmd"`
+	TRID    int64  // 0 for a new rate
+	DtStart rlib.JSONDate
+	DtStop  rlib.JSONDate
+	Rate    float64
+	Fee     float64
+}
+
+// TaxBindRentableType is the input data of the bindrt command
+type TaxBindRentableType struct {
+	Cmd     string `json:"cmd"`
+	RTTAXID int64  // 0 for a new binding
+	RTID    int64
+	DtStart rlib.JSONDate
+	DtStop  rlib.JSONDate
+}
+
+// TaxExemptRentalAgreement is the input data of the exemptra command
+type TaxExemptRentalAgreement struct {
+	Cmd     string `json:"cmd"`
+	RAID    int64
+	DtStart rlib.JSONDate
+	DtStop  rlib.JSONDate
+}
+
+// TaxAssessResponse is the response to the assess command
+type TaxAssessResponse struct {
+	Status string `json:"status"`
+	Count  int    `json:"count"` // number of charges taxed
+}
+
+// SvcHandlerTax handles the taxes of a business.  d.ID is the TAXID of the
+// tax for the save, saverate and bindrt commands.
+//
+// The server command can be:
+//      get, list - the taxes with their rates and rentable types
+//      save      - create or update a tax
+//      saverate  - create or update a rate of a tax
+//      bindrt    - apply a tax to a rentable type
+//      exemptra  - exempt a rental agreement from taxes
+//      assess    - tax the rent charges in searchDtStart - searchDtStop
+//-----------------------------------------------------------------------------
+func SvcHandlerTax(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerTax"
+	var (
+		err error
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("Request: %s:  BID = %d,  TAXID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get", "list":
+		listTaxes(w, r, d)
+	case "save":
+		saveTax(w, r, d)
+	case "saverate":
+		saveTaxRate(w, r, d)
+	case "bindrt":
+		bindTaxRentableType(w, r, d)
+	case "exemptra":
+		exemptTaxRentalAgreement(w, r, d)
+	case "assess":
+		assessTaxes(w, r, d)
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// listTaxes lists the taxes of a business
+// wsdoc {
+//  @Title  List Taxes
+//	@URL /v1/tax/:BUI
+//  @Method  POST
+//	@Synopsis List the taxes
+//  @Description  Returns the taxes of the business, each with its rates and
+//  @Description  the rentable types it applies to.
+//	@Input WebGridSearchRequest
+//  @Response TaxListResponse
+// wsdoc }
+func listTaxes(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "listTaxes"
+	var g TaxListResponse
+
+	m, err := rlib.GetTaxesByBusiness(r.Context(), d.BID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	b, err := rlib.GetRentableTypeTaxesByBusiness(r.Context(), d.BID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	rtm, err := rlib.GetBusinessRentableTypes(r.Context(), d.BID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	for i := 0; i < len(m); i++ {
+		var q TaxGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].TAXID
+		rates, err := rlib.GetTaxRates(r.Context(), m[i].TAXID)
+		if err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		for j := 0; j < len(rates); j++ {
+			var t TaxRateGrid
+			rlib.MigrateStructVals(&rates[j], &t)
+			q.Rates = append(q.Rates, t)
+		}
+		for j := 0; j < len(b); j++ {
+			if b[j].TAXID != m[i].TAXID {
+				continue
+			}
+			var t TaxRentableTypeGrid
+			rlib.MigrateStructVals(&b[j], &t)
+			t.RentableTypeName = rtm[b[j].RTID].Name
+			q.RentableTypes = append(q.RentableTypes, t)
+		}
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveTax creates or updates a tax
+// wsdoc {
+//  @Title  Save Tax
+//	@URL /v1/tax/:BUI/:TAXID
+//  @Method  POST
+//	@Synopsis Create or update a tax
+//  @Description  Creates a tax if TAXID is 0, otherwise updates it.  The tax
+//  @Description  assessments are posted to account rule ARID.  Stays longer
+//  @Description  than ExemptDays days are exempt; 0 means no exemption.
+//	@Input TaxSave
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveTax(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveTax"
+	var foo TaxSave
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	var a rlib.Tax
+	if d.ID > 0 {
+		var err error
+		if a, err = getBizTax(r, d); err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+	}
+	a.BID = d.BID
+	a.Name = strings.TrimSpace(foo.Name)
+	a.TaxingAuthority = strings.TrimSpace(foo.TaxingAuthority)
+	a.TaxingAuthorityAddress = strings.TrimSpace(foo.TaxingAuthorityAddress)
+	a.FilingDate = time.Time(foo.FilingDate)
+	a.FilingCycle = foo.FilingCycle
+	a.Instructions = foo.Instructions
+	a.ARID = foo.ARID
+	a.ExemptDays = foo.ExemptDays
+	if len(a.Name) == 0 {
+		SvcErrorReturn(w, fmt.Errorf("the tax must have a name"), funcname)
+		return
+	}
+	if a.ExemptDays < 0 {
+		SvcErrorReturn(w, fmt.Errorf("the exempt days cannot be negative"), funcname)
+		return
+	}
+	ar, err := rlib.GetAR(r.Context(), a.ARID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if ar.ARID == 0 || ar.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("account rule %d not found", a.ARID), funcname)
+		return
+	}
+
+	if a.TAXID == 0 {
+		_, err = rlib.InsertTax(r.Context(), &a)
+	} else {
+		err = rlib.UpdateTax(r.Context(), &a)
+	}
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, a.TAXID)
+}
+
+// saveTaxRate creates or updates a rate of a tax
+// wsdoc {
+//  @Title  Save Tax Rate
+//	@URL /v1/tax/:BUI/:TAXID
+//  @Method  POST
+//	@Synopsis Create or update a rate of a tax
+//  @Description  Creates a rate of the tax if TRID is 0, otherwise updates
+//  @Description  it.  The tax on a charge is Rate percent of the charge plus
+//  @Description  Fee.  The rates of a tax cannot overlap.
+//	@Input TaxRateSave
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveTaxRate(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveTaxRate"
+	var foo TaxRateSave
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	tax, err := getBizTax(r, d)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	a := rlib.TaxRate{
+		TRID:    foo.TRID,
+		TAXID:   tax.TAXID,
+		BID:     d.BID,
+		DtStart: time.Time(foo.DtStart),
+		DtStop:  time.Time(foo.DtStop),
+		Rate:    foo.Rate,
+		Fee:     foo.Fee,
+	}
+	if !a.DtStart.Before(a.DtStop) {
+		SvcErrorReturn(w, fmt.Errorf("the rate must stop after it starts"), funcname)
+		return
+	}
+	if a.Rate < 0 || a.Fee < 0 {
+		SvcErrorReturn(w, fmt.Errorf("the rate and fee cannot be negative"), funcname)
+		return
+	}
+	m, err := rlib.GetTaxRatesInRange(r.Context(), tax.TAXID, &a.DtStart, &a.DtStop)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	for i := 0; i < len(m); i++ {
+		if m[i].TRID != a.TRID {
+			SvcErrorReturn(w, fmt.Errorf("the rate overlaps the rate from %s to %s", m[i].DtStart.Format(rlib.RRDATEFMT4), m[i].DtStop.Format(rlib.RRDATEFMT4)), funcname)
+			return
+		}
+	}
+
+	if a.TRID == 0 {
+		_, err = rlib.InsertTaxRate(r.Context(), &a)
+	} else {
+		var old rlib.TaxRate
+		if old, err = rlib.GetTaxRate(r.Context(), a.TRID); err == nil && old.TAXID != tax.TAXID {
+			err = fmt.Errorf("tax rate %d not found", a.TRID)
+		}
+		if err == nil {
+			err = rlib.UpdateTaxRate(r.Context(), &a)
+		}
+	}
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, a.TRID)
+}
+
+// bindTaxRentableType applies a tax to a rentable type
+// wsdoc {
+//  @Title  Bind Tax To Rentable Type
+//	@URL /v1/tax/:BUI/:TAXID
+//  @Method  POST
+//	@Synopsis Apply a tax to a rentable type
+//  @Description  The rent charges of the rentables of type RTID are taxed
+//  @Description  by the tax from DtStart to DtStop.  Creates the binding if
+//  @Description  RTTAXID is 0, otherwise updates it.
+//	@Input TaxBindRentableType
+//  @Response SvcStatusResponse
+// wsdoc }
+func bindTaxRentableType(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "bindTaxRentableType"
+	var foo TaxBindRentableType
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	tax, err := getBizTax(r, d)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	var rt rlib.RentableType
+	if err = rlib.GetRentableType(r.Context(), foo.RTID, &rt); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if rt.RTID == 0 || rt.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("rentable type %d not found", foo.RTID), funcname)
+		return
+	}
+	a := rlib.RentableTypeTax{
+		RTTAXID: foo.RTTAXID,
+		RTID:    rt.RTID,
+		BID:     d.BID,
+		TAXID:   tax.TAXID,
+		DtStart: time.Time(foo.DtStart),
+		DtStop:  time.Time(foo.DtStop),
+	}
+	if !a.DtStart.Before(a.DtStop) {
+		SvcErrorReturn(w, fmt.Errorf("the binding must stop after it starts"), funcname)
+		return
+	}
+	if a.RTTAXID == 0 {
+		_, err = rlib.InsertRentableTypeTax(r.Context(), &a)
+	} else {
+		var old rlib.RentableTypeTax
+		if old, err = rlib.GetRentableTypeTax(r.Context(), a.RTTAXID); err == nil && old.BID != d.BID {
+			err = fmt.Errorf("rentable type tax %d not found", a.RTTAXID)
+		}
+		if err == nil {
+			err = rlib.UpdateRentableTypeTax(r.Context(), &a)
+		}
+	}
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, a.RTTAXID)
+}
+
+// exemptTaxRentalAgreement exempts a rental agreement from taxes
+// wsdoc {
+//  @Title  Exempt Rental Agreement From Taxes
+//	@URL /v1/tax/:BUI
+//  @Method  POST
+//	@Synopsis Exempt a rental agreement from taxes
+//  @Description  The charges of rental agreement RAID from DtStart to DtStop
+//  @Description  are not taxed.  Their tax records are flagged do not apply.
+//	@Input TaxExemptRentalAgreement
+//  @Response SvcStatusResponse
+// wsdoc }
+func exemptTaxRentalAgreement(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "exemptTaxRentalAgreement"
+	var foo TaxExemptRentalAgreement
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	ra, err := rlib.GetRentalAgreement(r.Context(), foo.RAID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if ra.RAID == 0 || ra.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("rental agreement %d not found", foo.RAID), funcname)
+		return
+	}
+	a := rlib.RentalAgreementTax{
+		RAID:    ra.RAID,
+		BID:     d.BID,
+		DtStart: time.Time(foo.DtStart),
+		DtStop:  time.Time(foo.DtStop),
+	}
+	if !a.DtStart.Before(a.DtStop) {
+		SvcErrorReturn(w, fmt.Errorf("the exemption must stop after it starts"), funcname)
+		return
+	}
+	if _, err = rlib.InsertRentalAgreementTax(r.Context(), &a); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, a.RATAXID)
+}
+
+// assessTaxes taxes the rent charges of a business
+// wsdoc {
+//  @Title  Assess Taxes
+//	@URL /v1/tax/:BUI
+//  @Method  POST
+//	@Synopsis Tax the rent charges
+//  @Description  Taxes the rent charges that start in searchDtStart -
+//  @Description  searchDtStop.  Charges already taxed are skipped.
+//	@Input WebGridSearchRequest
+//  @Response TaxAssessResponse
+// wsdoc }
+func assessTaxes(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "assessTaxes"
+	var g TaxAssessResponse
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Count, err = bizlogic.AssessTaxes(ctx, d.BID, &d.wsSearchReq.SearchDtStart, &d.wsSearchReq.SearchDtStop)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// getBizTax returns tax d.ID, or an error unless it belongs to business
+// d.BID
+//-----------------------------------------------------------------------------
+func getBizTax(r *http.Request, d *ServiceData) (rlib.Tax, error) {
+	a, err := rlib.GetTax(r.Context(), d.ID)
+	if err != nil {
+		return a, err
+	}
+	if a.TAXID == 0 || a.BID != d.BID {
+		return a, fmt.Errorf("tax %d not found", d.ID)
+	}
+	return a, nil
+}
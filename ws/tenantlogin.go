@@ -0,0 +1,236 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+)
+
+// TenantLoginGrid is a tenant portal login.  The password hash is never
+// sent to the client.
+type TenantLoginGrid struct {
+	Recid        int64 `json:"recid"`
+	TLID         int64
+	BID          int64
+	BUD          rlib.XJSONBud
+	TCID         int64
+	Tenant       string // name of the tenant
+	Username     string
+	FailedLogins int64             // consecutive failed logins
+	Locked       bool              // too many failed logins, set a new password to unlock
+	LastLogin    rlib.JSONDateTime // when the tenant last logged in
+	FLAGS        uint64            // 1<<0 = disabled
+}
+
+// TenantLoginSaveForm is the data sent to save a tenant login
+type TenantLoginSaveForm struct {
+	Recid    int64 `json:"recid"`
+	TLID     int64
+	TCID     int64
+	Username string
+	Password string // new password, "" to keep the current one
+	FLAGS    uint64
+}
+
+// TenantLoginSave is the input data format to save a tenant login
+type TenantLoginSave struct {
+	Cmd    string              `json:"cmd"`
+	Record TenantLoginSaveForm `json:"record"`
+}
+
+// TenantLoginResponse is the response to the get command
+type TenantLoginResponse struct {
+	Status string          `json:"status"`
+	Record TenantLoginGrid `json:"record"`
+}
+
+// TenantLoginListResponse is the response to the list command
+type TenantLoginListResponse struct {
+	Status  string            `json:"status"`
+	Total   int64             `json:"total"`
+	Records []TenantLoginGrid `json:"records"`
+}
+
+// SvcHandlerTenantLogin handles the tenant portal logins of a business
+// for the login d.ID
+//
+// The server command can be:
+//      get     - read the login
+//      list    - all the logins of the business
+//      save    - add or update a login, set its password
+//      delete  - remove a login
+//-----------------------------------------------------------------------------
+func SvcHandlerTenantLogin(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerTenantLogin"
+	var (
+		err error
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("Request: %s:  BID = %d,  TLID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getTenantLogin(w, r, d)
+	case "list":
+		listTenantLogins(w, r, d)
+	case "save":
+		saveTenantLogin(w, r, d)
+	case "delete":
+		deleteTenantLogin(w, r, d)
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// tenantLoginGrid converts login tl to its grid form
+func tenantLoginGrid(ctx context.Context, tl *rlib.TenantLogin) TenantLoginGrid {
+	var g TenantLoginGrid
+	var t rlib.Transactant
+	rlib.MigrateStructVals(tl, &g)
+	g.Recid = tl.TLID
+	g.BUD = rlib.GetBUDFromBIDList(tl.BID)
+	g.Locked = tl.FailedLogins >= rlib.TenantMaxFailedLogins
+	if err := rlib.GetTransactant(ctx, tl.TCID, &t); err == nil {
+		g.Tenant = t.GetFullTransactantName()
+	}
+	return g
+}
+
+// getTenantLogin reads a tenant login
+// wsdoc {
+//  @Title  Get Tenant Login
+//	@URL /v1/tenantlogin/:BUI/:TLID
+//  @Method  POST
+//	@Synopsis Get a tenant portal login
+//  @Description  Returns the login, without its password
+//	@Input WebGridSearchRequest
+//  @Response TenantLoginResponse
+// wsdoc }
+func getTenantLogin(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getTenantLogin"
+	var g TenantLoginResponse
+
+	tl, err := rlib.GetTenantLogin(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if tl.TLID == 0 || tl.BID != d.BID {
+		err = fmt.Errorf("tenant login %d not found", d.ID)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Record = tenantLoginGrid(r.Context(), &tl)
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// listTenantLogins lists the tenant logins of a business
+// wsdoc {
+//  @Title  List Tenant Logins
+//	@URL /v1/tenantlogin/:BUI
+//  @Method  POST
+//	@Synopsis List the tenant portal logins of a business
+//  @Description  Returns every login, ordered by username
+//	@Input WebGridSearchRequest
+//  @Response TenantLoginListResponse
+// wsdoc }
+func listTenantLogins(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "listTenantLogins"
+	var g TenantLoginListResponse
+
+	m, err := rlib.GetTenantLogins(r.Context(), d.BID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	for i := 0; i < len(m); i++ {
+		g.Records = append(g.Records, tenantLoginGrid(r.Context(), &m[i]))
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveTenantLogin adds or updates a tenant login
+// wsdoc {
+//  @Title  Save Tenant Login
+//	@URL /v1/tenantlogin/:BUI/:TLID
+//  @Method  POST
+//	@Synopsis Add or update a tenant portal login
+//  @Description  TLID 0 adds a new login, which needs a password.  Setting
+//  @Description  a new password unlocks a login locked by failed logins.
+//	@Input TenantLoginSave
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveTenantLogin(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveTenantLogin"
+	var foo TenantLoginSave
+
+	// do not print d.data, it can have the tenant's password
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	tl := rlib.TenantLogin{
+		TLID:     foo.Record.TLID,
+		BID:      d.BID,
+		TCID:     foo.Record.TCID,
+		Username: foo.Record.Username,
+		FLAGS:    foo.Record.FLAGS,
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if errlist := bizlogic.SaveTenantLogin(ctx, &tl, foo.Record.Password); len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, tl.TLID)
+}
+
+// deleteTenantLogin removes a tenant login
+// wsdoc {
+//  @Title  Delete Tenant Login
+//	@URL /v1/tenantlogin/:BUI/:TLID
+//  @Method  POST
+//	@Synopsis Delete a tenant portal login
+//  @Description  The tenant can no longer log in to the portal
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteTenantLogin(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "deleteTenantLogin"
+
+	tl, err := rlib.GetTenantLogin(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if tl.TLID == 0 || tl.BID != d.BID {
+		err = fmt.Errorf("tenant login %d not found", d.ID)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = rlib.DeleteTenantLogin(r.Context(), tl.TLID); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
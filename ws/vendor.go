@@ -0,0 +1,230 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+)
+
+// VendorGrid is a vendor as listed and edited in the vendor grid and form
+type VendorGrid struct {
+	Recid       int64 `json:"recid"`
+	VID         int64
+	BID         int64
+	BUD         rlib.XJSONBud
+	Name        string
+	ContactName string
+	Email       string
+	Phone       string
+	Address     string
+	Address2    string
+	City        string
+	State       string
+	PostalCode  string
+	Country     string
+	TaxID       string
+	Terms       int64  // days from the bill date to its due date
+	FLAGS       uint64 // 1<<0 = 1099 eligible, 1<<1 = inactive
+	Comment     string
+}
+
+// VendorResponse is the response to the get command
+type VendorResponse struct {
+	Status string     `json:"status"`
+	Record VendorGrid `json:"record"`
+}
+
+// VendorListResponse is the response to the list command
+type VendorListResponse struct {
+	Status  string       `json:"status"`
+	Total   int64        `json:"total"`
+	Records []VendorGrid `json:"records"`
+}
+
+// VendorSave is the input data format for the save command
+type VendorSave struct {
+	Cmd    string     `json:"cmd"`
+	Record VendorGrid `json:"record"`
+}
+
+// SvcHandlerVendor handles the vendors of a business for the vendor d.ID
+//
+// The server command can be:
+//      get     - read the vendor
+//      list    - all the vendors of the business
+//      save    - add or update a vendor
+//      delete  - remove a vendor that has no bills
+//-----------------------------------------------------------------------------
+func SvcHandlerVendor(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerVendor"
+	var (
+		err error
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("Request: %s:  BID = %d,  VID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getVendor(w, r, d)
+	case "list":
+		listVendors(w, r, d)
+	case "save":
+		saveVendor(w, r, d)
+	case "delete":
+		deleteVendor(w, r, d)
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getVendor reads a vendor
+// wsdoc {
+//  @Title  Get Vendor
+//	@URL /v1/vendor/:BUI/:VID
+//  @Method  POST
+//	@Synopsis Get a vendor
+//  @Description  Returns the vendor with the supplied VID
+//	@Input WebGridSearchRequest
+//  @Response VendorResponse
+// wsdoc }
+func getVendor(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getVendor"
+	var g VendorResponse
+
+	v, err := rlib.GetVendor(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if v.VID == 0 || v.BID != d.BID {
+		err = fmt.Errorf("vendor %d not found", d.ID)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	rlib.MigrateStructVals(&v, &g.Record)
+	g.Record.Recid = v.VID
+	g.Record.BUD = rlib.GetBUDFromBIDList(v.BID)
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// listVendors lists the vendors of a business
+// wsdoc {
+//  @Title  List Vendors
+//	@URL /v1/vendor/:BUI
+//  @Method  POST
+//	@Synopsis List the vendors of a business
+//  @Description  Returns every vendor, ordered by name
+//	@Input WebGridSearchRequest
+//  @Response VendorListResponse
+// wsdoc }
+func listVendors(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "listVendors"
+	var g VendorListResponse
+
+	m, err := rlib.GetVendorsByBusiness(r.Context(), d.BID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	for i := 0; i < len(m); i++ {
+		var q VendorGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].VID
+		q.BUD = rlib.GetBUDFromBIDList(m[i].BID)
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveVendor adds or updates a vendor
+// wsdoc {
+//  @Title  Save Vendor
+//	@URL /v1/vendor/:BUI/:VID
+//  @Method  POST
+//	@Synopsis Add or update a vendor
+//  @Description  VID 0 adds a new vendor.  A 1099 vendor needs a TaxID.
+//	@Input VendorSave
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveVendor(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveVendor"
+	var foo VendorSave
+
+	rlib.Console("Entered %s\n", funcname)
+
+	// do not print d.data, it has the vendor's tax id
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	var v rlib.Vendor
+	rlib.MigrateStructVals(&foo.Record, &v)
+	v.BID = d.BID
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if errlist := bizlogic.SaveVendor(ctx, &v); len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, v.VID)
+}
+
+// deleteVendor removes a vendor
+// wsdoc {
+//  @Title  Delete Vendor
+//	@URL /v1/vendor/:BUI/:VID
+//  @Method  POST
+//	@Synopsis Delete a vendor
+//  @Description  Only a vendor without bills can be deleted, mark it
+//  @Description  inactive otherwise.
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteVendor(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "deleteVendor"
+
+	v, err := rlib.GetVendor(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if v.VID == 0 || v.BID != d.BID {
+		err = fmt.Errorf("vendor %d not found", d.ID)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	m, err := rlib.GetVendorBillsByVID(r.Context(), v.VID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if len(m) > 0 {
+		err = fmt.Errorf("vendor %s has %d bills, it cannot be deleted", v.Name, len(m))
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = rlib.DeleteVendor(r.Context(), v.VID); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
@@ -0,0 +1,314 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// VendorBillLineGrid is one line of a vendor bill
+type VendorBillLineGrid struct {
+	Recid        int64 `json:"recid"`
+	VBLID        int64
+	LID          int64 // GL account debited
+	GLNumber     string
+	GLName       string
+	RID          int64 // rentable, 0 if none
+	RentableName string
+	Amount       float64
+	Description  string
+}
+
+// VendorBillGrid is a vendor bill as listed in the vendor bill grid
+type VendorBillGrid struct {
+	Recid      int64 `json:"recid"`
+	VBID       int64
+	BID        int64
+	BUD        rlib.XJSONBud
+	VID        int64
+	VendorName string
+	InvoiceNo  string
+	Dt         rlib.JSONDate
+	DueDt      rlib.JSONDate
+	Amount     float64
+	Status     int64 // 0 = entered, 1 = approved, 2 = paid, 3 = void
+	StatusName string
+	ApprovedBy int64
+	DtApproved rlib.JSONDateTime
+	VPRID      int64
+	DtPaid     rlib.JSONDate
+	DocNo      string
+	Comment    string
+}
+
+// VendorBillForm is a vendor bill with its lines
+type VendorBillForm struct {
+	VendorBillGrid
+	Lines []VendorBillLineGrid
+}
+
+// VendorBillResponse is the response to the get command
+type VendorBillResponse struct {
+	Status string         `json:"status"`
+	Record VendorBillForm `json:"record"`
+}
+
+// VendorBillListResponse is the response to the list command
+type VendorBillListResponse struct {
+	Status  string           `json:"status"`
+	Total   int64            `json:"total"`
+	Records []VendorBillGrid `json:"records"`
+}
+
+// VendorBillSave is the input data format for the save command
+type VendorBillSave struct {
+	Cmd    string         `json:"cmd"`
+	Record VendorBillForm `json:"record"`
+}
+
+// SvcHandlerVendorBill handles the vendor bills of a business for the bill
+// d.ID
+//
+// The server command can be:
+//      get      - read the bill and its lines
+//      list     - the bills dated in searchDtStart - searchDtStop
+//      save     - add or update an entered bill and its lines
+//      delete   - remove an entered bill
+//      approve  - approve an entered bill and post it to accounts payable
+//      void     - void an entered or approved bill
+//-----------------------------------------------------------------------------
+func SvcHandlerVendorBill(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerVendorBill"
+	var (
+		err error
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("Request: %s:  BID = %d,  VBID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getVendorBill(w, r, d)
+	case "list":
+		listVendorBills(w, r, d)
+	case "save":
+		saveVendorBill(w, r, d)
+	case "delete", "approve", "void":
+		changeVendorBill(w, r, d)
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// vendorBillGrid converts bill vb to its grid form.  vnames caches the
+// vendor names.
+func vendorBillGrid(ctx context.Context, vb *rlib.VendorBill, vnames map[int64]string) VendorBillGrid {
+	var g VendorBillGrid
+	rlib.MigrateStructVals(vb, &g)
+	g.Recid = vb.VBID
+	g.BUD = rlib.GetBUDFromBIDList(vb.BID)
+	g.StatusName = vb.StatusName()
+	name, ok := vnames[vb.VID]
+	if !ok {
+		if v, err := rlib.GetVendor(ctx, vb.VID); err == nil {
+			name = v.Name
+		}
+		vnames[vb.VID] = name
+	}
+	g.VendorName = name
+	return g
+}
+
+// getVendorBill reads a vendor bill
+// wsdoc {
+//  @Title  Get Vendor Bill
+//	@URL /v1/vendorbill/:BUI/:VBID
+//  @Method  POST
+//	@Synopsis Get a vendor bill
+//  @Description  Returns the bill with its lines
+//	@Input WebGridSearchRequest
+//  @Response VendorBillResponse
+// wsdoc }
+func getVendorBill(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getVendorBill"
+	var g VendorBillResponse
+
+	vb, err := rlib.GetVendorBill(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if vb.VBID == 0 || vb.BID != d.BID {
+		err = fmt.Errorf("vendor bill %d not found", d.ID)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Record.VendorBillGrid = vendorBillGrid(r.Context(), &vb, map[int64]string{})
+	m, err := rlib.GetVendorBillLines(r.Context(), vb.VBID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	for i := 0; i < len(m); i++ {
+		var q VendorBillLineGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].VBLID
+		if gl, ok := rlib.RRdb.BizTypes[d.BID].GLAccounts[m[i].LID]; ok {
+			q.GLNumber = gl.GLNumber
+			q.GLName = gl.Name
+		}
+		if m[i].RID > 0 {
+			if rnt, err := rlib.GetRentable(r.Context(), m[i].RID); err == nil {
+				q.RentableName = rnt.RentableName
+			}
+		}
+		g.Record.Lines = append(g.Record.Lines, q)
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// listVendorBills lists the vendor bills of a business
+// wsdoc {
+//  @Title  List Vendor Bills
+//	@URL /v1/vendorbill/:BUI
+//  @Method  POST
+//	@Synopsis List the vendor bills of a business
+//  @Description  Returns the bills dated in searchDtStart - searchDtStop,
+//  @Description  oldest first.  The lines are not included.
+//	@Input WebGridSearchRequest
+//  @Response VendorBillListResponse
+// wsdoc }
+func listVendorBills(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "listVendorBills"
+	var g VendorBillListResponse
+
+	m, err := rlib.GetVendorBillsByRange(r.Context(), d.BID, &d.wsSearchReq.SearchDtStart, &d.wsSearchReq.SearchDtStop)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	vnames := map[int64]string{}
+	for i := 0; i < len(m); i++ {
+		g.Records = append(g.Records, vendorBillGrid(r.Context(), &m[i], vnames))
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveVendorBill adds or updates a vendor bill
+// wsdoc {
+//  @Title  Save Vendor Bill
+//	@URL /v1/vendorbill/:BUI/:VBID
+//  @Method  POST
+//	@Synopsis Add or update a vendor bill
+//  @Description  VBID 0 adds a new bill.  Only entered bills can be saved,
+//  @Description  the lines replace the current ones and the Amount is their
+//  @Description  total.  If DueDt is not set it is computed from the
+//  @Description  vendor's terms.
+//	@Input VendorBillSave
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveVendorBill(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveVendorBill"
+	var foo VendorBillSave
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	vb := rlib.VendorBill{
+		VBID:      foo.Record.VBID,
+		BID:       d.BID,
+		VID:       foo.Record.VID,
+		InvoiceNo: foo.Record.InvoiceNo,
+		Dt:        time.Time(foo.Record.Dt),
+		DueDt:     time.Time(foo.Record.DueDt),
+		Comment:   foo.Record.Comment,
+	}
+	var m []rlib.VendorBillLine
+	for i := 0; i < len(foo.Record.Lines); i++ {
+		m = append(m, rlib.VendorBillLine{
+			LID:         foo.Record.Lines[i].LID,
+			RID:         foo.Record.Lines[i].RID,
+			Amount:      foo.Record.Lines[i].Amount,
+			Description: foo.Record.Lines[i].Description,
+		})
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if errlist := bizlogic.SaveVendorBill(ctx, &vb, m); len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, vb.VBID)
+}
+
+// changeVendorBill deletes, approves or voids a vendor bill
+// wsdoc {
+//  @Title  Delete, Approve or Void Vendor Bill
+//	@URL /v1/vendorbill/:BUI/:VBID
+//  @Method  POST
+//	@Synopsis Move a vendor bill along
+//  @Description  cmd "delete" removes an entered bill.  cmd "approve"
+//  @Description  approves an entered bill and posts it to accounts payable
+//  @Description  on the bill date.  cmd "void" voids an entered or approved
+//  @Description  bill, the accounts payable entry of an approved bill is
+//  @Description  reversed today.
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func changeVendorBill(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "changeVendorBill"
+	var errlist []bizlogic.BizError
+
+	rlib.Console("Entered %s\n", funcname)
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	switch d.wsSearchReq.Cmd {
+	case "delete":
+		errlist = bizlogic.DeleteVendorBill(ctx, d.BID, d.ID)
+	case "approve":
+		errlist = bizlogic.ApproveVendorBill(ctx, d.BID, d.ID)
+	case "void":
+		now := time.Now()
+		dt := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, rlib.RRdb.Zone)
+		errlist = bizlogic.VoidVendorBill(ctx, d.BID, d.ID, &dt)
+	}
+	if len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, d.ID)
+}
@@ -0,0 +1,220 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// VendorPaymentRunGrid is a vendor payment run
+type VendorPaymentRunGrid struct {
+	Recid        int64 `json:"recid"`
+	VPRID        int64
+	BID          int64
+	BUD          rlib.XJSONBud
+	DEPID        int64
+	Depository   string
+	Dt           rlib.JSONDate
+	FirstCheckNo int64
+	Amount       float64
+	BillCount    int64
+	Comment      string
+}
+
+// VendorPaymentRunForm is a payment run with the bills it paid
+type VendorPaymentRunForm struct {
+	VendorPaymentRunGrid
+	Bills []VendorBillGrid
+}
+
+// VendorPaymentRunResponse is the response to the get command
+type VendorPaymentRunResponse struct {
+	Status string               `json:"status"`
+	Record VendorPaymentRunForm `json:"record"`
+}
+
+// VendorPaymentRunListResponse is the response to the list command
+type VendorPaymentRunListResponse struct {
+	Status  string                 `json:"status"`
+	Total   int64                  `json:"total"`
+	Records []VendorPaymentRunGrid `json:"records"`
+}
+
+// VendorPaymentRunSaveForm describes a payment run to make
+type VendorPaymentRunSaveForm struct {
+	DEPID        int64
+	Dt           rlib.JSONDate
+	FirstCheckNo int64 // 0 if the bills are not paid by check
+	Comment      string
+	VBIDs        []int64 // the approved bills to pay
+}
+
+// VendorPaymentRunSave is the input data format for the save command
+type VendorPaymentRunSave struct {
+	Cmd    string                   `json:"cmd"`
+	Record VendorPaymentRunSaveForm `json:"record"`
+}
+
+// SvcHandlerVendorPaymentRun handles the vendor payment runs of a business
+// for the payment run d.ID
+//
+// The server command can be:
+//      get   - read the payment run and the bills it paid
+//      list  - the payment runs dated in searchDtStart - searchDtStop
+//      save  - pay a list of approved bills
+//-----------------------------------------------------------------------------
+func SvcHandlerVendorPaymentRun(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerVendorPaymentRun"
+	var (
+		err error
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("Request: %s:  BID = %d,  VPRID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getVendorPaymentRun(w, r, d)
+	case "list":
+		listVendorPaymentRuns(w, r, d)
+	case "save":
+		saveVendorPaymentRun(w, r, d)
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// vendorPaymentRunGrid converts payment run pr to its grid form
+func vendorPaymentRunGrid(r *http.Request, pr *rlib.VendorPaymentRun) VendorPaymentRunGrid {
+	var g VendorPaymentRunGrid
+	rlib.MigrateStructVals(pr, &g)
+	g.Recid = pr.VPRID
+	g.BUD = rlib.GetBUDFromBIDList(pr.BID)
+	if dep, err := rlib.GetDepository(r.Context(), pr.DEPID); err == nil {
+		g.Depository = dep.Name
+	}
+	return g
+}
+
+// getVendorPaymentRun reads a vendor payment run
+// wsdoc {
+//  @Title  Get Vendor Payment Run
+//	@URL /v1/vendorpmt/:BUI/:VPRID
+//  @Method  POST
+//	@Synopsis Get a vendor payment run
+//  @Description  Returns the payment run with the bills it paid
+//	@Input WebGridSearchRequest
+//  @Response VendorPaymentRunResponse
+// wsdoc }
+func getVendorPaymentRun(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getVendorPaymentRun"
+	var g VendorPaymentRunResponse
+
+	pr, err := rlib.GetVendorPaymentRun(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if pr.VPRID == 0 || pr.BID != d.BID {
+		err = fmt.Errorf("vendor payment run %d not found", d.ID)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Record.VendorPaymentRunGrid = vendorPaymentRunGrid(r, &pr)
+	m, err := rlib.GetVendorBillsByVPRID(r.Context(), pr.VPRID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	vnames := map[int64]string{}
+	for i := 0; i < len(m); i++ {
+		g.Record.Bills = append(g.Record.Bills, vendorBillGrid(r.Context(), &m[i], vnames))
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// listVendorPaymentRuns lists the vendor payment runs of a business
+// wsdoc {
+//  @Title  List Vendor Payment Runs
+//	@URL /v1/vendorpmt/:BUI
+//  @Method  POST
+//	@Synopsis List the vendor payment runs of a business
+//  @Description  Returns the payment runs dated in searchDtStart -
+//  @Description  searchDtStop, oldest first
+//	@Input WebGridSearchRequest
+//  @Response VendorPaymentRunListResponse
+// wsdoc }
+func listVendorPaymentRuns(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "listVendorPaymentRuns"
+	var g VendorPaymentRunListResponse
+
+	m, err := rlib.GetVendorPaymentRuns(r.Context(), d.BID, &d.wsSearchReq.SearchDtStart, &d.wsSearchReq.SearchDtStop)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	for i := 0; i < len(m); i++ {
+		g.Records = append(g.Records, vendorPaymentRunGrid(r, &m[i]))
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveVendorPaymentRun pays a list of approved vendor bills
+// wsdoc {
+//  @Title  Vendor Payment Run
+//	@URL /v1/vendorpmt/:BUI
+//  @Method  POST
+//	@Synopsis Pay approved vendor bills
+//  @Description  Pays the bills VBIDs from the depository DEPID on Dt.  Each
+//  @Description  bill is marked paid and a journal entry debiting accounts
+//  @Description  payable and crediting the depository's GL account is
+//  @Description  posted for it.  If FirstCheckNo is set the bills get
+//  @Description  consecutive check numbers in the order supplied.
+//	@Input VendorPaymentRunSave
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveVendorPaymentRun(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveVendorPaymentRun"
+	var foo VendorPaymentRunSave
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	pr := rlib.VendorPaymentRun{
+		BID:          d.BID,
+		DEPID:        foo.Record.DEPID,
+		Dt:           time.Time(foo.Record.Dt),
+		FirstCheckNo: foo.Record.FirstCheckNo,
+		Comment:      foo.Record.Comment,
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if errlist := bizlogic.PayVendorBills(ctx, &pr, foo.Record.VBIDs); len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, pr.VPRID)
+}
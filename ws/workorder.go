@@ -0,0 +1,414 @@
+package ws
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WorkOrderGrid is a work order as listed in the work order grid
+type WorkOrderGrid struct {
+	Recid            int64 `json:"recid"`
+	WOID             int64
+	BID              int64
+	BUD              rlib.XJSONBud
+	RID              int64
+	RentableName     string
+	RAID             int64
+	MRID             int64
+	Category         string
+	Priority         int64
+	PriorityName     string
+	Status           int64
+	StatusName       string
+	Vendor           string
+	Description      string
+	Dt               rlib.JSONDateTime
+	ScheduledStart   rlib.JSONDateTime
+	ScheduledStop    rlib.JSONDateTime
+	DtCompleted      rlib.JSONDate
+	LaborCost        float64
+	MaterialCost     float64
+	ChargebackAmount float64
+	FLAGS            uint64
+	DaysOpen         int64 // days since it was reported, until it was completed
+}
+
+// WorkOrderSearchResponse is the response to the work order search
+type WorkOrderSearchResponse struct {
+	Status  string          `json:"status"`
+	Total   int64           `json:"total"`
+	Records []WorkOrderGrid `json:"records"`
+}
+
+// WorkOrderForm is a work order as edited in the work order form
+type WorkOrderForm struct {
+	Recid            int64 `json:"recid"`
+	WOID             int64
+	BID              int64
+	BUD              rlib.XJSONBud
+	RID              int64
+	RentableName     string
+	RAID             int64
+	MRID             int64
+	Category         string
+	Priority         int64 // 0 = low, 1 = normal, 2 = high, 3 = emergency
+	Status           int64 // 0 = open, 1 = assigned, 2 = scheduled, 3 = in progress, 4 = completed, 5 = closed, 6 = canceled
+	Vendor           string
+	Description      string
+	Dt               rlib.JSONDateTime
+	ScheduledStart   rlib.JSONDateTime
+	ScheduledStop    rlib.JSONDateTime
+	DtCompleted      rlib.JSONDate
+	LaborCost        float64
+	LaborARID        int64
+	LaborEXPID       int64 // read only
+	MaterialCost     float64
+	MaterialARID     int64
+	MaterialEXPID    int64 // read only
+	ChargebackAmount float64
+	ChargebackARID   int64
+	ChargebackASMID  int64 // read only
+	FLAGS            uint64
+	Comment          string
+	LastModTime      rlib.JSONDateTime
+	LastModBy        int64
+	CreateTS         rlib.JSONDateTime
+	CreateBy         int64
+}
+
+// WorkOrderGetResponse is the response to the get command
+type WorkOrderGetResponse struct {
+	Status string        `json:"status"`
+	Record WorkOrderForm `json:"record"`
+}
+
+// WorkOrderSave is the input data format for the save command
+type WorkOrderSave struct {
+	Cmd    string        `json:"cmd"`
+	Record WorkOrderForm `json:"record"`
+}
+
+var workOrderSearchFieldMap = rlib.SelectQueryFieldMap{
+	"WOID":             {"WorkOrder.WOID"},
+	"RID":              {"WorkOrder.RID"},
+	"RentableName":     {"Rentable.RentableName"},
+	"RAID":             {"WorkOrder.RAID"},
+	"MRID":             {"WorkOrder.MRID"},
+	"Category":         {"WorkOrder.Category"},
+	"Priority":         {"WorkOrder.Priority"},
+	"Status":           {"WorkOrder.Status"},
+	"Vendor":           {"WorkOrder.Vendor"},
+	"Description":      {"WorkOrder.Description"},
+	"Dt":               {"WorkOrder.Dt"},
+	"ScheduledStart":   {"WorkOrder.ScheduledStart"},
+	"ScheduledStop":    {"WorkOrder.ScheduledStop"},
+	"DtCompleted":      {"WorkOrder.DtCompleted"},
+	"LaborCost":        {"WorkOrder.LaborCost"},
+	"MaterialCost":     {"WorkOrder.MaterialCost"},
+	"ChargebackAmount": {"WorkOrder.ChargebackAmount"},
+	"FLAGS":            {"WorkOrder.FLAGS"},
+}
+
+// which fields needs to be fetch to satisfy the struct
+var workOrderSearchSelectQueryFields = rlib.SelectQueryFields{
+	"WorkOrder.WOID",
+	"WorkOrder.RID",
+	"Rentable.RentableName",
+	"WorkOrder.RAID",
+	"WorkOrder.MRID",
+	"WorkOrder.Category",
+	"WorkOrder.Priority",
+	"WorkOrder.Status",
+	"WorkOrder.Vendor",
+	"WorkOrder.Description",
+	"WorkOrder.Dt",
+	"WorkOrder.ScheduledStart",
+	"WorkOrder.ScheduledStop",
+	"WorkOrder.DtCompleted",
+	"WorkOrder.LaborCost",
+	"WorkOrder.MaterialCost",
+	"WorkOrder.ChargebackAmount",
+	"WorkOrder.FLAGS",
+}
+
+// workOrderGridRowScan scans a result from sql row and dump it in a WorkOrderGrid struct
+func workOrderGridRowScan(rows *sql.Rows, a *WorkOrderGrid) error {
+	return rows.Scan(&a.WOID, &a.RID, &a.RentableName, &a.RAID, &a.MRID, &a.Category, &a.Priority, &a.Status, &a.Vendor, &a.Description, &a.Dt, &a.ScheduledStart, &a.ScheduledStop, &a.DtCompleted, &a.LaborCost, &a.MaterialCost, &a.ChargebackAmount, &a.FLAGS)
+}
+
+// SvcHandlerWorkOrder handles the requests for the work order d.ID
+//
+// The server command can be:
+//      get     - read the work order
+//      save    - add or update a work order, posts its costs when completed
+//      delete  - remove a work order that has not been completed
+//-----------------------------------------------------------------------------
+func SvcHandlerWorkOrder(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerWorkOrder"
+	var (
+		err error
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("Request: %s:  BID = %d,  WOID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		if d.ID <= 0 && d.wsSearchReq.Limit > 0 {
+			SvcSearchHandlerWorkOrders(w, r, d) // it is a query for the grid.
+			return
+		}
+		getWorkOrder(w, r, d)
+	case "save":
+		saveWorkOrder(w, r, d)
+	case "delete":
+		deleteWorkOrder(w, r, d)
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// SvcSearchHandlerWorkOrders returns the work orders of business d.BID
+// wsdoc {
+//  @Title  Search Work Orders
+//	@URL /v1/workorders/:BUI
+//  @Method  POST
+//	@Synopsis Search work orders
+//  @Descr  Search the work orders reported in searchDtStart - searchDtStop
+//  @Descr  and return those that match the Search Logic.  By default the
+//  @Descr  most urgent open work orders are listed first.
+//	@Input WebGridSearchRequest
+//  @Response WorkOrderSearchResponse
+// wsdoc }
+func SvcSearchHandlerWorkOrders(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcSearchHandlerWorkOrders"
+	var (
+		g     WorkOrderSearchResponse
+		err   error
+		order = "WorkOrder.Status ASC, WorkOrder.Priority DESC, WorkOrder.Dt ASC" // default ORDER
+		whr   = fmt.Sprintf("WorkOrder.BID=%d AND %q <= WorkOrder.Dt AND WorkOrder.Dt < %q", d.BID,
+			d.wsSearchReq.SearchDtStart.Format(rlib.RRDATEFMTSQL),
+			d.wsSearchReq.SearchDtStop.Format(rlib.RRDATEFMTSQL))
+	)
+
+	rlib.Console("Entered %s\n", funcname)
+
+	// get where clause and order clause for sql query
+	whereClause, orderClause := GetSearchAndSortSQL(d, workOrderSearchFieldMap)
+	if len(whereClause) > 0 {
+		whr += " AND (" + whereClause + ")"
+	}
+	if len(orderClause) > 0 {
+		order = orderClause
+	}
+
+	theQuery := `
+	SELECT {{.SelectClause}}
+	FROM WorkOrder
+	INNER JOIN Rentable ON WorkOrder.RID = Rentable.RID
+	WHERE {{.WhereClause}}
+	ORDER BY {{.OrderClause}}`
+
+	qc := rlib.QueryClause{
+		"SelectClause": strings.Join(workOrderSearchSelectQueryFields, ","),
+		"WhereClause":  whr,
+		"OrderClause":  order,
+	}
+
+	// get TOTAL COUNT First
+	countQuery := rlib.RenderSQLQuery(theQuery, qc)
+	g.Total, err = rlib.GetQueryCount(countQuery)
+	if err != nil {
+		rlib.Console("%s: Error from rlib.GetQueryCount: %s\n", funcname, err.Error())
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	// FETCH the records WITH LIMIT AND OFFSET
+	limitAndOffsetClause := `
+	LIMIT {{.LimitClause}}
+	OFFSET {{.OffsetClause}};`
+	theQueryWithLimit := theQuery + limitAndOffsetClause
+	qc["LimitClause"] = strconv.Itoa(d.wsSearchReq.Limit)
+	qc["OffsetClause"] = strconv.Itoa(d.wsSearchReq.Offset)
+	qry := rlib.RenderSQLQuery(theQueryWithLimit, qc)
+	rlib.Console("db query = %s\n", qry)
+
+	rows, err := rlib.RRdb.Dbrr.Query(qry)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	defer rows.Close()
+
+	now := time.Now()
+	i := int64(d.wsSearchReq.Offset)
+	count := 0
+	for rows.Next() {
+		var q WorkOrderGrid
+		q.Recid = i
+		q.BID = d.BID
+		q.BUD = rlib.GetBUDFromBIDList(q.BID)
+
+		if err = workOrderGridRowScan(rows, &q); err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		wo := rlib.WorkOrder{Priority: q.Priority, Status: q.Status}
+		q.PriorityName = wo.PriorityName()
+		q.StatusName = wo.StatusName()
+		dt := now
+		if !wo.IsOpen() && time.Time(q.DtCompleted).Year() > 1970 {
+			dt = time.Time(q.DtCompleted)
+		}
+		q.DaysOpen = int64(dt.Sub(time.Time(q.Dt)).Hours() / 24)
+
+		g.Records = append(g.Records, q)
+		count++ // update the count only after adding the record
+		if count >= d.wsSearchReq.Limit {
+			break // if we've added the max number requested, then exit
+		}
+		i++
+	}
+
+	if err = rows.Err(); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// getWorkOrder reads a work order
+// wsdoc {
+//  @Title  Get Work Order
+//	@URL /v1/workorder/:BUI/:WOID
+//  @Method  POST
+//	@Synopsis Get a work order
+//  @Description  Returns the work order with the ids of the expenses and of
+//  @Description  the chargeback assessment posted for it
+//	@Input WebGridSearchRequest
+//  @Response WorkOrderGetResponse
+// wsdoc }
+func getWorkOrder(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getWorkOrder"
+	var g WorkOrderGetResponse
+
+	wo, err := rlib.GetWorkOrder(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if wo.WOID == 0 || wo.BID != d.BID {
+		err = fmt.Errorf("work order %d not found", d.ID)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	rlib.MigrateStructVals(&wo, &g.Record)
+	g.Record.Recid = wo.WOID
+	g.Record.BUD = rlib.GetBUDFromBIDList(wo.BID)
+	rnt, err := rlib.GetRentable(r.Context(), wo.RID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Record.RentableName = rnt.RentableName
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveWorkOrder adds or updates a work order
+// wsdoc {
+//  @Title  Save Work Order
+//	@URL /v1/workorder/:BUI/:WOID
+//  @Method  POST
+//	@Synopsis Add or update a work order
+//  @Description  WOID 0 adds a new work order. Set MRID to open it for a
+//  @Description  tenant's maintenance request.  When the Status is set to
+//  @Description  completed the labor and material costs are posted as
+//  @Description  expenses and the chargeback is assessed to the RAID.
+//	@Input WorkOrderSave
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveWorkOrder(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveWorkOrder"
+	var foo WorkOrderSave
+
+	rlib.Console("Entered %s\n", funcname)
+	rlib.Console("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("Error with json.Unmarshal:  %s", err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	var wo rlib.WorkOrder
+	rlib.MigrateStructVals(&foo.Record, &wo)
+	wo.BID = d.BID
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if errlist := bizlogic.SaveWorkOrder(ctx, &wo); len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, wo.WOID)
+}
+
+// deleteWorkOrder removes a work order
+// wsdoc {
+//  @Title  Delete Work Order
+//	@URL /v1/workorder/:BUI/:WOID
+//  @Method  POST
+//	@Synopsis Delete a work order
+//  @Description  Only a work order whose costs have not been posted can be
+//  @Description  deleted, cancel it otherwise.
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteWorkOrder(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "deleteWorkOrder"
+
+	rlib.Console("Entered %s\n", funcname)
+
+	wo, err := rlib.GetWorkOrder(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if wo.WOID == 0 || wo.BID != d.BID {
+		err = fmt.Errorf("work order %d not found", d.ID)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if wo.LaborEXPID > 0 || wo.MaterialEXPID > 0 || wo.ChargebackASMID > 0 {
+		err = fmt.Errorf("the costs of work order %s have been posted, it cannot be deleted", wo.IDtoShortString())
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = rlib.DeleteWorkOrder(r.Context(), wo.WOID); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}